@@ -65,6 +65,22 @@ type ConnectionArgs struct {
 	// Caching support for image servers
 	CachePath   string
 	CacheExpiry time.Duration
+
+	// Keep connections to the server open and reuse them across requests.
+	// Over HTTPS, concurrent requests are multiplexed over a single HTTP/2 connection when supported by the server.
+	ReuseConnections bool
+
+	// Maximum number of connections to the server when reusing connections (0 for unlimited)
+	MaxConnections int
+
+	// Maximum number of idle connections kept open when reusing connections (0 for the default of 2)
+	MaxIdleConnections int
+
+	// How long idle connections are kept open when reusing connections (0 for the default of 90s)
+	IdleConnectionTimeout time.Duration
+
+	// Retry policy for requests failing because of a transient error (nil to disable retries)
+	RetryPolicy *RetryPolicy
 }
 
 // ConnectIncus lets you connect to a remote Incus daemon over HTTPs.
@@ -125,6 +141,7 @@ func ConnectIncusHTTPWithContext(ctx context.Context, args *ConnectionArgs, clie
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.RetryPolicy,
 	}
 
 	// Setup the HTTP client
@@ -212,6 +229,7 @@ func ConnectIncusUnixWithContext(ctx context.Context, path string, args *Connect
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.RetryPolicy,
 		project:            projectName,
 	}
 
@@ -278,7 +296,7 @@ func ConnectSimpleStreams(url string, args *ConnectionArgs) (ImageServer, error)
 	}
 
 	// Setup the HTTP client
-	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
+	httpClient, err := tlsHTTPClient(args)
 	if err != nil {
 		return nil, err
 	}
@@ -341,6 +359,7 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 		ctxConnectedCancel: ctxConnectedCancel,
 		eventConns:         make(map[string]*websocket.Conn),
 		eventListeners:     make(map[string][]*EventListener),
+		retryPolicy:        args.RetryPolicy,
 	}
 
 	if slices.Contains([]string{api.AuthenticationMethodOIDC}, args.AuthType) {
@@ -348,7 +367,7 @@ func httpsIncus(ctx context.Context, requestURL string, args *ConnectionArgs) (I
 	}
 
 	// Setup the HTTP client
-	httpClient, err := tlsHTTPClient(args.HTTPClient, args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify, args.Proxy, args.TransportWrapper)
+	httpClient, err := tlsHTTPClient(args)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +50,8 @@ type ProtocolIncus struct {
 	project       string
 
 	oidcClient *oidcClient
+
+	retryPolicy *RetryPolicy
 }
 
 // Disconnect gets rid of any background goroutines.
@@ -57,6 +59,9 @@ func (r *ProtocolIncus) Disconnect() {
 	if r.ctxConnected.Err() != nil {
 		r.ctxConnectedCancel()
 	}
+
+	// Close any connection kept open for reuse.
+	r.http.CloseIdleConnections()
 }
 
 // GetConnectionInfo returns the basic connection information used to interact with the server.
@@ -144,9 +149,19 @@ func (r *ProtocolIncus) GetHTTPClient() (*http.Client, error) {
 }
 
 // DoHTTP performs a Request, using OIDC authentication if set.
+// If a retry policy is set, requests failing because of a transient error are retried.
 func (r *ProtocolIncus) DoHTTP(req *http.Request) (*http.Response, error) {
 	r.addClientHeaders(req)
 
+	if r.retryPolicy != nil {
+		return r.retryPolicy.do(req, r.doHTTP)
+	}
+
+	return r.doHTTP(req)
+}
+
+// doHTTP sends a single request, using OIDC authentication if set.
+func (r *ProtocolIncus) doHTTP(req *http.Request) (*http.Response, error) {
 	if r.oidcClient != nil {
 		return r.oidcClient.do(req)
 	}
@@ -436,10 +451,10 @@ func (r *ProtocolIncus) rawWebsocket(url string) (*websocket.Conn, error) {
 		return nil, err
 	}
 
-	// Setup a new websocket dialer based on it
+	// Setup a new websocket dialer based on it, websockets can't be established over HTTP/2.
 	dialer := websocket.Dialer{
 		NetDialContext:   httpTransport.DialContext,
-		TLSClientConfig:  httpTransport.TLSClientConfig,
+		TLSClientConfig:  http1TLSConfig(httpTransport.TLSClientConfig),
 		Proxy:            httpTransport.Proxy,
 		HandshakeTimeout: time.Second * 5,
 	}
@@ -189,6 +189,41 @@ func (r *ProtocolIncus) GetClusterMemberState(name string) (*api.ClusterMemberSt
 	return &state, etag, err
 }
 
+// GetClusterMemberConfig gets the member-specific server configuration of a cluster member.
+func (r *ProtocolIncus) GetClusterMemberConfig(name string) (map[string]string, error) {
+	err := r.CheckExtension("cluster_member_config_diff")
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]string{}
+	u := api.NewURL().Path("cluster", "members", name, "config")
+	_, err = r.queryStruct("GET", u.String(), nil, "", &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// GetClusterMemberConfigDiff gets the member-specific server configuration keys of a cluster member whose value
+// differs from their default or from their value on other cluster members.
+func (r *ProtocolIncus) GetClusterMemberConfigDiff(name string) ([]api.ClusterMemberConfigDiff, error) {
+	err := r.CheckExtension("cluster_member_config_diff")
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := []api.ClusterMemberConfigDiff{}
+	u := api.NewURL().Path("cluster", "members", name, "config").WithQuery("diff", "1")
+	_, err = r.queryStruct("GET", u.String(), nil, "", &diffs)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffs, nil
+}
+
 // UpdateClusterMemberState evacuates or restores a cluster member.
 func (r *ProtocolIncus) UpdateClusterMemberState(name string, state api.ClusterMemberStatePost) (Operation, error) {
 	if !r.HasExtension("clustering_evacuation") {
@@ -0,0 +1,150 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetInstanceGroupNames returns a list of instance group names.
+func (r *ProtocolIncus) GetInstanceGroupNames() ([]string, error) {
+	if !r.HasExtension("instance_groups") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/instance-groups"
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetInstanceGroups returns a list of instance group structs.
+func (r *ProtocolIncus) GetInstanceGroups() ([]api.InstanceGroup, error) {
+	if !r.HasExtension("instance_groups") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	groups := []api.InstanceGroup{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/instance-groups?recursion=1", nil, "", &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetInstanceGroup returns an instance group entry for the provided name.
+func (r *ProtocolIncus) GetInstanceGroup(name string) (*api.InstanceGroup, string, error) {
+	if !r.HasExtension("instance_groups") {
+		return nil, "", fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	group := api.InstanceGroup{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/instance-groups/%s", url.PathEscape(name)), nil, "", &group)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &group, etag, nil
+}
+
+// CreateInstanceGroup defines a new instance group using the provided struct.
+func (r *ProtocolIncus) CreateInstanceGroup(group api.InstanceGroupsPost) error {
+	if !r.HasExtension("instance_groups") {
+		return fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", "/instance-groups", group, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInstanceGroup updates the instance group to match the provided struct.
+func (r *ProtocolIncus) UpdateInstanceGroup(name string, group api.InstanceGroupPut, ETag string) error {
+	if !r.HasExtension("instance_groups") {
+		return fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/instance-groups/%s", url.PathEscape(name)), group, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenameInstanceGroup renames an existing instance group entry.
+func (r *ProtocolIncus) RenameInstanceGroup(name string, group api.InstanceGroupPost) error {
+	if !r.HasExtension("instance_groups") {
+		return fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", fmt.Sprintf("/instance-groups/%s", url.PathEscape(name)), group, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInstanceGroup deletes an existing instance group.
+func (r *ProtocolIncus) DeleteInstanceGroup(name string) error {
+	if !r.HasExtension("instance_groups") {
+		return fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/instance-groups/%s", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInstanceGroupState changes the state of all the instances of an instance group.
+func (r *ProtocolIncus) UpdateInstanceGroupState(name string, state api.InstanceStatePut) (Operation, error) {
+	if !r.HasExtension("instance_groups") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	op, _, err := r.queryOperation("PUT", fmt.Sprintf("/instance-groups/%s/state", url.PathEscape(name)), state, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// CreateInstanceGroupSnapshot creates a snapshot of all the instances of an instance group.
+func (r *ProtocolIncus) CreateInstanceGroupSnapshot(name string, snapshot api.InstanceSnapshotsPost) (Operation, error) {
+	if !r.HasExtension("instance_groups") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_groups" API extension`)
+	}
+
+	// Send the request.
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/instance-groups/%s/snapshots", url.PathEscape(name)), snapshot, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
@@ -0,0 +1,120 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetInstanceTemplateNames returns a list of instance template names.
+func (r *ProtocolIncus) GetInstanceTemplateNames() ([]string, error) {
+	if !r.HasExtension("instance_templates") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := "/instance-templates"
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetInstanceTemplates returns a list of instance template structs.
+func (r *ProtocolIncus) GetInstanceTemplates() ([]api.InstanceTemplate, error) {
+	if !r.HasExtension("instance_templates") {
+		return nil, fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	templates := []api.InstanceTemplate{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", "/instance-templates?recursion=1", nil, "", &templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetInstanceTemplate returns an instance template entry for the provided name.
+func (r *ProtocolIncus) GetInstanceTemplate(name string) (*api.InstanceTemplate, string, error) {
+	if !r.HasExtension("instance_templates") {
+		return nil, "", fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	template := api.InstanceTemplate{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/instance-templates/%s", url.PathEscape(name)), nil, "", &template)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &template, etag, nil
+}
+
+// CreateInstanceTemplate defines a new instance template using the provided struct.
+func (r *ProtocolIncus) CreateInstanceTemplate(template api.InstanceTemplatesPost) error {
+	if !r.HasExtension("instance_templates") {
+		return fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", "/instance-templates", template, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInstanceTemplate updates the instance template to match the provided struct.
+func (r *ProtocolIncus) UpdateInstanceTemplate(name string, template api.InstanceTemplatePut, ETag string) error {
+	if !r.HasExtension("instance_templates") {
+		return fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/instance-templates/%s", url.PathEscape(name)), template, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenameInstanceTemplate renames an existing instance template entry.
+func (r *ProtocolIncus) RenameInstanceTemplate(name string, template api.InstanceTemplatePost) error {
+	if !r.HasExtension("instance_templates") {
+		return fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", fmt.Sprintf("/instance-templates/%s", url.PathEscape(name)), template, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInstanceTemplate deletes an existing instance template.
+func (r *ProtocolIncus) DeleteInstanceTemplate(name string) error {
+	if !r.HasExtension("instance_templates") {
+		return fmt.Errorf(`The server is missing the required "instance_templates" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/instance-templates/%s", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
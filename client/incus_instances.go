@@ -2,7 +2,9 @@ package incus
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -201,6 +203,27 @@ func (r *ProtocolIncus) UpdateInstances(state api.InstancesPut, ETag string) (Op
 	return op, nil
 }
 
+// UpdateInstancesState updates the listed instances to match the requested state.
+// The operation metadata reports the outcome for each instance under the "instances" key.
+func (r *ProtocolIncus) UpdateInstancesState(state api.InstancesStatePut) (Operation, error) {
+	if !r.HasExtension("instances_state_bulk") {
+		return nil, fmt.Errorf("The server is missing the required \"instances_state_bulk\" API extension")
+	}
+
+	path, v, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("PUT", fmt.Sprintf("%s/state?%s", path, v.Encode()), state, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
 // rebuildInstance initiates a rebuild of a given instance on the Incus Protocol server and returns the corresponding operation or an error.
 func (r *ProtocolIncus) rebuildInstance(instanceName string, instance api.InstanceRebuildPost) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -590,6 +613,83 @@ func (r *ProtocolIncus) CreateInstanceFromBackup(args InstanceBackupArgs) (Opera
 	return &op, nil
 }
 
+// CreateInstanceFromDisk requests that Incus creates a new virtual machine from a qcow2 or raw disk image.
+// If disk is nil, the server downloads the disk image from the source URL instead.
+func (r *ProtocolIncus) CreateInstanceFromDisk(instance api.InstancesPost, disk io.Reader) (Operation, error) {
+	if !r.HasExtension("instance_create_from_disk") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_create_from_disk\" API extension")
+	}
+
+	instance.Source.Type = "disk"
+	if instance.Type == "" {
+		instance.Type = api.InstanceTypeVM
+	}
+
+	path, _, err := r.instanceTypeToPath(instance.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if disk == nil {
+		// Send the request
+		op, _, err := r.queryOperation("POST", path, instance, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return op, nil
+	}
+
+	instanceJSON, err := json.Marshal(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	reqURL, err := r.setQueryAttributes(fmt.Sprintf("%s/1.0%s", r.httpBaseURL.String(), path))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", reqURL, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Incus-type", "disk")
+	req.Header.Set("X-Incus-instance", string(instanceJSON))
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	// Handle errors
+	response, _, err := incusParseResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get to the operation
+	respOperation, err := response.MetadataAsOperation()
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup an Operation wrapper
+	op := operation{
+		Operation: *respOperation,
+		r:         r,
+		chActive:  make(chan bool),
+	}
+
+	return &op, nil
+}
+
 // CreateInstance requests that Incus creates a new instance.
 func (r *ProtocolIncus) CreateInstance(instance api.InstancesPost) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(instance.Type)
@@ -612,6 +712,60 @@ func (r *ProtocolIncus) CreateInstance(instance api.InstancesPost) (Operation, e
 	return op, nil
 }
 
+// CreateMigrationToken requests a migration token allowing another server to push an instance into the project.
+func (r *ProtocolIncus) CreateMigrationToken(token api.MigrationTokensPost) (Operation, error) {
+	if !r.HasExtension("migration_token") {
+		return nil, fmt.Errorf("The server is missing the required \"migration_token\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", "/migration-tokens", token, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// CreateInstanceFromMigrationToken creates a new instance to be pushed by the source server using a migration token.
+func (r *ProtocolIncus) CreateInstanceFromMigrationToken(instance api.MigrationTokenInstancesPost) (Operation, error) {
+	if !r.HasExtension("migration_token") {
+		return nil, fmt.Errorf("The server is missing the required \"migration_token\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", "/migration-tokens/instances", instance, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// CreateInstanceDryRun validates a new instance request without creating anything.
+func (r *ProtocolIncus) CreateInstanceDryRun(instance api.InstancesPost) (*api.InstancesPostDryRun, error) {
+	if !r.HasExtension("instance_create_dry_run") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_create_dry_run\" API extension")
+	}
+
+	path, v, err := r.instanceTypeToPath(instance.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Set("dry-run", "1")
+
+	result := api.InstancesPostDryRun{}
+
+	// Send the request
+	_, err = r.queryStruct("POST", fmt.Sprintf("%s?%s", path, v.Encode()), instance, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // tryCreateInstance attempts to create a new instance on multiple target servers specified by their URLs.
 // It runs the instance creation asynchronously and returns a RemoteOperation to monitor the progress and any errors.
 func (r *ProtocolIncus) tryCreateInstance(req api.InstancesPost, urls []string, op Operation) (RemoteOperation, error) {
@@ -1508,6 +1662,117 @@ func (r *ProtocolIncus) CreateInstanceFile(instanceName string, filePath string,
 	return nil
 }
 
+// GetInstanceFileArchive retrieves the directory tree at the provided path in the instance as a tarball.
+//
+// Note that it's the caller's responsibility to close the returned ReadCloser.
+func (r *ProtocolIncus) GetInstanceFileArchive(instanceName string, filePath string) (io.ReadCloser, error) {
+	if !r.HasExtension("instance_file_archive") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_file_archive\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	requestURL := fmt.Sprintf("%s/1.0%s/%s/files?path=%s&archive=tar", r.httpBaseURL.String(), path, url.PathEscape(instanceName), url.QueryEscape(filePath))
+
+	requestURL, err = r.setQueryAttributes(requestURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// CreateInstanceFileArchive unpacks the provided tarball into the directory at the provided path in the instance.
+func (r *ProtocolIncus) CreateInstanceFileArchive(instanceName string, filePath string, content io.Reader) error {
+	if !r.HasExtension("instance_file_archive") {
+		return fmt.Errorf("The server is missing the required \"instance_file_archive\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return err
+	}
+
+	// Prepare the HTTP request
+	requestURL := fmt.Sprintf("%s/1.0%s/%s/files?path=%s&archive=tar", r.httpBaseURL.String(), path, url.PathEscape(instanceName), url.QueryEscape(filePath))
+
+	requestURL, err = r.setQueryAttributes(requestURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", requestURL, content)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-tar")
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return err
+	}
+
+	// Check the return value for a cleaner error
+	_, _, err = incusParseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceFileTree returns all entries of the directory tree at the provided path in the instance.
+// When withChecksums is set, the SHA256 checksum of every regular file is included.
+func (r *ProtocolIncus) GetInstanceFileTree(instanceName string, filePath string, withChecksums bool) ([]api.InstanceFileEntry, error) {
+	if !r.HasExtension("instance_file_sync") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_file_sync\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	recursion := 1
+	if withChecksums {
+		recursion = 2
+	}
+
+	// Fetch the raw value
+	entries := []api.InstanceFileEntry{}
+	_, err = r.queryStruct("GET", fmt.Sprintf("%s/%s/files?path=%s&recursion=%d", path, url.PathEscape(instanceName), url.QueryEscape(filePath), recursion), nil, "", &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 // DeleteInstanceFile deletes a file in the instance.
 func (r *ProtocolIncus) DeleteInstanceFile(instanceName string, filePath string) error {
 	if !r.HasExtension("file_delete") {
@@ -1569,7 +1834,8 @@ func (r *ProtocolIncus) rawSFTPConn(apiURL *url.URL) (net.Conn, error) {
 	var conn net.Conn
 
 	if httpTransport.TLSClientConfig != nil {
-		conn, err = httpTransport.DialTLSContext(context.Background(), "tcp", apiURL.Host)
+		// The connection gets upgraded to SFTP so it can't be negotiated as HTTP/2.
+		conn, err = httpTransport.DialTLSContext(context.WithValue(context.Background(), http1OnlyKey{}, true), "tcp", apiURL.Host)
 	} else {
 		conn, err = httpTransport.DialContext(context.Background(), "tcp", apiURL.Host)
 	}
@@ -2104,6 +2370,57 @@ func (r *ProtocolIncus) GetInstanceState(name string) (*api.InstanceState, strin
 	return &state, etag, nil
 }
 
+// GetInstanceNetworkUsage returns the network usage of the instance for each period (YYYY-MM), optionally filtered to the specified periods.
+func (r *ProtocolIncus) GetInstanceNetworkUsage(name string, periods ...string) ([]api.InstanceNetworkUsage, error) {
+	if !r.HasExtension("instances_network_usage") {
+		return nil, fmt.Errorf("The server is missing the required \"instances_network_usage\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := fmt.Sprintf("%s/%s/network-usage", path, url.PathEscape(name))
+	if len(periods) > 0 {
+		v := url.Values{}
+		v.Set("period", strings.Join(periods, ","))
+		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	}
+
+	usages := []api.InstanceNetworkUsage{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", uri, nil, "", &usages)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// GetInstanceCrashes returns the out of memory kills and guest panics of the instance, oldest first.
+func (r *ProtocolIncus) GetInstanceCrashes(name string) ([]api.InstanceCrash, error) {
+	if !r.HasExtension("instance_crash_events") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_crash_events\" API extension")
+	}
+
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	crashes := []api.InstanceCrash{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("%s/%s/crashes", path, url.PathEscape(name)), nil, "", &crashes)
+	if err != nil {
+		return nil, err
+	}
+
+	return crashes, nil
+}
+
 // UpdateInstanceState updates the instance to match the requested state.
 func (r *ProtocolIncus) UpdateInstanceState(name string, state api.InstanceStatePut, ETag string) (Operation, error) {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2137,43 +2454,453 @@ func (r *ProtocolIncus) GetInstanceAccess(name string) (api.Access, error) {
 	return access, nil
 }
 
-// GetInstanceLogfiles returns a list of logfiles for the instance.
-func (r *ProtocolIncus) GetInstanceLogfiles(name string) ([]string, error) {
-	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
-	if err != nil {
-		return nil, err
+// GetInstanceAttestation returns the confidential computing state of a virtual machine.
+func (r *ProtocolIncus) GetInstanceAttestation(name string) (*api.InstanceAttestation, error) {
+	if !r.HasExtension("instance_confidential_computing") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_confidential_computing\" API extension")
 	}
 
-	// Fetch the raw URL values.
-	urls := []string{}
-	baseURL := fmt.Sprintf("%s/%s/logs", path, url.PathEscape(name))
-	_, err = r.queryStruct("GET", baseURL, nil, "", &urls)
+	attestation := api.InstanceAttestation{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/attestation", url.PathEscape(name)), nil, "", &attestation)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse it.
-	return urlsToResourceNames(baseURL, urls...)
+	return &attestation, nil
 }
 
-// GetInstanceLogfile returns the content of the requested logfile.
-//
-// Note that it's the caller's responsibility to close the returned ReadCloser.
-func (r *ProtocolIncus) GetInstanceLogfile(name string, filename string) (io.ReadCloser, error) {
-	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
-	if err != nil {
-		return nil, err
+// GetInstanceAttestationReport requests a hardware attestation report bound to the nonce from a virtual machine.
+func (r *ProtocolIncus) GetInstanceAttestationReport(name string, nonce []byte) (io.ReadCloser, error) {
+	if !r.HasExtension("instance_confidential_computing") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_confidential_computing\" API extension")
 	}
 
 	// Prepare the HTTP request
-	url := fmt.Sprintf("%s/1.0%s/%s/logs/%s", r.httpBaseURL.String(), path, url.PathEscape(name), url.PathEscape(filename))
+	uri := fmt.Sprintf("%s/1.0/instances/%s/attestation/report?nonce=%s", r.httpBaseURL.String(), url.PathEscape(name), hex.EncodeToString(nonce))
 
-	url, err = r.setQueryAttributes(url)
+	uri, err := r.setQueryAttributes(uri)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// GetInstanceUserNames returns the names of the users of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUserNames(instanceName string) ([]string, error) {
+	if !r.HasExtension("instance_users") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := fmt.Sprintf("/instances/%s/users", url.PathEscape(instanceName))
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetInstanceUsers returns the users of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUsers(instanceName string) ([]api.InstanceUser, error) {
+	if !r.HasExtension("instance_users") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	users := []api.InstanceUser{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/users?recursion=1", url.PathEscape(instanceName)), nil, "", &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetInstanceUser returns a user of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUser(instanceName string, name string) (*api.InstanceUser, error) {
+	if !r.HasExtension("instance_users") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	user := api.InstanceUser{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/users/%s", url.PathEscape(instanceName), url.PathEscape(name)), nil, "", &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateInstanceUser creates a user in a virtual machine.
+func (r *ProtocolIncus) CreateInstanceUser(instanceName string, user api.InstanceUsersPost) error {
+	if !r.HasExtension("instance_users") {
+		return fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", fmt.Sprintf("/instances/%s/users", url.PathEscape(instanceName)), user, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateInstanceUser sets the password, SSH keys and sudo access of a user of a virtual machine.
+func (r *ProtocolIncus) UpdateInstanceUser(instanceName string, name string, user api.InstanceUserPut) error {
+	if !r.HasExtension("instance_users") {
+		return fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("PUT", fmt.Sprintf("/instances/%s/users/%s", url.PathEscape(instanceName), url.PathEscape(name)), user, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInstanceUser deletes a user of a virtual machine.
+func (r *ProtocolIncus) DeleteInstanceUser(instanceName string, name string) error {
+	if !r.HasExtension("instance_users") {
+		return fmt.Errorf("The server is missing the required \"instance_users\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("DELETE", fmt.Sprintf("/instances/%s/users/%s", url.PathEscape(instanceName), url.PathEscape(name)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceNVRAM exports the raw UEFI NVRAM of a virtual machine.
+func (r *ProtocolIncus) GetInstanceNVRAM(name string) (io.ReadCloser, error) {
+	if !r.HasExtension("instance_uefi_vars") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_uefi_vars\" API extension")
+	}
+
+	// Prepare the HTTP request
+	uri := fmt.Sprintf("%s/1.0/instances/%s/nvram", r.httpBaseURL.String(), url.PathEscape(name))
+
+	uri, err := r.setQueryAttributes(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// UpdateInstanceNVRAM replaces the UEFI NVRAM of a stopped virtual machine.
+func (r *ProtocolIncus) UpdateInstanceNVRAM(name string, content io.Reader) error {
+	if !r.HasExtension("instance_uefi_vars") {
+		return fmt.Errorf("The server is missing the required \"instance_uefi_vars\" API extension")
+	}
+
+	// Prepare the HTTP request
+	uri := fmt.Sprintf("%s/1.0/instances/%s/nvram", r.httpBaseURL.String(), url.PathEscape(name))
+
+	uri, err := r.setQueryAttributes(uri)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", uri, content)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return err
+	}
+
+	// Check the return value for a cleaner error
+	_, _, err = incusParseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceTPMState exports the state of a TPM device of a virtual machine, encrypted with the passphrase.
+func (r *ProtocolIncus) GetInstanceTPMState(name string, device string, passphrase string) (io.ReadCloser, error) {
+	if !r.HasExtension("instance_tpm_state") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_tpm_state\" API extension")
+	}
+
+	// Prepare the HTTP request
+	uri := fmt.Sprintf("%s/1.0/instances/%s/tpm/%s", r.httpBaseURL.String(), url.PathEscape(name), url.PathEscape(device))
+
+	uri, err := r.setQueryAttributes(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Incus-passphrase", passphrase)
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// UpdateInstanceTPMState replaces the state of a TPM device of a stopped virtual machine.
+func (r *ProtocolIncus) UpdateInstanceTPMState(name string, device string, content io.Reader, passphrase string) error {
+	if !r.HasExtension("instance_tpm_state") {
+		return fmt.Errorf("The server is missing the required \"instance_tpm_state\" API extension")
+	}
+
+	// Prepare the HTTP request
+	uri := fmt.Sprintf("%s/1.0/instances/%s/tpm/%s", r.httpBaseURL.String(), url.PathEscape(name), url.PathEscape(device))
+
+	uri, err := r.setQueryAttributes(uri)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PUT", uri, content)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Incus-passphrase", passphrase)
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return err
+	}
+
+	// Check the return value for a cleaner error
+	_, _, err = incusParseResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceUEFIVars returns the UEFI variables of a virtual machine.
+func (r *ProtocolIncus) GetInstanceUEFIVars(name string) (*api.InstanceUEFIVars, error) {
+	if !r.HasExtension("instance_uefi_vars") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_uefi_vars\" API extension")
+	}
+
+	vars := api.InstanceUEFIVars{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/uefi-vars", url.PathEscape(name)), nil, "", &vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vars, nil
+}
+
+// UpdateInstanceUEFIVars replaces the UEFI variables of a stopped virtual machine.
+func (r *ProtocolIncus) UpdateInstanceUEFIVars(name string, vars api.InstanceUEFIVars) error {
+	if !r.HasExtension("instance_uefi_vars") {
+		return fmt.Errorf("The server is missing the required \"instance_uefi_vars\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("PUT", fmt.Sprintf("/instances/%s/uefi-vars", url.PathEscape(name)), vars, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateInstanceUEFICertificate enrolls a secure boot certificate into a stopped virtual machine.
+func (r *ProtocolIncus) CreateInstanceUEFICertificate(name string, certificate api.InstanceUEFICertificatesPost) error {
+	if !r.HasExtension("instance_uefi_vars") {
+		return fmt.Errorf("The server is missing the required \"instance_uefi_vars\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", fmt.Sprintf("/instances/%s/uefi-vars/certificates", url.PathEscape(name)), certificate, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceRenderedCloudInit returns the cloud-init configuration of the instance as passed to the guest.
+func (r *ProtocolIncus) GetInstanceRenderedCloudInit(name string) (*api.InstanceRenderedCloudInit, error) {
+	if !r.HasExtension("cloud_init_templating") {
+		return nil, fmt.Errorf("The server is missing the required \"cloud_init_templating\" API extension")
+	}
+
+	cloudInit := api.InstanceRenderedCloudInit{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/rendered-cloud-init", url.PathEscape(name)), nil, "", &cloudInit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudInit, nil
+}
+
+// GetInstanceSyscalls returns the system call interception handlers of a container.
+func (r *ProtocolIncus) GetInstanceSyscalls(name string) ([]api.InstanceSyscallIntercept, error) {
+	if !r.HasExtension("instance_syscall_intercepts") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_syscall_intercepts\" API extension")
+	}
+
+	intercepts := []api.InstanceSyscallIntercept{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/syscalls", url.PathEscape(name)), nil, "", &intercepts)
+	if err != nil {
+		return nil, err
+	}
+
+	return intercepts, nil
+}
+
+// GetInstanceIdmap returns the ID map of a container along with its conflicts with other containers.
+func (r *ProtocolIncus) GetInstanceIdmap(name string) (*api.InstanceIdmap, string, error) {
+	if !r.HasExtension("instance_idmap") {
+		return nil, "", fmt.Errorf("The server is missing the required \"instance_idmap\" API extension")
+	}
+
+	idmap := api.InstanceIdmap{}
+
+	// Fetch the raw value
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/instances/%s/idmap", url.PathEscape(name)), nil, "", &idmap)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &idmap, etag, nil
+}
+
+// UpdateInstanceIdmap assigns a range of host IDs to a container.
+func (r *ProtocolIncus) UpdateInstanceIdmap(name string, idmap api.InstanceIdmapPut, ETag string) error {
+	if !r.HasExtension("instance_idmap") {
+		return fmt.Errorf("The server is missing the required \"instance_idmap\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("PUT", fmt.Sprintf("/instances/%s/idmap", url.PathEscape(name)), idmap, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceLogfiles returns a list of logfiles for the instance.
+func (r *ProtocolIncus) GetInstanceLogfiles(name string) ([]string, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := fmt.Sprintf("%s/%s/logs", path, url.PathEscape(name))
+	_, err = r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetInstanceLogfile returns the content of the requested logfile.
+//
+// Note that it's the caller's responsibility to close the returned ReadCloser.
+func (r *ProtocolIncus) GetInstanceLogfile(name string, filename string) (io.ReadCloser, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	url := fmt.Sprintf("%s/1.0%s/%s/logs/%s", r.httpBaseURL.String(), path, url.PathEscape(name), url.PathEscape(filename))
+
+	url, err = r.setQueryAttributes(url)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2195,6 +2922,18 @@ func (r *ProtocolIncus) GetInstanceLogfile(name string, filename string) (io.Rea
 	return resp.Body, err
 }
 
+// GetInstanceExecOutputLogfile returns the content of the requested exec-output logfile.
+//
+// Note that it's the caller's responsibility to close the returned ReadCloser.
+func (r *ProtocolIncus) GetInstanceExecOutputLogfile(name string, filename string) (io.ReadCloser, error) {
+	err := r.CheckExtension("instance_exec_recording")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getInstanceExecOutputLogFile(name, filename)
+}
+
 // DeleteInstanceLogfile deletes the requested logfile.
 func (r *ProtocolIncus) DeleteInstanceLogfile(name string, filename string) error {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2618,8 +3357,21 @@ func (r *ProtocolIncus) GetInstanceConsoleLog(instanceName string, args *Instanc
 		return nil, fmt.Errorf("The server is missing the required \"console\" API extension")
 	}
 
+	if args != nil && args.History && !r.HasExtension("console_log_history") {
+		return nil, fmt.Errorf("The server is missing the required \"console_log_history\" API extension")
+	}
+
+	if args != nil && args.Screenshot && !r.HasExtension("instance_console_screenshot") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_console_screenshot\" API extension")
+	}
+
 	// Prepare the HTTP request
 	url := fmt.Sprintf("%s/1.0%s/%s/console", r.httpBaseURL.String(), path, url.PathEscape(instanceName))
+	if args != nil && args.Screenshot {
+		url += "?type=screenshot"
+	} else if args != nil && args.History {
+		url += "?type=log"
+	}
 
 	url, err = r.setQueryAttributes(url)
 	if err != nil {
@@ -2648,6 +3400,55 @@ func (r *ProtocolIncus) GetInstanceConsoleLog(instanceName string, args *Instanc
 	return resp.Body, err
 }
 
+// CaptureInstanceTraffic captures the traffic of an instance NIC and returns it in the pcap format.
+// The capture ends once the returned content is fully read.
+func (r *ProtocolIncus) CaptureInstanceTraffic(instanceName string, capture api.InstanceCapturePost) (io.ReadCloser, error) {
+	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.HasExtension("instance_nic_capture") {
+		return nil, fmt.Errorf("The server is missing the required \"instance_nic_capture\" API extension")
+	}
+
+	body, err := json.Marshal(capture)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the HTTP request
+	url := fmt.Sprintf("%s/1.0%s/%s/state/capture", r.httpBaseURL.String(), path, url.PathEscape(instanceName))
+
+	url, err = r.setQueryAttributes(url)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the return value for a cleaner error
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp.Body, nil
+}
+
 // DeleteInstanceConsoleLog deletes the requested instance's console log.
 func (r *ProtocolIncus) DeleteInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) error {
 	path, _, err := r.instanceTypeToPath(api.InstanceTypeAny)
@@ -2659,8 +3460,17 @@ func (r *ProtocolIncus) DeleteInstanceConsoleLog(instanceName string, args *Inst
 		return fmt.Errorf("The server is missing the required \"console\" API extension")
 	}
 
+	uri := fmt.Sprintf("%s/%s/console", path, url.PathEscape(instanceName))
+	if args != nil && args.History {
+		if !r.HasExtension("console_log_history") {
+			return fmt.Errorf("The server is missing the required \"console_log_history\" API extension")
+		}
+
+		uri += "?type=log"
+	}
+
 	// Send the request
-	_, _, err = r.query("DELETE", fmt.Sprintf("%s/%s/console", path, url.PathEscape(instanceName)), nil, "")
+	_, _, err = r.query("DELETE", uri, nil, "")
 	if err != nil {
 		return err
 	}
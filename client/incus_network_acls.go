@@ -76,6 +76,31 @@ func (r *ProtocolIncus) GetNetworkACL(name string) (*api.NetworkACL, string, err
 	return &acl, etag, nil
 }
 
+// GetNetworkACLEffective returns the rules applied to the traffic of the NICs of an instance using the ACL.
+// If deviceName is empty, all the NICs using the ACL are returned.
+func (r *ProtocolIncus) GetNetworkACLEffective(name string, instanceName string, deviceName string) ([]api.NetworkACLEffective, error) {
+	if !r.HasExtension("network_acl_effective") {
+		return nil, fmt.Errorf(`The server is missing the required "network_acl_effective" API extension`)
+	}
+
+	v := url.Values{}
+	v.Set("instance", instanceName)
+
+	if deviceName != "" {
+		v.Set("device", deviceName)
+	}
+
+	effective := []api.NetworkACLEffective{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/network-acls/%s/effective?%s", url.PathEscape(name), v.Encode()), nil, "", &effective)
+	if err != nil {
+		return nil, err
+	}
+
+	return effective, nil
+}
+
 // GetNetworkACLLogfile returns a reader for the ACL log file.
 //
 // Note that it's the caller's responsibility to close the returned ReadCloser.
@@ -0,0 +1,105 @@
+package incus
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetNetworkDHCPReservationHwaddrs returns a list of network DHCP reservation MAC addresses.
+func (r *ProtocolIncus) GetNetworkDHCPReservationHwaddrs(networkName string) ([]string, error) {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return nil, fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	// Fetch the raw URL values.
+	urls := []string{}
+	baseURL := fmt.Sprintf("/networks/%s/dhcp-reservations", url.PathEscape(networkName))
+	_, err := r.queryStruct("GET", baseURL, nil, "", &urls)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse it.
+	return urlsToResourceNames(baseURL, urls...)
+}
+
+// GetNetworkDHCPReservations returns a list of Network DHCP reservation structs.
+func (r *ProtocolIncus) GetNetworkDHCPReservations(networkName string) ([]api.NetworkDHCPReservation, error) {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return nil, fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	reservations := []api.NetworkDHCPReservation{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/networks/%s/dhcp-reservations?recursion=1", url.PathEscape(networkName)), nil, "", &reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// GetNetworkDHCPReservation returns a Network DHCP reservation entry for the provided network and MAC address.
+func (r *ProtocolIncus) GetNetworkDHCPReservation(networkName string, hwaddr string) (*api.NetworkDHCPReservation, string, error) {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return nil, "", fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	reservation := api.NetworkDHCPReservation{}
+
+	// Fetch the raw value.
+	etag, err := r.queryStruct("GET", fmt.Sprintf("/networks/%s/dhcp-reservations/%s", url.PathEscape(networkName), url.PathEscape(hwaddr)), nil, "", &reservation)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &reservation, etag, nil
+}
+
+// CreateNetworkDHCPReservation defines a new network DHCP reservation using the provided struct.
+func (r *ProtocolIncus) CreateNetworkDHCPReservation(networkName string, reservation api.NetworkDHCPReservationsPost) error {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", fmt.Sprintf("/networks/%s/dhcp-reservations", url.PathEscape(networkName)), reservation, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateNetworkDHCPReservation updates the network DHCP reservation to match the provided struct.
+func (r *ProtocolIncus) UpdateNetworkDHCPReservation(networkName string, hwaddr string, reservation api.NetworkDHCPReservationPut, ETag string) error {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("PUT", fmt.Sprintf("/networks/%s/dhcp-reservations/%s", url.PathEscape(networkName), url.PathEscape(hwaddr)), reservation, ETag)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteNetworkDHCPReservation deletes an existing network DHCP reservation.
+func (r *ProtocolIncus) DeleteNetworkDHCPReservation(networkName string, hwaddr string) error {
+	if !r.HasExtension("network_dhcp_reservations") {
+		return fmt.Errorf(`The server is missing the required "network_dhcp_reservations" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/networks/%s/dhcp-reservations/%s", url.PathEscape(networkName), url.PathEscape(hwaddr)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
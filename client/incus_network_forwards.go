@@ -59,6 +59,23 @@ func (r *ProtocolIncus) GetNetworkForward(networkName string, listenAddress stri
 	return &forward, etag, nil
 }
 
+// GetNetworkForwardState returns the traffic counters of a network forward.
+func (r *ProtocolIncus) GetNetworkForwardState(networkName string, listenAddress string) (*api.NetworkForwardState, error) {
+	if !r.HasExtension("network_forward_state") {
+		return nil, fmt.Errorf(`The server is missing the required "network_forward_state" API extension`)
+	}
+
+	forwardState := api.NetworkForwardState{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/networks/%s/forwards/%s/state", url.PathEscape(networkName), url.PathEscape(listenAddress)), nil, "", &forwardState)
+	if err != nil {
+		return nil, err
+	}
+
+	return &forwardState, nil
+}
+
 // CreateNetworkForward defines a new network forward using the provided struct.
 func (r *ProtocolIncus) CreateNetworkForward(networkName string, forward api.NetworkForwardsPost) error {
 	if !r.HasExtension("network_forward") {
@@ -108,3 +108,50 @@ func (r *ProtocolIncus) DeleteNetworkPeer(networkName string, peerName string) e
 
 	return nil
 }
+
+// GetNetworkPeerRequests returns a list of pending network peerings requested by other networks.
+func (r *ProtocolIncus) GetNetworkPeerRequests(networkName string) ([]api.NetworkPeerRequest, error) {
+	if !r.HasExtension("network_peer_requests") {
+		return nil, fmt.Errorf(`The server is missing the required "network_peer_requests" API extension`)
+	}
+
+	requests := []api.NetworkPeerRequest{}
+
+	// Fetch the raw value.
+	_, err := r.queryStruct("GET", fmt.Sprintf("/networks/%s/peer-requests", url.PathEscape(networkName)), nil, "", &requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// ApproveNetworkPeerRequest creates the mutual network peering requested by the source network.
+func (r *ProtocolIncus) ApproveNetworkPeerRequest(networkName string, sourceProject string, sourceNetwork string, peer api.NetworkPeerRequestPost) error {
+	if !r.HasExtension("network_peer_requests") {
+		return fmt.Errorf(`The server is missing the required "network_peer_requests" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("POST", fmt.Sprintf("/networks/%s/peer-requests/%s/%s", url.PathEscape(networkName), url.PathEscape(sourceProject), url.PathEscape(sourceNetwork)), peer, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RejectNetworkPeerRequest deletes the pending network peering requested by the source network.
+func (r *ProtocolIncus) RejectNetworkPeerRequest(networkName string, sourceProject string, sourceNetwork string) error {
+	if !r.HasExtension("network_peer_requests") {
+		return fmt.Errorf(`The server is missing the required "network_peer_requests" API extension`)
+	}
+
+	// Send the request.
+	_, _, err := r.query("DELETE", fmt.Sprintf("/networks/%s/peer-requests/%s/%s", url.PathEscape(networkName), url.PathEscape(sourceProject), url.PathEscape(sourceNetwork)), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -108,6 +108,38 @@ func (r *ProtocolIncus) GetNetworkState(name string) (*api.NetworkState, error)
 	return &state, nil
 }
 
+// TraceNetwork traces the path of a packet sent by an instance through the network.
+func (r *ProtocolIncus) TraceNetwork(name string, trace api.NetworkTracePost) (*api.NetworkTrace, error) {
+	if !r.HasExtension("network_trace") {
+		return nil, fmt.Errorf("The server is missing the required \"network_trace\" API extension")
+	}
+
+	result := api.NetworkTrace{}
+
+	// Send the request
+	_, err := r.queryStruct("POST", fmt.Sprintf("/networks/%s/trace", url.PathEscape(name)), trace, "", &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// MoveNetworkGateway moves the gateway of the network to another chassis.
+func (r *ProtocolIncus) MoveNetworkGateway(name string, gateway api.NetworkGatewayPost) error {
+	if !r.HasExtension("network_ovn_gateway_chassis") {
+		return fmt.Errorf("The server is missing the required \"network_ovn_gateway_chassis\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", fmt.Sprintf("/networks/%s/gateway", url.PathEscape(name)), gateway, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateNetwork defines a new network using the provided Network struct.
 func (r *ProtocolIncus) CreateNetwork(network api.NetworksPost) error {
 	if !r.HasExtension("network") {
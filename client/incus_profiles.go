@@ -65,6 +65,24 @@ func (r *ProtocolIncus) GetProfile(name string) (*api.Profile, string, error) {
 	return &profile, etag, nil
 }
 
+// GetProfilePreview returns the changes the supplied profile configuration would make to the instances using the profile.
+func (r *ProtocolIncus) GetProfilePreview(name string, profile api.ProfilePut) (*api.ProfilePreview, error) {
+	err := r.CheckExtension("profile_preview")
+	if err != nil {
+		return nil, err
+	}
+
+	preview := api.ProfilePreview{}
+
+	// Fetch the raw value
+	_, err = r.queryStruct("GET", fmt.Sprintf("/profiles/%s/preview", url.PathEscape(name)), profile, "", &preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
 // CreateProfile defines a new instance profile.
 func (r *ProtocolIncus) CreateProfile(profile api.ProfilesPost) error {
 	// Send the request
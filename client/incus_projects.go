@@ -3,6 +3,7 @@ package incus
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -78,6 +79,47 @@ func (r *ProtocolIncus) GetProjectState(name string) (*api.ProjectState, error)
 	return &projectState, nil
 }
 
+// GetProjectCleanup returns the resources the cleanup policy of the project would remove if it ran now.
+func (r *ProtocolIncus) GetProjectCleanup(name string) (*api.ProjectCleanup, error) {
+	if !r.HasExtension("projects_cleanup") {
+		return nil, fmt.Errorf("The server is missing the required \"projects_cleanup\" API extension")
+	}
+
+	cleanup := api.ProjectCleanup{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/projects/%s/cleanup", url.PathEscape(name)), nil, "", &cleanup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cleanup, nil
+}
+
+// GetProjectNetworkUsage returns the network usage of the project's instances for each period (YYYY-MM), optionally filtered to the specified periods.
+func (r *ProtocolIncus) GetProjectNetworkUsage(name string, periods ...string) ([]api.ProjectNetworkUsage, error) {
+	if !r.HasExtension("instances_network_usage") {
+		return nil, fmt.Errorf("The server is missing the required \"instances_network_usage\" API extension")
+	}
+
+	uri := fmt.Sprintf("/projects/%s/network-usage", url.PathEscape(name))
+	if len(periods) > 0 {
+		v := url.Values{}
+		v.Set("period", strings.Join(periods, ","))
+		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	}
+
+	usages := []api.ProjectNetworkUsage{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", uri, nil, "", &usages)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
 // GetProjectAccess returns an Access entry for the specified project.
 func (r *ProtocolIncus) GetProjectAccess(name string) (api.Access, error) {
 	access := api.Access{}
@@ -169,3 +211,18 @@ func (r *ProtocolIncus) DeleteProjectForce(name string) error {
 
 	return nil
 }
+
+// CreateProjectSnapshots creates a snapshot of all (or the listed) instances of the project at about the same time.
+func (r *ProtocolIncus) CreateProjectSnapshots(name string, snapshot api.ProjectSnapshotsPost) (Operation, error) {
+	if !r.HasExtension("project_snapshots") {
+		return nil, fmt.Errorf("The server is missing the required \"project_snapshots\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/projects/%s/snapshots", url.PathEscape(name)), snapshot, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
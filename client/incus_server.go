@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"slices"
 
 	"github.com/gorilla/websocket"
@@ -98,6 +99,74 @@ func (r *ProtocolIncus) GetServerResources() (*api.Resources, error) {
 	return &resources, nil
 }
 
+// GetServerChecks returns the host compatibility checks of a given Incus server.
+func (r *ProtocolIncus) GetServerChecks() ([]api.ResourcesCheck, error) {
+	if !r.HasExtension("resources_checks") {
+		return nil, fmt.Errorf("The server is missing the required \"resources_checks\" API extension")
+	}
+
+	resources := api.Resources{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/resources?checks=1", nil, "", &resources)
+	if err != nil {
+		return nil, err
+	}
+
+	return resources.Checks, nil
+}
+
+// GetServerResourcesState returns the live utilization of a given Incus server.
+func (r *ProtocolIncus) GetServerResourcesState() (*api.ResourcesState, error) {
+	if !r.HasExtension("resources_state") {
+		return nil, fmt.Errorf("The server is missing the required \"resources_state\" API extension")
+	}
+
+	state := api.ResourcesState{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/resources/state", nil, "", &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state, nil
+}
+
+// GetServerBGPStatus returns the state of the BGP server and its peers.
+func (r *ProtocolIncus) GetServerBGPStatus() (*api.BGPStatus, error) {
+	if !r.HasExtension("bgp_status") {
+		return nil, fmt.Errorf("The server is missing the required \"bgp_status\" API extension")
+	}
+
+	status := api.BGPStatus{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/bgp", nil, "", &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// GetServerPreseed returns the current server configuration as a preseed document.
+func (r *ProtocolIncus) GetServerPreseed() (*api.InitPreseed, error) {
+	if !r.HasExtension("preseed_export") {
+		return nil, fmt.Errorf("The server is missing the required \"preseed_export\" API extension")
+	}
+
+	config := api.InitPreseed{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", "/preseed", nil, "", &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
 // UseProject returns a client that will use a specific project.
 func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 	return &ProtocolIncus{
@@ -117,6 +186,7 @@ func (r *ProtocolIncus) UseProject(name string) InstanceServer {
 		eventConns:           make(map[string]*websocket.Conn),  // New project specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New project specific listeners.
 		oidcClient:           r.oidcClient,
+		retryPolicy:          r.retryPolicy,
 	}
 }
 
@@ -140,6 +210,7 @@ func (r *ProtocolIncus) UseTarget(name string) InstanceServer {
 		eventConns:           make(map[string]*websocket.Conn),  // New target specific listener conns.
 		eventListeners:       make(map[string][]*EventListener), // New target specific listeners.
 		oidcClient:           r.oidcClient,
+		retryPolicy:          r.retryPolicy,
 		clusterTarget:        name,
 	}
 }
@@ -188,6 +259,70 @@ func (r *ProtocolIncus) GetMetrics() (string, error) {
 	return string(content), nil
 }
 
+// CreateSupportBundle requests the generation of a support bundle by the server.
+// The name of the generated bundle is included in the operation metadata.
+func (r *ProtocolIncus) CreateSupportBundle() (Operation, error) {
+	if !r.HasExtension("support_bundle") {
+		return nil, fmt.Errorf("The server is missing the required \"support_bundle\" API extension")
+	}
+
+	op, _, err := r.queryOperation("POST", "/admin/support-bundle", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// GetSupportBundleFile downloads a support bundle from the server into the provided writer.
+func (r *ProtocolIncus) GetSupportBundleFile(name string, target io.Writer) (int64, error) {
+	if !r.HasExtension("support_bundle") {
+		return 0, fmt.Errorf("The server is missing the required \"support_bundle\" API extension")
+	}
+
+	// Prepare the request.
+	requestURL, err := r.setQueryAttributes(fmt.Sprintf("%s/1.0/admin/support-bundle/%s", r.httpBaseURL.String(), url.PathEscape(name)))
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// Send the request.
+	resp, err := r.DoHTTP(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _, err := incusParseResponse(resp)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return io.Copy(target, resp.Body)
+}
+
+// DeleteSupportBundle removes a support bundle from the server.
+func (r *ProtocolIncus) DeleteSupportBundle(name string) error {
+	if !r.HasExtension("support_bundle") {
+		return fmt.Errorf("The server is missing the required \"support_bundle\" API extension")
+	}
+
+	_, _, err := r.query("DELETE", api.NewURL().Path("admin", "support-bundle", name).String(), nil, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ApplyServerPreseed configures a target Incus server with the provided server and cluster configuration.
 func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 	// Apply server configuration.
@@ -309,6 +444,61 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 		return nil
 	}
 
+	// Apply network ACL configuration function.
+	applyNetworkACL := func(target api.InitNetworkACLProjectPost) error {
+		acl, etag, err := r.UseProject(target.Project).GetNetworkACL(target.Name)
+		if err != nil {
+			// Create the network ACL if doesn't exist.
+			err := r.UseProject(target.Project).CreateNetworkACL(target.NetworkACLsPost)
+			if err != nil {
+				return fmt.Errorf("Failed to create network ACL %q in project %q: %w", target.Name, target.Project, err)
+			}
+		} else {
+			// Description override.
+			if target.Description != "" {
+				acl.Description = target.Description
+			}
+
+			// Rules overrides.
+			if target.Ingress != nil {
+				acl.Ingress = target.Ingress
+			}
+
+			if target.Egress != nil {
+				acl.Egress = target.Egress
+			}
+
+			// Config overrides.
+			for k, v := range target.Config {
+				acl.Config[k] = fmt.Sprintf("%v", v)
+			}
+
+			// Apply it.
+			err = r.UseProject(target.Project).UpdateNetworkACL(target.Name, acl.Writable(), etag)
+			if err != nil {
+				return fmt.Errorf("Failed to update network ACL %q in project %q: %w", target.Name, target.Project, err)
+			}
+		}
+
+		return nil
+	}
+
+	// Apply network ACLs in the default project before the networks which may reference them.
+	for i := range config.Server.NetworkACLs {
+		if config.Server.NetworkACLs[i].Project == "" {
+			config.Server.NetworkACLs[i].Project = api.ProjectDefaultName
+		}
+
+		if config.Server.NetworkACLs[i].Project != api.ProjectDefaultName {
+			continue
+		}
+
+		err := applyNetworkACL(config.Server.NetworkACLs[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	// Apply networks in the default project before other projects config applied (so that if the projects
 	// depend on a network in the default project they can have their config applied successfully).
 	for i := range config.Server.Networks {
@@ -393,6 +583,18 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 		}
 	}
 
+	// Apply network ACLs in non-default projects after project config applied (so that their projects exist).
+	for i := range config.Server.NetworkACLs {
+		if config.Server.NetworkACLs[i].Project == api.ProjectDefaultName {
+			continue
+		}
+
+		err := applyNetworkACL(config.Server.NetworkACLs[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	// Apply networks in non-default projects after project config applied (so that their projects exist).
 	for i := range config.Server.Networks {
 		if config.Server.Networks[i].Project == api.ProjectDefaultName {
@@ -407,31 +609,19 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 
 	// Apply profile configuration.
 	if config.Server.Profiles != nil && len(config.Server.Profiles) > 0 {
-		// Get the list of profiles.
-		profileNames, err := r.GetProfileNames()
-		if err != nil {
-			return fmt.Errorf("Failed to retrieve list of profiles: %w", err)
-		}
-
 		// Profile creator.
-		createProfile := func(profile api.ProfilesPost) error {
+		createProfile := func(target api.InitProfileProjectPost) error {
 			// Create the profile if doesn't exist.
-			err := r.CreateProfile(profile)
+			err := r.UseProject(target.Project).CreateProfile(target.ProfilesPost)
 			if err != nil {
-				return fmt.Errorf("Failed to create profile %q: %w", profile.Name, err)
+				return fmt.Errorf("Failed to create profile %q in project %q: %w", target.Name, target.Project, err)
 			}
 
 			return nil
 		}
 
 		// Profile updater.
-		updateProfile := func(target api.ProfilesPost) error {
-			// Get the current profile.
-			profile, etag, err := r.GetProfile(target.Name)
-			if err != nil {
-				return fmt.Errorf("Failed to retrieve current profile %q: %w", target.Name, err)
-			}
-
+		updateProfile := func(target api.InitProfileProjectPost, profile *api.Profile, etag string) error {
 			// Description override.
 			if target.Description != "" {
 				profile.Description = target.Description
@@ -442,6 +632,11 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 				profile.Config[k] = fmt.Sprintf("%v", v)
 			}
 
+			// Parents override.
+			if target.Parents != nil {
+				profile.Parents = target.Parents
+			}
+
 			// Device overrides.
 			for k, v := range target.Devices {
 				// New device.
@@ -458,17 +653,28 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 			}
 
 			// Apply it.
-			err = r.UpdateProfile(target.Name, profile.Writable(), etag)
+			err := r.UseProject(target.Project).UpdateProfile(target.Name, profile.Writable(), etag)
 			if err != nil {
-				return fmt.Errorf("Failed to update profile %q: %w", target.Name, err)
+				return fmt.Errorf("Failed to update profile %q in project %q: %w", target.Name, target.Project, err)
 			}
 
 			return nil
 		}
 
 		for _, profile := range config.Server.Profiles {
-			// New profile.
-			if !slices.Contains(profileNames, profile.Name) {
+			// Populate default project if not specified for backwards compatibility with earlier
+			// preseed dump files.
+			if profile.Project == "" {
+				profile.Project = api.ProjectDefaultName
+			}
+
+			current, etag, err := r.UseProject(profile.Project).GetProfile(profile.Name)
+			if err != nil {
+				if !api.StatusErrorCheck(err, http.StatusNotFound) {
+					return fmt.Errorf("Failed to retrieve current profile %q in project %q: %w", profile.Name, profile.Project, err)
+				}
+
+				// New profile.
 				err := createProfile(profile)
 				if err != nil {
 					return err
@@ -478,7 +684,7 @@ func (r *ProtocolIncus) ApplyServerPreseed(config api.InitPreseed) error {
 			}
 
 			// Existing profile.
-			err := updateProfile(profile)
+			err = updateProfile(profile, current, etag)
 			if err != nil {
 				return err
 			}
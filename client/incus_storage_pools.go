@@ -122,3 +122,50 @@ func (r *ProtocolIncus) GetStoragePoolResources(name string) (*api.ResourcesStor
 
 	return &res, nil
 }
+
+// BenchmarkStoragePool measures the IO performance of a storage pool.
+func (r *ProtocolIncus) BenchmarkStoragePool(name string) (Operation, error) {
+	if !r.HasExtension("storage_pool_benchmark") {
+		return nil, fmt.Errorf("The server is missing the required \"storage_pool_benchmark\" API extension")
+	}
+
+	// Send the request
+	op, _, err := r.queryOperation("POST", fmt.Sprintf("/storage-pools/%s/benchmark", url.PathEscape(name)), nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// GetStoragePoolKey returns the status of the encryption key of a storage pool.
+func (r *ProtocolIncus) GetStoragePoolKey(name string) (*api.StoragePoolKey, error) {
+	if !r.HasExtension("storage_pool_key") {
+		return nil, fmt.Errorf("The server is missing the required \"storage_pool_key\" API extension")
+	}
+
+	key := api.StoragePoolKey{}
+
+	// Fetch the raw value
+	_, err := r.queryStruct("GET", fmt.Sprintf("/storage-pools/%s/key", url.PathEscape(name)), nil, "", &key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// UpdateStoragePoolKey loads, unloads or changes the encryption key of a storage pool.
+func (r *ProtocolIncus) UpdateStoragePoolKey(name string, key api.StoragePoolKeyPost) error {
+	if !r.HasExtension("storage_pool_key") {
+		return fmt.Errorf("The server is missing the required \"storage_pool_key\" API extension")
+	}
+
+	// Send the request
+	_, _, err := r.query("POST", fmt.Sprintf("/storage-pools/%s/key", url.PathEscape(name)), key, "")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -77,8 +77,15 @@ type InstanceServer interface {
 	GetMetrics() (metrics string, err error)
 	GetServer() (server *api.Server, ETag string, err error)
 	GetServerResources() (resources *api.Resources, err error)
+	GetServerChecks() (checks []api.ResourcesCheck, err error)
+	GetServerResourcesState() (state *api.ResourcesState, err error)
+	GetServerBGPStatus() (status *api.BGPStatus, err error)
 	UpdateServer(server api.ServerPut, ETag string) (err error)
 	ApplyServerPreseed(config api.InitPreseed) error
+	GetServerPreseed() (config *api.InitPreseed, err error)
+	CreateSupportBundle() (op Operation, err error)
+	GetSupportBundleFile(name string, target io.Writer) (size int64, err error)
+	DeleteSupportBundle(name string) (err error)
 	HasExtension(extension string) (exists bool)
 	RequireAuthenticated(authenticated bool)
 	IsClustered() (clustered bool)
@@ -108,6 +115,9 @@ type InstanceServer interface {
 	GetInstance(name string) (instance *api.Instance, ETag string, err error)
 	GetInstanceFull(name string) (instance *api.InstanceFull, ETag string, err error)
 	CreateInstance(instance api.InstancesPost) (op Operation, err error)
+	CreateInstanceDryRun(instance api.InstancesPost) (result *api.InstancesPostDryRun, err error)
+	CreateInstanceFromMigrationToken(instance api.MigrationTokenInstancesPost) (op Operation, err error)
+	CreateMigrationToken(token api.MigrationTokensPost) (op Operation, err error)
 	CreateInstanceFromImage(source ImageServer, image api.Image, req api.InstancesPost) (op RemoteOperation, err error)
 	CopyInstance(source InstanceServer, instance api.Instance, args *InstanceCopyArgs) (op RemoteOperation, err error)
 	UpdateInstance(name string, instance api.InstancePut, ETag string) (op Operation, err error)
@@ -115,6 +125,7 @@ type InstanceServer interface {
 	MigrateInstance(name string, instance api.InstancePost) (op Operation, err error)
 	DeleteInstance(name string) (op Operation, err error)
 	UpdateInstances(state api.InstancesPut, ETag string) (op Operation, err error)
+	UpdateInstancesState(state api.InstancesStatePut) (op Operation, err error)
 	RebuildInstance(instanceName string, req api.InstanceRebuildPost) (op Operation, err error)
 	RebuildInstanceFromImage(source ImageServer, image api.Image, instanceName string, req api.InstanceRebuildPost) (op RemoteOperation, err error)
 
@@ -124,10 +135,14 @@ type InstanceServer interface {
 
 	GetInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) (content io.ReadCloser, err error)
 	DeleteInstanceConsoleLog(instanceName string, args *InstanceConsoleLogArgs) (err error)
+	CaptureInstanceTraffic(instanceName string, capture api.InstanceCapturePost) (content io.ReadCloser, err error)
 
 	GetInstanceFile(instanceName string, path string) (content io.ReadCloser, resp *InstanceFileResponse, err error)
 	CreateInstanceFile(instanceName string, path string, args InstanceFileArgs) (err error)
 	DeleteInstanceFile(instanceName string, path string) (err error)
+	GetInstanceFileTree(instanceName string, path string, withChecksums bool) (entries []api.InstanceFileEntry, err error)
+	GetInstanceFileArchive(instanceName string, path string) (content io.ReadCloser, err error)
+	CreateInstanceFileArchive(instanceName string, path string, content io.Reader) (err error)
 
 	GetInstanceFileSFTPConn(instanceName string) (net.Conn, error)
 	GetInstanceFileSFTP(instanceName string) (*sftp.Client, error)
@@ -150,14 +165,40 @@ type InstanceServer interface {
 	DeleteInstanceBackup(instanceName string, name string) (op Operation, err error)
 	GetInstanceBackupFile(instanceName string, name string, req *BackupFileRequest) (resp *BackupFileResponse, err error)
 	CreateInstanceFromBackup(args InstanceBackupArgs) (op Operation, err error)
+	CreateInstanceFromDisk(instance api.InstancesPost, disk io.Reader) (op Operation, err error)
 
 	GetInstanceState(name string) (state *api.InstanceState, ETag string, err error)
+	GetInstanceNetworkUsage(name string, periods ...string) (usages []api.InstanceNetworkUsage, err error)
+	GetInstanceCrashes(name string) (crashes []api.InstanceCrash, err error)
 	UpdateInstanceState(name string, state api.InstanceStatePut, ETag string) (op Operation, err error)
 
 	GetInstanceAccess(name string) (access api.Access, err error)
 
+	GetInstanceAttestation(name string) (attestation *api.InstanceAttestation, err error)
+	GetInstanceAttestationReport(name string, nonce []byte) (content io.ReadCloser, err error)
+	GetInstanceUserNames(instanceName string) (names []string, err error)
+	GetInstanceUsers(instanceName string) (users []api.InstanceUser, err error)
+	GetInstanceUser(instanceName string, name string) (user *api.InstanceUser, err error)
+	CreateInstanceUser(instanceName string, user api.InstanceUsersPost) (err error)
+	UpdateInstanceUser(instanceName string, name string, user api.InstanceUserPut) (err error)
+	DeleteInstanceUser(instanceName string, name string) (err error)
+
+	GetInstanceNVRAM(name string) (content io.ReadCloser, err error)
+	UpdateInstanceNVRAM(name string, content io.Reader) (err error)
+	GetInstanceTPMState(name string, device string, passphrase string) (content io.ReadCloser, err error)
+	UpdateInstanceTPMState(name string, device string, content io.Reader, passphrase string) (err error)
+	GetInstanceUEFIVars(name string) (vars *api.InstanceUEFIVars, err error)
+	UpdateInstanceUEFIVars(name string, vars api.InstanceUEFIVars) (err error)
+	CreateInstanceUEFICertificate(name string, certificate api.InstanceUEFICertificatesPost) (err error)
+
+	GetInstanceRenderedCloudInit(name string) (cloudInit *api.InstanceRenderedCloudInit, err error)
+	GetInstanceSyscalls(name string) (intercepts []api.InstanceSyscallIntercept, err error)
+	GetInstanceIdmap(name string) (idmap *api.InstanceIdmap, ETag string, err error)
+	UpdateInstanceIdmap(name string, idmap api.InstanceIdmapPut, ETag string) (err error)
+
 	GetInstanceLogfiles(name string) (logfiles []string, err error)
 	GetInstanceLogfile(name string, filename string) (content io.ReadCloser, err error)
+	GetInstanceExecOutputLogfile(name string, filename string) (content io.ReadCloser, err error)
 	DeleteInstanceLogfile(name string, filename string) (err error)
 
 	GetInstanceMetadata(name string) (metadata *api.ImageMetadata, ETag string, err error)
@@ -168,6 +209,26 @@ type InstanceServer interface {
 	CreateInstanceTemplateFile(instanceName string, templateName string, content io.ReadSeeker) (err error)
 	DeleteInstanceTemplateFile(name string, templateName string) (err error)
 
+	// Instance group functions ("instance_groups" API extension)
+	GetInstanceGroupNames() (names []string, err error)
+	GetInstanceGroups() (groups []api.InstanceGroup, err error)
+	GetInstanceGroup(name string) (group *api.InstanceGroup, ETag string, err error)
+	CreateInstanceGroup(group api.InstanceGroupsPost) (err error)
+	UpdateInstanceGroup(name string, group api.InstanceGroupPut, ETag string) (err error)
+	RenameInstanceGroup(name string, group api.InstanceGroupPost) (err error)
+	DeleteInstanceGroup(name string) (err error)
+	UpdateInstanceGroupState(name string, state api.InstanceStatePut) (op Operation, err error)
+	CreateInstanceGroupSnapshot(name string, snapshot api.InstanceSnapshotsPost) (op Operation, err error)
+
+	// Instance template functions ("instance_templates" API extension)
+	GetInstanceTemplateNames() (names []string, err error)
+	GetInstanceTemplates() (templates []api.InstanceTemplate, err error)
+	GetInstanceTemplate(name string) (template *api.InstanceTemplate, ETag string, err error)
+	CreateInstanceTemplate(template api.InstanceTemplatesPost) (err error)
+	UpdateInstanceTemplate(name string, template api.InstanceTemplatePut, ETag string) (err error)
+	RenameInstanceTemplate(name string, template api.InstanceTemplatePost) (err error)
+	DeleteInstanceTemplate(name string) (err error)
+
 	// Event handling functions
 	GetEvents() (listener *EventListener, err error)
 	GetEventsAllProjects() (listener *EventListener, err error)
@@ -195,6 +256,8 @@ type InstanceServer interface {
 	GetNetwork(name string) (network *api.Network, ETag string, err error)
 	GetNetworkLeases(name string) (leases []api.NetworkLease, err error)
 	GetNetworkState(name string) (state *api.NetworkState, err error)
+	TraceNetwork(name string, trace api.NetworkTracePost) (result *api.NetworkTrace, err error)
+	MoveNetworkGateway(name string, gateway api.NetworkGatewayPost) (err error)
 	CreateNetwork(network api.NetworksPost) (err error)
 	UpdateNetwork(name string, network api.NetworkPut, ETag string) (err error)
 	RenameNetwork(name string, network api.NetworkPost) (err error)
@@ -204,14 +267,24 @@ type InstanceServer interface {
 	GetNetworkForwardAddresses(networkName string) ([]string, error)
 	GetNetworkForwards(networkName string) ([]api.NetworkForward, error)
 	GetNetworkForward(networkName string, listenAddress string) (forward *api.NetworkForward, ETag string, err error)
+	GetNetworkForwardState(networkName string, listenAddress string) (state *api.NetworkForwardState, err error)
 	CreateNetworkForward(networkName string, forward api.NetworkForwardsPost) error
 	UpdateNetworkForward(networkName string, listenAddress string, forward api.NetworkForwardPut, ETag string) (err error)
 	DeleteNetworkForward(networkName string, listenAddress string) (err error)
 
+	// Network DHCP reservation functions ("network_dhcp_reservations" API extension)
+	GetNetworkDHCPReservationHwaddrs(networkName string) ([]string, error)
+	GetNetworkDHCPReservations(networkName string) ([]api.NetworkDHCPReservation, error)
+	GetNetworkDHCPReservation(networkName string, hwaddr string) (reservation *api.NetworkDHCPReservation, ETag string, err error)
+	CreateNetworkDHCPReservation(networkName string, reservation api.NetworkDHCPReservationsPost) error
+	UpdateNetworkDHCPReservation(networkName string, hwaddr string, reservation api.NetworkDHCPReservationPut, ETag string) (err error)
+	DeleteNetworkDHCPReservation(networkName string, hwaddr string) (err error)
+
 	// Network load balancer functions ("network_load_balancer" API extension)
 	GetNetworkLoadBalancerAddresses(networkName string) ([]string, error)
 	GetNetworkLoadBalancers(networkName string) ([]api.NetworkLoadBalancer, error)
 	GetNetworkLoadBalancer(networkName string, listenAddress string) (forward *api.NetworkLoadBalancer, ETag string, err error)
+	GetNetworkLoadBalancerState(networkName string, listenAddress string) (state *api.NetworkLoadBalancerState, err error)
 	CreateNetworkLoadBalancer(networkName string, forward api.NetworkLoadBalancersPost) error
 	UpdateNetworkLoadBalancer(networkName string, listenAddress string, forward api.NetworkLoadBalancerPut, ETag string) (err error)
 	DeleteNetworkLoadBalancer(networkName string, listenAddress string) (err error)
@@ -224,12 +297,18 @@ type InstanceServer interface {
 	UpdateNetworkPeer(networkName string, peerName string, peer api.NetworkPeerPut, ETag string) (err error)
 	DeleteNetworkPeer(networkName string, peerName string) (err error)
 
+	// Network peer request functions ("network_peer_requests" API extension)
+	GetNetworkPeerRequests(networkName string) ([]api.NetworkPeerRequest, error)
+	ApproveNetworkPeerRequest(networkName string, sourceProject string, sourceNetwork string, peer api.NetworkPeerRequestPost) (err error)
+	RejectNetworkPeerRequest(networkName string, sourceProject string, sourceNetwork string) (err error)
+
 	// Network ACL functions ("network_acl" API extension)
 	GetNetworkACLNames() (names []string, err error)
 	GetNetworkACLs() (acls []api.NetworkACL, err error)
 	GetNetworkACLsAllProjects() (acls []api.NetworkACL, err error)
 	GetNetworkACL(name string) (acl *api.NetworkACL, ETag string, err error)
 	GetNetworkACLLogfile(name string) (log io.ReadCloser, err error)
+	GetNetworkACLEffective(name string, instanceName string, deviceName string) (effective []api.NetworkACLEffective, err error)
 	CreateNetworkACL(acl api.NetworkACLsPost) (err error)
 	UpdateNetworkACL(name string, acl api.NetworkACLPut, ETag string) (err error)
 	RenameNetworkACL(name string, acl api.NetworkACLPost) (err error)
@@ -279,6 +358,7 @@ type InstanceServer interface {
 	GetProfileNames() (names []string, err error)
 	GetProfiles() (profiles []api.Profile, err error)
 	GetProfile(name string) (profile *api.Profile, ETag string, err error)
+	GetProfilePreview(name string, profile api.ProfilePut) (preview *api.ProfilePreview, err error)
 	CreateProfile(profile api.ProfilesPost) (err error)
 	UpdateProfile(name string, profile api.ProfilePut, ETag string) (err error)
 	RenameProfile(name string, profile api.ProfilePost) (err error)
@@ -289,18 +369,24 @@ type InstanceServer interface {
 	GetProjects() (projects []api.Project, err error)
 	GetProject(name string) (project *api.Project, ETag string, err error)
 	GetProjectState(name string) (project *api.ProjectState, err error)
+	GetProjectNetworkUsage(name string, periods ...string) (usages []api.ProjectNetworkUsage, err error)
+	GetProjectCleanup(name string) (cleanup *api.ProjectCleanup, err error)
 	GetProjectAccess(name string) (access api.Access, err error)
 	CreateProject(project api.ProjectsPost) (err error)
 	UpdateProject(name string, project api.ProjectPut, ETag string) (err error)
 	RenameProject(name string, project api.ProjectPost) (op Operation, err error)
 	DeleteProject(name string) (err error)
 	DeleteProjectForce(name string) (err error)
+	CreateProjectSnapshots(name string, snapshot api.ProjectSnapshotsPost) (op Operation, err error)
 
 	// Storage pool functions ("storage" API extension)
 	GetStoragePoolNames() (names []string, err error)
 	GetStoragePools() (pools []api.StoragePool, err error)
 	GetStoragePool(name string) (pool *api.StoragePool, ETag string, err error)
 	GetStoragePoolResources(name string) (resources *api.ResourcesStoragePool, err error)
+	BenchmarkStoragePool(name string) (op Operation, err error)
+	GetStoragePoolKey(name string) (key *api.StoragePoolKey, err error)
+	UpdateStoragePoolKey(name string, key api.StoragePoolKeyPost) (err error)
 	CreateStoragePool(pool api.StoragePoolsPost) (err error)
 	UpdateStoragePool(name string, pool api.StoragePoolPut, ETag string) (err error)
 	DeleteStoragePool(name string) (err error)
@@ -377,6 +463,8 @@ type InstanceServer interface {
 	CreateClusterMember(member api.ClusterMembersPost) (op Operation, err error)
 	UpdateClusterCertificate(certs api.ClusterCertificatePut, ETag string) (err error)
 	GetClusterMemberState(name string) (*api.ClusterMemberState, string, error)
+	GetClusterMemberConfig(name string) (config map[string]string, err error)
+	GetClusterMemberConfigDiff(name string) (diffs []api.ClusterMemberConfigDiff, err error)
 	UpdateClusterMemberState(name string, state api.ClusterMemberStatePost) (op Operation, err error)
 	GetClusterGroups() ([]api.ClusterGroup, error)
 	GetClusterGroupNames() ([]string, error)
@@ -605,6 +693,11 @@ type InstanceConsoleArgs struct {
 // The InstanceConsoleLogArgs struct is used to pass additional options during a
 // instance console log request.
 type InstanceConsoleLogArgs struct {
+	// Whether to use the persistent console history rather than the current buffer (requires "console_log_history")
+	History bool
+
+	// Whether to get a PNG screenshot of the graphical output rather than the log (requires "instance_console_screenshot")
+	Screenshot bool
 }
 
 // The InstanceExecArgs struct is used to pass additional options during instance exec.
@@ -0,0 +1,154 @@
+package incus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// RetryPolicy defines how requests failing because of a transient error are retried.
+//
+// Only requests using a safe method (GET, HEAD and OPTIONS) are retried, as those can't have side effects
+// on the server. A request is retried when the connection to the server failed or was reset, when a proxy
+// reported the server as unavailable (502, 503 and 504) or when the cluster database was unavailable,
+// for example during a change of leader.
+type RetryPolicy struct {
+	// Maximum number of retries of a request (0 for the default of 3)
+	MaxRetries int
+
+	// Delay before the first retry, doubled after each retry (0 for the default of 1s)
+	InitialBackoff time.Duration
+
+	// Maximum delay between retries (0 for the default of 30s)
+	MaxBackoff time.Duration
+}
+
+// retryMethods are the HTTP methods of the requests which can be safely retried.
+var retryMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+
+// retryStatusCodes are the HTTP status codes indicating a transient failure.
+var retryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// retryDatabaseErrors are the errors returned by the server when the cluster database is temporarily unavailable.
+var retryDatabaseErrors = []string{
+	"database is locked",
+	"bad connection",
+	"not leader",
+	"leadership lost",
+	"no available dqlite leader server found",
+}
+
+// do sends the request using the send function, retrying it according to the policy.
+func (p *RetryPolicy) do(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	// Only retry requests without side effects and whose body can be sent again.
+	if !slices.Contains(retryMethods, req.Method) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return send(req)
+	}
+
+	maxRetries := p.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 3
+	}
+
+	backoff := p.InitialBackoff
+	if backoff == 0 {
+		backoff = time.Second
+	}
+
+	maxBackoff := p.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	for retry := 0; ; retry++ {
+		resp, err := send(req)
+		if retry >= maxRetries || !retryableResponse(resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		logger.Debug("Retrying request after transient failure", logger.Ctx{"method": req.Method, "url": req.URL.String(), "retry": retry + 1, "err": err})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryableResponse returns whether the request failed with a transient error and can be retried.
+// The body of server errors is buffered in order to be inspected.
+func retryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return retryableError(err)
+	}
+
+	if slices.Contains(retryStatusCodes, resp.StatusCode) {
+		return true
+	}
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		return false
+	}
+
+	// Put the inspected start of the body back in front of the rest of it.
+	body := resp.Body
+	data, err := io.ReadAll(io.LimitReader(body, 1024*1024))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), body), body}
+
+	if err != nil {
+		return false
+	}
+
+	response := api.Response{}
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(retryDatabaseErrors, func(msg string) bool {
+		return strings.Contains(response.Error, msg)
+	})
+}
+
+// retryableError returns whether the error sending a request is transient.
+func retryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,280 @@
+package incus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// testRetryServer starts a server on a unix socket whose responses are decided by the respond function,
+// based on the number of the request (starting at 1). It returns a client using the retry policy.
+func testRetryServer(t *testing.T, policy *RetryPolicy, respond func(w http.ResponseWriter, r *http.Request, count int)) (InstanceServer, *atomic.Int32) {
+	t.Helper()
+
+	count := &atomic.Int32{}
+
+	socketPath := filepath.Join(t.TempDir(), "unix.socket")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Failed to listen on %q: %v", socketPath, err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respond(w, r, int(count.Add(1)))
+	}))
+
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	client, err := ConnectIncusUnix(socketPath, &ConnectionArgs{SkipGetServer: true, RetryPolicy: policy})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	t.Cleanup(client.Disconnect)
+
+	return client, count
+}
+
+// testRetryRespond writes an API response with the given status code and error.
+func testRetryRespond(w http.ResponseWriter, code int, errorMessage string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if code == http.StatusOK {
+		_ = json.NewEncoder(w).Encode(api.ResponseRaw{Type: api.SyncResponse, Status: "Success", StatusCode: http.StatusOK, Metadata: []string{"/1.0/projects/default"}})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(api.ResponseRaw{Type: api.ErrorResponse, Code: code, Error: errorMessage})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		failures      int
+		code          int
+		errorMessage  string
+		expectedCount int
+		expectErr     bool
+	}{
+		{"no failure", 0, 0, "", 1, false},
+		{"unavailable", 2, http.StatusServiceUnavailable, "", 3, false},
+		{"bad gateway", 1, http.StatusBadGateway, "", 2, false},
+		{"database locked", 1, http.StatusInternalServerError, "Failed to fetch projects: database is locked", 2, false},
+		{"leader change", 1, http.StatusInternalServerError, "Failed to begin transaction: not leader", 2, false},
+		{"other server error", 1, http.StatusInternalServerError, "Failed to fetch projects: no such table", 1, true},
+		{"not found", 1, http.StatusNotFound, "Not found", 1, true},
+		{"too many failures", 5, http.StatusServiceUnavailable, "", 3, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}
+			client, count := testRetryServer(t, policy, func(w http.ResponseWriter, r *http.Request, count int) {
+				if count <= test.failures {
+					testRetryRespond(w, test.code, test.errorMessage)
+					return
+				}
+
+				testRetryRespond(w, http.StatusOK, "")
+			})
+
+			names, err := client.GetProjectNames()
+			if test.expectErr {
+				if err == nil {
+					t.Error("Expected error, received nil")
+				}
+			} else if err != nil || len(names) != 1 || names[0] != "default" {
+				t.Errorf("Expected the default project, got %v (%v)", names, err)
+			}
+
+			if int(count.Load()) != test.expectedCount {
+				t.Errorf("Expected %d requests, got %d", test.expectedCount, count.Load())
+			}
+		})
+	}
+}
+
+func TestRetryPolicyUnsafeMethod(t *testing.T) {
+	client, count := testRetryServer(t, &RetryPolicy{InitialBackoff: time.Millisecond}, func(w http.ResponseWriter, r *http.Request, count int) {
+		testRetryRespond(w, http.StatusServiceUnavailable, "")
+	})
+
+	err := client.CreateProject(api.ProjectsPost{Name: "foo"})
+	if err == nil {
+		t.Error("Expected error, received nil")
+	}
+
+	if count.Load() != 1 {
+		t.Errorf("Expected a single POST request, got %d", count.Load())
+	}
+}
+
+func TestRetryPolicyDisabled(t *testing.T) {
+	client, count := testRetryServer(t, nil, func(w http.ResponseWriter, r *http.Request, count int) {
+		testRetryRespond(w, http.StatusServiceUnavailable, "")
+	})
+
+	_, err := client.GetProjectNames()
+	if err == nil {
+		t.Error("Expected error, received nil")
+	}
+
+	if count.Load() != 1 {
+		t.Errorf("Expected a single request without a retry policy, got %d", count.Load())
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	var delays []time.Duration
+	last := time.Now()
+
+	send := func(req *http.Request) (*http.Response, error) {
+		now := time.Now()
+		delays = append(delays, now.Sub(last))
+		last = now
+
+		return nil, syscall.ECONNREFUSED
+	}
+
+	policy := &RetryPolicy{MaxRetries: 3, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	req, _ := http.NewRequest(http.MethodGet, "http://unix.socket/1.0", nil)
+
+	_, err := policy.do(req, send)
+	if err == nil {
+		t.Fatal("Expected error, received nil")
+	}
+
+	if len(delays) != 4 {
+		t.Fatalf("Expected 4 attempts, got %d", len(delays))
+	}
+
+	// The delay doubles after each retry, up to the maximum.
+	for i, minDelay := range []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond} {
+		if delays[i+1] < minDelay {
+			t.Errorf("Expected retry %d to wait at least %v, waited %v", i+1, minDelay, delays[i+1])
+		}
+	}
+}
+
+func TestRetryPolicyRequestBody(t *testing.T) {
+	var bodies []string
+
+	send := func(req *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(data))
+
+		if len(bodies) == 1 {
+			return nil, syscall.ECONNRESET
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}
+
+	// Requests with a body which can be sent again are retried with the full body.
+	req, _ := http.NewRequest(http.MethodGet, "http://unix.socket/1.0", strings.NewReader("content"))
+	_, err := (&RetryPolicy{InitialBackoff: time.Millisecond}).do(req, send)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[1] != "content" {
+		t.Errorf("Expected the body to be sent twice, got %q", bodies)
+	}
+
+	// Requests with a body which can't be sent again aren't retried.
+	bodies = nil
+	req, _ = http.NewRequest(http.MethodGet, "http://unix.socket/1.0", io.NopCloser(strings.NewReader("content")))
+	_, err = (&RetryPolicy{InitialBackoff: time.Millisecond}).do(req, send)
+	if err == nil {
+		t.Error("Expected error, received nil")
+	}
+
+	if len(bodies) != 1 {
+		t.Errorf("Expected a single attempt, got %d", len(bodies))
+	}
+}
+
+func TestRetryPolicyContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	send := func(req *http.Request) (*http.Response, error) {
+		attempts++
+		cancel()
+
+		return nil, syscall.ECONNREFUSED
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://unix.socket/1.0", nil)
+	_, err := (&RetryPolicy{InitialBackoff: time.Hour}).do(req, send)
+	if err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetryableResponseKeepsBody(t *testing.T) {
+	// Server errors larger than the inspected size must still be readable in full.
+	message := strings.Repeat("x", 2*1024*1024)
+	data, err := json.Marshal(api.ResponseRaw{Type: api.ErrorResponse, Code: http.StatusInternalServerError, Error: message})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(bytes.NewReader(data))}
+	if retryableResponse(resp, nil) {
+		t.Error("Expected the response not to be retryable")
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || !bytes.Equal(body, data) {
+		t.Errorf("Expected the full body to be preserved (%d bytes), got %d bytes (%v)", len(data), len(body), err)
+	}
+}
+
+func TestRetryableError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{fmt.Errorf("Failed connecting: %w", syscall.ECONNREFUSED), true},
+		{fmt.Errorf("Failed reading: %w", syscall.ECONNRESET), true},
+		{io.ErrUnexpectedEOF, true},
+		{&net.OpError{Op: "dial", Err: timeoutError{}}, true},
+		{context.Canceled, false},
+		{fmt.Errorf("Request failed: %w", context.DeadlineExceeded), false},
+		{fmt.Errorf("Invalid certificate"), false},
+	}
+
+	for _, test := range tests {
+		if retryableError(test.err) != test.expected {
+			t.Errorf("Expected retryable=%v for %q", test.expected, test.err)
+		}
+	}
+}
+
+// timeoutError is a network error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
@@ -18,12 +18,12 @@ import (
 )
 
 // tlsHTTPClient creates an HTTP client with a specified Transport Layer Security (TLS) configuration.
-// It takes in parameters for client certificates, keys, Certificate Authority, server certificates,
-// a boolean for skipping verification, a proxy function, and a transport wrapper function.
-// It returns the HTTP client with the provided configurations and handles any errors that might occur during the setup process.
-func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey string, tlsCA string, tlsServerCert string, insecureSkipVerify bool, proxyFunc func(req *http.Request) (*url.URL, error), transportWrapper func(t *http.Transport) HTTPTransporter) (*http.Client, error) {
+// It takes in the connection arguments providing the client certificates, keys, Certificate Authority,
+// server certificates, a boolean for skipping verification, a proxy function, a transport wrapper function
+// and whether connections should be reused.
+func tlsHTTPClient(args *ConnectionArgs) (*http.Client, error) {
 	// Get the TLS configuration
-	tlsConfig, err := localtls.GetTLSConfigMem(tlsClientCert, tlsClientKey, tlsCA, tlsServerCert, insecureSkipVerify)
+	tlsConfig, err := localtls.GetTLSConfigMem(args.TLSClientCert, args.TLSClientKey, args.TLSCA, args.TLSServerCert, args.InsecureSkipVerify)
 	if err != nil {
 		return nil, err
 	}
@@ -39,10 +39,13 @@ func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey strin
 	}
 
 	// Allow overriding the proxy
-	if proxyFunc != nil {
-		transport.Proxy = proxyFunc
+	if args.Proxy != nil {
+		transport.Proxy = args.Proxy
 	}
 
+	// Keep connections open if requested
+	setupConnectionReuse(transport, args)
+
 	// Special TLS handling
 	transport.DialTLSContext = func(ctx context.Context, network string, addr string) (net.Conn, error) {
 		tlsDial := func(network string, addr string, config *tls.Config, resetName bool) (net.Conn, error) {
@@ -82,22 +85,28 @@ func tlsHTTPClient(client *http.Client, tlsClientCert string, tlsClientKey strin
 			return tlsConn, nil
 		}
 
-		conn, err := tlsDial(network, addr, transport.TLSClientConfig, false)
+		config := transport.TLSClientConfig
+		if ctx.Value(http1OnlyKey{}) != nil {
+			config = http1TLSConfig(config)
+		}
+
+		conn, err := tlsDial(network, addr, config, false)
 		if err != nil {
 			// We may have gotten redirected to a non-Incus machine
-			return tlsDial(network, addr, transport.TLSClientConfig, true)
+			return tlsDial(network, addr, config, true)
 		}
 
 		return conn, nil
 	}
 
 	// Define the http client
+	client := args.HTTPClient
 	if client == nil {
 		client = &http.Client{}
 	}
 
-	if transportWrapper != nil {
-		client.Transport = transportWrapper(transport)
+	if args.TransportWrapper != nil {
+		client.Transport = args.TransportWrapper(transport)
 	} else {
 		client.Transport = transport
 	}
@@ -142,6 +151,9 @@ func unixHTTPClient(args *ConnectionArgs, path string) (*http.Client, error) {
 		TLSHandshakeTimeout:   time.Second * 5,
 	}
 
+	// Keep connections open if requested
+	setupConnectionReuse(transport, args)
+
 	// Define the http client
 	client := args.HTTPClient
 	if client == nil {
@@ -161,6 +173,47 @@ func unixHTTPClient(args *ConnectionArgs, path string) (*http.Client, error) {
 	return client, nil
 }
 
+// http1OnlyKey is set in the context of dials for connections which get upgraded to another protocol
+// and so can't be negotiated as HTTP/2.
+type http1OnlyKey struct{}
+
+// http1TLSConfig returns a copy of the TLS configuration which doesn't offer HTTP/2.
+func http1TLSConfig(config *tls.Config) *tls.Config {
+	if config == nil {
+		return nil
+	}
+
+	config = config.Clone()
+	config.NextProtos = nil
+
+	return config
+}
+
+// setupConnectionReuse configures the transport to keep connections open and reuse them across requests
+// when requested in the connection arguments. Over TLS, HTTP/2 is used when supported by the server so
+// that concurrent requests are multiplexed over the same connection.
+func setupConnectionReuse(transport *http.Transport, args *ConnectionArgs) {
+	if !args.ReuseConnections {
+		return
+	}
+
+	transport.DisableKeepAlives = false
+	transport.MaxConnsPerHost = args.MaxConnections
+	transport.MaxIdleConnsPerHost = args.MaxIdleConnections
+	transport.IdleConnTimeout = args.IdleConnectionTimeout
+
+	if transport.IdleConnTimeout == 0 {
+		transport.IdleConnTimeout = 90 * time.Second
+	}
+
+	if transport.TLSClientConfig != nil {
+		transport.ForceAttemptHTTP2 = true
+
+		// Allow the connections which can't be reused (websockets) to resume the TLS session.
+		transport.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+	}
+}
+
 // remoteOperationResult used for storing the error that occurred for a particular remote URL.
 type remoteOperationResult struct {
 	URL   string
@@ -7,11 +7,11 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
 
 	"github.com/mdlayher/vsock"
 
 	"github.com/lxc/incus/v6/client"
-	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/ports"
 	"github.com/lxc/incus/v6/internal/server/response"
 	localvsock "github.com/lxc/incus/v6/internal/server/vsock"
@@ -29,6 +29,7 @@ var api10Cmd = APIEndpoint{
 
 var api10 = []APIEndpoint{
 	api10Cmd,
+	attestationCmd,
 	execCmd,
 	eventsCmd,
 	metricsCmd,
@@ -38,6 +39,8 @@ var api10 = []APIEndpoint{
 	operationWait,
 	sftpCmd,
 	stateCmd,
+	usersCmd,
+	userCmd,
 }
 
 func api10Get(d *Daemon, r *http.Request) response.Response {
@@ -50,7 +53,7 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 		AuthMethods:   []string{api.AuthenticationMethodTLS},
 	}
 
-	uname, err := linux.Uname()
+	kernel, kernelVersion, kernelArchitecture, err := osUname()
 	if err != nil {
 		return response.InternalError(err)
 	}
@@ -61,9 +64,9 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 	}
 
 	env := api.ServerEnvironment{
-		Kernel:             uname.Sysname,
-		KernelArchitecture: uname.Machine,
-		KernelVersion:      uname.Release,
+		Kernel:             kernel,
+		KernelArchitecture: kernelArchitecture,
+		KernelVersion:      kernelVersion,
 		Server:             "incus-agent",
 		ServerPid:          os.Getpid(),
 		ServerVersion:      version.Version,
@@ -73,7 +76,17 @@ func api10Get(d *Daemon, r *http.Request) response.Response {
 	fullSrv := api.Server{ServerUntrusted: srv}
 	fullSrv.Environment = env
 
-	return response.SyncResponseETag(true, fullSrv, fullSrv)
+	resp := agentAPI.API10Get{
+		Server:   fullSrv,
+		Features: osFeatures,
+	}
+
+	return response.SyncResponseETag(true, resp, resp)
+}
+
+// hasFeature returns whether the agent supports the given feature on this operating system.
+func hasFeature(name string) bool {
+	return slices.Contains(osFeatures, name)
 }
 
 func setConnectionInfo(d *Daemon, rd io.Reader) error {
@@ -130,6 +143,10 @@ func api10Put(d *Daemon, r *http.Request) response.Response {
 }
 
 func startDevIncusServer(d *Daemon) error {
+	if !hasFeature(agentAPI.FeatureDevIncus) {
+		return nil
+	}
+
 	d.DevIncusMu.Lock()
 	defer d.DevIncusMu.Unlock()
 
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/response"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// tsmReportPath is the configfs-tsm interface used to request attestation reports from the guest firmware.
+const tsmReportPath = "/sys/kernel/config/tsm/report"
+
+var attestationCmd = APIEndpoint{
+	Name: "attestation",
+	Path: "attestation",
+
+	Post: APIEndpointAction{Handler: attestationPost},
+}
+
+func attestationPost(d *Daemon, r *http.Request) response.Response {
+	req := agentAPI.AttestationReportPost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if len(req.Nonce) > 64 {
+		return response.BadRequest(fmt.Errorf("Nonce can't be longer than 64 bytes"))
+	}
+
+	if !util.PathExists(tsmReportPath) {
+		return response.NotImplemented(fmt.Errorf("Attestation reports aren't supported by this guest"))
+	}
+
+	report, err := attestationReport(req.Nonce)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, report)
+}
+
+// attestationReport requests a new attestation report through configfs-tsm.
+func attestationReport(nonce []byte) (*agentAPI.AttestationReport, error) {
+	// The report data is always 64 bytes, pad the nonce with zeroes.
+	inblob := make([]byte, 64)
+	copy(inblob, nonce)
+
+	reportPath, err := os.MkdirTemp(tsmReportPath, "incus-")
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating attestation report entry: %w", err)
+	}
+
+	defer func() { _ = os.Remove(reportPath) }()
+
+	err = os.WriteFile(filepath.Join(reportPath, "inblob"), inblob, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed writing attestation report data: %w", err)
+	}
+
+	provider, err := os.ReadFile(filepath.Join(reportPath, "provider"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading attestation provider: %w", err)
+	}
+
+	outblob, err := os.ReadFile(filepath.Join(reportPath, "outblob"))
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating attestation report: %w", err)
+	}
+
+	return &agentAPI.AttestationReport{
+		Provider: strings.TrimSpace(string(provider)),
+		Report:   outblob,
+	}, nil
+}
@@ -19,12 +19,12 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/lxc/incus/v6/internal/jmap"
-	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/response"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/api"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/ws"
@@ -59,6 +59,10 @@ func execPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Websockets are required for VM exec"))
 	}
 
+	if post.Interactive && !hasFeature(agentAPI.FeatureExecInteractive) {
+		return response.NotImplemented(fmt.Errorf("Interactive exec isn't supported on this operating system"))
+	}
+
 	env := map[string]string{}
 
 	if post.Environment != nil {
@@ -258,7 +262,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 	if s.interactive {
 		ttys = make([]*os.File, 1)
 		ptys = make([]*os.File, 1)
-		ptys[0], ttys[0], err = linux.OpenPty(int64(s.uid), int64(s.gid))
+		ptys[0], ttys[0], err = osOpenPty(int64(s.uid), int64(s.gid))
 		if err != nil {
 			return err
 		}
@@ -268,7 +272,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 		stderr = ttys[0]
 
 		if s.width > 0 && s.height > 0 {
-			_ = linux.SetPtySize(int(ptys[0].Fd()), s.width, s.height)
+			_ = osSetPtySize(int(ptys[0].Fd()), s.width, s.height)
 		}
 	} else {
 		ttys = make([]*os.File, 3)
@@ -439,7 +443,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 					continue
 				}
 
-				err = linux.SetPtySize(int(ptys[0].Fd()), winchWidth, winchHeight)
+				err = osSetPtySize(int(ptys[0].Fd()), winchWidth, winchHeight)
 				if err != nil {
 					l.Debug("Failed to set window size", logger.Ctx{"err": err, "width": winchWidth, "height": winchHeight})
 					continue
@@ -468,7 +472,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 			conn := s.conns[0]
 			s.connsLock.Unlock()
 
-			readDone, writeDone := ws.Mirror(conn, linux.NewExecWrapper(waitAttachedChildIsDead, ptys[0]))
+			readDone, writeDone := ws.Mirror(conn, osExecWrapper(waitAttachedChildIsDead, ptys[0]))
 
 			<-readDone
 			<-writeDone
@@ -501,7 +505,7 @@ func (s *execWs) Do(op *operations.Operation) error {
 		}
 	}
 
-	exitStatus, err := linux.ExitStatus(cmd.Wait())
+	exitStatus, err := osExitStatus(cmd.Wait())
 
 	l.Debug("Instance process stopped", logger.Ctx{"err": err, "exitStatus": exitStatus})
 	return finisher(exitStatus, nil)
@@ -16,8 +16,8 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/sys/unix"
 
-	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
@@ -112,20 +112,9 @@ func (c *cmdAgent) Run(cmd *cobra.Command, args []string) error {
 	reconfigureNetworkInterfaces()
 
 	// Load the kernel driver.
-	if !util.PathExists("/dev/vsock") {
-		logger.Info("Loading vsock module")
-
-		err = linux.LoadModule("vsock")
-		if err != nil {
-			return fmt.Errorf("Unable to load the vsock kernel module: %w", err)
-		}
-
-		// Wait for vsock device to appear.
-		for i := 0; i < 5; i++ {
-			if !util.PathExists("/dev/vsock") {
-				time.Sleep(1 * time.Second)
-			}
-		}
+	err = osSetupVsock()
+	if err != nil {
+		return err
 	}
 
 	// Mount shares from host.
@@ -260,6 +249,10 @@ func (c *cmdAgent) writeStatus(status string) error {
 
 // mountHostShares reads the agent-mounts.json file from config share and mounts the shares requested.
 func (c *cmdAgent) mountHostShares() {
+	if !hasFeature(agentAPI.FeatureMounts) {
+		return
+	}
+
 	agentMountsFile := "./agent-mounts.json"
 	if !util.PathExists(agentMountsFile) {
 		return
@@ -306,7 +299,7 @@ func tryMountShared(src string, dst string, fstype string, opts []string) error
 		if err != nil {
 			return fmt.Errorf("Failed to create mount target %q", dst)
 		}
-	} else if linux.IsMountPoint(dst) {
+	} else if osIsMountPoint(dst) {
 		// Already mounted.
 		return nil
 	}
@@ -12,9 +12,9 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/lxc/incus/v6/internal/linux"
 	"github.com/lxc/incus/v6/internal/server/metrics"
 	"github.com/lxc/incus/v6/internal/server/response"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
@@ -31,6 +31,10 @@ var metricsCmd = APIEndpoint{
 }
 
 func metricsGet(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureMetrics) {
+		return response.NotImplemented(nil)
+	}
+
 	out := metrics.Metrics{}
 
 	diskStats, err := getDiskMetrics(d)
@@ -284,20 +288,11 @@ func getFilesystemMetrics(d *Daemon) ([]metrics.FilesystemMetrics, error) {
 
 		stats.Mountpoint = fields[1]
 
-		statfs, err := linux.StatVFS(stats.Mountpoint)
+		stats.FSType, stats.SizeBytes, stats.FreeBytes, stats.AvailableBytes, err = osStatFS(stats.Mountpoint)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to stat %s: %w", stats.Mountpoint, err)
 		}
 
-		fsType, err := linux.FSTypeToName(int32(statfs.Type))
-		if err == nil {
-			stats.FSType = fsType
-		}
-
-		stats.AvailableBytes = statfs.Bavail * uint64(statfs.Bsize)
-		stats.FreeBytes = statfs.Bfree * uint64(statfs.Bsize)
-		stats.SizeBytes = statfs.Blocks * uint64(statfs.Bsize)
-
 		stats.Device = fields[0]
 
 		out = append(out, stats)
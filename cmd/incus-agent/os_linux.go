@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/linux"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// osFeatures lists the agent features supported on this operating system.
+var osFeatures = []string{
+	agentAPI.FeatureExec,
+	agentAPI.FeatureExecInteractive,
+	agentAPI.FeatureFiles,
+	agentAPI.FeatureState,
+	agentAPI.FeatureMetrics,
+	agentAPI.FeatureMounts,
+	agentAPI.FeatureDevIncus,
+	agentAPI.FeatureAttestation,
+	agentAPI.FeatureUsers,
+}
+
+// osUname returns the kernel name, release and architecture.
+func osUname() (string, string, string, error) {
+	uname, err := linux.Uname()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return uname.Sysname, uname.Release, uname.Machine, nil
+}
+
+// osSetupVsock makes sure the vsock device is available.
+func osSetupVsock() error {
+	if util.PathExists("/dev/vsock") {
+		return nil
+	}
+
+	logger.Info("Loading vsock module")
+
+	err := linux.LoadModule("vsock")
+	if err != nil {
+		return fmt.Errorf("Unable to load the vsock kernel module: %w", err)
+	}
+
+	// Wait for vsock device to appear.
+	for i := 0; i < 5; i++ {
+		if !util.PathExists("/dev/vsock") {
+			time.Sleep(1 * time.Second)
+		}
+	}
+
+	return nil
+}
+
+// osOpenPty opens a new PTY owned by the given user and group.
+func osOpenPty(uid int64, gid int64) (*os.File, *os.File, error) {
+	return linux.OpenPty(uid, gid)
+}
+
+// osSetPtySize resizes a PTY.
+func osSetPtySize(fd int, width int, height int) error {
+	return linux.SetPtySize(fd, width, height)
+}
+
+// osExecWrapper wraps a PTY so reads stop once the attached process has ended.
+func osExecWrapper(ctx context.Context, f *os.File) io.ReadWriteCloser {
+	return linux.NewExecWrapper(ctx, f)
+}
+
+// osExitStatus extracts the exit status of a command.
+func osExitStatus(err error) (int, error) {
+	return linux.ExitStatus(err)
+}
+
+// osIsMountPoint returns whether the path is a mount point.
+func osIsMountPoint(path string) bool {
+	return linux.IsMountPoint(path)
+}
+
+// osStatFS returns the filesystem type as well as the size, free and available bytes of a filesystem.
+func osStatFS(path string) (string, uint64, uint64, uint64, error) {
+	statfs, err := linux.StatVFS(path)
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+
+	fsType, _ := linux.FSTypeToName(int32(statfs.Type))
+
+	return fsType, statfs.Blocks * uint64(statfs.Bsize), statfs.Bfree * uint64(statfs.Bsize), statfs.Bavail * uint64(statfs.Bsize), nil
+}
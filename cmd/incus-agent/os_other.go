@@ -0,0 +1,96 @@
+//go:build !linux
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+)
+
+// osFeatures lists the agent features supported on this operating system.
+// Only a subset is available outside of Linux.
+var osFeatures = []string{
+	agentAPI.FeatureExec,
+	agentAPI.FeatureFiles,
+	agentAPI.FeatureState,
+}
+
+// errNotSupported is returned by functions which aren't available on this operating system.
+var errNotSupported = fmt.Errorf("Not supported on this operating system")
+
+// osUname returns the kernel name, release and architecture.
+func osUname() (string, string, string, error) {
+	uname := unix.Utsname{}
+	err := unix.Uname(&uname)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	toString := func(b []byte) string {
+		return strings.TrimRight(string(b), "\x00")
+	}
+
+	return toString(uname.Sysname[:]), toString(uname.Release[:]), toString(uname.Machine[:]), nil
+}
+
+// osSetupVsock makes sure the vsock device is available.
+func osSetupVsock() error {
+	return nil
+}
+
+// osOpenPty opens a new PTY owned by the given user and group.
+func osOpenPty(uid int64, gid int64) (*os.File, *os.File, error) {
+	return nil, nil, errNotSupported
+}
+
+// osSetPtySize resizes a PTY.
+func osSetPtySize(fd int, width int, height int) error {
+	return errNotSupported
+}
+
+// osExecWrapper wraps a PTY so reads stop once the attached process has ended.
+func osExecWrapper(ctx context.Context, f *os.File) io.ReadWriteCloser {
+	return f
+}
+
+// osExitStatus extracts the exit status of a command.
+func osExitStatus(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		status, isWaitStatus := exitErr.Sys().(unix.WaitStatus)
+		if isWaitStatus && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+
+		return exitErr.ExitCode(), nil
+	}
+
+	return -1, err
+}
+
+// osIsMountPoint returns whether the path is a mount point.
+func osIsMountPoint(path string) bool {
+	return false
+}
+
+// osStatFS returns the filesystem type as well as the size, free and available bytes of a filesystem.
+func osStatFS(path string) (string, uint64, uint64, uint64, error) {
+	return "", 0, 0, 0, errNotSupported
+}
+
+// reconfigureNetworkInterfaces applies the NIC configuration provided by the host.
+func reconfigureNetworkInterfaces() {
+}
@@ -0,0 +1,527 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	agentAPI "github.com/lxc/incus/v6/shared/api/agent"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// usersSudoersPath is where the sudo rules of the managed users are written.
+const usersSudoersPath = "/etc/sudoers.d"
+
+// usersUIDMin is the lowest UID of the regular users (root aside) listed by the agent.
+const usersUIDMin = 1000
+
+// usersNameRegex matches the user names which are portable across distributions.
+var usersNameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_.-]{0,31}$`)
+
+var usersCmd = APIEndpoint{
+	Name: "users",
+	Path: "users",
+
+	Get:  APIEndpointAction{Handler: usersGet},
+	Post: APIEndpointAction{Handler: usersPost},
+}
+
+var userCmd = APIEndpoint{
+	Name: "user",
+	Path: "users/{name}",
+
+	Get:    APIEndpointAction{Handler: userGet},
+	Put:    APIEndpointAction{Handler: userPut},
+	Delete: APIEndpointAction{Handler: userDelete},
+}
+
+// passwdEntry is a user of the guest as described in /etc/passwd.
+type passwdEntry struct {
+	name  string
+	uid   int64
+	gid   int64
+	home  string
+	shell string
+}
+
+// usersLoad returns the users of the guest.
+func usersLoad() ([]passwdEntry, error) {
+	f, err := os.Open("/etc/passwd")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	entries := []passwdEntry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) != 7 {
+			continue
+		}
+
+		uid, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		gid, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, passwdEntry{name: fields[0], uid: uid, gid: gid, home: fields[5], shell: fields[6]})
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// userLoad returns the user of the guest with the given name, nil if it doesn't exist.
+func userLoad(name string) (*passwdEntry, error) {
+	entries, err := usersLoad()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.name == name {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// userName returns the validated user name of the request.
+func userName(r *http.Request) (string, error) {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return "", err
+	}
+
+	if !usersNameRegex.MatchString(name) {
+		return "", fmt.Errorf("Invalid user name %q", name)
+	}
+
+	return name, nil
+}
+
+// userSudoersPath returns the path of the sudo rule of the user.
+// Files whose name contain a dot are ignored by sudo, so those are replaced.
+func userSudoersPath(name string) string {
+	return filepath.Join(usersSudoersPath, "incus-"+strings.ReplaceAll(name, ".", "_"))
+}
+
+// userAuthorizedKeysPath returns the authorized keys file of the user.
+func userAuthorizedKeysPath(entry *passwdEntry) string {
+	return filepath.Join(entry.home, ".ssh", "authorized_keys")
+}
+
+// userRender returns the API representation of the user.
+func userRender(entry *passwdEntry) api.InstanceUser {
+	user := api.InstanceUser{
+		Name:  entry.name,
+		UID:   entry.uid,
+		Shell: entry.shell,
+	}
+
+	user.SSHKeys = []string{}
+	user.Sudo = util.PathExists(userSudoersPath(entry.name))
+
+	content, err := userReadAuthorizedKeys(entry)
+	if err != nil {
+		return user
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user.SSHKeys = append(user.SSHKeys, line)
+	}
+
+	return user
+}
+
+// userReadAuthorizedKeys returns the content of the authorized keys file of the user.
+// Links aren't followed as the user controls its home directory.
+func userReadAuthorizedKeys(entry *passwdEntry) ([]byte, error) {
+	homeFd, err := unix.Open(entry.home, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = unix.Close(homeFd) }()
+
+	sshFd, err := unix.Openat(homeFd, ".ssh", unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = unix.Close(sshFd) }()
+
+	fd, err := unix.Openat(sshFd, "authorized_keys", unix.O_RDONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	f := os.NewFile(uintptr(fd), "authorized_keys")
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("%q isn't a regular file", userAuthorizedKeysPath(entry))
+	}
+
+	return io.ReadAll(f)
+}
+
+// userValidate checks the modifiable fields of a user.
+func userValidate(req api.InstanceUserPut) error {
+	if strings.ContainsAny(req.Password, "\r\n") {
+		return fmt.Errorf("Password can't contain line breaks")
+	}
+
+	for _, key := range req.SSHKeys {
+		if strings.TrimSpace(key) == "" || strings.ContainsAny(key, "\r\n") {
+			return fmt.Errorf("Invalid SSH key %q", key)
+		}
+	}
+
+	return nil
+}
+
+// userUpdate applies the modifiable fields to the user.
+func userUpdate(entry *passwdEntry, req api.InstanceUserPut) error {
+	if req.Password != "" {
+		err := subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(entry.name+":"+req.Password+"\n"), nil, "chpasswd")
+		if err != nil {
+			return fmt.Errorf("Failed setting the password: %w", err)
+		}
+	}
+
+	err := userUpdateAuthorizedKeys(entry, req.SSHKeys)
+	if err != nil {
+		return fmt.Errorf("Failed setting the SSH keys: %w", err)
+	}
+
+	sudoersPath := userSudoersPath(entry.name)
+	if !req.Sudo {
+		err := os.Remove(sudoersPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Failed removing the sudo rule: %w", err)
+		}
+
+		return nil
+	}
+
+	if !util.PathExists(usersSudoersPath) {
+		return fmt.Errorf("Sudo isn't available in this guest")
+	}
+
+	// Write the rule aside first as sudo ignores the files containing a dot.
+	err = os.WriteFile(sudoersPath+".tmp", []byte(fmt.Sprintf("%s ALL=(ALL:ALL) NOPASSWD:ALL\n", entry.name)), 0440)
+	if err != nil {
+		return fmt.Errorf("Failed writing the sudo rule: %w", err)
+	}
+
+	err = os.Rename(sudoersPath+".tmp", sudoersPath)
+	if err != nil {
+		_ = os.Remove(sudoersPath + ".tmp")
+		return fmt.Errorf("Failed writing the sudo rule: %w", err)
+	}
+
+	return nil
+}
+
+// userUpdateAuthorizedKeys replaces the authorized keys of the user.
+//
+// The user controls its home directory, so everything below it is accessed relative to the opened
+// directories and without following links.
+func userUpdateAuthorizedKeys(entry *passwdEntry, keys []string) error {
+	homeFd, err := unix.Open(entry.home, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("Failed opening %q: %w", entry.home, err)
+	}
+
+	defer func() { _ = unix.Close(homeFd) }()
+
+	sshFd, err := unix.Openat(homeFd, ".ssh", unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		if len(keys) == 0 {
+			return nil
+		}
+
+		err = unix.Mkdirat(homeFd, ".ssh", 0700)
+		if err != nil {
+			return fmt.Errorf("Failed creating the SSH directory: %w", err)
+		}
+
+		sshFd, err = unix.Openat(homeFd, ".ssh", unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+		if err == nil {
+			err = unix.Fchown(sshFd, int(entry.uid), int(entry.gid))
+			if err != nil {
+				_ = unix.Close(sshFd)
+			}
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed opening the SSH directory: %w", err)
+	}
+
+	defer func() { _ = unix.Close(sshFd) }()
+
+	// Clear any leftover from a previous attempt, removing a link rather than its target.
+	tmpName := ".authorized_keys.tmp"
+	err = unix.Unlinkat(sshFd, tmpName, 0)
+	if err != nil && !errors.Is(err, unix.ENOENT) {
+		return err
+	}
+
+	fd, err := unix.Openat(sshFd, tmpName, unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0600)
+	if err != nil {
+		return err
+	}
+
+	f := os.NewFile(uintptr(fd), tmpName)
+	defer func() { _ = unix.Unlinkat(sshFd, tmpName, 0) }()
+	defer func() { _ = f.Close() }()
+
+	for _, key := range keys {
+		_, err = fmt.Fprintln(f, strings.TrimSpace(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = f.Chown(int(entry.uid), int(entry.gid))
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	// Renaming replaces any link rather than its target.
+	return unix.Renameat(sshFd, tmpName, sshFd, "authorized_keys")
+}
+
+func usersGet(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureUsers) {
+		return response.NotImplemented(nil)
+	}
+
+	entries, err := usersLoad()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	users := []api.InstanceUser{}
+	for _, entry := range entries {
+		if entry.uid != 0 && (entry.uid < usersUIDMin || entry.uid == 65534) {
+			continue
+		}
+
+		users = append(users, userRender(&entry))
+	}
+
+	return response.SyncResponse(true, users)
+}
+
+func usersPost(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureUsers) {
+		return response.NotImplemented(nil)
+	}
+
+	req := api.InstanceUsersPost{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if !usersNameRegex.MatchString(req.Name) {
+		return response.BadRequest(fmt.Errorf("Invalid user name %q", req.Name))
+	}
+
+	if req.Shell != "" && !filepath.IsAbs(req.Shell) {
+		return response.BadRequest(fmt.Errorf("Invalid shell %q", req.Shell))
+	}
+
+	err = userValidate(req.InstanceUserPut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entry, err := userLoad(req.Name)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if entry != nil {
+		return response.Conflict(fmt.Errorf("User %q already exists", req.Name))
+	}
+
+	args := []string{"--create-home"}
+	if req.Shell != "" {
+		args = append(args, "--shell", req.Shell)
+	}
+
+	_, err = subprocess.RunCommand("useradd", append(args, req.Name)...)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed creating user %q: %w", req.Name, err))
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() { _, _ = subprocess.RunCommand("userdel", "--remove", req.Name) })
+
+	entry, err = userLoad(req.Name)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if entry == nil {
+		return response.InternalError(fmt.Errorf("User %q wasn't created", req.Name))
+	}
+
+	err = userUpdate(entry, req.InstanceUserPut)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	revert.Success()
+
+	return response.EmptySyncResponse
+}
+
+func userGet(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureUsers) {
+		return response.NotImplemented(nil)
+	}
+
+	name, err := userName(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entry, err := userLoad(name)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if entry == nil {
+		return response.NotFound(fmt.Errorf("User %q not found", name))
+	}
+
+	return response.SyncResponse(true, userRender(entry))
+}
+
+func userPut(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureUsers) {
+		return response.NotImplemented(nil)
+	}
+
+	name, err := userName(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req := api.InstanceUserPut{}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = userValidate(req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entry, err := userLoad(name)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if entry == nil {
+		return response.NotFound(fmt.Errorf("User %q not found", name))
+	}
+
+	err = userUpdate(entry, req)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+func userDelete(d *Daemon, r *http.Request) response.Response {
+	if !hasFeature(agentAPI.FeatureUsers) {
+		return response.NotImplemented(nil)
+	}
+
+	name, err := userName(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	entry, err := userLoad(name)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	if entry == nil {
+		return response.NotFound(fmt.Errorf("User %q not found", name))
+	}
+
+	if entry.uid == 0 {
+		return response.BadRequest(fmt.Errorf("The root user can't be deleted"))
+	}
+
+	_, err = subprocess.RunCommand("userdel", "--remove", name)
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed deleting user %q: %w", name, err))
+	}
+
+	err = os.Remove(userSudoersPath(name))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return response.InternalError(fmt.Errorf("Failed removing the sudo rule: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
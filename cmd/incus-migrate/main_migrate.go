@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -49,6 +50,15 @@ func (c *cmdMigrate) Command() *cobra.Command {
   additional mount you list, then transfer this through the migration
   API to create a new instance from it.
 
+  Virtual machines can also be created from disk images in any format
+  supported by qemu-img (such as qcow2 or VMDK) and from OVF/OVA exports,
+  whose CPU, memory, firmware and network interfaces are carried over.
+
+  Windows systems get the virtio drivers injected (using virt-v2v-in-place)
+  so they can boot on the virtual hardware of the instance. Live Windows
+  systems should first be exported to a VHDX disk image using a VSS aware
+  tool (such as Disk2vhd) to get a consistent copy.
+
   The same set of options as ` + "`incus launch`" + ` are also supported.
 `
 	cmd.RunE = c.Run
@@ -62,6 +72,25 @@ type cmdMigrateData struct {
 	Mounts       []string
 	InstanceArgs api.InstancesPost
 	Project      string
+
+	workDir   string // Temporary directory holding extracted and converted disk images.
+	nics      int    // Number of network interfaces of the imported virtual machine.
+	virtioWin string // Path to the virtio drivers to inject into Windows virtual machines.
+	rootBus   string // Bus of the root disk of the virtual machine.
+}
+
+// tempDir returns the temporary directory holding extracted and converted disk images, creating it if needed.
+func (c *cmdMigrateData) tempDir() (string, error) {
+	if c.workDir == "" {
+		dir, err := os.MkdirTemp("", "incus-migrate_import_")
+		if err != nil {
+			return "", err
+		}
+
+		c.workDir = dir
+	}
+
+	return c.workDir, nil
 }
 
 func (c *cmdMigrateData) Render() string {
@@ -332,7 +361,7 @@ func (c *cmdMigrate) RunInteractive(server incus.InstanceServer) (cmdMigrateData
 
 	// Provide source path
 	if config.InstanceArgs.Type == api.InstanceTypeVM {
-		question = "Please provide the path to a disk, partition, or raw image file: "
+		question = "Please provide the path to a disk, partition, disk image file or OVF/OVA export: "
 	} else {
 		question = "Please provide the path to a root filesystem: "
 	}
@@ -354,10 +383,21 @@ func (c *cmdMigrate) RunInteractive(server incus.InstanceServer) (cmdMigrateData
 	}
 
 	if config.InstanceArgs.Type == api.InstanceTypeVM {
+		ovf, err := c.importOVF(&config)
+		if err != nil {
+			return cmdMigrateData{}, err
+		}
+
+		// Default to the firmware of imported virtual machines.
+		defaultUEFI := "yes"
+		if ovf != nil && !ovf.EFI {
+			defaultUEFI = "no"
+		}
+
 		architectureName, _ := osarch.ArchitectureGetLocal()
 
 		if slices.Contains([]string{"x86_64", "aarch64"}, architectureName) {
-			hasUEFI, err := c.global.asker.AskBool("Does the VM support UEFI booting? [default=yes]: ", "yes")
+			hasUEFI, err := c.global.asker.AskBool(fmt.Sprintf("Does the VM support UEFI booting? [default=%s]: ", defaultUEFI), defaultUEFI)
 			if err != nil {
 				return cmdMigrateData{}, err
 			}
@@ -376,6 +416,11 @@ func (c *cmdMigrate) RunInteractive(server incus.InstanceServer) (cmdMigrateData
 				config.InstanceArgs.Config["security.secureboot"] = "false"
 			}
 		}
+
+		err = c.askWindows(server, &config)
+		if err != nil {
+			return cmdMigrateData{}, err
+		}
 	}
 
 	var mounts []string
@@ -494,10 +539,37 @@ func (c *cmdMigrate) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	config, err := c.RunInteractive(server)
+
+	defer func() {
+		if config.workDir != "" {
+			_ = os.RemoveAll(config.workDir)
+		}
+	}()
+
 	if err != nil {
 		return err
 	}
 
+	// Convert disk images to the raw format.
+	if config.InstanceArgs.Type == api.InstanceTypeVM {
+		workDir, err := config.tempDir()
+		if err != nil {
+			return err
+		}
+
+		config.SourcePath, err = convertDisk(config.SourcePath, workDir)
+		if err != nil {
+			return err
+		}
+
+		if config.virtioWin != "" {
+			config.SourcePath, err = injectVirtioDrivers(config.SourcePath, workDir, config.virtioWin)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if config.Project != "" {
 		server = server.UseProject(config.Project)
 	}
@@ -692,6 +764,10 @@ func (c *cmdMigrate) askStorage(server incus.InstanceServer, config *cmdMigrateD
 		"path": "/",
 	}
 
+	if config.rootBus != "" {
+		config.InstanceArgs.Devices["root"]["io.bus"] = config.rootBus
+	}
+
 	changeStorageSize, err := c.global.asker.AskBool("Do you want to change the storage size? [default=no]: ", "no")
 	if err != nil {
 		return err
@@ -730,5 +806,119 @@ func (c *cmdMigrate) askNetwork(server incus.InstanceServer, config *cmdMigrateD
 		"name":    "eth0",
 	}
 
+	// Connect the additional network interfaces of imported virtual machines to the same network.
+	for i := 1; i < config.nics; i++ {
+		name := fmt.Sprintf("eth%d", i)
+
+		config.InstanceArgs.Devices[name] = map[string]string{
+			"type":    "nic",
+			"nictype": "bridged",
+			"parent":  network,
+			"name":    name,
+		}
+	}
+
 	return nil
 }
+
+// askWindows sets up the migration of Windows virtual machines, either injecting the virtio drivers into the
+// disk or falling back to the NVMe bus for the root disk when the drivers can't be injected.
+func (c *cmdMigrate) askWindows(server incus.InstanceServer, config *cmdMigrateData) error {
+	isWindows, err := c.global.asker.AskBool("Is the VM running Windows? [default=no]: ", "no")
+	if err != nil {
+		return err
+	}
+
+	if !isWindows {
+		return nil
+	}
+
+	config.InstanceArgs.Config["image.os"] = "Windows"
+
+	_, errV2V := exec.LookPath("virt-v2v-in-place")
+	_, errQemuImg := exec.LookPath("qemu-img")
+	if errV2V == nil && errQemuImg == nil {
+		injectDrivers, err := c.global.asker.AskBool("Do you want to inject the virtio drivers? [default=yes]: ", "yes")
+		if err != nil {
+			return err
+		}
+
+		if injectDrivers {
+			config.virtioWin, err = c.global.asker.AskString(fmt.Sprintf("Please provide the path to the virtio-win drivers (ISO or directory) [default=%s]: ", windowsVirtioDefaultPath), windowsVirtioDefaultPath, func(s string) error {
+				if !util.PathExists(s) {
+					return errors.New("Path does not exist")
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			return nil
+		}
+	} else {
+		fmt.Println("The virtio drivers can't be injected as virt-v2v-in-place or qemu-img are missing")
+	}
+
+	// Windows ships with NVMe drivers, so it can boot without the virtio drivers.
+	fmt.Println("The root disk will use the NVMe bus, the virtio drivers should be installed after the migration")
+	config.rootBus = "nvme"
+
+	return c.askStorage(server, config)
+}
+
+// importOVF maps the hardware of a virtual machine exported as an OVF descriptor or OVA archive to the instance
+// config, and points the source path to its first disk image.
+func (c *cmdMigrate) importOVF(config *cmdMigrateData) (*ovfInfo, error) {
+	ext := strings.ToLower(filepath.Ext(config.SourcePath))
+	if ext != ".ova" && ext != ".ovf" {
+		return nil, nil
+	}
+
+	_, err := exec.LookPath("qemu-img")
+	if err != nil {
+		return nil, errors.New("The qemu-img tool is required to import OVF exports")
+	}
+
+	descriptor := config.SourcePath
+	if ext == ".ova" {
+		workDir, err := config.tempDir()
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Printf("Extracting OVA archive %q\n", config.SourcePath)
+
+		descriptor, err = extractOVA(config.SourcePath, workDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := parseOVF(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.CPUs > 0 {
+		config.InstanceArgs.Config["limits.cpu"] = strconv.FormatInt(info.CPUs, 10)
+	}
+
+	if info.Memory > 0 {
+		config.InstanceArgs.Config["limits.memory"] = fmt.Sprintf("%dMiB", info.Memory>>20)
+	}
+
+	config.nics = info.NICs
+	if config.nics > 1 {
+		fmt.Printf("The virtual machine has %d network interfaces, change the instance network to connect them all\n", config.nics)
+	}
+
+	if len(info.Disks) > 1 {
+		fmt.Printf("Only the first disk of the virtual machine is imported, skipping %d additional disks\n", len(info.Disks)-1)
+	}
+
+	config.SourcePath = info.Disks[0]
+
+	return info, nil
+}
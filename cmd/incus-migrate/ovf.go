@@ -0,0 +1,258 @@
+package main
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+// OVF hardware resource types (CIM_ResourceAllocationSettingData).
+const (
+	ovfResourceCPU      = 3
+	ovfResourceMemory   = 4
+	ovfResourceEthernet = 10
+	ovfResourceDisk     = 17
+)
+
+// ovfEnvelope is the subset of an OVF descriptor used to map a virtual machine to an instance.
+type ovfEnvelope struct {
+	Files []struct {
+		ID   string `xml:"id,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"References>File"`
+
+	Disks []struct {
+		DiskID  string `xml:"diskId,attr"`
+		FileRef string `xml:"fileRef,attr"`
+	} `xml:"DiskSection>Disk"`
+
+	Items []struct {
+		ResourceType    int    `xml:"ResourceType"`
+		VirtualQuantity int64  `xml:"VirtualQuantity"`
+		AllocationUnits string `xml:"AllocationUnits"`
+		HostResource    string `xml:"HostResource"`
+	} `xml:"VirtualSystem>VirtualHardwareSection>Item"`
+
+	Config []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:"value,attr"`
+	} `xml:"VirtualSystem>VirtualHardwareSection>Config"`
+}
+
+// ovfInfo is the hardware of a virtual machine described by an OVF descriptor.
+type ovfInfo struct {
+	CPUs   int64
+	Memory int64 // In bytes.
+	NICs   int
+	Disks  []string // Paths of the disk images, in the order of the virtual hardware.
+	EFI    bool
+}
+
+// ovfAllocationUnitsRegex matches the programmatic units of the OVF specification (for example "byte * 2^20").
+var ovfAllocationUnitsRegex = regexp.MustCompile(`^byte\s*\*\s*2\^(\d+)$`)
+
+// ovfAllocationUnits returns the size in bytes of the OVF allocation units, defaulting to megabytes.
+func ovfAllocationUnits(units string) (int64, error) {
+	units = strings.TrimSpace(units)
+
+	match := ovfAllocationUnitsRegex.FindStringSubmatch(units)
+	if match != nil {
+		exponent, err := strconv.Atoi(match[1])
+		if err != nil || exponent > 62 {
+			return -1, fmt.Errorf("Invalid allocation units %q", units)
+		}
+
+		return 1 << exponent, nil
+	}
+
+	switch strings.ToLower(units) {
+	case "", "megabytes", "mb":
+		return 1 << 20, nil
+	case "kilobytes", "kb":
+		return 1 << 10, nil
+	case "gigabytes", "gb":
+		return 1 << 30, nil
+	case "byte", "bytes":
+		return 1, nil
+	}
+
+	return -1, fmt.Errorf("Unsupported allocation units %q", units)
+}
+
+// parseOVF parses the OVF descriptor, resolving the disk images relative to its directory.
+func parseOVF(path string) (*ovfInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	envelope := ovfEnvelope{}
+	err = xml.NewDecoder(f).Decode(&envelope)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing OVF descriptor %q: %w", path, err)
+	}
+
+	files := map[string]string{}
+	for _, file := range envelope.Files {
+		files[file.ID] = file.Href
+	}
+
+	disks := map[string]string{}
+	for _, disk := range envelope.Disks {
+		disks[disk.DiskID] = files[disk.FileRef]
+	}
+
+	info := &ovfInfo{}
+
+	for _, item := range envelope.Items {
+		switch item.ResourceType {
+		case ovfResourceCPU:
+			info.CPUs += item.VirtualQuantity
+		case ovfResourceMemory:
+			units, err := ovfAllocationUnits(item.AllocationUnits)
+			if err != nil {
+				return nil, err
+			}
+
+			info.Memory += item.VirtualQuantity * units
+		case ovfResourceEthernet:
+			info.NICs++
+		case ovfResourceDisk:
+			// Disks are referenced as "ovf:/disk/<id>" (or "/disk/<id>" in older descriptors).
+			diskID := item.HostResource[strings.LastIndex(item.HostResource, "/")+1:]
+
+			href := disks[diskID]
+			if href == "" {
+				return nil, fmt.Errorf("Disk %q isn't described in OVF descriptor %q", item.HostResource, path)
+			}
+
+			// Only accept disk images next to the descriptor.
+			if filepath.IsAbs(href) || strings.Contains(href, "..") {
+				return nil, fmt.Errorf("Invalid disk image reference %q in OVF descriptor %q", href, path)
+			}
+
+			info.Disks = append(info.Disks, filepath.Join(filepath.Dir(path), href))
+		}
+	}
+
+	for _, config := range envelope.Config {
+		if config.Key == "firmware" && config.Value == "efi" {
+			info.EFI = true
+		}
+	}
+
+	if len(info.Disks) == 0 {
+		return nil, fmt.Errorf("No disk found in OVF descriptor %q", path)
+	}
+
+	return info, nil
+}
+
+// extractOVA extracts the OVA archive into the directory and returns the path to its OVF descriptor.
+func extractOVA(path string, dir string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	descriptor := ""
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("Failed reading OVA archive %q: %w", path, err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// OVA archives are flat, only keep the base name of the entries.
+		target := filepath.Join(dir, filepath.Base(hdr.Name))
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return "", err
+		}
+
+		_, err = io.Copy(out, tr)
+		_ = out.Close()
+		if err != nil {
+			return "", fmt.Errorf("Failed extracting %q from OVA archive %q: %w", hdr.Name, path, err)
+		}
+
+		if strings.HasSuffix(strings.ToLower(hdr.Name), ".ovf") {
+			descriptor = target
+		}
+	}
+
+	if descriptor == "" {
+		return "", fmt.Errorf("No OVF descriptor found in OVA archive %q", path)
+	}
+
+	return descriptor, nil
+}
+
+// convertDisk converts a disk image file to the raw format expected by the migration in the directory, returning
+// the path to transfer. Block devices and raw images are used as-is, as are all images when qemu-img is missing.
+func convertDisk(path string, dir string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !fi.Mode().IsRegular() {
+		return path, nil
+	}
+
+	_, err = exec.LookPath("qemu-img")
+	if err != nil {
+		return path, nil
+	}
+
+	out, err := subprocess.RunCommand("qemu-img", "info", "--output=json", path)
+	if err != nil {
+		return "", fmt.Errorf("Failed inspecting disk image %q: %w", path, err)
+	}
+
+	var info struct {
+		Format string `json:"format"`
+	}
+
+	err = json.Unmarshal([]byte(out), &info)
+	if err != nil {
+		return "", fmt.Errorf("Failed parsing disk image information: %w", err)
+	}
+
+	if info.Format == "raw" {
+		return path, nil
+	}
+
+	fmt.Printf("Converting disk image %q from %s to raw format\n", path, info.Format)
+
+	target := filepath.Join(dir, "root.raw")
+	_, err = subprocess.RunCommand("qemu-img", "convert", "-f", info.Format, "-O", "raw", path, target)
+	if err != nil {
+		return "", fmt.Errorf("Failed converting disk image %q: %w", path, err)
+	}
+
+	return target, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/lxc/incus/v6/shared/subprocess"
+)
+
+// windowsVirtioDefaultPath is where distributions install the virtio-win drivers.
+const windowsVirtioDefaultPath = "/usr/share/virtio-win"
+
+// injectVirtioDrivers injects the virtio drivers into the Windows system on the disk, returning the path to
+// transfer. As the drivers are injected in place, disks outside of the directory are first copied into it.
+func injectVirtioDrivers(path string, dir string, virtioWin string) (string, error) {
+	_, err := exec.LookPath("virt-v2v-in-place")
+	if err != nil {
+		return "", errors.New("The virt-v2v-in-place tool is required to inject the virtio drivers")
+	}
+
+	if filepath.Dir(path) != dir {
+		target := filepath.Join(dir, "root.raw")
+
+		fmt.Printf("Copying disk %q\n", path)
+
+		_, err = subprocess.RunCommand("qemu-img", "convert", "-f", "raw", "-O", "raw", path, target)
+		if err != nil {
+			return "", fmt.Errorf("Failed copying disk %q: %w", path, err)
+		}
+
+		path = target
+	}
+
+	fmt.Println("Injecting virtio drivers")
+
+	env := append(os.Environ(), "VIRTIO_WIN="+virtioWin)
+	_, _, err = subprocess.RunCommandSplit(context.TODO(), env, nil, "virt-v2v-in-place", "--block-driver", "virtio-scsi", "-i", "disk", path)
+	if err != nil {
+		return "", fmt.Errorf("Failed injecting virtio drivers into %q: %w", path, err)
+	}
+
+	return path, nil
+}
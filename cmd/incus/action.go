@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"slices"
 	"strings"
 
@@ -30,7 +29,9 @@ func (c *cmdStart) Command() *cobra.Command {
 	cmd.Use = usage("start", i18n.G("[<remote>:]<instance> [[<remote>:]<instance>...]"))
 	cmd.Short = i18n.G("Start instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Start instances`))
+		`Start instances
+
+Passing @<group> instead of an instance name acts on all the instances of an instance group.`))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpInstances(toComplete)
@@ -55,7 +56,9 @@ func (c *cmdPause) Command() *cobra.Command {
 	cmd.Use = usage("pause", i18n.G("[<remote>:]<instance> [[<remote>:]<instance>...]"))
 	cmd.Short = i18n.G("Pause instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Pause instances`))
+		`Pause instances
+
+Passing @<group> instead of an instance name acts on all the instances of an instance group.`))
 	cmd.Aliases = []string{"freeze"}
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -81,7 +84,9 @@ func (c *cmdResume) Command() *cobra.Command {
 	cmd.Use = usage("resume", i18n.G("[<remote>:]<instance> [[<remote>:]<instance>...]"))
 	cmd.Short = i18n.G("Resume instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Resume instances`))
+		`Resume instances
+
+Passing @<group> instead of an instance name acts on all the instances of an instance group.`))
 	cmd.Aliases = []string{"unfreeze"}
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -107,7 +112,9 @@ func (c *cmdRestart) Command() *cobra.Command {
 	cmd.Use = usage("restart", i18n.G("[<remote>:]<instance> [[<remote>:]<instance>...]"))
 	cmd.Short = i18n.G("Restart instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Restart instances`))
+		`Restart instances
+
+Passing @<group> instead of an instance name acts on all the instances of an instance group.`))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpInstances(toComplete)
@@ -132,7 +139,9 @@ func (c *cmdStop) Command() *cobra.Command {
 	cmd.Use = usage("stop", i18n.G("[<remote>:]<instance> [[<remote>:]<instance>...]"))
 	cmd.Short = i18n.G("Stop instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Stop instances`))
+		`Stop instances
+
+Passing @<group> instead of an instance name acts on all the instances of an instance group.`))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpInstances(toComplete)
@@ -144,12 +153,13 @@ func (c *cmdStop) Command() *cobra.Command {
 type cmdAction struct {
 	global *cmdGlobal
 
-	flagAll       bool
-	flagConsole   string
-	flagForce     bool
-	flagStateful  bool
-	flagStateless bool
-	flagTimeout   int
+	flagAll         bool
+	flagConcurrency int
+	flagConsole     string
+	flagForce       bool
+	flagStateful    bool
+	flagStateless   bool
+	flagTimeout     int
 }
 
 // Command is a method of the cmdAction structure which constructs and configures a cobra Command object.
@@ -159,6 +169,7 @@ func (c *cmdAction) Command(action string) *cobra.Command {
 	cmd.RunE = c.Run
 
 	cmd.Flags().BoolVar(&c.flagAll, "all", false, i18n.G("Run against all instances"))
+	cmd.Flags().IntVar(&c.flagConcurrency, "concurrency", 0, i18n.G("Maximum number of instances to process in parallel (0 for no limit)")+"``")
 
 	if action == "stop" {
 		cmd.Flags().BoolVar(&c.flagStateful, "stateful", false, i18n.G("Store the instance state"))
@@ -280,6 +291,46 @@ func (c *cmdAction) doAction(action string, conf *config.Config, nameArg string)
 		return fmt.Errorf(i18n.G("Must supply instance name for: ")+"\"%s\"", nameArg)
 	}
 
+	// Instance groups are handled server side.
+	groupName, isGroup := strings.CutPrefix(name, "@")
+	if isGroup {
+		if c.flagConsole != "" {
+			return fmt.Errorf(i18n.G("--console can't be used with instance groups"))
+		}
+
+		req := api.InstanceStatePut{
+			Action:   action,
+			Timeout:  c.flagTimeout,
+			Force:    c.flagForce,
+			Stateful: state,
+		}
+
+		op, err := d.UpdateInstanceGroupState(groupName, req)
+		if err != nil {
+			return err
+		}
+
+		progress := cli.ProgressRenderer{
+			Quiet: c.global.flagQuiet,
+		}
+
+		_, err = op.AddHandler(progress.UpdateOp)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		err = cli.CancelableWait(op, &progress)
+		if err != nil {
+			progress.Done("")
+			return err
+		}
+
+		progress.Done("")
+
+		return nil
+	}
+
 	if action == "start" {
 		current, _, err := d.GetInstance(name)
 		if err != nil {
@@ -436,32 +487,7 @@ func (c *cmdAction) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Run the action for every listed instance
-	results := runBatch(names, func(name string) error { return c.doAction(cmd.Name(), conf, name) })
+	results := runBatch(names, c.flagConcurrency, func(name string) error { return c.doAction(cmd.Name(), conf, name) })
 
-	// Single instance is easy
-	if len(results) == 1 {
-		return results[0].err
-	}
-
-	// Do fancier rendering for batches
-	success := true
-
-	for _, result := range results {
-		if result.err == nil {
-			continue
-		}
-
-		success = false
-		msg := fmt.Sprintf(i18n.G("error: %v"), result.err)
-		for _, line := range strings.Split(msg, "\n") {
-			fmt.Fprintf(os.Stderr, "%s: %s\n", result.name, line)
-		}
-	}
-
-	if !success {
-		fmt.Fprintln(os.Stderr, "")
-		return fmt.Errorf(i18n.G("Some instances failed to %s"), cmd.Name())
-	}
-
-	return nil
+	return batchError(results, fmt.Errorf(i18n.G("Some instances failed to %s"), cmd.Name()))
 }
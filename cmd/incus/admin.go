@@ -20,6 +20,10 @@ func (c *cmdAdmin) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage incus daemon`))
 
+	// bgp
+	adminBGPCmd := cmdAdminBGP{global: c.global}
+	cmd.AddCommand(adminBGPCmd.Command())
+
 	// cluster
 	adminClusterCmd := cmdAdminCluster{global: c.global}
 	cmd.AddCommand(adminClusterCmd.Command())
@@ -40,6 +44,14 @@ func (c *cmdAdmin) Command() *cobra.Command {
 	sqlCmd := cmdAdminSQL{global: c.global}
 	cmd.AddCommand(sqlCmd.Command())
 
+	// support-bundle sub-command
+	adminSupportBundleCmd := cmdAdminSupportBundle{global: c.global}
+	cmd.AddCommand(adminSupportBundleCmd.Command())
+
+	// sync sub-command
+	adminSyncCmd := cmdAdminSync{global: c.global}
+	cmd.AddCommand(adminSyncCmd.Command())
+
 	// waitready sub-command
 	adminWaitreadyCmd := cmdAdminWaitready{global: c.global}
 	cmd.AddCommand(adminWaitreadyCmd.Command())
@@ -0,0 +1,101 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdAdminBGP struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+func (c *cmdAdminBGP) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("bgp")
+	cmd.Short = i18n.G("Show the state of the BGP server and its peers")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Show the state of the BGP server and its peers
+
+  For each peer, this shows the BGP session state, the number of prefixes
+  advertised to and received from the peer and the BFD session state (if enabled).`))
+	cmd.RunE = c.Run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+func (c *cmdAdminBGP) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 0)
+	if exit {
+		return err
+	}
+
+	// Connect to daemon.
+	d, err := incus.ConnectIncusUnix("", nil)
+	if err != nil {
+		return err
+	}
+
+	status, err := d.GetServerBGPStatus()
+	if err != nil {
+		return err
+	}
+
+	if c.flagFormat == cli.TableFormatTable {
+		if !status.Running {
+			fmt.Println(i18n.G("BGP server: not running"))
+		} else {
+			fmt.Printf(i18n.G("BGP server: listening on %s (ASN %d, router ID %s)")+"\n", status.Address, status.ASN, status.RouterID)
+		}
+
+		if len(status.Peers) == 0 {
+			return nil
+		}
+
+		fmt.Println("")
+	}
+
+	data := [][]string{}
+	for _, peer := range status.Peers {
+		bfdState := peer.BFDState
+		if bfdState == "" {
+			bfdState = "-"
+		}
+
+		established := ""
+		if !peer.EstablishedAt.IsZero() {
+			established = peer.EstablishedAt.Local().Format(dateLayout)
+		}
+
+		data = append(data, []string{
+			peer.Address,
+			fmt.Sprintf("%d", peer.ASN),
+			peer.State,
+			established,
+			fmt.Sprintf("%d", peer.PrefixesAdvertised),
+			fmt.Sprintf("%d/%d", peer.PrefixesAccepted, peer.PrefixesReceived),
+			bfdState,
+		})
+	}
+
+	header := []string{
+		i18n.G("PEER"),
+		i18n.G("ASN"),
+		i18n.G("STATE"),
+		i18n.G("ESTABLISHED"),
+		i18n.G("ADVERTISED"),
+		i18n.G("ACCEPTED/RECEIVED"),
+		i18n.G("BFD"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, status)
+}
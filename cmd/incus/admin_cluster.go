@@ -30,6 +30,20 @@ func (c *cmdAdminCluster) Command() *cobra.Command {
 }
 
 func (c *cmdAdminCluster) Run(cmd *cobra.Command, args []string) {
+	path, env := incusdPath()
+	if path == "" {
+		fmt.Println(i18n.G(`The "cluster" subcommand requires access to internal server data.
+To do so, it's actually part of the "incusd" binary rather than "incus".
+
+You can invoke it through "incusd cluster".`))
+		os.Exit(1)
+	}
+
+	_ = doExec(path, append([]string{"incusd", "cluster"}, args...), env)
+}
+
+// incusdPath returns the path to the incusd binary, if found, along with the environment to run it with.
+func incusdPath() (string, []string) {
 	env := getEnviron()
 	path, _ := exec.LookPath("incusd")
 	if path == "" {
@@ -43,13 +57,5 @@ func (c *cmdAdminCluster) Run(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if path == "" {
-		fmt.Println(i18n.G(`The "cluster" subcommand requires access to internal server data.
-To do so, it's actually part of the "incusd" binary rather than "incus".
-
-You can invoke it through "incusd cluster".`))
-		os.Exit(1)
-	}
-
-	_ = doExec(path, append([]string{"incusd", "cluster"}, args...), env)
+	return path, env
 }
@@ -13,6 +13,23 @@ import (
 )
 
 func (c *cmdAdminInit) RunDump(d incus.InstanceServer) error {
+	// Let the server export its full configuration when supported.
+	if d.HasExtension("preseed_export") {
+		config, err := d.GetServerPreseed()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to retrieve current server configuration: %w"), err)
+		}
+
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to retrieve current server configuration: %w"), err)
+		}
+
+		fmt.Printf("%s\n", out)
+
+		return nil
+	}
+
 	currentServer, _, err := d.GetServer()
 	if err != nil {
 		return fmt.Errorf(i18n.G("Failed to retrieve current server configuration: %w"), err)
@@ -65,11 +82,12 @@ func (c *cmdAdminInit) RunDump(d incus.InstanceServer) error {
 	}
 
 	for _, profile := range profiles {
-		profilesPost := api.ProfilesPost{}
+		profilesPost := api.InitProfileProjectPost{}
 		profilesPost.Config = profile.Config
 		profilesPost.Description = profile.Description
 		profilesPost.Devices = profile.Devices
 		profilesPost.Name = profile.Name
+		profilesPost.Project = api.ProjectDefaultName
 
 		config.Profiles = append(config.Profiles, profilesPost)
 	}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -20,6 +21,8 @@ import (
 
 type cmdAdminRecover struct {
 	global *cmdGlobal
+
+	flagFromDBBackup string
 }
 
 func (c *cmdAdminRecover) Command() *cobra.Command {
@@ -30,9 +33,18 @@ func (c *cmdAdminRecover) Command() *cobra.Command {
 
   This command is mostly used for disaster recovery. It will ask you about unknown storage pools and attempt to
   access them, along with existing storage pools, and identify any missing instances and volumes that exist on the
-  pools but are not in the database. It will then offer to recreate these database records.`))
+  pools but are not in the database. It will then offer to recreate these database records.
+
+  With --from-db-backup, the global database is instead bootstrapped from one of the automatic database
+  backups (or any global database dump). The daemon must be stopped and this server becomes the only
+  database member of the cluster.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin recover --from-db-backup /var/lib/incus/backups/database/global_20240501T000000Z.sql.gz
+    Restore the global database from the specified backup.`))
 	cmd.RunE = c.Run
 
+	cmd.Flags().StringVar(&c.flagFromDBBackup, "from-db-backup", "", i18n.G("Bootstrap the global database from a database backup")+"``")
+
 	return cmd
 }
 
@@ -42,6 +54,10 @@ func (c *cmdAdminRecover) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Invalid arguments"))
 	}
 
+	if c.flagFromDBBackup != "" {
+		return c.recoverFromDBBackup()
+	}
+
 	d, err := incus.ConnectIncusUnix("", nil)
 	if err != nil {
 		return err
@@ -210,6 +226,20 @@ func (c *cmdAdminRecover) Run(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if len(res.UnknownNetworkACLs) > 0 {
+			fmt.Println(i18n.G("The following unknown network ACLs have been found:"))
+			for _, unknownACL := range res.UnknownNetworkACLs {
+				fmt.Printf(" - "+i18n.G("Network ACL %q in project %q")+"\n", unknownACL.Name, unknownACL.Project)
+			}
+		}
+
+		if len(res.UnknownNetworks) > 0 {
+			fmt.Println(i18n.G("The following unknown networks have been found:"))
+			for _, unknownNet := range res.UnknownNetworks {
+				fmt.Printf(" - "+i18n.G("Network %q of type %q in project %q (includes %d forwards and %d load balancers)")+"\n", unknownNet.Name, unknownNet.Type, unknownNet.Project, unknownNet.ForwardCount, unknownNet.LoadBalancerCount)
+			}
+		}
+
 		if len(res.DependencyErrors) > 0 {
 			fmt.Println(i18n.G("You are currently missing the following:"))
 			for _, depErr := range res.DependencyErrors {
@@ -218,7 +248,7 @@ func (c *cmdAdminRecover) Run(cmd *cobra.Command, args []string) error {
 
 			_, _ = c.global.asker.AskString(i18n.G("Please create those missing entries and then hit ENTER:")+" ", "", validate.Optional())
 		} else {
-			if len(unknownPools) == 0 && len(res.UnknownVolumes) == 0 {
+			if len(unknownPools) == 0 && len(res.UnknownVolumes) == 0 && len(res.UnknownNetworks) == 0 && len(res.UnknownNetworkACLs) == 0 {
 				fmt.Println(i18n.G("No unknown storage pools or volumes found. Nothing to do."))
 				return nil
 			}
@@ -252,3 +282,21 @@ func (c *cmdAdminRecover) Run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// recoverFromDBBackup hands over the database restoration to the daemon binary as it needs access to internal server data.
+func (c *cmdAdminRecover) recoverFromDBBackup() error {
+	path, env := incusdPath()
+	if path == "" {
+		return fmt.Errorf(i18n.G(`Recovering from a database backup requires access to internal server data.
+To do so, it's actually part of the "incusd" binary rather than "incus".
+
+You can invoke it through "incusd cluster recover-from-db-backup".`))
+	}
+
+	backupPath, err := filepath.Abs(c.flagFromDBBackup)
+	if err != nil {
+		return err
+	}
+
+	return doExec(path, []string{"incusd", "cluster", "recover-from-db-backup", backupPath}, env)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"slices"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -47,6 +48,11 @@ func (c *cmdAdminSQL) Command() *cobra.Command {
   If <query> is the special value ".schema", the command returns the SQL
   text schema of the given database.
 
+  If <query> is the special value ".stats", the command returns the
+  statistics of the slow queries to the global database made by the
+  targeted server. Slow queries are only recorded when the
+  "core.debug_slow_query_threshold" server configuration key is set.
+
   This internal command is mostly useful for debugging and disaster
   recovery. The development team will occasionally provide hotfixes to users as a
   set of database queries to fix some data inconsistency.`))
@@ -95,6 +101,26 @@ func (c *cmdAdminSQL) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if query == ".stats" {
+		if database != "global" {
+			return fmt.Errorf(i18n.G("Query statistics are only available for the global database"))
+		}
+
+		response, _, err := d.RawQuery("GET", "/internal/sql/stats", nil, "")
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to request query statistics: %w"), err)
+		}
+
+		stats := []internalSQL.SQLQueryStats{}
+		err = json.Unmarshal(response.Metadata, &stats)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to parse query statistics response: %w"), err)
+		}
+
+		sqlPrintQueryStats(stats)
+		return nil
+	}
+
 	if query == ".dump" || query == ".schema" {
 		url := fmt.Sprintf("/internal/sql?database=%s", database)
 		if query == ".schema" {
@@ -167,3 +193,16 @@ func sqlPrintSelectResult(result internalSQL.SQLResult) {
 
 	table.Render()
 }
+
+func sqlPrintQueryStats(stats []internalSQL.SQLQueryStats) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader([]string{i18n.G("PATTERN"), i18n.G("COUNT"), i18n.G("TOTAL TIME"), i18n.G("AVERAGE TIME"), i18n.G("MAX TIME")})
+	for _, s := range stats {
+		table.Append([]string{s.Pattern, fmt.Sprintf("%d", s.Count), s.TotalTime.String(), (s.TotalTime / time.Duration(s.Count)).String(), s.MaxTime.String()})
+	}
+
+	table.Render()
+}
@@ -0,0 +1,111 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdAdminSupportBundle struct {
+	global *cmdGlobal
+
+	flagTarget string
+}
+
+func (c *cmdAdminSupportBundle) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("support-bundle", i18n.G("[<path>]"))
+	cmd.Short = i18n.G("Generate a support bundle")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Generate a support bundle
+
+  This gathers the recent daemon logs, the server configuration (with secrets redacted),
+  the warnings, the versions, the hardware resources and the recently failed operations
+  into a tarball which is then downloaded to the provided path (or the current directory).`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus admin support-bundle
+    Generate a support bundle and save it in the current directory.
+
+incus admin support-bundle --target server01 /tmp/server01.tar.gz
+    Generate a support bundle for cluster member server01 and save it as /tmp/server01.tar.gz.`))
+	cmd.RunE = c.Run
+	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+
+	return cmd
+}
+
+func (c *cmdAdminSupportBundle) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Connect to daemon.
+	var d incus.InstanceServer
+	d, err = incus.ConnectIncusUnix("", nil)
+	if err != nil {
+		return err
+	}
+
+	if c.flagTarget != "" {
+		d = d.UseTarget(c.flagTarget)
+	}
+
+	// Generate the bundle.
+	op, err := d.CreateSupportBundle()
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	name, ok := op.Get().Metadata["name"].(string)
+	if !ok {
+		return fmt.Errorf(i18n.G("Failed getting the name of the support bundle"))
+	}
+
+	// Download the bundle.
+	path := name
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	target, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = target.Close() }()
+
+	_, err = d.GetSupportBundleFile(name, target)
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	err = target.Close()
+	if err != nil {
+		return err
+	}
+
+	// Remove the bundle from the server now that it was retrieved.
+	err = d.DeleteSupportBundle(name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Support bundle saved to %s")+"\n", path)
+	}
+
+	return nil
+}
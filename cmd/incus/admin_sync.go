@@ -0,0 +1,455 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Resources that can be synchronized.
+const (
+	syncProfiles  = "profiles"
+	syncImages    = "images"
+	syncInstances = "instances"
+	syncVolumes   = "volumes"
+)
+
+type cmdAdminSync struct {
+	global *cmdGlobal
+
+	flagInterval string
+	flagMode     string
+	flagSkip     []string
+}
+
+func (c *cmdAdminSync) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("sync", i18n.G("<source remote>: <target remote>: [<project>...]"))
+	cmd.Short = i18n.G("Mirror projects to a standby server")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Mirror projects to a standby server
+
+  The profiles, images, instances and custom storage volumes of the projects (all of them if none
+  is specified) are copied from the source server to the target server. Instances and volumes that
+  already exist on the target are refreshed, only transferring the differences along with any new
+  snapshots, so that scheduled snapshots on the source turn into periodic backups on the target.
+
+  Mirrored instances have boot.autostart disabled on the target and are never started by this command.
+
+  With --interval, the synchronization is repeated at the given interval until one of them fails.
+
+  Errors are reported on standard error as they happen. The command exits with a non-zero status
+  if any resource failed to synchronize, after trying all the others.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus admin sync main: standby: default web --interval 1h
+    Mirror the "default" and "web" projects from the "main" remote to the "standby" remote every hour.`))
+	cmd.RunE = c.Run
+
+	cmd.Flags().StringVar(&c.flagInterval, "interval", "", i18n.G("Repeat the synchronization at this interval (e.g. 30m or 6h)")+"``")
+	cmd.Flags().StringVar(&c.flagMode, "mode", "pull", i18n.G("Transfer mode. One of pull, push or relay")+"``")
+	cmd.Flags().StringSliceVar(&c.flagSkip, "skip", nil, i18n.G("Resources to skip (profiles, images, instances or volumes)")+"``")
+
+	return cmd
+}
+
+func (c *cmdAdminSync) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	for _, resource := range c.flagSkip {
+		if !slices.Contains([]string{syncProfiles, syncImages, syncInstances, syncVolumes}, resource) {
+			return fmt.Errorf(i18n.G("Unknown resource to skip %q"), resource)
+		}
+	}
+
+	var interval time.Duration
+	if c.flagInterval != "" {
+		interval, err = time.ParseDuration(c.flagInterval)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf(i18n.G("Invalid interval %q"), c.flagInterval)
+		}
+	}
+
+	// Connect to the servers.
+	sourceRemote, sourceName, err := conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	targetRemote, targetName, err := conf.ParseRemote(args[1])
+	if err != nil {
+		return err
+	}
+
+	if sourceName != "" || targetName != "" {
+		return fmt.Errorf(i18n.G("The source and target must be remotes (ending with a colon)"))
+	}
+
+	if sourceRemote == targetRemote {
+		return fmt.Errorf(i18n.G("The source and target remotes must be different"))
+	}
+
+	source, err := conf.GetInstanceServer(sourceRemote)
+	if err != nil {
+		return err
+	}
+
+	target, err := conf.GetInstanceServer(targetRemote)
+	if err != nil {
+		return err
+	}
+
+	for {
+		errs := c.sync(source, target, args[2:])
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, err)
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf(i18n.G("Failed synchronizing %d resources"), len(errs))
+		}
+
+		if interval == 0 {
+			return nil
+		}
+
+		fmt.Printf(i18n.G("Next synchronization at %s")+"\n", time.Now().Add(interval).Format(time.RFC3339))
+		time.Sleep(interval)
+	}
+}
+
+// sync mirrors the projects from the source to the target, carrying on with the other resources on failure.
+// It returns all the errors that were encountered.
+func (c *cmdAdminSync) sync(source incus.InstanceServer, target incus.InstanceServer, projects []string) []error {
+	var err error
+
+	if len(projects) == 0 {
+		projects, err = source.GetProjectNames()
+		if err != nil {
+			return []error{fmt.Errorf(i18n.G("Failed getting projects: %w"), err)}
+		}
+	}
+
+	var errs []error
+
+	for _, projectName := range projects {
+		err := c.syncProject(source, target, projectName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(i18n.G("Failed synchronizing project %q: %w"), projectName, err))
+			continue
+		}
+
+		projectSource := source.UseProject(projectName)
+		projectTarget := target.UseProject(projectName)
+
+		// Profiles and images go first as the instances depend on them.
+		syncFuncs := []struct {
+			resource string
+			sync     func(source incus.InstanceServer, target incus.InstanceServer) []error
+		}{
+			{syncProfiles, c.syncProfiles},
+			{syncImages, c.syncImages},
+			{syncVolumes, c.syncVolumes},
+			{syncInstances, c.syncInstances},
+		}
+
+		for _, syncFunc := range syncFuncs {
+			if slices.Contains(c.flagSkip, syncFunc.resource) {
+				continue
+			}
+
+			for _, err := range syncFunc.sync(projectSource, projectTarget) {
+				errs = append(errs, fmt.Errorf(i18n.G("Project %q: %w"), projectName, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// syncProject creates or updates the project on the target.
+func (c *cmdAdminSync) syncProject(source incus.InstanceServer, target incus.InstanceServer, name string) error {
+	project, _, err := source.GetProject(name)
+	if err != nil {
+		return err
+	}
+
+	_, etag, err := target.GetProject(name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, 404) {
+			return err
+		}
+
+		return target.CreateProject(api.ProjectsPost{Name: name, ProjectPut: project.Writable()})
+	}
+
+	return target.UpdateProject(name, project.Writable(), etag)
+}
+
+// syncProfiles creates or updates the profiles on the target.
+func (c *cmdAdminSync) syncProfiles(source incus.InstanceServer, target incus.InstanceServer) []error {
+	profiles, err := source.GetProfiles()
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting profiles: %w"), err)}
+	}
+
+	targetProfiles, err := target.GetProfileNames()
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting profiles: %w"), err)}
+	}
+
+	var errs []error
+
+	for _, profile := range profiles {
+		if slices.Contains(targetProfiles, profile.Name) {
+			err = target.UpdateProfile(profile.Name, profile.Writable(), "")
+		} else {
+			err = target.CreateProfile(api.ProfilesPost{Name: profile.Name, ProfilePut: profile.Writable()})
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf(i18n.G("Failed synchronizing profile %q: %w"), profile.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// syncImages copies the images missing on the target and points the aliases to them.
+func (c *cmdAdminSync) syncImages(source incus.InstanceServer, target incus.InstanceServer) []error {
+	images, err := source.GetImages()
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting images: %w"), err)}
+	}
+
+	targetFingerprints, err := target.GetImageFingerprints()
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting images: %w"), err)}
+	}
+
+	var errs []error
+
+	for _, image := range images {
+		// Cached images are downloaded on demand.
+		if image.Cached {
+			continue
+		}
+
+		if !slices.Contains(targetFingerprints, image.Fingerprint) {
+			op, err := target.CopyImage(source, image, &incus.ImageCopyArgs{
+				AutoUpdate: image.AutoUpdate,
+				Public:     image.Public,
+				Type:       image.Type,
+				Mode:       c.flagMode,
+				Profiles:   image.Profiles,
+			})
+			if err == nil {
+				err = c.wait(op, fmt.Sprintf(i18n.G("Copying image %s"), image.Fingerprint[0:12]))
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf(i18n.G("Failed copying image %q: %w"), image.Fingerprint[0:12], err))
+				continue
+			}
+		}
+
+		for _, alias := range image.Aliases {
+			targetAlias, etag, err := target.GetImageAlias(alias.Name)
+			if err == nil {
+				if targetAlias.Target == image.Fingerprint {
+					continue
+				}
+
+				err = target.UpdateImageAlias(alias.Name, api.ImageAliasesEntryPut{Description: alias.Description, Target: image.Fingerprint}, etag)
+			} else if api.StatusErrorCheck(err, 404) {
+				aliasPost := api.ImageAliasesPost{}
+				aliasPost.Name = alias.Name
+				aliasPost.Description = alias.Description
+				aliasPost.Target = image.Fingerprint
+
+				err = target.CreateImageAlias(aliasPost)
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf(i18n.G("Failed synchronizing image alias %q: %w"), alias.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// syncVolumes copies or refreshes the custom storage volumes on the target, along with their snapshots.
+func (c *cmdAdminSync) syncVolumes(source incus.InstanceServer, target incus.InstanceServer) []error {
+	pools, err := source.GetStoragePoolNames()
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting storage pools: %w"), err)}
+	}
+
+	var errs []error
+
+	for _, pool := range pools {
+		volumes, err := source.GetStoragePoolVolumes(pool)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(i18n.G("Failed getting storage volumes of pool %q: %w"), pool, err))
+			continue
+		}
+
+		targetVolumes, err := target.GetStoragePoolVolumeNames(pool)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(i18n.G("Failed getting storage volumes of pool %q: %w"), pool, err))
+			continue
+		}
+
+		for _, volume := range volumes {
+			if volume.Type != "custom" {
+				continue
+			}
+
+			args := incus.StoragePoolVolumeCopyArgs{
+				Name:    volume.Name,
+				Mode:    c.flagMode,
+				Refresh: slices.Contains(targetVolumes, "custom/"+volume.Name),
+			}
+
+			op, err := target.CopyStoragePoolVolume(pool, source, pool, volume, &args)
+			if err == nil {
+				err = c.wait(op, fmt.Sprintf(i18n.G("Copying storage volume %s"), volume.Name))
+			}
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf(i18n.G("Failed copying storage volume %q: %w"), volume.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// syncInstances copies or refreshes the instances on the target, along with their snapshots.
+func (c *cmdAdminSync) syncInstances(source incus.InstanceServer, target incus.InstanceServer) []error {
+	instances, err := source.GetInstances(api.InstanceTypeAny)
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting instances: %w"), err)}
+	}
+
+	targetInstances, err := target.GetInstanceNames(api.InstanceTypeAny)
+	if err != nil {
+		return []error{fmt.Errorf(i18n.G("Failed getting instances: %w"), err)}
+	}
+
+	var errs []error
+
+	for _, inst := range instances {
+		// Ephemeral instances don't survive being stopped, so there is nothing to recover.
+		if inst.Ephemeral {
+			continue
+		}
+
+		err := c.syncInstance(source, target, inst, slices.Contains(targetInstances, inst.Name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf(i18n.G("Failed copying instance %q: %w"), inst.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// syncInstance copies the instance to the target, or refreshes it if it already exists.
+func (c *cmdAdminSync) syncInstance(source incus.InstanceServer, target incus.InstanceServer, inst api.Instance, refresh bool) error {
+	if inst.Config == nil {
+		inst.Config = map[string]string{}
+	}
+
+	// Strip the volatile keys, unless refreshing.
+	for k := range inst.Config {
+		if !refresh && !instance.InstanceIncludeWhenCopying(k, true) {
+			delete(inst.Config, k)
+		}
+	}
+
+	// The mirrored instances must not start on the standby server.
+	inst.Config["boot.autostart"] = "false"
+	delete(inst.Config, "volatile.last_state.power")
+
+	writable := inst.Writable()
+
+	op, err := target.CopyInstance(source, inst, &incus.InstanceCopyArgs{
+		Mode:    c.flagMode,
+		Refresh: refresh,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.wait(op, fmt.Sprintf(i18n.G("Copying instance %s"), inst.Name))
+	if err != nil {
+		return err
+	}
+
+	if !refresh {
+		return nil
+	}
+
+	// Refreshing only transfers the data, so update the configuration too.
+	targetInst, etag, err := target.GetInstance(inst.Name)
+	if err != nil {
+		return err
+	}
+
+	// Ensure we don't change the target's volatile.idmap.next value.
+	writable.Config["volatile.idmap.next"] = targetInst.Config["volatile.idmap.next"]
+
+	// Ensure we don't change the target's root disk pool.
+	srcRootDiskDeviceKey, _, _ := instance.GetRootDiskDevice(writable.Devices)
+	destRootDiskDeviceKey, destRootDiskDevice, _ := instance.GetRootDiskDevice(targetInst.Devices)
+	if srcRootDiskDeviceKey != "" && srcRootDiskDeviceKey == destRootDiskDeviceKey {
+		writable.Devices[destRootDiskDeviceKey]["pool"] = destRootDiskDevice["pool"]
+	}
+
+	updateOp, err := target.UpdateInstance(inst.Name, writable, etag)
+	if err != nil {
+		return err
+	}
+
+	return updateOp.Wait()
+}
+
+// wait waits for the remote operation to complete, rendering its progress after the description.
+func (c *cmdAdminSync) wait(op incus.RemoteOperation, description string) error {
+	progress := cli.ProgressRenderer{
+		Format: description + ": %s",
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err := op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = cli.CancelableWait(op, &progress)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done("")
+
+	return nil
+}
@@ -0,0 +1,232 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+	config "github.com/lxc/incus/v6/shared/cliconfig"
+)
+
+// testSyncServer is a fake server holding projects and their profiles.
+type testSyncServer struct {
+	incus.InstanceServer
+
+	project        string
+	projects       map[string]api.ProjectPut
+	profiles       map[string]map[string]api.ProfilePut
+	brokenProfiles []string
+}
+
+func newTestSyncServer() *testSyncServer {
+	return &testSyncServer{
+		project:  api.ProjectDefaultName,
+		projects: map[string]api.ProjectPut{},
+		profiles: map[string]map[string]api.ProfilePut{},
+	}
+}
+
+func (s *testSyncServer) addProfile(projectName string, name string, description string) {
+	_, ok := s.projects[projectName]
+	if !ok {
+		s.projects[projectName] = api.ProjectPut{}
+	}
+
+	if s.profiles[projectName] == nil {
+		s.profiles[projectName] = map[string]api.ProfilePut{}
+	}
+
+	s.profiles[projectName][name] = api.ProfilePut{Description: description}
+}
+
+func (s *testSyncServer) UseProject(name string) incus.InstanceServer {
+	projectServer := *s
+	projectServer.project = name
+
+	return &projectServer
+}
+
+func (s *testSyncServer) GetProjectNames() ([]string, error) {
+	names := []string{}
+	for name := range s.projects {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+func (s *testSyncServer) GetProject(name string) (*api.Project, string, error) {
+	project, ok := s.projects[name]
+	if !ok {
+		return nil, "", api.StatusErrorf(http.StatusNotFound, "Project not found")
+	}
+
+	return &api.Project{Name: name, ProjectPut: project}, "", nil
+}
+
+func (s *testSyncServer) CreateProject(project api.ProjectsPost) error {
+	s.projects[project.Name] = project.ProjectPut
+	s.profiles[project.Name] = map[string]api.ProfilePut{}
+
+	return nil
+}
+
+func (s *testSyncServer) UpdateProject(name string, project api.ProjectPut, ETag string) error {
+	s.projects[name] = project
+
+	return nil
+}
+
+func (s *testSyncServer) GetProfileNames() ([]string, error) {
+	names := []string{}
+	for name := range s.profiles[s.project] {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+func (s *testSyncServer) GetProfiles() ([]api.Profile, error) {
+	profiles := []api.Profile{}
+	for name, profile := range s.profiles[s.project] {
+		profiles = append(profiles, api.Profile{Name: name, ProfilePut: profile})
+	}
+
+	slices.SortFunc(profiles, func(a api.Profile, b api.Profile) int { return strings.Compare(a.Name, b.Name) })
+
+	return profiles, nil
+}
+
+func (s *testSyncServer) CreateProfile(profile api.ProfilesPost) error {
+	if slices.Contains(s.brokenProfiles, profile.Name) {
+		return fmt.Errorf("Broken profile")
+	}
+
+	s.profiles[s.project][profile.Name] = profile.ProfilePut
+
+	return nil
+}
+
+func (s *testSyncServer) UpdateProfile(name string, profile api.ProfilePut, ETag string) error {
+	s.profiles[s.project][name] = profile
+
+	return nil
+}
+
+func testAdminSync() *cmdAdminSync {
+	return &cmdAdminSync{
+		global:   &cmdGlobal{},
+		flagSkip: []string{syncImages, syncVolumes, syncInstances},
+	}
+}
+
+func TestAdminSyncProfiles(t *testing.T) {
+	source := newTestSyncServer()
+	source.projects[api.ProjectDefaultName] = api.ProjectPut{Description: "Default"}
+	source.addProfile(api.ProjectDefaultName, "default", "new")
+	source.addProfile("web", "default", "web default")
+	source.addProfile("web", "frontend", "web frontend")
+
+	target := newTestSyncServer()
+	target.addProfile(api.ProjectDefaultName, "default", "old")
+	target.addProfile(api.ProjectDefaultName, "extra", "extra")
+
+	errs := testAdminSync().sync(source, target, nil)
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if target.projects[api.ProjectDefaultName].Description != "Default" {
+		t.Errorf("Expected the default project to be updated, got %+v", target.projects[api.ProjectDefaultName])
+	}
+
+	expected := map[string]map[string]api.ProfilePut{
+		api.ProjectDefaultName: {
+			"default": {Description: "new"},
+			"extra":   {Description: "extra"},
+		},
+		"web": {
+			"default":  {Description: "web default"},
+			"frontend": {Description: "web frontend"},
+		},
+	}
+
+	for projectName, profiles := range expected {
+		for name, profile := range profiles {
+			if target.profiles[projectName][name].Description != profile.Description {
+				t.Errorf("Expected profile %q of project %q to be %+v, got %+v", name, projectName, profile, target.profiles[projectName][name])
+			}
+		}
+	}
+}
+
+func TestAdminSyncErrors(t *testing.T) {
+	source := newTestSyncServer()
+	source.addProfile(api.ProjectDefaultName, "broken", "")
+	source.addProfile(api.ProjectDefaultName, "working", "")
+
+	target := newTestSyncServer()
+	target.brokenProfiles = []string{"broken"}
+
+	errs := testAdminSync().sync(source, target, []string{"missing", api.ProjectDefaultName})
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	if !strings.Contains(errs[0].Error(), `"missing"`) {
+		t.Errorf("Expected an error about the missing project, got %q", errs[0])
+	}
+
+	if !strings.Contains(errs[1].Error(), `"broken"`) {
+		t.Errorf("Expected an error about the broken profile, got %q", errs[1])
+	}
+
+	// The other resources are still synchronized.
+	_, ok := target.profiles[api.ProjectDefaultName]["working"]
+	if !ok {
+		t.Error("Expected the working profile to be synchronized despite the errors")
+	}
+}
+
+func TestAdminSyncRunChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		interval string
+		skip     []string
+		expected string
+	}{
+		{"unknown skip", []string{"local:", "other:"}, "", []string{"networks"}, "Unknown resource to skip"},
+		{"invalid interval", []string{"local:", "other:"}, "-1h", nil, "Invalid interval"},
+		{"instance instead of remote", []string{"local:c1", "other:"}, "", nil, "must be remotes"},
+		{"same remotes", []string{"local:", "local:"}, "", nil, "must be different"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf := config.NewConfig("", true)
+			conf.Remotes["other"] = config.Remote{Addr: "https://127.0.0.1:8443"}
+
+			c := &cmdAdminSync{
+				global:       &cmdGlobal{conf: conf},
+				flagInterval: test.interval,
+				flagSkip:     test.skip,
+			}
+
+			err := c.Run(&cobra.Command{}, test.args)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	incus "github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// applySpec represents the declarative document consumed by "incus apply".
+type applySpec struct {
+	Networks       []api.NetworksPost   `yaml:"networks"`
+	StorageVolumes []applyStorageVolume `yaml:"storage_volumes"`
+	Profiles       []api.ProfilesPost   `yaml:"profiles"`
+	Instances      []applyInstance      `yaml:"instances"`
+}
+
+// applyStorageVolume represents a custom storage volume in an apply document.
+type applyStorageVolume struct {
+	api.StorageVolumesPost `yaml:",inline"`
+
+	Pool string `yaml:"pool"`
+}
+
+// applyInstance represents an instance in an apply document.
+type applyInstance struct {
+	api.InstancesPost `yaml:",inline"`
+
+	Image string `yaml:"image"`
+}
+
+// applyChange represents a single planned change.
+type applyChange struct {
+	kind   string
+	name   string
+	create bool
+	diff   []string
+	run    func() error
+}
+
+type cmdApply struct {
+	global *cmdGlobal
+
+	flagFile   string
+	flagDryRun bool
+}
+
+func (c *cmdApply) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("apply", i18n.G("[<remote>:]"))
+	cmd.Short = i18n.G("Converge the server state to a declarative document")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Converge the server state to a declarative document
+
+The document lists networks, storage volumes, profiles and instances.
+Missing objects are created and existing ones are updated to match the document.
+
+Configuration keys and devices which aren't part of the document are left untouched
+and objects which aren't listed in the document are never deleted.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus apply -f spec.yaml
+    Create or update the objects described in spec.yaml.
+
+incus apply -f spec.yaml --dry-run
+    Only show the changes which would be made.`))
+
+	cmd.Flags().StringVarP(&c.flagFile, "file", "f", "", i18n.G("Path to the document (\"-\" for stdin)")+"``")
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, i18n.G("Only show the changes which would be made"))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+func (c *cmdApply) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagFile == "" {
+		return fmt.Errorf(i18n.G("A document must be provided with --file"))
+	}
+
+	// Read the document.
+	var content []byte
+	if c.flagFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(c.flagFile)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	spec := applySpec{}
+	err = yaml.UnmarshalStrict(content, &spec)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed parsing the document: %w"), err)
+	}
+
+	// Connect to the server.
+	remote := conf.DefaultRemote
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+	if resource.name != "" {
+		return fmt.Errorf(i18n.G("Only a remote name can be provided"))
+	}
+
+	// Plan the changes, dependencies first.
+	changes := []applyChange{}
+	for _, network := range spec.Networks {
+		change, err := c.planNetwork(resource.server, network)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, *change)
+	}
+
+	for _, volume := range spec.StorageVolumes {
+		change, err := c.planStorageVolume(resource.server, volume)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, *change)
+	}
+
+	for _, profile := range spec.Profiles {
+		change, err := c.planProfile(resource.server, profile)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, *change)
+	}
+
+	for _, inst := range spec.Instances {
+		change, err := c.planInstance(resource.remote, resource.server, inst)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, *change)
+	}
+
+	// Show the plan.
+	for _, change := range changes {
+		if change.create {
+			fmt.Printf("+ %s %s\n", change.kind, change.name)
+		} else if len(change.diff) > 0 {
+			fmt.Printf("~ %s %s\n", change.kind, change.name)
+			for _, line := range change.diff {
+				fmt.Printf("    %s\n", line)
+			}
+		} else {
+			fmt.Printf("= %s %s\n", change.kind, change.name)
+		}
+	}
+
+	if c.flagDryRun {
+		return nil
+	}
+
+	// Apply the changes.
+	for _, change := range changes {
+		if change.run == nil {
+			continue
+		}
+
+		err = change.run()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed applying %s %q: %w"), change.kind, change.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *cmdApply) planNetwork(d incus.InstanceServer, network api.NetworksPost) (*applyChange, error) {
+	if network.Name == "" {
+		return nil, fmt.Errorf(i18n.G("Networks must have a name"))
+	}
+
+	change := &applyChange{kind: "network", name: network.Name}
+
+	current, etag, err := d.GetNetwork(network.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		change.create = true
+		change.run = func() error { return d.CreateNetwork(network) }
+		return change, nil
+	}
+
+	put := current.Writable()
+	put.Config, change.diff = applyMergeConfig(put.Config, network.Config)
+	change.diff = append(change.diff, applyMergeDescription(&put.Description, network.Description)...)
+
+	if len(change.diff) > 0 {
+		change.run = func() error { return d.UpdateNetwork(network.Name, put, etag) }
+	}
+
+	return change, nil
+}
+
+func (c *cmdApply) planStorageVolume(d incus.InstanceServer, volume applyStorageVolume) (*applyChange, error) {
+	if volume.Pool == "" || volume.Name == "" {
+		return nil, fmt.Errorf(i18n.G("Storage volumes must have a pool and a name"))
+	}
+
+	if volume.Type == "" {
+		volume.Type = "custom"
+	}
+
+	change := &applyChange{kind: "storage volume", name: fmt.Sprintf("%s/%s", volume.Pool, volume.Name)}
+
+	current, etag, err := d.GetStoragePoolVolume(volume.Pool, volume.Type, volume.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		change.create = true
+		change.run = func() error { return d.CreateStoragePoolVolume(volume.Pool, volume.StorageVolumesPost) }
+		return change, nil
+	}
+
+	put := current.Writable()
+	put.Config, change.diff = applyMergeConfig(put.Config, volume.Config)
+	change.diff = append(change.diff, applyMergeDescription(&put.Description, volume.Description)...)
+
+	if len(change.diff) > 0 {
+		change.run = func() error {
+			return d.UpdateStoragePoolVolume(volume.Pool, volume.Type, volume.Name, put, etag)
+		}
+	}
+
+	return change, nil
+}
+
+func (c *cmdApply) planProfile(d incus.InstanceServer, profile api.ProfilesPost) (*applyChange, error) {
+	if profile.Name == "" {
+		return nil, fmt.Errorf(i18n.G("Profiles must have a name"))
+	}
+
+	change := &applyChange{kind: "profile", name: profile.Name}
+
+	current, etag, err := d.GetProfile(profile.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		change.create = true
+		change.run = func() error { return d.CreateProfile(profile) }
+		return change, nil
+	}
+
+	put := current.Writable()
+	put.Config, change.diff = applyMergeConfig(put.Config, profile.Config)
+
+	var devicesDiff []string
+	put.Devices, devicesDiff = applyMergeDevices(put.Devices, profile.Devices)
+	change.diff = append(change.diff, devicesDiff...)
+	change.diff = append(change.diff, applyMergeDescription(&put.Description, profile.Description)...)
+
+	if len(change.diff) > 0 {
+		change.run = func() error { return d.UpdateProfile(profile.Name, put, etag) }
+	}
+
+	return change, nil
+}
+
+func (c *cmdApply) planInstance(remote string, d incus.InstanceServer, inst applyInstance) (*applyChange, error) {
+	if inst.Name == "" {
+		return nil, fmt.Errorf(i18n.G("Instances must have a name"))
+	}
+
+	change := &applyChange{kind: "instance", name: inst.Name}
+
+	current, etag, err := d.GetInstance(inst.Name)
+	if err != nil {
+		if !api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		change.create = true
+		change.run = func() error { return c.createInstance(remote, d, inst) }
+		return change, nil
+	}
+
+	put := current.Writable()
+	put.Config, change.diff = applyMergeConfig(put.Config, inst.Config)
+
+	var devicesDiff []string
+	put.Devices, devicesDiff = applyMergeDevices(put.Devices, inst.Devices)
+	change.diff = append(change.diff, devicesDiff...)
+	change.diff = append(change.diff, applyMergeDescription(&put.Description, inst.Description)...)
+
+	if inst.Profiles != nil && !slices.Equal(put.Profiles, inst.Profiles) {
+		change.diff = append(change.diff, fmt.Sprintf("profiles: %v -> %v", put.Profiles, inst.Profiles))
+		put.Profiles = inst.Profiles
+	}
+
+	if len(change.diff) > 0 {
+		change.run = func() error {
+			op, err := d.UpdateInstance(inst.Name, put, etag)
+			if err != nil {
+				return err
+			}
+
+			return op.Wait()
+		}
+	}
+
+	return change, nil
+}
+
+func (c *cmdApply) createInstance(remote string, d incus.InstanceServer, inst applyInstance) error {
+	conf := c.global.conf
+	req := inst.InstancesPost
+
+	// Create an empty instance or one with an explicit source if no image is provided.
+	if inst.Image == "" {
+		if req.Source.Type == "" {
+			req.Source.Type = "none"
+		}
+
+		op, err := d.CreateInstance(req)
+		if err != nil {
+			return err
+		}
+
+		return op.Wait()
+	}
+
+	iremote, image, err := conf.ParseRemote(inst.Image)
+	if err != nil {
+		return err
+	}
+
+	iremote, image = guessImage(conf, d, remote, iremote, image)
+	imgRemote, imgInfo, err := getImgInfo(d, conf, iremote, remote, image, &req.Source)
+	if err != nil {
+		return err
+	}
+
+	if req.Type == "" && conf.Remotes[iremote].Protocol == "incus" {
+		req.Type = api.InstanceType(imgInfo.Type)
+	}
+
+	op, err := d.CreateInstanceFromImage(imgRemote, *imgInfo, req)
+	if err != nil {
+		return err
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: i18n.G("Retrieving image: %s"),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	_, err = op.AddHandler(progress.UpdateOp)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	err = cli.CancelableWait(op, &progress)
+	if err != nil {
+		progress.Done("")
+		return err
+	}
+
+	progress.Done("")
+
+	return nil
+}
+
+// applyMergeConfig merges the wanted configuration into the current one.
+// It returns the resulting configuration along with a description of the changed keys.
+func applyMergeConfig(current map[string]string, wanted map[string]string) (map[string]string, []string) {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+
+	diff := []string{}
+	for _, key := range applySortedKeys(wanted) {
+		oldValue, ok := merged[key]
+		if ok && oldValue == wanted[key] {
+			continue
+		}
+
+		if ok {
+			diff = append(diff, fmt.Sprintf("config.%s: %q -> %q", key, oldValue, wanted[key]))
+		} else {
+			diff = append(diff, fmt.Sprintf("config.%s: %q", key, wanted[key]))
+		}
+
+		merged[key] = wanted[key]
+	}
+
+	return merged, diff
+}
+
+// applyMergeDevices merges the wanted devices into the current ones, replacing devices with the same name.
+// It returns the resulting devices along with a description of the changed devices.
+func applyMergeDevices(current map[string]map[string]string, wanted map[string]map[string]string) (map[string]map[string]string, []string) {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]map[string]string{}
+	}
+
+	diff := []string{}
+	for _, name := range applySortedKeys(wanted) {
+		oldDevice, ok := merged[name]
+		if ok && maps.Equal(oldDevice, wanted[name]) {
+			continue
+		}
+
+		if ok {
+			diff = append(diff, fmt.Sprintf("devices.%s: updated", name))
+		} else {
+			diff = append(diff, fmt.Sprintf("devices.%s: added", name))
+		}
+
+		merged[name] = wanted[name]
+	}
+
+	return merged, diff
+}
+
+// applyMergeDescription updates the description if a new one is provided.
+func applyMergeDescription(current *string, wanted string) []string {
+	if wanted == "" || *current == wanted {
+		return nil
+	}
+
+	diff := []string{fmt.Sprintf("description: %q -> %q", *current, wanted)}
+	*current = wanted
+
+	return diff
+}
+
+func applySortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
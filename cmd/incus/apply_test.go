@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMergeConfig(t *testing.T) {
+	current := map[string]string{"limits.cpu": "1", "user.foo": "bar"}
+	wanted := map[string]string{"limits.cpu": "2", "limits.memory": "1GiB", "user.foo": "bar"}
+
+	merged, diff := applyMergeConfig(current, wanted)
+	assert.Equal(t, map[string]string{"limits.cpu": "2", "limits.memory": "1GiB", "user.foo": "bar"}, merged)
+	assert.Equal(t, []string{`config.limits.cpu: "1" -> "2"`, `config.limits.memory: "1GiB"`}, diff)
+
+	// The current configuration must not be modified.
+	assert.Equal(t, "1", current["limits.cpu"])
+
+	_, diff = applyMergeConfig(merged, wanted)
+	assert.Empty(t, diff)
+}
+
+func TestApplyMergeDevices(t *testing.T) {
+	current := map[string]map[string]string{
+		"eth0": {"type": "nic", "network": "incusbr0"},
+		"root": {"type": "disk", "pool": "default", "path": "/"},
+	}
+
+	wanted := map[string]map[string]string{
+		"eth0": {"type": "nic", "network": "web"},
+		"data": {"type": "disk", "pool": "default", "source": "data", "path": "/srv"},
+		"root": {"type": "disk", "pool": "default", "path": "/"},
+	}
+
+	merged, diff := applyMergeDevices(current, wanted)
+	assert.Equal(t, wanted, merged)
+	assert.Equal(t, []string{"devices.data: added", "devices.eth0: updated"}, diff)
+}
@@ -55,6 +55,10 @@ func (c *cmdCluster) Command() *cobra.Command {
 	clusterInfoCmd := cmdClusterInfo{global: c.global, cluster: c}
 	cmd.AddCommand(clusterInfoCmd.Command())
 
+	// Show config diff
+	clusterShowConfigDiffCmd := cmdClusterShowConfigDiff{global: c.global, cluster: c}
+	cmd.AddCommand(clusterShowConfigDiffCmd.Command())
+
 	// Get
 	clusterGetCmd := cmdClusterGet{global: c.global, cluster: c}
 	cmd.AddCommand(clusterGetCmd.Command())
@@ -309,6 +313,8 @@ func (c *cmdClusterList) Run(cmd *cobra.Command, args []string) error {
 type cmdClusterShow struct {
 	global  *cmdGlobal
 	cluster *cmdCluster
+
+	flagFormat string
 }
 
 func (c *cmdClusterShow) Command() *cobra.Command {
@@ -318,6 +324,7 @@ func (c *cmdClusterShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show details of a cluster member`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -352,13 +359,11 @@ func (c *cmdClusterShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Render as YAML
-	data, err := yaml.Marshal(&member)
+	err = cli.RenderObject(c.flagFormat, &member)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", data)
 	return nil
 }
 
@@ -366,6 +371,8 @@ func (c *cmdClusterShow) Run(cmd *cobra.Command, args []string) error {
 type cmdClusterInfo struct {
 	global  *cmdGlobal
 	cluster *cmdCluster
+
+	flagFormat string
 }
 
 func (c *cmdClusterInfo) Command() *cobra.Command {
@@ -375,6 +382,7 @@ func (c *cmdClusterInfo) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show useful information about a cluster member`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -409,14 +417,92 @@ func (c *cmdClusterInfo) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Render as YAML.
-	data, err := yaml.Marshal(&member)
+	return cli.RenderObject(c.flagFormat, member)
+}
+
+// Show config diff.
+type cmdClusterShowConfigDiff struct {
+	global  *cmdGlobal
+	cluster *cmdCluster
+
+	flagFormat string
+}
+
+func (c *cmdClusterShowConfigDiff) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show-config-diff", i18n.G("[<remote>:]<member>"))
+	cmd.Short = i18n.G("Show configuration differences of a cluster member")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show configuration differences of a cluster member
+
+Lists the member-specific server configuration keys whose value on the cluster member
+differs from their default or from their value on other cluster members.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus cluster show-config-diff server01
+    Show the configuration keys of server01 that differ from their default or from other cluster members.`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpClusterMembers(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdClusterShowConfigDiff) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", data)
-	return nil
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing cluster member name"))
+	}
+
+	// Get the configuration differences.
+	diffs, err := resource.server.GetClusterMemberConfigDiff(resource.name)
+	if err != nil {
+		return err
+	}
+
+	// Render the table.
+	data := [][]string{}
+	for _, diff := range diffs {
+		members := make([]string, 0, len(diff.Members))
+		for name, value := range diff.Members {
+			members = append(members, fmt.Sprintf("%s=%s", name, value))
+		}
+
+		sort.Strings(members)
+
+		data = append(data, []string{diff.Key, diff.Value, diff.Default, strings.Join(members, "\n")})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("KEY"),
+		i18n.G("VALUE"),
+		i18n.G("DEFAULT"),
+		i18n.G("OTHER MEMBERS"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, diffs)
 }
 
 // Get.
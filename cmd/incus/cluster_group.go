@@ -652,6 +652,8 @@ func (c *cmdClusterGroupRename) Run(cmd *cobra.Command, args []string) error {
 type cmdClusterGroupShow struct {
 	global  *cmdGlobal
 	cluster *cmdCluster
+
+	flagFormat string
 }
 
 // Setting up the 'show' command to display the configurations of a specified cluster group in a remote server.
@@ -662,6 +664,7 @@ func (c *cmdClusterGroupShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show cluster group configurations`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -701,14 +704,7 @@ func (c *cmdClusterGroupShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&group)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &group)
 }
 
 // Add.
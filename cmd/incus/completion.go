@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -131,37 +132,21 @@ func (g *cmdGlobal) cmpClusterMemberRoles(memberName string) ([]string, cobra.Sh
 }
 
 func (g *cmdGlobal) cmpClusterMembers(toComplete string) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.ParseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		cluster, _, err := resource.server.GetCluster()
-		if err != nil || !cluster.Enabled {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		// Get the cluster members
-		members, err := resource.server.GetClusterMembers()
+	results := g.cmpRemoteEntities(toComplete, "cluster-members", func(d incus.InstanceServer) ([]string, error) {
+		cluster, _, err := d.GetCluster()
 		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
+			return nil, err
 		}
 
-		for _, member := range members {
-			var name string
-
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = member.ServerName
-			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, member.ServerName)
-			}
-
-			results = append(results, name)
+		if !cluster.Enabled {
+			return []string{}, nil
 		}
-	}
+
+		// Get the cluster members
+		return d.GetClusterMemberNames()
+	})
 
 	if !strings.Contains(toComplete, ":") {
 		remotes, directives := g.cmpRemotes(false)
@@ -173,7 +158,6 @@ func (g *cmdGlobal) cmpClusterMembers(toComplete string) ([]string, cobra.ShellC
 }
 
 func (g *cmdGlobal) cmpImages(toComplete string) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
 	var remote string
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
@@ -183,23 +167,28 @@ func (g *cmdGlobal) cmpImages(toComplete string) ([]string, cobra.ShellCompDirec
 		remote = g.conf.DefaultRemote
 	}
 
-	remoteServer, _ := g.conf.GetImageServer(remote)
-
-	images, _ := remoteServer.GetImages()
+	aliases := g.cmpCachedNames(remote, "image-aliases", func() ([]string, error) {
+		remoteServer, err := g.conf.GetImageServer(remote)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, image := range images {
-		for _, alias := range image.Aliases {
-			var name string
+		images, err := remoteServer.GetImages()
+		if err != nil {
+			return nil, err
+		}
 
-			if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = alias.Name
-			} else {
-				name = fmt.Sprintf("%s:%s", remote, alias.Name)
+		aliases := []string{}
+		for _, image := range images {
+			for _, alias := range image.Aliases {
+				aliases = append(aliases, alias.Name)
 			}
-
-			results = append(results, name)
 		}
-	}
+
+		return aliases, nil
+	})
+
+	results := g.cmpPrefixRemote(toComplete, remote, aliases)
 
 	if !strings.Contains(toComplete, ":") {
 		remotes, directives := g.cmpRemotes(true)
@@ -284,6 +273,22 @@ func (g *cmdGlobal) cmpInstanceSnapshots(instanceName string) ([]string, cobra.S
 }
 
 func (g *cmdGlobal) cmpInstances(toComplete string) ([]string, cobra.ShellCompDirective) {
+	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
+
+	results := g.cmpRemoteEntities(toComplete, "instances", func(d incus.InstanceServer) ([]string, error) {
+		return d.GetInstanceNames(api.InstanceTypeAny)
+	})
+
+	if !strings.Contains(toComplete, ":") {
+		remotes, directives := g.cmpRemotes(false)
+		results = append(results, remotes...)
+		cmpDirectives |= directives
+	}
+
+	return results, cmpDirectives
+}
+
+func (g *cmdGlobal) cmpInstanceGroups(toComplete string) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
@@ -292,14 +297,46 @@ func (g *cmdGlobal) cmpInstances(toComplete string) ([]string, cobra.ShellCompDi
 	if len(resources) > 0 {
 		resource := resources[0]
 
-		instances, _ := resource.server.GetInstanceNames(api.InstanceTypeAny)
-		for _, instName := range instances {
+		groups, _ := resource.server.GetInstanceGroupNames()
+		for _, group := range groups {
 			var name string
 
 			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = instName
+				name = group
 			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, instName)
+				name = fmt.Sprintf("%s:%s", resource.remote, group)
+			}
+
+			results = append(results, name)
+		}
+	}
+
+	if !strings.Contains(toComplete, ":") {
+		remotes, directives := g.cmpRemotes(false)
+		results = append(results, remotes...)
+		cmpDirectives |= directives
+	}
+
+	return results, cmpDirectives
+}
+
+func (g *cmdGlobal) cmpInstanceTemplates(toComplete string) ([]string, cobra.ShellCompDirective) {
+	results := []string{}
+	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
+
+	resources, _ := g.ParseServers(toComplete)
+
+	if len(resources) > 0 {
+		resource := resources[0]
+
+		templates, _ := resource.server.GetInstanceTemplateNames()
+		for _, template := range templates {
+			var name string
+
+			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+				name = template
+			} else {
+				name = fmt.Sprintf("%s:%s", resource.remote, template)
 			}
 
 			results = append(results, name)
@@ -467,6 +504,25 @@ func (g *cmdGlobal) cmpNetworkForwardConfigs(networkName string, listenAddress s
 	return results, cobra.ShellCompDirectiveNoFileComp
 }
 
+func (g *cmdGlobal) cmpNetworkDHCPReservations(networkName string) ([]string, cobra.ShellCompDirective) {
+	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
+
+	resources, _ := g.ParseServers(networkName)
+
+	if len(resources) <= 0 {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	resource := resources[0]
+
+	results, err := resource.server.GetNetworkDHCPReservationHwaddrs(resource.name)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return results, cmpDirectives
+}
+
 func (g *cmdGlobal) cmpNetworkForwards(networkName string) ([]string, cobra.ShellCompDirective) {
 	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
@@ -552,31 +608,11 @@ func (g *cmdGlobal) cmpNetworkPeers(networkName string) ([]string, cobra.ShellCo
 }
 
 func (g *cmdGlobal) cmpNetworks(toComplete string) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.ParseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		networks, err := resource.server.GetNetworkNames()
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		for _, network := range networks {
-			var name string
-
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = network
-			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, network)
-			}
-
-			results = append(results, name)
-		}
-	}
+	results := g.cmpRemoteEntities(toComplete, "networks", func(d incus.InstanceServer) ([]string, error) {
+		return d.GetNetworkNames()
+	})
 
 	if !strings.Contains(toComplete, ":") {
 		remotes, directives := g.cmpRemotes(false)
@@ -830,28 +866,11 @@ func (g *cmdGlobal) cmpProfileNamesFromRemote(toComplete string) ([]string, cobr
 }
 
 func (g *cmdGlobal) cmpProfiles(toComplete string, includeRemotes bool) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.ParseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		profiles, _ := resource.server.GetProfileNames()
-
-		for _, profile := range profiles {
-			var name string
-
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = profile
-			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, profile)
-			}
-
-			results = append(results, name)
-		}
-	}
+	results := g.cmpRemoteEntities(toComplete, "profiles", func(d incus.InstanceServer) ([]string, error) {
+		return d.GetProfileNames()
+	})
 
 	if includeRemotes && !strings.Contains(toComplete, ":") {
 		remotes, directives := g.cmpRemotes(false)
@@ -885,31 +904,11 @@ func (g *cmdGlobal) cmpProjectConfigs(projectName string) ([]string, cobra.Shell
 }
 
 func (g *cmdGlobal) cmpProjects(toComplete string) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
 	cmpDirectives := cobra.ShellCompDirectiveNoFileComp
 
-	resources, _ := g.ParseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		projects, err := resource.server.GetProjectNames()
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		for _, project := range projects {
-			var name string
-
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = project
-			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, project)
-			}
-
-			results = append(results, name)
-		}
-	}
+	results := g.cmpRemoteEntities(toComplete, "projects", func(d incus.InstanceServer) ([]string, error) {
+		return d.GetProjectNames()
+	})
 
 	if !strings.Contains(toComplete, ":") {
 		remotes, directives := g.cmpRemotes(false)
@@ -1005,27 +1004,9 @@ func (g *cmdGlobal) cmpStoragePoolWithVolume(toComplete string) ([]string, cobra
 }
 
 func (g *cmdGlobal) cmpStoragePools(toComplete string) ([]string, cobra.ShellCompDirective) {
-	results := []string{}
-
-	resources, _ := g.ParseServers(toComplete)
-
-	if len(resources) > 0 {
-		resource := resources[0]
-
-		storagePools, _ := resource.server.GetStoragePoolNames()
-
-		for _, storage := range storagePools {
-			var name string
-
-			if resource.remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
-				name = storage
-			} else {
-				name = fmt.Sprintf("%s:%s", resource.remote, storage)
-			}
-
-			results = append(results, name)
-		}
-	}
+	results := g.cmpRemoteEntities(toComplete, "storage-pools", func(d incus.InstanceServer) ([]string, error) {
+		return d.GetStoragePoolNames()
+	})
 
 	if !strings.Contains(toComplete, ":") {
 		remotes, _ := g.cmpRemotes(false)
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// cmpCacheTimeout is the maximum time spent retrieving names from a remote before falling back to the cache.
+var cmpCacheTimeout = 2 * time.Second
+
+// cmpCachePath returns the path of the completion cache of the remote.
+func (g *cmdGlobal) cmpCachePath(remote string) string {
+	return g.conf.ConfigPath("completions", fmt.Sprintf("%s.json", remote))
+}
+
+// cmpCachedNames returns the names of a kind of entity on the remote as retrieved by the get function.
+// The names are cached on disk per project so that the last known names are returned when the remote
+// doesn't respond within cmpCacheTimeout or fails.
+func (g *cmdGlobal) cmpCachedNames(remote string, kind string, get func() ([]string, error)) []string {
+	// No configuration directory to store the cache into.
+	if g.conf.ConfigDir == "" {
+		names, _ := get()
+		return names
+	}
+
+	project := g.conf.ProjectOverride
+	if project == "" {
+		project = g.conf.Remotes[remote].Project
+	}
+
+	if project == "" {
+		project = api.ProjectDefaultName
+	}
+
+	key := fmt.Sprintf("%s/%s", project, kind)
+
+	cache := map[string][]string{}
+	content, err := os.ReadFile(g.cmpCachePath(remote))
+	if err == nil {
+		_ = json.Unmarshal(content, &cache)
+	}
+
+	type result struct {
+		names []string
+		err   error
+	}
+
+	chResult := make(chan result, 1)
+	go func() {
+		names, err := get()
+		chResult <- result{names, err}
+	}()
+
+	select {
+	case res := <-chResult:
+		if res.err != nil {
+			return cache[key]
+		}
+
+		cache[key] = res.names
+
+		content, err := json.Marshal(cache)
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(g.cmpCachePath(remote)), 0700)
+			if err == nil {
+				_ = os.WriteFile(g.cmpCachePath(remote), content, 0600)
+			}
+		}
+
+		return res.names
+	case <-time.After(cmpCacheTimeout):
+		return cache[key]
+	}
+}
+
+// cmpRemoteEntities returns the completions for a kind of entity on the remote referenced by toComplete,
+// using the cached names if the remote is slow or unreachable.
+func (g *cmdGlobal) cmpRemoteEntities(toComplete string, kind string, get func(d incus.InstanceServer) ([]string, error)) []string {
+	remote, _, err := g.conf.ParseRemote(toComplete)
+	if err != nil {
+		return nil
+	}
+
+	names := g.cmpCachedNames(remote, kind, func() ([]string, error) {
+		d, err := g.conf.GetInstanceServer(remote)
+		if err != nil {
+			return nil, err
+		}
+
+		return get(d)
+	})
+
+	return g.cmpPrefixRemote(toComplete, remote, names)
+}
+
+// cmpPrefixRemote prefixes the names with their remote unless they are on the implicit default remote.
+func (g *cmdGlobal) cmpPrefixRemote(toComplete string, remote string, names []string) []string {
+	results := make([]string, 0, len(names))
+	for _, name := range names {
+		if remote == g.conf.DefaultRemote && !strings.Contains(toComplete, g.conf.DefaultRemote) {
+			results = append(results, name)
+		} else {
+			results = append(results, fmt.Sprintf("%s:%s", remote, name))
+		}
+	}
+
+	return results
+}
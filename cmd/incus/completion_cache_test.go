@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	config "github.com/lxc/incus/v6/shared/cliconfig"
+)
+
+// Test that the completion cache returns the last known names when the remote fails or is slow.
+func TestCmpCachedNames(t *testing.T) {
+	g := &cmdGlobal{conf: config.NewConfig(t.TempDir(), true)}
+
+	names := g.cmpCachedNames("local", "instances", func() ([]string, error) {
+		return []string{"c1", "c2"}, nil
+	})
+
+	assert.Equal(t, []string{"c1", "c2"}, names)
+
+	names = g.cmpCachedNames("local", "instances", func() ([]string, error) {
+		return nil, errors.New("unreachable")
+	})
+
+	assert.Equal(t, []string{"c1", "c2"}, names)
+
+	timeout := cmpCacheTimeout
+	cmpCacheTimeout = 10 * time.Millisecond
+	defer func() { cmpCacheTimeout = timeout }()
+
+	names = g.cmpCachedNames("local", "instances", func() ([]string, error) {
+		time.Sleep(time.Second)
+		return []string{"c3"}, nil
+	})
+
+	assert.Equal(t, []string{"c1", "c2"}, names)
+
+	// The cache is per project.
+	g.conf.ProjectOverride = "other"
+	names = g.cmpCachedNames("local", "instances", func() ([]string, error) {
+		return nil, errors.New("unreachable")
+	})
+
+	assert.Empty(t, names)
+}
@@ -741,6 +741,7 @@ type cmdConfigShow struct {
 	config *cmdConfig
 
 	flagExpanded bool
+	flagFormat   string
 }
 
 // Command sets up the "show" command, which displays instance or server configurations based on the provided arguments.
@@ -753,6 +754,7 @@ func (c *cmdConfigShow) Command() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&c.flagExpanded, "expanded", "e", false, i18n.G("Show the expanded configuration"))
 	cmd.Flags().StringVar(&c.config.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -766,7 +768,7 @@ func (c *cmdConfigShow) Command() *cobra.Command {
 	return cmd
 }
 
-// Run executes the "show" command, displaying the YAML-formatted configuration of a specified server or instance.
+// Run executes the "show" command, displaying the YAML or JSON formatted configuration of a specified server or instance.
 func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 	// Quick checks.
 	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
@@ -788,7 +790,7 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 	resource := resources[0]
 
 	// Show configuration
-	var data []byte
+	var brief any
 
 	if resource.name == "" {
 		// Quick check.
@@ -811,11 +813,8 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		brief := server.Writable()
-		data, err = yaml.Marshal(&brief)
-		if err != nil {
-			return err
-		}
+		writable := server.Writable()
+		brief = &writable
 	} else {
 		// Quick checks.
 		if c.config.flagTarget != "" {
@@ -823,8 +822,6 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 		}
 
 		// Instance or snapshot config
-		var brief any
-
 		if instance.IsSnapshot(resource.name) {
 			// Snapshot
 			fields := strings.Split(resource.name, instance.SnapshotDelimiter)
@@ -854,16 +851,9 @@ func (c *cmdConfigShow) Run(cmd *cobra.Command, args []string) error {
 				brief.(*api.InstancePut).Devices = inst.ExpandedDevices
 			}
 		}
-
-		data, err = yaml.Marshal(&brief)
-		if err != nil {
-			return err
-		}
 	}
 
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, brief)
 }
 
 // Unset.
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
@@ -744,6 +743,8 @@ type cmdConfigDeviceShow struct {
 	config       *cmdConfig
 	configDevice *cmdConfigDevice
 	profile      *cmdProfile
+
+	flagFormat string
 }
 
 func (c *cmdConfigDeviceShow) Command() *cobra.Command {
@@ -758,6 +759,7 @@ func (c *cmdConfigDeviceShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show full device configuration`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -812,14 +814,7 @@ func (c *cmdConfigDeviceShow) Run(cmd *cobra.Command, args []string) error {
 		devices = inst.Devices
 	}
 
-	data, err := yaml.Marshal(&devices)
-	if err != nil {
-		return err
-	}
-
-	fmt.Print(string(data))
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &devices)
 }
 
 // Unset.
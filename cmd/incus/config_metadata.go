@@ -178,6 +178,8 @@ type cmdConfigMetadataShow struct {
 	global         *cmdGlobal
 	config         *cmdConfig
 	configMetadata *cmdConfigMetadata
+
+	flagFormat string
 }
 
 func (c *cmdConfigMetadataShow) Command() *cobra.Command {
@@ -187,6 +189,7 @@ func (c *cmdConfigMetadataShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show instance metadata files`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -225,12 +228,5 @@ func (c *cmdConfigMetadataShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	content, err := yaml.Marshal(metadata)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", content)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, metadata)
 }
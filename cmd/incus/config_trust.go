@@ -12,6 +12,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -44,6 +45,10 @@ func (c *cmdConfigTrust) Command() *cobra.Command {
 	configTrustAddCertificateCmd := cmdConfigTrustAddCertificate{global: c.global, config: c.config, configTrust: c}
 	cmd.AddCommand(configTrustAddCertificateCmd.Command())
 
+	// Add migration token
+	configTrustAddMigrationTokenCmd := cmdConfigTrustAddMigrationToken{global: c.global, config: c.config, configTrust: c}
+	cmd.AddCommand(configTrustAddMigrationTokenCmd.Command())
+
 	// Edit
 	configTrustEditCmd := cmdConfigTrustEdit{global: c.global, config: c.config, configTrust: c}
 	cmd.AddCommand(configTrustEditCmd.Command())
@@ -80,6 +85,7 @@ type cmdConfigTrustAdd struct {
 	config      *cmdConfig
 	configTrust *cmdConfigTrust
 
+	flagExpiry     string
 	flagProjects   string
 	flagRestricted bool
 }
@@ -92,10 +98,16 @@ func (c *cmdConfigTrustAdd) Command() *cobra.Command {
 		`Add new trusted client
 
 This will issue a trust token to be used by the client to add itself to the trust store.
+
+When an expiry is set, the client is automatically removed from the trust store once it's reached.
 `))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus config trust add ci --expiry 2h --projects dev
+    Issue a token for a client restricted to the "dev" project for the next two hours.`))
 
 	cmd.Flags().BoolVar(&c.flagRestricted, "restricted", false, i18n.G("Restrict the certificate to one or more projects"))
-	cmd.Flags().StringVar(&c.flagProjects, "projects", "", i18n.G("List of projects to restrict the certificate to")+"``")
+	cmd.Flags().StringVar(&c.flagProjects, "projects", "", i18n.G("List of projects to restrict the certificate to (implies --restricted)")+"``")
+	cmd.Flags().StringVar(&c.flagExpiry, "expiry", "", i18n.G("Time after which the client is removed from the trust store (e.g. 2h)")+"``")
 
 	cmd.RunE = c.Run
 
@@ -128,9 +140,23 @@ func (c *cmdConfigTrustAdd) Run(cmd *cobra.Command, args []string) error {
 	cert.Restricted = c.flagRestricted
 
 	if c.flagProjects != "" {
+		cert.Restricted = true
 		cert.Projects = strings.Split(c.flagProjects, ",")
 	}
 
+	if c.flagExpiry != "" {
+		if !resource.server.HasExtension("certificate_expiry") {
+			return fmt.Errorf(i18n.G("The server doesn't support expiring certificates"))
+		}
+
+		expiry, err := time.ParseDuration(c.flagExpiry)
+		if err != nil || expiry <= 0 {
+			return fmt.Errorf(i18n.G("Invalid expiry %q"), c.flagExpiry)
+		}
+
+		cert.ExpiresAt = time.Now().Add(expiry)
+	}
+
 	// Create the token.
 	op, err := resource.server.CreateCertificateToken(cert)
 	if err != nil {
@@ -152,6 +178,72 @@ func (c *cmdConfigTrustAdd) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Add migration token.
+type cmdConfigTrustAddMigrationToken struct {
+	global      *cmdGlobal
+	config      *cmdConfig
+	configTrust *cmdConfigTrust
+}
+
+func (c *cmdConfigTrustAddMigrationToken) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("add-migration-token", i18n.G("[<remote>:][<instance>]"))
+	cmd.Short = i18n.G("Issue a migration token")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Issue a migration token
+
+This will issue a single-use token allowing another server to push an instance into the project,
+without having to trust its certificate. The token can optionally be restricted to an instance name.
+
+The token is to be used with "incus move --target-token" against the source server.
+`))
+
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+func (c *cmdConfigTrustAddMigrationToken) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	remote := ""
+	if len(args) > 0 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Create the token.
+	op, err := resource.server.CreateMigrationToken(api.MigrationTokensPost{Name: resource.name})
+	if err != nil {
+		return err
+	}
+
+	opAPI := op.Get()
+	migrationToken, err := opAPI.ToMigrationToken()
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed converting token operation to migration token: %w"), err)
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Println(i18n.G("Migration token:"))
+	}
+
+	fmt.Println(migrationToken.String())
+
+	return nil
+}
+
 // Add certificate.
 type cmdConfigTrustAddCertificate struct {
 	global      *cmdGlobal
@@ -485,6 +577,11 @@ func (c *cmdConfigTrustList) issueDateColumnData(rowData rowData) string {
 }
 
 func (c *cmdConfigTrustList) expiryDateColumnData(rowData rowData) string {
+	// The client is removed from the trust store when its access expires, before its certificate does.
+	if !rowData.Cert.ExpiresAt.IsZero() && rowData.Cert.ExpiresAt.Before(rowData.TlsCert.NotAfter) {
+		return rowData.Cert.ExpiresAt.Local().Format(dateLayout)
+	}
+
 	return rowData.TlsCert.NotAfter.Local().Format(dateLayout)
 }
 
@@ -794,6 +891,8 @@ type cmdConfigTrustShow struct {
 	global      *cmdGlobal
 	config      *cmdConfig
 	configTrust *cmdConfigTrust
+
+	flagFormat string
 }
 
 func (c *cmdConfigTrustShow) Command() *cobra.Command {
@@ -803,6 +902,7 @@ func (c *cmdConfigTrustShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show trust configurations`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -834,12 +934,5 @@ func (c *cmdConfigTrustShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&cert)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &cert)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -9,12 +10,15 @@ import (
 	"runtime"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/spf13/cobra"
 
 	"github.com/lxc/incus/v6/client"
+	"github.com/lxc/incus/v6/internal/asciicast"
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/api"
@@ -27,7 +31,11 @@ type cmdConsole struct {
 	global *cmdGlobal
 
 	flagShowLog bool
+	flagHistory bool
 	flagType    string
+	flagReplay  string
+
+	flagScreenshot string
 }
 
 func (c *cmdConsole) Command() *cobra.Command {
@@ -38,11 +46,24 @@ func (c *cmdConsole) Command() *cobra.Command {
 		`Attach to instance consoles
 
 This command allows you to interact with the boot console of an instance
-as well as retrieve past log entries from it.`))
+as well as retrieve past log entries from it.
+
+For containers, --history can be combined with --show-log to retrieve the
+console output of the previous boots too.
+
+The --replay flag plays back an interactive exec session that was recorded
+with "incus exec --record-output", using its session ID.
+
+For virtual machines, --screenshot saves a PNG screenshot of the graphical
+output to the given file, which helps diagnosing boot issues that don't show
+on the serial console.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagShowLog, "show-log", false, i18n.G("Retrieve the instance's console log"))
+	cmd.Flags().BoolVar(&c.flagHistory, "history", false, i18n.G("Include the console log of previous boots (with --show-log)"))
 	cmd.Flags().StringVarP(&c.flagType, "type", "t", "console", i18n.G("Type of connection to establish: 'console' for serial console, 'vga' for SPICE graphical output")+"``")
+	cmd.Flags().StringVar(&c.flagReplay, "replay", "", i18n.G("Replay a recorded exec session")+"``")
+	cmd.Flags().StringVar(&c.flagScreenshot, "screenshot", "", i18n.G("Save a screenshot of the graphical output to a PNG file")+"``")
 
 	return cmd
 }
@@ -124,13 +145,38 @@ func (c *cmdConsole) Run(cmd *cobra.Command, args []string) error {
 }
 
 func (c *cmdConsole) console(d incus.InstanceServer, name string) error {
+	// Replay a recorded session if requested.
+	if c.flagReplay != "" {
+		if c.flagShowLog {
+			return fmt.Errorf(i18n.G("Can't specify --show-log with --replay"))
+		}
+
+		return c.replay(d, name)
+	}
+
+	// Save a screenshot if requested.
+	if c.flagScreenshot != "" {
+		if c.flagShowLog {
+			return fmt.Errorf(i18n.G("Can't specify --show-log with --screenshot"))
+		}
+
+		return c.screenshot(d, name)
+	}
+
+	if c.flagHistory && !c.flagShowLog {
+		return fmt.Errorf(i18n.G("The --history flag can only be used with --show-log"))
+	}
+
 	// Show the current log if requested.
 	if c.flagShowLog {
 		if c.flagType != "console" {
 			return fmt.Errorf(i18n.G("The --show-log flag is only supported for by 'console' output type"))
 		}
 
-		console := &incus.InstanceConsoleLogArgs{}
+		console := &incus.InstanceConsoleLogArgs{
+			History: c.flagHistory,
+		}
+
 		log, err := d.GetInstanceConsoleLog(name, console)
 		if err != nil {
 			return err
@@ -160,6 +206,74 @@ func (c *cmdConsole) console(d incus.InstanceServer, name string) error {
 	return fmt.Errorf(i18n.G("Unknown console type %q"), c.flagType)
 }
 
+func (c *cmdConsole) screenshot(d incus.InstanceServer, name string) error {
+	screenshot, err := d.GetInstanceConsoleLog(name, &incus.InstanceConsoleLogArgs{Screenshot: true})
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = screenshot.Close() }()
+
+	f, err := os.Create(c.flagScreenshot)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, screenshot)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (c *cmdConsole) replay(d incus.InstanceServer, name string) error {
+	fileName := c.flagReplay
+	if !strings.HasSuffix(fileName, ".cast") {
+		fileName = fmt.Sprintf("exec_%s.cast", strings.TrimPrefix(fileName, "exec_"))
+	}
+
+	content, err := d.GetInstanceExecOutputLogfile(name, fileName)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = content.Close() }()
+
+	recording, err := asciicast.NewReader(content)
+	if err != nil {
+		return err
+	}
+
+	// Play back the output events with their original timing.
+	var last float64
+	for {
+		event, err := recording.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		if event.Type != asciicast.EventOutput {
+			continue
+		}
+
+		time.Sleep(time.Duration((event.Time - last) * float64(time.Second)))
+		last = event.Time
+
+		_, err = os.Stdout.WriteString(event.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *cmdConsole) text(d incus.InstanceServer, name string) error {
 	// Configure the terminal
 	cfd := int(os.Stdin.Fd())
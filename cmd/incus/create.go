@@ -16,7 +16,9 @@ import (
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/api"
 	config "github.com/lxc/incus/v6/shared/cliconfig"
+	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/termios"
+	"github.com/lxc/incus/v6/shared/units"
 )
 
 type cmdCreate struct {
@@ -32,7 +34,9 @@ type cmdCreate struct {
 	flagType       string
 	flagNoProfiles bool
 	flagEmpty      bool
+	flagDisk       string
 	flagVM         bool
+	flagTemplate   string
 }
 
 func (c *cmdCreate) Command() *cobra.Command {
@@ -43,7 +47,10 @@ func (c *cmdCreate) Command() *cobra.Command {
 	cmd.Example = cli.FormatSection("", i18n.G(`incus create images:ubuntu/22.04 u1
 
 incus create images:ubuntu/22.04 u1 < config.yaml
-    Create the instance with configuration from config.yaml`))
+    Create the instance with configuration from config.yaml
+
+incus create v1 --disk ./disk.qcow2
+    Create a virtual machine using a local qcow2 disk image as its root disk`))
 
 	cmd.Aliases = []string{"init"}
 	cmd.RunE = c.Run
@@ -57,7 +64,9 @@ incus create images:ubuntu/22.04 u1 < config.yaml
 	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().BoolVar(&c.flagNoProfiles, "no-profiles", false, i18n.G("Create the instance with no profiles applied"))
 	cmd.Flags().BoolVar(&c.flagEmpty, "empty", false, i18n.G("Create an empty instance"))
+	cmd.Flags().StringVar(&c.flagDisk, "disk", "", i18n.G("Create a virtual machine from a qcow2 or raw disk image (path or URL)")+"``")
 	cmd.Flags().BoolVar(&c.flagVM, "vm", false, i18n.G("Create a virtual machine"))
+	cmd.Flags().StringVar(&c.flagTemplate, "template", "", i18n.G("Instance template to apply to the new instance")+"``")
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) != 0 {
@@ -77,7 +86,7 @@ func (c *cmdCreate) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if len(args) == 0 && !c.flagEmpty {
+	if len(args) == 0 && !c.flagEmpty && c.flagDisk == "" {
 		_ = cmd.Usage()
 		return nil
 	}
@@ -129,8 +138,16 @@ func (c *cmdCreate) create(conf *config.Config, args []string, launch bool) (inc
 		}
 	}
 
-	if c.flagEmpty {
+	if c.flagEmpty && c.flagDisk != "" {
+		return nil, "", fmt.Errorf(i18n.G("--empty cannot be combined with --disk"))
+	}
+
+	if c.flagEmpty || c.flagDisk != "" {
 		if len(args) > 1 {
+			if c.flagDisk != "" {
+				return nil, "", fmt.Errorf(i18n.G("--disk cannot be combined with an image name"))
+			}
+
 			return nil, "", fmt.Errorf(i18n.G("--empty cannot be combined with an image name"))
 		}
 
@@ -248,8 +265,10 @@ func (c *cmdCreate) create(conf *config.Config, args []string, launch bool) (inc
 
 	// Decide whether we are creating a container or a virtual machine.
 	instanceDBType := api.InstanceTypeContainer
-	if c.flagVM {
+	if c.flagVM || c.flagDisk != "" {
 		instanceDBType = api.InstanceTypeVM
+	} else if c.flagTemplate != "" {
+		instanceDBType = "" // Let the instance template decide.
 	}
 
 	// Set the target if provided.
@@ -263,6 +282,7 @@ func (c *cmdCreate) create(conf *config.Config, args []string, launch bool) (inc
 		InstanceType: c.flagType,
 		Type:         instanceDBType,
 		Start:        launch,
+		Template:     c.flagTemplate,
 	}
 
 	req.Config = configMap
@@ -346,7 +366,56 @@ func (c *cmdCreate) create(conf *config.Config, args []string, launch bool) (inc
 	req.Devices = devicesMap
 
 	var opInfo api.Operation
-	if !c.flagEmpty {
+	if c.flagDisk != "" {
+		var disk io.Reader
+
+		progress := cli.ProgressRenderer{
+			Format: i18n.G("Importing disk image: %s"),
+			Quiet:  c.global.flagQuiet,
+		}
+
+		if strings.HasPrefix(c.flagDisk, "http://") || strings.HasPrefix(c.flagDisk, "https://") {
+			req.Source.URL = c.flagDisk
+		} else {
+			file, err := os.Open(c.flagDisk)
+			if err != nil {
+				return nil, "", err
+			}
+
+			defer func() { _ = file.Close() }()
+
+			fstat, err := file.Stat()
+			if err != nil {
+				return nil, "", err
+			}
+
+			disk = &ioprogress.ProgressReader{
+				ReadCloser: file,
+				Tracker: &ioprogress.ProgressTracker{
+					Length: fstat.Size(),
+					Handler: func(percent int64, speed int64) {
+						progress.UpdateProgress(ioprogress.ProgressData{Text: fmt.Sprintf("%d%% (%s/s)", percent, units.GetByteSizeString(speed, 2))})
+					},
+				},
+			}
+		}
+
+		op, err := d.CreateInstanceFromDisk(req, disk)
+		if err != nil {
+			progress.Done("")
+			return nil, "", err
+		}
+
+		err = cli.CancelableWait(op, &progress)
+		if err != nil {
+			progress.Done("")
+			return nil, "", err
+		}
+
+		progress.Done("")
+
+		opInfo = op.Get()
+	} else if !c.flagEmpty {
 		// Get the image server and image info
 		iremote, image = guessImage(conf, d, remote, iremote, image)
 
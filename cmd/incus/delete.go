@@ -19,6 +19,7 @@ import (
 type cmdDelete struct {
 	global *cmdGlobal
 
+	flagConcurrency    int
 	flagForce          bool
 	flagForceProtected bool
 	flagInteractive    bool
@@ -35,6 +36,7 @@ func (c *cmdDelete) Command() *cobra.Command {
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVarP(&c.flagForce, "force", "f", false, i18n.G("Force the removal of running instances"))
 	cmd.Flags().BoolVarP(&c.flagInteractive, "interactive", "i", false, i18n.G("Require user confirmation"))
+	cmd.Flags().IntVar(&c.flagConcurrency, "concurrency", 1, i18n.G("Maximum number of instances to process in parallel (0 for no limit)")+"``")
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return c.global.cmpInstances(toComplete)
@@ -85,74 +87,91 @@ func (c *cmdDelete) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Process with deletion.
-	for _, resource := range resources {
-		connInfo, err := resource.server.GetConnectionInfo()
-		if err != nil {
-			return err
-		}
-
-		if c.flagInteractive {
+	// Confirm all the deletions before starting.
+	if c.flagInteractive {
+		for _, resource := range resources {
 			err := c.promptDelete(resource.name)
 			if err != nil {
 				return err
 			}
 		}
+	}
 
-		ct, _, err := resource.server.GetInstance(resource.name)
-		if err != nil {
-			return err
-		}
+	// Process with deletion.
+	names := make([]string, 0, len(resources))
+	nameResources := make(map[string]remoteResource, len(resources))
+	for _, resource := range resources {
+		name := fmt.Sprintf("%s:%s", resource.remote, resource.name)
+		names = append(names, name)
+		nameResources[name] = resource
+	}
 
-		if ct.StatusCode != 0 && ct.StatusCode != api.Stopped {
-			if !c.flagForce {
-				return fmt.Errorf(i18n.G("The instance is currently running, stop it first or pass --force"))
-			}
+	results := runBatch(names, c.flagConcurrency, func(name string) error { return c.deleteInstance(nameResources[name]) })
 
-			req := api.InstanceStatePut{
-				Action:  "stop",
-				Timeout: -1,
-				Force:   true,
-			}
+	return batchError(results, fmt.Errorf(i18n.G("Some instances failed to be deleted")))
+}
 
-			op, err := resource.server.UpdateInstanceState(resource.name, req, "")
-			if err != nil {
-				return err
-			}
+// deleteInstance deletes the instance, stopping it first and removing its protection if requested.
+func (c *cmdDelete) deleteInstance(resource remoteResource) error {
+	connInfo, err := resource.server.GetConnectionInfo()
+	if err != nil {
+		return err
+	}
 
-			err = op.Wait()
-			if err != nil {
-				return fmt.Errorf(i18n.G("Stopping the instance failed: %s"), err)
-			}
+	ct, _, err := resource.server.GetInstance(resource.name)
+	if err != nil {
+		return err
+	}
 
-			if ct.Ephemeral {
-				continue
-			}
+	if ct.StatusCode != 0 && ct.StatusCode != api.Stopped {
+		if !c.flagForce {
+			return fmt.Errorf(i18n.G("The instance is currently running, stop it first or pass --force"))
 		}
 
-		if c.flagForceProtected && util.IsTrue(ct.ExpandedConfig["security.protection.delete"]) {
-			// Refresh in case we had to stop it above.
-			ct, etag, err := resource.server.GetInstance(resource.name)
-			if err != nil {
-				return err
-			}
+		req := api.InstanceStatePut{
+			Action:  "stop",
+			Timeout: -1,
+			Force:   true,
+		}
 
-			ct.Config["security.protection.delete"] = "false"
-			op, err := resource.server.UpdateInstance(resource.name, ct.Writable(), etag)
-			if err != nil {
-				return err
-			}
+		op, err := resource.server.UpdateInstanceState(resource.name, req, "")
+		if err != nil {
+			return err
+		}
 
-			err = op.Wait()
-			if err != nil {
-				return err
-			}
+		err = op.Wait()
+		if err != nil {
+			return fmt.Errorf(i18n.G("Stopping the instance failed: %s"), err)
 		}
 
-		err = c.doDelete(resource.server, resource.name)
+		if ct.Ephemeral {
+			return nil
+		}
+	}
+
+	if c.flagForceProtected && util.IsTrue(ct.ExpandedConfig["security.protection.delete"]) {
+		// Refresh in case we had to stop it above.
+		ct, etag, err := resource.server.GetInstance(resource.name)
+		if err != nil {
+			return err
+		}
+
+		ct.Config["security.protection.delete"] = "false"
+		op, err := resource.server.UpdateInstance(resource.name, ct.Writable(), etag)
+		if err != nil {
+			return err
+		}
+
+		err = op.Wait()
 		if err != nil {
-			return fmt.Errorf(i18n.G("Failed deleting instance %q in project %q: %w"), resource.name, connInfo.Project, err)
+			return err
 		}
 	}
+
+	err = c.doDelete(resource.server, resource.name)
+	if err != nil {
+		return fmt.Errorf(i18n.G("Failed deleting instance %q in project %q: %w"), resource.name, connInfo.Project, err)
+	}
+
 	return nil
 }
@@ -30,6 +30,9 @@ type cmdExec struct {
 	flagUser                uint32
 	flagGroup               uint32
 	flagCwd                 string
+	flagRecordOutput        bool
+	flagLimitsCPU           string
+	flagLimitsMemory        string
 
 	interactive bool
 }
@@ -64,6 +67,9 @@ incus exec c1 -- ls -lh /
 	cmd.Flags().Uint32Var(&c.flagUser, "user", 0, i18n.G("User ID to run the command as (default 0)")+"``")
 	cmd.Flags().Uint32Var(&c.flagGroup, "group", 0, i18n.G("Group ID to run the command as (default 0)")+"``")
 	cmd.Flags().StringVar(&c.flagCwd, "cwd", "", i18n.G("Directory to run the command in (default /root)")+"``")
+	cmd.Flags().BoolVar(&c.flagRecordOutput, "record-output", false, i18n.G("Record the interactive session on the server"))
+	cmd.Flags().StringVar(&c.flagLimitsCPU, "limits-cpu", "", i18n.G("CPU allowance for the command (e.g. 50% or 25ms/100ms)")+"``")
+	cmd.Flags().StringVar(&c.flagLimitsMemory, "limits-memory", "", i18n.G("Memory limit for the command (e.g. 25% or 512MiB)")+"``")
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -156,6 +162,10 @@ func (c *cmdExec) Run(cmd *cobra.Command, args []string) error {
 		c.interactive = stdinTerminal && stdoutTerminal
 	}
 
+	if c.flagRecordOutput && !c.interactive {
+		return fmt.Errorf(i18n.G("--record-output requires an interactive session"))
+	}
+
 	// Record terminal state
 	var oldttystate *termios.State
 	if c.interactive && stdinTerminal {
@@ -200,6 +210,23 @@ func (c *cmdExec) Run(cmd *cobra.Command, args []string) error {
 		Cwd:         c.flagCwd,
 	}
 
+	if c.flagRecordOutput {
+		if !d.HasExtension("instance_exec_recording") {
+			return fmt.Errorf(i18n.G("The server doesn't support recording interactive sessions"))
+		}
+
+		req.RecordOutput = true
+	}
+
+	if c.flagLimitsCPU != "" || c.flagLimitsMemory != "" {
+		if !d.HasExtension("instance_exec_limits") {
+			return fmt.Errorf(i18n.G("The server doesn't support limiting the resources of commands"))
+		}
+
+		req.LimitsCPU = c.flagLimitsCPU
+		req.LimitsMemory = c.flagLimitsMemory
+	}
+
 	execArgs := incus.InstanceExecArgs{
 		Stdin:    stdin,
 		Stdout:   stdout,
@@ -231,5 +258,13 @@ func (c *cmdExec) Run(cmd *cobra.Command, args []string) error {
 	// Wait for any remaining I/O to be flushed
 	<-execArgs.DataDone
 
+	if c.flagRecordOutput {
+		if oldttystate != nil {
+			_ = termios.Restore(stdinFd, oldttystate)
+		}
+
+		fmt.Fprintf(os.Stderr, i18n.G("Session recorded as %s")+"\n", opAPI.ID)
+	}
+
 	return nil
 }
@@ -1,10 +1,15 @@
 package main
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"math/rand"
 	"net"
 	"os"
@@ -13,6 +18,7 @@ import (
 	"path"
 	"path/filepath"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -23,6 +29,7 @@ import (
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
 	internalIO "github.com/lxc/incus/v6/internal/io"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/ioprogress"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/termios"
@@ -47,6 +54,7 @@ type cmdFile struct {
 
 	flagMkdir     bool
 	flagRecursive bool
+	flagArchive   bool
 }
 
 func fileGetWrapper(server incus.InstanceServer, inst string, path string) (buf io.ReadCloser, resp *incus.InstanceFileResponse, err error) {
@@ -105,6 +113,10 @@ func (c *cmdFile) Command() *cobra.Command {
 	filePushCmd := cmdFilePush{global: c.global, file: c}
 	cmd.AddCommand(filePushCmd.Command())
 
+	// Sync
+	fileSyncCmd := cmdFileSync{global: c.global, file: c}
+	cmd.AddCommand(fileSyncCmd.Command())
+
 	// Edit
 	fileEditCmd := cmdFileEdit{global: c.global, file: c, filePull: &filePullCmd, filePush: &filePushCmd}
 	cmd.AddCommand(fileEditCmd.Command())
@@ -434,6 +446,7 @@ func (c *cmdFilePull) Command() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&c.file.flagMkdir, "create-dirs", "p", false, i18n.G("Create any directories necessary"))
 	cmd.Flags().BoolVarP(&c.file.flagRecursive, "recursive", "r", false, i18n.G("Recursively transfer files"))
+	cmd.Flags().BoolVar(&c.file.flagArchive, "archive", false, i18n.G("Transfer directories as a single archive (implies --recursive)"))
 	cmd.RunE = c.Run
 
 	return cmd
@@ -446,6 +459,10 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.file.flagArchive {
+		c.file.flagRecursive = true
+	}
+
 	// Determine the target
 	target := filepath.Clean(args[len(args)-1])
 
@@ -511,7 +528,12 @@ func (c *cmdFilePull) Run(cmd *cobra.Command, args []string) error {
 					targetIsDir = true
 				}
 
-				err := c.file.recursivePullFile(resource.server, pathSpec[0], pathSpec[1], target)
+				if c.file.flagArchive {
+					err = c.file.archivePullFile(resource.server, pathSpec[0], pathSpec[1], target)
+				} else {
+					err = c.file.recursivePullFile(resource.server, pathSpec[0], pathSpec[1], target)
+				}
+
 				if err != nil {
 					return err
 				}
@@ -656,6 +678,7 @@ func (c *cmdFilePush) Command() *cobra.Command {
 	cmd.Flags().IntVar(&c.file.flagUID, "uid", -1, i18n.G("Set the file's uid on push")+"``")
 	cmd.Flags().IntVar(&c.file.flagGID, "gid", -1, i18n.G("Set the file's gid on push")+"``")
 	cmd.Flags().StringVar(&c.file.flagMode, "mode", "", i18n.G("Set the file's perms on push")+"``")
+	cmd.Flags().BoolVar(&c.file.flagArchive, "archive", false, i18n.G("Transfer directories as a single archive (implies --recursive)"))
 	cmd.RunE = c.Run
 
 	return cmd
@@ -668,6 +691,10 @@ func (c *cmdFilePush) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.file.flagArchive {
+		c.file.flagRecursive = true
+	}
+
 	// Parse the destination
 	target := args[len(args)-1]
 	pathSpec := strings.SplitN(target, "/", 2)
@@ -746,7 +773,12 @@ func (c *cmdFilePush) Run(cmd *cobra.Command, args []string) error {
 
 		// Transfer the files
 		for _, fname := range sourcefilenames {
-			err := c.file.recursivePushFile(resource.server, resource.name, fname, targetPath)
+			if c.file.flagArchive {
+				err = c.file.archivePushFile(resource.server, resource.name, fname, targetPath)
+			} else {
+				err = c.file.recursivePushFile(resource.server, resource.name, fname, targetPath)
+			}
+
 			if err != nil {
 				return err
 			}
@@ -994,89 +1026,332 @@ func (c *cmdFile) recursivePushFile(d incus.InstanceServer, inst string, source
 
 		// Prepare for file transfer
 		targetPath := path.Join(target, filepath.ToSlash(p[sourceLen:]))
-		mode, uid, gid := internalIO.GetOwnerMode(fInfo)
-		args := incus.InstanceFileArgs{
-			UID:  int64(uid),
-			GID:  int64(gid),
-			Mode: int(mode.Perm()),
+
+		return c.pushEntry(d, inst, p, targetPath, fInfo)
+	}
+
+	return filepath.Walk(source, sendFile)
+}
+
+// pushEntry transfers a single local file, directory or symlink to the target path in the instance.
+func (c *cmdFile) pushEntry(d incus.InstanceServer, inst string, p string, targetPath string, fInfo os.FileInfo) error {
+	mode, uid, gid := internalIO.GetOwnerMode(fInfo)
+	args := incus.InstanceFileArgs{
+		UID:  int64(uid),
+		GID:  int64(gid),
+		Mode: int(mode.Perm()),
+	}
+
+	var readCloser io.ReadCloser
+
+	if fInfo.IsDir() {
+		// Directory handling
+		args.Type = "directory"
+	} else if fInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+		// Symlink handling
+		symlinkTarget, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+
+		args.Type = "symlink"
+		args.Content = bytes.NewReader([]byte(symlinkTarget))
+		readCloser = io.NopCloser(args.Content)
+	} else {
+		// File handling
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = f.Close() }()
+
+		args.Type = "file"
+		args.Content = f
+		readCloser = f
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: fmt.Sprintf(i18n.G("Pushing %s to %s: %%s"), p, targetPath),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	if args.Type != "directory" {
+		contentLength, err := args.Content.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+
+		_, err = args.Content.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		args.Content = internalIO.NewReadSeeker(&ioprogress.ProgressReader{
+			ReadCloser: readCloser,
+			Tracker: &ioprogress.ProgressTracker{
+				Length: contentLength,
+				Handler: func(percent int64, speed int64) {
+					progress.UpdateProgress(ioprogress.ProgressData{
+						Text: fmt.Sprintf("%d%% (%s/s)", percent,
+							units.GetByteSizeString(speed, 2))})
+				},
+			},
+		}, args.Content)
+	}
+
+	logger.Infof("Pushing %s to %s (%s)", p, targetPath, args.Type)
+	err := d.CreateInstanceFile(inst, targetPath, args)
+	if err != nil {
+		if args.Type != "directory" {
+			progress.Done("")
+		}
+
+		return err
+	}
+
+	if args.Type != "directory" {
+		progress.Done("")
+	}
+
+	return nil
+}
+
+// archivePullFile transfers the directory tree at p from the instance as a single tarball and unpacks it into targetDir.
+func (c *cmdFile) archivePullFile(d incus.InstanceServer, inst string, p string, targetDir string) error {
+	target := filepath.Join(targetDir, filepath.Base(p))
+	logger.Infof("Pulling %s from %s (archive)", target, p)
+
+	content, err := d.GetInstanceFileArchive(inst, p)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = content.Close() }()
+
+	err = os.MkdirAll(target, DirMode)
+	if err != nil {
+		return err
+	}
+
+	progress := cli.ProgressRenderer{
+		Format: fmt.Sprintf(i18n.G("Pulling %s from %s: %%s"), p, target),
+		Quiet:  c.global.flagQuiet,
+	}
+
+	reader := &ioprogress.ProgressReader{
+		ReadCloser: content,
+		Tracker: &ioprogress.ProgressTracker{
+			Handler: func(bytesReceived int64, speed int64) {
+				progress.UpdateProgress(ioprogress.ProgressData{
+					Text: fmt.Sprintf("%s (%s/s)",
+						units.GetByteSizeString(bytesReceived, 2),
+						units.GetByteSizeString(speed, 2))})
+			},
+		},
+	}
+
+	defer progress.Done("")
+
+	return archiveExtract(reader, target)
+}
+
+// archiveExtract unpacks the tarball read from r into target.
+// Entries are kept within target and are never written through a symlink created by an earlier entry.
+func archiveExtract(r io.Reader, target string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
 		}
 
-		var readCloser io.ReadCloser
+		entryPath, err := archiveEntryPath(target, hdr.Name)
+		if err != nil {
+			return err
+		}
 
-		if fInfo.IsDir() {
-			// Directory handling
-			args.Type = "directory"
-		} else if fInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
-			// Symlink handling
-			symlinkTarget, err := os.Readlink(p)
+		// Replace whatever is already there (such as from an earlier pull of the same tree),
+		// apart from directories which are merged.
+		fInfo, err := os.Lstat(entryPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		if err == nil && (hdr.Typeflag != tar.TypeDir || !fInfo.IsDir()) {
+			err = os.Remove(entryPath)
 			if err != nil {
 				return err
 			}
+		}
 
-			args.Type = "symlink"
-			args.Content = bytes.NewReader([]byte(symlinkTarget))
-			readCloser = io.NopCloser(args.Content)
-		} else {
-			// File handling
-			f, err := os.Open(p)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(entryPath, DirMode)
 			if err != nil {
 				return err
 			}
 
-			defer func() { _ = f.Close() }()
+			err = os.Chmod(entryPath, os.FileMode(hdr.Mode).Perm())
+		case tar.TypeReg:
+			var f *os.File
+			f, err = os.OpenFile(entryPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return err
+			}
 
-			args.Type = "file"
-			args.Content = f
-			readCloser = f
+			_, err = io.Copy(f, tr)
+			if err != nil {
+				_ = f.Close()
+				return err
+			}
+
+			err = f.Close()
+		case tar.TypeSymlink:
+			err = os.Symlink(hdr.Linkname, entryPath)
+		default:
+			return fmt.Errorf(i18n.G("Unknown file type '%s'"), string(hdr.Typeflag))
 		}
 
-		progress := cli.ProgressRenderer{
-			Format: fmt.Sprintf(i18n.G("Pushing %s to %s: %%s"), p, targetPath),
-			Quiet:  c.global.flagQuiet,
+		if err != nil {
+			return err
 		}
+	}
 
-		if args.Type != "directory" {
-			contentLength, err := args.Content.Seek(0, io.SeekEnd)
+	return nil
+}
+
+// archiveEntryPath returns the local path of the archive entry name within target.
+// It fails if any of the parents of the entry is a symlink, as following it could escape target.
+// The entry itself isn't checked as it gets replaced rather than followed.
+func archiveEntryPath(target string, name string) (string, error) {
+	rel := filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+name), "/"))
+	if rel == "" {
+		return target, nil
+	}
+
+	parts := strings.Split(rel, string(filepath.Separator))
+
+	current := target
+	for _, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+
+		fInfo, err := os.Lstat(current)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// Nothing further down can exist yet.
+				break
+			}
+
+			return "", err
+		}
+
+		if fInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+			return "", fmt.Errorf(i18n.G("Archive entry %q goes through symlink %q"), name, current)
+		}
+	}
+
+	return filepath.Join(target, rel), nil
+}
+
+// archivePushFile transfers the local source tree to the instance as a single tarball.
+func (c *cmdFile) archivePushFile(d incus.InstanceServer, inst string, source string, target string) error {
+	source = filepath.Clean(source)
+	sourceDir, _ := filepath.Split(source)
+	sourceLen := len(sourceDir)
+
+	logger.Infof("Pushing %s to %s (archive)", source, target)
+
+	pr, pw := io.Pipe()
+
+	// Generate the tarball.
+	go func() {
+		tw := tar.NewWriter(pw)
+
+		err := filepath.Walk(source, func(p string, fInfo os.FileInfo, err error) error {
+			if err != nil {
+				return fmt.Errorf(i18n.G("Failed to walk path for %s: %s"), p, err)
+			}
+
+			// Detect unsupported files
+			if !fInfo.Mode().IsRegular() && !fInfo.Mode().IsDir() && fInfo.Mode()&os.ModeSymlink != os.ModeSymlink {
+				return fmt.Errorf(i18n.G("'%s' isn't a supported file type"), p)
+			}
+
+			link := ""
+			if fInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+				link, err = os.Readlink(p)
+				if err != nil {
+					return err
+				}
+			}
+
+			hdr, err := tar.FileInfoHeader(fInfo, link)
 			if err != nil {
 				return err
 			}
 
-			_, err = args.Content.Seek(0, io.SeekStart)
+			_, uid, gid := internalIO.GetOwnerMode(fInfo)
+			hdr.Name = filepath.ToSlash(p[sourceLen:])
+			hdr.Uid = uid
+			hdr.Gid = gid
+			hdr.Uname = ""
+			hdr.Gname = ""
+
+			err = tw.WriteHeader(hdr)
 			if err != nil {
 				return err
 			}
 
-			args.Content = internalIO.NewReadSeeker(&ioprogress.ProgressReader{
-				ReadCloser: readCloser,
-				Tracker: &ioprogress.ProgressTracker{
-					Length: contentLength,
-					Handler: func(percent int64, speed int64) {
-						progress.UpdateProgress(ioprogress.ProgressData{
-							Text: fmt.Sprintf("%d%% (%s/s)", percent,
-								units.GetByteSizeString(speed, 2))})
-					},
-				},
-			}, args.Content)
-		}
+			if fInfo.Mode().IsRegular() {
+				f, err := os.Open(p)
+				if err != nil {
+					return err
+				}
 
-		logger.Infof("Pushing %s to %s (%s)", p, targetPath, args.Type)
-		err = d.CreateInstanceFile(inst, targetPath, args)
-		if err != nil {
-			if args.Type != "directory" {
-				progress.Done("")
+				defer func() { _ = f.Close() }()
+
+				_, err = io.Copy(tw, f)
+				if err != nil {
+					return err
+				}
 			}
 
-			return err
+			return nil
+		})
+		if err == nil {
+			err = tw.Close()
 		}
 
-		if args.Type != "directory" {
-			progress.Done("")
-		}
+		_ = pw.CloseWithError(err)
+	}()
 
-		return nil
+	progress := cli.ProgressRenderer{
+		Format: fmt.Sprintf(i18n.G("Pushing %s to %s: %%s"), source, target),
+		Quiet:  c.global.flagQuiet,
 	}
 
-	return filepath.Walk(source, sendFile)
+	reader := &ioprogress.ProgressReader{
+		ReadCloser: pr,
+		Tracker: &ioprogress.ProgressTracker{
+			Handler: func(bytesSent int64, speed int64) {
+				progress.UpdateProgress(ioprogress.ProgressData{
+					Text: fmt.Sprintf("%s (%s/s)",
+						units.GetByteSizeString(bytesSent, 2),
+						units.GetByteSizeString(speed, 2))})
+			},
+		},
+	}
+
+	err := d.CreateInstanceFileArchive(inst, target, reader)
+	_ = pr.Close()
+	progress.Done("")
+
+	return err
 }
 
 func (c *cmdFile) recursiveMkdir(d incus.InstanceServer, inst string, p string, mode *os.FileMode, uid int64, gid int64) error {
@@ -1144,6 +1419,8 @@ type cmdFileMount struct {
 	flagListen   string
 	flagAuthNone bool
 	flagAuthUser string
+	flagReadOnly bool
+	flagOptions  []string
 }
 
 func (c *cmdFileMount) Command() *cobra.Command {
@@ -1151,15 +1428,26 @@ func (c *cmdFileMount) Command() *cobra.Command {
 	cmd.Use = usage("mount", i18n.G("[<remote>:]<instance>[/<path>] [<target path>]"))
 	cmd.Short = i18n.G("Mount files from instances")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
-		`Mount files from instances`))
+		`Mount files from instances
+
+When a target path is given, the instance's filesystem is mounted on it
+through sshfs (FUSE) using the instance SFTP API. Without a target path,
+an SSH SFTP server is started locally instead.
+
+SFTP access can be blocked by the project (restricted.sftp).`))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus file mount foo/root fooroot
-   To mount /root from the instance foo onto the local fooroot directory.`))
+   To mount /root from the instance foo onto the local fooroot directory.
+
+incus file mount foo fooroot --read-only
+   To mount the whole filesystem of the instance foo read-only onto the local fooroot directory.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().StringVar(&c.flagListen, "listen", "", i18n.G("Setup SSH SFTP listener on address:port instead of mounting"))
 	cmd.Flags().BoolVar(&c.flagAuthNone, "no-auth", false, i18n.G("Disable authentication when using SSH SFTP listener"))
 	cmd.Flags().StringVar(&c.flagAuthUser, "auth-user", "", i18n.G("Set authentication user when using SSH SFTP listener"))
+	cmd.Flags().BoolVar(&c.flagReadOnly, "read-only", false, i18n.G("Mount the instance filesystem read-only"))
+	cmd.Flags().StringArrayVarP(&c.flagOptions, "option", "o", nil, i18n.G("Additional sshfs mount option (can be specified multiple times)")+"``")
 
 	return cmd
 }
@@ -1199,11 +1487,16 @@ func (c *cmdFileMount) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Target path and --listen flag cannot be used together"))
 	}
 
+	// Check that mount options are only used in sshfs mode.
+	if targetPath == "" && (c.flagReadOnly || len(c.flagOptions) > 0) {
+		return fmt.Errorf(i18n.G("Mount options can only be used when a target path is provided"))
+	}
+
 	instSpec := strings.SplitN(resource.name, "/", 2)
 
-	// Check instance path is provided in sshfs mode.
+	// Default to the root of the instance in sshfs mode.
 	if len(instSpec) < 2 && targetPath != "" {
-		return fmt.Errorf(i18n.G("Invalid instance path: %q"), resource.name)
+		instSpec = append(instSpec, "/")
 	}
 
 	// Check instance path isn't provided in listener mode.
@@ -1245,7 +1538,18 @@ func (c *cmdFileMount) sshfsMount(ctx context.Context, resource remoteResource,
 	// so that the mount can be seen to be associated with Incus and the instance in the local mount table.
 	sourceURL := fmt.Sprintf("incus.%s:%s", instName, instPath)
 
-	sshfsCmd := exec.Command(sshfsPath, "-o", "slave", sourceURL, targetPath)
+	sshfsArgs := []string{"-o", "slave"}
+	if c.flagReadOnly {
+		sshfsArgs = append(sshfsArgs, "-o", "ro")
+	}
+
+	for _, option := range c.flagOptions {
+		sshfsArgs = append(sshfsArgs, "-o", option)
+	}
+
+	sshfsArgs = append(sshfsArgs, sourceURL, targetPath)
+
+	sshfsCmd := exec.Command(sshfsPath, sshfsArgs...)
 
 	// Setup pipes.
 	stdin, err := sshfsCmd.StdinPipe()
@@ -1484,3 +1788,311 @@ func (c *cmdFileMount) sshSFTPServer(ctx context.Context, instName string, resou
 		}()
 	}
 }
+
+// Sync.
+type cmdFileSync struct {
+	global *cmdGlobal
+	file   *cmdFile
+
+	flagDelete bool
+	flagDryRun bool
+}
+
+func (c *cmdFileSync) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("sync", i18n.G("<source path> [<remote>:]<instance>/<path>"))
+	cmd.Short = i18n.G("Synchronize a directory into instances")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Synchronize a directory into instances
+
+The content of the source directory is copied into the target path.
+Only entries whose content, type, ownership or mode differ are transferred,
+based on checksums computed by the server. Changed files are transferred as a whole.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus file sync ./app foo/srv/app --delete
+   To synchronize the local "app" directory into /srv/app in the instance "foo",
+   removing any files that don't exist locally.`))
+
+	cmd.Flags().BoolVar(&c.flagDelete, "delete", false, i18n.G("Delete files in the instance that don't exist in the source"))
+	cmd.Flags().BoolVar(&c.flagDryRun, "dry-run", false, i18n.G("Only show what would be transferred or deleted"))
+	cmd.RunE = c.Run
+
+	return cmd
+}
+
+func (c *cmdFileSync) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse the destination.
+	pathSpec := strings.SplitN(args[1], "/", 2)
+	if len(pathSpec) != 2 {
+		return fmt.Errorf(i18n.G("Invalid target %s"), args[1])
+	}
+
+	targetPath := path.Clean("/" + pathSpec[1])
+
+	resources, err := c.global.ParseServers(pathSpec[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	// Check the source.
+	source := filepath.Clean(args[0])
+	sourceInfo, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	if !sourceInfo.IsDir() {
+		return fmt.Errorf(i18n.G("%s is not a directory"), source)
+	}
+
+	// Create the target directory if missing.
+	_, resp, err := resource.server.GetInstanceFile(resource.name, targetPath)
+	targetExists := err == nil
+	if !targetExists {
+		if !c.flagDryRun {
+			mode, uid, gid := internalIO.GetOwnerMode(sourceInfo)
+
+			err = c.file.recursiveMkdir(resource.server, resource.name, targetPath, &mode, int64(uid), int64(gid))
+			if err != nil {
+				return err
+			}
+		}
+	} else if resp.Type != "directory" {
+		return fmt.Errorf(i18n.G("%s is not a directory"), targetPath)
+	}
+
+	// Get the current state of the target.
+	remoteEntries := map[string]api.InstanceFileEntry{}
+	if targetExists {
+		entries, err := resource.server.GetInstanceFileTree(resource.name, targetPath, true)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			remoteEntries[entry.Path] = entry
+		}
+	}
+
+	pushes, extra, err := fileSyncPlan(source, remoteEntries)
+	if err != nil {
+		return err
+	}
+
+	// Transfer anything that changed.
+	for _, push := range pushes {
+		if push.replace {
+			err = c.deleteEntries(resource.server, resource.name, targetPath, remoteEntries, push.relPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		entryPath := path.Join(targetPath, push.relPath)
+		if c.flagDryRun {
+			fmt.Printf(i18n.G("Would push %s to %s")+"\n", push.localPath, entryPath)
+			continue
+		}
+
+		err = c.file.pushEntry(resource.server, resource.name, push.localPath, entryPath, push.fInfo)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Remove anything that doesn't exist locally.
+	deleted := 0
+	if c.flagDelete {
+		remaining := len(remoteEntries)
+		for _, relPath := range extra {
+			err = c.deleteEntries(resource.server, resource.name, targetPath, remoteEntries, relPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted = remaining - len(remoteEntries)
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Transferred %d entries, deleted %d entries")+"\n", len(pushes), deleted)
+	}
+
+	return nil
+}
+
+// fileSyncPush is a local entry which needs to be transferred to the instance.
+type fileSyncPush struct {
+	relPath   string
+	localPath string
+	fInfo     os.FileInfo
+
+	// replace is set when the existing entry in the instance must be deleted first.
+	replace bool
+}
+
+// fileSyncPlan compares the local source tree with the entries of the instance.
+// It returns the local entries to transfer, in walk order, and the remote entries which don't exist locally.
+// Changed files are transferred as a whole.
+func fileSyncPlan(source string, remoteEntries map[string]api.InstanceFileEntry) ([]fileSyncPush, []string, error) {
+	pushes := []fileSyncPush{}
+	localEntries := map[string]bool{}
+	err := filepath.Walk(source, func(p string, fInfo os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to walk path for %s: %s"), p, err)
+		}
+
+		if p == source {
+			return nil
+		}
+
+		// Detect unsupported files.
+		if !fInfo.Mode().IsRegular() && !fInfo.Mode().IsDir() && fInfo.Mode()&os.ModeSymlink != os.ModeSymlink {
+			return fmt.Errorf(i18n.G("'%s' isn't a supported file type"), p)
+		}
+
+		relPath, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+		localEntries[relPath] = true
+
+		push := fileSyncPush{relPath: relPath, localPath: p, fInfo: fInfo}
+
+		remote, found := remoteEntries[relPath]
+		if found {
+			unchanged, err := fileEntryUnchanged(p, fInfo, remote)
+			if err != nil {
+				return err
+			}
+
+			if unchanged {
+				return nil
+			}
+
+			// Only the content of regular files can be updated in place, replace anything else.
+			push.replace = remote.Type != "file" || fileEntryType(fInfo) != "file" || !fileEntryOwnerModeMatch(fInfo, remote)
+		}
+
+		pushes = append(pushes, push)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extra := []string{}
+	for relPath := range remoteEntries {
+		if !localEntries[relPath] {
+			extra = append(extra, relPath)
+		}
+	}
+
+	sort.Strings(extra)
+
+	return pushes, extra, nil
+}
+
+// fileEntryUnchanged checks whether a local entry matches its counterpart in the instance.
+func fileEntryUnchanged(p string, fInfo os.FileInfo, remote api.InstanceFileEntry) (bool, error) {
+	if remote.Type != fileEntryType(fInfo) {
+		return false, nil
+	}
+
+	switch remote.Type {
+	case "symlink":
+		target, err := os.Readlink(p)
+		if err != nil {
+			return false, err
+		}
+
+		return target == remote.Target, nil
+	case "file":
+		if remote.Size != fInfo.Size() || !fileEntryOwnerModeMatch(fInfo, remote) {
+			return false, nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return false, err
+		}
+
+		defer func() { _ = f.Close() }()
+
+		hash := sha256.New()
+		_, err = io.Copy(hash, f)
+		if err != nil {
+			return false, err
+		}
+
+		return hex.EncodeToString(hash.Sum(nil)) == remote.Checksum, nil
+	}
+
+	return true, nil
+}
+
+// deleteEntries removes an entry from the instance along with anything below it.
+func (c *cmdFileSync) deleteEntries(d incus.InstanceServer, inst string, targetPath string, remoteEntries map[string]api.InstanceFileEntry, relPath string) error {
+	_, found := remoteEntries[relPath]
+	if !found {
+		return nil
+	}
+
+	// Delete children first.
+	children := []string{}
+	for entryPath := range remoteEntries {
+		if strings.HasPrefix(entryPath, relPath+"/") {
+			children = append(children, entryPath)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(children)))
+	children = append(children, relPath)
+
+	for _, entryPath := range children {
+		fullPath := path.Join(targetPath, entryPath)
+		if c.flagDryRun {
+			fmt.Printf(i18n.G("Would delete %s")+"\n", fullPath)
+		} else {
+			logger.Infof("Deleting %s", fullPath)
+
+			err := d.DeleteInstanceFile(inst, fullPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		delete(remoteEntries, entryPath)
+	}
+
+	return nil
+}
+
+// fileEntryOwnerModeMatch checks whether the ownership and mode of a local file match those of an instance entry.
+func fileEntryOwnerModeMatch(fInfo os.FileInfo, remote api.InstanceFileEntry) bool {
+	mode, uid, gid := internalIO.GetOwnerMode(fInfo)
+
+	return remote.Mode == int(mode.Perm()) && remote.UID == int64(uid) && remote.GID == int64(gid)
+}
+
+// fileEntryType returns the file API type matching the local file.
+func fileEntryType(fInfo os.FileInfo) string {
+	if fInfo.IsDir() {
+		return "directory"
+	} else if fInfo.Mode()&os.ModeSymlink == os.ModeSymlink {
+		return "symlink"
+	}
+
+	return "file"
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	internalIO "github.com/lxc/incus/v6/internal/io"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+type testArchiveEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func testArchive(t *testing.T, entries []testArchiveEntry) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	for _, entry := range entries {
+		hdr := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     0o644,
+			Size:     int64(len(entry.content)),
+		}
+
+		if entry.typeflag == tar.TypeDir {
+			hdr.Mode = 0o755
+		}
+
+		err := tw.WriteHeader(hdr)
+		if err != nil {
+			t.Fatalf("Failed to write header: %v", err)
+		}
+
+		_, err = tw.Write([]byte(entry.content))
+		if err != nil {
+			t.Fatalf("Failed to write content: %v", err)
+		}
+	}
+
+	err := tw.Close()
+	if err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+
+	return buf
+}
+
+func TestArchiveExtract(t *testing.T) {
+	target := t.TempDir()
+
+	archive := testArchive(t, []testArchiveEntry{
+		{name: "dir/", typeflag: tar.TypeDir},
+		{name: "dir/file", typeflag: tar.TypeReg, content: "hello"},
+		{name: "dir/link", typeflag: tar.TypeSymlink, linkname: "file"},
+		{name: "../../outside", typeflag: tar.TypeReg, content: "clamped"},
+	})
+
+	err := archiveExtract(archive, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(target, "dir", "file"))
+	if err != nil || string(content) != "hello" {
+		t.Errorf("Expected %q, got %q (%v)", "hello", content, err)
+	}
+
+	link, err := os.Readlink(filepath.Join(target, "dir", "link"))
+	if err != nil || link != "file" {
+		t.Errorf("Expected symlink to %q, got %q (%v)", "file", link, err)
+	}
+
+	content, err = os.ReadFile(filepath.Join(target, "outside"))
+	if err != nil || string(content) != "clamped" {
+		t.Errorf("Expected %q, got %q (%v)", "clamped", content, err)
+	}
+}
+
+func TestArchiveExtractTwice(t *testing.T) {
+	target := t.TempDir()
+
+	for i := 0; i < 2; i++ {
+		archive := testArchive(t, []testArchiveEntry{
+			{name: "dir/", typeflag: tar.TypeDir},
+			{name: "dir/file", typeflag: tar.TypeReg, content: "hello"},
+			{name: "dir/link", typeflag: tar.TypeSymlink, linkname: "file"},
+			{name: "dir/sub/", typeflag: tar.TypeDir},
+		})
+
+		err := archiveExtract(archive, target)
+		if err != nil {
+			t.Fatalf("Unexpected error on extraction %d: %v", i+1, err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(target, "dir", "link"))
+	if err != nil || string(content) != "hello" {
+		t.Errorf("Expected %q, got %q (%v)", "hello", content, err)
+	}
+}
+
+func TestArchiveExtractReplaceSymlink(t *testing.T) {
+	outside := t.TempDir()
+	target := t.TempDir()
+
+	archive := testArchive(t, []testArchiveEntry{
+		{name: "a", typeflag: tar.TypeSymlink, linkname: filepath.Join(outside, ".bashrc")},
+		{name: "a", typeflag: tar.TypeReg, content: "replaced"},
+	})
+
+	err := archiveExtract(archive, target)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The symlink is replaced rather than written through.
+	fInfo, err := os.Lstat(filepath.Join(target, "a"))
+	if err != nil || !fInfo.Mode().IsRegular() {
+		t.Errorf("Expected a regular file replacing the symlink, got %v (%v)", fInfo, err)
+	}
+
+	entries, err := os.ReadDir(outside)
+	if err != nil {
+		t.Fatalf("Failed to read outside directory: %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Errorf("Expected nothing written outside the target, found %d entries", len(entries))
+	}
+}
+
+func TestArchiveExtractSymlinkEscape(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries func(outside string) []testArchiveEntry
+	}{
+		{
+			name: "write through symlinked parent",
+			entries: func(outside string) []testArchiveEntry {
+				return []testArchiveEntry{
+					{name: "a", typeflag: tar.TypeSymlink, linkname: outside},
+					{name: "a/.bashrc", typeflag: tar.TypeReg, content: "evil"},
+				}
+			},
+		},
+		{
+			name: "write through nested symlinked parent",
+			entries: func(outside string) []testArchiveEntry {
+				return []testArchiveEntry{
+					{name: "a/", typeflag: tar.TypeDir},
+					{name: "a/b", typeflag: tar.TypeSymlink, linkname: "../../../../../../" + outside},
+					{name: "a/b/.bashrc", typeflag: tar.TypeReg, content: "evil"},
+				}
+			},
+		},
+		{
+			name: "create directory through symlink",
+			entries: func(outside string) []testArchiveEntry {
+				return []testArchiveEntry{
+					{name: "a", typeflag: tar.TypeSymlink, linkname: outside},
+					{name: "a/sub/", typeflag: tar.TypeDir},
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outside := t.TempDir()
+			target := t.TempDir()
+
+			err := archiveExtract(testArchive(t, test.entries(outside)), target)
+			if err == nil {
+				t.Fatal("Expected error for malicious archive, received nil")
+			}
+
+			entries, err := os.ReadDir(outside)
+			if err != nil {
+				t.Fatalf("Failed to read outside directory: %v", err)
+			}
+
+			if len(entries) != 0 {
+				t.Errorf("Expected nothing written outside the target, found %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func testFileEntry(t *testing.T, p string, entryType string) api.InstanceFileEntry {
+	t.Helper()
+
+	fInfo, err := os.Lstat(p)
+	if err != nil {
+		t.Fatalf("Failed to stat %q: %v", p, err)
+	}
+
+	mode, uid, gid := internalIO.GetOwnerMode(fInfo)
+	entry := api.InstanceFileEntry{
+		Type: entryType,
+		Mode: int(mode.Perm()),
+		UID:  int64(uid),
+		GID:  int64(gid),
+		Size: fInfo.Size(),
+	}
+
+	switch entryType {
+	case "file":
+		content, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("Failed to read %q: %v", p, err)
+		}
+
+		hash := sha256.Sum256(content)
+		entry.Checksum = hex.EncodeToString(hash[:])
+	case "symlink":
+		entry.Target, err = os.Readlink(p)
+		if err != nil {
+			t.Fatalf("Failed to read link %q: %v", p, err)
+		}
+	}
+
+	return entry
+}
+
+func TestFileSyncPlan(t *testing.T) {
+	source := t.TempDir()
+
+	for name, content := range map[string]string{"same": "same", "changed": "new", "new": "new", "was-dir": "file"} {
+		err := os.WriteFile(filepath.Join(source, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := os.Mkdir(filepath.Join(source, "dir"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink("same", filepath.Join(source, "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink("same", filepath.Join(source, "relinked"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Describe the instance side from the local state, then alter it.
+	remoteEntries := map[string]api.InstanceFileEntry{
+		"same":     testFileEntry(t, filepath.Join(source, "same"), "file"),
+		"changed":  testFileEntry(t, filepath.Join(source, "changed"), "file"),
+		"dir":      testFileEntry(t, filepath.Join(source, "dir"), "directory"),
+		"link":     testFileEntry(t, filepath.Join(source, "link"), "symlink"),
+		"relinked": testFileEntry(t, filepath.Join(source, "relinked"), "symlink"),
+	}
+
+	changed := remoteEntries["changed"]
+	changed.Checksum = "0000"
+	remoteEntries["changed"] = changed
+
+	relinked := remoteEntries["relinked"]
+	relinked.Target = "elsewhere"
+	remoteEntries["relinked"] = relinked
+
+	remoteEntries["was-dir"] = remoteEntries["dir"]
+	remoteEntries["was-dir/child"] = remoteEntries["same"]
+	remoteEntries["extra"] = remoteEntries["same"]
+
+	pushes, extra, err := fileSyncPlan(source, remoteEntries)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]bool{
+		"changed":  false,
+		"new":      false,
+		"relinked": true,
+		"was-dir":  true,
+	}
+
+	if len(pushes) != len(expected) {
+		t.Errorf("Expected %d entries to push, got %d: %v", len(expected), len(pushes), pushes)
+	}
+
+	for _, push := range pushes {
+		replace, found := expected[push.relPath]
+		if !found {
+			t.Errorf("Unexpected push of %q", push.relPath)
+			continue
+		}
+
+		if push.replace != replace {
+			t.Errorf("Expected replace=%v for %q, got %v", replace, push.relPath, push.replace)
+		}
+
+		if push.localPath != filepath.Join(source, push.relPath) {
+			t.Errorf("Unexpected local path %q for %q", push.localPath, push.relPath)
+		}
+	}
+
+	if !reflect.DeepEqual(extra, []string{"extra", "was-dir/child"}) {
+		t.Errorf("Unexpected extra entries: %v", extra)
+	}
+}
+
+func TestFileSyncPlanOwnerModeChange(t *testing.T) {
+	source := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(source, "file"), []byte("content"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := testFileEntry(t, filepath.Join(source, "file"), "file")
+	remote.Mode = 0o600
+
+	pushes, extra, err := fileSyncPlan(source, map[string]api.InstanceFileEntry{"file": remote})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(pushes) != 1 || !pushes[0].replace {
+		t.Errorf("Expected the file to be replaced, got %v", pushes)
+	}
+
+	if len(extra) != 0 {
+		t.Errorf("Unexpected extra entries: %v", extra)
+	}
+}
@@ -0,0 +1,705 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdGroup struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for managing instance groups.
+func (c *cmdGroup) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("group")
+	cmd.Short = i18n.G("Manage instance groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage instance groups
+
+Instance groups are named sets of instances which can be started, stopped,
+restarted, paused, resumed and snapshotted together by passing @<group>
+instead of an instance name to those commands.`))
+
+	// Add
+	groupAddCmd := cmdGroupAdd{global: c.global}
+	cmd.AddCommand(groupAddCmd.Command())
+
+	// Create
+	groupCreateCmd := cmdGroupCreate{global: c.global}
+	cmd.AddCommand(groupCreateCmd.Command())
+
+	// Delete
+	groupDeleteCmd := cmdGroupDelete{global: c.global}
+	cmd.AddCommand(groupDeleteCmd.Command())
+
+	// Edit
+	groupEditCmd := cmdGroupEdit{global: c.global}
+	cmd.AddCommand(groupEditCmd.Command())
+
+	// List
+	groupListCmd := cmdGroupList{global: c.global}
+	cmd.AddCommand(groupListCmd.Command())
+
+	// Remove
+	groupRemoveCmd := cmdGroupRemove{global: c.global}
+	cmd.AddCommand(groupRemoveCmd.Command())
+
+	// Rename
+	groupRenameCmd := cmdGroupRename{global: c.global}
+	cmd.AddCommand(groupRenameCmd.Command())
+
+	// Show
+	groupShowCmd := cmdGroupShow{global: c.global}
+	cmd.AddCommand(groupShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Add.
+type cmdGroupAdd struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for adding instances to an instance group.
+func (c *cmdGroupAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("add", i18n.G("[<remote>:]<group> <instance> [<instance>...]"))
+	cmd.Short = i18n.G("Add instances to an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Add instances to an instance group`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return c.global.cmpInstances(toComplete)
+	}
+
+	return cmd
+}
+
+// Run adds instances to an instance group.
+func (c *cmdGroupAdd) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	group, etag, err := resource.server.GetInstanceGroup(resource.name)
+	if err != nil {
+		return err
+	}
+
+	for _, instName := range args[1:] {
+		if slices.Contains(group.Instances, instName) {
+			return fmt.Errorf(i18n.G("Instance %s is already in group %s"), instName, resource.name)
+		}
+
+		group.Instances = append(group.Instances, instName)
+	}
+
+	err = resource.server.UpdateInstanceGroup(resource.name, group.Writable(), etag)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instances %s added to group %s")+"\n", strings.Join(args[1:], ", "), resource.name)
+	}
+
+	return nil
+}
+
+// Create.
+type cmdGroupCreate struct {
+	global *cmdGlobal
+
+	flagDescription string
+}
+
+// Command returns a cobra.Command for creating an instance group.
+func (c *cmdGroupCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("[<remote>:]<group> [<instance>...]"))
+	cmd.Short = i18n.G("Create an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create an instance group`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus group create web web01 web02
+    Create the "web" instance group with the "web01" and "web02" instances.
+
+incus group create web < config.yaml
+    Create an instance group with configuration from config.yaml`))
+
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Instance group description")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(false)
+		}
+
+		return c.global.cmpInstances(toComplete)
+	}
+
+	return cmd
+}
+
+// Run creates an instance group.
+func (c *cmdGroupCreate) Run(cmd *cobra.Command, args []string) error {
+	var stdinData api.InstanceGroupPut
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, -1)
+	if exit {
+		return err
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(contents, &stdinData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	// Create the instance group
+	group := api.InstanceGroupsPost{
+		Name:             resource.name,
+		InstanceGroupPut: stdinData,
+	}
+
+	if c.flagDescription != "" {
+		group.Description = c.flagDescription
+	}
+
+	group.Instances = append(group.Instances, args[1:]...)
+
+	err = resource.server.CreateInstanceGroup(group)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance group %s created")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdGroupDelete struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for deleting an instance group.
+func (c *cmdGroupDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<group>"))
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = i18n.G("Delete an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Delete an instance group
+
+The instances of the group are left untouched.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run deletes an instance group.
+func (c *cmdGroupDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	// Delete the instance group
+	err = resource.server.DeleteInstanceGroup(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance group %s deleted")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdGroupEdit struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for editing an instance group.
+func (c *cmdGroupEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", i18n.G("[<remote>:]<group>"))
+	cmd.Short = i18n.G("Edit an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Edit an instance group`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// helpTemplate returns a string explaining the expected YAML structure for an instance group.
+func (c *cmdGroupEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the instance group.
+### Any line starting with a '# will be ignored.
+###
+### An instance group consists of a description and a list of instances.
+###
+### An example would look like:
+### description: Web servers
+### instances:
+### - web01
+### - web02
+###
+### Note that the name is shown but cannot be changed`)
+}
+
+// Run edits an instance group, either through an editor or from stdin.
+func (c *cmdGroupEdit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		newdata := api.InstanceGroupPut{}
+
+		err = yaml.Unmarshal(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		return resource.server.UpdateInstanceGroup(resource.name, newdata, "")
+	}
+
+	// Extract the current value
+	group, etag, err := resource.server.GetInstanceGroup(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(group)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := textEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor
+		newdata := api.InstanceGroupPut{}
+
+		err = yaml.Unmarshal(content, &newdata)
+		if err == nil {
+			err = resource.server.UpdateInstanceGroup(resource.name, newdata, etag)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = textEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List.
+type cmdGroupList struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for listing the instance groups.
+func (c *cmdGroupList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List the instance groups")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the instance groups`))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run lists the instance groups, their descriptions and instances.
+func (c *cmdGroupList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name != "" {
+		return fmt.Errorf(i18n.G("Filtering isn't supported yet"))
+	}
+
+	groups, err := resource.server.GetInstanceGroups()
+	if err != nil {
+		return err
+	}
+
+	// Render the table
+	data := [][]string{}
+	for _, group := range groups {
+		line := []string{group.Name, group.Description, strings.Join(group.Instances, "\n")}
+		data = append(data, line)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("INSTANCES"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, groups)
+}
+
+// Remove.
+type cmdGroupRemove struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for removing instances from an instance group.
+func (c *cmdGroupRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("remove", i18n.G("[<remote>:]<group> <instance> [<instance>...]"))
+	cmd.Short = i18n.G("Remove instances from an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Remove instances from an instance group`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return c.global.cmpInstances(toComplete)
+	}
+
+	return cmd
+}
+
+// Run removes instances from an instance group.
+func (c *cmdGroupRemove) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	group, etag, err := resource.server.GetInstanceGroup(resource.name)
+	if err != nil {
+		return err
+	}
+
+	for _, instName := range args[1:] {
+		if !slices.Contains(group.Instances, instName) {
+			return fmt.Errorf(i18n.G("Instance %s isn't in group %s"), instName, resource.name)
+		}
+	}
+
+	instances := []string{}
+	for _, instName := range group.Instances {
+		if slices.Contains(args[1:], instName) {
+			continue
+		}
+
+		instances = append(instances, instName)
+	}
+
+	group.Instances = instances
+
+	err = resource.server.UpdateInstanceGroup(resource.name, group.Writable(), etag)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instances %s removed from group %s")+"\n", strings.Join(args[1:], ", "), resource.name)
+	}
+
+	return nil
+}
+
+// Rename.
+type cmdGroupRename struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for renaming an instance group.
+func (c *cmdGroupRename) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("rename", i18n.G("[<remote>:]<group> <new-name>"))
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = i18n.G("Rename an instance group")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Rename an instance group`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run renames an instance group.
+func (c *cmdGroupRename) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	// Perform the rename
+	err = resource.server.RenameInstanceGroup(resource.name, api.InstanceGroupPost{Name: args[1]})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance group %s renamed to %s")+"\n", resource.name, args[1])
+	}
+
+	return nil
+}
+
+// Show.
+type cmdGroupShow struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for showing an instance group.
+func (c *cmdGroupShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<group>"))
+	cmd.Short = i18n.G("Show instance group configurations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show instance group configurations`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceGroups(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run prints the configuration of an instance group in YAML format.
+func (c *cmdGroupShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance group name"))
+	}
+
+	// Show the instance group
+	group, _, err := resource.server.GetInstanceGroup(resource.name)
+	if err != nil {
+		return err
+	}
+
+	return cli.RenderObject(c.flagFormat, &group)
+}
@@ -317,6 +317,8 @@ func (c *cmdImageCopy) Run(cmd *cobra.Command, args []string) error {
 type cmdImageDelete struct {
 	global *cmdGlobal
 	image  *cmdImage
+
+	flagConcurrency int
 }
 
 func (c *cmdImageDelete) Command() *cobra.Command {
@@ -327,6 +329,7 @@ func (c *cmdImageDelete) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Delete images`))
 
+	cmd.Flags().IntVar(&c.flagConcurrency, "concurrency", 1, i18n.G("Maximum number of images to process in parallel (0 for no limit)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -349,24 +352,31 @@ func (c *cmdImageDelete) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	names := make([]string, 0, len(resources))
+	nameResources := make(map[string]remoteResource, len(resources))
 	for _, resource := range resources {
 		if resource.name == "" {
 			return fmt.Errorf(i18n.G("Image identifier missing"))
 		}
 
+		name := fmt.Sprintf("%s:%s", resource.remote, resource.name)
+		names = append(names, name)
+		nameResources[name] = resource
+	}
+
+	results := runBatch(names, c.flagConcurrency, func(name string) error {
+		resource := nameResources[name]
+
 		image := c.image.dereferenceAlias(resource.server, "", resource.name)
 		op, err := resource.server.DeleteImage(image)
 		if err != nil {
 			return err
 		}
 
-		err = op.Wait()
-		if err != nil {
-			return err
-		}
-	}
+		return op.Wait()
+	})
 
-	return nil
+	return batchError(results, fmt.Errorf(i18n.G("Some images failed to be deleted")))
 }
 
 // Edit.
@@ -920,7 +930,8 @@ type cmdImageInfo struct {
 	global *cmdGlobal
 	image  *cmdImage
 
-	flagVM bool
+	flagVM     bool
+	flagFormat string
 }
 
 func (c *cmdImageInfo) Command() *cobra.Command {
@@ -931,6 +942,7 @@ func (c *cmdImageInfo) Command() *cobra.Command {
 		`Show useful information about images`))
 
 	cmd.Flags().BoolVar(&c.flagVM, "vm", false, i18n.G("Query virtual machine images"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -974,6 +986,10 @@ func (c *cmdImageInfo) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, info)
+	}
+
 	public := i18n.G("no")
 	if info.Public {
 		public = i18n.G("yes")
@@ -1497,7 +1513,8 @@ type cmdImageShow struct {
 	global *cmdGlobal
 	image  *cmdImage
 
-	flagVM bool
+	flagVM     bool
+	flagFormat string
 }
 
 func (c *cmdImageShow) Command() *cobra.Command {
@@ -1508,6 +1525,7 @@ func (c *cmdImageShow) Command() *cobra.Command {
 		`Show image properties`))
 
 	cmd.Flags().BoolVar(&c.flagVM, "vm", false, i18n.G("Query virtual machine images"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1552,14 +1570,7 @@ func (c *cmdImageShow) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	properties := info.Writable()
-	data, err := yaml.Marshal(&properties)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &properties)
 }
 
 type cmdImageGetProp struct {
@@ -7,7 +7,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 
 	"github.com/lxc/incus/v6/client"
 	cli "github.com/lxc/incus/v6/internal/cmd"
@@ -21,10 +20,15 @@ import (
 type cmdInfo struct {
 	global *cmdGlobal
 
-	flagShowAccess bool
-	flagShowLog    bool
-	flagResources  bool
-	flagTarget     string
+	flagChecks       bool
+	flagFormat       string
+	flagShowAccess   bool
+	flagShowCrashes  bool
+	flagShowIdmap    bool
+	flagShowLog      bool
+	flagShowSyscalls bool
+	flagResources    bool
+	flagTarget       string
 }
 
 func (c *cmdInfo) Command() *cobra.Command {
@@ -37,14 +41,28 @@ func (c *cmdInfo) Command() *cobra.Command {
 		`incus info [<remote>:]<instance> [--show-log]
     For instance information.
 
+incus info [<remote>:]<instance> --show-syscalls
+    For the system call interception state of a container.
+
+incus info [<remote>:]<instance> --show-idmap
+    For the ID map of a container and its conflicts with other containers.
+
 incus info [<remote>:] [--resources]
-    For server information.`))
+    For server information.
+
+incus info [<remote>:] --checks
+    For the host compatibility checks of the server.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().BoolVar(&c.flagShowAccess, "show-access", false, i18n.G("Show the instance's access list"))
 	cmd.Flags().BoolVar(&c.flagShowLog, "show-log", false, i18n.G("Show the instance's recent log entries"))
+	cmd.Flags().BoolVar(&c.flagShowCrashes, "show-crashes", false, i18n.G("Show the instance's out of memory kills and guest panics"))
+	cmd.Flags().BoolVar(&c.flagShowIdmap, "show-idmap", false, i18n.G("Show the instance's ID map"))
+	cmd.Flags().BoolVar(&c.flagShowSyscalls, "show-syscalls", false, i18n.G("Show the instance's system call interception state"))
 	cmd.Flags().BoolVar(&c.flagResources, "resources", false, i18n.G("Show the resources available to the server"))
+	cmd.Flags().BoolVar(&c.flagChecks, "checks", false, i18n.G("Show the host compatibility checks of the server"))
 	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
@@ -95,19 +113,66 @@ func (c *cmdInfo) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		data, err := yaml.Marshal(access)
+		return cli.RenderObject(c.objectFormat(), access)
+	}
+
+	if c.flagShowIdmap {
+		idmap, _, err := d.GetInstanceIdmap(cName)
 		if err != nil {
 			return err
 		}
 
-		fmt.Printf("%s", data)
+		return cli.RenderObject(c.objectFormat(), idmap)
+	}
 
-		return nil
+	if c.flagShowSyscalls {
+		intercepts, err := d.GetInstanceSyscalls(cName)
+		if err != nil {
+			return err
+		}
+
+		if c.flagFormat != "text" {
+			return cli.RenderObject(c.flagFormat, intercepts)
+		}
+
+		data := [][]string{}
+		for _, intercept := range intercepts {
+			data = append(data, []string{
+				intercept.Name,
+				strings.Join(intercept.Syscalls, ", "),
+				fmt.Sprint(intercept.Enabled),
+				fmt.Sprint(intercept.Loaded),
+				fmt.Sprint(intercept.Intercepted),
+				fmt.Sprint(intercept.Errors),
+				fmt.Sprint(intercept.Skipped),
+			})
+		}
+
+		header := []string{
+			i18n.G("HANDLER"),
+			i18n.G("SYSCALLS"),
+			i18n.G("ENABLED"),
+			i18n.G("LOADED"),
+			i18n.G("INTERCEPTED"),
+			i18n.G("ERRORS"),
+			i18n.G("SKIPPED"),
+		}
+
+		return cli.RenderTable(cli.TableFormatTable, header, data, intercepts)
 	}
 
 	return c.instanceInfo(d, conf.Remotes[remote], cName, c.flagShowLog)
 }
 
+// objectFormat returns the format used to render the information which is shown as YAML by default.
+func (c *cmdInfo) objectFormat() string {
+	if c.flagFormat == "text" {
+		return cli.TableFormatYAML
+	}
+
+	return c.flagFormat
+}
+
 func (c *cmdInfo) renderGPU(gpu api.ResourcesGPUCard, prefix string, initial bool) {
 	if initial {
 		fmt.Print(prefix)
@@ -388,6 +453,31 @@ func (c *cmdInfo) remoteInfo(d incus.InstanceServer) error {
 		d = d.UseTarget(c.flagTarget)
 	}
 
+	if c.flagChecks {
+		checks, err := d.GetServerChecks()
+		if err != nil {
+			return err
+		}
+
+		if c.flagFormat != "text" {
+			return cli.RenderObject(c.flagFormat, checks)
+		}
+
+		data := [][]string{}
+		for _, check := range checks {
+			data = append(data, []string{check.Name, strings.ToUpper(check.Status), check.Description, check.Message})
+		}
+
+		header := []string{
+			i18n.G("NAME"),
+			i18n.G("STATUS"),
+			i18n.G("DESCRIPTION"),
+			i18n.G("MESSAGE"),
+		}
+
+		return cli.RenderTable(cli.TableFormatTable, header, data, checks)
+	}
+
 	if c.flagResources {
 		if !d.HasExtension("resources_v2") {
 			return fmt.Errorf(i18n.G("The server doesn't implement the newer v2 resources API"))
@@ -398,6 +488,10 @@ func (c *cmdInfo) remoteInfo(d incus.InstanceServer) error {
 			return err
 		}
 
+		if c.flagFormat != "text" {
+			return cli.RenderObject(c.flagFormat, resources)
+		}
+
 		// System
 		fmt.Printf(i18n.G("System:") + "\n")
 		if resources.System.UUID != "" {
@@ -511,7 +605,19 @@ func (c *cmdInfo) remoteInfo(d incus.InstanceServer) error {
 
 		// Memory
 		fmt.Printf("\n" + i18n.G("Memory:") + "\n")
-		if resources.Memory.HugepagesTotal > 0 {
+		if len(resources.Memory.Hugepages) > 0 {
+			for _, hugepages := range resources.Memory.Hugepages {
+				if hugepages.Total == 0 {
+					continue
+				}
+
+				fmt.Printf("  "+i18n.G("Hugepages (%s):")+"\n", units.GetByteSizeStringIEC(int64(hugepages.Size), 0))
+				fmt.Printf("    "+i18n.G("Free: %v")+"\n", units.GetByteSizeStringIEC(int64(hugepages.Free), 2))
+				fmt.Printf("    "+i18n.G("Reserved: %v")+"\n", units.GetByteSizeStringIEC(int64(hugepages.Reserved), 2))
+				fmt.Printf("    "+i18n.G("Used: %v")+"\n", units.GetByteSizeStringIEC(int64(hugepages.Used), 2))
+				fmt.Printf("    "+i18n.G("Total: %v")+"\n", units.GetByteSizeStringIEC(int64(hugepages.Total), 2))
+			}
+		} else if resources.Memory.HugepagesTotal > 0 {
 			fmt.Printf("  " + i18n.G("Hugepages:"+"\n"))
 			fmt.Printf("    "+i18n.G("Free: %v")+"\n", units.GetByteSizeStringIEC(int64(resources.Memory.HugepagesTotal-resources.Memory.HugepagesUsed), 2))
 			fmt.Printf("    "+i18n.G("Used: %v")+"\n", units.GetByteSizeStringIEC(int64(resources.Memory.HugepagesUsed), 2))
@@ -607,14 +713,7 @@ func (c *cmdInfo) remoteInfo(d incus.InstanceServer) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&serverStatus)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.objectFormat(), serverStatus)
 }
 
 func (c *cmdInfo) instanceInfo(d incus.InstanceServer, remote config.Remote, name string, showLog bool) error {
@@ -629,6 +728,28 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, remote config.Remote, nam
 		return err
 	}
 
+	if c.flagFormat != "text" {
+		if showLog {
+			return fmt.Errorf(i18n.G("--show-log can't be used with --format"))
+		}
+
+		if c.flagShowCrashes {
+			return fmt.Errorf(i18n.G("--show-crashes can't be used with --format"))
+		}
+
+		return cli.RenderObject(c.flagFormat, inst)
+	}
+
+	// The full instance doesn't include the OVN state of the NICs, get it from the instance state.
+	if inst.IsActive() && d.HasExtension("instance_state_network_details") {
+		state, _, err := d.GetInstanceState(name)
+		if err != nil {
+			return err
+		}
+
+		inst.State = state
+	}
+
 	fmt.Printf(i18n.G("Name: %s")+"\n", inst.Name)
 
 	fmt.Printf(i18n.G("Status: %s")+"\n", strings.ToUpper(inst.Status))
@@ -666,6 +787,28 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, remote config.Remote, nam
 			fmt.Printf(i18n.G("Started: %s")+"\n", inst.State.StartedAt.Local().Format(dateLayout))
 		}
 
+		if len(inst.State.Probes) > 0 {
+			fmt.Println("\n" + i18n.G("Probes:"))
+
+			for _, kind := range []string{"readiness", "liveness"} {
+				probe, ok := inst.State.Probes[kind]
+				if !ok {
+					continue
+				}
+
+				status := i18n.G("failing")
+				if probe.Passing {
+					status = i18n.G("passing")
+				}
+
+				if probe.LastError != "" {
+					fmt.Printf("  %s: %s (%s)\n", kind, status, probe.LastError)
+				} else {
+					fmt.Printf("  %s: %s\n", kind, status)
+				}
+			}
+		}
+
 		fmt.Println("\n" + i18n.G("Resources:"))
 		// Processes
 		fmt.Printf("  "+i18n.G("Processes: %d")+"\n", inst.State.Processes)
@@ -752,6 +895,55 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, remote config.Remote, nam
 				networkInfo += fmt.Sprintf("      %s: %d\n", i18n.G("Packets received"), network[netName].Counters.PacketsReceived)
 				networkInfo += fmt.Sprintf("      %s: %d\n", i18n.G("Packets sent"), network[netName].Counters.PacketsSent)
 
+				if network[netName].LinkSpeed != 0 {
+					networkInfo += fmt.Sprintf("      %s: %dMbit/s\n", i18n.G("Link speed"), network[netName].LinkSpeed)
+				}
+
+				if network[netName].LinkDuplex != "" {
+					networkInfo += fmt.Sprintf("      %s: %s\n", i18n.G("Link duplex"), network[netName].LinkDuplex)
+				}
+
+				if len(network[netName].DropReasons) > 0 {
+					reasons := make([]string, 0, len(network[netName].DropReasons))
+					for reason := range network[netName].DropReasons {
+						reasons = append(reasons, reason)
+					}
+
+					sort.Strings(reasons)
+
+					networkInfo += fmt.Sprintf("      %s:\n", i18n.G("Drops and errors"))
+					for _, reason := range reasons {
+						networkInfo += fmt.Sprintf("        %s: %d\n", reason, network[netName].DropReasons[reason])
+					}
+				}
+
+				if network[netName].Queues != nil {
+					queues := network[netName].Queues
+					networkInfo += fmt.Sprintf("      %s:\n", i18n.G("Queues"))
+					networkInfo += fmt.Sprintf("        %s: %d\n", i18n.G("Receive queues"), queues.RXQueues)
+					networkInfo += fmt.Sprintf("        %s: %d\n", i18n.G("Transmit queues"), queues.TXQueues)
+					networkInfo += fmt.Sprintf("        %s: %d\n", i18n.G("Transmit queue length"), queues.TXQueueLength)
+					networkInfo += fmt.Sprintf("        %s: %s\n", i18n.G("Transmit bytes in flight"), units.GetByteSizeString(int64(queues.TXInflightBytes), 2))
+				}
+
+				if network[netName].OVN != nil {
+					ovn := network[netName].OVN
+					networkInfo += fmt.Sprintf("      %s:\n", i18n.G("OVN port"))
+					networkInfo += fmt.Sprintf("        %s: %s\n", i18n.G("Name"), ovn.LogicalSwitchPort)
+					networkInfo += fmt.Sprintf("        %s: %v\n", i18n.G("Enabled"), ovn.Enabled)
+					networkInfo += fmt.Sprintf("        %s: %v\n", i18n.G("Up"), ovn.Up)
+					if ovn.Chassis != "" {
+						networkInfo += fmt.Sprintf("        %s: %s\n", i18n.G("Chassis"), ovn.Chassis)
+					}
+				}
+
+				if len(network[netName].HostRoutes) > 0 {
+					networkInfo += fmt.Sprintf("      %s:\n", i18n.G("Host routes"))
+					for _, route := range network[netName].HostRoutes {
+						networkInfo += fmt.Sprintf("        - %s\n", route)
+					}
+				}
+
 				networkInfo += fmt.Sprintf("      %s:\n", i18n.G("IP addresses"))
 
 				for _, addr := range network[netName].Addresses {
@@ -893,5 +1085,37 @@ func (c *cmdInfo) instanceInfo(d incus.InstanceServer, remote config.Remote, nam
 		fmt.Printf("\n"+i18n.G("Log:")+"\n\n%s\n", string(stuff))
 	}
 
+	if c.flagShowCrashes {
+		crashes, err := d.GetInstanceCrashes(name)
+		if err != nil {
+			return err
+		}
+
+		crashData := [][]string{}
+		for _, crash := range crashes {
+			details := []string{}
+			for k, v := range crash.Details {
+				details = append(details, fmt.Sprintf("%s=%s", k, v))
+			}
+
+			sort.Strings(details)
+
+			if crash.Type == api.InstanceCrashOOMKill {
+				details = append(details, fmt.Sprintf(i18n.G("%d processes killed"), crash.Count))
+			}
+
+			crashData = append(crashData, []string{crash.Timestamp.Local().Format(dateLayout), crash.Type, strings.Join(details, ", ")})
+		}
+
+		crashHeader := []string{
+			i18n.G("Date"),
+			i18n.G("Type"),
+			i18n.G("Details"),
+		}
+
+		fmt.Println("\n" + i18n.G("Crashes:"))
+		_ = cli.RenderTable(cli.TableFormatTable, crashHeader, crashData, crashes)
+	}
+
 	return nil
 }
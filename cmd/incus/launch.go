@@ -33,7 +33,10 @@ incus launch images:ubuntu/22.04 u2 -t aws:t2.micro
     Create and start a container using the same size as an AWS t2.micro (1 vCPU, 1GiB of RAM)
 
 incus launch images:ubuntu/22.04 v1 --vm -c limits.cpu=4 -c limits.memory=4GiB
-    Create and start a virtual machine with 4 vCPUs and 4GiB of RAM`))
+    Create and start a virtual machine with 4 vCPUs and 4GiB of RAM
+
+incus launch images:debian/12 web02 --template web
+    Create and start an instance using the configuration, devices and profiles of the "web" instance template`))
 	cmd.Hidden = false
 
 	cmd.RunE = c.Run
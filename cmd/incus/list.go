@@ -118,7 +118,11 @@ Custom columns are defined with "[config:|devices:]key[:name][:maxWidth]":
   Defaults to the key if not specified or empty.
 
   MAXWIDTH: Max width of the column (longer results are truncated).
-  Defaults to -1 (unlimited). Use 0 to limit to the column header size.`))
+  Defaults to -1 (unlimited). Use 0 to limit to the column header size.
+
+Named column presets can be defined under "list-columns" in the client
+configuration and passed to -c by name. A remote's "list_columns" property
+selects the preset used when neither -c nor --fast is specified.`))
 
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus list -c nFs46,volatile.eth0.hwaddr:MAC,config:image.os,devices:eth0.parent:ETHP
@@ -503,6 +507,12 @@ func (c *cmdList) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Apply any configured column preset.
+	err = c.applyColumnPreset(cmd, conf, remote)
+	if err != nil {
+		return err
+	}
+
 	// Get the list of columns
 	columns, needsData, err := c.parseColumns(d.IsClustered())
 	if err != nil {
@@ -564,6 +574,38 @@ func (c *cmdList) Run(cmd *cobra.Command, args []string) error {
 	return c.listInstances(conf, d, instancesFiltered, clientFilters, columns)
 }
 
+// applyColumnPreset resolves the named column presets from the client configuration.
+// A --columns value matching a preset name is expanded, otherwise the remote's
+// default preset is used when neither --columns nor --fast were passed.
+func (c *cmdList) applyColumnPreset(cmd *cobra.Command, conf *config.Config, remote string) error {
+	if cmd.Flags().Changed("columns") {
+		columns, ok := conf.ListColumns[c.flagColumns]
+		if ok {
+			c.flagColumns = columns
+		}
+
+		return nil
+	}
+
+	if c.flagFast {
+		return nil
+	}
+
+	preset := conf.Remotes[remote].ListColumns
+	if preset == "" {
+		return nil
+	}
+
+	columns, ok := conf.ListColumns[preset]
+	if !ok {
+		return fmt.Errorf(i18n.G("Column preset %q for remote %q doesn't exist"), preset, remote)
+	}
+
+	c.flagColumns = columns
+
+	return nil
+}
+
 func (c *cmdList) parseColumns(clustered bool) ([]column, bool, error) {
 	columnsShorthandMap := map[rune]column{
 		'4': {i18n.G("IPV4"), c.IP4ColumnData, true, false},
@@ -9,6 +9,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/shared/api"
+	config "github.com/lxc/incus/v6/shared/cliconfig"
 )
 
 func TestDotPrefixMatch(t *testing.T) {
@@ -341,3 +342,47 @@ func TestInvalidColumns(t *testing.T) {
 	run("config:image")
 	run("devices:eth0")
 }
+
+func TestApplyColumnPreset(t *testing.T) {
+	conf := &config.Config{
+		ListColumns: map[string]string{"vms": "nsmMu"},
+		Remotes: map[string]config.Remote{
+			"foo": {ListColumns: "vms"},
+			"bar": {ListColumns: "missing"},
+			"baz": {},
+		},
+	}
+
+	run := func(remote string, args []string, expected string, fails bool) {
+		list := cmdList{}
+		cmd := list.Command()
+		err := cmd.ParseFlags(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = list.applyColumnPreset(cmd, conf, remote)
+		if fails {
+			if err == nil {
+				t.Errorf("Expected error for remote %q, received nil", remote)
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for remote %q: %v", remote, err)
+		}
+
+		if list.flagColumns != expected {
+			t.Errorf("Expected columns %q for remote %q, got %q", expected, remote, list.flagColumns)
+		}
+	}
+
+	run("foo", nil, "nsmMu", false)
+	run("foo", []string{"-c", "ns"}, "ns", false)
+	run("foo", []string{"--fast"}, defaultColumns, false)
+	run("baz", nil, defaultColumns, false)
+	run("baz", []string{"-c", "vms"}, "nsmMu", false)
+	run("bar", nil, "", true)
+}
@@ -121,6 +121,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	adminCmd := cmdAdmin{global: &globalCmd}
 	app.AddCommand(adminCmd.Command())
 
+	// apply sub-command
+	applyCmd := cmdApply{global: &globalCmd}
+	app.AddCommand(applyCmd.Command())
+
 	// cluster sub-command
 	clusterCmd := cmdCluster{global: &globalCmd}
 	app.AddCommand(clusterCmd.Command())
@@ -157,6 +161,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	fileCmd := cmdFile{global: &globalCmd}
 	app.AddCommand(fileCmd.Command())
 
+	// group sub-command
+	groupCmd := cmdGroup{global: &globalCmd}
+	app.AddCommand(groupCmd.Command())
+
 	// import sub-command
 	importCmd := cmdImport{global: &globalCmd}
 	app.AddCommand(importCmd.Command())
@@ -237,6 +245,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	resumeCmd := cmdResume{global: &globalCmd}
 	app.AddCommand(resumeCmd.Command())
 
+	// shell sub-command
+	shellCmd := cmdShell{global: &globalCmd}
+	app.AddCommand(shellCmd.Command())
+
 	// snapshot sub-command
 	snapshotCmd := cmdSnapshot{global: &globalCmd}
 	app.AddCommand(snapshotCmd.Command())
@@ -253,6 +265,10 @@ Custom commands can be defined through aliases, use "incus alias" to control tho
 	stopCmd := cmdStop{global: &globalCmd}
 	app.AddCommand(stopCmd.Command())
 
+	// template sub-command
+	templateCmd := cmdTemplate{global: &globalCmd}
+	app.AddCommand(templateCmd.Command())
+
 	// version sub-command
 	versionCmd := cmdVersion{global: &globalCmd}
 	app.AddCommand(versionCmd.Command())
@@ -368,6 +384,14 @@ func (c *cmdGlobal) PreRun(cmd *cobra.Command, args []string) error {
 		c.conf = config.NewConfig(filepath.Dir(c.confPath), true)
 	}
 
+	// Add the remotes defined in the environment
+	if !c.flagForceLocal {
+		err = c.conf.LoadEnvironmentRemotes()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Override the project
 	if c.flagProject != "" {
 		c.conf.ProjectOverride = c.flagProject
@@ -24,6 +24,7 @@ type cmdMove struct {
 	flagStorage           string
 	flagTarget            string
 	flagTargetProject     string
+	flagTargetToken       string
 	flagAllowInconsistent bool
 }
 
@@ -41,6 +42,9 @@ Transfer modes (--mode):
  - relay: The CLI connects to both source and server and proxies the data (both source and target must listen on network)
 
 The pull transfer mode is the default as it is compatible with all server versions.
+
+A migration token issued by the target server (--target-token) can be used to have the source server
+push the instance without the servers having to trust each other.
 `))
 	cmd.Example = cli.FormatSection("", i18n.G(
 		`incus move [<remote>:]<source instance> [<remote>:][<destination instance>] [--instance-only]
@@ -50,7 +54,10 @@ incus move <old name> <new name> [--instance-only]
     Rename a local instance.
 
 incus move <instance>/<old snapshot name> <instance>/<new snapshot name>
-    Rename a snapshot.`))
+    Rename a snapshot.
+
+incus move [<remote>:]<instance> [<new name>] --target-token <token>
+    Move an instance to the server which issued the migration token.`))
 
 	cmd.RunE = c.Run
 	cmd.Flags().StringArrayVarP(&c.flagConfig, "config", "c", nil, i18n.G("Config key/value to apply to the target instance")+"``")
@@ -63,6 +70,7 @@ incus move <instance>/<old snapshot name> <instance>/<new snapshot name>
 	cmd.Flags().StringVarP(&c.flagStorage, "storage", "s", "", i18n.G("Storage pool name")+"``")
 	cmd.Flags().StringVar(&c.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 	cmd.Flags().StringVar(&c.flagTargetProject, "target-project", "", i18n.G("Copy to a project different from the source")+"``")
+	cmd.Flags().StringVar(&c.flagTargetToken, "target-token", "", i18n.G("Migration token issued by the target server")+"``")
 	cmd.Flags().BoolVar(&c.flagAllowInconsistent, "allow-inconsistent", false, i18n.G("Ignore copy errors for volatile files"))
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -84,7 +92,7 @@ func (c *cmdMove) Run(cmd *cobra.Command, args []string) error {
 	conf := c.global.conf
 
 	// Quick checks.
-	if c.flagTarget == "" && c.flagTargetProject == "" && c.flagStorage == "" {
+	if c.flagTarget == "" && c.flagTargetProject == "" && c.flagStorage == "" && c.flagTargetToken == "" {
 		exit, err := c.global.CheckArgs(cmd, args, 2, 2)
 		if exit {
 			return err
@@ -117,6 +125,32 @@ func (c *cmdMove) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Push the instance to the server which issued the migration token.
+	if c.flagTargetToken != "" {
+		if c.flagTarget != "" || c.flagTargetProject != "" || c.flagMode != moveDefaultMode {
+			return fmt.Errorf(i18n.G("--target-token can't be used with --target, --target-project or --mode"))
+		}
+
+		if len(args) == 2 && destRemote != conf.DefaultRemote {
+			return fmt.Errorf(i18n.G("The destination name can't include a remote when using --target-token"))
+		}
+
+		err = c.moveInstance(args[0], sourceRemote+":"+destName, !c.flagStateless)
+		if err != nil {
+			return err
+		}
+
+		del := cmdDelete{global: c.global}
+		del.flagForce = true
+		del.flagForceProtected = true
+		err = del.Run(cmd, args[:1])
+		if err != nil {
+			return fmt.Errorf(i18n.G("Failed to delete original instance after copying it: %w"), err)
+		}
+
+		return nil
+	}
+
 	// As an optimization, if the source and destination are the same, do
 	// this via a simple rename. This only works for instances that aren't
 	// running, instances that are running should be live migrated (of
@@ -278,6 +312,7 @@ func (c *cmdMove) moveInstance(sourceResource string, destResource string, state
 		Pool:         c.flagStorage,
 		Project:      c.flagTargetProject,
 		Live:         stateful,
+		TargetToken:  c.flagTargetToken,
 	}
 
 	// Override profiles.
@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -84,6 +86,10 @@ func (c *cmdNetwork) Command() *cobra.Command {
 	networkListLeasesCmd := cmdNetworkListLeases{global: c.global, network: c}
 	cmd.AddCommand(networkListLeasesCmd.Command())
 
+	// Move gateway
+	networkMoveGatewayCmd := cmdNetworkMoveGateway{global: c.global, network: c}
+	cmd.AddCommand(networkMoveGatewayCmd.Command())
+
 	// Rename
 	networkRenameCmd := cmdNetworkRename{global: c.global, network: c}
 	cmd.AddCommand(networkRenameCmd.Command())
@@ -96,6 +102,10 @@ func (c *cmdNetwork) Command() *cobra.Command {
 	networkShowCmd := cmdNetworkShow{global: c.global, network: c}
 	cmd.AddCommand(networkShowCmd.Command())
 
+	// Trace
+	networkTraceCmd := cmdNetworkTrace{global: c.global, network: c}
+	cmd.AddCommand(networkTraceCmd.Command())
+
 	// Unset
 	networkUnsetCmd := cmdNetworkUnset{global: c.global, network: c, networkSet: &networkSetCmd}
 	cmd.AddCommand(networkUnsetCmd.Command())
@@ -104,6 +114,10 @@ func (c *cmdNetwork) Command() *cobra.Command {
 	networkACLCmd := cmdNetworkACL{global: c.global}
 	cmd.AddCommand(networkACLCmd.Command())
 
+	// DHCP reservation
+	networkDHCPReservationCmd := cmdNetworkDHCPReservation{global: c.global}
+	cmd.AddCommand(networkDHCPReservationCmd.Command())
+
 	// Forward
 	networkForwardCmd := cmdNetworkForward{global: c.global}
 	cmd.AddCommand(networkForwardCmd.Command())
@@ -908,6 +922,8 @@ func (c *cmdNetworkGet) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkInfo struct {
 	global  *cmdGlobal
 	network *cmdNetwork
+
+	flagFormat string
 }
 
 func (c *cmdNetworkInfo) Command() *cobra.Command {
@@ -918,6 +934,7 @@ func (c *cmdNetworkInfo) Command() *cobra.Command {
 		`Get runtime information on networks`))
 
 	cmd.Flags().StringVar(&c.network.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -965,6 +982,10 @@ func (c *cmdNetworkInfo) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, state)
+	}
+
 	// Interface information.
 	fmt.Printf(i18n.G("Name: %s")+"\n", resource.name)
 	fmt.Printf(i18n.G("MAC address: %s")+"\n", state.Hwaddr)
@@ -1030,6 +1051,37 @@ func (c *cmdNetworkInfo) Run(cmd *cobra.Command, args []string) error {
 		if client.HasExtension("network_state_ovn_lr") {
 			fmt.Printf("  %s: %s\n", i18n.G("Logical router"), state.OVN.LogicalRouter)
 		}
+
+		if len(state.OVN.GatewayChassis) > 0 {
+			fmt.Printf("  %s:\n", i18n.G("Gateway chassis"))
+			for _, chassis := range state.OVN.GatewayChassis {
+				if chassis.Active {
+					fmt.Printf("    - %s (%s: %d, %s)\n", chassis.Name, i18n.G("priority"), chassis.Priority, i18n.G("active"))
+				} else {
+					fmt.Printf("    - %s (%s: %d)\n", chassis.Name, i18n.G("priority"), chassis.Priority)
+				}
+			}
+		}
+	}
+
+	// Uplink information.
+	if state.Uplink != nil {
+		fmt.Println("")
+		fmt.Println(i18n.G("Uplink:"))
+
+		if len(state.Uplink.Ranges) > 0 {
+			fmt.Printf("  %s:\n", i18n.G("OVN ranges"))
+			for _, r := range state.Uplink.Ranges {
+				fmt.Printf("    - %s (%s: %d/%d)\n", r.Range, i18n.G("allocated"), r.Used, r.Total)
+			}
+		}
+
+		if len(state.Uplink.Networks) > 0 {
+			fmt.Printf("  %s:\n", i18n.G("OVN networks"))
+			for _, usage := range state.Uplink.Networks {
+				fmt.Printf("    - %s/%s: %s\n", usage.Project, usage.Name, strings.Join(usage.Addresses, ", "))
+			}
+		}
 	}
 
 	return nil
@@ -1306,6 +1358,68 @@ func (c *cmdNetworkListLeases) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(c.flagFormat, header, data, leases)
 }
 
+// Move gateway.
+type cmdNetworkMoveGateway struct {
+	global  *cmdGlobal
+	network *cmdNetwork
+}
+
+func (c *cmdNetworkMoveGateway) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("move-gateway", i18n.G("[<remote>:]<network> <chassis>"))
+	cmd.Short = i18n.G("Move the gateway of a network to another chassis")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Move the gateway of a network to another chassis
+
+The chassis gets the highest priority in the chassis group of the OVN network,
+until the network is next started on it.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network move-gateway ovn0 server02
+    Move the gateway of network ovn0 to chassis server02`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.global.cmpNetworks(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkMoveGateway) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	err = resource.server.MoveNetworkGateway(resource.name, api.NetworkGatewayPost{Chassis: args[1]})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Gateway of network %s moved to %s")+"\n", resource.name, args[1])
+	}
+
+	return nil
+}
+
 // Rename.
 type cmdNetworkRename struct {
 	global  *cmdGlobal
@@ -1467,6 +1581,8 @@ func (c *cmdNetworkSet) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkShow struct {
 	global  *cmdGlobal
 	network *cmdNetwork
+
+	flagFormat string
 }
 
 func (c *cmdNetworkShow) Command() *cobra.Command {
@@ -1477,6 +1593,7 @@ func (c *cmdNetworkShow) Command() *cobra.Command {
 		`Show network configurations`))
 
 	cmd.Flags().StringVar(&c.network.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1522,14 +1639,123 @@ func (c *cmdNetworkShow) Run(cmd *cobra.Command, args []string) error {
 
 	sort.Strings(network.UsedBy)
 
-	data, err := yaml.Marshal(&network)
+	return cli.RenderObject(c.flagFormat, &network)
+}
+
+// Trace.
+type cmdNetworkTrace struct {
+	global  *cmdGlobal
+	network *cmdNetwork
+
+	flagSource     string
+	flagDevice     string
+	flagDest       string
+	flagProtocol   string
+	flagSourcePort uint64
+	flagFormat     string
+}
+
+func (c *cmdNetworkTrace) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("trace", i18n.G("[<remote>:]<network> --src <instance> --dst <address>[:<port>]"))
+	cmd.Short = i18n.G("Trace a packet through a network")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Trace a packet through a network
+
+The packet is sent from the NIC of the instance connected to the network and the steps
+it goes through are shown, followed by the verdict (allow, drop or reject).`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network trace ovn0 --src c1 --dst 10.0.0.1:80
+    Trace a TCP connection from instance c1 to port 80 of 10.0.0.1
+
+incus network trace incusbr0 --src c1 --dst 192.0.2.1 --protocol icmp4
+    Trace a ping from instance c1 to 192.0.2.1`))
+
+	cmd.Flags().StringVar(&c.flagSource, "src", "", i18n.G("Instance the packet is sent from")+"``")
+	cmd.Flags().StringVar(&c.flagDevice, "device", "", i18n.G("NIC device of the instance the packet is sent from")+"``")
+	cmd.Flags().StringVar(&c.flagDest, "dst", "", i18n.G("Destination address and port of the packet")+"``")
+	cmd.Flags().StringVar(&c.flagProtocol, "protocol", "tcp", i18n.G("Protocol of the packet (tcp|udp|icmp4|icmp6)")+"``")
+	cmd.Flags().Uint64Var(&c.flagSourcePort, "src-port", 0, i18n.G("Source port of the packet")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return c.global.cmpNetworks(toComplete)
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkTrace) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagSource == "" {
+		return fmt.Errorf(i18n.G("The source instance must be specified with --src"))
+	}
+
+	if c.flagDest == "" {
+		return fmt.Errorf(i18n.G("The destination must be specified with --dst"))
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", data)
+	resource := resources[0]
 
-	return nil
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	trace := api.NetworkTracePost{
+		Instance:    c.flagSource,
+		Device:      c.flagDevice,
+		Protocol:    c.flagProtocol,
+		SourcePort:  c.flagSourcePort,
+		Destination: c.flagDest,
+	}
+
+	// The port is optional for ICMP.
+	host, port, err := net.SplitHostPort(c.flagDest)
+	if err == nil {
+		trace.Destination = host
+		trace.DestinationPort, err = strconv.ParseUint(port, 10, 16)
+		if err != nil {
+			return fmt.Errorf(i18n.G("Invalid destination port %q"), port)
+		}
+	}
+
+	result, err := resource.server.TraceNetwork(resource.name, trace)
+	if err != nil {
+		return err
+	}
+
+	data := [][]string{}
+	for _, step := range result.Steps {
+		data = append(data, []string{step.Stage, step.Match, step.Action})
+	}
+
+	if c.flagFormat == "table" {
+		fmt.Printf(i18n.G("Source: %s")+"\n", result.Source)
+		fmt.Printf(i18n.G("Verdict: %s")+"\n\n", result.Verdict)
+	}
+
+	header := []string{
+		i18n.G("STAGE"),
+		i18n.G("MATCH"),
+		i18n.G("ACTION"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, result)
 }
 
 // Unset.
@@ -39,6 +39,10 @@ func (c *cmdNetworkACL) Command() *cobra.Command {
 	networkACLShowLogCmd := cmdNetworkACLShowLog{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLShowLogCmd.Command())
 
+	// Show effective.
+	networkACLShowEffectiveCmd := cmdNetworkACLShowEffective{global: c.global, networkACL: c}
+	cmd.AddCommand(networkACLShowEffectiveCmd.Command())
+
 	// Get.
 	networkACLGetCmd := cmdNetworkACLGet{global: c.global, networkACL: c}
 	cmd.AddCommand(networkACLGetCmd.Command())
@@ -181,6 +185,8 @@ func (c *cmdNetworkACLList) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkACLShow struct {
 	global     *cmdGlobal
 	networkACL *cmdNetworkACL
+
+	flagFormat string
 }
 
 func (c *cmdNetworkACLShow) Command() *cobra.Command {
@@ -188,6 +194,7 @@ func (c *cmdNetworkACLShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<ACL>"))
 	cmd.Short = i18n.G("Show network ACL configurations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network ACL configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -228,14 +235,7 @@ func (c *cmdNetworkACLShow) Run(cmd *cobra.Command, args []string) error {
 
 	sort.Strings(netACL.UsedBy)
 
-	data, err := yaml.Marshal(&netACL)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &netACL)
 }
 
 // Show log.
@@ -292,6 +292,70 @@ func (c *cmdNetworkACLShowLog) Run(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// Show effective.
+type cmdNetworkACLShowEffective struct {
+	global     *cmdGlobal
+	networkACL *cmdNetworkACL
+
+	flagDevice string
+	flagFormat string
+}
+
+func (c *cmdNetworkACLShowEffective) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show-effective", i18n.G("[<remote>:]<ACL> <instance>"))
+	cmd.Short = i18n.G("Show the effective network ACL rules of an instance")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Show the effective network ACL rules of an instance
+
+For each NIC of the instance using the ACL, either directly or through its network, the rules of all
+the ACLs applied to the NIC are shown in the order they're evaluated in, followed by the default rule.`))
+	cmd.Flags().StringVar(&c.flagDevice, "device", "", i18n.G("Only show the rules of this NIC device")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworkACLs(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkACLShowEffective) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network ACL name"))
+	}
+
+	// Show the effective rules.
+	effective, err := resource.server.GetNetworkACLEffective(resource.name, args[1], c.flagDevice)
+	if err != nil {
+		return err
+	}
+
+	return cli.RenderObject(c.flagFormat, &effective)
+}
+
 // Get.
 type cmdNetworkACLGet struct {
 	global     *cmdGlobal
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdNetworkDHCPReservation struct {
+	global *cmdGlobal
+}
+
+func (c *cmdNetworkDHCPReservation) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("dhcp-reservation")
+	cmd.Short = i18n.G("Manage network DHCP reservations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Manage network DHCP reservations"))
+
+	// List.
+	networkDHCPReservationListCmd := cmdNetworkDHCPReservationList{global: c.global, networkDHCPReservation: c}
+	cmd.AddCommand(networkDHCPReservationListCmd.Command())
+
+	// Show.
+	networkDHCPReservationShowCmd := cmdNetworkDHCPReservationShow{global: c.global, networkDHCPReservation: c}
+	cmd.AddCommand(networkDHCPReservationShowCmd.Command())
+
+	// Create.
+	networkDHCPReservationCreateCmd := cmdNetworkDHCPReservationCreate{global: c.global, networkDHCPReservation: c}
+	cmd.AddCommand(networkDHCPReservationCreateCmd.Command())
+
+	// Edit.
+	networkDHCPReservationEditCmd := cmdNetworkDHCPReservationEdit{global: c.global, networkDHCPReservation: c}
+	cmd.AddCommand(networkDHCPReservationEditCmd.Command())
+
+	// Delete.
+	networkDHCPReservationDeleteCmd := cmdNetworkDHCPReservationDelete{global: c.global, networkDHCPReservation: c}
+	cmd.AddCommand(networkDHCPReservationDeleteCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// List.
+type cmdNetworkDHCPReservationList struct {
+	global                 *cmdGlobal
+	networkDHCPReservation *cmdNetworkDHCPReservation
+
+	flagFormat string
+}
+
+func (c *cmdNetworkDHCPReservationList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]<network>"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List available network DHCP reservations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("List available network DHCP reservations"))
+
+	cmd.RunE = c.Run
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkDHCPReservationList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	reservations, err := resource.server.GetNetworkDHCPReservations(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data := make([][]string, 0, len(reservations))
+	for _, reservation := range reservations {
+		data = append(data, []string{
+			reservation.Hwaddr,
+			reservation.IPv4Address,
+			reservation.IPv6Address,
+			reservation.Hostname,
+			reservation.Description,
+		})
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("MAC ADDRESS"),
+		i18n.G("IPV4"),
+		i18n.G("IPV6"),
+		i18n.G("HOSTNAME"),
+		i18n.G("DESCRIPTION"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, reservations)
+}
+
+// Show.
+type cmdNetworkDHCPReservationShow struct {
+	global                 *cmdGlobal
+	networkDHCPReservation *cmdNetworkDHCPReservation
+
+	flagFormat string
+}
+
+func (c *cmdNetworkDHCPReservationShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<network> <MAC>"))
+	cmd.Short = i18n.G("Show network DHCP reservation configurations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network DHCP reservation configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkDHCPReservations(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkDHCPReservationShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing MAC address"))
+	}
+
+	// Show the network DHCP reservation config.
+	reservation, _, err := resource.server.GetNetworkDHCPReservation(resource.name, args[1])
+	if err != nil {
+		return err
+	}
+
+	return cli.RenderObject(c.flagFormat, &reservation)
+}
+
+// Create.
+type cmdNetworkDHCPReservationCreate struct {
+	global                 *cmdGlobal
+	networkDHCPReservation *cmdNetworkDHCPReservation
+
+	flagIPv4        string
+	flagIPv6        string
+	flagHostname    string
+	flagDescription string
+}
+
+func (c *cmdNetworkDHCPReservationCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("[<remote>:]<network> <MAC>"))
+	cmd.Short = i18n.G("Create new network DHCP reservations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Create new network DHCP reservations"))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network dhcp-reservation create n1 00:16:3e:c4:32:77 --ipv4 10.0.0.10 --hostname printer
+
+incus network dhcp-reservation create n1 00:16:3e:c4:32:77 < config.yaml
+    Create a new network DHCP reservation for network n1 from config.yaml`))
+
+	cmd.RunE = c.Run
+
+	cmd.Flags().StringVar(&c.flagIPv4, "ipv4", "", i18n.G("IPv4 address to reserve")+"``")
+	cmd.Flags().StringVar(&c.flagIPv6, "ipv6", "", i18n.G("IPv6 address to reserve")+"``")
+	cmd.Flags().StringVar(&c.flagHostname, "hostname", "", i18n.G("Host name of the device")+"``")
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Reservation description")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkDHCPReservationCreate) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing MAC address"))
+	}
+
+	// If stdin isn't a terminal, read yaml from it.
+	var reservationPut api.NetworkDHCPReservationPut
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &reservationPut)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply the flags on top.
+	if c.flagIPv4 != "" {
+		reservationPut.IPv4Address = c.flagIPv4
+	}
+
+	if c.flagIPv6 != "" {
+		reservationPut.IPv6Address = c.flagIPv6
+	}
+
+	if c.flagHostname != "" {
+		reservationPut.Hostname = c.flagHostname
+	}
+
+	if c.flagDescription != "" {
+		reservationPut.Description = c.flagDescription
+	}
+
+	// Create the network DHCP reservation.
+	reservation := api.NetworkDHCPReservationsPost{
+		Hwaddr:                    args[1],
+		NetworkDHCPReservationPut: reservationPut,
+	}
+
+	reservation.Normalise()
+
+	err = resource.server.CreateNetworkDHCPReservation(resource.name, reservation)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Network DHCP reservation %s created")+"\n", reservation.Hwaddr)
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdNetworkDHCPReservationEdit struct {
+	global                 *cmdGlobal
+	networkDHCPReservation *cmdNetworkDHCPReservation
+}
+
+func (c *cmdNetworkDHCPReservationEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", i18n.G("[<remote>:]<network> <MAC>"))
+	cmd.Short = i18n.G("Edit network DHCP reservation configurations as YAML")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Edit network DHCP reservation configurations as YAML"))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkDHCPReservations(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkDHCPReservationEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the network DHCP reservation.
+### Any line starting with a '# will be ignored.
+###
+### A network DHCP reservation hands out fixed addresses to the device with the given MAC address.
+###
+### An example would look like:
+### hwaddr: 00:16:3e:c4:32:77
+### description: Office printer
+### ipv4_address: 198.51.100.10
+### ipv6_address: 2001:db8::10
+### hostname: printer
+###
+### Note that the hwaddr cannot be changed.`)
+}
+
+func (c *cmdNetworkDHCPReservationEdit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing MAC address"))
+	}
+
+	client := resource.server
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		// Allow output of `incus network dhcp-reservation show` command to be passed in here, but only take
+		// the contents of the NetworkDHCPReservationPut fields when updating. The other fields are silently discarded.
+		newData := api.NetworkDHCPReservation{}
+		err = yaml.UnmarshalStrict(contents, &newData)
+		if err != nil {
+			return err
+		}
+
+		newData.Normalise()
+
+		return client.UpdateNetworkDHCPReservation(resource.name, args[1], newData.NetworkDHCPReservationPut, "")
+	}
+
+	// Get the current config.
+	reservation, etag, err := client.GetNetworkDHCPReservation(resource.name, args[1])
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&reservation)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor.
+	content, err := textEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor.
+		newData := api.NetworkDHCPReservation{} // We show the full info, but only send the writable fields.
+		err = yaml.UnmarshalStrict(content, &newData)
+		if err == nil {
+			newData.Normalise()
+			err = client.UpdateNetworkDHCPReservation(resource.name, args[1], newData.Writable(), etag)
+		}
+
+		// Respawn the editor.
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = textEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdNetworkDHCPReservationDelete struct {
+	global                 *cmdGlobal
+	networkDHCPReservation *cmdNetworkDHCPReservation
+}
+
+func (c *cmdNetworkDHCPReservationDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<network> <MAC>"))
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = i18n.G("Delete network DHCP reservations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Delete network DHCP reservations"))
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkDHCPReservations(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkDHCPReservationDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing MAC address"))
+	}
+
+	// Delete the network DHCP reservation.
+	err = resource.server.DeleteNetworkDHCPReservation(resource.name, args[1])
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Network DHCP reservation %s deleted")+"\n", args[1])
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/lxc/incus/v6/internal/i18n"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/termios"
+	"github.com/lxc/incus/v6/shared/units"
 )
 
 type cmdNetworkForward struct {
@@ -35,6 +36,10 @@ func (c *cmdNetworkForward) Command() *cobra.Command {
 	networkForwardShowCmd := cmdNetworkForwardShow{global: c.global, networkForward: c}
 	cmd.AddCommand(networkForwardShowCmd.Command())
 
+	// Info.
+	networkForwardInfoCmd := cmdNetworkForwardInfo{global: c.global, networkForward: c}
+	cmd.AddCommand(networkForwardInfoCmd.Command())
+
 	// Create.
 	networkForwardCreateCmd := cmdNetworkForwardCreate{global: c.global, networkForward: c}
 	cmd.AddCommand(networkForwardCreateCmd.Command())
@@ -165,6 +170,8 @@ func (c *cmdNetworkForwardList) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkForwardShow struct {
 	global         *cmdGlobal
 	networkForward *cmdNetworkForward
+
+	flagFormat string
 }
 
 func (c *cmdNetworkForwardShow) Command() *cobra.Command {
@@ -172,6 +179,7 @@ func (c *cmdNetworkForwardShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<network> <listen_address>"))
 	cmd.Short = i18n.G("Show network forward configurations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network forward configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.Flags().StringVar(&c.networkForward.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
@@ -227,12 +235,102 @@ func (c *cmdNetworkForwardShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&forward)
+	return cli.RenderObject(c.flagFormat, &forward)
+}
+
+// Info.
+type cmdNetworkForwardInfo struct {
+	global         *cmdGlobal
+	networkForward *cmdNetworkForward
+
+	flagFormat string
+}
+
+func (c *cmdNetworkForwardInfo) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("info", i18n.G("[<remote>:]<network> <listen_address>"))
+	cmd.Short = i18n.G("Get network forward traffic counters")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Get the connection, packet and byte counters of a network forward
+
+Counters are only available on bridge networks using the nftables firewall driver.`))
+	cmd.RunE = c.Run
+
+	cmd.Flags().StringVar(&c.networkForward.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkForwards(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkForwardInfo) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing listen address"))
+	}
+
+	client := resource.server
+
+	// If a target was specified, use the forward on the given member.
+	if c.networkForward.flagTarget != "" {
+		client = client.UseTarget(c.networkForward.flagTarget)
+	}
+
+	// Get the network forward counters.
+	forwardState, err := client.GetNetworkForwardState(resource.name, args[1])
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", data)
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, forwardState)
+	}
+
+	printCounters := func(counters api.NetworkForwardStateCounters) {
+		fmt.Printf("    %s: %d\n", i18n.G("Connections"), counters.Connections)
+		fmt.Printf("    %s: %d\n", i18n.G("Packets"), counters.Packets)
+		fmt.Printf("    %s: %s\n", i18n.G("Bytes"), units.GetByteSizeStringIEC(int64(counters.Bytes), 2))
+	}
+
+	if forwardState.DefaultTarget != nil {
+		fmt.Println(i18n.G("Default target:"))
+		printCounters(*forwardState.DefaultTarget)
+	}
+
+	if len(forwardState.Ports) > 0 {
+		fmt.Println(i18n.G("Ports:"))
+		for _, port := range forwardState.Ports {
+			fmt.Printf("  %s/%s:\n", port.Protocol, port.ListenPort)
+			printCounters(port.NetworkForwardStateCounters)
+		}
+	}
 
 	return nil
 }
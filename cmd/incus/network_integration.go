@@ -636,6 +636,8 @@ func (c *cmdNetworkIntegrationUnset) Run(cmd *cobra.Command, args []string) erro
 type cmdNetworkIntegrationShow struct {
 	global             *cmdGlobal
 	networkIntegration *cmdNetworkIntegration
+
+	flagFormat string
 }
 
 // Command returns a cobra command for inclusion.
@@ -646,6 +648,7 @@ func (c *cmdNetworkIntegrationShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show network integration options`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -677,12 +680,5 @@ func (c *cmdNetworkIntegrationShow) Run(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	data, err := yaml.Marshal(&networkIntegration)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &networkIntegration)
 }
@@ -36,6 +36,10 @@ func (c *cmdNetworkLoadBalancer) Command() *cobra.Command {
 	networkLoadBalancerShowCmd := cmdNetworkLoadBalancerShow{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerShowCmd.Command())
 
+	// Info.
+	networkLoadBalancerInfoCmd := cmdNetworkLoadBalancerInfo{global: c.global, networkLoadBalancer: c}
+	cmd.AddCommand(networkLoadBalancerInfoCmd.Command())
+
 	// Create.
 	networkLoadBalancerCreateCmd := cmdNetworkLoadBalancerCreate{global: c.global, networkLoadBalancer: c}
 	cmd.AddCommand(networkLoadBalancerCreateCmd.Command())
@@ -168,6 +172,8 @@ func (c *cmdNetworkLoadBalancerList) Run(cmd *cobra.Command, args []string) erro
 type cmdNetworkLoadBalancerShow struct {
 	global              *cmdGlobal
 	networkLoadBalancer *cmdNetworkLoadBalancer
+
+	flagFormat string
 }
 
 func (c *cmdNetworkLoadBalancerShow) Command() *cobra.Command {
@@ -175,6 +181,7 @@ func (c *cmdNetworkLoadBalancerShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<network> <listen_address>"))
 	cmd.Short = i18n.G("Show network load balancer configurations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network load balancer configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
@@ -230,12 +237,98 @@ func (c *cmdNetworkLoadBalancerShow) Run(cmd *cobra.Command, args []string) erro
 		return err
 	}
 
-	data, err := yaml.Marshal(&loadBalancer)
+	return cli.RenderObject(c.flagFormat, &loadBalancer)
+}
+
+// Info.
+type cmdNetworkLoadBalancerInfo struct {
+	global              *cmdGlobal
+	networkLoadBalancer *cmdNetworkLoadBalancer
+
+	flagFormat string
+}
+
+func (c *cmdNetworkLoadBalancerInfo) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("info", i18n.G("[<remote>:]<network> <listen_address>"))
+	cmd.Short = i18n.G("Get current load balancer status")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Get current load balancer status, including backend health"))
+	cmd.RunE = c.Run
+
+	cmd.Flags().StringVar(&c.networkLoadBalancer.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		if len(args) == 1 {
+			return c.global.cmpNetworkLoadBalancers(args[0])
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkLoadBalancerInfo) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	if args[1] == "" {
+		return fmt.Errorf(i18n.G("Missing listen address"))
+	}
+
+	client := resource.server
+
+	// If a target was specified, use the load balancer on the given member.
+	if c.networkLoadBalancer.flagTarget != "" {
+		client = client.UseTarget(c.networkLoadBalancer.flagTarget)
+	}
+
+	// Get the load balancer state.
+	loadBalancerState, err := client.GetNetworkLoadBalancerState(resource.name, args[1])
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("%s", data)
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, loadBalancerState)
+	}
+
+	backendNames := make([]string, 0, len(loadBalancerState.BackendHealth))
+	for backendName := range loadBalancerState.BackendHealth {
+		backendNames = append(backendNames, backendName)
+	}
+
+	sort.Strings(backendNames)
+
+	fmt.Println(i18n.G("Backend health:"))
+	for _, backendName := range backendNames {
+		backend := loadBalancerState.BackendHealth[backendName]
+
+		fmt.Printf("  %s (%s):\n", backendName, backend.Address)
+		for _, port := range backend.Ports {
+			fmt.Printf("    - %s/%d: %s\n", port.Protocol, port.Port, port.Status)
+		}
+	}
 
 	return nil
 }
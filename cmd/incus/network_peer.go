@@ -59,6 +59,10 @@ func (c *cmdNetworkPeer) Command() *cobra.Command {
 	networkPeerDeleteCmd := cmdNetworkPeerDelete{global: c.global, networkPeer: c}
 	cmd.AddCommand(networkPeerDeleteCmd.Command())
 
+	// Request.
+	networkPeerRequestCmd := cmdNetworkPeerRequest{global: c.global, networkPeer: c}
+	cmd.AddCommand(networkPeerRequestCmd.Command())
+
 	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
 	cmd.Args = cobra.NoArgs
 	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
@@ -165,6 +169,8 @@ func (c *cmdNetworkPeerList) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkPeerShow struct {
 	global      *cmdGlobal
 	networkPeer *cmdNetworkPeer
+
+	flagFormat string
 }
 
 func (c *cmdNetworkPeerShow) Command() *cobra.Command {
@@ -172,6 +178,7 @@ func (c *cmdNetworkPeerShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<network> <peer name>"))
 	cmd.Short = i18n.G("Show network peer configurations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network peer configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -220,14 +227,7 @@ func (c *cmdNetworkPeerShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&peer)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &peer)
 }
 
 // Create.
@@ -814,3 +814,263 @@ func (c *cmdNetworkPeerDelete) Run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// Request.
+type cmdNetworkPeerRequest struct {
+	global      *cmdGlobal
+	networkPeer *cmdNetworkPeer
+
+	flagFormat string
+	flagName   string
+}
+
+func (c *cmdNetworkPeerRequest) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("request")
+	cmd.Short = i18n.G("Manage network peering requests")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Manage network peering requests"))
+
+	// Request List.
+	cmd.AddCommand(c.CommandList())
+
+	// Request Approve.
+	cmd.AddCommand(c.CommandApprove())
+
+	// Request Reject.
+	cmd.AddCommand(c.CommandReject())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// parseSource splits a peering request source into its project and network names.
+func (c *cmdNetworkPeerRequest) parseSource(source string) (string, string, error) {
+	sourceParts := strings.SplitN(source, "/", 2)
+	if len(sourceParts) != 2 || sourceParts[0] == "" || sourceParts[1] == "" {
+		return "", "", fmt.Errorf(i18n.G("Invalid source %q (must be <project>/<network>)"), source)
+	}
+
+	return sourceParts[0], sourceParts[1], nil
+}
+
+func (c *cmdNetworkPeerRequest) CommandList() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]<network>"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List pending network peering requests")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("List pending network peering requests"))
+
+	cmd.RunE = c.RunList
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkPeerRequest) RunList(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	requests, err := resource.server.GetNetworkPeerRequests(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data := make([][]string, 0, len(requests))
+	for _, request := range requests {
+		details := []string{
+			fmt.Sprintf("%s/%s", request.SourceProject, request.SourceNetwork),
+			request.Name,
+			request.Description,
+		}
+
+		data = append(data, details)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("SOURCE"),
+		i18n.G("NAME"),
+		i18n.G("DESCRIPTION"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, requests)
+}
+
+func (c *cmdNetworkPeerRequest) CommandApprove() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("approve", i18n.G("[<remote>:]<network> <source project>/<source network> [key=value...]"))
+	cmd.Short = i18n.G("Approve network peering requests")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Approve network peering requests
+
+This creates the mutual network peering with the requesting network.
+By default, the peering uses the same name as on the requesting network.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus network peer request approve default project1/network1 security.acls=web
+    Approve the peering requested by network1 in project1, only allowing the traffic matching the ingress rules of the web ACL from it.`))
+
+	cmd.RunE = c.RunApprove
+	cmd.Flags().StringVar(&c.flagName, "name", "", i18n.G("Name of the peering on the local network")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkPeerRequest) RunApprove(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, -1)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	sourceProject, sourceNetwork, err := c.parseSource(args[1])
+	if err != nil {
+		return err
+	}
+
+	// If stdin isn't a terminal, read yaml from it.
+	var peerPut api.NetworkPeerPut
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.UnmarshalStrict(contents, &peerPut)
+		if err != nil {
+			return err
+		}
+	}
+
+	if peerPut.Config == nil {
+		peerPut.Config = map[string]string{}
+	}
+
+	// Get config filters from arguments.
+	for i := 2; i < len(args); i++ {
+		entry := strings.SplitN(args[i], "=", 2)
+		if len(entry) < 2 {
+			return fmt.Errorf(i18n.G("Bad key/value pair: %s"), args[i])
+		}
+
+		peerPut.Config[entry[0]] = entry[1]
+	}
+
+	// Approve the network peering request.
+	peer := api.NetworkPeerRequestPost{
+		Name:           c.flagName,
+		NetworkPeerPut: peerPut,
+	}
+
+	err = resource.server.ApproveNetworkPeerRequest(resource.name, sourceProject, sourceNetwork, peer)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Network peering request from %s approved")+"\n", args[1])
+	}
+
+	return nil
+}
+
+func (c *cmdNetworkPeerRequest) CommandReject() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("reject", i18n.G("[<remote>:]<network> <source project>/<source network>"))
+	cmd.Short = i18n.G("Reject network peering requests")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(`Reject network peering requests
+
+This deletes the pending network peering from the requesting network.`))
+
+	cmd.RunE = c.RunReject
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpNetworks(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdNetworkPeerRequest) RunReject(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote.
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing network name"))
+	}
+
+	sourceProject, sourceNetwork, err := c.parseSource(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Reject the network peering request.
+	err = resource.server.RejectNetworkPeerRequest(resource.name, sourceProject, sourceNetwork)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Network peering request from %s rejected")+"\n", args[1])
+	}
+
+	return nil
+}
@@ -172,6 +172,8 @@ func (c *cmdNetworkZoneList) Run(cmd *cobra.Command, args []string) error {
 type cmdNetworkZoneShow struct {
 	global      *cmdGlobal
 	networkZone *cmdNetworkZone
+
+	flagFormat string
 }
 
 func (c *cmdNetworkZoneShow) Command() *cobra.Command {
@@ -179,6 +181,7 @@ func (c *cmdNetworkZoneShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<Zone>"))
 	cmd.Short = i18n.G("Show network zone configurations")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network zone configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -219,14 +222,7 @@ func (c *cmdNetworkZoneShow) Run(cmd *cobra.Command, args []string) error {
 
 	sort.Strings(netZone.UsedBy)
 
-	data, err := yaml.Marshal(&netZone)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &netZone)
 }
 
 // Get.
@@ -852,6 +848,8 @@ func (c *cmdNetworkZoneRecordList) Run(cmd *cobra.Command, args []string) error
 type cmdNetworkZoneRecordShow struct {
 	global            *cmdGlobal
 	networkZoneRecord *cmdNetworkZoneRecord
+
+	flagFormat string
 }
 
 func (c *cmdNetworkZoneRecordShow) Command() *cobra.Command {
@@ -859,6 +857,7 @@ func (c *cmdNetworkZoneRecordShow) Command() *cobra.Command {
 	cmd.Use = usage("show", i18n.G("[<remote>:]<zone> <record>"))
 	cmd.Short = i18n.G("Show network zone record configuration")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G("Show network zone record configurations"))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -900,14 +899,7 @@ func (c *cmdNetworkZoneRecordShow) Run(cmd *cobra.Command, args []string) error
 		return err
 	}
 
-	data, err := yaml.Marshal(&netRecord)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &netRecord)
 }
 
 // Get.
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
@@ -185,6 +184,8 @@ func (c *cmdOperationList) Run(cmd *cobra.Command, args []string) error {
 type cmdOperationShow struct {
 	global    *cmdGlobal
 	operation *cmdOperation
+
+	flagFormat string
 }
 
 func (c *cmdOperationShow) Command() *cobra.Command {
@@ -197,6 +198,7 @@ func (c *cmdOperationShow) Command() *cobra.Command {
 		`incus operation show 344a79e4-d88a-45bf-9c39-c72c26f6ab8a
     Show details on that operation UUID`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -223,13 +225,5 @@ func (c *cmdOperationShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Render as YAML
-	data, err := yaml.Marshal(&op)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &op)
 }
@@ -1096,6 +1096,8 @@ func (c *cmdProfileSet) Run(cmd *cobra.Command, args []string) error {
 type cmdProfileShow struct {
 	global  *cmdGlobal
 	profile *cmdProfile
+
+	flagFormat string
 }
 
 func (c *cmdProfileShow) Command() *cobra.Command {
@@ -1105,6 +1107,7 @@ func (c *cmdProfileShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show profile configurations`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1143,14 +1146,7 @@ func (c *cmdProfileShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&profile)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &profile)
 }
 
 // Unset.
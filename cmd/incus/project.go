@@ -911,6 +911,8 @@ func (c *cmdProjectUnset) Run(cmd *cobra.Command, args []string) error {
 type cmdProjectShow struct {
 	global  *cmdGlobal
 	project *cmdProject
+
+	flagFormat string
 }
 
 func (c *cmdProjectShow) Command() *cobra.Command {
@@ -920,6 +922,7 @@ func (c *cmdProjectShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show project options`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -958,14 +961,7 @@ func (c *cmdProjectShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&project)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &project)
 }
 
 // Switch project.
@@ -1038,8 +1034,10 @@ type cmdProjectInfo struct {
 	global  *cmdGlobal
 	project *cmdProject
 
-	flagShowAccess bool
-	flagFormat     string
+	flagShowAccess       bool
+	flagShowNetworkUsage bool
+	flagPeriod           string
+	flagFormat           string
 }
 
 func (c *cmdProjectInfo) Command() *cobra.Command {
@@ -1049,6 +1047,8 @@ func (c *cmdProjectInfo) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Get a summary of resource allocations`))
 	cmd.Flags().BoolVar(&c.flagShowAccess, "show-access", false, i18n.G("Show the instance's access list"))
+	cmd.Flags().BoolVar(&c.flagShowNetworkUsage, "show-network-usage", false, i18n.G("Show the network usage of the project's instances"))
+	cmd.Flags().StringVar(&c.flagPeriod, "period", "", i18n.G("Comma-separated list of periods (YYYY-MM) to show the network usage for")+"``")
 	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
 
 	cmd.RunE = c.Run
@@ -1098,6 +1098,10 @@ func (c *cmdProjectInfo) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if c.flagShowNetworkUsage {
+		return c.renderNetworkUsage(resource)
+	}
+
 	// Get the current allocations
 	projectState, err := resource.server.GetProjectState(resource.name)
 	if err != nil {
@@ -1137,3 +1141,48 @@ func (c *cmdProjectInfo) Run(cmd *cobra.Command, args []string) error {
 
 	return cli.RenderTable(c.flagFormat, header, data, projectState)
 }
+
+func (c *cmdProjectInfo) renderNetworkUsage(resource remoteResource) error {
+	var periods []string
+	if c.flagPeriod != "" {
+		periods = strings.Split(c.flagPeriod, ",")
+	}
+
+	usages, err := resource.server.GetProjectNetworkUsage(resource.name, periods...)
+	if err != nil {
+		return err
+	}
+
+	// Render the output
+	data := [][]string{}
+	for _, usage := range usages {
+		instanceData := [][]string{}
+		for instanceName, counters := range usage.Instances {
+			instanceData = append(instanceData, []string{
+				usage.Period,
+				instanceName,
+				units.GetByteSizeStringIEC(counters.BytesReceived, 2),
+				units.GetByteSizeStringIEC(counters.BytesSent, 2),
+			})
+		}
+
+		// Periods are already ordered, only sort the instances within each of them.
+		sort.Sort(cli.SortColumnsNaturally(instanceData))
+		data = append(data, instanceData...)
+		data = append(data, []string{
+			usage.Period,
+			i18n.G("TOTAL"),
+			units.GetByteSizeStringIEC(usage.BytesReceived, 2),
+			units.GetByteSizeStringIEC(usage.BytesSent, 2),
+		})
+	}
+
+	header := []string{
+		i18n.G("PERIOD"),
+		i18n.G("INSTANCE"),
+		i18n.G("RECEIVED"),
+		i18n.G("SENT"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, usages)
+}
@@ -828,6 +828,10 @@ func (c *cmdRemoteRename) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Remote %s is static and cannot be modified"), args[0])
 	}
 
+	if rc.Environment {
+		return fmt.Errorf(i18n.G("Remote %s is defined in the environment and cannot be modified"), args[0])
+	}
+
 	_, ok = conf.Remotes[args[1]]
 	if ok {
 		return fmt.Errorf(i18n.G("Remote %s already exists"), args[1])
@@ -907,6 +911,10 @@ func (c *cmdRemoteRemove) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Remote %s is static and cannot be modified"), args[0])
 	}
 
+	if rc.Environment {
+		return fmt.Errorf(i18n.G("Remote %s is defined in the environment and cannot be modified"), args[0])
+	}
+
 	if rc.Global {
 		return fmt.Errorf(i18n.G("Remote %s is global and cannot be removed"), args[0])
 	}
@@ -1016,6 +1024,10 @@ func (c *cmdRemoteSetURL) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf(i18n.G("Remote %s is static and cannot be modified"), args[0])
 	}
 
+	if rc.Environment {
+		return fmt.Errorf(i18n.G("Remote %s is defined in the environment and cannot be modified"), args[0])
+	}
+
 	remote := conf.Remotes[args[0]]
 	if remote.Global {
 		err := conf.CopyGlobalCert(args[0], args[0])
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/lxc/incus/v6/client"
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+)
+
+type cmdShell struct {
+	global *cmdGlobal
+	exec   cmdExec
+
+	flagUser       string
+	flagDetectUser bool
+}
+
+// shellUser represents an entry of the instance's /etc/passwd.
+type shellUser struct {
+	Name  string
+	UID   uint32
+	GID   uint32
+	Home  string
+	Shell string
+}
+
+func (c *cmdShell) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("shell", i18n.G("[<remote>:]<instance>"))
+	cmd.Short = i18n.G("Open a login shell in instances")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Open a login shell in instances
+
+This runs "su -l" in the instance, logging in as root by default.
+
+The --user flag takes either a user ID, which "su -l" is then run as, or a user
+name to log in as. With --detect-user, the login user is detected from the
+instance instead. The cloud-init default user is used when present, otherwise
+the first regular user account with a login shell is selected, falling back to root.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus shell c1
+	Open a login shell as root in instance "c1"
+
+incus shell c1 --user alice
+	Open a login shell as "alice" in instance "c1"
+
+incus shell c1 --detect-user
+	Open a login shell for the detected user in instance "c1"`))
+
+	cmd.RunE = c.Run
+	cmd.Flags().StringVar(&c.flagUser, "user", "", i18n.G("User ID to run su as, or user name to log in as (default root)")+"``")
+	cmd.Flags().BoolVar(&c.flagDetectUser, "detect-user", false, i18n.G("Log in as the user detected from the instance"))
+	cmd.Flags().StringArrayVar(&c.exec.flagEnvironment, "env", nil, i18n.G("Environment variable to set (e.g. HOME=/home/foo)")+"``")
+	cmd.Flags().StringVar(&c.exec.flagMode, "mode", "auto", i18n.G("Override the terminal mode (auto, interactive or non-interactive)")+"``")
+	cmd.Flags().BoolVarP(&c.exec.flagForceInteractive, "force-interactive", "t", false, i18n.G("Force pseudo-terminal allocation"))
+	cmd.Flags().BoolVarP(&c.exec.flagForceNonInteractive, "force-noninteractive", "T", false, i18n.G("Disable pseudo-terminal allocation"))
+	cmd.Flags().BoolVarP(&c.exec.flagDisableStdin, "disable-stdin", "n", false, i18n.G("Disable stdin (reads from /dev/null)"))
+	cmd.Flags().Uint32Var(&c.exec.flagGroup, "group", 0, i18n.G("Group ID to run the command as (default 0)")+"``")
+	cmd.Flags().StringVar(&c.exec.flagCwd, "cwd", "", i18n.G("Directory to run the command in (default /root)")+"``")
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdShell) Run(cmd *cobra.Command, args []string) error {
+	conf := c.global.conf
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	if c.flagDetectUser && c.flagUser != "" {
+		return fmt.Errorf(i18n.G("--detect-user can't be used together with --user"))
+	}
+
+	userFlag := c.flagUser
+	if c.flagDetectUser {
+		// Connect to the daemon
+		remote, name, err := conf.ParseRemote(args[0])
+		if err != nil {
+			return err
+		}
+
+		d, err := conf.GetInstanceServer(remote)
+		if err != nil {
+			return err
+		}
+
+		user, err := c.detectUser(d, name)
+		if err != nil {
+			return err
+		}
+
+		userFlag = user.Name
+	}
+
+	uid, command := shellLoginCommand(userFlag)
+
+	// Hand over to exec for the terminal handling.
+	c.exec.global = c.global
+	c.exec.flagUser = uid
+
+	return c.exec.Run(cmd, append([]string{args[0]}, command...))
+}
+
+// shellLoginCommand returns the user ID to run "su" as and its command line for the --user flag.
+// A numeric value is a user ID, like for exec, anything else is the name of the user to log in as.
+func shellLoginCommand(user string) (uint32, []string) {
+	if user == "" {
+		return 0, []string{"su", "-l"}
+	}
+
+	uid, err := strconv.ParseUint(user, 10, 32)
+	if err == nil {
+		return uint32(uid), []string{"su", "-l"}
+	}
+
+	return 0, []string{"su", "-l", user}
+}
+
+// detectUser attempts to find a sensible login user for the instance.
+func (c *cmdShell) detectUser(d incus.InstanceServer, name string) (*shellUser, error) {
+	passwd, err := c.readFile(d, name, "/etc/passwd")
+	if err != nil {
+		return nil, err
+	}
+
+	// Look for the cloud-init default user, ignoring any failure.
+	cloudUser := ""
+	cloudConfig, err := c.readFile(d, name, "/etc/cloud/cloud.cfg")
+	if err == nil {
+		cloudUser = shellCloudInitUser(cloudConfig)
+	}
+
+	return shellDetectUser(passwd, cloudUser)
+}
+
+// readFile fetches the content of a file from the instance.
+func (c *cmdShell) readFile(d incus.InstanceServer, name string, path string) ([]byte, error) {
+	content, _, err := d.GetInstanceFile(name, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = content.Close() }()
+
+	return io.ReadAll(content)
+}
+
+// shellParsePasswd parses the content of a passwd file.
+func shellParsePasswd(content []byte) []shellUser {
+	users := []shellUser{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ":")
+		if len(fields) != 7 {
+			continue
+		}
+
+		uid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		gid, err := strconv.ParseUint(fields[3], 10, 32)
+		if err != nil {
+			continue
+		}
+
+		users = append(users, shellUser{
+			Name:  fields[0],
+			UID:   uint32(uid),
+			GID:   uint32(gid),
+			Home:  fields[5],
+			Shell: fields[6],
+		})
+	}
+
+	return users
+}
+
+// shellCloudInitUser extracts the default user name from a cloud-init configuration.
+func shellCloudInitUser(content []byte) string {
+	cloudConfig := struct {
+		SystemInfo struct {
+			DefaultUser struct {
+				Name string `yaml:"name"`
+			} `yaml:"default_user"`
+		} `yaml:"system_info"`
+	}{}
+
+	err := yaml.Unmarshal(content, &cloudConfig)
+	if err != nil {
+		return ""
+	}
+
+	return cloudConfig.SystemInfo.DefaultUser.Name
+}
+
+// shellDetectUser selects the login user from the passwd entries.
+// The cloud-init default user takes precedence, followed by the first
+// regular user account with a login shell.
+func shellDetectUser(passwd []byte, cloudUser string) (*shellUser, error) {
+	users := shellParsePasswd(passwd)
+
+	nologinShells := []string{"", "/bin/false", "/usr/bin/false", "/sbin/nologin", "/usr/sbin/nologin", "/bin/sync"}
+
+	if cloudUser != "" {
+		for _, user := range users {
+			if user.Name == cloudUser {
+				return &user, nil
+			}
+		}
+	}
+
+	for _, user := range users {
+		if user.UID < 1000 || user.UID >= 60000 {
+			continue
+		}
+
+		if slices.Contains(nologinShells, user.Shell) {
+			continue
+		}
+
+		return &user, nil
+	}
+
+	for _, user := range users {
+		if user.UID == 0 {
+			return &user, nil
+		}
+	}
+
+	return nil, fmt.Errorf(i18n.G("No suitable user found"))
+}
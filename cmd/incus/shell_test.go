@@ -0,0 +1,85 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+const testPasswd = `root:x:0:0:root:/root:/bin/bash
+daemon:x:1:1:daemon:/usr/sbin:/usr/sbin/nologin
+nobody:x:65534:65534:nobody:/nonexistent:/usr/sbin/nologin
+svc:x:1000:1000::/home/svc:/usr/sbin/nologin
+alice:x:1001:1001:Alice:/home/alice:/bin/bash
+ubuntu:x:1002:1002:Ubuntu:/home/ubuntu:/bin/bash
+`
+
+func TestShellDetectUser(t *testing.T) {
+	tests := []struct {
+		passwd    string
+		cloudUser string
+		expected  string
+	}{
+		{testPasswd, "", "alice"},
+		{testPasswd, "ubuntu", "ubuntu"},
+		{testPasswd, "missing", "alice"},
+		{"root:x:0:0:root:/root:/bin/sh\n", "", "root"},
+	}
+
+	for _, test := range tests {
+		user, err := shellDetectUser([]byte(test.passwd), test.cloudUser)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if user.Name != test.expected {
+			t.Errorf("Expected user %q, got %q", test.expected, user.Name)
+		}
+	}
+
+	_, err := shellDetectUser([]byte("invalid\n"), "")
+	if err == nil {
+		t.Error("Expected error for empty passwd, received nil")
+	}
+}
+
+func TestShellCloudInitUser(t *testing.T) {
+	content := []byte(`system_info:
+  distro: ubuntu
+  default_user:
+    name: ubuntu
+    lock_passwd: True
+`)
+
+	if shellCloudInitUser(content) != "ubuntu" {
+		t.Errorf("Expected cloud-init user %q, got %q", "ubuntu", shellCloudInitUser(content))
+	}
+
+	if shellCloudInitUser([]byte("users: [default]\n")) != "" {
+		t.Error("Expected no cloud-init user")
+	}
+}
+
+func TestShellLoginCommand(t *testing.T) {
+	tests := []struct {
+		user        string
+		expectedUID uint32
+		expectedCmd []string
+	}{
+		{"", 0, []string{"su", "-l"}},
+		{"1000", 1000, []string{"su", "-l"}},
+		{"0", 0, []string{"su", "-l"}},
+		{"alice", 0, []string{"su", "-l", "alice"}},
+		{"1000a", 0, []string{"su", "-l", "1000a"}},
+	}
+
+	for _, test := range tests {
+		uid, command := shellLoginCommand(test.user)
+		if uid != test.expectedUID {
+			t.Errorf("Expected UID %d for %q, got %d", test.expectedUID, test.user, uid)
+		}
+
+		if !slices.Equal(command, test.expectedCmd) {
+			t.Errorf("Expected command %v for %q, got %v", test.expectedCmd, test.user, command)
+		}
+	}
+}
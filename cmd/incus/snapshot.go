@@ -69,6 +69,8 @@ type cmdSnapshotCreate struct {
 	flagStateful bool
 	flagNoExpiry bool
 	flagReuse    bool
+	flagAll      bool
+	flagQuiesce  bool
 }
 
 func (c *cmdSnapshotCreate) Command() *cobra.Command {
@@ -79,16 +81,33 @@ func (c *cmdSnapshotCreate) Command() *cobra.Command {
 		`Create instance snapshots
 
 When --stateful is used, attempt to checkpoint the instance's
-running state, including process memory state, TCP connections, ...`))
+running state, including process memory state, TCP connections, ...
+
+Passing @<group> instead of an instance name snapshots all the instances
+of an instance group under the same snapshot name.
+
+When --all is used, all the instances of the project are snapshotted at
+about the same time under the same snapshot name, for point-in-time
+recovery of the whole project. The arguments then are
+[<remote>:][<snapshot name>]. With --quiesce, the filesystem buffers of
+the running instances are flushed through their agent beforehand.`))
 	cmd.Example = cli.FormatSection("", i18n.G(`incus snapshot create u1 snap0
 	Create a snapshot of "u1" called "snap0".
 
 incus snapshot create u1 snap0 < config.yaml
-	Create a snapshot of "u1" called "snap0" with the configuration from "config.yaml".`))
+	Create a snapshot of "u1" called "snap0" with the configuration from "config.yaml".
+
+incus snapshot create @web before-upgrade
+	Create a snapshot called "before-upgrade" of all the instances of the "web" group.
+
+incus snapshot create --project prod --all --quiesce nightly
+	Create a snapshot called "nightly" of all the instances of the "prod" project.`))
 
 	cmd.Flags().BoolVar(&c.flagStateful, "stateful", false, i18n.G("Whether or not to snapshot the instance's running state"))
 	cmd.Flags().BoolVar(&c.flagNoExpiry, "no-expiry", false, i18n.G("Ignore any configured auto-expiry for the instance"))
 	cmd.Flags().BoolVar(&c.flagReuse, "reuse", false, i18n.G("If the snapshot name already exists, delete and create a new one"))
+	cmd.Flags().BoolVar(&c.flagAll, "all", false, i18n.G("Snapshot all the instances of the project"))
+	cmd.Flags().BoolVar(&c.flagQuiesce, "quiesce", false, i18n.G("Flush the filesystem buffers of the running instances first (requires --all)"))
 
 	cmd.RunE = c.Run
 
@@ -108,11 +127,26 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 	conf := c.global.conf
 
 	// Quick checks.
-	exit, err := c.global.CheckArgs(cmd, args, 1, 2)
+	minArgs := 1
+	maxArgs := 2
+	if c.flagAll {
+		minArgs = 0
+		maxArgs = 1
+	}
+
+	exit, err := c.global.CheckArgs(cmd, args, minArgs, maxArgs)
 	if exit {
 		return err
 	}
 
+	if c.flagQuiesce && !c.flagAll {
+		return fmt.Errorf(i18n.G("--quiesce can only be used with --all"))
+	}
+
+	if c.flagAll && c.flagReuse {
+		return fmt.Errorf(i18n.G("--reuse can't be used with --all"))
+	}
+
 	// If stdin isn't a terminal, read text from it
 	if !termios.IsTerminal(getStdinFd()) {
 		contents, err := io.ReadAll(os.Stdin)
@@ -126,6 +160,11 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Snapshot all the instances of the project in a single operation.
+	if c.flagAll {
+		return c.runAll(args, stdinData)
+	}
+
 	var snapname string
 	if len(args) < 2 {
 		snapname = ""
@@ -153,6 +192,11 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	groupName, isGroup := strings.CutPrefix(name, "@")
+	if isGroup && c.flagReuse {
+		return fmt.Errorf(i18n.G("--reuse can't be used with instance groups"))
+	}
+
 	if c.flagReuse && snapname != "" {
 		snap, _, _ := d.GetInstanceSnapshot(name, snapname)
 		if snap != nil {
@@ -179,6 +223,16 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 		req.ExpiresAt = &stdinData.ExpiresAt
 	}
 
+	// Snapshot all the instances of a group in a single operation.
+	if isGroup {
+		op, err := d.CreateInstanceGroupSnapshot(groupName, req)
+		if err != nil {
+			return err
+		}
+
+		return op.Wait()
+	}
+
 	op, err := d.CreateInstanceSnapshot(name, req)
 	if err != nil {
 		return err
@@ -187,6 +241,52 @@ func (c *cmdSnapshotCreate) Run(cmd *cobra.Command, args []string) error {
 	return op.Wait()
 }
 
+// runAll snapshots all the instances of the current project.
+func (c *cmdSnapshotCreate) runAll(args []string, stdinData api.InstanceSnapshotPut) error {
+	conf := c.global.conf
+
+	target := ""
+	if len(args) > 0 {
+		target = args[0]
+	}
+
+	remote, snapname, err := conf.ParseRemote(target)
+	if err != nil {
+		return err
+	}
+
+	d, err := conf.GetInstanceServer(remote)
+	if err != nil {
+		return err
+	}
+
+	info, err := d.GetConnectionInfo()
+	if err != nil {
+		return err
+	}
+
+	req := api.ProjectSnapshotsPost{
+		InstanceSnapshotsPost: api.InstanceSnapshotsPost{
+			Name:     snapname,
+			Stateful: c.flagStateful,
+		},
+		Quiesce: c.flagQuiesce,
+	}
+
+	if c.flagNoExpiry {
+		req.ExpiresAt = &time.Time{}
+	} else if !stdinData.ExpiresAt.IsZero() {
+		req.ExpiresAt = &stdinData.ExpiresAt
+	}
+
+	op, err := d.CreateProjectSnapshots(info.Project, req)
+	if err != nil {
+		return err
+	}
+
+	return op.Wait()
+}
+
 // Delete.
 type cmdSnapshotDelete struct {
 	global   *cmdGlobal
@@ -516,6 +616,7 @@ type cmdSnapshotShow struct {
 	snapshot *cmdSnapshot
 
 	flagExpanded bool
+	flagFormat   string
 }
 
 func (c *cmdSnapshotShow) Command() *cobra.Command {
@@ -525,6 +626,7 @@ func (c *cmdSnapshotShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show instance snapshot configuration`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -563,12 +665,5 @@ func (c *cmdSnapshotShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&snap)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &snap)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -37,6 +38,10 @@ func (c *cmdStorage) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage storage pools and volumes`))
 
+	// Benchmark
+	storageBenchmarkCmd := cmdStorageBenchmark{global: c.global, storage: c}
+	cmd.AddCommand(storageBenchmarkCmd.Command())
+
 	// Create
 	storageCreateCmd := cmdStorageCreate{global: c.global, storage: c}
 	cmd.AddCommand(storageCreateCmd.Command())
@@ -57,6 +62,10 @@ func (c *cmdStorage) Command() *cobra.Command {
 	storageInfoCmd := cmdStorageInfo{global: c.global, storage: c}
 	cmd.AddCommand(storageInfoCmd.Command())
 
+	// Key
+	storageKeyCmd := cmdStorageKey{global: c.global, storage: c}
+	cmd.AddCommand(storageKeyCmd.Command())
+
 	// List
 	storageListCmd := cmdStorageList{global: c.global, storage: c}
 	cmd.AddCommand(storageListCmd.Command())
@@ -87,6 +96,103 @@ func (c *cmdStorage) Command() *cobra.Command {
 	return cmd
 }
 
+// Benchmark.
+type cmdStorageBenchmark struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+
+	flagFormat string
+}
+
+func (c *cmdStorageBenchmark) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("benchmark", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Benchmark storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Benchmark storage pools
+
+Measures the sequential throughput and random IOPS of the storage pool on a server
+using a temporary volume. The result is also recorded in the volatile.benchmark.*
+configuration keys of the pool.`))
+
+	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageBenchmark) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing pool name"))
+	}
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !resource.server.IsClustered() {
+			return fmt.Errorf(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		resource.server = resource.server.UseTarget(c.storage.flagTarget)
+	}
+
+	// Run the benchmark
+	op, err := resource.server.BenchmarkStoragePool(resource.name)
+	if err != nil {
+		return err
+	}
+
+	err = op.Wait()
+	if err != nil {
+		return err
+	}
+
+	// The result is returned as the operation metadata.
+	metadata, err := json.Marshal(op.Get().Metadata)
+	if err != nil {
+		return err
+	}
+
+	result := api.StoragePoolBenchmark{}
+	err = json.Unmarshal(metadata, &result)
+	if err != nil {
+		return err
+	}
+
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, result)
+	}
+
+	fmt.Printf(i18n.G("Sequential read: %s/s")+"\n", units.GetByteSizeStringIEC(result.SequentialRead, 2))
+	fmt.Printf(i18n.G("Sequential write: %s/s")+"\n", units.GetByteSizeStringIEC(result.SequentialWrite, 2))
+	fmt.Printf(i18n.G("Random read: %d IOPS")+"\n", result.RandomRead)
+	fmt.Printf(i18n.G("Random write: %d IOPS")+"\n", result.RandomWrite)
+
+	return nil
+}
+
 // Create.
 type cmdStorageCreate struct {
 	global  *cmdGlobal
@@ -474,7 +580,15 @@ type cmdStorageInfo struct {
 	global  *cmdGlobal
 	storage *cmdStorage
 
-	flagBytes bool
+	flagBytes  bool
+	flagFormat string
+}
+
+// storagePoolInfo is the structured output of the storage info command.
+type storagePoolInfo struct {
+	api.StoragePool `yaml:",inline"`
+
+	Resources *api.ResourcesStoragePool `json:"resources" yaml:"resources"`
 }
 
 func (c *cmdStorageInfo) Command() *cobra.Command {
@@ -486,6 +600,7 @@ func (c *cmdStorageInfo) Command() *cobra.Command {
 
 	cmd.Flags().BoolVar(&c.flagBytes, "bytes", false, i18n.G("Show the used and free space in bytes"))
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -538,6 +653,10 @@ func (c *cmdStorageInfo) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, storagePoolInfo{StoragePool: *pool, Resources: res})
+	}
+
 	// Declare the poolinfo map of maps in order to build up the yaml
 	poolinfo := make(map[string]map[string]string)
 	poolusedby := make(map[string]map[string][]string)
@@ -921,6 +1040,7 @@ type cmdStorageShow struct {
 	storage *cmdStorage
 
 	flagResources bool
+	flagFormat    string
 }
 
 func (c *cmdStorageShow) Command() *cobra.Command {
@@ -932,6 +1052,7 @@ func (c *cmdStorageShow) Command() *cobra.Command {
 
 	cmd.Flags().BoolVar(&c.flagResources, "resources", false, i18n.G("Show the resources available to the storage pool"))
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -981,14 +1102,7 @@ func (c *cmdStorageShow) Run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		data, err := yaml.Marshal(&res)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%s", data)
-
-		return nil
+		return cli.RenderObject(c.flagFormat, &res)
 	}
 
 	pool, _, err := client.GetStoragePool(resource.name)
@@ -998,14 +1112,7 @@ func (c *cmdStorageShow) Run(cmd *cobra.Command, args []string) error {
 
 	sort.Strings(pool.UsedBy)
 
-	data, err := yaml.Marshal(&pool)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &pool)
 }
 
 // Unset.
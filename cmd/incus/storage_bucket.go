@@ -652,6 +652,8 @@ func (c *cmdStorageBucketSet) Run(cmd *cobra.Command, args []string) error {
 type cmdStorageBucketShow struct {
 	global        *cmdGlobal
 	storageBucket *cmdStorageBucket
+
+	flagFormat string
 }
 
 func (c *cmdStorageBucketShow) Command() *cobra.Command {
@@ -664,6 +666,7 @@ func (c *cmdStorageBucketShow) Command() *cobra.Command {
     Will show the properties of a bucket called "data" in the "default" pool.`))
 
 	cmd.Flags().StringVar(&c.storageBucket.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -704,14 +707,7 @@ func (c *cmdStorageBucketShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&bucket)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &bucket)
 }
 
 // Unset.
@@ -1180,6 +1176,8 @@ func (c *cmdStorageBucketKeyEdit) Run(cmd *cobra.Command, args []string) error {
 type cmdStorageBucketKeyShow struct {
 	global           *cmdGlobal
 	storageBucketKey *cmdStorageBucketKey
+
+	flagFormat string
 }
 
 func (c *cmdStorageBucketKeyShow) Command() *cobra.Command {
@@ -1192,6 +1190,7 @@ func (c *cmdStorageBucketKeyShow) Command() *cobra.Command {
     Will show the properties of a bucket key called "foo" for a bucket called "data" in the "default" pool.`))
 
 	cmd.Flags().StringVar(&c.storageBucketKey.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -1236,14 +1235,7 @@ func (c *cmdStorageBucketKeyShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&bucket)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &bucket)
 }
 
 type cmdStorageBucketExport struct {
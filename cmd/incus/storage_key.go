@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdStorageKey struct {
+	global  *cmdGlobal
+	storage *cmdStorage
+}
+
+func (c *cmdStorageKey) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("key")
+	cmd.Short = i18n.G("Manage storage pool encryption keys")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage storage pool encryption keys`))
+
+	// Change
+	storageKeyChangeCmd := cmdStorageKeyChange{global: c.global, storageKey: c}
+	cmd.AddCommand(storageKeyChangeCmd.Command())
+
+	// Load
+	storageKeyLoadCmd := cmdStorageKeyLoad{global: c.global, storageKey: c}
+	cmd.AddCommand(storageKeyLoadCmd.Command())
+
+	// Show
+	storageKeyShowCmd := cmdStorageKeyShow{global: c.global, storageKey: c}
+	cmd.AddCommand(storageKeyShowCmd.Command())
+
+	// Unload
+	storageKeyUnloadCmd := cmdStorageKeyUnload{global: c.global, storageKey: c}
+	cmd.AddCommand(storageKeyUnloadCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// parsePool parses the pool argument and applies the target.
+func (c *cmdStorageKey) parsePool(arg string) (*remoteResource, error) {
+	resources, err := c.global.ParseServers(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return nil, fmt.Errorf(i18n.G("Missing pool name"))
+	}
+
+	// Targeting
+	if c.storage.flagTarget != "" {
+		if !resource.server.IsClustered() {
+			return nil, fmt.Errorf(i18n.G("To use --target, the destination remote must be a cluster"))
+		}
+
+		resource.server = resource.server.UseTarget(c.storage.flagTarget)
+	}
+
+	return &resource, nil
+}
+
+// readKey prompts for a key on the terminal (twice if confirmation is needed) or reads it from stdin.
+func (c *cmdStorageKey) readKey(question string, confirm bool) (string, error) {
+	if termios.IsTerminal(getStdinFd()) {
+		if confirm {
+			return cli.AskPassword(question), nil
+		}
+
+		return cli.AskPasswordOnce(question), nil
+	}
+
+	key, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(key), "\n"), nil
+}
+
+// Change.
+type cmdStorageKeyChange struct {
+	global     *cmdGlobal
+	storageKey *cmdStorageKey
+}
+
+func (c *cmdStorageKeyChange) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("change", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Change the encryption key of storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Change the encryption key of storage pools
+
+The new key is prompted for, or read from stdin if it isn't a terminal.
+The current key must be loaded.`))
+
+	cmd.Flags().StringVar(&c.storageKey.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageKeyChange) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	resource, err := c.storageKey.parsePool(args[0])
+	if err != nil {
+		return err
+	}
+
+	newKey, err := c.storageKey.readKey(i18n.G("New key: "), true)
+	if err != nil {
+		return err
+	}
+
+	return resource.server.UpdateStoragePoolKey(resource.name, api.StoragePoolKeyPost{Action: "change", NewKey: newKey})
+}
+
+// Load.
+type cmdStorageKeyLoad struct {
+	global     *cmdGlobal
+	storageKey *cmdStorageKey
+
+	flagFetch bool
+}
+
+func (c *cmdStorageKeyLoad) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("load", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Load the encryption key of storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Load the encryption key of storage pools
+
+The key is prompted for, or read from stdin if it isn't a terminal.
+With --fetch, the server retrieves the key from the location configured on the pool instead.
+
+Once its key is loaded, an unavailable storage pool is brought back online.`))
+
+	cmd.Flags().BoolVar(&c.flagFetch, "fetch", false, i18n.G("Have the server fetch the key from its configured location"))
+	cmd.Flags().StringVar(&c.storageKey.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageKeyLoad) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	resource, err := c.storageKey.parsePool(args[0])
+	if err != nil {
+		return err
+	}
+
+	req := api.StoragePoolKeyPost{Action: "load"}
+	if !c.flagFetch {
+		req.Key, err = c.storageKey.readKey(i18n.G("Key: "), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resource.server.UpdateStoragePoolKey(resource.name, req)
+}
+
+// Show.
+type cmdStorageKeyShow struct {
+	global     *cmdGlobal
+	storageKey *cmdStorageKey
+}
+
+func (c *cmdStorageKeyShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Show the encryption key status of storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show the encryption key status of storage pools`))
+
+	cmd.Flags().StringVar(&c.storageKey.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageKeyShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	resource, err := c.storageKey.parsePool(args[0])
+	if err != nil {
+		return err
+	}
+
+	key, err := resource.server.GetStoragePoolKey(resource.name)
+	if err != nil {
+		return err
+	}
+
+	return cli.RenderObject("yaml", key)
+}
+
+// Unload.
+type cmdStorageKeyUnload struct {
+	global     *cmdGlobal
+	storageKey *cmdStorageKey
+}
+
+func (c *cmdStorageKeyUnload) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("unload", i18n.G("[<remote>:]<pool>"))
+	cmd.Short = i18n.G("Unload the encryption key of storage pools")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Unload the encryption key of storage pools
+
+All the instances and custom volumes using the pool must be stopped or unmounted first.`))
+
+	cmd.Flags().StringVar(&c.storageKey.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpStoragePools(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdStorageKeyUnload) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	resource, err := c.storageKey.parsePool(args[0])
+	if err != nil {
+		return err
+	}
+
+	return resource.server.UpdateStoragePoolKey(resource.name, api.StoragePoolKeyPost{Action: "unload"})
+}
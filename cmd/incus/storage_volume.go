@@ -1301,6 +1301,17 @@ type cmdStorageVolumeInfo struct {
 	global        *cmdGlobal
 	storage       *cmdStorage
 	storageVolume *cmdStorageVolume
+
+	flagFormat string
+}
+
+// storageVolumeInfo is the structured output of the storage volume info command.
+type storageVolumeInfo struct {
+	api.StorageVolume `yaml:",inline"`
+
+	State     *api.StorageVolumeState       `json:"state" yaml:"state"`
+	Snapshots []api.StorageVolumeSnapshot   `json:"snapshots" yaml:"snapshots"`
+	Backups   []api.StoragePoolVolumeBackup `json:"backups" yaml:"backups"`
 }
 
 func (c *cmdStorageVolumeInfo) Command() *cobra.Command {
@@ -1320,6 +1331,7 @@ incus storage volume info default virtual-machine/data
     Returns state information for a virtual machine "data" in pool "default".`))
 
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "text", i18n.G("Format (json|text|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -1407,6 +1419,10 @@ func (c *cmdStorageVolumeInfo) Run(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if c.flagFormat != "text" {
+		return cli.RenderObject(c.flagFormat, storageVolumeInfo{StorageVolume: *vol, State: volState, Snapshots: volSnapshots, Backups: volBackups})
+	}
+
 	// Render the overview.
 	fmt.Printf(i18n.G("Name: %s")+"\n", vol.Name)
 	if vol.Description != "" {
@@ -2098,6 +2114,8 @@ type cmdStorageVolumeShow struct {
 	global        *cmdGlobal
 	storage       *cmdStorage
 	storageVolume *cmdStorageVolume
+
+	flagFormat string
 }
 
 func (c *cmdStorageVolumeShow) Command() *cobra.Command {
@@ -2119,6 +2137,7 @@ incus storage volume show default container/data
     Will show the properties of the filesystem for a container called "data" in the "default" pool.`))
 
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -2179,14 +2198,7 @@ func (c *cmdStorageVolumeShow) Run(cmd *cobra.Command, args []string) error {
 
 	sort.Strings(vol.UsedBy)
 
-	data, err := yaml.Marshal(&vol)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &vol)
 }
 
 // Unset.
@@ -2818,6 +2830,8 @@ type cmdStorageVolumeSnapshotShow struct {
 	storage               *cmdStorage
 	storageVolume         *cmdStorageVolume
 	storageVolumeSnapshot *cmdStorageVolumeSnapshot
+
+	flagFormat string
 }
 
 func (c *cmdStorageVolumeSnapshotShow) Command() *cobra.Command {
@@ -2837,6 +2851,7 @@ func (c *cmdStorageVolumeSnapshotShow) Command() *cobra.Command {
 
 	cmd.Flags().StringVar(&c.storage.flagTarget, "target", "", i18n.G("Cluster member name")+"``")
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -2895,14 +2910,7 @@ func (c *cmdStorageVolumeSnapshotShow) Run(cmd *cobra.Command, args []string) er
 		return err
 	}
 
-	data, err := yaml.Marshal(&vol)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &vol)
 }
 
 // Export.
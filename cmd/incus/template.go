@@ -0,0 +1,637 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+
+	cli "github.com/lxc/incus/v6/internal/cmd"
+	"github.com/lxc/incus/v6/internal/i18n"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/termios"
+)
+
+type cmdTemplate struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for managing instance templates.
+func (c *cmdTemplate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("template")
+	cmd.Short = i18n.G("Manage instance templates")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Manage instance templates
+
+Instance templates hold the type, configuration, devices and profiles of an
+instance, without its root filesystem. They can be combined with any
+compatible image through "incus launch --template".`))
+
+	// Capture
+	templateCaptureCmd := cmdTemplateCapture{global: c.global}
+	cmd.AddCommand(templateCaptureCmd.Command())
+
+	// Create
+	templateCreateCmd := cmdTemplateCreate{global: c.global}
+	cmd.AddCommand(templateCreateCmd.Command())
+
+	// Delete
+	templateDeleteCmd := cmdTemplateDelete{global: c.global}
+	cmd.AddCommand(templateDeleteCmd.Command())
+
+	// Edit
+	templateEditCmd := cmdTemplateEdit{global: c.global}
+	cmd.AddCommand(templateEditCmd.Command())
+
+	// List
+	templateListCmd := cmdTemplateList{global: c.global}
+	cmd.AddCommand(templateListCmd.Command())
+
+	// Rename
+	templateRenameCmd := cmdTemplateRename{global: c.global}
+	cmd.AddCommand(templateRenameCmd.Command())
+
+	// Show
+	templateShowCmd := cmdTemplateShow{global: c.global}
+	cmd.AddCommand(templateShowCmd.Command())
+
+	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
+	cmd.Args = cobra.NoArgs
+	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
+	return cmd
+}
+
+// Capture.
+type cmdTemplateCapture struct {
+	global *cmdGlobal
+
+	flagDescription string
+}
+
+// Command returns a cobra.Command for capturing an instance as an instance template.
+func (c *cmdTemplateCapture) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("capture", i18n.G("[<remote>:]<instance> <template>"))
+	cmd.Short = i18n.G("Capture an instance as an instance template")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Capture an instance as an instance template
+
+The type, local configuration, local devices and profiles of the instance are
+stored in the template. The root filesystem of the instance isn't.`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus template capture web01 web
+    Create the "web" instance template from the "web01" instance.`))
+
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Instance template description")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstances(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run captures an instance as an instance template.
+func (c *cmdTemplateCapture) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance name"))
+	}
+
+	// Capture the instance
+	template := api.InstanceTemplatesPost{
+		Name:   args[1],
+		Source: resource.name,
+	}
+
+	template.Description = c.flagDescription
+
+	err = resource.server.CreateInstanceTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance template %s captured from %s")+"\n", args[1], resource.name)
+	}
+
+	return nil
+}
+
+// Create.
+type cmdTemplateCreate struct {
+	global *cmdGlobal
+
+	flagDescription string
+}
+
+// Command returns a cobra.Command for creating an instance template.
+func (c *cmdTemplateCreate) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("create", i18n.G("[<remote>:]<template>"))
+	cmd.Short = i18n.G("Create an instance template")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Create an instance template`))
+	cmd.Example = cli.FormatSection("", i18n.G(`incus template create web < config.yaml
+    Create an instance template with configuration from config.yaml`))
+
+	cmd.Flags().StringVar(&c.flagDescription, "description", "", i18n.G("Instance template description")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run creates an instance template.
+func (c *cmdTemplateCreate) Run(cmd *cobra.Command, args []string) error {
+	var stdinData api.InstanceTemplatePut
+
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		err = yaml.Unmarshal(contents, &stdinData)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance template name"))
+	}
+
+	// Create the instance template
+	template := api.InstanceTemplatesPost{
+		Name:                resource.name,
+		InstanceTemplatePut: stdinData,
+	}
+
+	if c.flagDescription != "" {
+		template.Description = c.flagDescription
+	}
+
+	err = resource.server.CreateInstanceTemplate(template)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance template %s created")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Delete.
+type cmdTemplateDelete struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for deleting an instance template.
+func (c *cmdTemplateDelete) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("delete", i18n.G("[<remote>:]<template>"))
+	cmd.Aliases = []string{"rm"}
+	cmd.Short = i18n.G("Delete an instance template")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Delete an instance template
+
+The instances created from the template are left untouched.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run deletes an instance template.
+func (c *cmdTemplateDelete) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance template name"))
+	}
+
+	// Delete the instance template
+	err = resource.server.DeleteInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance template %s deleted")+"\n", resource.name)
+	}
+
+	return nil
+}
+
+// Edit.
+type cmdTemplateEdit struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for editing an instance template.
+func (c *cmdTemplateEdit) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("edit", i18n.G("[<remote>:]<template>"))
+	cmd.Short = i18n.G("Edit an instance template")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Edit an instance template`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// helpTemplate returns a string explaining the expected YAML structure for an instance template.
+func (c *cmdTemplateEdit) helpTemplate() string {
+	return i18n.G(
+		`### This is a YAML representation of the instance template.
+### Any line starting with a '# will be ignored.
+###
+### An instance template consists of a description, an optional instance type,
+### a set of configuration keys, a set of devices and a list of profiles.
+###
+### An example would look like:
+### description: Web servers
+### type: container
+### config:
+###   limits.cpu: "2"
+### devices:
+###   http:
+###     type: proxy
+###     listen: tcp:0.0.0.0:80
+###     connect: tcp:127.0.0.1:80
+### profiles:
+### - default
+###
+### Note that the name is shown but cannot be changed`)
+}
+
+// Run edits an instance template, either through an editor or from stdin.
+func (c *cmdTemplateEdit) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance template name"))
+	}
+
+	// If stdin isn't a terminal, read text from it
+	if !termios.IsTerminal(getStdinFd()) {
+		contents, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		newdata := api.InstanceTemplatePut{}
+
+		err = yaml.Unmarshal(contents, &newdata)
+		if err != nil {
+			return err
+		}
+
+		return resource.server.UpdateInstanceTemplate(resource.name, newdata, "")
+	}
+
+	// Extract the current value
+	template, etag, err := resource.server.GetInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	// Spawn the editor
+	content, err := textEditor("", []byte(c.helpTemplate()+"\n\n"+string(data)))
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Parse the text received from the editor
+		newdata := api.InstanceTemplatePut{}
+
+		err = yaml.Unmarshal(content, &newdata)
+		if err == nil {
+			err = resource.server.UpdateInstanceTemplate(resource.name, newdata, etag)
+		}
+
+		// Respawn the editor
+		if err != nil {
+			fmt.Fprintf(os.Stderr, i18n.G("Config parsing error: %s")+"\n", err)
+			fmt.Println(i18n.G("Press enter to open the editor again or ctrl+c to abort change"))
+
+			_, err := os.Stdin.Read(make([]byte, 1))
+			if err != nil {
+				return err
+			}
+
+			content, err = textEditor("", content)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		break
+	}
+
+	return nil
+}
+
+// List.
+type cmdTemplateList struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for listing the instance templates.
+func (c *cmdTemplateList) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("list", i18n.G("[<remote>:]"))
+	cmd.Aliases = []string{"ls"}
+	cmd.Short = i18n.G("List the instance templates")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`List the instance templates`))
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpRemotes(false)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run lists the instance templates, their types, descriptions and profiles.
+func (c *cmdTemplateList) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 0, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remote := ""
+	if len(args) == 1 {
+		remote = args[0]
+	}
+
+	resources, err := c.global.ParseServers(remote)
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name != "" {
+		return fmt.Errorf(i18n.G("Filtering isn't supported yet"))
+	}
+
+	templates, err := resource.server.GetInstanceTemplates()
+	if err != nil {
+		return err
+	}
+
+	// Render the table
+	data := [][]string{}
+	for _, template := range templates {
+		instanceType := string(template.Type)
+		if instanceType == "" {
+			instanceType = i18n.G("ANY")
+		} else if template.Type == api.InstanceTypeContainer {
+			instanceType = i18n.G("CONTAINER")
+		} else if template.Type == api.InstanceTypeVM {
+			instanceType = i18n.G("VIRTUAL-MACHINE")
+		}
+
+		line := []string{template.Name, instanceType, template.Description, strings.Join(template.Profiles, "\n")}
+		data = append(data, line)
+	}
+
+	sort.Sort(cli.SortColumnsNaturally(data))
+
+	header := []string{
+		i18n.G("NAME"),
+		i18n.G("TYPE"),
+		i18n.G("DESCRIPTION"),
+		i18n.G("PROFILES"),
+	}
+
+	return cli.RenderTable(c.flagFormat, header, data, templates)
+}
+
+// Rename.
+type cmdTemplateRename struct {
+	global *cmdGlobal
+}
+
+// Command returns a cobra.Command for renaming an instance template.
+func (c *cmdTemplateRename) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("rename", i18n.G("[<remote>:]<template> <new-name>"))
+	cmd.Aliases = []string{"mv"}
+	cmd.Short = i18n.G("Rename an instance template")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Rename an instance template`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run renames an instance template.
+func (c *cmdTemplateRename) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance template name"))
+	}
+
+	// Perform the rename
+	err = resource.server.RenameInstanceTemplate(resource.name, api.InstanceTemplatePost{Name: args[1]})
+	if err != nil {
+		return err
+	}
+
+	if !c.global.flagQuiet {
+		fmt.Printf(i18n.G("Instance template %s renamed to %s")+"\n", resource.name, args[1])
+	}
+
+	return nil
+}
+
+// Show.
+type cmdTemplateShow struct {
+	global *cmdGlobal
+
+	flagFormat string
+}
+
+// Command returns a cobra.Command for showing an instance template.
+func (c *cmdTemplateShow) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("show", i18n.G("[<remote>:]<template>"))
+	cmd.Short = i18n.G("Show instance template configurations")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Show instance template configurations`))
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return c.global.cmpInstanceTemplates(toComplete)
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+// Run prints the configuration of an instance template in YAML format.
+func (c *cmdTemplateShow) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 1, 1)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	resources, err := c.global.ParseServers(args[0])
+	if err != nil {
+		return err
+	}
+
+	resource := resources[0]
+
+	if resource.name == "" {
+		return fmt.Errorf(i18n.G("Missing instance template name"))
+	}
+
+	// Show the instance template
+	template, _, err := resource.server.GetInstanceTemplate(resource.name)
+	if err != nil {
+		return err
+	}
+
+	return cli.RenderObject(c.flagFormat, &template)
+}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/i18n"
@@ -27,23 +28,67 @@ type batchResult struct {
 	name string
 }
 
-func runBatch(names []string, action func(name string) error) []batchResult {
-	chResult := make(chan batchResult, len(names))
+// runBatch runs the action against all the names, running at most concurrency actions in parallel
+// (no limit if concurrency is 0 or lower). The results are returned in the order of the names.
+func runBatch(names []string, concurrency int, action func(name string) error) []batchResult {
+	if concurrency <= 0 || concurrency > len(names) {
+		concurrency = len(names)
+	}
+
+	results := make([]batchResult, len(names))
+	chIndex := make(chan int)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-	for _, name := range names {
-		go func(name string) {
-			chResult <- batchResult{action(name), name}
-		}(name)
+			for i := range chIndex {
+				results[i] = batchResult{action(names[i]), names[i]}
+			}
+		}()
 	}
 
-	results := []batchResult{}
-	for range names {
-		results = append(results, <-chResult)
+	for i := range names {
+		chIndex <- i
 	}
 
+	close(chIndex)
+	wg.Wait()
+
 	return results
 }
 
+// batchError renders the errors of a batch to stderr, prefixed by the name they relate to.
+// A single result has its error returned as is, otherwise the provided error is returned if any action failed.
+func batchError(results []batchResult, err error) error {
+	if len(results) == 1 {
+		return results[0].err
+	}
+
+	success := true
+
+	for _, result := range results {
+		if result.err == nil {
+			continue
+		}
+
+		success = false
+		msg := fmt.Sprintf(i18n.G("error: %v"), result.err)
+		for _, line := range strings.Split(msg, "\n") {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", result.name, line)
+		}
+	}
+
+	if !success {
+		fmt.Fprintln(os.Stderr, "")
+		return err
+	}
+
+	return nil
+}
+
 // Add a device to an instance.
 func instanceDeviceAdd(client incus.InstanceServer, name string, devName string, dev map[string]string) error {
 	// Get the instance entry
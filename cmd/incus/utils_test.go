@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -82,3 +85,41 @@ func (s *utilsTestSuite) TestGetServerSupportedFilters() {
 	s.Equal([]string{"type=container"}, supportedFilters)
 	s.Equal([]string{"foo", "user.blah=a", "status=running,stopped"}, unsupportedFilters)
 }
+
+func (s *utilsTestSuite) TestRunBatchConcurrency() {
+	names := []string{"a", "b", "c", "d", "e"}
+
+	var running, maxRunning atomic.Int32
+	results := runBatch(names, 2, func(name string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		if name == "c" {
+			return errors.New("failed")
+		}
+
+		return nil
+	})
+
+	s.LessOrEqual(maxRunning.Load(), int32(2))
+	s.Len(results, len(names))
+
+	for i, result := range results {
+		s.Equal(names[i], result.name)
+
+		if result.name == "c" {
+			s.Error(result.err)
+		} else {
+			s.NoError(result.err)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v2"
 
 	cli "github.com/lxc/incus/v6/internal/cmd"
 	"github.com/lxc/incus/v6/internal/i18n"
@@ -28,7 +27,6 @@ func (c *cmdWarning) Command() *cobra.Command {
 	cmd.Short = i18n.G("Manage warnings")
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Manage warnings`))
-	cmd.Hidden = true
 
 	// List
 	warningListCmd := cmdWarningList{global: c.global, warning: c}
@@ -38,6 +36,10 @@ func (c *cmdWarning) Command() *cobra.Command {
 	warningAcknowledgeCmd := cmdWarningAcknowledge{global: c.global, warning: c}
 	cmd.AddCommand(warningAcknowledgeCmd.Command())
 
+	// Severity
+	warningSeverityCmd := cmdWarningSeverity{global: c.global, warning: c}
+	cmd.AddCommand(warningSeverityCmd.Command())
+
 	// Show
 	warningShowCmd := cmdWarningShow{global: c.global, warning: c}
 	cmd.AddCommand(warningShowCmd.Command())
@@ -80,6 +82,7 @@ Default column layout is: utSscpLl
 
 Column shorthand chars:
 
+    a - Acknowledged by
     c - Count
     l - Last seen
     L - Location
@@ -169,6 +172,10 @@ func (c *cmdWarningList) Run(cmd *cobra.Command, args []string) error {
 	return cli.RenderTable(c.flagFormat, headers, data, rawData)
 }
 
+func (c *cmdWarningList) acknowledgedByColumnData(warning api.Warning) string {
+	return warning.AcknowledgedBy
+}
+
 func (c *cmdWarningList) countColumnData(warning api.Warning) string {
 	return fmt.Sprintf("%d", warning.Count)
 }
@@ -207,6 +214,7 @@ func (c *cmdWarningList) uuidColumnData(warning api.Warning) string {
 
 func (c *cmdWarningList) parseColumns(clustered bool) ([]warningColumn, error) {
 	columnsShorthandMap := map[rune]warningColumn{
+		'a': {i18n.G("ACKNOWLEDGED BY"), c.acknowledgedByColumnData},
 		'c': {i18n.G("COUNT"), c.countColumnData},
 		'f': {i18n.G("FIRST SEEN"), c.firstSeenColumnData},
 		'l': {i18n.G("LAST SEEN"), c.lastSeenColumnData},
@@ -286,15 +294,89 @@ func (c *cmdWarningAcknowledge) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	warning := api.WarningPut{Status: "acknowledged"}
+	warning, etag, err := remoteServer.GetWarning(UUID)
+	if err != nil {
+		return err
+	}
+
+	warning.Status = "acknowledged"
+
+	return remoteServer.UpdateWarning(UUID, warning.WarningPut, etag)
+}
+
+// Severity.
+type cmdWarningSeverity struct {
+	global  *cmdGlobal
+	warning *cmdWarning
+}
+
+func (c *cmdWarningSeverity) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = usage("severity", i18n.G("[<remote>:]<warning-uuid> <severity>"))
+	cmd.Short = i18n.G("Override the severity of a warning")
+	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
+		`Override the severity of a warning
+
+The severity can be one of "low", "moderate" or "high".
+Use "default" to restore the default severity of the warning type.`))
+	cmd.Example = cli.FormatSection("", i18n.G(
+		`incus warning severity e9e9da0d-2538-4351-8047-46d4a8ae4dbb high
+    Always report this warning with a high severity.`))
+
+	cmd.RunE = c.Run
+
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return []string{"low", "moderate", "high", "default"}, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return cmd
+}
+
+func (c *cmdWarningSeverity) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	exit, err := c.global.CheckArgs(cmd, args, 2, 2)
+	if exit {
+		return err
+	}
+
+	// Parse remote
+	remoteName, UUID, err := c.global.conf.ParseRemote(args[0])
+	if err != nil {
+		return err
+	}
+
+	remoteServer, err := c.global.conf.GetInstanceServer(remoteName)
+	if err != nil {
+		return err
+	}
+
+	if !remoteServer.HasExtension("warnings_workflow") {
+		return fmt.Errorf(i18n.G("The server doesn't support overriding the severity of warnings"))
+	}
+
+	warning, etag, err := remoteServer.GetWarning(UUID)
+	if err != nil {
+		return err
+	}
+
+	warning.SeverityOverride = args[1]
+	if warning.SeverityOverride == "default" {
+		warning.SeverityOverride = ""
+	}
 
-	return remoteServer.UpdateWarning(UUID, warning, "")
+	return remoteServer.UpdateWarning(UUID, warning.WarningPut, etag)
 }
 
 // Show.
 type cmdWarningShow struct {
 	global  *cmdGlobal
 	warning *cmdWarning
+
+	flagFormat string
 }
 
 func (c *cmdWarningShow) Command() *cobra.Command {
@@ -304,6 +386,7 @@ func (c *cmdWarningShow) Command() *cobra.Command {
 	cmd.Long = cli.FormatSection(i18n.G("Description"), i18n.G(
 		`Show warning`))
 
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", i18n.G("Format (json|yaml)")+"``")
 	cmd.RunE = c.Run
 
 	return cmd
@@ -332,14 +415,7 @@ func (c *cmdWarningShow) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	data, err := yaml.Marshal(&warning)
-	if err != nil {
-		return err
-	}
-
-	fmt.Printf("%s", data)
-
-	return nil
+	return cli.RenderObject(c.flagFormat, &warning)
 }
 
 // Delete.
@@ -16,6 +16,7 @@ import (
 	clusterConfig "github.com/lxc/incus/v6/internal/server/cluster/config"
 	"github.com/lxc/incus/v6/internal/server/config"
 	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/node"
@@ -38,7 +39,12 @@ var api10Cmd = APIEndpoint{
 
 var api10 = []APIEndpoint{
 	api10Cmd,
+	api10BGPCmd,
+	api10PreseedCmd,
 	api10ResourcesCmd,
+	api10ResourcesStateCmd,
+	supportBundlesCmd,
+	supportBundleCmd,
 	certificateCmd,
 	certificatesCmd,
 	clusterCmd,
@@ -46,13 +52,16 @@ var api10 = []APIEndpoint{
 	clusterGroupsCmd,
 	clusterNodeCmd,
 	clusterNodeStateCmd,
+	clusterNodeConfigCmd,
 	clusterNodesCmd,
 	clusterCertificateCmd,
 	instanceBackupCmd,
 	instanceBackupExportCmd,
 	instanceBackupsCmd,
+	instancesStateCmd,
 	instanceCmd,
 	instanceConsoleCmd,
+	instanceCrashesCmd,
 	instanceExecCmd,
 	instanceFileCmd,
 	instanceExecOutputCmd,
@@ -67,7 +76,26 @@ var api10 = []APIEndpoint{
 	instanceSnapshotCmd,
 	instanceSnapshotsCmd,
 	instanceStateCmd,
+	instanceCaptureCmd,
+	instanceNetworkUsageCmd,
 	instanceAccessCmd,
+	instanceAttestationCmd,
+	instanceAttestationReportCmd,
+	instanceUsersCmd,
+	instanceUserCmd,
+	instanceNVRAMCmd,
+	instanceTPMCmd,
+	instanceUEFIVarsCmd,
+	instanceUEFICertificatesCmd,
+	instanceRenderedCloudInitCmd,
+	instanceSyscallsCmd,
+	instanceIdmapCmd,
+	instanceGroupsCmd,
+	instanceGroupCmd,
+	instanceGroupStateCmd,
+	instanceGroupSnapshotsCmd,
+	instanceTemplatesCmd,
+	instanceTemplateCmd,
 	eventsCmd,
 	imageAliasCmd,
 	imageAliasesCmd,
@@ -77,22 +105,33 @@ var api10 = []APIEndpoint{
 	imagesCmd,
 	imageSecretCmd,
 	metadataConfigurationCmd,
+	migrationTokensCmd,
+	migrationTokenInstancesCmd,
 	networkCmd,
 	networkLeasesCmd,
 	networksCmd,
+	networkGatewayCmd,
 	networkStateCmd,
+	networkTraceCmd,
 	networkACLCmd,
 	networkACLsCmd,
 	networkACLLogCmd,
+	networkACLEffectiveCmd,
 	networkAllocationsCmd,
+	networkDHCPReservationCmd,
+	networkDHCPReservationsCmd,
 	networkForwardCmd,
+	networkForwardStateCmd,
 	networkForwardsCmd,
 	networkIntegrationCmd,
 	networkIntegrationsCmd,
 	networkLoadBalancerCmd,
+	networkLoadBalancerStateCmd,
 	networkLoadBalancersCmd,
 	networkPeerCmd,
 	networkPeersCmd,
+	networkPeerRequestCmd,
+	networkPeerRequestsCmd,
 	networkZoneCmd,
 	networkZonesCmd,
 	networkZoneRecordCmd,
@@ -102,12 +141,18 @@ var api10 = []APIEndpoint{
 	operationWait,
 	operationWebsocket,
 	profileCmd,
+	profilePreviewCmd,
 	profilesCmd,
 	projectCmd,
 	projectsCmd,
 	projectStateCmd,
+	projectSnapshotsCmd,
+	projectCleanupCmd,
+	projectNetworkUsageCmd,
 	projectAccessCmd,
 	storagePoolCmd,
+	storagePoolBenchmarkCmd,
+	storagePoolKeyCmd,
 	storagePoolResourcesCmd,
 	storagePoolsCmd,
 	storagePoolBucketsCmd,
@@ -785,6 +830,7 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 	acmeChanged := false
 	bgpChanged := false
 	dnsChanged := false
+	loggingChanged := false
 	lokiChanged := false
 	oidcChanged := false
 	openFGAChanged := false
@@ -809,6 +855,12 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		case "core.bgp_asn":
 			bgpChanged = true
 
+		case "core.ca_ocsp", "core.ca_ocsp_strict":
+			localUtil.SetOCSPChecking(clusterConfig.CAOCSP(), clusterConfig.CAOCSPStrict())
+
+		case "core.debug_slow_query_threshold":
+			dbCluster.SetSlowQueryThreshold(clusterConfig.DebugSlowQueryThreshold())
+
 		case "core.https_trusted_proxy":
 			s.Endpoints.NetworkUpdateTrustedProxy(clusterChanged[key])
 
@@ -844,6 +896,9 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 
 		case "core.syslog_socket":
 			syslogChanged = true
+
+		case "core.log_level", "core.log_syslog", "core.log_debug":
+			loggingChanged = true
 		}
 	}
 
@@ -988,6 +1043,13 @@ func doApi10UpdateTriggers(d *Daemon, nodeChanged, clusterChanged map[string]str
 		}
 	}
 
+	if loggingChanged {
+		err := d.setupLogging(nodeConfig.LogLevel(), nodeConfig.LogSyslog(), nodeConfig.LogDebug())
+		if err != nil {
+			return err
+		}
+	}
+
 	// Compile and load the instance placement scriptlet.
 	value, ok = clusterChanged["instances.placement.scriptlet"]
 	if ok {
@@ -97,6 +97,12 @@ var clusterNodeCmd = APIEndpoint{
 	Post:   APIEndpointAction{Handler: clusterNodePost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var clusterNodeConfigCmd = APIEndpoint{
+	Path: "cluster/members/{name}/config",
+
+	Get: APIEndpointAction{Handler: clusterNodeConfigGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanView)},
+}
+
 var clusterNodeStateCmd = APIEndpoint{
 	Path: "cluster/members/{name}/state",
 
@@ -2915,6 +2921,213 @@ func internalClusterRaftNodeDelete(d *Daemon, r *http.Request) response.Response
 	return response.SyncResponse(true, nil)
 }
 
+// swagger:operation GET /1.0/cluster/members/{name}/config cluster cluster_member_config_get
+//
+//	Get the member-specific configuration of the cluster member
+//
+//	Gets the member-specific server configuration keys of a specific cluster member which aren't set to their default.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Cluster member configuration
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: object
+//	          additionalProperties:
+//	            type: string
+//	          description: Member-specific configuration
+//	          example: {"core.https_address": "10.0.0.1:8443"}
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/cluster/members/{name}/config?diff=1 cluster cluster_member_config_get_diff
+//
+//	Get the member-specific configuration differences of the cluster member
+//
+//	Gets the member-specific server configuration keys of a specific cluster member whose value differs
+//	from their default or from their value on other cluster members.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Cluster member configuration differences
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of configuration differences
+//	          items:
+//	            $ref: "#/definitions/ClusterMemberConfigDiff"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func clusterNodeConfigGet(d *Daemon, r *http.Request) response.Response {
+	memberName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	s := d.State()
+
+	if !s.ServerClustered {
+		return response.BadRequest(fmt.Errorf("This server is not clustered"))
+	}
+
+	if !util.IsTrue(request.QueryParam(r, "diff")) {
+		// Forward request.
+		resp := forwardedResponseToNode(s, r, memberName)
+		if resp != nil {
+			return resp
+		}
+
+		return response.SyncResponse(true, s.LocalConfig.Dump())
+	}
+
+	var members []db.NodeInfo
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !slices.ContainsFunc(members, func(member db.NodeInfo) bool { return member.Name == memberName }) {
+		return response.NotFound(fmt.Errorf("Cluster member %q not found", memberName))
+	}
+
+	// Retrieve the member-specific configuration of all reachable members.
+	configs := map[string]map[string]string{}
+	for _, member := range members {
+		if member.Name == s.ServerName {
+			configs[member.Name] = s.LocalConfig.Dump()
+			continue
+		}
+
+		if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+			if member.Name == memberName {
+				return response.BadRequest(fmt.Errorf("Cluster member %q is offline", memberName))
+			}
+
+			logger.Warn("Skipping offline cluster member when comparing configuration", logger.Ctx{"member": member.Name})
+			continue
+		}
+
+		client, err := cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		config, err := client.GetClusterMemberConfig(member.Name)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed getting configuration of cluster member %q: %w", member.Name, err))
+		}
+
+		configs[member.Name] = config
+	}
+
+	return response.SyncResponse(true, clusterMemberConfigDiff(memberName, configs))
+}
+
+// clusterMemberConfigDiff compares the member-specific configuration of a member with its defaults and with the
+// configuration of the other members. The configurations only contain the keys which aren't set to their default.
+func clusterMemberConfigDiff(memberName string, configs map[string]map[string]string) []api.ClusterMemberConfigDiff {
+	// Keys missing from all configurations are set to their default everywhere.
+	keys := []string{}
+	for _, config := range configs {
+		for key := range config {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	value := func(config map[string]string, key string) string {
+		v, ok := config[key]
+		if ok {
+			return v
+		}
+
+		return node.ConfigSchema[key].Default
+	}
+
+	diffs := []api.ClusterMemberConfigDiff{}
+	for _, key := range keys {
+		diff := api.ClusterMemberConfigDiff{
+			Key:     key,
+			Value:   value(configs[memberName], key),
+			Default: node.ConfigSchema[key].Default,
+			Members: map[string]string{},
+		}
+
+		diff.NotDefault = diff.Value != diff.Default
+
+		for name, config := range configs {
+			if name == memberName {
+				continue
+			}
+
+			otherValue := value(config, key)
+			if otherValue != diff.Value {
+				diff.Members[name] = otherValue
+			}
+		}
+
+		if diff.NotDefault || len(diff.Members) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
 // swagger:operation GET /1.0/cluster/members/{name}/state cluster cluster_member_state_get
 //
 //	Get state of the cluster member
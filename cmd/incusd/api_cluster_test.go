@@ -151,3 +151,34 @@ func (f *clusterFixture) ClientUnix(daemon *Daemon) incus.InstanceServer {
 
 	return client
 }
+
+// Member-specific configuration keys differing from their default or between members are reported.
+func TestClusterMemberConfigDiff(t *testing.T) {
+	configs := map[string]map[string]string{
+		"node1": {"core.https_address": "10.0.0.1:8443", "storage.images_volume": "local/images"},
+		"node2": {"core.https_address": "10.0.0.2:8443", "storage.images_volume": "local/images"},
+		"node3": {"core.https_address": "10.0.0.3:8443", "core.bgp_address": "10.0.0.3:179"},
+	}
+
+	diffs := clusterMemberConfigDiff("node1", configs)
+	require.Len(t, diffs, 3)
+
+	assert.Equal(t, api.ClusterMemberConfigDiff{
+		Key:     "core.bgp_address",
+		Members: map[string]string{"node3": "10.0.0.3:179"},
+	}, diffs[0])
+
+	assert.Equal(t, api.ClusterMemberConfigDiff{
+		Key:        "core.https_address",
+		Value:      "10.0.0.1:8443",
+		NotDefault: true,
+		Members:    map[string]string{"node2": "10.0.0.2:8443", "node3": "10.0.0.3:8443"},
+	}, diffs[1])
+
+	assert.Equal(t, api.ClusterMemberConfigDiff{
+		Key:        "storage.images_volume",
+		Value:      "local/images",
+		NotDefault: true,
+		Members:    map[string]string{"node3": ""},
+	}, diffs[2])
+}
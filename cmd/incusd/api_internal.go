@@ -61,10 +61,12 @@ var apiInternal = []APIEndpoint{
 	internalGarbageCollectorCmd,
 	internalImageOptimizeCmd,
 	internalImageRefreshCmd,
+	internalInstanceRebuildCmd,
 	internalRAFTSnapshotCmd,
 	internalReadyCmd,
 	internalShutdownCmd,
 	internalSQLCmd,
+	internalSQLStatsCmd,
 	internalWarningCreateCmd,
 }
 
@@ -105,6 +107,12 @@ var internalSQLCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalSQLPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalSQLStatsCmd = APIEndpoint{
+	Path: "sql/stats",
+
+	Get: APIEndpointAction{Handler: internalSQLStatsGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 var internalGarbageCollectorCmd = APIEndpoint{
 	Path: "gc",
 
@@ -129,6 +137,12 @@ var internalImageOptimizeCmd = APIEndpoint{
 	Post: APIEndpointAction{Handler: internalOptimizeImage, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
 }
 
+var internalInstanceRebuildCmd = APIEndpoint{
+	Path: "instance-rebuild",
+
+	Post: APIEndpointAction{Handler: internalInstanceRebuild, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
 var internalWarningCreateCmd = APIEndpoint{
 	Path: "testing/warnings",
 
@@ -146,6 +160,12 @@ type internalImageOptimizePost struct {
 	Pool  string    `json:"pool"  yaml:"pool"`
 }
 
+type internalInstanceRebuildPost struct {
+	Project string    `json:"project" yaml:"project"`
+	Name    string    `json:"name"    yaml:"name"`
+	Image   api.Image `json:"image"   yaml:"image"`
+}
+
 type internalWarningCreatePost struct {
 	Location       string `json:"location"         yaml:"location"`
 	Project        string `json:"project"          yaml:"project"`
@@ -439,6 +459,11 @@ func internalSQLGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, internalSQL.SQLDump{Text: dump})
 }
 
+// Get the slow query statistics of the cluster database.
+func internalSQLStatsGet(d *Daemon, r *http.Request) response.Response {
+	return response.SyncResponse(true, cluster.GetSlowQueryStats())
+}
+
 // Execute queries.
 func internalSQLPost(d *Daemon, r *http.Request) response.Response {
 	s := d.State()
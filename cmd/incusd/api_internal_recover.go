@@ -14,11 +14,14 @@ import (
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/backup"
 	backupConfig "github.com/lxc/incus/v6/internal/server/backup/config"
+	clusterRequest "github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -27,6 +30,7 @@ import (
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 // Define API endpoints for recover actions.
@@ -53,6 +57,7 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 	var projects map[string]*api.Project
 	var projectProfiles map[string][]*api.Profile
 	var projectNetworks map[string]map[int64]api.Network
+	var projectNetworkACLs map[string][]string
 
 	// Retrieve all project, profile and network info in a single transaction so we can use it for all
 	// imported instances and volumes, and avoid repeatedly querying the same information.
@@ -101,6 +106,12 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 			return err
 		}
 
+		// Load list of project/network ACL names for validation.
+		projectNetworkACLs, err = tx.GetNetworkACLsAllProjects(ctx)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -121,6 +132,68 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 		}
 	}
 
+	// Look for OVN networks and network ACLs that can be recovered from the hints stored in the OVN northbound
+	// database. Network recovery isn't supported when clustered, as the network records are shared.
+	var unknownNetworks []*network.OVNRecoveryHint
+	var unknownNetworkACLs []*acl.OVNACLRecoveryHint
+
+	if s.OVNNB != nil && !s.ServerClustered {
+		unknownNetworks, unknownNetworkACLs, err = internalRecoverScanOVN(ctx, s, projectNetworkACLs)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed checking OVN networks: %w", err))
+		}
+	}
+
+	// networkKnown returns whether the network exists or is going to be recovered.
+	networkKnown := func(projectName string, networkName string) bool {
+		for _, n := range projectNetworks[projectName] {
+			if n.Name == networkName {
+				return true
+			}
+		}
+
+		for _, hint := range unknownNetworks {
+			if hint.Project == projectName && hint.Network.Name == networkName {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// Check dependencies are met for each network ACL and network.
+	for _, hint := range unknownNetworkACLs {
+		if projects[hint.Project] == nil {
+			addDependencyError(fmt.Errorf("Project %q", hint.Project))
+		}
+	}
+
+	for _, hint := range unknownNetworks {
+		if projects[hint.Project] == nil {
+			addDependencyError(fmt.Errorf("Project %q", hint.Project))
+			continue
+		}
+
+		uplinkName := hint.Network.Config["network"]
+		if uplinkName != "" && !networkKnown(api.ProjectDefaultName, uplinkName) {
+			addDependencyError(fmt.Errorf("Network %q in project %q", uplinkName, api.ProjectDefaultName))
+		}
+
+		for _, aclName := range util.SplitNTrimSpace(hint.Network.Config["security.acls"], ",", -1, true) {
+			foundACL := slices.Contains(projectNetworkACLs[hint.Project], aclName)
+			for _, aclHint := range unknownNetworkACLs {
+				if aclHint.Project == hint.Project && aclHint.ACL.Name == aclName {
+					foundACL = true
+					break
+				}
+			}
+
+			if !foundACL {
+				addDependencyError(fmt.Errorf("Network ACL %q in project %q", aclName, hint.Project))
+			}
+		}
+	}
+
 	// Used to store the unknown volumes for each pool & project.
 	poolsProjectVols := make(map[string]map[string][]*backupConfig.Config)
 
@@ -251,15 +324,7 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 						continue
 					}
 
-					foundNetwork := false
-					for _, n := range projectNetworks[networkProjectName] {
-						if n.Name == devConfig["network"] {
-							foundNetwork = true
-							break
-						}
-					}
-
-					if !foundNetwork {
+					if !networkKnown(networkProjectName, devConfig["network"]) {
 						addDependencyError(fmt.Errorf("Network %q in project %q", devConfig["network"], projectName))
 					}
 				}
@@ -302,11 +367,49 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 			}
 		}
 
+		for _, hint := range unknownNetworkACLs {
+			res.UnknownNetworkACLs = append(res.UnknownNetworkACLs, internalRecover.ValidateNetworkACL{
+				Name:    hint.ACL.Name,
+				Project: hint.Project,
+			})
+		}
+
+		for _, hint := range unknownNetworks {
+			res.UnknownNetworks = append(res.UnknownNetworks, internalRecover.ValidateNetwork{
+				Name:              hint.Network.Name,
+				Type:              hint.Network.Type,
+				Project:           hint.Project,
+				ForwardCount:      len(hint.Forwards),
+				LoadBalancerCount: len(hint.LoadBalancers),
+			})
+		}
+
 		return response.SyncResponse(true, &res)
 	}
 
 	// If in import mode and no dependency errors, then re-create missing DB records.
 
+	// Recover the network ACLs first, as the networks may be using them.
+	for _, hint := range unknownNetworkACLs {
+		cleanup, err := internalRecoverImportNetworkACL(ctx, s, hint)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed importing network ACL %q in project %q: %w", hint.ACL.Name, hint.Project, err))
+		}
+
+		revert.Add(cleanup)
+	}
+
+	// Recover the networks along with their forwards and load balancers (do this before recovering instances so
+	// that any instances connected to them can be created).
+	for _, hint := range unknownNetworks {
+		cleanup, err := internalRecoverImportNetwork(ctx, s, hint)
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed importing network %q in project %q: %w", hint.Network.Name, hint.Project, err))
+		}
+
+		revert.Add(cleanup)
+	}
+
 	// Create the pools themselves.
 	for _, pool := range pools {
 		// Create missing storage pool DB record if neeed.
@@ -500,6 +603,208 @@ func internalRecoverScan(ctx context.Context, s *state.State, userPools []api.St
 	return response.EmptySyncResponse
 }
 
+// internalRecoverScanOVN returns the OVN networks and network ACLs which are missing from the database, but can be
+// recovered from the hints stored in the OVN northbound database.
+func internalRecoverScanOVN(ctx context.Context, s *state.State, projectNetworkACLs map[string][]string) ([]*network.OVNRecoveryHint, []*acl.OVNACLRecoveryHint, error) {
+	switchHints, err := s.OVNNB.GetLogicalSwitchRecoveryHints(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed getting network recovery hints: %w", err)
+	}
+
+	portGroupHints, err := s.OVNNB.GetPortGroupRecoveryHints(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed getting network ACL recovery hints: %w", err)
+	}
+
+	networks := []*network.OVNRecoveryHint{}
+	networkACLs := []*acl.OVNACLRecoveryHint{}
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for switchName, hintJSON := range switchHints {
+			hint := &network.OVNRecoveryHint{}
+			err := json.Unmarshal([]byte(hintJSON), hint)
+			if err != nil {
+				logger.Warn("Failed parsing network recovery hint", logger.Ctx{"switch": switchName, "err": err})
+				continue
+			}
+
+			if hint.Network.Type != "ovn" {
+				continue
+			}
+
+			// Check whether the network still exists. As the OVN objects of the network are named after
+			// its ID, the network can only be recovered if its ID is available.
+			networkName, projectName, err := tx.GetNetworkNameAndProjectWithID(ctx, int(hint.ID))
+			if err == nil {
+				if networkName != hint.Network.Name || projectName != hint.Project {
+					logger.Warn("Skipping network recovery as its ID is in use", logger.Ctx{"project": hint.Project, "network": hint.Network.Name, "id": hint.ID})
+				}
+
+				continue
+			} else if !response.IsNotFoundError(err) {
+				return err
+			}
+
+			_, err = tx.GetNetworkID(ctx, hint.Project, hint.Network.Name)
+			if err == nil {
+				logger.Warn("Skipping network recovery as its name is in use", logger.Ctx{"project": hint.Project, "network": hint.Network.Name})
+				continue
+			} else if !response.IsNotFoundError(err) {
+				return err
+			}
+
+			networks = append(networks, hint)
+		}
+
+		for portGroupName, hintJSON := range portGroupHints {
+			hint := &acl.OVNACLRecoveryHint{}
+			err := json.Unmarshal([]byte(hintJSON), hint)
+			if err != nil {
+				logger.Warn("Failed parsing network ACL recovery hint", logger.Ctx{"portGroup": portGroupName, "err": err})
+				continue
+			}
+
+			// Check whether the network ACL still exists. As the OVN objects of the network ACL are named
+			// after its ID, the network ACL can only be recovered if its ID is available.
+			aclName, projectName, err := tx.GetNetworkACLNameAndProjectWithID(ctx, int(hint.ID))
+			if err == nil {
+				if aclName != hint.ACL.Name || projectName != hint.Project {
+					logger.Warn("Skipping network ACL recovery as its ID is in use", logger.Ctx{"project": hint.Project, "networkACL": hint.ACL.Name, "id": hint.ID})
+				}
+
+				continue
+			} else if !response.IsNotFoundError(err) {
+				return err
+			}
+
+			if slices.Contains(projectNetworkACLs[hint.Project], hint.ACL.Name) {
+				logger.Warn("Skipping network ACL recovery as its name is in use", logger.Ctx{"project": hint.Project, "networkACL": hint.ACL.Name})
+				continue
+			}
+
+			networkACLs = append(networkACLs, hint)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sort by ID so that the recovered records are created in their original order.
+	slices.SortFunc(networks, func(a *network.OVNRecoveryHint, b *network.OVNRecoveryHint) int {
+		return int(a.ID - b.ID)
+	})
+
+	slices.SortFunc(networkACLs, func(a *acl.OVNACLRecoveryHint, b *acl.OVNACLRecoveryHint) int {
+		return int(a.ID - b.ID)
+	})
+
+	return networks, networkACLs, nil
+}
+
+// internalRecoverImportNetworkACL recreates the database record for a network ACL.
+// Returns a revert fail function that can be used to undo this function if a subsequent step fails.
+func internalRecoverImportNetworkACL(ctx context.Context, s *state.State, hint *acl.OVNACLRecoveryHint) (revert.Hook, error) {
+	// Keep the original ID as the OVN port groups of the network ACL are named after it.
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.CreateNetworkACLWithID(ctx, hint.ID, hint.Project, &api.NetworkACLsPost{
+			NetworkACLPost: hint.ACL.NetworkACLPost,
+			NetworkACLPut:  hint.ACL.NetworkACLPut,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating network ACL record: %w", err)
+	}
+
+	err = s.Authorizer.AddNetworkACL(ctx, hint.Project, hint.ACL.Name)
+	if err != nil {
+		logger.Error("Failed to add network ACL to authorizer", logger.Ctx{"name": hint.ACL.Name, "project": hint.Project, "error": err})
+	}
+
+	cleanup := func() {
+		_ = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.DeleteNetworkACL(ctx, hint.ID)
+		})
+	}
+
+	return cleanup, nil
+}
+
+// internalRecoverImportNetwork recreates the database records for an OVN network along with its forwards and load
+// balancers, and then brings the logical network in the OVN northbound database back in line with them.
+// Returns a revert fail function that can be used to undo this function if a subsequent step fails.
+func internalRecoverImportNetwork(ctx context.Context, s *state.State, hint *network.OVNRecoveryHint) (revert.Hook, error) {
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Keep the original ID as the OVN objects of the network are named after it.
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.CreateNetworkWithID(ctx, hint.ID, hint.Project, hint.Network.Name, hint.Network.Description, db.NetworkTypeOVN, hint.Network.Config)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating network record: %w", err)
+	}
+
+	revert.Add(func() {
+		_ = s.DB.Cluster.Transaction(context.Background(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.DeleteNetwork(ctx, hint.Project, hint.Network.Name)
+		})
+	})
+
+	n, err := network.LoadByName(s, hint.Project, hint.Network.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading network: %w", err)
+	}
+
+	err = n.Validate(n.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the existing logical network rather than creating a new one.
+	err = n.Recover()
+	if err != nil {
+		return nil, err
+	}
+
+	err = n.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.NetworkNodeCreated(n.ID())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Recreate the forwards and load balancers, this also replaces their OVN load balancers.
+	for _, forward := range hint.Forwards {
+		err = n.ForwardCreate(api.NetworkForwardsPost{ListenAddress: forward.ListenAddress, NetworkForwardPut: forward.NetworkForwardPut}, clusterRequest.ClientTypeNormal)
+		if err != nil {
+			return nil, fmt.Errorf("Failed creating forward %q: %w", forward.ListenAddress, err)
+		}
+	}
+
+	for _, loadBalancer := range hint.LoadBalancers {
+		err = n.LoadBalancerCreate(api.NetworkLoadBalancersPost{ListenAddress: loadBalancer.ListenAddress, NetworkLoadBalancerPut: loadBalancer.NetworkLoadBalancerPut}, clusterRequest.ClientTypeNormal)
+		if err != nil {
+			return nil, fmt.Errorf("Failed creating load balancer %q: %w", loadBalancer.ListenAddress, err)
+		}
+	}
+
+	err = s.Authorizer.AddNetwork(ctx, hint.Project, hint.Network.Name)
+	if err != nil {
+		logger.Error("Failed to add network to authorizer", logger.Ctx{"name": hint.Network.Name, "project": hint.Project, "error": err})
+	}
+
+	cleanup := revert.Clone().Fail
+	revert.Success()
+	return cleanup, nil
+}
+
 // internalRecoverImportInstance recreates the database records for an instance and returns the new instance.
 // Returns a revert fail function that can be used to undo this function if a subsequent step fails.
 func internalRecoverImportInstance(s *state.State, pool storagePools.Pool, projectName string, poolVol *backupConfig.Config, profiles []api.Profile) (instance.Instance, revert.Hook, error) {
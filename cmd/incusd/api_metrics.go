@@ -22,6 +22,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -334,6 +335,14 @@ func internalMetrics(ctx context.Context, daemonStartTime time.Time, tx *db.Clus
 	// Daemon uptime
 	out.AddSamples(metrics.UptimeSeconds, metrics.Sample{Value: time.Since(daemonStartTime).Seconds()})
 
+	// Rejected TLS handshakes
+	out.AddSamples(metrics.TLSHandshakesRejectedTotal, metrics.Sample{Value: float64(localUtil.TLSHandshakesRejected())})
+
+	// Exec and console sessions
+	out.AddSamples(metrics.SessionsActive, metrics.Sample{Value: float64(instanceSessionsActive())})
+	out.AddSamples(metrics.SessionsRejectedTotal, metrics.Sample{Value: float64(instanceSessionsRejected.Load())})
+	out.AddSamples(metrics.SessionsIdleClosedTotal, metrics.Sample{Value: float64(instanceSessionsIdleClosed.Load())})
+
 	// Number of goroutines
 	out.AddSamples(metrics.GoGoroutines, metrics.Sample{Value: float64(runtime.NumGoroutine())})
 
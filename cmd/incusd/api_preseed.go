@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+var api10PreseedCmd = APIEndpoint{
+	Path: "preseed",
+
+	Get: APIEndpointAction{Handler: api10PreseedGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// swagger:operation GET /1.0/preseed server preseed_get
+//
+//	Export the server configuration
+//
+//	Exports the server configuration, projects, storage pools, networks, network ACLs and profiles
+//	as a preseed document suitable for `incus admin init --preseed`.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Preseed document
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InitPreseed"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func api10PreseedGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	preseed := api.InitPreseed{}
+
+	config, err := daemonConfigRender(s)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	preseed.Server.Config = config
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Projects.
+		projects, err := dbCluster.GetProjects(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbProject := range projects {
+			project, err := dbProject.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			preseed.Server.Projects = append(preseed.Server.Projects, api.ProjectsPost{
+				Name:       project.Name,
+				ProjectPut: project.Writable(),
+			})
+		}
+
+		// Storage pools.
+		pools, _, err := tx.GetStoragePools(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, pool := range pools {
+			if pool.Status != api.StoragePoolStatusCreated {
+				continue
+			}
+
+			preseed.Server.StoragePools = append(preseed.Server.StoragePools, api.StoragePoolsPost{
+				Name:           pool.Name,
+				Driver:         pool.Driver,
+				StoragePoolPut: api.StoragePoolPut{Config: preseedConfig(pool.Config), Description: pool.Description},
+			})
+		}
+
+		sort.Slice(preseed.Server.StoragePools, func(i, j int) bool {
+			return preseed.Server.StoragePools[i].Name < preseed.Server.StoragePools[j].Name
+		})
+
+		// Network ACLs.
+		acls, err := tx.GetNetworkACLsAllProjects(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, projectName := range preseedSortedKeys(acls) {
+			for _, aclName := range acls[projectName] {
+				_, acl, err := tx.GetNetworkACL(ctx, projectName, aclName)
+				if err != nil {
+					return err
+				}
+
+				aclPost := api.InitNetworkACLProjectPost{Project: projectName}
+				aclPost.Name = acl.Name
+				aclPost.NetworkACLPut = acl.Writable()
+
+				preseed.Server.NetworkACLs = append(preseed.Server.NetworkACLs, aclPost)
+			}
+		}
+
+		// Networks.
+		networks, err := tx.GetNetworksAllProjects(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, projectName := range preseedSortedKeys(networks) {
+			networkNames := networks[projectName]
+			sort.Strings(networkNames)
+
+			for _, networkName := range networkNames {
+				_, network, _, err := tx.GetNetworkInAnyState(ctx, projectName, networkName)
+				if err != nil {
+					return err
+				}
+
+				if network.Status != api.NetworkStatusCreated {
+					continue
+				}
+
+				networkPost := api.InitNetworksProjectPost{Project: projectName}
+				networkPost.Name = network.Name
+				networkPost.Type = network.Type
+				networkPost.Description = network.Description
+				networkPost.Config = preseedConfig(network.Config)
+
+				preseed.Server.Networks = append(preseed.Server.Networks, networkPost)
+			}
+		}
+
+		// Profiles.
+		profiles, err := dbCluster.GetProfiles(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbProfile := range profiles {
+			profile, err := dbProfile.ToAPI(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			profilePost := api.InitProfileProjectPost{Project: dbProfile.Project}
+			profilePost.Name = profile.Name
+			profilePost.Description = profile.Description
+			profilePost.Config = preseedConfig(profile.Config)
+			profilePost.Devices = profile.Devices
+			profilePost.Parents = profile.Parents
+
+			preseed.Server.Profiles = append(preseed.Server.Profiles, profilePost)
+		}
+
+		// Parent profiles must be created before the profiles inheriting from them.
+		preseed.Server.Profiles = preseedSortProfiles(preseed.Server.Profiles)
+
+		return nil
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Record the cluster so that a new one can be bootstrapped from the document.
+	if s.ServerClustered {
+		preseed.Cluster = &api.InitClusterPreseed{}
+		preseed.Cluster.Enabled = true
+		preseed.Cluster.ServerName = s.ServerName
+	}
+
+	return response.SyncResponse(true, preseed)
+}
+
+// preseedConfig returns a copy of the config without the volatile keys which can't be set on creation.
+func preseedConfig(config map[string]string) map[string]string {
+	result := make(map[string]string, len(config))
+	for k, v := range config {
+		if strings.HasPrefix(k, "volatile.") {
+			continue
+		}
+
+		result[k] = v
+	}
+
+	return result
+}
+
+// preseedSortedKeys returns the project names of a per-project map in a stable order.
+func preseedSortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// preseedSortProfiles orders the profiles so that parent profiles come before their children.
+func preseedSortProfiles(profiles []api.InitProfileProjectPost) []api.InitProfileProjectPost {
+	sorted := make([]api.InitProfileProjectPost, 0, len(profiles))
+	added := map[string]bool{}
+
+	for len(profiles) > 0 {
+		remaining := profiles[:0:0]
+		for _, profile := range profiles {
+			ready := true
+			for _, parent := range profile.Parents {
+				if !added[profile.Project+"/"+parent] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				sorted = append(sorted, profile)
+				added[profile.Project+"/"+profile.Name] = true
+			} else {
+				remaining = append(remaining, profile)
+			}
+		}
+
+		// Don't loop forever on missing or looping parents.
+		if len(remaining) == len(profiles) {
+			return append(sorted, remaining...)
+		}
+
+		profiles = remaining
+	}
+
+	return sorted
+}
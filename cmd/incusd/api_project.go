@@ -16,6 +16,7 @@ import (
 
 	"github.com/lxc/incus/v6/client"
 	"github.com/lxc/incus/v6/internal/jmap"
+	"github.com/lxc/incus/v6/internal/server/apparmor"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
@@ -58,6 +59,18 @@ var projectStateCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: projectStateGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
 }
 
+var projectSnapshotsCmd = APIEndpoint{
+	Path: "projects/{name}/snapshots",
+
+	Post: APIEndpointAction{Handler: projectSnapshotsPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+}
+
+var projectCleanupCmd = APIEndpoint{
+	Path: "projects/{name}/cleanup",
+
+	Get: APIEndpointAction{Handler: projectCleanupGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+}
+
 var projectAccessCmd = APIEndpoint{
 	Path: "projects/{name}/access",
 
@@ -1262,6 +1275,107 @@ func projectStateGet(d *Daemon, r *http.Request) response.Response {
 	return response.SyncResponse(true, &state)
 }
 
+// swagger:operation POST /1.0/projects/{name}/snapshots projects project_snapshots_post
+//
+//	Snapshot the instances of the project
+//
+//	Creates a snapshot with the same name of all (or the listed) instances of the project at about the same time,
+//	optionally flushing their filesystem buffers first, for point-in-time recovery of the whole project.
+//	If any snapshot fails, the snapshots created by the request are deleted again.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: body
+//	    name: snapshot
+//	    description: Snapshot request
+//	    required: false
+//	    schema:
+//	      $ref: "#/definitions/ProjectSnapshotsPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectSnapshotsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ProjectSnapshotsPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	var instNames []string
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		instNames, err = tx.GetInstanceNames(ctx, name)
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Restrict to the requested instances.
+	if len(req.Instances) > 0 {
+		for _, instName := range req.Instances {
+			if !slices.Contains(instNames, instName) {
+				return response.BadRequest(fmt.Errorf("Instance %q not found in project %q", instName, name))
+			}
+		}
+
+		instNames = req.Instances
+	}
+
+	if len(instNames) == 0 {
+		return response.BadRequest(fmt.Errorf("No instances to snapshot in project %q", name))
+	}
+
+	userHasPermission, err := s.Authorizer.GetPermissionChecker(r.Context(), r, auth.EntitlementCanManageSnapshots, auth.ObjectTypeInstance)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	for _, instName := range instNames {
+		if !userHasPermission(auth.ObjectInstance(name, instName)) {
+			return response.Forbidden(fmt.Errorf("Not allowed to snapshot instance %q", instName))
+		}
+	}
+
+	err = instancesSnapshotPrepare(r.Context(), s, name, instNames, &req.InstanceSnapshotsPost)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	snapshot := func(op *operations.Operation) error {
+		return instancesSnapshotCreate(s, r, op, name, instNames, req.InstanceSnapshotsPost, req.Quiesce)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["projects"] = []api.URL{*api.NewURL().Path(version.APIVersion, "projects", name)}
+	for _, instName := range instNames {
+		resources["instances_snapshots"] = append(resources["instances_snapshots"], *api.NewURL().Path(version.APIVersion, "instances", instName, "snapshots", req.Name).Project(name))
+	}
+
+	op, err := operations.OperationCreate(s, name, operations.OperationClassTask, operationtype.SnapshotCreate, resources, nil, snapshot, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
 // Check if a project is empty.
 func projectIsEmpty(ctx context.Context, project *cluster.Project, tx *db.ClusterTx) (bool, error) {
 	usedBy, err := projectUsedBy(ctx, tx, project)
@@ -1301,6 +1415,36 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Compression algorithm to use for backups
 		"backups.compression_algorithm": validate.IsCompressionAlgorithm,
 
+		// gendoc:generate(entity=project, group=specific, key=cleanup.schedule)
+		// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`), a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`), or leave empty to disable the cleanup.
+		//
+		// See {ref}`projects-cleanup` for more information.
+		// ---
+		//  type: string
+		//  shortdesc: Schedule for removing the abandoned resources of the project
+		"cleanup.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+
+		// gendoc:generate(entity=project, group=specific, key=cleanup.images.unused_expiry)
+		// Specify the number of days after which an image that has no alias and isn't used by any instance is deleted.
+		// ---
+		//  type: integer
+		//  shortdesc: When an unused image is deleted by the project cleanup
+		"cleanup.images.unused_expiry": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=specific, key=cleanup.instances.stopped_expiry)
+		// Specify the number of days after which an instance that has been stopped since is deleted.
+		// ---
+		//  type: integer
+		//  shortdesc: When a stopped instance is deleted by the project cleanup
+		"cleanup.instances.stopped_expiry": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=specific, key=cleanup.volumes.unused_expiry)
+		// Specify the number of days after which a custom storage volume that isn't used by any instance or profile is deleted.
+		// ---
+		//  type: integer
+		//  shortdesc: When an unused custom storage volume is deleted by the project cleanup
+		"cleanup.volumes.unused_expiry": validate.Optional(validate.IsUint32),
+
 		// gendoc:generate(entity=project, group=features, key=features.profiles)
 		//
 		// ---
@@ -1447,6 +1591,30 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  shortdesc: Maximum number of networks that the project can have
 		"limits.networks": validate.Optional(validate.IsUint32),
 
+		// gendoc:generate(entity=project, group=limits, key=limits.operations.backups)
+		// Further backups of the project are queued until running ones complete.
+		// The limit applies on each cluster member, on top of {config:option}`server-miscellaneous:operations.max_backups`.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of concurrent backups of the project on each cluster member
+		"limits.operations.backups": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.operations.image_downloads)
+		// Further image downloads of the project are queued until running ones complete.
+		// The limit applies on each cluster member, on top of {config:option}`server-miscellaneous:operations.max_image_downloads`.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of concurrent image downloads of the project on each cluster member
+		"limits.operations.image_downloads": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=project, group=limits, key=limits.operations.migrations)
+		// Further outgoing migrations of the project are queued until running ones complete.
+		// The limit applies on each cluster member, on top of {config:option}`server-miscellaneous:operations.max_migrations`.
+		// ---
+		//  type: integer
+		//  shortdesc: Maximum number of concurrent outgoing migrations of the project on each cluster member
+		"limits.operations.migrations": validate.Optional(validate.IsUint32),
+
 		// gendoc:generate(entity=project, group=restricted, key=restricted)
 		// This option must be enabled to allow the `restricted.*` keys to take effect.
 		// To temporarily remove the restrictions, you can disable this option instead of clearing the related keys.
@@ -1690,6 +1858,25 @@ func projectValidateConfig(s *state.State, config map[string]string) error {
 		//  defaultdesc: `block`
 		//  shortdesc: Whether to prevent creating instance or volume snapshots
 		"restricted.snapshots": isEitherAllowOrBlock,
+
+		// gendoc:generate(entity=project, group=restricted, key=restricted.sftp)
+		// Possible values are `allow` or `block`.
+		// When set to `allow`, SFTP connections to the instances of the project are permitted.
+		// This covers `incus file mount` as well as any other client using the instance SFTP API.
+		// ---
+		//  type: string
+		//  defaultdesc: `allow`
+		//  shortdesc: Whether to prevent SFTP access to instances
+		"restricted.sftp": isEitherAllowOrBlock,
+
+		// gendoc:generate(entity=project, group=specific, key=security.apparmor)
+		// AppArmor rules added to the profile of all instances of the project, to tighten or relax their confinement.
+		// Only plain rules are accepted: includes, nested profiles, hats, profile transitions and unconfined execution are rejected.
+		// The rules apply to instances when they next start.
+		// ---
+		//  type: blob
+		//  shortdesc: Additional AppArmor rules for the instances of the project
+		"security.apparmor": apparmor.ValidateProjectRules,
 	}
 
 	for k, v := range config {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/task"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// refreshRevocationListTask reloads the network certificate whenever the CA revocation list changes on disk,
+// so that the TLS endpoints reject newly revoked client certificates without a restart.
+func refreshRevocationListTask(d *Daemon) (task.Func, task.Schedule) {
+	crlPath := filepath.Join(d.os.VarDir, "ca.crl")
+
+	// The revocation list currently on disk was loaded along with the network certificate.
+	var lastModTime time.Time
+	fi, err := os.Stat(crlPath)
+	if err == nil {
+		lastModTime = fi.ModTime()
+	}
+
+	f := func(ctx context.Context) {
+		var modTime time.Time
+		fi, err := os.Stat(crlPath)
+		if err == nil {
+			modTime = fi.ModTime()
+		}
+
+		if modTime.Equal(lastModTime) {
+			return
+		}
+
+		// The revocation list is only used when in CA mode.
+		if d.endpoints.NetworkCert().CA() == nil {
+			lastModTime = modTime
+			return
+		}
+
+		cert, err := internalUtil.LoadCert(d.os.VarDir)
+		if err != nil {
+			logger.Warn("Failed reloading certificate revocation list", logger.Ctx{"err": err})
+			return
+		}
+
+		lastModTime = modTime
+
+		d.endpoints.NetworkUpdateCert(cert)
+		d.gateway.NetworkUpdateCert(cert)
+
+		logger.Info("Reloaded certificate revocation list")
+	}
+
+	return f, task.Every(time.Minute)
+}
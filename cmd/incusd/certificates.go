@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"crypto/x509"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
@@ -29,6 +30,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
@@ -226,6 +228,11 @@ func updateCertificateCache(d *Daemon) {
 	}
 
 	for i, dbCert := range dbCerts {
+		// Skip expired certificates which haven't been pruned yet.
+		if dbCert.ExpiryDate.Valid && dbCert.ExpiryDate.Time.Before(time.Now()) {
+			continue
+		}
+
 		_, found := newCerts[dbCert.Type]
 		if !found {
 			newCerts[dbCert.Type] = make(map[string]x509.Certificate)
@@ -313,6 +320,79 @@ func updateCertificateCacheFromLocal(d *Daemon) error {
 	return nil
 }
 
+// pruneExpiredCertificates removes the expired certificates from the trust store.
+func pruneExpiredCertificates(ctx context.Context, s *state.State) error {
+	var expiredCerts []dbCluster.Certificate
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbCerts, err := dbCluster.GetCertificates(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, dbCert := range dbCerts {
+			if !dbCert.ExpiryDate.Valid || dbCert.ExpiryDate.Time.After(time.Now()) {
+				continue
+			}
+
+			err = dbCluster.DeleteCertificate(ctx, tx.Tx(), dbCert.Fingerprint)
+			if err != nil {
+				return err
+			}
+
+			expiredCerts = append(expiredCerts, dbCert)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed removing expired certificates: %w", err)
+	}
+
+	if len(expiredCerts) == 0 {
+		return nil
+	}
+
+	// Notify other members about the removed certificates.
+	notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAlive)
+	if err != nil {
+		return err
+	}
+
+	for _, dbCert := range expiredCerts {
+		err = notifier(func(client incus.InstanceServer) error {
+			return client.DeleteCertificate(dbCert.Fingerprint)
+		})
+		if err != nil {
+			logger.Warn("Failed notifying other members about expired certificate", logger.Ctx{"fingerprint": dbCert.Fingerprint, "err": err})
+		}
+
+		err = s.Authorizer.DeleteCertificate(ctx, dbCert.Fingerprint)
+		if err != nil {
+			logger.Error("Failed to remove certificate from authorizer", logger.Ctx{"fingerprint": dbCert.Fingerprint, "error": err})
+		}
+
+		logger.Info("Removed expired certificate from the trust store", logger.Ctx{"name": dbCert.Name, "fingerprint": dbCert.Fingerprint})
+		s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.CertificateExpired.Event(dbCert.Fingerprint, nil, map[string]any{"name": dbCert.Name, "expires_at": dbCert.ExpiryDate.Time}))
+	}
+
+	// Reload the cache.
+	s.UpdateCertificateCache()
+
+	return nil
+}
+
+func pruneExpiredCertificatesTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := pruneExpiredCertificates(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed pruning expired certificates", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
+
 // clusterMemberJoinTokenValid searches for cluster join token that matches the join token provided.
 // Returns matching operation if found and cancels the operation, otherwise returns nil.
 func clusterMemberJoinTokenValid(s *state.State, r *http.Request, projectName string, joinToken *api.ClusterMemberJoinToken) (*api.Operation, error) {
@@ -584,6 +664,7 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 					req.Type = tokenReq.Type
 					req.Restricted = tokenReq.Restricted
 					req.Projects = tokenReq.Projects
+					req.ExpiresAt = tokenReq.ExpiresAt
 				case map[string]any:
 					req.Name = tokenReq["name"].(string)
 					req.Type = tokenReq["type"].(string)
@@ -592,6 +673,14 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 						req.Projects = append(req.Projects, project.(string))
 					}
 
+					expiresAt, ok := tokenReq["expires_at"].(string)
+					if ok {
+						req.ExpiresAt, err = time.Parse(time.RFC3339Nano, expiresAt)
+						if err != nil {
+							return response.InternalError(fmt.Errorf("Bad certificate add operation expiry: %w", err))
+						}
+					}
+
 				default:
 					return response.InternalError(fmt.Errorf("Bad certificate add operation data"))
 				}
@@ -606,6 +695,16 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
+	if !req.ExpiresAt.IsZero() {
+		if req.Type != api.CertificateTypeClient {
+			return response.BadRequest(fmt.Errorf("Only client certificates can expire"))
+		}
+
+		if req.ExpiresAt.Before(time.Now()) {
+			return response.BadRequest(fmt.Errorf("The certificate expiry date is in the past"))
+		}
+	}
+
 	// Extract the certificate.
 	var cert *x509.Certificate
 	if req.Certificate != "" {
@@ -729,6 +828,10 @@ func certificatesPost(d *Daemon, r *http.Request) response.Response {
 				Description: req.Description,
 			}
 
+			if !req.ExpiresAt.IsZero() {
+				dbCert.ExpiryDate = sql.NullTime{Time: req.ExpiresAt, Valid: true}
+			}
+
 			_, err := dbCluster.CreateCertificateWithProjects(ctx, tx.Tx(), dbCert, req.Projects)
 			return err
 		})
@@ -1073,6 +1176,11 @@ func doCertificateUpdate(d *Daemon, dbInfo api.Certificate, req api.CertificateP
 			}
 		}
 
+		// The expiry date can't be changed.
+		if !dbInfo.ExpiresAt.IsZero() {
+			dbCert.ExpiryDate = sql.NullTime{Time: dbInfo.ExpiresAt, Valid: true}
+		}
+
 		// Update the database record.
 		err = s.DB.UpdateCertificate(context.Background(), dbInfo.Fingerprint, dbCert, certProjects)
 		if err != nil {
@@ -173,6 +173,7 @@ type Daemon struct {
 type DaemonConfig struct {
 	Group              string        // Group name the local unix socket should be chown'ed to
 	Trace              []string      // List of sub-systems to trace
+	Syslog             bool          // Whether logging to syslog was requested on the command line
 	RaftLatency        float64       // Coarse grain measure of the cluster latency
 	DqliteSetupTimeout time.Duration // How long to wait for the cluster database to be up
 }
@@ -1367,9 +1368,14 @@ func (d *Daemon) init() error {
 	d.proxy = proxy.FromConfig(d.globalConfig.ProxyHTTPS(), d.globalConfig.ProxyHTTP(), d.globalConfig.ProxyIgnoreHosts())
 
 	d.gateway.HeartbeatOfflineThreshold = d.globalConfig.OfflineThreshold()
+	dbCluster.SetSlowQueryThreshold(d.globalConfig.DebugSlowQueryThreshold())
+	localUtil.SetOCSPChecking(d.globalConfig.CAOCSP(), d.globalConfig.CAOCSPStrict())
 	lokiURL, lokiUsername, lokiPassword, lokiCACert, lokiInstance, lokiLoglevel, lokiLabels, lokiTypes := d.globalConfig.LokiServer()
 	oidcIssuer, oidcClientID, oidcAudience, oidcClaim := d.globalConfig.OIDCServer()
 	syslogSocketEnabled := d.localConfig.SyslogSocket()
+	logLevel := d.localConfig.LogLevel()
+	logSyslog := d.localConfig.LogSyslog()
+	logDebug := d.localConfig.LogDebug()
 	openfgaAPIURL, openfgaAPIToken, openfgaStoreID := d.globalConfig.OpenFGA()
 	instancePlacementScriptlet := d.globalConfig.InstancesPlacementScriptlet()
 
@@ -1384,6 +1390,12 @@ func (d *Daemon) init() error {
 		}
 	}
 
+	// Setup logging.
+	err = d.setupLogging(logLevel, logSyslog, logDebug)
+	if err != nil {
+		return err
+	}
+
 	// Setup syslog listener.
 	if syslogSocketEnabled {
 		err = d.setupSyslogSocket(true)
@@ -1614,17 +1626,68 @@ func (d *Daemon) init() error {
 		// Prune expired instance snapshots and take snapshot of instances (minutely check of configurable cron expression)
 		d.tasks.Add(pruneExpiredAndAutoCreateInstanceSnapshotsTask(d))
 
+		// Run the scheduled tasks of instances (minutely check of configurable cron expressions)
+		d.tasks.Add(instanceTasksTask(d))
+
+		// Stop and delete expired instances (minutely)
+		d.tasks.Add(instanceExpiryTask(d))
+
 		// Prune expired custom volume snapshots and take snapshots of custom volumes (minutely check of configurable cron expression)
 		d.tasks.Add(pruneExpiredAndAutoCreateCustomVolumeSnapshotsTask(d))
 
 		// Remove resolved warnings (daily)
 		d.tasks.Add(pruneResolvedWarningsTask(d))
 
+		// Resolve warnings according to the auto-resolution rules (hourly)
+		d.tasks.Add(autoResolveWarningsTask(d))
+
 		// Auto-renew server certificate (daily)
 		d.tasks.Add(autoRenewCertificateTask(d))
 
 		// Remove expired tokens (hourly)
 		d.tasks.Add(autoRemoveExpiredTokensTask(d))
+
+		// Remove expired certificates from the trust store (minutely)
+		d.tasks.Add(pruneExpiredCertificatesTask(d))
+
+		// Reload the CA revocation list when it changes (minutely)
+		d.tasks.Add(refreshRevocationListTask(d))
+
+		// Account instance network usage (every 5 minutes)
+		d.tasks.Add(instanceNetworkUsageTask(d))
+
+		// Freeze and unfreeze instances according to host memory pressure (every 10 seconds)
+		d.tasks.Add(instancesMemoryPressureTask(d))
+
+		// Report out of memory kills in containers (every 10 seconds)
+		d.tasks.Add(instancesOOMKillsTask(d))
+
+		// Run the readiness and liveness probes of instances (every 5 seconds)
+		d.tasks.Add(instancesProbesTask(d))
+
+		// Warn about volumes nearing their quota (every 15 minutes)
+		d.tasks.Add(storageQuotaWarningsTask(d))
+
+		// Warn about uplink networks nearing the exhaustion of their OVN ranges (every 15 minutes)
+		d.tasks.Add(networkUplinkWarningsTask(d))
+
+		// Remove the abandoned resources of projects (minutely check of configurable cron expressions)
+		d.tasks.Add(projectCleanupTask(d))
+
+		// Export the custom volumes shared through NFS (minutely)
+		d.tasks.Add(storageVolumesSharingTask(d))
+
+		// Run the scheduled maintenance of storage pools (minutely check of configurable cron expressions)
+		d.tasks.Add(storagePoolMaintenanceTask(d))
+
+		// Monitor the usage of storage pools (minutely)
+		d.tasks.Add(storagePoolMonitorTask(d))
+
+		// Back up the global database (hourly check of configurable interval)
+		d.tasks.Add(databaseBackupsTask(d))
+
+		// Emit events when the gateway of OVN networks moves (every 10 seconds)
+		d.tasks.Add(networkGatewayTask(d))
 	}
 
 	// Start all background tasks
@@ -2139,6 +2202,39 @@ func (d *Daemon) setupOpenFGA(apiURL string, apiToken string, storeID string) er
 	return nil
 }
 
+// Logging configuration, applied on top of the command line flags.
+func (d *Daemon) setupLogging(level string, syslog bool, debugSubsystems []string) error {
+	debug := daemon.Debug
+	verbose := daemon.Verbose
+
+	switch level {
+	case "warning":
+		debug, verbose = false, false
+	case "info":
+		debug, verbose = false, true
+	case "debug":
+		debug, verbose = true, false
+	}
+
+	syslogName := ""
+	if syslog || d.config.Syslog {
+		syslogName = "incus"
+	}
+
+	err := logger.Reconfigure(syslogName, verbose, debug)
+	if err != nil {
+		return fmt.Errorf("Failed to reconfigure logging: %w", err)
+	}
+
+	d.events.SetLogLevel(debug, verbose)
+	d.devIncusEvents.SetLogLevel(debug, verbose)
+
+	response.Init(daemon.Debug || slices.Contains(debugSubsystems, "api"))
+	rsync.Debug.Store(daemon.Debug || slices.Contains(debugSubsystems, "rsync"))
+
+	return nil
+}
+
 // Syslog listener.
 func (d *Daemon) setupSyslogSocket(enable bool) error {
 	// Always cancel the context to ensure that no goroutines leak.
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// databaseBackupPrefix and databaseBackupSuffix surround the timestamp in the name of the database backups.
+const (
+	databaseBackupPrefix = "global_"
+	databaseBackupSuffix = ".sql.gz"
+)
+
+// databaseBackupsPath returns the directory holding the automatic database backups.
+func databaseBackupsPath() string {
+	return internalUtil.VarPath("backups", "database")
+}
+
+// databaseBackupsList returns the names of the existing database backups, oldest first.
+func databaseBackupsList() ([]string, error) {
+	entries, err := os.ReadDir(databaseBackupsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasPrefix(entry.Name(), databaseBackupPrefix) || !strings.HasSuffix(entry.Name(), databaseBackupSuffix) {
+			continue
+		}
+
+		names = append(names, entry.Name())
+	}
+
+	// The UTC timestamp in the name sorts chronologically.
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// databaseBackupCreate dumps the global database into a new compressed backup and returns its path.
+func databaseBackupCreate(ctx context.Context, s *state.State) (string, error) {
+	tx, err := s.DB.Cluster.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("Failed to start transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	dump, err := query.Dump(ctx, tx, false)
+	if err != nil {
+		return "", fmt.Errorf("Failed to dump global database: %w", err)
+	}
+
+	err = os.MkdirAll(databaseBackupsPath(), 0700)
+	if err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so that an interrupted backup never looks complete.
+	f, err := os.CreateTemp(databaseBackupsPath(), ".tmp_")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = os.Remove(f.Name()) }()
+	defer func() { _ = f.Close() }()
+
+	gz := gzip.NewWriter(f)
+
+	_, err = gz.Write([]byte(dump))
+	if err != nil {
+		return "", err
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(databaseBackupsPath(), databaseBackupPrefix+time.Now().UTC().Format("20060102T150405Z")+databaseBackupSuffix)
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// databaseBackupsUpdate creates a new database backup if the latest one is older than the configured interval
+// and removes the backups exceeding the configured retention.
+func databaseBackupsUpdate(ctx context.Context, s *state.State) error {
+	interval := time.Duration(s.GlobalConfig.BackupsDatabaseIntervalHours()) * time.Hour
+	if interval <= 0 {
+		return nil
+	}
+
+	names, err := databaseBackupsList()
+	if err != nil {
+		return fmt.Errorf("Failed listing database backups: %w", err)
+	}
+
+	if len(names) > 0 {
+		fi, err := os.Stat(filepath.Join(databaseBackupsPath(), names[len(names)-1]))
+		if err != nil {
+			return err
+		}
+
+		if time.Since(fi.ModTime()) < interval {
+			return nil
+		}
+	}
+
+	path, err := databaseBackupCreate(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Backed up the global database", logger.Ctx{"path": path})
+
+	names, err = databaseBackupsList()
+	if err != nil {
+		return fmt.Errorf("Failed listing database backups: %w", err)
+	}
+
+	retention := int(s.GlobalConfig.BackupsDatabaseRetention())
+	for len(names) > retention {
+		err = os.Remove(filepath.Join(databaseBackupsPath(), names[0]))
+		if err != nil {
+			return fmt.Errorf("Failed removing database backup %q: %w", names[0], err)
+		}
+
+		names = names[1:]
+	}
+
+	return nil
+}
+
+func databaseBackupsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := databaseBackupsUpdate(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed backing up the global database", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Hourly()
+}
@@ -503,6 +503,12 @@ func deviceTaskBalance(s *state.State) {
 			}
 		}
 
+		// Use the CPUs picked by the automatic allocation.
+		if conf["limits.cpu.allocation"] == "numa-auto" && conf["volatile.cpu.allocation"] != "" {
+			cpulimit = conf["volatile.cpu.allocation"]
+			numaCpus = nil
+		}
+
 		// Check that the container is running.
 		// We use InitPID here rather than IsRunning because this task is triggered during the container's
 		// onStart hook, which is during the time that the start lock is held, which causes IsRunning to
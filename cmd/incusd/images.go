@@ -1796,6 +1796,7 @@ func autoUpdateImages(ctx context.Context, s *state.State) error {
 
 		var deleteIDs []int
 		var newImage *api.Image
+		var refreshedProjects []string
 
 		for _, image := range images {
 			filter := dbCluster.ImageFilter{Project: &image.Project}
@@ -1824,6 +1825,10 @@ func autoUpdateImages(ctx context.Context, s *state.State) error {
 				}
 			} else {
 				deleteIDs = append(deleteIDs, image.ID)
+
+				if newInfo != nil {
+					refreshedProjects = append(refreshedProjects, image.Project)
+				}
 			}
 
 			// newInfo will have the same content for each image in the list.
@@ -1857,6 +1862,12 @@ func autoUpdateImages(ctx context.Context, s *state.State) error {
 				return nil
 			})
 
+			for _, projectName := range refreshedProjects {
+				err := instanceImageOutdatedNotify(ctx, s, projectName, fingerprint, newImage)
+				if err != nil {
+					logger.Error("Failed notifying outdated instances", logger.Ctx{"err": err, "project": projectName, "fingerprint": fingerprint})
+				}
+			}
 		}
 	}
 
@@ -2655,99 +2666,94 @@ func imageDelete(d *Daemon, r *http.Request) response.Response {
 	}
 
 	do := func(op *operations.Operation) error {
-		// Lock this operation to ensure that concurrent image operations don't conflict.
-		// Other operations will wait for this one to finish.
-		unlock, err := imageOperationLock(context.TODO(), imgInfo.Fingerprint)
-		if err != nil {
-			return err
-		}
+		return doImageDelete(s, op, projectName, imgID, imgInfo, isClusterNotification(r))
+	}
 
-		defer unlock()
+	resources := map[string][]api.URL{}
+	resources["images"] = []api.URL{*api.NewURL().Path(version.APIVersion, "images", imgInfo.Fingerprint)}
 
-		var exist bool
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.ImageDelete, resources, nil, do, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
 
-		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-			// Check image still exists and another request hasn't removed it since we resolved the image
-			// fingerprint above.
-			exist, err = tx.ImageExists(ctx, projectName, imgInfo.Fingerprint)
+	return operations.OperationResponse(op)
+}
 
-			return err
-		})
-		if err != nil {
-			return err
-		}
+// doImageDelete removes the image from the project. Unless it's still referenced by other projects, the image is
+// also removed from the storage pools and from the disk of all the cluster members.
+func doImageDelete(s *state.State, op *operations.Operation, projectName string, imgID int, imgInfo *api.Image, clusterNotification bool) error {
+	// Lock this operation to ensure that concurrent image operations don't conflict.
+	// Other operations will wait for this one to finish.
+	unlock, err := imageOperationLock(context.TODO(), imgInfo.Fingerprint)
+	if err != nil {
+		return err
+	}
 
-		if !exist {
-			return api.StatusErrorf(http.StatusNotFound, "Image not found")
-		}
+	defer unlock()
 
-		if !isClusterNotification(r) {
-			var referenced bool
+	var exist bool
 
-			err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-				// Check if the image being deleted is actually still
-				// referenced by other projects. In that case we don't want to
-				// physically delete it just yet, but just to remove the
-				// relevant database entry.
-				referenced, err = tx.ImageIsReferencedByOtherProjects(ctx, projectName, imgInfo.Fingerprint)
-				if err != nil {
-					return err
-				}
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check image still exists and another request hasn't removed it since we resolved the image
+		// fingerprint above.
+		exist, err = tx.ImageExists(ctx, projectName, imgInfo.Fingerprint)
 
-				if referenced {
-					err = tx.DeleteImage(ctx, imgID)
-					if err != nil {
-						return fmt.Errorf("Error deleting image info from the database: %w", err)
-					}
-				}
+		return err
+	})
+	if err != nil {
+		return err
+	}
 
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+	if !exist {
+		return api.StatusErrorf(http.StatusNotFound, "Image not found")
+	}
 
-			if referenced {
-				return nil
-			}
+	if !clusterNotification {
+		var referenced bool
 
-			// Notify the other nodes about the removed image so they can remove it from disk too.
-			notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAll)
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			// Check if the image being deleted is actually still
+			// referenced by other projects. In that case we don't want to
+			// physically delete it just yet, but just to remove the
+			// relevant database entry.
+			referenced, err = tx.ImageIsReferencedByOtherProjects(ctx, projectName, imgInfo.Fingerprint)
 			if err != nil {
 				return err
 			}
 
-			err = notifier(func(client incus.InstanceServer) error {
-				op, err := client.UseProject(projectName).DeleteImage(imgInfo.Fingerprint)
+			if referenced {
+				err = tx.DeleteImage(ctx, imgID)
 				if err != nil {
-					return fmt.Errorf("Failed to request to delete image from peer node: %w", err)
+					return fmt.Errorf("Error deleting image info from the database: %w", err)
 				}
+			}
 
-				err = op.Wait()
-				if err != nil {
-					return fmt.Errorf("Failed to delete image from peer node: %w", err)
-				}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+		if referenced {
+			return nil
 		}
 
-		var poolIDs []int64
-		var poolNames []string
+		// Notify the other nodes about the removed image so they can remove it from disk too.
+		notifier, err := cluster.NewNotifier(s, s.Endpoints.NetworkCert(), s.ServerCert(), cluster.NotifyAll)
+		if err != nil {
+			return err
+		}
 
-		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-			// Delete the pool volumes.
-			poolIDs, err = tx.GetPoolsWithImage(ctx, imgInfo.Fingerprint)
+		err = notifier(func(client incus.InstanceServer) error {
+			op, err := client.UseProject(projectName).DeleteImage(imgInfo.Fingerprint)
 			if err != nil {
-				return err
+				return fmt.Errorf("Failed to request to delete image from peer node: %w", err)
 			}
 
-			poolNames, err = tx.GetPoolNamesFromIDs(ctx, poolIDs)
+			err = op.Wait()
 			if err != nil {
-				return err
+				return fmt.Errorf("Failed to delete image from peer node: %w", err)
 			}
 
 			return nil
@@ -2755,55 +2761,66 @@ func imageDelete(d *Daemon, r *http.Request) response.Response {
 		if err != nil {
 			return err
 		}
+	}
 
-		for _, poolName := range poolNames {
-			pool, err := storagePools.LoadByName(s, poolName)
-			if err != nil {
-				return fmt.Errorf("Error loading storage pool %q to delete image %q: %w", poolName, imgInfo.Fingerprint, err)
-			}
+	var poolIDs []int64
+	var poolNames []string
 
-			// Only perform the deletion of remote volumes on the server handling the request.
-			if !isClusterNotification(r) || !pool.Driver().Info().Remote {
-				err = pool.DeleteImage(imgInfo.Fingerprint, op)
-				if err != nil {
-					return fmt.Errorf("Error deleting image %q from storage pool %q: %w", imgInfo.Fingerprint, pool.Name(), err)
-				}
-			}
+	err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Delete the pool volumes.
+		poolIDs, err = tx.GetPoolsWithImage(ctx, imgInfo.Fingerprint)
+		if err != nil {
+			return err
 		}
 
-		// Remove the database entry.
-		if !isClusterNotification(r) {
-			err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-				return tx.DeleteImage(ctx, imgID)
-			})
-			if err != nil {
-				return fmt.Errorf("Error deleting image info from the database: %w", err)
-			}
+		poolNames, err = tx.GetPoolNamesFromIDs(ctx, poolIDs)
+		if err != nil {
+			return err
 		}
 
-		// Remove main image file from disk.
-		imageDeleteFromDisk(imgInfo.Fingerprint)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-		// Remove image from authorizer.
-		err = s.Authorizer.DeleteImage(s.ShutdownCtx, projectName, imgInfo.Fingerprint)
+	for _, poolName := range poolNames {
+		pool, err := storagePools.LoadByName(s, poolName)
 		if err != nil {
-			logger.Error("Failed to remove image from authorizer", logger.Ctx{"fingerprint": imgInfo.Fingerprint, "project": projectName, "error": err})
+			return fmt.Errorf("Error loading storage pool %q to delete image %q: %w", poolName, imgInfo.Fingerprint, err)
 		}
 
-		s.Events.SendLifecycle(projectName, lifecycle.ImageDeleted.Event(imgInfo.Fingerprint, projectName, op.Requestor(), nil))
+		// Only perform the deletion of remote volumes on the server handling the request.
+		if !clusterNotification || !pool.Driver().Info().Remote {
+			err = pool.DeleteImage(imgInfo.Fingerprint, op)
+			if err != nil {
+				return fmt.Errorf("Error deleting image %q from storage pool %q: %w", imgInfo.Fingerprint, pool.Name(), err)
+			}
+		}
+	}
 
-		return nil
+	// Remove the database entry.
+	if !clusterNotification {
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.DeleteImage(ctx, imgID)
+		})
+		if err != nil {
+			return fmt.Errorf("Error deleting image info from the database: %w", err)
+		}
 	}
 
-	resources := map[string][]api.URL{}
-	resources["images"] = []api.URL{*api.NewURL().Path(version.APIVersion, "images", imgInfo.Fingerprint)}
+	// Remove main image file from disk.
+	imageDeleteFromDisk(imgInfo.Fingerprint)
 
-	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.ImageDelete, resources, nil, do, nil, nil, r)
+	// Remove image from authorizer.
+	err = s.Authorizer.DeleteImage(s.ShutdownCtx, projectName, imgInfo.Fingerprint)
 	if err != nil {
-		return response.InternalError(err)
+		logger.Error("Failed to remove image from authorizer", logger.Ctx{"fingerprint": imgInfo.Fingerprint, "project": projectName, "error": err})
 	}
 
-	return operations.OperationResponse(op)
+	s.Events.SendLifecycle(projectName, lifecycle.ImageDeleted.Event(imgInfo.Fingerprint, projectName, op.Requestor(), nil))
+
+	return nil
 }
 
 // Helper to delete an image file from the local images directory.
@@ -4458,6 +4475,11 @@ func imageRefresh(d *Daemon, r *http.Request) response.Response {
 			if err != nil {
 				logger.Error("Error deleting old image from database", logger.Ctx{"err": err, "fingerprint": fingerprint, "ID": imageID})
 			}
+
+			notifyErr := instanceImageOutdatedNotify(context.TODO(), s, projectName, fingerprint, newImage)
+			if notifyErr != nil {
+				logger.Error("Failed notifying outdated instances", logger.Ctx{"err": notifyErr, "project": projectName, "fingerprint": fingerprint})
+			}
 		}
 
 		return err
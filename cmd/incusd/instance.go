@@ -67,6 +67,41 @@ func instanceCreateAsEmpty(s *state.State, args db.InstanceArgs) (instance.Insta
 	return inst, nil
 }
 
+// instanceCreateFromDisk creates a virtual machine using the disk image file as its root disk.
+func instanceCreateFromDisk(s *state.State, args db.InstanceArgs, diskPath string, op *operations.Operation) (instance.Instance, error) {
+	revert := revert.New()
+	defer revert.Fail()
+
+	// Create the instance record.
+	inst, instOp, cleanup, err := instance.CreateInternal(s, args, true, true)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating instance record: %w", err)
+	}
+
+	revert.Add(cleanup)
+	defer instOp.Done(err)
+
+	pool, err := storagePools.LoadByInstance(s, inst)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading instance storage pool: %w", err)
+	}
+
+	err = pool.CreateInstanceFromDisk(inst, diskPath, op)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating instance from disk image: %w", err)
+	}
+
+	revert.Add(func() { _ = inst.Delete(true) })
+
+	err = inst.UpdateBackupFile()
+	if err != nil {
+		return nil, err
+	}
+
+	revert.Success()
+	return inst, nil
+}
+
 // instanceImageTransfer transfers an image from another cluster node.
 func instanceImageTransfer(s *state.State, r *http.Request, projectName string, hash string, nodeAddress string) error {
 	logger.Debugf("Transferring image %q from node %q", hash, nodeAddress)
@@ -216,6 +251,8 @@ func instanceRebuildFromImage(ctx context.Context, s *state.State, r *http.Reque
 		return fmt.Errorf("Failed rebuilding instance from image: %w", err)
 	}
 
+	instanceImageOutdatedResolve(s, inst)
+
 	return nil
 }
 
@@ -225,6 +262,8 @@ func instanceRebuildFromEmpty(s *state.State, inst instance.Instance, op *operat
 		return fmt.Errorf("Failed rebuilding as an empty instance: %w", err)
 	}
 
+	instanceImageOutdatedResolve(s, inst)
+
 	return nil
 }
 
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+)
+
+// swagger:operation GET /1.0/instances/{name}/attestation instances instance_attestation_get
+//
+//	Get the confidential computing state
+//
+//	Gets the confidential computing technology used by a running virtual machine along with its
+//	launch measurement (when available from the host).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Attestation
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceAttestation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceAttestationGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "Attestation")
+	if resp != nil {
+		return resp
+	}
+
+	attestation, err := inst.Attestation()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, attestation)
+}
+
+// swagger:operation GET /1.0/instances/{name}/attestation/report instances instance_attestation_report_get
+//
+//	Get an attestation report
+//
+//	Requests a new hardware attestation report from the guest (AMD SEV-SNP and Intel TDX only).
+//	The report is generated by the guest through the agent and returned as-is for verification.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: nonce
+//	    description: Hex encoded data to bind into the report (up to 64 bytes)
+//	    type: string
+//	    example: 0123456789abcdef
+//	responses:
+//	  "200":
+//	    description: Raw attestation report
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceAttestationReportGet(d *Daemon, r *http.Request) response.Response {
+	nonce, err := hex.DecodeString(request.QueryParam(r, "nonce"))
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid nonce: %w", err))
+	}
+
+	if len(nonce) > 64 {
+		return response.BadRequest(fmt.Errorf("Nonce can't be longer than 64 bytes"))
+	}
+
+	inst, resp := instanceVMLoad(d, r, "Attestation")
+	if resp != nil {
+		return resp
+	}
+
+	report, err := inst.AttestationReport(nonce)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Filename:     "report.bin",
+		File:         bytes.NewReader(report),
+		FileModified: time.Now(),
+		FileSize:     int64(len(report)),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
+// instanceVMLoad loads the virtual machine targeted by a VM-only request.
+// A response is returned instead if the request was forwarded or failed.
+func instanceVMLoad(d *Daemon, r *http.Request, feature string) (instance.VM, response.Response) {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return nil, response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Forward the request if the instance is remote.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if resp != nil {
+		return nil, resp
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return nil, response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.VM {
+		return nil, response.BadRequest(fmt.Errorf("%s is only supported on virtual machines", feature))
+	}
+
+	vm, ok := inst.(instance.VM)
+	if !ok {
+		return nil, response.InternalError(fmt.Errorf("Failed to cast instance to VM"))
+	}
+
+	return vm, nil
+}
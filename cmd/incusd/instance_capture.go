@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/gorilla/mux"
+	"golang.org/x/sys/unix"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// Default and maximum bounds of instance NIC captures.
+const (
+	instanceCaptureDefaultDuration = 60
+	instanceCaptureMaxDuration     = 3600
+	instanceCaptureDefaultSize     = 10 * 1024 * 1024
+	instanceCaptureMaxSize         = 1024 * 1024 * 1024
+)
+
+// swagger:operation POST /1.0/instances/{name}/state/capture instances instance_state_capture_post
+//
+//	Capture the traffic of an instance NIC
+//
+//	Captures the traffic going through the host side interface of an instance NIC and streams it back in the pcap format, until the duration elapsed, the maximum size was reached or the client disconnected.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/vnd.tcpdump.pcap
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: capture
+//	    description: Capture request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceCapturePost"
+//	responses:
+//	  "200":
+//	    description: Raw pcap data
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceCapturePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Forward the request if the instance is remote, streaming back the capture.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceCapturePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Duration == 0 {
+		req.Duration = instanceCaptureDefaultDuration
+	}
+
+	if req.Duration < 0 || req.Duration > instanceCaptureMaxDuration {
+		return response.BadRequest(fmt.Errorf("Capture duration must be between 1 and %d seconds", instanceCaptureMaxDuration))
+	}
+
+	if req.MaxSize == 0 {
+		req.MaxSize = instanceCaptureDefaultSize
+	}
+
+	if req.MaxSize < 0 || req.MaxSize > instanceCaptureMaxSize {
+		return response.BadRequest(fmt.Errorf("Capture maximum size must be between 1 and %d bytes", instanceCaptureMaxSize))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !inst.IsRunning() {
+		return response.BadRequest(fmt.Errorf("Instance is not running"))
+	}
+
+	dev, ok := inst.ExpandedDevices()[req.Device]
+	if !ok || dev["type"] != "nic" {
+		return response.NotFound(fmt.Errorf("NIC device %q not found", req.Device))
+	}
+
+	hostName := inst.ExpandedConfig()[fmt.Sprintf("volatile.%s.host_name", req.Device)]
+	if hostName == "" {
+		return response.BadRequest(fmt.Errorf("NIC device %q has no host side interface to capture on", req.Device))
+	}
+
+	iface, err := net.InterfaceByName(hostName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed finding interface %q: %w", hostName, err))
+	}
+
+	fd, err := instanceCaptureOpen(iface)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		defer func() { _ = unix.Close(fd) }()
+
+		w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment;filename=%s-%s.pcap", name, req.Device))
+		w.WriteHeader(http.StatusOK)
+
+		l := logger.AddContext(logger.Ctx{"project": projectName, "instance": name, "device": req.Device})
+		l.Info("Capturing instance NIC traffic", logger.Ctx{"interface": hostName, "duration": req.Duration, "maxSize": req.MaxSize})
+
+		err := instanceCaptureRun(r, w, fd, iface, time.Duration(req.Duration)*time.Second, req.MaxSize)
+		if err != nil {
+			l.Warn("Failed capturing instance NIC traffic", logger.Ctx{"err": err})
+			return err
+		}
+
+		l.Info("Finished capturing instance NIC traffic")
+
+		return nil
+	})
+}
+
+// instanceCaptureOpen opens a packet socket receiving all the traffic of the interface.
+func instanceCaptureOpen(iface *net.Interface) (int, error) {
+	// The protocol is expected in network byte order.
+	protocol := make([]byte, 2)
+	binary.BigEndian.PutUint16(protocol, unix.ETH_P_ALL)
+	ethAll := binary.NativeEndian.Uint16(protocol)
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(ethAll))
+	if err != nil {
+		return -1, fmt.Errorf("Failed opening packet socket: %w", err)
+	}
+
+	err = unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: ethAll, Ifindex: iface.Index})
+	if err != nil {
+		_ = unix.Close(fd)
+		return -1, fmt.Errorf("Failed binding packet socket to %q: %w", iface.Name, err)
+	}
+
+	// Wake up regularly to check whether the capture should stop.
+	err = unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 1})
+	if err != nil {
+		_ = unix.Close(fd)
+		return -1, fmt.Errorf("Failed setting packet socket timeout: %w", err)
+	}
+
+	return fd, nil
+}
+
+// instanceCaptureRun writes the packets received on the socket to the client in the pcap format until the
+// duration elapsed, the size limit was reached or the client went away.
+func instanceCaptureRun(r *http.Request, w io.Writer, fd int, iface *net.Interface, duration time.Duration, maxSize int64) error {
+	snapLen := iface.MTU + 18 // Ethernet header and VLAN tag.
+	if snapLen < 1518 {
+		snapLen = 1518
+	}
+
+	writer := pcapgo.NewWriter(w)
+	err := writer.WriteFileHeader(uint32(snapLen), layers.LinkTypeEthernet)
+	if err != nil {
+		return err
+	}
+
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, snapLen)
+	deadline := time.Now().Add(duration)
+
+	// File header.
+	var written int64 = 24
+
+	for time.Now().Before(deadline) && r.Context().Err() == nil {
+		n, _, err := unix.Recvfrom(fd, buf, unix.MSG_TRUNC)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+
+			return fmt.Errorf("Failed reading packet: %w", err)
+		}
+
+		captureLen := min(n, len(buf))
+
+		// Packet header and data.
+		written += 16 + int64(captureLen)
+		if written > maxSize {
+			break
+		}
+
+		err = writer.WritePacket(gopacket.CaptureInfo{Timestamp: time.Now(), CaptureLength: captureLen, Length: n}, buf[:captureLen])
+		if err != nil {
+			return err
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	return nil
+}
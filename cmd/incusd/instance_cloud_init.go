@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// swagger:operation GET /1.0/instances/{name}/rendered-cloud-init instances instance_rendered_cloud_init_get
+//
+//	Get the rendered cloud-init configuration
+//
+//	Gets the cloud-init configuration of the instance as passed to the guest,
+//	with any template (see `cloud-init.templating`) rendered.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Rendered cloud-init configuration
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceRenderedCloudInit"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceRenderedCloudInitGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	config, err := instance.CloudInitConfig(inst)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Fallback to the legacy keys the same way the guest does.
+	value := func(key string, legacyKey string) string {
+		v, ok := config[key]
+		if !ok {
+			return config[legacyKey]
+		}
+
+		return v
+	}
+
+	resp := api.InstanceRenderedCloudInit{
+		UserData:      value("cloud-init.user-data", "user.user-data"),
+		VendorData:    value("cloud-init.vendor-data", "user.vendor-data"),
+		NetworkConfig: value("cloud-init.network-config", "user.network-config"),
+	}
+
+	return response.SyncResponse(true, resp)
+}
@@ -66,6 +66,9 @@ type consoleWs struct {
 
 	// channel type (either console or vga)
 	protocol string
+
+	// session counted against the session limits
+	session *instanceSession
 }
 
 func (s *consoleWs) Metadata() any {
@@ -109,6 +112,8 @@ func (s *consoleWs) connectConsole(op *operations.Operation, r *http.Request, w
 			s.conns[fd] = conn
 			s.connsLock.Unlock()
 
+			s.session.Touch()
+
 			if fd == -1 {
 				s.controlConnected <- true
 				return nil
@@ -162,6 +167,8 @@ func (s *consoleWs) connectVGA(op *operations.Operation, r *http.Request, w http
 
 		logger.Debug("VGA dynamic websocket connected")
 
+		s.session.Touch()
+
 		console, _, err := s.instance.Console("vga")
 		if err != nil {
 			_ = conn.Close()
@@ -175,7 +182,7 @@ func (s *consoleWs) connectVGA(op *operations.Operation, r *http.Request, w http
 			defer l.Debug("Finished mirroring websocket to console")
 
 			l.Debug("Started mirroring websocket")
-			readDone, writeDone := ws.Mirror(conn, console)
+			readDone, writeDone := ws.Mirror(conn, s.session.ReadWriteCloser(console))
 
 			<-readDone
 			l.Debug("Finished mirroring console to websocket")
@@ -195,6 +202,8 @@ func (s *consoleWs) connectVGA(op *operations.Operation, r *http.Request, w http
 }
 
 func (s *consoleWs) Do(op *operations.Operation) error {
+	defer s.session.End()
+
 	switch s.protocol {
 	case instance.ConsoleTypeConsole:
 		return s.doConsole(op)
@@ -205,9 +214,40 @@ func (s *consoleWs) Do(op *operations.Operation) error {
 	}
 }
 
+// closeIdle notifies the clients that the session is being closed because it was idle for too long.
+func (s *consoleWs) closeIdle() {
+	s.connsLock.Lock()
+	defer s.connsLock.Unlock()
+
+	for _, conn := range s.conns {
+		if conn != nil {
+			instanceSessionCloseIdle(conn)
+		}
+	}
+
+	for conn := range s.dynamic {
+		instanceSessionCloseIdle(conn)
+	}
+}
+
 func (s *consoleWs) doConsole(op *operations.Operation) error {
 	defer logger.Debug("Console websocket finished")
-	<-s.allConnected
+
+	select {
+	case <-s.allConnected:
+	case <-s.session.Idle():
+		s.closeIdle()
+
+		s.connsLock.Lock()
+		for _, conn := range s.conns {
+			if conn != nil {
+				_ = conn.Close()
+			}
+		}
+		s.connsLock.Unlock()
+
+		return fmt.Errorf("Session idle timeout reached while waiting for websockets to connect")
+	}
 
 	// Get console from instance.
 	console, consoleDisconnectCh, err := s.instance.Console(s.protocol)
@@ -244,6 +284,8 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 				return
 			}
 
+			s.session.Touch()
+
 			buf, err := io.ReadAll(r)
 			if err != nil {
 				logger.Debugf("Failed to read message: %v", err)
@@ -293,7 +335,7 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 		defer l.Debug("Finished mirroring websocket to console")
 
 		l.Debug("Started mirroring websocket")
-		readDone, writeDone := ws.Mirror(conn, console)
+		readDone, writeDone := ws.Mirror(conn, s.session.ReadWriteCloser(console))
 
 		<-readDone
 		l.Debug("Finished mirroring console to websocket")
@@ -307,6 +349,10 @@ func (s *consoleWs) doConsole(op *operations.Operation) error {
 		close(consoleDisconnectCh)
 	case <-consoleDoneCh:
 		close(consoleDisconnectCh)
+	case <-s.session.Idle():
+		logger.Warn("Console session idle timeout reached", logger.Ctx{"project": s.instance.Project().Name, "instance": s.instance.Name()})
+		s.closeIdle()
+		close(consoleDisconnectCh)
 	}
 
 	// Get the console and control websockets.
@@ -364,15 +410,27 @@ func (s *consoleWs) doVGA(op *operations.Operation) error {
 				close(consoleDoneCh)
 				return
 			}
+
+			s.session.Touch()
 		}
 	}()
 
-	// Wait until the control channel is done.
-	<-consoleDoneCh
+	// Wait until the control channel is done or the session is idle.
+	select {
+	case <-consoleDoneCh:
+	case <-s.session.Idle():
+		logger.Warn("VGA console session idle timeout reached", logger.Ctx{"project": s.instance.Project().Name, "instance": s.instance.Name()})
+		s.closeIdle()
+	}
+
 	s.connsLock.Lock()
 	control := s.conns[-1]
 	s.connsLock.Unlock()
-	err := control.Close()
+
+	var err error
+	if control != nil {
+		err = control.Close()
+	}
 
 	// Close all dynamic connections.
 	for conn, console := range s.dynamic {
@@ -487,6 +545,10 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("VGA console is only supported by virtual machines"))
 	}
 
+	if post.Type == instance.ConsoleTypeVGA && util.IsFalse(inst.ExpandedConfig()["spice.enabled"]) {
+		return response.BadRequest(fmt.Errorf("VGA console is disabled on this instance"))
+	}
+
 	if !inst.IsRunning() {
 		return response.BadRequest(fmt.Errorf("Instance is not running"))
 	}
@@ -495,7 +557,13 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance is frozen"))
 	}
 
+	session, err := instanceSessionStart(s, r, inst)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	ws := &consoleWs{}
+	ws.session = session
 	ws.fds = map[int]string{}
 	ws.conns = map[int]*websocket.Conn{}
 	ws.conns[-1] = nil
@@ -504,6 +572,7 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 	for i := -1; i < len(ws.conns)-1; i++ {
 		ws.fds[i], err = internalUtil.RandomHexString(32)
 		if err != nil {
+			session.End()
 			return response.InternalError(err)
 		}
 	}
@@ -520,6 +589,7 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 
 	op, err := operations.OperationCreate(s, projectName, operations.OperationClassWebsocket, operationtype.ConsoleShow, resources, ws.Metadata(), ws.Do, nil, ws.Connect, r)
 	if err != nil {
+		session.End()
 		return response.InternalError(err)
 	}
 
@@ -532,6 +602,12 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 //
 //	Gets the console log for the instance.
 //
+//	With `type=log`, the persistent console history of a container is returned,
+//	starting with the oldest kept boot.
+//
+//	With `type=screenshot`, a PNG screenshot of the graphical output of a virtual
+//	machine is returned.
+//
 //	---
 //	produces:
 //	  - application/json
@@ -541,6 +617,11 @@ func instanceConsolePost(d *Daemon, r *http.Request) response.Response {
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: type
+//	    description: Console log type (empty for the current buffer, "log" for the persistent history or "screenshot" for a screenshot)
+//	    type: string
+//	    example: log
 //	responses:
 //	  "200":
 //	     description: Raw console log
@@ -590,11 +671,26 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	logType := request.QueryParam(r, "type")
+
+	// Return a screenshot of the graphical output if requested.
+	if logType == "screenshot" {
+		return instanceConsoleScreenshotGet(r, inst)
+	}
+
 	if inst.Type() != instancetype.Container {
 		return response.SmartError(fmt.Errorf("Console backlog is only supported on containers"))
 	}
 
 	c := inst.(instance.Container)
+
+	// Return the persistent console history if requested.
+	if logType == "log" {
+		return instanceConsoleHistoryGet(r, c)
+	} else if logType != "" {
+		return response.BadRequest(fmt.Errorf("Invalid console log type %q", logType))
+	}
+
 	ent := response.FileResponseEntry{}
 	if !c.IsRunning() {
 		// Check if we have data we can return.
@@ -638,12 +734,78 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
 
+// instanceConsoleHistoryGet returns the console log of the previous boots followed by the current one.
+func instanceConsoleHistoryGet(r *http.Request, c instance.Container) response.Response {
+	consoleLogPath := c.ConsoleBufferLogPath()
+	paths := append(internalUtil.RotatedFiles(consoleLogPath), consoleLogPath)
+
+	var buf bytes.Buffer
+	var modified time.Time
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return response.SmartError(err)
+		}
+
+		fi, err := f.Stat()
+		if err == nil && fi.ModTime().After(modified) {
+			modified = fi.ModTime()
+		}
+
+		_, err = io.Copy(&buf, f)
+		_ = f.Close()
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	if buf.Len() == 0 {
+		return response.FileResponse(r, nil, nil)
+	}
+
+	ent := response.FileResponseEntry{
+		File:         bytes.NewReader(buf.Bytes()),
+		FileModified: modified,
+		FileSize:     int64(buf.Len()),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
+// instanceConsoleScreenshotGet returns a PNG screenshot of the graphical output of a virtual machine.
+func instanceConsoleScreenshotGet(r *http.Request, inst instance.Instance) response.Response {
+	vm, ok := inst.(instance.VM)
+	if !ok {
+		return response.BadRequest(fmt.Errorf("Console screenshots are only supported on virtual machines"))
+	}
+
+	screenshot, err := vm.ConsoleScreenshot()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Filename:     "screenshot.png",
+		File:         bytes.NewReader(screenshot),
+		FileModified: time.Now(),
+		FileSize:     int64(len(screenshot)),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
 // swagger:operation DELETE /1.0/instances/{name}/console instances instance_console_delete
 //
 //	Clear the console log
 //
 //	Clears the console log buffer.
 //
+//	With `type=log`, the persistent console history of a container is cleared instead.
+//
 //	---
 //	produces:
 //	  - application/json
@@ -653,6 +815,11 @@ func instanceConsoleLogGet(d *Daemon, r *http.Request) response.Response {
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: type
+//	    description: Console log type (empty for the current buffer or "log" for the persistent history)
+//	    type: string
+//	    example: log
 //	responses:
 //	  "200":
 //	    $ref: "#/responses/EmptySyncResponse"
@@ -710,6 +877,26 @@ func instanceConsoleLogDelete(d *Daemon, r *http.Request) response.Response {
 		return os.Truncate(path, 0)
 	}
 
+	// Clear the persistent console history if requested.
+	logType := request.QueryParam(r, "type")
+	if logType == "log" {
+		consoleLogPath := c.ConsoleBufferLogPath()
+		for _, path := range internalUtil.RotatedFiles(consoleLogPath) {
+			err := os.Remove(path)
+			if err != nil && !os.IsNotExist(err) {
+				return response.SmartError(err)
+			}
+		}
+
+		if !util.PathExists(consoleLogPath) {
+			return response.EmptySyncResponse
+		}
+
+		return response.SmartError(truncateConsoleLogFile(consoleLogPath))
+	} else if logType != "" {
+		return response.BadRequest(fmt.Errorf("Invalid console log type %q", logType))
+	}
+
 	if !inst.IsRunning() {
 		consoleLogpath := c.ConsoleBufferLogPath()
 		return response.SmartError(truncateConsoleLogFile(consoleLogpath))
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var instanceCrashesCmd = APIEndpoint{
+	Name: "instanceCrashes",
+	Path: "instances/{name}/crashes",
+
+	Get: APIEndpointAction{Handler: instanceCrashesGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
+// swagger:operation GET /1.0/instances/{name}/crashes instances instance_crashes_get
+//
+//	Get the crash history
+//
+//	Returns the out of memory kills and guest panics of the instance, oldest first.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Crash history
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of crashes
+//	          items:
+//	            $ref: "#/definitions/InstanceCrash"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceCrashesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Handle requests targeted to an instance on a different member.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if resp != nil {
+		return resp
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	crashes, err := inst.Crashes()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, crashes)
+}
+
+// instancesOOMKillsUpdate records the processes of the local containers killed by the out of memory killer since
+// the previous check, given the counters of that check (keyed by instance ID) which it updates.
+func instancesOOMKillsUpdate(s *state.State, oomKills map[int]int64, initial bool) error {
+	insts, err := instance.LoadNodeAll(s, instancetype.Container)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	seen := map[int]bool{}
+	for _, inst := range insts {
+		c, ok := inst.(instance.Container)
+		if !ok || !inst.IsRunning() {
+			continue
+		}
+
+		count, err := c.OOMKills()
+		if err != nil {
+			continue
+		}
+
+		seen[inst.ID()] = true
+
+		previous, found := oomKills[inst.ID()]
+		oomKills[inst.ID()] = count
+
+		// Only take a baseline of the containers which were already running when the daemon started.
+		if !found && initial {
+			continue
+		}
+
+		// The counter restarts from zero when the container restarts.
+		if count < previous {
+			previous = 0
+		}
+
+		if count == previous {
+			continue
+		}
+
+		err = inst.RecordCrash(api.InstanceCrash{
+			Type:      api.InstanceCrashOOMKill,
+			Timestamp: time.Now().UTC(),
+			Count:     count - previous,
+		})
+		if err != nil {
+			logger.Warn("Failed recording out of memory kills", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+		}
+	}
+
+	// Forget about the stopped and deleted containers.
+	for id := range oomKills {
+		if !seen[id] {
+			delete(oomKills, id)
+		}
+	}
+
+	return nil
+}
+
+// instancesOOMKillsTask reports the processes of the local containers killed by the out of memory killer.
+func instancesOOMKillsTask(d *Daemon) (task.Func, task.Schedule) {
+	oomKills := map[int]int64{}
+	initial := true
+
+	f := func(ctx context.Context) {
+		err := instancesOOMKillsUpdate(d.State(), oomKills, initial)
+		if err != nil {
+			logger.Error("Failed checking for out of memory kills", logger.Ctx{"err": err})
+			return
+		}
+
+		initial = false
+	}
+
+	return f, task.Every(10 * time.Second)
+}
@@ -20,14 +20,17 @@ import (
 	"github.com/gorilla/websocket"
 	"golang.org/x/sys/unix"
 
+	"github.com/lxc/incus/v6/internal/asciicast"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/jmap"
 	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
@@ -57,6 +60,23 @@ type execWs struct {
 	waitControlConnected  *cancel.Canceller
 	fds                   map[int]string
 	s                     *state.State
+	session               *instanceSession
+}
+
+// execRecorder mirrors the data read from an interactive exec session into a recording.
+type execRecorder struct {
+	io.ReadWriteCloser
+
+	w io.Writer
+}
+
+func (r *execRecorder) Read(p []byte) (int, error) {
+	n, err := r.ReadWriteCloser.Read(p)
+	if n > 0 {
+		_, _ = r.w.Write(p[:n])
+	}
+
+	return n, err
 }
 
 func (s *execWs) Metadata() any {
@@ -96,6 +116,7 @@ func (s *execWs) Connect(op *operations.Operation, r *http.Request, w http.Respo
 			val, found := s.conns[fd]
 			if found && val == nil {
 				s.conns[fd] = conn
+				s.session.Touch()
 
 				// Set TCP timeout options.
 				remoteTCP, _ := tcp.ExtractConn(conn.UnderlyingConn())
@@ -158,6 +179,8 @@ func (s *execWs) Connect(op *operations.Operation, r *http.Request, w http.Respo
 }
 
 func (s *execWs) Do(op *operations.Operation) error {
+	defer s.session.End()
+
 	// Once this function ends ensure that any connected websockets are closed.
 	defer func() {
 		s.connsLock.Lock()
@@ -256,6 +279,19 @@ func (s *execWs) Do(op *operations.Operation) error {
 		stderr = ttys[execWSStderr]
 	}
 
+	// Setup the session recording.
+	var recording *asciicast.Writer
+	var recordingOutput io.Writer
+	if s.req.RecordOutput {
+		var cleanup func()
+		recording, recordingOutput, cleanup, err = s.startRecording(op)
+		if err != nil {
+			return err
+		}
+
+		defer cleanup()
+	}
+
 	waitAttachedChildIsDead, markAttachedChildIsDead := context.WithCancel(context.Background())
 	var wgEOF sync.WaitGroup
 
@@ -321,6 +357,30 @@ func (s *execWs) Do(op *operations.Operation) error {
 		}
 	}
 
+	// Kill the command once an interactive session has been idle for too long.
+	// Non-interactive commands may legitimately run without any input or output.
+	if s.req.Interactive {
+		go func() {
+			select {
+			case <-s.session.Idle():
+			case <-waitAttachedChildIsDead.Done():
+				return
+			}
+
+			l.Warn("Exec session idle timeout reached, killing command")
+
+			s.connsLock.Lock()
+			for _, conn := range s.conns {
+				if conn != nil {
+					instanceSessionCloseIdle(conn)
+				}
+			}
+			s.connsLock.Unlock()
+
+			cmdKillOnce.Do(cmdKill)
+		}()
+	}
+
 	// Now that process has started, we can start the control handler.
 	wgEOF.Add(1)
 	go func() {
@@ -358,6 +418,8 @@ func (s *execWs) Do(op *operations.Operation) error {
 				return
 			}
 
+			s.session.Touch()
+
 			buf, err := io.ReadAll(r)
 			if err != nil {
 				// Check if command process has finished normally, if so, no need to kill it.
@@ -399,6 +461,13 @@ func (s *execWs) Do(op *operations.Operation) error {
 					l.Debug("Failed to set window size", logger.Ctx{"err": err, "width": winchWidth, "height": winchHeight})
 					continue
 				}
+
+				if recording != nil {
+					err = recording.WriteResize(winchWidth, winchHeight)
+					if err != nil {
+						l.Warn("Failed recording window size", logger.Ctx{"err": err})
+					}
+				}
 			} else if command.Command == "signal" {
 				err := cmd.Signal(unix.Signal(command.Signal))
 				if err != nil {
@@ -429,10 +498,20 @@ func (s *execWs) Do(op *operations.Operation) error {
 			if s.instance.Type() == instancetype.Container {
 				// For containers, we are running the command via the locally managed PTY and so
 				// need to use the same PTY handle for both read and write.
-				readDone, writeDone = ws.Mirror(conn, linux.NewExecWrapper(waitAttachedChildIsDead, ptys[0]))
+				var rwc io.ReadWriteCloser = linux.NewExecWrapper(waitAttachedChildIsDead, ptys[0])
+				if recordingOutput != nil {
+					rwc = &execRecorder{ReadWriteCloser: rwc, w: recordingOutput}
+				}
+
+				readDone, writeDone = ws.Mirror(conn, s.session.ReadWriteCloser(rwc))
 			} else {
-				readDone = ws.MirrorRead(conn, ptys[execWSStdout])
-				writeDone = ws.MirrorWrite(conn, ttys[execWSStdin])
+				var r io.Reader = ptys[execWSStdout]
+				if recordingOutput != nil {
+					r = io.TeeReader(r, recordingOutput)
+				}
+
+				readDone = ws.MirrorRead(conn, s.session.Reader(r))
+				writeDone = ws.MirrorWrite(conn, s.session.Writer(ttys[execWSStdin]))
 			}
 
 			readErr = <-readDone
@@ -477,10 +556,10 @@ func (s *execWs) Do(op *operations.Operation) error {
 				}
 
 				if i == execWSStdin {
-					err = <-ws.MirrorWrite(conn, ttys[i])
+					err = <-ws.MirrorWrite(conn, s.session.Writer(ttys[i]))
 					_ = ttys[i].Close()
 				} else {
-					err = <-ws.MirrorRead(conn, linux.NewExecWrapper(waitAttachedChildIsDead, ptys[i]))
+					err = <-ws.MirrorRead(conn, s.session.Reader(linux.NewExecWrapper(waitAttachedChildIsDead, ptys[i])))
 					_ = ptys[i].Close()
 					wgEOF.Done()
 				}
@@ -494,6 +573,64 @@ func (s *execWs) Do(op *operations.Operation) error {
 	return finisher(exitStatus, err)
 }
 
+// startRecording sets up the recording of an interactive session.
+// The raw output is written to a stdout file and an asciicast file records its timing.
+func (s *execWs) startRecording(op *operations.Operation) (*asciicast.Writer, io.Writer, func(), error) {
+	execOutputDir := s.instance.ExecOutputPath()
+	err := os.Mkdir(execOutputDir, 0600)
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return nil, nil, nil, err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	stdout, err := os.OpenFile(filepath.Join(execOutputDir, fmt.Sprintf("exec_%s.stdout", op.ID())), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	revert.Add(func() { _ = stdout.Close() })
+
+	cast, err := os.OpenFile(filepath.Join(execOutputDir, fmt.Sprintf("exec_%s.cast", op.ID())), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	revert.Add(func() { _ = cast.Close() })
+
+	header := asciicast.Header{
+		Width:   s.req.Width,
+		Height:  s.req.Height,
+		Command: strings.Join(s.req.Command, " "),
+		Title:   fmt.Sprintf("%s/%s", s.instance.Project().Name, s.instance.Name()),
+		Env: map[string]string{
+			"TERM": s.req.Environment["TERM"],
+			"USER": s.req.Environment["USER"],
+		},
+	}
+
+	recording, err := asciicast.NewWriter(cast, header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	revert.Add(func() { _ = recording.Close() })
+
+	err = op.ExtendMetadata(jmap.Map{"output": jmap.Map{
+		"1":    fmt.Sprintf("/%s/instances/%s/logs/exec-output/%s", version.APIVersion, s.instance.Name(), filepath.Base(stdout.Name())),
+		"cast": fmt.Sprintf("/%s/instances/%s/logs/exec-output/%s", version.APIVersion, s.instance.Name(), filepath.Base(cast.Name())),
+	}})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cleanup := revert.Clone().Fail
+	revert.Success()
+
+	return recording, io.MultiWriter(stdout, recording), cleanup, nil
+}
+
 // swagger:operation POST /1.0/instances/{name}/exec instances instance_exec_post
 //
 //	Run a command
@@ -562,12 +699,26 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Constraint validations.
-	if post.RecordOutput && post.WaitForWS {
-		return response.BadRequest(fmt.Errorf("Cannot use %q in combination with %q", "record-output", "wait-for-websocket"))
+	if post.RecordOutput && post.WaitForWS && !post.Interactive {
+		return response.BadRequest(fmt.Errorf("Cannot use %q in combination with %q outside of interactive mode", "record-output", "wait-for-websocket"))
 	}
 
-	if post.Interactive && post.RecordOutput {
-		return response.BadRequest(fmt.Errorf("Cannot use %q in combination with %q", "interactive", "record-output"))
+	if post.Interactive && post.RecordOutput && !post.WaitForWS {
+		return response.BadRequest(fmt.Errorf("Cannot use %q in combination with %q without %q", "interactive", "record-output", "wait-for-websocket"))
+	}
+
+	if post.LimitsCPU != "" {
+		err = internalInstance.InstanceConfigKeysContainer["limits.cpu.allowance"](post.LimitsCPU)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q: %w", "limits-cpu", err))
+		}
+	}
+
+	if post.LimitsMemory != "" {
+		err = internalInstance.InstanceConfigKeysAny["limits.memory"](post.LimitsMemory)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Invalid %q: %w", "limits-memory", err))
+		}
 	}
 
 	// Forward the request if the container is remote.
@@ -604,70 +755,32 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance is frozen"))
 	}
 
-	// Process environment.
-	if post.Environment == nil {
-		post.Environment = map[string]string{}
+	if (post.LimitsCPU != "" || post.LimitsMemory != "") && inst.Type() != instancetype.Container {
+		return response.BadRequest(fmt.Errorf("Exec limits are only supported for containers"))
 	}
 
-	// Override any environment variable settings from the instance if not manually specified in post.
-	for k, v := range inst.ExpandedConfig() {
-		if strings.HasPrefix(k, "environment.") {
-			envKey := strings.TrimPrefix(k, "environment.")
-			_, found := post.Environment[envKey]
-			if !found {
-				post.Environment[envKey] = v
-			}
-		}
-	}
-
-	// Set default value for PATH.
-	_, ok := post.Environment["PATH"]
-	if !ok {
-		post.Environment["PATH"] = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
-
-		if inst.Type() == instancetype.Container {
-			// Add some additional paths. This directly looks through /proc
-			// rather than use FileExists as none of those paths are expected to be
-			// symlinks and this is much faster than forking a sub-process and
-			// attaching to the instance.
-			extraPaths := map[string]string{
-				"/snap":      "/snap/bin",
-				"/etc/NIXOS": "/run/current-system/sw/bin",
-			}
+	// Enforce the exec policy before setting up any session.
+	err = internalInstance.ExecCommandAllowed(inst.ExpandedConfig(), post.Command)
+	if err != nil {
+		event := lifecycle.InstanceExecDenied.Event(inst, logger.Ctx{"command": post.Command})
+		event.Requestor = request.CreateRequestor(r)
+		s.Events.SendLifecycle(projectName, event)
 
-			instPID := inst.InitPID()
-			for k, v := range extraPaths {
-				if util.PathExists(fmt.Sprintf("/proc/%d/root%s", instPID, k)) {
-					post.Environment["PATH"] = fmt.Sprintf("%s:%s", post.Environment["PATH"], v)
-				}
-			}
-		}
+		return response.Forbidden(err)
 	}
 
-	// If running as root, set some env variables.
-	if post.User == 0 {
-		// Set default value for HOME.
-		_, ok = post.Environment["HOME"]
-		if !ok {
-			post.Environment["HOME"] = "/root"
-		}
+	// Process environment.
+	instanceExecEnvironment(inst, &post)
 
-		// Set default value for USER.
-		_, ok = post.Environment["USER"]
-		if !ok {
-			post.Environment["USER"] = "root"
+	if post.WaitForWS {
+		session, err := instanceSessionStart(s, r, inst)
+		if err != nil {
+			return response.SmartError(err)
 		}
-	}
-
-	// Set default value for LANG.
-	_, ok = post.Environment["LANG"]
-	if !ok {
-		post.Environment["LANG"] = "C.UTF-8"
-	}
 
-	if post.WaitForWS {
 		ws := &execWs{}
 		ws.s = d.State()
+		ws.session = session
 		ws.fds = map[int]string{}
 
 		ws.conns = map[int]*websocket.Conn{}
@@ -684,6 +797,7 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 		for i := range ws.conns {
 			ws.fds[i], err = internalUtil.RandomHexString(32)
 			if err != nil {
+				session.End()
 				return response.InternalError(err)
 			}
 		}
@@ -696,6 +810,7 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 
 		op, err := operations.OperationCreate(s, projectName, operations.OperationClassWebsocket, operationtype.CommandExec, resources, ws.Metadata(), ws.Do, nil, ws.Connect, r)
 		if err != nil {
+			session.End()
 			return response.InternalError(err)
 		}
 
@@ -777,3 +892,67 @@ func instanceExecPost(d *Daemon, r *http.Request) response.Response {
 
 	return operations.OperationResponse(op)
 }
+
+// instanceExecEnvironment fills the environment of a command to run in the instance with the instance's
+// environment.* keys and default values for the usual variables.
+func instanceExecEnvironment(inst instance.Instance, post *api.InstanceExecPost) {
+	if post.Environment == nil {
+		post.Environment = map[string]string{}
+	}
+
+	// Override any environment variable settings from the instance if not manually specified in post.
+	for k, v := range inst.ExpandedConfig() {
+		if strings.HasPrefix(k, "environment.") {
+			envKey := strings.TrimPrefix(k, "environment.")
+			_, found := post.Environment[envKey]
+			if !found {
+				post.Environment[envKey] = v
+			}
+		}
+	}
+
+	// Set default value for PATH.
+	_, ok := post.Environment["PATH"]
+	if !ok {
+		post.Environment["PATH"] = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+		if inst.Type() == instancetype.Container {
+			// Add some additional paths. This directly looks through /proc
+			// rather than use FileExists as none of those paths are expected to be
+			// symlinks and this is much faster than forking a sub-process and
+			// attaching to the instance.
+			extraPaths := map[string]string{
+				"/snap":      "/snap/bin",
+				"/etc/NIXOS": "/run/current-system/sw/bin",
+			}
+
+			instPID := inst.InitPID()
+			for k, v := range extraPaths {
+				if util.PathExists(fmt.Sprintf("/proc/%d/root%s", instPID, k)) {
+					post.Environment["PATH"] = fmt.Sprintf("%s:%s", post.Environment["PATH"], v)
+				}
+			}
+		}
+	}
+
+	// If running as root, set some env variables.
+	if post.User == 0 {
+		// Set default value for HOME.
+		_, ok = post.Environment["HOME"]
+		if !ok {
+			post.Environment["HOME"] = "/root"
+		}
+
+		// Set default value for USER.
+		_, ok = post.Environment["USER"]
+		if !ok {
+			post.Environment["USER"] = "root"
+		}
+	}
+
+	// Set default value for LANG.
+	_, ok = post.Environment["LANG"]
+	if !ok {
+		post.Environment["LANG"] = "C.UTF-8"
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// instanceExpiryWarned tracks the expiry date for which an instance-expiring event was last emitted for each
+// instance, so that it's only emitted once unless the expiry changes.
+var instanceExpiryWarned = sync.Map{}
+
+// instanceExpiryRunning tracks the instances being deleted, so that slow deletions don't pile up.
+var instanceExpiryRunning = sync.Map{}
+
+// instanceExpiry returns when the instance expires and when a warning should be emitted about it.
+// A zero expiry date is returned if the instance doesn't expire.
+func instanceExpiry(inst instance.Instance) (time.Time, time.Time, error) {
+	config := inst.ExpandedConfig()
+
+	expiry, err := internalInstance.GetExpiry(inst.CreationDate(), config["expiry.ttl"])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid expiry.ttl: %w", err)
+	}
+
+	if expiry.IsZero() {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	warning := config["expiry.warning"]
+	if warning == "" {
+		warning = "10M"
+	}
+
+	// Measure the warning period from the expiry itself and subtract it.
+	warnUntil, err := internalInstance.GetExpiry(expiry, warning)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("Invalid expiry.warning: %w", err)
+	}
+
+	return expiry, expiry.Add(-warnUntil.Sub(expiry)), nil
+}
+
+// instanceExpire stops the expired instance and deletes it.
+func instanceExpire(inst instance.Instance) error {
+	if inst.IsRunning() {
+		err := inst.Stop(false)
+		if err != nil {
+			return fmt.Errorf("Failed stopping instance: %w", err)
+		}
+
+		// Ephemeral instances get deleted on stop.
+		if inst.IsEphemeral() {
+			return nil
+		}
+	}
+
+	err := inst.Delete(false)
+	if err != nil {
+		return fmt.Errorf("Failed deleting instance: %w", err)
+	}
+
+	return nil
+}
+
+func instanceExpiryTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		insts, err := instance.LoadNodeAll(s, instancetype.Any)
+		if err != nil {
+			logger.Error("Failed loading instances for expiry", logger.Ctx{"err": err})
+			return
+		}
+
+		now := time.Now()
+
+		for _, inst := range insts {
+			inst := inst
+			l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+
+			expiry, warnAt, err := instanceExpiry(inst)
+			if err != nil {
+				l.Warn("Failed getting instance expiry", logger.Ctx{"err": err})
+				continue
+			}
+
+			if expiry.IsZero() {
+				instanceExpiryWarned.Delete(inst.ID())
+				continue
+			}
+
+			if now.Before(warnAt) {
+				continue
+			}
+
+			// Emit the warning once per expiry date, even if the instance already expired.
+			var warnedExpiry time.Time
+
+			warned, ok := instanceExpiryWarned.Load(inst.ID())
+			if ok {
+				warnedExpiry, ok = warned.(time.Time)
+			}
+
+			if !ok || !warnedExpiry.Equal(expiry) {
+				instanceExpiryWarned.Store(inst.ID(), expiry)
+
+				s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceExpiring.Event(inst, map[string]any{
+					"expires_at": expiry,
+				}))
+			}
+
+			// Leave protected instances alone rather than stopping them and failing to delete them.
+			if now.Before(expiry) || util.IsTrue(inst.ExpandedConfig()["security.protection.delete"]) {
+				continue
+			}
+
+			_, loaded := instanceExpiryRunning.LoadOrStore(inst.ID(), struct{}{})
+			if loaded {
+				continue
+			}
+
+			opRun := func(op *operations.Operation) error {
+				defer instanceExpiryRunning.Delete(inst.ID())
+
+				err := instanceExpire(inst)
+				if err != nil {
+					return err
+				}
+
+				instanceExpiryWarned.Delete(inst.ID())
+				l.Info("Deleted expired instance")
+
+				return nil
+			}
+
+			resources := map[string][]api.URL{}
+			resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+			op, err := operations.OperationCreate(s, inst.Project().Name, operations.OperationClassTask, operationtype.InstanceDelete, resources, nil, opRun, nil, nil, nil)
+			if err != nil {
+				instanceExpiryRunning.Delete(inst.ID())
+				l.Error("Failed creating instance expiry operation", logger.Ctx{"err": err})
+				continue
+			}
+
+			err = op.Start()
+			if err != nil {
+				instanceExpiryRunning.Delete(inst.ID())
+				l.Error("Failed starting instance expiry operation", logger.Ctx{"err": err})
+			}
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
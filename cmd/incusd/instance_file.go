@@ -1,7 +1,10 @@
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/fs"
@@ -10,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -71,6 +75,23 @@ func instanceFileHandler(d *Daemon, r *http.Request) response.Response {
 		path = "/" + path
 	}
 
+	// Handle archive transfers.
+	archive := r.FormValue("archive")
+	if archive != "" {
+		if archive != "tar" {
+			return response.BadRequest(fmt.Errorf("Unsupported archive format %q", archive))
+		}
+
+		switch r.Method {
+		case "GET":
+			return instanceFileArchiveGet(s, inst, path)
+		case "POST":
+			return instanceFileArchivePost(s, inst, path, r)
+		default:
+			return response.BadRequest(fmt.Errorf("Method %q doesn't support archives", r.Method))
+		}
+	}
+
 	switch r.Method {
 	case "GET":
 		return instanceFileGet(s, inst, path, r)
@@ -106,6 +127,16 @@ func instanceFileHandler(d *Daemon, r *http.Request) response.Response {
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: recursion
+//	    description: Return the whole directory tree (1) or the tree with file checksums (2)
+//	    type: integer
+//	    example: 1
+//	  - in: query
+//	    name: archive
+//	    description: Return the directory as an archive of the given format (tar)
+//	    type: string
+//	    example: tar
 //	responses:
 //	  "200":
 //	     description: Raw file or directory listing
@@ -247,6 +278,18 @@ func instanceFileGet(s *state.State, inst instance.Instance, path string, r *htt
 		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFileRetrieved.Event(inst, logger.Ctx{"path": path}))
 		return response.FileResponse(r, files, headers)
 	} else if fileType == "directory" {
+		// Return the whole directory tree if requested.
+		recursion, _ := strconv.Atoi(r.FormValue("recursion"))
+		if recursion > 0 {
+			entries, err := instanceFileTree(client, path, recursion > 1)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFileRetrieved.Event(inst, logger.Ctx{"path": path}))
+			return response.SyncResponseHeaders(true, entries, headers)
+		}
+
 		dirEnts := []string{}
 
 		// List the directory.
@@ -266,6 +309,83 @@ func instanceFileGet(s *state.State, inst instance.Instance, path string, r *htt
 	}
 }
 
+// instanceFileTree walks the directory tree at root and returns all its entries.
+// The SHA256 checksum of regular files is included when withChecksums is set.
+func instanceFileTree(client *sftp.Client, root string, withChecksums bool) ([]api.InstanceFileEntry, error) {
+	entries := []api.InstanceFileEntry{}
+
+	walker := client.Walk(root)
+	for walker.Step() {
+		err := walker.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		if walker.Path() == root {
+			continue
+		}
+
+		stat := walker.Stat()
+		fileStat, ok := stat.Sys().(*sftp.FileStat)
+		if !ok {
+			return nil, fmt.Errorf("Unexpected file stat type %T for %q", stat.Sys(), walker.Path())
+		}
+
+		entry := api.InstanceFileEntry{
+			Path: strings.TrimPrefix(strings.TrimPrefix(walker.Path(), root), "/"),
+			Mode: int(stat.Mode().Perm()),
+			UID:  int64(fileStat.UID),
+			GID:  int64(fileStat.GID),
+			Size: stat.Size(),
+		}
+
+		if stat.IsDir() {
+			entry.Type = "directory"
+		} else if stat.Mode()&os.ModeSymlink == os.ModeSymlink {
+			entry.Type = "symlink"
+
+			entry.Target, err = client.ReadLink(walker.Path())
+			if err != nil {
+				return nil, err
+			}
+		} else if stat.Mode().IsRegular() {
+			entry.Type = "file"
+
+			if withChecksums {
+				entry.Checksum, err = instanceFileChecksum(client, walker.Path())
+				if err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			// Skip devices, sockets and pipes.
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// instanceFileChecksum returns the SHA256 checksum of the file at path.
+func instanceFileChecksum(client *sftp.Client, path string) (string, error) {
+	file, err := client.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // swagger:operation HEAD /1.0/instances/{name}/files instances instance_files_head
 //
 //	Get metadata for a file
@@ -392,6 +512,11 @@ func instanceFileHead(s *state.State, inst instance.Instance, path string, r *ht
 //	    description: Project name
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: archive
+//	    description: Unpack the request body as an archive of the given format (tar) into the directory
+//	    type: string
+//	    example: tar
 //	  - in: body
 //	    name: raw_file
 //	    description: Raw file content
@@ -562,6 +687,190 @@ func instanceFilePost(s *state.State, inst instance.Instance, path string, r *ht
 	}
 }
 
+// instanceFileArchiveGet streams the directory tree at path as a tarball.
+func instanceFileArchiveGet(s *state.State, inst instance.Instance, path string) response.Response {
+	// Get a SFTP client.
+	client, err := inst.FileSFTP()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	// Check that the path is a directory.
+	stat, err := client.Stat(path)
+	if err != nil {
+		_ = client.Close()
+		return response.SmartError(err)
+	}
+
+	if !stat.IsDir() {
+		_ = client.Close()
+		return response.BadRequest(fmt.Errorf("Path %q isn't a directory", path))
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFileRetrieved.Event(inst, logger.Ctx{"path": path}))
+
+	return response.ManualResponse(func(w http.ResponseWriter) error {
+		defer func() { _ = client.Close() }()
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.WriteHeader(http.StatusOK)
+
+		tw := tar.NewWriter(w)
+
+		walker := client.Walk(path)
+		for walker.Step() {
+			err := walker.Err()
+			if err != nil {
+				return err
+			}
+
+			if walker.Path() == path {
+				continue
+			}
+
+			stat := walker.Stat()
+			fileStat, ok := stat.Sys().(*sftp.FileStat)
+			if !ok {
+				return fmt.Errorf("Unexpected file stat type %T for %q", stat.Sys(), walker.Path())
+			}
+
+			link := ""
+			if stat.Mode()&os.ModeSymlink == os.ModeSymlink {
+				link, err = client.ReadLink(walker.Path())
+				if err != nil {
+					return err
+				}
+			} else if !stat.IsDir() && !stat.Mode().IsRegular() {
+				// Skip devices, sockets and pipes.
+				continue
+			}
+
+			hdr, err := tar.FileInfoHeader(stat, link)
+			if err != nil {
+				return err
+			}
+
+			hdr.Name = strings.TrimPrefix(strings.TrimPrefix(walker.Path(), path), "/")
+			if stat.IsDir() {
+				hdr.Name += "/"
+			}
+
+			hdr.Uid = int(fileStat.UID)
+			hdr.Gid = int(fileStat.GID)
+			hdr.Uname = ""
+			hdr.Gname = ""
+
+			err = tw.WriteHeader(hdr)
+			if err != nil {
+				return err
+			}
+
+			if stat.Mode().IsRegular() {
+				file, err := client.Open(walker.Path())
+				if err != nil {
+					return err
+				}
+
+				_, err = io.Copy(tw, file)
+				_ = file.Close()
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return tw.Close()
+	})
+}
+
+// instanceFileArchivePost unpacks the tarball in the request body into the directory at path.
+func instanceFileArchivePost(s *state.State, inst instance.Instance, path string, r *http.Request) response.Response {
+	// Get a SFTP client.
+	client, err := inst.FileSFTP()
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	defer func() { _ = client.Close() }()
+
+	// Create the target directory if missing.
+	err = client.MkdirAll(path)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	tr := tar.NewReader(r.Body)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return response.BadRequest(err)
+		}
+
+		// Keep all entries within the target directory.
+		target := filepath.Join(path, filepath.Clean("/"+hdr.Name))
+		if target == path {
+			continue
+		}
+
+		// Replace anything that isn't a directory.
+		stat, err := client.Lstat(target)
+		if err == nil && !(stat.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if stat.IsDir() {
+				err = client.RemoveAll(target)
+			} else {
+				err = client.Remove(target)
+			}
+
+			if err != nil {
+				return response.SmartError(err)
+			}
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = client.MkdirAll(target)
+		case tar.TypeReg:
+			var file *sftp.File
+			file, err = client.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			_, err = io.Copy(file, tr)
+			_ = file.Close()
+		case tar.TypeSymlink:
+			err = client.Symlink(hdr.Linkname, target)
+		default:
+			return response.BadRequest(fmt.Errorf("Unsupported archive entry type for %q", hdr.Name))
+		}
+
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if hdr.Typeflag == tar.TypeSymlink {
+			continue
+		}
+
+		err = client.Chmod(target, fs.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		err = client.Chown(target, hdr.Uid, hdr.Gid)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceFilePushed.Event(inst, logger.Ctx{"path": path}))
+	return response.EmptySyncResponse
+}
+
 // swagger:operation DELETE /1.0/instances/{name}/files instances instance_files_delete
 //
 //	Delete a file
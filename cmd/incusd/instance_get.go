@@ -13,6 +13,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 // swagger:operation GET /1.0/instances/{name} instances instance_get
@@ -145,7 +146,14 @@ func instanceGet(d *Daemon, r *http.Request) response.Response {
 		state, etag, err = c.Render()
 	} else {
 		hostInterfaces, _ := net.Interfaces()
-		state, etag, err = c.RenderFull(hostInterfaces)
+
+		var full *api.InstanceFull
+		full, etag, err = c.RenderFull(hostInterfaces)
+		if err == nil && full.State != nil {
+			full.State.Probes = instanceProbesRender(c)
+		}
+
+		state = full
 	}
 
 	if err != nil {
@@ -0,0 +1,852 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+var instanceGroupsCmd = APIEndpoint{
+	Path: "instance-groups",
+
+	Get:  APIEndpointAction{Handler: instanceGroupsGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView)},
+	Post: APIEndpointAction{Handler: instanceGroupsPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+}
+
+var instanceGroupCmd = APIEndpoint{
+	Path: "instance-groups/{name}",
+
+	Delete: APIEndpointAction{Handler: instanceGroupDelete, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Get:    APIEndpointAction{Handler: instanceGroupGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView)},
+	Patch:  APIEndpointAction{Handler: instanceGroupPut, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Post:   APIEndpointAction{Handler: instanceGroupPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Put:    APIEndpointAction{Handler: instanceGroupPut, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+}
+
+var instanceGroupStateCmd = APIEndpoint{
+	Path: "instance-groups/{name}/state",
+
+	Put: APIEndpointAction{Handler: instanceGroupStatePut, AccessHandler: allowAuthenticated},
+}
+
+var instanceGroupSnapshotsCmd = APIEndpoint{
+	Path: "instance-groups/{name}/snapshots",
+
+	Post: APIEndpointAction{Handler: instanceGroupSnapshotsPost, AccessHandler: allowAuthenticated},
+}
+
+// instanceGroupValidateName checks the name of an instance group.
+func instanceGroupValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("No name provided")
+	}
+
+	err := validate.IsURLSegmentSafe(name)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(name, " ") {
+		return fmt.Errorf("Instance group names may not contain spaces")
+	}
+
+	if strings.Contains(name, ":") {
+		return fmt.Errorf("Instance group names may not contain colons")
+	}
+
+	return nil
+}
+
+// instanceGroupLoad loads the instance group from the request.
+func instanceGroupLoad(s *state.State, r *http.Request) (int64, *api.InstanceGroup, error) {
+	projectName := request.ProjectParam(r)
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return -1, nil, err
+	}
+
+	var id int64
+	var group *api.InstanceGroup
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, group, err = tx.GetInstanceGroup(ctx, projectName, name)
+
+		return err
+	})
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return id, group, nil
+}
+
+// instanceGroupCheckPermission checks that the requestor has the given entitlement on all the instances of the group.
+// Bulk operations are refused as a whole rather than silently skipping instances.
+func instanceGroupCheckPermission(s *state.State, r *http.Request, group *api.InstanceGroup, entitlement auth.Entitlement) error {
+	userHasPermission, err := s.Authorizer.GetPermissionChecker(r.Context(), r, entitlement, auth.ObjectTypeInstance)
+	if err != nil {
+		return err
+	}
+
+	for _, instName := range group.Instances {
+		if !userHasPermission(auth.ObjectInstance(group.Project, instName)) {
+			return api.StatusErrorf(http.StatusForbidden, "Not allowed to act on instance %q of the group", instName)
+		}
+	}
+
+	return nil
+}
+
+// instanceStateChangeNeeded returns whether the given action changes the state of an instance.
+func instanceStateChangeNeeded(action internalInstance.InstanceAction, running bool, frozen bool) bool {
+	switch action {
+	case internalInstance.Freeze, internalInstance.Restart, internalInstance.Stop:
+		return running
+
+	case internalInstance.Start:
+		return !running
+
+	case internalInstance.Unfreeze:
+		return frozen
+	}
+
+	return true
+}
+
+// instanceStateChange changes the state of an instance, on whichever cluster member it's located.
+// Returns false if the instance already was in the requested state.
+func instanceStateChange(s *state.State, r *http.Request, op *operations.Operation, projectName string, instName string, req api.InstanceStatePut) (bool, error) {
+	action := internalInstance.InstanceAction(req.Action)
+
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, instName, r, instancetype.Any)
+	if err != nil {
+		return false, err
+	}
+
+	if client != nil {
+		inst, _, err := client.GetInstance(instName)
+		if err != nil {
+			return false, err
+		}
+
+		if !instanceStateChangeNeeded(action, inst.StatusCode == api.Running || inst.StatusCode == api.Frozen, inst.StatusCode == api.Frozen) {
+			return false, nil
+		}
+
+		remoteOp, err := client.UpdateInstanceState(instName, req, "")
+		if err != nil {
+			return false, err
+		}
+
+		return true, remoteOp.Wait()
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, instName)
+	if err != nil {
+		return false, err
+	}
+
+	if !instanceStateChangeNeeded(action, inst.IsRunning(), inst.IsFrozen()) {
+		return false, nil
+	}
+
+	inst.SetOperation(op)
+
+	return true, doInstanceStatePut(inst, req)
+}
+
+// instanceGroupResources returns the resources of an operation acting on an instance group.
+func instanceGroupResources(group *api.InstanceGroup) map[string][]api.URL {
+	resources := map[string][]api.URL{}
+	resources["instance_groups"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instance-groups", group.Name)}
+
+	for _, instName := range group.Instances {
+		resources["instances"] = append(resources["instances"], *api.NewURL().Path(version.APIVersion, "instances", instName))
+	}
+
+	return resources
+}
+
+// API endpoints
+
+// swagger:operation GET /1.0/instance-groups instance-groups instance_groups_get
+//
+//  Get the instance groups
+//
+//  Returns a list of instance groups (URLs).
+//
+//  ---
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//  responses:
+//    "200":
+//      description: API endpoints
+//      schema:
+//        type: object
+//        description: Sync response
+//        properties:
+//          type:
+//            type: string
+//            description: Response type
+//            example: sync
+//          status:
+//            type: string
+//            description: Status description
+//            example: Success
+//          status_code:
+//            type: integer
+//            description: Status code
+//            example: 200
+//          metadata:
+//            type: array
+//            description: List of endpoints
+//            items:
+//              type: string
+//            example: |-
+//              [
+//                "/1.0/instance-groups/web",
+//                "/1.0/instance-groups/db"
+//              ]
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/instance-groups?recursion=1 instance-groups instance_groups_get_recursion1
+//
+//	Get the instance groups
+//
+//	Returns a list of instance groups (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of instance groups
+//	          items:
+//	            $ref: "#/definitions/InstanceGroup"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	var groups []api.InstanceGroup
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		groups, err = tx.GetInstanceGroups(ctx, projectName)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading instance groups: %w", err))
+	}
+
+	if localUtil.IsRecursionRequest(r) {
+		return response.SyncResponse(true, groups)
+	}
+
+	groupURLs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		groupURLs = append(groupURLs, api.NewURL().Path(version.APIVersion, "instance-groups", group.Name).String())
+	}
+
+	return response.SyncResponse(true, groupURLs)
+}
+
+// swagger:operation POST /1.0/instance-groups instance-groups instance_groups_post
+//
+//	Add an instance group
+//
+//	Creates a new instance group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: group
+//	    description: Instance group
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceGroupsPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	req := api.InstanceGroupsPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceGroupValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, _, err := tx.GetInstanceGroup(ctx, projectName, req.Name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Instance group %q already exists", req.Name)
+		}
+
+		_, err = tx.CreateInstanceGroup(ctx, projectName, &req)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating instance group: %w", err))
+	}
+
+	lc := lifecycle.InstanceGroupCreated.Event(req.Name, projectName, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/instance-groups/{name} instance-groups instance_group_delete
+//
+//	Delete the instance group
+//
+//	Removes the instance group, the instances themselves are left untouched.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, group, err := instanceGroupLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.DeleteInstanceGroup(ctx, id)
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed deleting instance group: %w", err))
+	}
+
+	s.Events.SendLifecycle(group.Project, lifecycle.InstanceGroupDeleted.Event(group.Name, group.Project, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/instance-groups/{name} instance-groups instance_group_get
+//
+//	Get the instance group
+//
+//	Gets a specific instance group.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Instance group
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceGroup"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupGet(d *Daemon, r *http.Request) response.Response {
+	_, group, err := instanceGroupLoad(d.State(), r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, group, group.Etag())
+}
+
+// swagger:operation PATCH /1.0/instance-groups/{name} instance-groups instance_group_patch
+//
+//  Partially update the instance group
+//
+//  Updates a subset of the instance group configuration.
+//
+//  ---
+//  consumes:
+//    - application/json
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//    - in: body
+//      name: group
+//      description: Instance group configuration
+//      required: true
+//      schema:
+//        $ref: "#/definitions/InstanceGroupPut"
+//  responses:
+//    "200":
+//      $ref: "#/responses/EmptySyncResponse"
+//    "400":
+//      $ref: "#/responses/BadRequest"
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "412":
+//      $ref: "#/responses/PreconditionFailed"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation PUT /1.0/instance-groups/{name} instance-groups instance_group_put
+//
+//	Update the instance group
+//
+//	Updates the entire instance group configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: group
+//	    description: Instance group configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceGroupPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, group, err := instanceGroupLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, group.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Start from the current values when partially updating the group.
+	req := api.InstanceGroupPut{}
+	if r.Method == http.MethodPatch {
+		req = group.Writable()
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateInstanceGroup(ctx, group.Project, id, &req)
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed updating instance group: %w", err))
+	}
+
+	s.Events.SendLifecycle(group.Project, lifecycle.InstanceGroupUpdated.Event(group.Name, group.Project, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/instance-groups/{name} instance-groups instance_group_post
+//
+//	Rename the instance group
+//
+//	Renames an existing instance group.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: group
+//	    description: Instance group rename request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceGroupPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, group, err := instanceGroupLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceGroupPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceGroupValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check that the name isn't already in use.
+		_, _, err := tx.GetInstanceGroup(ctx, group.Project, req.Name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Name %q already in use", req.Name)
+		}
+
+		return tx.RenameInstanceGroup(ctx, id, req.Name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.InstanceGroupRenamed.Event(req.Name, group.Project, request.CreateRequestor(r), logger.Ctx{"old_name": group.Name})
+	s.Events.SendLifecycle(group.Project, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation PUT /1.0/instance-groups/{name}/state instance-groups instance_group_state_put
+//
+//	Change the state of the instance group
+//
+//	Changes the running state of all the instances of the group as a single operation.
+//	Instances which already are in the requested state are skipped. If any instance fails to start,
+//	the instances started by the request are stopped again.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: state
+//	    description: State
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceStatePut"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupStatePut(d *Daemon, r *http.Request) response.Response {
+	// Don't mess with instances while in setup mode.
+	<-d.waitReady.Done()
+
+	s := d.State()
+
+	_, group, err := instanceGroupLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceStatePut{}
+	req.Timeout = -1
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Determine operation type.
+	opType, err := instanceActionToOptype(req.Action)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceGroupCheckPermission(s, r, group, auth.EntitlementCanUpdateState)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	do := func(op *operations.Operation) error {
+		reverter := revert.New()
+		defer reverter.Fail()
+
+		failures := map[string]error{}
+		failuresLock := sync.Mutex{}
+		wgAction := sync.WaitGroup{}
+
+		for _, instName := range group.Instances {
+			wgAction.Add(1)
+			go func(instName string) {
+				defer wgAction.Done()
+
+				changed, err := instanceStateChange(s, r, op, group.Project, instName, req)
+
+				failuresLock.Lock()
+				defer failuresLock.Unlock()
+
+				if err != nil {
+					failures[instName] = err
+					return
+				}
+
+				if changed && internalInstance.InstanceAction(req.Action) == internalInstance.Start {
+					reverter.Add(func() {
+						_, err := instanceStateChange(s, r, op, group.Project, instName, api.InstanceStatePut{Action: string(internalInstance.Stop), Force: true})
+						if err != nil {
+							logger.Warn("Failed stopping instance after failed group start", logger.Ctx{"project": group.Project, "instance": instName, "err": err})
+						}
+					})
+				}
+			}(instName)
+		}
+
+		wgAction.Wait()
+
+		err := coalesceErrors(true, failures)
+		if err != nil {
+			return err
+		}
+
+		reverter.Success()
+
+		return nil
+	}
+
+	op, err := operations.OperationCreate(s, group.Project, operations.OperationClassTask, opType, instanceGroupResources(group), nil, do, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// swagger:operation POST /1.0/instance-groups/{name}/snapshots instance-groups instance_group_snapshots_post
+//
+//	Snapshot the instance group
+//
+//	Creates a snapshot with the same name of all the instances of the group as a single operation.
+//	If any snapshot fails, the snapshots created by the request are deleted again.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: snapshot
+//	    description: Snapshot request
+//	    required: false
+//	    schema:
+//	      $ref: "#/definitions/InstanceSnapshotsPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceGroupSnapshotsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	_, group, err := instanceGroupLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceSnapshotsPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceGroupCheckPermission(s, r, group, auth.EntitlementCanManageSnapshots)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = instancesSnapshotPrepare(r.Context(), s, group.Project, group.Instances, &req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	snapshot := func(op *operations.Operation) error {
+		return instancesSnapshotCreate(s, r, op, group.Project, group.Instances, req, false)
+	}
+
+	resources := instanceGroupResources(group)
+	for _, instName := range group.Instances {
+		resources["instances_snapshots"] = append(resources["instances_snapshots"], *api.NewURL().Path(version.APIVersion, "instances", instName, "snapshots", req.Name))
+	}
+
+	op, err := operations.OperationCreate(s, group.Project, operations.OperationClassTask, operationtype.SnapshotCreate, resources, nil, snapshot, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
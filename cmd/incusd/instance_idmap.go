@@ -0,0 +1,271 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	projecthelpers "github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// instanceIdmapLoad loads the container targeted by the request.
+// A response is returned instead when the request was forwarded to another server or failed.
+func instanceIdmapLoad(d *Daemon, r *http.Request, lock bool) (instance.Container, func(), response.Response) {
+	s := d.State()
+
+	instanceType, err := urlInstanceTypeDetect(r)
+	if err != nil {
+		return nil, nil, response.SmartError(err)
+	}
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return nil, nil, response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return nil, nil, response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	// Forward the request if the instance is remote.
+	resp, err := forwardedResponseIfInstanceIsRemote(s, r, projectName, name, instanceType)
+	if err != nil {
+		return nil, nil, response.SmartError(err)
+	}
+
+	if resp != nil {
+		return nil, nil, resp
+	}
+
+	unlock := func() {}
+	if lock {
+		unlock, err = instanceOperationLock(s.ShutdownCtx, projectName, name)
+		if err != nil {
+			return nil, nil, response.SmartError(err)
+		}
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, name)
+	if err != nil {
+		unlock()
+		return nil, nil, response.SmartError(err)
+	}
+
+	if inst.Type() != instancetype.Container {
+		unlock()
+		return nil, nil, response.BadRequest(fmt.Errorf("ID maps are only supported on containers"))
+	}
+
+	c, ok := inst.(instance.Container)
+	if !ok {
+		unlock()
+		return nil, nil, response.InternalError(fmt.Errorf("Failed to cast instance to container"))
+	}
+
+	return c, unlock, nil
+}
+
+// swagger:operation GET /1.0/instances/{name}/idmap instances instance_idmap_get
+//
+//	Get the ID map
+//
+//	Gets the current and next ID maps of a container, the range of host IDs it
+//	uses and its conflicts with other containers.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: ID map
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceIdmap"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceIdmapGet(d *Daemon, r *http.Request) response.Response {
+	c, _, resp := instanceIdmapLoad(d, r, false)
+	if resp != nil {
+		return resp
+	}
+
+	idmap, err := c.Idmap()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	etag := []any{idmap.Isolated, idmap.Base, idmap.Size}
+
+	return response.SyncResponseETag(true, idmap, etag)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/idmap instances instance_idmap_put
+//
+//	Update the ID map
+//
+//	Assigns a range of host IDs to the container.
+//	The request is rejected with the list of conflicts when the range overlaps
+//	with other isolated containers or differs from the range of the containers
+//	it shares custom volumes with.
+//	The new ID map is applied on the next start of the container.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: idmap
+//	    description: ID map range
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceIdmapPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceIdmapPut(d *Daemon, r *http.Request) response.Response {
+	// Don't mess with instance while in setup mode.
+	<-d.waitReady.Done()
+
+	s := d.State()
+
+	c, unlock, resp := instanceIdmapLoad(d, r, true)
+	if resp != nil {
+		return resp
+	}
+
+	defer unlock()
+
+	req := api.InstanceIdmapPut{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if c.IsPrivileged() {
+		return response.BadRequest(fmt.Errorf("Privileged containers don't use an ID map"))
+	}
+
+	if req.Base < 0 || req.Size < 0 {
+		return response.BadRequest(fmt.Errorf("The base and size of the ID map can't be negative"))
+	}
+
+	if !req.Isolated && (req.Base != 0 || req.Size != 0) {
+		return response.BadRequest(fmt.Errorf("A base and size can only be set on an isolated ID map"))
+	}
+
+	// Validate the ETag.
+	current, err := c.Idmap()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = localUtil.EtagCheck(r, []any{current.Isolated, current.Base, current.Size})
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Apply the range through the container configuration.
+	config := maps.Clone(c.LocalConfig())
+	delete(config, "security.idmap.isolated")
+	delete(config, "security.idmap.base")
+	delete(config, "security.idmap.size")
+
+	if req.Isolated {
+		config["security.idmap.isolated"] = "true"
+	} else if util.IsTrue(c.ExpandedConfig()["security.idmap.isolated"]) {
+		// Override the value coming from the profiles.
+		config["security.idmap.isolated"] = "false"
+	}
+
+	if req.Base > 0 {
+		config["security.idmap.base"] = strconv.FormatInt(req.Base, 10)
+	}
+
+	if req.Size > 0 {
+		config["security.idmap.size"] = strconv.FormatInt(req.Size, 10)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return projecthelpers.AllowInstanceUpdate(tx, c.Project().Name, c.Name(), api.InstancePut{Config: config}, c.LocalConfig())
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	args := db.InstanceArgs{
+		Architecture: c.Architecture(),
+		Config:       config,
+		Description:  c.Description(),
+		Devices:      c.LocalDevices(),
+		Ephemeral:    c.IsEphemeral(),
+		Profiles:     c.Profiles(),
+		Project:      c.Project().Name,
+	}
+
+	err = c.Update(args, true)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
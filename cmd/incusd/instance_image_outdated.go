@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// instanceImageOutdatedNotify raises a warning and emits a lifecycle event for each instance created from
+// the previous version of a refreshed image. If enabled, the ephemeral instances are rebuilt from the new image
+// by the cluster member they are located on.
+func instanceImageOutdatedNotify(ctx context.Context, s *state.State, projectName string, oldFingerprint string, newImage *api.Image) error {
+	var outdated []instance.Instance
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		err := tx.InstanceList(ctx, func(dbInst db.InstanceArgs, p api.Project) error {
+			if dbInst.Config["volatile.base_image"] != oldFingerprint || project.ImageProjectFromRecord(&p) != projectName {
+				return nil
+			}
+
+			inst, err := instance.Load(s, dbInst, p)
+			if err != nil {
+				return fmt.Errorf("Failed loading instance %q in project %q: %w", dbInst.Name, dbInst.Project, err)
+			}
+
+			outdated = append(outdated, inst)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("Image %q was refreshed to %q", oldFingerprint[:12], newImage.Fingerprint[:12])
+		for _, inst := range outdated {
+			err = tx.UpsertWarning(ctx, inst.Location(), inst.Project().Name, dbCluster.TypeInstance, inst.ID(), warningtype.InstanceImageOutdated, message)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rebuild := s.GlobalConfig.ImagesAutoRebuildEphemeral()
+
+	for _, inst := range outdated {
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceImageOutdated.Event(inst, map[string]any{
+			"old_fingerprint": oldFingerprint,
+			"new_fingerprint": newImage.Fingerprint,
+		}))
+
+		if !rebuild || !inst.IsEphemeral() {
+			continue
+		}
+
+		l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "fingerprint": newImage.Fingerprint})
+
+		// Rebuild the instance on the cluster member it's located on.
+		client, err := cluster.ConnectIfInstanceIsRemote(s, inst.Project().Name, inst.Name(), nil, inst.Type())
+		if err != nil {
+			l.Error("Failed connecting to the cluster member of the outdated instance", logger.Ctx{"err": err})
+			continue
+		}
+
+		if client != nil {
+			req := internalInstanceRebuildPost{
+				Project: inst.Project().Name,
+				Name:    inst.Name(),
+				Image:   *newImage,
+			}
+
+			_, _, err = client.RawQuery("POST", "/internal/instance-rebuild", req, "")
+		} else {
+			err = instanceImageOutdatedRebuild(ctx, s, inst, newImage)
+		}
+
+		if err != nil {
+			l.Error("Failed rebuilding outdated ephemeral instance", logger.Ctx{"err": err})
+			continue
+		}
+
+		l.Info("Rebuilt outdated ephemeral instance")
+	}
+
+	return nil
+}
+
+// instanceImageOutdatedRebuild rebuilds an ephemeral instance from the refreshed image.
+// As stopping an ephemeral instance deletes it, a running instance is temporarily made persistent,
+// stopped, rebuilt and started again.
+func instanceImageOutdatedRebuild(ctx context.Context, s *state.State, inst instance.Instance, img *api.Image) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	running := inst.IsRunning()
+	if running {
+		err := instanceImageOutdatedSetEphemeral(inst, false)
+		if err != nil {
+			return err
+		}
+
+		reverter.Add(func() { _ = instanceImageOutdatedSetEphemeral(inst, true) })
+
+		err = inst.Stop(false)
+		if err != nil {
+			return fmt.Errorf("Failed stopping instance: %w", err)
+		}
+	}
+
+	err := instanceRebuildFromImage(ctx, s, nil, inst, img, nil)
+	if err != nil {
+		return err
+	}
+
+	if !running {
+		reverter.Success()
+		return nil
+	}
+
+	// Reload the instance to pick up its new configuration.
+	inst, err = instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+	if err != nil {
+		return err
+	}
+
+	err = instanceImageOutdatedSetEphemeral(inst, true)
+	if err != nil {
+		return err
+	}
+
+	reverter.Success()
+
+	err = inst.Start(false)
+	if err != nil {
+		return fmt.Errorf("Failed starting instance: %w", err)
+	}
+
+	return nil
+}
+
+// instanceImageOutdatedSetEphemeral changes whether the instance is ephemeral, leaving the rest of its configuration untouched.
+func instanceImageOutdatedSetEphemeral(inst instance.Instance, ephemeral bool) error {
+	args := db.InstanceArgs{
+		Architecture: inst.Architecture(),
+		Config:       inst.LocalConfig(),
+		Description:  inst.Description(),
+		Devices:      inst.LocalDevices(),
+		Ephemeral:    ephemeral,
+		Profiles:     inst.Profiles(),
+		Project:      inst.Project().Name,
+		ExpiryDate:   inst.ExpiryDate(),
+	}
+
+	err := inst.Update(args, false)
+	if err != nil {
+		return fmt.Errorf("Failed updating instance: %w", err)
+	}
+
+	return nil
+}
+
+// instanceImageOutdatedResolve resolves the outdated image warning of an instance once it has been rebuilt.
+func instanceImageOutdatedResolve(s *state.State, inst instance.Instance) {
+	err := warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, inst.Project().Name, warningtype.InstanceImageOutdated, dbCluster.TypeInstance, inst.ID())
+	if err != nil {
+		logger.Warn("Failed resolving outdated image warning", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+	}
+}
+
+// internalInstanceRebuild rebuilds a local outdated ephemeral instance on behalf of the cluster member
+// which refreshed its image.
+func internalInstanceRebuild(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	req := internalInstanceRebuildPost{}
+
+	// Parse the request.
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, req.Project, req.Name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if !inst.IsEphemeral() {
+		return response.BadRequest(fmt.Errorf("Only ephemeral instances can be rebuilt automatically"))
+	}
+
+	err = instanceImageOutdatedRebuild(r.Context(), s, inst, &req.Image)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
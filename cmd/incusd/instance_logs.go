@@ -57,7 +57,7 @@ var instanceExecOutputsCmd = APIEndpoint{
 //
 //	Get the log files
 //
-//	Returns a list of log files (URLs).
+//	Returns a list of log files (URLs), including the crash dumps captured when the instance process crashed.
 //
 //	---
 //	produces:
@@ -324,8 +324,8 @@ func instanceLogDelete(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Log file name %q not valid", file))
 	}
 
-	if !strings.HasSuffix(file, ".log") || file == "lxc.log" || file == "qemu.log" {
-		return response.BadRequest(fmt.Errorf("Only log files excluding qemu.log and lxc.log may be deleted"))
+	if (!strings.HasSuffix(file, ".log") && !strings.HasPrefix(file, "crash_")) || file == "lxc.log" || file == "qemu.log" {
+		return response.BadRequest(fmt.Errorf("Only log files and crash dumps excluding qemu.log and lxc.log may be deleted"))
 	}
 
 	err = os.Remove(internalUtil.LogPath(project.Instance(projectName, name), file))
@@ -670,11 +670,12 @@ func validLogFileName(fname string) bool {
 	 */
 	return fname == "lxc.log" ||
 		fname == "qemu.log" ||
+		strings.HasPrefix(fname, "crash_") ||
 		strings.HasPrefix(fname, "migration_") ||
 		strings.HasPrefix(fname, "snapshot_")
 }
 
 func validExecOutputFileName(fName string) bool {
-	return (strings.HasSuffix(fName, ".stdout") || strings.HasSuffix(fName, ".stderr")) &&
+	return (strings.HasSuffix(fName, ".stdout") || strings.HasSuffix(fName, ".stderr") || strings.HasSuffix(fName, ".cast")) &&
 		strings.HasPrefix(fName, "exec_")
 }
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// instanceNetworkUsagePeriodFormat is the layout of the monthly accounting periods.
+const instanceNetworkUsagePeriodFormat = "2006-01"
+
+var instanceNetworkUsageCmd = APIEndpoint{
+	Name: "instanceNetworkUsage",
+	Path: "instances/{name}/network-usage",
+
+	Get: APIEndpointAction{Handler: instanceNetworkUsageGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
+var projectNetworkUsageCmd = APIEndpoint{
+	Path: "projects/{name}/network-usage",
+
+	Get: APIEndpointAction{Handler: projectNetworkUsageGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView, "name")},
+}
+
+// instanceNetworkUsagePeriods returns the accounting periods requested through the period query parameter.
+func instanceNetworkUsagePeriods(r *http.Request) ([]string, error) {
+	periods := util.SplitNTrimSpace(r.FormValue("period"), ",", -1, true)
+	for _, period := range periods {
+		_, err := time.Parse(instanceNetworkUsagePeriodFormat, period)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid period %q (must be YYYY-MM)", period)
+		}
+	}
+
+	return periods, nil
+}
+
+// swagger:operation GET /1.0/instances/{name}/network-usage instances instance_network_usage_get
+//
+//	Get the network usage
+//
+//	Gets the cumulative network transfer of the instance for each monthly period.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: period
+//	    description: Comma-separated list of periods (YYYY-MM) to return
+//	    type: string
+//	    example: 2024-05
+//	responses:
+//	  "200":
+//	    description: Network usage
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of network usage periods
+//	          items:
+//	            $ref: "#/definitions/InstanceNetworkUsage"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceNetworkUsageGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if internalInstance.IsSnapshot(name) {
+		return response.BadRequest(fmt.Errorf("Invalid instance name"))
+	}
+
+	periods, err := instanceNetworkUsagePeriods(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	var usages []api.InstanceNetworkUsage
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		instanceID, err := dbCluster.GetInstanceID(ctx, tx.Tx(), projectName, name)
+		if err != nil {
+			return err
+		}
+
+		usages, err = tx.GetInstanceNetworkUsage(ctx, int(instanceID), periods...)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, usages)
+}
+
+// swagger:operation GET /1.0/projects/{name}/network-usage projects project_network_usage_get
+//
+//	Get the network usage
+//
+//	Gets the cumulative network transfer of the project's instances for each monthly period.
+//	This includes the instances that have since been deleted.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: period
+//	    description: Comma-separated list of periods (YYYY-MM) to return
+//	    type: string
+//	    example: 2024-05
+//	responses:
+//	  "200":
+//	    description: Network usage
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of network usage periods
+//	          items:
+//	            $ref: "#/definitions/ProjectNetworkUsage"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectNetworkUsageGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	periods, err := instanceNetworkUsagePeriods(r)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	var usages []api.ProjectNetworkUsage
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check the project exists.
+		_, err := dbCluster.GetProjectID(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		usages, err = tx.GetProjectNetworkUsage(ctx, name, periods...)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, usages)
+}
+
+// instanceNetworkUsageCounters returns the current network counters of each NIC of the instance having a host
+// side interface, keyed by the name of that interface. The counters are reported from the instance's point of view.
+func instanceNetworkUsageCounters(inst instance.Instance) (map[string]api.NetworkUsageCounters, error) {
+	counters := map[string]api.NetworkUsageCounters{}
+
+	for devName, devConfig := range inst.ExpandedDevices() {
+		if devConfig["type"] != "nic" {
+			continue
+		}
+
+		hostName := inst.LocalConfig()[fmt.Sprintf("volatile.%s.host_name", devName)]
+		if hostName == "" {
+			continue // NIC types without a host side interface can't be accounted.
+		}
+
+		hostCounters, err := resources.GetNetworkCounters(hostName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting network interface counters for %q: %w", devName, err)
+		}
+
+		// Reverse the host counters to get the values from the instance's point of view.
+		counters[hostName] = api.NetworkUsageCounters{
+			BytesReceived:   hostCounters.BytesSent,
+			BytesSent:       hostCounters.BytesReceived,
+			PacketsReceived: hostCounters.PacketsSent,
+			PacketsSent:     hostCounters.PacketsReceived,
+		}
+	}
+
+	return counters, nil
+}
+
+// instanceNetworkUsageUpdate accounts the network transfer of the running instances on this member in the
+// current monthly period.
+func instanceNetworkUsageUpdate(ctx context.Context, s *state.State) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	type instanceUsage struct {
+		projectName  string
+		instanceID   int
+		instanceName string
+		counters     map[string]api.NetworkUsageCounters
+	}
+
+	period := time.Now().UTC().Format(instanceNetworkUsagePeriodFormat)
+	usages := make([]instanceUsage, 0, len(instances))
+
+	for _, inst := range instances {
+		if inst.IsSnapshot() || !inst.IsRunning() {
+			continue
+		}
+
+		counters, err := instanceNetworkUsageCounters(inst)
+		if err != nil {
+			logger.Warn("Failed getting instance network usage", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			continue
+		}
+
+		usages = append(usages, instanceUsage{projectName: inst.Project().Name, instanceID: inst.ID(), instanceName: inst.Name(), counters: counters})
+	}
+
+	if len(usages) == 0 {
+		return nil
+	}
+
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, usage := range usages {
+			err := tx.UpdateInstanceNetworkUsage(ctx, usage.projectName, usage.instanceID, usage.instanceName, period, usage.counters)
+			if err != nil {
+				return fmt.Errorf("Failed updating network usage of instance %q in project %q: %w", usage.instanceName, usage.projectName, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func instanceNetworkUsageTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instanceNetworkUsageUpdate(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed updating instance network usage", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(5 * time.Minute)
+}
@@ -6,17 +6,22 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"os/exec"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 
 	"github.com/lxc/incus/v6/client"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
@@ -24,6 +29,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/scriptlet"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
 	apiScriptlet "github.com/lxc/incus/v6/shared/api/scriptlet"
@@ -163,6 +169,17 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		req.Name = ""
 	}
 
+	// Migration tokens can only be used to push the instance to another server.
+	if req.TargetToken != "" {
+		if !req.Migration {
+			return response.BadRequest(fmt.Errorf("Migration tokens can only be used for migrations"))
+		}
+
+		if req.Project != "" || req.Target != nil || target != "" {
+			return response.BadRequest(fmt.Errorf("Migration tokens can't be combined with a target project, server or cluster member"))
+		}
+	}
+
 	// Validate the new target project (if provided).
 	if req.Project != "" {
 		// Confirm access to target project.
@@ -182,16 +199,18 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 			return response.BadRequest(err)
 		}
 
-		// Check that the new isn't already in use.
-		var id int
-		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
-			// Check that the name isn't already in use.
-			id, _ = tx.GetInstanceID(ctx, instProject, req.Name)
+		// Check that the new isn't already in use (the target server checks it when using a migration token).
+		if req.TargetToken == "" {
+			var id int
+			err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+				// Check that the name isn't already in use.
+				id, _ = tx.GetInstanceID(ctx, instProject, req.Name)
 
-			return nil
-		})
-		if id > 0 {
-			return response.Conflict(fmt.Errorf("Name %q already in use", req.Name))
+				return nil
+			})
+			if id > 0 {
+				return response.Conflict(fmt.Errorf("Name %q already in use", req.Name))
+			}
 		}
 	}
 
@@ -223,6 +242,11 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(fmt.Errorf("Instance snapshots cannot be moved on their own"))
 	}
 
+	// Push the instance to the server which issued the migration token.
+	if req.TargetToken != "" {
+		return instancePostMigrationToken(s, r, inst, req)
+	}
+
 	// Checks for running instances.
 	if inst.IsRunning() && (req.Pool != "" || req.Project != "" || target != "") {
 		// Stateless migrations need the instance stopped.
@@ -414,6 +438,8 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	ws.liveMigration = req.LiveMigration
+
 	resources := map[string][]api.URL{}
 	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", name)}
 	run := func(op *operations.Operation) error {
@@ -444,6 +470,132 @@ func instancePost(d *Daemon, r *http.Request) response.Response {
 	return operations.OperationResponse(op)
 }
 
+// instancePostMigrationToken pushes an instance to the server which issued the migration token.
+func instancePostMigrationToken(s *state.State, r *http.Request, inst instance.Instance, req api.InstancePost) response.Response {
+	token, err := internalUtil.MigrationTokenDecode(req.TargetToken)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid migration token: %w", err))
+	}
+
+	name := inst.Name()
+	if req.Name != "" {
+		name = req.Name
+	}
+
+	if token.Name != "" && token.Name != name {
+		return response.BadRequest(fmt.Errorf("Migration token is restricted to instance %q", token.Name))
+	}
+
+	// Stateless migrations need the instance stopped.
+	if inst.IsRunning() && !req.Live {
+		return response.BadRequest(fmt.Errorf("Instance must be stopped to be moved statelessly"))
+	} else if !inst.IsRunning() {
+		req.Live = false
+	}
+
+	// Get the current instance info.
+	instInfoRaw, _, err := inst.Render()
+	if err != nil {
+		return response.InternalError(fmt.Errorf("Failed getting source instance info: %w", err))
+	}
+
+	targetInstInfo, ok := instInfoRaw.(*api.Instance)
+	if !ok {
+		return response.InternalError(fmt.Errorf("Unexpected result from source instance render"))
+	}
+
+	// Apply the config overrides.
+	for k, v := range req.Config {
+		targetInstInfo.Config[k] = v
+	}
+
+	// Apply the device overrides.
+	for k, v := range req.Devices {
+		targetInstInfo.Devices[k] = v
+	}
+
+	// Apply the profile overrides.
+	if req.Profiles != nil {
+		targetInstInfo.Profiles = req.Profiles
+	}
+
+	// Handle storage pool override.
+	if req.Pool != "" {
+		rootDevKey, rootDev, err := internalInstance.GetRootDiskDevice(inst.ExpandedDevices().CloneNative())
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		rootDev["pool"] = req.Pool
+		targetInstInfo.Devices[rootDevKey] = rootDev
+	}
+
+	// Connect to the target server, trusting the certificate from the migration token.
+	target, targetCert, targetAddress, err := migrationTokenConnect(s, token)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Create the instance on the target server.
+	createReq := api.MigrationTokenInstancesPost{
+		InstancesPost: api.InstancesPost{
+			Name:        name,
+			InstancePut: targetInstInfo.Writable(),
+			Type:        api.InstanceType(targetInstInfo.Type),
+			Source: api.InstanceSource{
+				Type:              "migration",
+				Mode:              "push",
+				BaseImage:         targetInstInfo.Config["volatile.base_image"],
+				Live:              req.Live,
+				InstanceOnly:      req.InstanceOnly,
+				AllowInconsistent: req.AllowInconsistent,
+			},
+		},
+		Secret: token.Secret,
+	}
+
+	targetOp, err := target.CreateInstanceFromMigrationToken(createReq)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating instance on target server: %w", err))
+	}
+
+	targetOpAPI := targetOp.Get()
+
+	targetSecrets := map[string]string{}
+	for k, v := range targetOpAPI.Metadata {
+		secret, ok := v.(string)
+		if ok {
+			targetSecrets[k] = secret
+		}
+	}
+
+	// Push the instance.
+	ws, err := newMigrationSource(inst, req.Live, req.InstanceOnly, req.AllowInconsistent, "", &api.InstancePostTarget{
+		Certificate: targetCert,
+		Operation:   fmt.Sprintf("https://%s/%s/operations/%s", targetAddress, version.APIVersion, url.PathEscape(targetOpAPI.ID)),
+		Websockets:  targetSecrets,
+	})
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	ws.liveMigration = req.LiveMigration
+
+	run := func(op *operations.Operation) error {
+		return ws.Do(s, op)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+	op, err := operations.OperationCreate(s, inst.Project().Name, operations.OperationClassTask, operationtype.InstanceMigrate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
 // Perform the server-side migration.
 func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance, req api.InstancePost, sourceMemberInfo *db.NodeInfo, targetMemberInfo *db.NodeInfo, op *operations.Operation) error {
 	// Load the instance storage pool.
@@ -710,6 +862,39 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 
 	// Handle remote migrations (location changes).
 	if targetMemberInfo != nil && inst.Location() != targetMemberInfo.Name {
+		revert := revert.New()
+		defer revert.Fail()
+
+		// Containers can't be live migrated without CRIU, stop them and start them back up on the target instead.
+		restart := false
+		if req.Live && inst.Type() == instancetype.Container {
+			_, err := exec.LookPath("criu")
+			if err != nil {
+				l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+				l.Warn("CRIU isn't installed, falling back to restarting the instance on the target")
+
+				timeout, err := strconv.Atoi(inst.ExpandedConfig()["boot.host_shutdown_timeout"])
+				if err != nil {
+					timeout = evacuateHostShutdownDefaultTimeout
+				}
+
+				err = inst.Shutdown(time.Duration(timeout) * time.Second)
+				if err != nil {
+					l.Warn("Failed shutting down instance, forcing stop", logger.Ctx{"err": err})
+
+					err = inst.Stop(false)
+					if err != nil {
+						return fmt.Errorf("Failed stopping instance: %w", err)
+					}
+				}
+
+				revert.Add(func() { _ = inst.Start(false) })
+
+				req.Live = false
+				restart = true
+			}
+		}
+
 		// Get the client.
 		networkCert := s.Endpoints.NetworkCert()
 		target, err := cluster.Connect(targetMemberInfo.Address, networkCert, s.ServerCert(), nil, true)
@@ -751,6 +936,8 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 			return fmt.Errorf("Failed setting up instance migration on source: %w", err)
 		}
 
+		sourceMigration.liveMigration = req.LiveMigration
+
 		run := func(op *operations.Operation) error {
 			return sourceMigration.Do(s, op)
 		}
@@ -881,6 +1068,21 @@ func migrateInstance(ctx context.Context, s *state.State, inst instance.Instance
 				return fmt.Errorf("Failed deleting instance on source member: %w", err)
 			}
 		}
+
+		revert.Success()
+
+		// Start the instance back up on the target.
+		if restart {
+			startOp, err := target.UpdateInstanceState(inst.Name(), api.InstanceStatePut{Action: "start"}, "")
+			if err != nil {
+				return fmt.Errorf("Failed starting instance on destination: %w", err)
+			}
+
+			err = startOp.Wait()
+			if err != nil {
+				return fmt.Errorf("Failed starting instance on destination: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// instanceProbeKinds are the probes which can be configured on an instance.
+var instanceProbeKinds = []string{"readiness", "liveness"}
+
+// instanceProbe tracks the state of a probe of a running instance.
+type instanceProbe struct {
+	api.InstanceStateProbe
+
+	successes int64
+	running   bool
+}
+
+// instanceProbes tracks the probes of the running instances of this member, keyed by instance ID and probe kind.
+var instanceProbes = map[string]*instanceProbe{}
+var instanceProbesLock sync.Mutex
+
+// instanceProbeKey returns the key of the probe of the instance in instanceProbes.
+func instanceProbeKey(inst instance.Instance, kind string) string {
+	return fmt.Sprintf("%d/%s", inst.ID(), kind)
+}
+
+// instanceProbeSetting returns the integer setting of the probe of the instance, or its default value.
+func instanceProbeSetting(inst instance.Instance, kind string, key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(inst.ExpandedConfig()["probe."+kind+"."+key], 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// instanceProbesRender returns the state of the probes of the instance, nil if it doesn't have any.
+func instanceProbesRender(inst instance.Instance) map[string]api.InstanceStateProbe {
+	instanceProbesLock.Lock()
+	defer instanceProbesLock.Unlock()
+
+	var probes map[string]api.InstanceStateProbe
+	for _, kind := range instanceProbeKinds {
+		if inst.ExpandedConfig()["probe."+kind] == "" {
+			continue
+		}
+
+		if probes == nil {
+			probes = map[string]api.InstanceStateProbe{}
+		}
+
+		probe, ok := instanceProbes[instanceProbeKey(inst, kind)]
+		if ok {
+			probes[kind] = probe.InstanceStateProbe
+		} else {
+			probes[kind] = api.InstanceStateProbe{}
+		}
+	}
+
+	return probes
+}
+
+// instanceProbeRun runs the probe of the instance, returning an error if it fails.
+func instanceProbeRun(inst instance.Instance, probe string, timeout time.Duration) error {
+	if strings.HasPrefix(probe, "tcp:") {
+		conn, err := net.DialTimeout("tcp", strings.TrimPrefix(probe, "tcp:"), timeout)
+		if err != nil {
+			return err
+		}
+
+		_ = conn.Close()
+
+		return nil
+	}
+
+	if strings.HasPrefix(probe, "http://") || strings.HasPrefix(probe, "https://") {
+		// The probe only checks that the service answers, not who it is.
+		client := &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+
+		resp, err := client.Get(probe)
+		if err != nil {
+			return err
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("Unexpected HTTP status %q", resp.Status)
+		}
+
+		return nil
+	}
+
+	post := api.InstanceExecPost{
+		Command: []string{"/bin/sh", "-c", strings.TrimPrefix(probe, "exec:")},
+	}
+
+	instanceExecEnvironment(inst, &post)
+
+	err := internalInstance.ExecCommandAllowed(inst.ExpandedConfig(), post.Command)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := inst.Exec(post, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	timer := time.AfterFunc(timeout, func() { _ = cmd.Signal(unix.SIGKILL) })
+	exitStatus, err := cmd.Wait()
+	timedOut := !timer.Stop()
+	if err != nil {
+		return err
+	}
+
+	if timedOut {
+		return fmt.Errorf("Command timed out after %s", timeout)
+	}
+
+	if exitStatus != 0 {
+		return fmt.Errorf("Command exited with status %d", exitStatus)
+	}
+
+	return nil
+}
+
+// instanceProbeRecord records the result of a run of the probe of the instance, acting on the probe starting to
+// pass or to fail. Readiness probes drive the ready state of the instance while failing liveness probes get the
+// instance restarted.
+func instanceProbeRecord(s *state.State, inst instance.Instance, kind string, probeErr error) {
+	instanceProbesLock.Lock()
+
+	probe, ok := instanceProbes[instanceProbeKey(inst, kind)]
+	if !ok {
+		instanceProbesLock.Unlock()
+		return
+	}
+
+	probe.running = false
+	probe.LastRun = time.Now().UTC()
+
+	changed := false
+	if probeErr == nil {
+		probe.successes++
+		probe.Failures = 0
+		probe.LastError = ""
+
+		if !probe.Passing && probe.successes >= instanceProbeSetting(inst, kind, "success_threshold", 1) {
+			probe.Passing = true
+			changed = true
+		}
+	} else {
+		probe.successes = 0
+		probe.Failures++
+		probe.LastError = probeErr.Error()
+
+		if probe.Passing && probe.Failures >= instanceProbeSetting(inst, kind, "failure_threshold", 3) {
+			probe.Passing = false
+			changed = true
+		}
+	}
+
+	passing := probe.Passing
+
+	// Start over once the instance gets restarted.
+	if changed && !passing && kind == "liveness" {
+		delete(instanceProbes, instanceProbeKey(inst, kind))
+	}
+
+	instanceProbesLock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "probe": kind})
+
+	if !passing {
+		l.Warn("Instance probe failing", logger.Ctx{"err": probeErr})
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceProbeFailed.Event(inst, map[string]any{"probe": kind, "error": probeErr.Error()}))
+	}
+
+	if kind == "readiness" {
+		err := inst.VolatileSet(map[string]string{"volatile.last_state.ready": strconv.FormatBool(passing)})
+		if err != nil {
+			l.Error("Failed recording instance ready state", logger.Ctx{"err": err})
+			return
+		}
+
+		if passing {
+			s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceReady.Event(inst, nil))
+		}
+	} else if !passing {
+		l.Warn("Restarting instance as its liveness probe is failing")
+
+		err := inst.Restart(30 * time.Second)
+		if err != nil {
+			l.Error("Failed restarting instance", logger.Ctx{"err": err})
+		}
+	}
+}
+
+// instancesProbesUpdate runs the probes of the running instances of this member that are due.
+func instancesProbesUpdate(s *state.State) error {
+	insts, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	running := []instance.Instance{}
+	for _, inst := range insts {
+		if inst.IsRunning() && !inst.IsFrozen() {
+			running = append(running, inst)
+		}
+	}
+
+	instanceProbesLock.Lock()
+	defer instanceProbesLock.Unlock()
+
+	active := map[string]bool{}
+	for _, inst := range running {
+		inst := inst
+
+		for _, kind := range instanceProbeKinds {
+			kind := kind
+
+			probeSpec := inst.ExpandedConfig()["probe."+kind]
+			if probeSpec == "" {
+				continue
+			}
+
+			key := instanceProbeKey(inst, kind)
+			active[key] = true
+
+			probe, ok := instanceProbes[key]
+			if !ok {
+				probe = &instanceProbe{}
+				instanceProbes[key] = probe
+			}
+
+			interval := time.Duration(instanceProbeSetting(inst, kind, "interval", 10)) * time.Second
+			if probe.running || time.Since(probe.LastRun) < interval {
+				continue
+			}
+
+			probe.running = true
+			timeout := time.Duration(instanceProbeSetting(inst, kind, "timeout", 5)) * time.Second
+
+			go func() {
+				err := instanceProbeRun(inst, probeSpec, timeout)
+				instanceProbeRecord(s, inst, kind, err)
+			}()
+		}
+	}
+
+	// Forget about the probes of the instances which stopped or lost their probes.
+	for key, probe := range instanceProbes {
+		if !active[key] && !probe.running {
+			delete(instanceProbes, key)
+		}
+	}
+
+	return nil
+}
+
+func instancesProbesTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instancesProbesUpdate(d.State())
+		if err != nil {
+			logger.Error("Failed running instance probes", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(5 * time.Second)
+}
@@ -167,6 +167,8 @@ func instancePut(d *Daemon, r *http.Request) response.Response {
 				Project:      projectName,
 			}
 
+			inst.SetOperation(op)
+
 			err = inst.Update(args, true)
 			if err != nil {
 				return err
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// instanceSessionsMu protects the session counts.
+var instanceSessionsMu sync.Mutex
+
+// instanceSessionsPerIdentity counts the active exec and console sessions per identity.
+var instanceSessionsPerIdentity = map[string]int64{}
+
+// instanceSessionsPerInstance counts the active exec and console sessions per instance.
+var instanceSessionsPerInstance = map[string]int64{}
+
+// instanceSessionsRejected counts the sessions rejected because of the session limits.
+var instanceSessionsRejected atomic.Int64
+
+// instanceSessionsIdleClosed counts the sessions closed because of the idle timeout.
+var instanceSessionsIdleClosed atomic.Int64
+
+// instanceSession is an exec or console websocket session, counted against the session limits.
+type instanceSession struct {
+	identity string
+	instance string
+
+	lastActivity atomic.Int64
+	idle         chan struct{}
+	done         chan struct{}
+	endOnce      sync.Once
+}
+
+// instanceSessionStart registers a new exec or console session for the requestor on the instance.
+// An error is returned if this would exceed one of the session limits.
+// If an idle timeout is configured, the channel returned by Idle gets closed once the session is inactive for too long.
+func instanceSessionStart(s *state.State, r *http.Request, inst instance.Instance) (*instanceSession, error) {
+	requestor := request.CreateRequestor(r)
+
+	session := &instanceSession{
+		identity: requestor.Protocol + "/" + requestor.Username,
+		instance: inst.Project().Name + "/" + inst.Name(),
+		idle:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	maxPerIdentity, maxPerInstance := s.GlobalConfig.InstancesSessionLimits()
+
+	instanceSessionsMu.Lock()
+	defer instanceSessionsMu.Unlock()
+
+	if maxPerIdentity > 0 && instanceSessionsPerIdentity[session.identity] >= maxPerIdentity {
+		instanceSessionsRejected.Add(1)
+		return nil, api.StatusErrorf(http.StatusTooManyRequests, "Too many concurrent sessions for this identity (limit is %d)", maxPerIdentity)
+	}
+
+	if maxPerInstance > 0 && instanceSessionsPerInstance[session.instance] >= maxPerInstance {
+		instanceSessionsRejected.Add(1)
+		return nil, api.StatusErrorf(http.StatusTooManyRequests, "Too many concurrent sessions for this instance (limit is %d)", maxPerInstance)
+	}
+
+	instanceSessionsPerIdentity[session.identity]++
+	instanceSessionsPerInstance[session.instance]++
+
+	session.Touch()
+
+	idleTimeout := s.GlobalConfig.InstancesSessionIdleTimeout()
+	if idleTimeout > 0 {
+		go session.watch(idleTimeout)
+	}
+
+	return session, nil
+}
+
+// instanceSessionsActive returns the number of active exec and console sessions.
+func instanceSessionsActive() int64 {
+	instanceSessionsMu.Lock()
+	defer instanceSessionsMu.Unlock()
+
+	var total int64
+	for _, count := range instanceSessionsPerInstance {
+		total += count
+	}
+
+	return total
+}
+
+// instanceSessionCloseIdle notifies the client that the session is being closed because it was idle for too long.
+func instanceSessionCloseIdle(conn *websocket.Conn) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "Session idle timeout reached"), time.Now().Add(5*time.Second))
+}
+
+// watch closes the idle channel once the session has been inactive for longer than the timeout.
+func (s *instanceSession) watch(timeout time.Duration) {
+	ticker := time.NewTicker(min(timeout/4, 10*time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		if time.Since(time.Unix(0, s.lastActivity.Load())) < timeout {
+			continue
+		}
+
+		instanceSessionsIdleClosed.Add(1)
+		close(s.idle)
+		return
+	}
+}
+
+// Touch records activity on the session.
+func (s *instanceSession) Touch() {
+	s.lastActivity.Store(time.Now().UnixNano())
+}
+
+// Idle returns a channel which gets closed once the session has been inactive for longer than the idle timeout.
+func (s *instanceSession) Idle() <-chan struct{} {
+	return s.idle
+}
+
+// End unregisters the session, it is safe to call multiple times.
+func (s *instanceSession) End() {
+	s.endOnce.Do(func() {
+		close(s.done)
+
+		instanceSessionsMu.Lock()
+		defer instanceSessionsMu.Unlock()
+
+		instanceSessionsPerIdentity[s.identity]--
+		if instanceSessionsPerIdentity[s.identity] <= 0 {
+			delete(instanceSessionsPerIdentity, s.identity)
+		}
+
+		instanceSessionsPerInstance[s.instance]--
+		if instanceSessionsPerInstance[s.instance] <= 0 {
+			delete(instanceSessionsPerInstance, s.instance)
+		}
+	})
+}
+
+// Reader returns a reader recording activity on the session whenever data is read.
+func (s *instanceSession) Reader(r io.Reader) io.Reader {
+	return &instanceSessionReader{Reader: r, session: s}
+}
+
+// Writer returns a writer recording activity on the session whenever data is written.
+func (s *instanceSession) Writer(w io.Writer) io.Writer {
+	return &instanceSessionWriter{Writer: w, session: s}
+}
+
+// ReadWriteCloser returns a ReadWriteCloser recording activity on the session whenever data is read or written.
+func (s *instanceSession) ReadWriteCloser(rwc io.ReadWriteCloser) io.ReadWriteCloser {
+	return &instanceSessionReadWriteCloser{ReadWriteCloser: rwc, session: s}
+}
+
+type instanceSessionReader struct {
+	io.Reader
+
+	session *instanceSession
+}
+
+func (r *instanceSessionReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.session.Touch()
+	}
+
+	return n, err
+}
+
+type instanceSessionWriter struct {
+	io.Writer
+
+	session *instanceSession
+}
+
+func (w *instanceSessionWriter) Write(p []byte) (int, error) {
+	w.session.Touch()
+	return w.Writer.Write(p)
+}
+
+type instanceSessionReadWriteCloser struct {
+	io.ReadWriteCloser
+
+	session *instanceSession
+}
+
+func (rwc *instanceSessionReadWriteCloser) Read(p []byte) (int, error) {
+	n, err := rwc.ReadWriteCloser.Read(p)
+	if n > 0 {
+		rwc.session.Touch()
+	}
+
+	return n, err
+}
+
+func (rwc *instanceSessionReadWriteCloser) Write(p []byte) (int, error) {
+	rwc.session.Touch()
+	return rwc.ReadWriteCloser.Write(p)
+}
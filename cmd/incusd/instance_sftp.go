@@ -13,7 +13,12 @@ import (
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/events"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/shared/api"
@@ -26,6 +31,7 @@ import (
 //	Get the instance SFTP connection
 //
 //	Upgrades the request to an SFTP connection of the instance's filesystem.
+//	Requires the project to allow SFTP access (`restricted.sftp`).
 //
 //	---
 //	produces:
@@ -59,6 +65,28 @@ func instanceSFTPHandler(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(api.StatusErrorf(http.StatusBadRequest, "Missing or invalid upgrade header"))
 	}
 
+	// Check project restrictions.
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		return project.AllowSFTP(p)
+	})
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return response.SmartError(err)
+		}
+
+		return response.Forbidden(err)
+	}
+
 	// Redirect to correct server if needed.
 	instanceType, err := urlInstanceTypeDetect(r)
 	if err != nil {
@@ -92,6 +120,11 @@ func instanceSFTPHandler(d *Daemon, r *http.Request) response.Response {
 		if err != nil {
 			return response.SmartError(api.StatusErrorf(http.StatusInternalServerError, "Failed getting instance SFTP connection: %v", err))
 		}
+
+		// Only the member running the instance records the session so it's never reported twice.
+		resp.inst = inst
+		resp.events = s.Events
+		resp.requestor = request.CreateRequestor(r)
 	}
 
 	return resp
@@ -102,6 +135,11 @@ type sftpServeResponse struct {
 	projectName string
 	instName    string
 	instConn    net.Conn
+
+	// Set when the instance is local and the session should be recorded.
+	inst      instance.Instance
+	events    *events.Server
+	requestor *api.EventLifecycleRequestor
 }
 
 func (r *sftpServeResponse) String() string {
@@ -142,6 +180,12 @@ func (r *sftpServeResponse) Render(w http.ResponseWriter) error {
 		return api.StatusErrorf(http.StatusInternalServerError, err.Error())
 	}
 
+	if r.inst != nil {
+		eventCtx := map[string]any{"remote": remoteConn.RemoteAddr().String()}
+		r.events.SendLifecycle(r.projectName, lifecycle.InstanceSFTPConnected.Event(r.inst, r.requestor, eventCtx))
+		defer r.events.SendLifecycle(r.projectName, lifecycle.InstanceSFTPDisconnected.Event(r.inst, r.requestor, eventCtx))
+	}
+
 	ctx, cancel := context.WithCancel(r.req.Context())
 	l := logger.AddContext(logger.Ctx{
 		"project":  r.projectName,
@@ -104,6 +104,8 @@ func instanceState(d *Daemon, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	state.Probes = instanceProbesRender(c)
+
 	return response.SyncResponse(true, state)
 }
 
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// instanceTasksRunning tracks the scheduled tasks being run, so that slow tasks don't pile up.
+var instanceTasksRunning = sync.Map{}
+
+// instanceTaskNames returns the names of the scheduled tasks of an instance.
+func instanceTaskNames(config map[string]string) []string {
+	names := []string{}
+	for k := range config {
+		if !strings.HasPrefix(k, "tasks.") || !strings.HasSuffix(k, ".schedule") {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(k, "tasks."), ".schedule"))
+	}
+
+	return names
+}
+
+// instanceTaskRun runs a scheduled task of an instance, recording its output and status.
+func instanceTaskRun(inst instance.Instance, taskName string, op *operations.Operation) error {
+	config := inst.ExpandedConfig()
+	prefix := "tasks." + taskName + "."
+
+	post := api.InstanceExecPost{
+		Command: []string{"/bin/sh", "-c", config[prefix+"command"]},
+		Cwd:     config[prefix+"cwd"],
+	}
+
+	if config[prefix+"user"] != "" {
+		user, err := strconv.ParseUint(config[prefix+"user"], 10, 32)
+		if err != nil {
+			return err
+		}
+
+		post.User = uint32(user)
+	}
+
+	if config[prefix+"group"] != "" {
+		group, err := strconv.ParseUint(config[prefix+"group"], 10, 32)
+		if err != nil {
+			return err
+		}
+
+		post.Group = uint32(group)
+	}
+
+	instanceExecEnvironment(inst, &post)
+
+	exitStatus := -1
+	output := ""
+
+	runErr := func() error {
+		err := internalInstance.ExecCommandAllowed(config, post.Command)
+		if err != nil {
+			return err
+		}
+
+		// Ensure exec-output directory exists.
+		execOutputDir := inst.ExecOutputPath()
+		err = os.Mkdir(execOutputDir, 0600)
+		if err != nil && !errors.Is(err, fs.ErrExist) {
+			return err
+		}
+
+		// Prepare stdout and stderr recording.
+		stdout, err := os.OpenFile(filepath.Join(execOutputDir, fmt.Sprintf("exec_%s.stdout", op.ID())), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = stdout.Close() }()
+
+		stderr, err := os.OpenFile(filepath.Join(execOutputDir, fmt.Sprintf("exec_%s.stderr", op.ID())), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+		if err != nil {
+			return err
+		}
+
+		defer func() { _ = stderr.Close() }()
+
+		output = fmt.Sprintf("/%s/instances/%s/logs/exec-output/%s", version.APIVersion, inst.Name(), filepath.Base(stdout.Name()))
+
+		// Run the command.
+		cmd, err := inst.Exec(post, nil, stdout, stderr)
+		if err != nil {
+			return err
+		}
+
+		exitStatus, err = cmd.Wait()
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}()
+
+	err := inst.VolatileSet(map[string]string{
+		"volatile.tasks." + taskName + ".last_run":    time.Now().UTC().Format(time.RFC3339),
+		"volatile.tasks." + taskName + ".last_status": strconv.Itoa(exitStatus),
+		"volatile.tasks." + taskName + ".last_output": output,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed recording status of task %q: %w", taskName, err)
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("Failed running task %q: %w", taskName, runErr)
+	}
+
+	return nil
+}
+
+// instanceTasksTask runs the scheduled tasks of the running instances of this member (minutely check of their
+// cron expressions).
+func instanceTasksTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		insts, err := instance.LoadNodeAll(s, instancetype.Any)
+		if err != nil {
+			logger.Error("Failed loading instances for scheduled tasks", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, inst := range insts {
+			inst := inst
+
+			if !inst.IsRunning() || inst.IsFrozen() {
+				continue
+			}
+
+			for _, taskName := range instanceTaskNames(inst.ExpandedConfig()) {
+				taskName := taskName
+
+				if !snapshotIsScheduledNow(inst.ExpandedConfig()["tasks."+taskName+".schedule"], int64(inst.ID())) {
+					continue
+				}
+
+				key := fmt.Sprintf("%d/%s", inst.ID(), taskName)
+				_, loaded := instanceTasksRunning.LoadOrStore(key, struct{}{})
+				if loaded {
+					logger.Warn("Skipping scheduled task still running from its previous run", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "task": taskName})
+					continue
+				}
+
+				opRun := func(op *operations.Operation) error {
+					defer instanceTasksRunning.Delete(key)
+
+					return instanceTaskRun(inst, taskName, op)
+				}
+
+				resources := map[string][]api.URL{}
+				resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", inst.Name())}
+
+				op, err := operations.OperationCreate(s, inst.Project().Name, operations.OperationClassTask, operationtype.CommandExec, resources, nil, opRun, nil, nil, nil)
+				if err != nil {
+					instanceTasksRunning.Delete(key)
+					logger.Error("Failed creating scheduled task operation", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "task": taskName, "err": err})
+					continue
+				}
+
+				err = op.Start()
+				if err != nil {
+					instanceTasksRunning.Delete(key)
+					logger.Error("Failed starting scheduled task operation", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "task": taskName, "err": err})
+				}
+			}
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
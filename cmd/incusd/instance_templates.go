@@ -0,0 +1,720 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+var instanceTemplatesCmd = APIEndpoint{
+	Path: "instance-templates",
+
+	Get:  APIEndpointAction{Handler: instanceTemplatesGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView)},
+	Post: APIEndpointAction{Handler: instanceTemplatesPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+}
+
+var instanceTemplateCmd = APIEndpoint{
+	Path: "instance-templates/{name}",
+
+	Delete: APIEndpointAction{Handler: instanceTemplateDelete, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Get:    APIEndpointAction{Handler: instanceTemplateGet, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanView)},
+	Patch:  APIEndpointAction{Handler: instanceTemplatePut, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Post:   APIEndpointAction{Handler: instanceTemplatePost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+	Put:    APIEndpointAction{Handler: instanceTemplatePut, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+}
+
+// instanceTemplateValidateName checks the name of an instance template.
+func instanceTemplateValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("No name provided")
+	}
+
+	err := validate.IsURLSegmentSafe(name)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(name, " ") {
+		return fmt.Errorf("Instance template names may not contain spaces")
+	}
+
+	if strings.Contains(name, ":") {
+		return fmt.Errorf("Instance template names may not contain colons")
+	}
+
+	return nil
+}
+
+// instanceTemplateValidate checks the type, configuration and devices of an instance template.
+func instanceTemplateValidate(s *state.State, ctx context.Context, projectName string, info *api.InstanceTemplatePut) error {
+	instanceType := instancetype.Any
+	if info.Type != "" {
+		var err error
+
+		instanceType, err = instancetype.New(string(info.Type))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := instance.ValidConfig(s.OS, info.Config, false, instanceType)
+	if err != nil {
+		return err
+	}
+
+	var p *api.Project
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return instance.ValidDevices(s, *p, instanceType, deviceConfig.NewDevices(info.Devices), nil)
+}
+
+// instanceTemplateCapture returns the type, local configuration, local devices and profiles of an instance.
+// The volatile and image keys are left out as they are specific to the instance and its image.
+func instanceTemplateCapture(ctx context.Context, tx *db.ClusterTx, projectName string, instName string) (*api.InstanceTemplatePut, error) {
+	if internalInstance.IsSnapshot(instName) {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Instance templates can't be captured from snapshots")
+	}
+
+	inst, err := instance.LoadInstanceDatabaseObject(ctx, tx, projectName, instName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading instance %q: %w", instName, err)
+	}
+
+	instArgs, err := tx.InstancesToInstanceArgs(ctx, true, *inst)
+	if err != nil {
+		return nil, err
+	}
+
+	args := instArgs[inst.ID]
+
+	info := api.InstanceTemplatePut{
+		Type:     api.InstanceType(args.Type.String()),
+		Config:   map[string]string{},
+		Devices:  args.Devices.CloneNative(),
+		Profiles: make([]string, 0, len(args.Profiles)),
+	}
+
+	for key, value := range args.Config {
+		if strings.HasPrefix(key, internalInstance.ConfigVolatilePrefix) || strings.HasPrefix(key, "image.") {
+			continue
+		}
+
+		info.Config[key] = value
+	}
+
+	for _, profile := range args.Profiles {
+		info.Profiles = append(info.Profiles, profile.Name)
+	}
+
+	return &info, nil
+}
+
+// instanceTemplateApply fills the type, configuration, devices and profiles of a new instance request from
+// the instance template it references. Values set in the request take precedence over those of the template.
+func instanceTemplateApply(s *state.State, ctx context.Context, projectName string, req *api.InstancesPost) error {
+	if req.Source.Type == "copy" || req.Source.Type == "migration" {
+		return api.StatusErrorf(http.StatusBadRequest, "Instance templates can only be used when creating instances from an image or without a source")
+	}
+
+	var template *api.InstanceTemplate
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		_, template, err = tx.GetInstanceTemplate(ctx, projectName, req.Template)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading instance template %q: %w", req.Template, err)
+	}
+
+	if template.Type != "" {
+		if req.Type != "" && req.Type != template.Type {
+			return api.StatusErrorf(http.StatusBadRequest, "Instance template %q can only be used for %q instances", template.Name, template.Type)
+		}
+
+		req.Type = template.Type
+	}
+
+	if req.Config == nil {
+		req.Config = map[string]string{}
+	}
+
+	for key, value := range template.Config {
+		_, found := req.Config[key]
+		if !found {
+			req.Config[key] = value
+		}
+	}
+
+	if req.Devices == nil {
+		req.Devices = map[string]map[string]string{}
+	}
+
+	for name, device := range template.Devices {
+		_, found := req.Devices[name]
+		if !found {
+			req.Devices[name] = maps.Clone(device)
+		}
+	}
+
+	if req.Profiles == nil && template.Profiles != nil {
+		req.Profiles = append([]string{}, template.Profiles...)
+	}
+
+	return nil
+}
+
+// instanceTemplateLoad loads the instance template from the request.
+func instanceTemplateLoad(s *state.State, r *http.Request) (int64, *api.InstanceTemplate, error) {
+	projectName := request.ProjectParam(r)
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return -1, nil, err
+	}
+
+	var id int64
+	var template *api.InstanceTemplate
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, template, err = tx.GetInstanceTemplate(ctx, projectName, name)
+
+		return err
+	})
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return id, template, nil
+}
+
+// API endpoints
+
+// swagger:operation GET /1.0/instance-templates instance-templates instance_templates_get
+//
+//  Get the instance templates
+//
+//  Returns a list of instance templates (URLs).
+//
+//  ---
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//  responses:
+//    "200":
+//      description: API endpoints
+//      schema:
+//        type: object
+//        description: Sync response
+//        properties:
+//          type:
+//            type: string
+//            description: Response type
+//            example: sync
+//          status:
+//            type: string
+//            description: Status description
+//            example: Success
+//          status_code:
+//            type: integer
+//            description: Status code
+//            example: 200
+//          metadata:
+//            type: array
+//            description: List of endpoints
+//            items:
+//              type: string
+//            example: |-
+//              [
+//                "/1.0/instance-templates/web",
+//                "/1.0/instance-templates/db"
+//              ]
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/instance-templates?recursion=1 instance-templates instance_templates_get_recursion1
+//
+//	Get the instance templates
+//
+//	Returns a list of instance templates (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of instance templates
+//	          items:
+//	            $ref: "#/definitions/InstanceTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatesGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	var templates []api.InstanceTemplate
+
+	err := s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		templates, err = tx.GetInstanceTemplates(ctx, projectName)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading instance templates: %w", err))
+	}
+
+	if localUtil.IsRecursionRequest(r) {
+		return response.SyncResponse(true, templates)
+	}
+
+	templateURLs := make([]string, 0, len(templates))
+	for _, template := range templates {
+		templateURLs = append(templateURLs, api.NewURL().Path(version.APIVersion, "instance-templates", template.Name).String())
+	}
+
+	return response.SyncResponse(true, templateURLs)
+}
+
+// swagger:operation POST /1.0/instance-templates instance-templates instance_templates_post
+//
+//	Add an instance template
+//
+//	Creates a new instance template.
+//	If a source instance is provided, its type, configuration, devices and profiles are captured
+//	into the template, its root filesystem isn't.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: template
+//	    description: Instance template
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatesPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	req := api.InstanceTemplatesPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceTemplateValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Capture the source instance.
+	if req.Source != "" {
+		var captured *api.InstanceTemplatePut
+
+		err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+			captured, err = instanceTemplateCapture(ctx, tx, projectName, req.Source)
+
+			return err
+		})
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		if req.Description != "" {
+			captured.Description = req.Description
+		}
+
+		req.InstanceTemplatePut = *captured
+	}
+
+	err = instanceTemplateValidate(s, r.Context(), projectName, &req.InstanceTemplatePut)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, _, err := tx.GetInstanceTemplate(ctx, projectName, req.Name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Instance template %q already exists", req.Name)
+		}
+
+		_, err = tx.CreateInstanceTemplate(ctx, projectName, req.Name, &req.InstanceTemplatePut)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating instance template: %w", err))
+	}
+
+	lc := lifecycle.InstanceTemplateCreated.Event(req.Name, projectName, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(projectName, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/instance-templates/{name} instance-templates instance_template_delete
+//
+//	Delete the instance template
+//
+//	Removes the instance template, the instances created from it are left untouched.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplateDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, template, err := instanceTemplateLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.DeleteInstanceTemplate(ctx, id)
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed deleting instance template: %w", err))
+	}
+
+	s.Events.SendLifecycle(template.Project, lifecycle.InstanceTemplateDeleted.Event(template.Name, template.Project, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/instance-templates/{name} instance-templates instance_template_get
+//
+//	Get the instance template
+//
+//	Gets a specific instance template.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Instance template
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceTemplate"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplateGet(d *Daemon, r *http.Request) response.Response {
+	_, template, err := instanceTemplateLoad(d.State(), r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, template, template.Etag())
+}
+
+// swagger:operation PATCH /1.0/instance-templates/{name} instance-templates instance_template_patch
+//
+//  Partially update the instance template
+//
+//  Updates a subset of the instance template configuration.
+//
+//  ---
+//  consumes:
+//    - application/json
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//    - in: body
+//      name: template
+//      description: Instance template configuration
+//      required: true
+//      schema:
+//        $ref: "#/definitions/InstanceTemplatePut"
+//  responses:
+//    "200":
+//      $ref: "#/responses/EmptySyncResponse"
+//    "400":
+//      $ref: "#/responses/BadRequest"
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "412":
+//      $ref: "#/responses/PreconditionFailed"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation PUT /1.0/instance-templates/{name} instance-templates instance_template_put
+//
+//	Update the instance template
+//
+//	Updates the entire instance template configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: template
+//	    description: Instance template configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatePut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatePut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, template, err := instanceTemplateLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, template.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Start from the current values when partially updating the template.
+	req := api.InstanceTemplatePut{}
+	if r.Method == http.MethodPatch {
+		req = template.Writable()
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceTemplateValidate(s, r.Context(), template.Project, &req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpdateInstanceTemplate(ctx, id, &req)
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed updating instance template: %w", err))
+	}
+
+	s.Events.SendLifecycle(template.Project, lifecycle.InstanceTemplateUpdated.Event(template.Name, template.Project, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/instance-templates/{name} instance-templates instance_template_post
+//
+//	Rename the instance template
+//
+//	Renames an existing instance template.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: template
+//	    description: Instance template rename request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceTemplatePost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTemplatePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	id, template, err := instanceTemplateLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.InstanceTemplatePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instanceTemplateValidateName(req.Name)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		// Check that the name isn't already in use.
+		_, _, err := tx.GetInstanceTemplate(ctx, template.Project, req.Name)
+		if err == nil {
+			return api.StatusErrorf(http.StatusConflict, "Name %q already in use", req.Name)
+		}
+
+		return tx.RenameInstanceTemplate(ctx, id, req.Name)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	lc := lifecycle.InstanceTemplateRenamed.Event(req.Name, template.Project, request.CreateRequestor(r), logger.Ctx{"old_name": template.Name})
+	s.Events.SendLifecycle(template.Project, lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/response"
+)
+
+// swagger:operation GET /1.0/instances/{name}/tpm/{device} instances instance_tpm_get
+//
+//	Export the TPM state
+//
+//	Exports the state of a TPM device of a virtual machine, encrypted with the provided passphrase.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: header
+//	    name: X-Incus-passphrase
+//	    description: Passphrase used to encrypt the TPM state
+//	    type: string
+//	    required: true
+//	responses:
+//	  "200":
+//	    description: Encrypted TPM state
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTPMGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "TPM state export")
+	if resp != nil {
+		return resp
+	}
+
+	devName, err := url.PathUnescape(mux.Vars(r)["device"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	state, err := inst.TPMState(devName, r.Header.Get("X-Incus-passphrase"))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Filename:     fmt.Sprintf("%s.tpm", devName),
+		File:         bytes.NewReader(state),
+		FileModified: time.Now(),
+		FileSize:     int64(len(state)),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/tpm/{device} instances instance_tpm_put
+//
+//	Import the TPM state
+//
+//	Replaces the state of a TPM device of a stopped virtual machine with a previously exported one.
+//
+//	---
+//	consumes:
+//	  - application/octet-stream
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: header
+//	    name: X-Incus-passphrase
+//	    description: Passphrase the TPM state was encrypted with
+//	    type: string
+//	    required: true
+//	  - in: body
+//	    name: raw_file
+//	    description: Encrypted TPM state
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceTPMPut(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "TPM state import")
+	if resp != nil {
+		return resp
+	}
+
+	devName, err := url.PathUnescape(mux.Vars(r)["device"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// TPM states are only a few KiB, refuse anything unreasonably large.
+	data, err := io.ReadAll(io.LimitReader(r.Body, 64*1024*1024))
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = inst.TPMStateUpdate(devName, data, r.Header.Get("X-Incus-passphrase"))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
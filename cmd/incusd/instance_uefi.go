@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// swagger:operation GET /1.0/instances/{name}/nvram instances instance_nvram_get
+//
+//	Export the NVRAM
+//
+//	Exports the raw UEFI NVRAM (firmware variables store) of a virtual machine.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: Raw NVRAM file
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceNVRAMGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "NVRAM")
+	if resp != nil {
+		return resp
+	}
+
+	nvram, err := inst.NVRAM()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Filename:     "qemu.nvram",
+		File:         bytes.NewReader(nvram),
+		FileModified: time.Now(),
+		FileSize:     int64(len(nvram)),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/nvram instances instance_nvram_put
+//
+//	Import the NVRAM
+//
+//	Replaces the UEFI NVRAM of a stopped virtual machine with a previously exported one.
+//
+//	---
+//	consumes:
+//	  - application/octet-stream
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: raw_file
+//	    description: Raw NVRAM file
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceNVRAMPut(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "NVRAM")
+	if resp != nil {
+		return resp
+	}
+
+	// NVRAM files are only a few MiB, refuse anything unreasonably large.
+	data, err := io.ReadAll(io.LimitReader(r.Body, 64*1024*1024))
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = inst.NVRAMUpdate(data)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/instances/{name}/uefi-vars instances instance_uefi_vars_get
+//
+//	Get the UEFI variables
+//
+//	Gets the UEFI variables stored in the NVRAM of a virtual machine.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: UEFI variables
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceUEFIVars"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFIVarsGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "UEFI variables")
+	if resp != nil {
+		return resp
+	}
+
+	vars, err := inst.UEFIVars()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, vars)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/uefi-vars instances instance_uefi_vars_put
+//
+//	Update the UEFI variables
+//
+//	Replaces the UEFI variables stored in the NVRAM of a stopped virtual machine.
+//	Variables which aren't part of the request are removed.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: uefi-vars
+//	    description: UEFI variables
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUEFIVars"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFIVarsPut(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "UEFI variables")
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceUEFIVars{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = inst.UEFIVarsUpdate(req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation POST /1.0/instances/{name}/uefi-vars/certificates instances instance_uefi_certificates_post
+//
+//	Enroll a secure boot certificate
+//
+//	Adds an X.509 certificate to the db or KEK secure boot database of a stopped virtual machine.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: certificate
+//	    description: Certificate to enroll
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUEFICertificatesPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUEFICertificatesPost(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "UEFI variables")
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceUEFICertificatesPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	certBlock, _ := pem.Decode([]byte(req.Certificate))
+	if certBlock == nil {
+		return response.BadRequest(fmt.Errorf("Invalid PEM certificate"))
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("Invalid certificate: %w", err))
+	}
+
+	err = inst.UEFIEnrollCertificate(req.Database, cert)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
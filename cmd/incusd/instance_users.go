@@ -0,0 +1,427 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// swagger:operation GET /1.0/instances/{name}/users instances instance_users_get
+//
+//	Get the users
+//
+//	Returns a list of the users of a running virtual machine (URLs), as reported by its agent.
+//	Only root and the regular users are listed.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of endpoints
+//	          items:
+//	            type: string
+//	          example: |-
+//	            [
+//	              "/1.0/instances/foo/users/root",
+//	              "/1.0/instances/foo/users/alice"
+//	            ]
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/instances/{name}/users?recursion=1 instances instance_users_get_recursion1
+//
+//	Get the users
+//
+//	Returns a list of the users of a running virtual machine (structs), as reported by its agent.
+//	Only root and the regular users are listed.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of users
+//	          items:
+//	            $ref: "#/definitions/InstanceUser"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUsersGet(d *Daemon, r *http.Request) response.Response {
+	inst, resp := instanceVMLoad(d, r, "User management")
+	if resp != nil {
+		return resp
+	}
+
+	users, err := inst.Users()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if localUtil.IsRecursionRequest(r) {
+		return response.SyncResponse(true, users)
+	}
+
+	urls := []string{}
+	for _, user := range users {
+		urls = append(urls, api.NewURL().Path(version.APIVersion, "instances", inst.Name(), "users", user.Name).String())
+	}
+
+	return response.SyncResponse(true, urls)
+}
+
+// swagger:operation POST /1.0/instances/{name}/users instances instance_users_post
+//
+//	Create a user
+//
+//	Creates a user inside a running virtual machine through its agent, optionally setting its password,
+//	SSH keys and sudo access.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: user
+//	    description: User
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUsersPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUsersPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// Load the instance first as the request may need forwarding along with its body.
+	inst, resp := instanceVMLoad(d, r, "User management")
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceUsersPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Name == "" {
+		return response.BadRequest(fmt.Errorf("No user name provided"))
+	}
+
+	commands := []string{"useradd"}
+	if req.Password != "" {
+		commands = append(commands, "chpasswd")
+	}
+
+	resp = instanceUsersExecAllowed(s, r, inst, commands...)
+	if resp != nil {
+		return resp
+	}
+
+	err = inst.UserCreate(req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	event := lifecycle.InstanceUserCreated.Event(inst, logger.Ctx{"user": req.Name})
+	event.Requestor = request.CreateRequestor(r)
+	s.Events.SendLifecycle(inst.Project().Name, event)
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/instances/{name}/users/{user} instances instance_user_get
+//
+//	Get the user
+//
+//	Gets a specific user of a running virtual machine, as reported by its agent.
+//	The password of the user is never returned.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: User
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstanceUser"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUserGet(d *Daemon, r *http.Request) response.Response {
+	userName, err := url.PathUnescape(mux.Vars(r)["user"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, resp := instanceVMLoad(d, r, "User management")
+	if resp != nil {
+		return resp
+	}
+
+	user, err := inst.User(userName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, user)
+}
+
+// swagger:operation PUT /1.0/instances/{name}/users/{user} instances instance_user_put
+//
+//	Update the user
+//
+//	Sets the password (when provided), SSH keys and sudo access of a user of a running virtual machine
+//	through its agent. The provided SSH keys replace the existing ones.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: user
+//	    description: User configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstanceUserPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUserPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	userName, err := url.PathUnescape(mux.Vars(r)["user"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Load the instance first as the request may need forwarding along with its body.
+	inst, resp := instanceVMLoad(d, r, "User management")
+	if resp != nil {
+		return resp
+	}
+
+	req := api.InstanceUserPut{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Password != "" {
+		resp = instanceUsersExecAllowed(s, r, inst, "chpasswd")
+		if resp != nil {
+			return resp
+		}
+	}
+
+	err = inst.UserUpdate(userName, req)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	event := lifecycle.InstanceUserUpdated.Event(inst, logger.Ctx{"user": userName})
+	event.Requestor = request.CreateRequestor(r)
+	s.Events.SendLifecycle(inst.Project().Name, event)
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation DELETE /1.0/instances/{name}/users/{user} instances instance_user_delete
+//
+//	Delete the user
+//
+//	Deletes a user of a running virtual machine along with its home directory and sudo access.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instanceUserDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	userName, err := url.PathUnescape(mux.Vars(r)["user"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, resp := instanceVMLoad(d, r, "User management")
+	if resp != nil {
+		return resp
+	}
+
+	resp = instanceUsersExecAllowed(s, r, inst, "userdel")
+	if resp != nil {
+		return resp
+	}
+
+	err = inst.UserDelete(userName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	event := lifecycle.InstanceUserDeleted.Event(inst, logger.Ctx{"user": userName})
+	event.Requestor = request.CreateRequestor(r)
+	s.Events.SendLifecycle(inst.Project().Name, event)
+
+	return response.EmptySyncResponse
+}
+
+// instanceUsersExecAllowed applies the exec policy of the instance to the commands the agent runs to
+// manage the users.
+func instanceUsersExecAllowed(s *state.State, r *http.Request, inst instance.Instance, commands ...string) response.Response {
+	for _, command := range commands {
+		err := internalInstance.ExecCommandAllowed(inst.ExpandedConfig(), []string{command})
+		if err != nil {
+			event := lifecycle.InstanceExecDenied.Event(inst, logger.Ctx{"command": []string{command}})
+			event.Requestor = request.CreateRequestor(r)
+			s.Events.SendLifecycle(inst.Project().Name, event)
+
+			return response.Forbidden(err)
+		}
+	}
+
+	return nil
+}
@@ -3,21 +3,25 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	"github.com/lxc/incus/v6/internal/server/db/warningtype"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/internal/server/warnings"
@@ -36,6 +40,14 @@ var instancesCmd = APIEndpoint{
 	Put:  APIEndpointAction{Handler: instancesPut, AccessHandler: allowAuthenticated},
 }
 
+// Needs to be registered before instanceCmd so that it takes precedence.
+var instancesStateCmd = APIEndpoint{
+	Name: "instancesState",
+	Path: "instances/state",
+
+	Put: APIEndpointAction{Handler: instancesStatePut, AccessHandler: allowAuthenticated},
+}
+
 var instanceCmd = APIEndpoint{
 	Name: "instance",
 	Path: "instances/{name}",
@@ -62,6 +74,13 @@ var instanceStateCmd = APIEndpoint{
 	Put: APIEndpointAction{Handler: instanceStatePut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanUpdateState, "name")},
 }
 
+var instanceCaptureCmd = APIEndpoint{
+	Name: "instanceCapture",
+	Path: "instances/{name}/state/capture",
+
+	Post: APIEndpointAction{Handler: instanceCapturePost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanCaptureTraffic, "name")},
+}
+
 var instanceSFTPCmd = APIEndpoint{
 	Name: "instanceFile",
 	Path: "instances/{name}/sftp",
@@ -156,6 +175,90 @@ var instanceBackupExportCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: instanceBackupExportGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanManageBackups, "name")},
 }
 
+var instanceAttestationCmd = APIEndpoint{
+	Name: "instanceAttestation",
+	Path: "instances/{name}/attestation",
+
+	Get: APIEndpointAction{Handler: instanceAttestationGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceAttestationReportCmd = APIEndpoint{
+	Name: "instanceAttestationReport",
+	Path: "instances/{name}/attestation/report",
+
+	Get: APIEndpointAction{Handler: instanceAttestationReportGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceUsersCmd = APIEndpoint{
+	Name: "instanceUsers",
+	Path: "instances/{name}/users",
+
+	Get:  APIEndpointAction{Handler: instanceUsersGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Post: APIEndpointAction{Handler: instanceUsersPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanExec, "name")},
+}
+
+var instanceUserCmd = APIEndpoint{
+	Name: "instanceUser",
+	Path: "instances/{name}/users/{user}",
+
+	Get:    APIEndpointAction{Handler: instanceUserGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put:    APIEndpointAction{Handler: instanceUserPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanExec, "name")},
+	Delete: APIEndpointAction{Handler: instanceUserDelete, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanExec, "name")},
+}
+
+var instanceNVRAMCmd = APIEndpoint{
+	Name: "instanceNVRAM",
+	Path: "instances/{name}/nvram",
+
+	Get: APIEndpointAction{Handler: instanceNVRAMGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put: APIEndpointAction{Handler: instanceNVRAMPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceTPMCmd = APIEndpoint{
+	Name: "instanceTPM",
+	Path: "instances/{name}/tpm/{device}",
+
+	Get: APIEndpointAction{Handler: instanceTPMGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+	Put: APIEndpointAction{Handler: instanceTPMPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceUEFIVarsCmd = APIEndpoint{
+	Name: "instanceUEFIVars",
+	Path: "instances/{name}/uefi-vars",
+
+	Get: APIEndpointAction{Handler: instanceUEFIVarsGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put: APIEndpointAction{Handler: instanceUEFIVarsPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceUEFICertificatesCmd = APIEndpoint{
+	Name: "instanceUEFICertificates",
+	Path: "instances/{name}/uefi-vars/certificates",
+
+	Post: APIEndpointAction{Handler: instanceUEFICertificatesPost, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
+var instanceRenderedCloudInitCmd = APIEndpoint{
+	Name: "instanceRenderedCloudInit",
+	Path: "instances/{name}/rendered-cloud-init",
+
+	Get: APIEndpointAction{Handler: instanceRenderedCloudInitGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
+var instanceSyscallsCmd = APIEndpoint{
+	Name: "instanceSyscalls",
+	Path: "instances/{name}/syscalls",
+
+	Get: APIEndpointAction{Handler: instanceSyscallsGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+}
+
+var instanceIdmapCmd = APIEndpoint{
+	Name: "instanceIdmap",
+	Path: "instances/{name}/idmap",
+
+	Get: APIEndpointAction{Handler: instanceIdmapGet, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanView, "name")},
+	Put: APIEndpointAction{Handler: instanceIdmapPut, AccessHandler: allowPermission(auth.ObjectTypeInstance, auth.EntitlementCanEdit, "name")},
+}
+
 var instanceAccessCmd = APIEndpoint{
 	Name: "access",
 	Path: "instances/{name}/access",
@@ -198,6 +301,134 @@ func instanceShouldAutoStart(inst instance.Instance) bool {
 	return util.IsTrue(autoStart) || (autoStart == "" && lastState == instance.PowerStateRunning)
 }
 
+// instanceDependencies returns the instances among the given ones that the instance depends on to boot.
+func instanceDependencies(inst instance.Instance, instances []instance.Instance) []instance.Instance {
+	var dependencies []instance.Instance
+
+	for _, name := range util.SplitNTrimSpace(inst.ExpandedConfig()["boot.depends_on"], ",", -1, true) {
+		for _, dependency := range instances {
+			if dependency.Project().Name == inst.Project().Name && dependency.Name() == name {
+				dependencies = append(dependencies, dependency)
+				break
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// instancesDependencyOrder returns the instances with each of them moved after the instances it depends on, keeping
+// their order otherwise. Circular dependencies are ignored.
+func instancesDependencyOrder(instances []instance.Instance) []instance.Instance {
+	ordered := make([]instance.Instance, 0, len(instances))
+	visiting := map[int]bool{}
+	visited := map[int]bool{}
+
+	var visit func(inst instance.Instance)
+	visit = func(inst instance.Instance) {
+		if visited[inst.ID()] {
+			return
+		}
+
+		visiting[inst.ID()] = true
+
+		for _, dependency := range instanceDependencies(inst, instances) {
+			if visiting[dependency.ID()] {
+				logger.Warn("Ignoring circular boot dependency", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "dependency": dependency.Name()})
+				continue
+			}
+
+			visit(dependency)
+		}
+
+		visiting[inst.ID()] = false
+		visited[inst.ID()] = true
+		ordered = append(ordered, inst)
+	}
+
+	for _, inst := range instances {
+		visit(inst)
+	}
+
+	return ordered
+}
+
+// instanceReady returns whether the instance passes its readiness check.
+func instanceReady(s *state.State, inst instance.Instance) bool {
+	if !inst.IsRunning() {
+		return false
+	}
+
+	check := inst.ExpandedConfig()["boot.ready_check"]
+
+	switch {
+	case check == "" || check == "running":
+		return true
+	case check == "ready":
+		// Reload the instance to get its current ready state.
+		current, err := instance.LoadByProjectAndName(s, inst.Project().Name, inst.Name())
+		if err != nil {
+			return false
+		}
+
+		return util.IsTrue(current.LocalConfig()["volatile.last_state.ready"])
+	case check == "agent":
+		vm, ok := inst.(instance.VM)
+		if !ok {
+			return true
+		}
+
+		return vm.AgentRunning()
+	case strings.HasPrefix(check, "tcp:"):
+		conn, err := net.DialTimeout("tcp", strings.TrimPrefix(check, "tcp:"), time.Second)
+		if err != nil {
+			return false
+		}
+
+		_ = conn.Close()
+
+		return true
+	}
+
+	return false
+}
+
+// instanceWaitDependencies waits for the instances the instance depends on to be ready, each of them for at most its
+// readiness timeout.
+func instanceWaitDependencies(s *state.State, inst instance.Instance, instances []instance.Instance) {
+	for _, dependency := range instanceDependencies(inst, instances) {
+		depLogger := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "dependency": dependency.Name()})
+
+		if !dependency.IsRunning() {
+			depLogger.Warn("Boot dependency isn't running")
+			continue
+		}
+
+		timeout := 120 * time.Second
+		readyTimeout, err := strconv.Atoi(dependency.ExpandedConfig()["boot.ready_timeout"])
+		if err == nil {
+			timeout = time.Duration(readyTimeout) * time.Second
+		}
+
+		ctx, cancel := context.WithTimeout(s.ShutdownCtx, timeout)
+
+		ready := instanceReady(s, dependency)
+		for !ready && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+				ready = instanceReady(s, dependency)
+			}
+		}
+
+		cancel()
+
+		if !ready {
+			depLogger.Warn("Timed out waiting for boot dependency to be ready", logger.Ctx{"timeout": timeout})
+		}
+	}
+}
+
 func instancesStart(s *state.State, instances []instance.Instance) {
 	// Check if the cluster is currently evacuated.
 	if s.DB.Cluster.LocalNodeIsEvacuated() {
@@ -211,6 +442,9 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 	// Sort based on instance boot priority.
 	sort.Sort(instanceAutostartList(instances))
 
+	// Start the instances after the ones they depend on.
+	instances = instancesDependencyOrder(instances)
+
 	// Let's make up to 3 attempts to start instances.
 	maxAttempts := 3
 
@@ -225,6 +459,9 @@ func instancesStart(s *state.State, instances []instance.Instance) {
 			continue
 		}
 
+		// Wait for the instances it depends on to be ready.
+		instanceWaitDependencies(s, inst, instances)
+
 		// Get the instance config.
 		config := inst.ExpandedConfig()
 		autoStartDelay := config["boot.autostart.delay"]
@@ -385,11 +622,73 @@ func instancesOnDisk(s *state.State) ([]instance.Instance, error) {
 func instancesShutdown(s *state.State, instances []instance.Instance) {
 	sort.Sort(instanceStopList(instances))
 
+	// Only running instances need stopping.
+	running := make([]instance.Instance, 0, len(instances))
+	for _, inst := range instances {
+		if inst.IsRunning() {
+			running = append(running, inst)
+		}
+	}
+
+	if len(running) == 0 {
+		return
+	}
+
+	// Determine the deadline for all instances to shutdown cleanly, if any.
+	var deadline time.Time
+	if s.GlobalConfig != nil {
+		budget := s.GlobalConfig.InstancesHostShutdownTimeout()
+		if budget > 0 {
+			deadline = time.Now().Add(budget)
+		}
+	}
+
+	// Report the progress through an operation, if the database is still available.
+	var progressMu sync.Mutex
+	var op *operations.Operation
+	var instancesStopped int
+	progress := map[string]any{
+		"instances_total":   len(running),
+		"instances_stopped": 0,
+	}
+
+	opDone := make(chan struct{})
+	defer close(opDone)
+
+	if s.DB.Cluster != nil {
+		opRun := func(op *operations.Operation) error {
+			<-opDone
+			return nil
+		}
+
+		var err error
+		op, err = operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.InstancesShutdown, nil, progress, opRun, nil, nil, nil)
+		if err == nil {
+			err = op.Start()
+		}
+
+		if err != nil {
+			logger.Warn("Failed creating instances shutdown operation", logger.Ctx{"err": err})
+			op = nil
+		}
+	}
+
+	updateProgress := func(update func()) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+
+		update()
+
+		if op != nil {
+			_ = op.UpdateMetadata(progress)
+		}
+	}
+
 	// Limit shutdown concurrency to number of instances or number of CPU cores (which ever is less).
 	var wg sync.WaitGroup
 	instShutdownCh := make(chan instance.Instance)
 	maxConcurrent := runtime.NumCPU()
-	instCount := len(instances)
+	instCount := len(running)
 	if instCount < maxConcurrent {
 		maxConcurrent = instCount
 	}
@@ -404,19 +703,30 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 					timeoutSeconds, _ = strconv.Atoi(value)
 				}
 
+				timeout := time.Second * time.Duration(timeoutSeconds)
+
+				// Don't wait past the overall deadline.
+				if !deadline.IsZero() {
+					timeout = min(timeout, time.Until(deadline))
+				}
+
 				action := inst.ExpandedConfig()["boot.host_shutdown_action"]
 				if action == "stateful-stop" {
 					err := inst.Stop(true)
 					if err != nil {
 						logger.Warn("Failed statefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 					}
-				} else if action == "force-stop" {
+				} else if action == "force-stop" || timeout <= 0 {
+					if action != "force-stop" {
+						logger.Warn("Instances shutdown timeout exceeded, forcefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name()})
+					}
+
 					err := inst.Stop(false)
 					if err != nil {
 						logger.Warn("Failed forcefully stopping instance", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 					}
 				} else {
-					err := inst.Shutdown(time.Second * time.Duration(timeoutSeconds))
+					err := inst.Shutdown(timeout)
 					if err != nil {
 						logger.Warn("Failed shutting down instance, forcefully stopping", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
 						err = inst.Stop(false)
@@ -433,18 +743,18 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 					_ = inst.VolatileSet(map[string]string{"volatile.last_state.power": instance.PowerStateRunning})
 				}
 
+				updateProgress(func() {
+					instancesStopped++
+					progress["instances_stopped"] = instancesStopped
+				})
+
 				wg.Done()
 			}
 		}(instShutdownCh)
 	}
 
 	var currentBatchPriority int
-	for i, inst := range instances {
-		// Skip stopped instances.
-		if !inst.IsRunning() {
-			continue
-		}
-
+	for i, inst := range running {
 		priority, _ := strconv.Atoi(inst.ExpandedConfig()["boot.stop.priority"])
 
 		// Shutdown instances in priority batches, logging at the start of each batch.
@@ -454,6 +764,7 @@ func instancesShutdown(s *state.State, instances []instance.Instance) {
 			// Wait for instances with higher priority to finish before starting next batch.
 			wg.Wait()
 			logger.Info("Stopping instances", logger.Ctx{"stopPriority": currentBatchPriority})
+			updateProgress(func() { progress["stop_priority"] = currentBatchPriority })
 		}
 
 		wg.Add(1)
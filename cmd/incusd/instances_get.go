@@ -469,6 +469,10 @@ func doInstancesGet(s *state.State, r *http.Request) (any, error) {
 						if err != nil {
 							resultErrListAppend(dbInst, err)
 						} else {
+							if c.State != nil {
+								c.State.Probes = instanceProbesRender(inst)
+							}
+
 							resultFullListAppend(c)
 						}
 					}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// parseMemoryPressure returns the `some avg10` value of the PSI memory pressure information.
+func parseMemoryPressure(r io.Reader) (float64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			value, found := strings.CutPrefix(field, "avg10=")
+			if !found {
+				continue
+			}
+
+			return strconv.ParseFloat(value, 64)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("No memory pressure information found")
+}
+
+// memoryPressure returns the current host memory pressure.
+func memoryPressure() (float64, error) {
+	f, err := os.Open("/proc/pressure/memory")
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	return parseMemoryPressure(f)
+}
+
+// instanceFreezePriority returns the memory pressure freeze priority of an instance, or -1 if it can't be frozen.
+func instanceFreezePriority(inst instance.Instance) int {
+	value := inst.ExpandedConfig()["limits.memory.freeze_priority"]
+	if value == "" {
+		return -1
+	}
+
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+
+	return priority
+}
+
+// memoryPressureCandidates returns the running instances which can be frozen, lowest priority first, or the ones
+// frozen because of memory pressure which can be unfrozen, highest priority first.
+func memoryPressureCandidates(insts []instance.Instance, freeze bool) []instance.Instance {
+	candidates := []instance.Instance{}
+	for _, inst := range insts {
+		if !inst.IsRunning() {
+			continue
+		}
+
+		if freeze {
+			if instanceFreezePriority(inst) >= 0 && !inst.IsFrozen() {
+				candidates = append(candidates, inst)
+			}
+
+			continue
+		}
+
+		// Instances frozen because of memory pressure get unfrozen even if their priority changed since.
+		if util.IsTrue(inst.LocalConfig()["volatile.memory_pressure.frozen"]) && inst.IsFrozen() {
+			candidates = append(candidates, inst)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if freeze {
+			return instanceFreezePriority(candidates[i]) < instanceFreezePriority(candidates[j])
+		}
+
+		// Instances which can't be frozen anymore are unfrozen first.
+		priorityI := instanceFreezePriority(candidates[i])
+		if priorityI < 0 {
+			priorityI = math.MaxInt
+		}
+
+		priorityJ := instanceFreezePriority(candidates[j])
+		if priorityJ < 0 {
+			priorityJ = math.MaxInt
+		}
+
+		return priorityI > priorityJ
+	})
+
+	return candidates
+}
+
+// instancesMemoryPressureUpdate freezes a single instance if the host is under memory pressure, or unfreezes one
+// which was frozen because of it once the pressure subsided.
+func instancesMemoryPressureUpdate(s *state.State) error {
+	freezeThreshold, unfreezeThreshold := s.GlobalConfig.InstancesMemoryPressureThresholds()
+	if freezeThreshold == 0 {
+		return nil
+	}
+
+	pressure, err := memoryPressure()
+	if err != nil {
+		return fmt.Errorf("Failed getting host memory pressure: %w", err)
+	}
+
+	freeze := pressure >= float64(freezeThreshold)
+	if !freeze && pressure >= float64(unfreezeThreshold) {
+		return nil
+	}
+
+	insts, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	// Forget about instances which were stopped or unfrozen in the meantime.
+	for _, inst := range insts {
+		if util.IsTrue(inst.LocalConfig()["volatile.memory_pressure.frozen"]) && !inst.IsFrozen() {
+			err := inst.VolatileSet(map[string]string{"volatile.memory_pressure.frozen": ""})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	candidates := memoryPressureCandidates(insts, freeze)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	inst := candidates[0]
+	ctx := map[string]any{"pressure": pressure}
+	l := logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "pressure": pressure})
+
+	if freeze {
+		err = inst.VolatileSet(map[string]string{"volatile.memory_pressure.frozen": "true"})
+		if err != nil {
+			return err
+		}
+
+		err = inst.Freeze()
+		if err != nil {
+			_ = inst.VolatileSet(map[string]string{"volatile.memory_pressure.frozen": ""})
+			return fmt.Errorf("Failed freezing instance %q: %w", inst.Name(), err)
+		}
+
+		l.Warn("Froze instance because of host memory pressure")
+		s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceMemoryPressureFrozen.Event(inst, ctx))
+
+		return nil
+	}
+
+	err = inst.Unfreeze()
+	if err != nil {
+		return fmt.Errorf("Failed unfreezing instance %q: %w", inst.Name(), err)
+	}
+
+	err = inst.VolatileSet(map[string]string{"volatile.memory_pressure.frozen": ""})
+	if err != nil {
+		return err
+	}
+
+	l.Info("Unfroze instance as host memory pressure subsided")
+	s.Events.SendLifecycle(inst.Project().Name, lifecycle.InstanceMemoryPressureUnfrozen.Event(inst, ctx))
+
+	return nil
+}
+
+// instancesMemoryPressureTask freezes and unfreezes instances according to the host memory pressure.
+func instancesMemoryPressureTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := instancesMemoryPressureUpdate(d.State())
+		if err != nil {
+			logger.Error("Failed handling host memory pressure", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(10 * time.Second)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/instance"
+)
+
+func TestParseMemoryPressure(t *testing.T) {
+	pressure, err := parseMemoryPressure(strings.NewReader(`some avg10=12.34 avg60=5.00 avg300=1.00 total=123456
+full avg10=3.21 avg60=1.00 avg300=0.50 total=65432
+`))
+	require.NoError(t, err)
+	assert.InEpsilon(t, 12.34, pressure, 0.0001)
+
+	_, err = parseMemoryPressure(strings.NewReader("full avg10=3.21 avg60=1.00 avg300=0.50 total=65432\n"))
+	assert.Error(t, err)
+}
+
+// testPressureInstance is an instance only implementing what's needed to pick memory pressure candidates.
+type testPressureInstance struct {
+	instance.Instance
+
+	name     string
+	priority string
+	frozen   bool
+	frozenBy bool
+}
+
+func (i *testPressureInstance) Name() string { return i.name }
+
+func (i *testPressureInstance) IsRunning() bool { return true }
+
+func (i *testPressureInstance) IsFrozen() bool { return i.frozen }
+
+func (i *testPressureInstance) ExpandedConfig() map[string]string {
+	return map[string]string{"limits.memory.freeze_priority": i.priority}
+}
+
+func (i *testPressureInstance) LocalConfig() map[string]string {
+	return map[string]string{"volatile.memory_pressure.frozen": strconv.FormatBool(i.frozenBy)}
+}
+
+func TestMemoryPressureCandidates(t *testing.T) {
+	insts := []instance.Instance{
+		&testPressureInstance{name: "low", priority: "1"},
+		&testPressureInstance{name: "high", priority: "5"},
+		&testPressureInstance{name: "none"},
+		&testPressureInstance{name: "frozen-high", priority: "8", frozen: true, frozenBy: true},
+		&testPressureInstance{name: "frozen-low", priority: "2", frozen: true, frozenBy: true},
+		&testPressureInstance{name: "frozen-removed", frozen: true, frozenBy: true},
+		&testPressureInstance{name: "frozen-manually", priority: "3", frozen: true},
+	}
+
+	names := func(candidates []instance.Instance) []string {
+		result := []string{}
+		for _, inst := range candidates {
+			result = append(result, inst.Name())
+		}
+
+		return result
+	}
+
+	assert.Equal(t, []string{"low", "high"}, names(memoryPressureCandidates(insts, true)))
+
+	// Instances frozen because of memory pressure are unfrozen even once their priority was removed.
+	assert.Equal(t, []string{"frozen-removed", "frozen-high", "frozen-low"}, names(memoryPressureCandidates(insts, false)))
+}
@@ -13,6 +13,7 @@ import (
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/gorilla/websocket"
 
+	"github.com/lxc/incus/v6/client"
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/revert"
 	"github.com/lxc/incus/v6/internal/server/backup"
@@ -21,16 +22,20 @@ import (
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
 	"github.com/lxc/incus/v6/internal/server/db/operationtype"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/hugepages"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/response"
 	"github.com/lxc/incus/v6/internal/server/scriptlet"
 	"github.com/lxc/incus/v6/internal/server/state"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -38,6 +43,7 @@ import (
 	"github.com/lxc/incus/v6/shared/archive"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/units"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
@@ -196,6 +202,128 @@ func createFromNone(s *state.State, r *http.Request, projectName string, profile
 	return operations.OperationResponse(op)
 }
 
+// createFromDisk creates a virtual machine using a qcow2 or raw disk image as its root disk.
+// The disk image is either the uploaded file at diskPath or downloaded from the source URL.
+func createFromDisk(s *state.State, r *http.Request, projectName string, profiles []api.Profile, req *api.InstancesPost, diskPath string) response.Response {
+	revert := revert.New()
+	defer revert.Fail()
+
+	if diskPath != "" {
+		revert.Add(func() { _ = os.Remove(diskPath) })
+	} else if req.Source.URL == "" {
+		return response.BadRequest(fmt.Errorf("Must specify a disk image URL or upload a disk image"))
+	}
+
+	if s.DB.Cluster.LocalNodeIsEvacuated() {
+		return response.Forbidden(fmt.Errorf("Cluster member is evacuated"))
+	}
+
+	dbType, err := instancetype.New(string(req.Type))
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if dbType != instancetype.VM {
+		return response.BadRequest(fmt.Errorf("Disk images can only be used for virtual machines"))
+	}
+
+	devices := deviceConfig.NewDevices(req.Devices)
+
+	args := db.InstanceArgs{
+		Project:     projectName,
+		Config:      req.Config,
+		Type:        dbType,
+		Description: req.Description,
+		Devices:     deviceConfig.ApplyDeviceInitialValues(devices, profiles),
+		Ephemeral:   req.Ephemeral,
+		Name:        req.Name,
+		Profiles:    profiles,
+	}
+
+	if req.Architecture != "" {
+		architecture, err := osarch.ArchitectureId(req.Architecture)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		args.Architecture = architecture
+	}
+
+	run := func(op *operations.Operation) error {
+		var err error
+
+		path := diskPath
+		if path == "" {
+			path, err = instanceDiskDownload(s, req.Source.URL)
+			if err != nil {
+				return err
+			}
+		}
+
+		defer func() { _ = os.Remove(path) }()
+
+		// Actually create the instance.
+		_, err = instanceCreateFromDisk(s, args, path, op)
+		if err != nil {
+			return err
+		}
+
+		return instanceCreateFinish(s, req, args)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["instances"] = []api.URL{*api.NewURL().Path(version.APIVersion, "instances", req.Name)}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, operationtype.InstanceCreate, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	revert.Success()
+	return operations.OperationResponse(op)
+}
+
+// instanceDiskDownload downloads the disk image at the URL into a temporary file and returns its path.
+func instanceDiskDownload(s *state.State, url string) (string, error) {
+	httpClient, err := localUtil.HTTPClient("", s.Proxy)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(s.ShutdownCtx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("User-Agent", version.UserAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Failed downloading disk image %q: %w", url, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed downloading disk image %q: %s", url, resp.Status)
+	}
+
+	diskFile, err := os.CreateTemp(internalUtil.VarPath("images"), "incus_disk_")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = diskFile.Close() }()
+
+	_, err = io.Copy(diskFile, resp.Body)
+	if err != nil {
+		_ = os.Remove(diskFile.Name())
+		return "", fmt.Errorf("Failed downloading disk image %q: %w", url, err)
+	}
+
+	return diskFile.Name(), nil
+}
+
 func createFromMigration(ctx context.Context, s *state.State, r *http.Request, projectName string, profiles []api.Profile, req *api.InstancesPost) response.Response {
 	if s.DB.Cluster.LocalNodeIsEvacuated() && r != nil && r.Context().Value(request.CtxProtocol) != "cluster" {
 		return response.Forbidden(fmt.Errorf("Cluster member is evacuated"))
@@ -777,7 +905,9 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 //	Creates a new instance.
 //	Depending on the source, this can create an instance from an existing
 //	local image, remote image, existing local instance or snapshot, remote
-//	migration stream or backup file.
+//	migration stream, backup file or qcow2/raw disk image.
+//	When creating from an image or without a source, an instance template
+//	can be referenced to fill in the type, configuration, devices and profiles.
 //
 //	---
 //	consumes:
@@ -795,6 +925,11 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 //	    description: Cluster member
 //	    type: string
 //	    example: default
+//	  - in: query
+//	    name: dry-run
+//	    description: Only validate the request and return the expanded instance (see InstancesPostDryRun), not supported for uploads
+//	    type: boolean
+//	    example: true
 //	  - in: body
 //	    name: instance
 //	    description: Instance request
@@ -805,7 +940,41 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 //	    name: raw_backup
 //	    description: Raw backup file
 //	    required: false
+//	  - in: body
+//	    name: raw_disk
+//	    description: Raw qcow2 or raw disk image (requires the "X-Incus-type" header to be set to "disk")
+//	    required: false
+//	  - in: header
+//	    name: X-Incus-type
+//	    description: Type of the uploaded file ("disk" for disk images, backup otherwise)
+//	    schema:
+//	      type: string
+//	  - in: header
+//	    name: X-Incus-instance
+//	    description: JSON encoded instance request when uploading a disk image
+//	    schema:
+//	      type: string
 //	responses:
+//	  "200":
+//	    description: Dry-run result
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/InstancesPostDryRun"
 //	  "202":
 //	    $ref: "#/responses/Operation"
 //	  "400":
@@ -815,6 +984,11 @@ func createFromBackup(s *state.State, r *http.Request, projectName string, data
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func instancesPost(d *Daemon, r *http.Request) response.Response {
+	// Uploads are consumed as they're processed, so they can't only be validated.
+	if r.Header.Get("Content-Type") == "application/octet-stream" && util.IsTrue(request.QueryParam(r, "dry-run")) {
+		return response.BadRequest(fmt.Errorf("Dry-run isn't supported when uploading a backup or disk image"))
+	}
+
 	s := d.State()
 
 	targetProjectName := request.ProjectParam(r)
@@ -822,16 +996,53 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 
 	logger.Debug("Responding to instance create")
 
+	req := api.InstancesPost{}
+
+	// Path to an uploaded disk image (for disk source).
+	var diskPath string
+
 	// If we're getting binary content, process separately
 	if r.Header.Get("Content-Type") == "application/octet-stream" {
-		return createFromBackup(s, r, targetProjectName, r.Body, r.Header.Get("X-Incus-pool"), r.Header.Get("X-Incus-name"))
-	}
+		if r.Header.Get("X-Incus-type") != "disk" {
+			return createFromBackup(s, r, targetProjectName, r.Body, r.Header.Get("X-Incus-pool"), r.Header.Get("X-Incus-name"))
+		}
 
-	// Parse the request
-	req := api.InstancesPost{}
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		return response.BadRequest(err)
+		// Parse the request from the header as the body is the disk image.
+		err := json.Unmarshal([]byte(r.Header.Get("X-Incus-instance")), &req)
+		if err != nil {
+			return response.BadRequest(fmt.Errorf("Failed parsing instance request: %w", err))
+		}
+
+		if req.Source.Type != "disk" {
+			return response.BadRequest(fmt.Errorf("Uploaded disk images require the disk source type"))
+		}
+
+		// Stream uploaded disk image into temporary file.
+		diskFile, err := os.CreateTemp(internalUtil.VarPath("images"), "incus_disk_")
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		diskPath = diskFile.Name()
+		_, err = io.Copy(diskFile, r.Body)
+		_ = diskFile.Close()
+		if err != nil {
+			_ = os.Remove(diskPath)
+			return response.InternalError(err)
+		}
+
+		// Only cleanup the uploaded disk image if not handed over to createFromDisk.
+		defer func() {
+			if diskPath != "" {
+				_ = os.Remove(diskPath)
+			}
+		}()
+	} else {
+		// Parse the request
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return response.BadRequest(err)
+		}
 	}
 
 	// Set type from URL if missing
@@ -844,6 +1055,18 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		req.Type = api.InstanceType(urlType.String())
 	}
 
+	// Apply the instance template.
+	if req.Template != "" {
+		err = instanceTemplateApply(s, r.Context(), targetProjectName, &req)
+		if err != nil {
+			return response.SmartError(err)
+		}
+	}
+
+	if req.Type == "" && req.Source.Type == "disk" {
+		req.Type = api.InstanceTypeVM // Disk images are only usable by virtual machines.
+	}
+
 	if req.Type == "" {
 		req.Type = api.InstanceTypeContainer // Default to container if not specified.
 	}
@@ -1079,7 +1302,14 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return response.BadRequest(err)
 	}
 
-	if s.ServerClustered && !clusterNotification && targetMemberInfo == nil {
+	// Uploaded disk images can only be used on the member receiving them.
+	if s.ServerClustered && !clusterNotification && targetMemberInfo == nil && diskPath == "" {
+		// Exclude the cluster members lacking the huge pages to back the instance memory.
+		candidateMembers, err = instancePlacementHugepages(s, r, candidateMembers, req.Type, db.ExpandInstanceConfig(req.Config, profiles))
+		if err != nil {
+			return response.SmartError(err)
+		}
+
 		// Run instance placement scriptlet if enabled and no cluster member selected yet.
 		if s.GlobalConfig.InstancesPlacementScriptlet() != "" {
 			leaderAddress, err := d.gateway.LeaderAddress()
@@ -1120,7 +1350,16 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		req.Config["volatile.cluster.group"] = targetGroupName
 	}
 
+	// Only validate the request if requested.
+	if util.IsTrue(request.QueryParam(r, "dry-run")) {
+		return instancesPostDryRun(s, *targetProject, profiles, targetMemberInfo, &req)
+	}
+
 	if targetMemberInfo != nil && targetMemberInfo.Address != "" && targetMemberInfo.Name != s.ServerName {
+		if diskPath != "" {
+			return response.BadRequest(fmt.Errorf("Uploaded disk images can't be used on another cluster member"))
+		}
+
 		client, err := cluster.Connect(targetMemberInfo.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
 		if err != nil {
 			return response.SmartError(err)
@@ -1144,6 +1383,10 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 		return createFromImage(s, r, *targetProject, profiles, sourceImage, sourceImageRef, &req)
 	case "none":
 		return createFromNone(s, r, targetProjectName, profiles, &req)
+	case "disk":
+		uploadPath := diskPath
+		diskPath = "" // createFromDisk now owns the uploaded disk image.
+		return createFromDisk(s, r, targetProjectName, profiles, &req, uploadPath)
 	case "migration":
 		return createFromMigration(r.Context(), s, r, targetProjectName, profiles, &req)
 	case "copy":
@@ -1153,6 +1396,124 @@ func instancesPost(d *Daemon, r *http.Request) response.Response {
 	}
 }
 
+// instancesPostDryRun validates a new instance request without creating anything.
+// instancePlacementHugepages returns the candidate cluster members with enough free huge pages to back the memory
+// of a virtual machine using huge pages.
+func instancePlacementHugepages(s *state.State, r *http.Request, candidateMembers []db.NodeInfo, instType api.InstanceType, config map[string]string) ([]db.NodeInfo, error) {
+	if instType != api.InstanceTypeVM || util.IsFalseOrEmpty(config["limits.memory.hugepages"]) {
+		return candidateMembers, nil
+	}
+
+	memSize := config["limits.memory"]
+	if memSize == "" {
+		memSize = instanceDrivers.QEMUDefaultMemSize
+	}
+
+	memSizeBytes, err := units.ParseByteSizeString(memSize)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid limits.memory: %w", err)
+	}
+
+	members := []db.NodeInfo{}
+	for _, member := range candidateMembers {
+		var res *api.Resources
+
+		if member.Name == s.ServerName {
+			res, err = resources.GetResources()
+			if err == nil {
+				hugepages.Adjust(res.Memory.Hugepages)
+			}
+		} else {
+			var client incus.InstanceServer
+
+			client, err = cluster.Connect(member.Address, s.Endpoints.NetworkCert(), s.ServerCert(), r, true)
+			if err == nil {
+				res, err = client.GetServerResources()
+			}
+		}
+
+		if err != nil {
+			logger.Warn("Failed getting huge pages of cluster member", logger.Ctx{"member": member.Name, "err": err})
+			continue
+		}
+
+		// Virtual machines use the default huge page size.
+		for _, entry := range res.Memory.Hugepages {
+			if entry.Size == res.Memory.HugepagesSize && entry.Free >= uint64(memSizeBytes) {
+				members = append(members, member)
+				break
+			}
+		}
+	}
+
+	if len(members) == 0 {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "No cluster member has %s of free huge pages available for the instance", units.GetByteSizeStringIEC(memSizeBytes, 2))
+	}
+
+	return members, nil
+}
+
+func instancesPostDryRun(s *state.State, p api.Project, profiles []api.Profile, targetMemberInfo *db.NodeInfo, req *api.InstancesPost) response.Response {
+	instanceType, err := instancetype.New(string(req.Type))
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	localDevices := deviceConfig.NewDevices(req.Devices)
+	expandedConfig := db.ExpandInstanceConfig(req.Config, profiles)
+	expandedDevices := db.ExpandInstanceDevices(localDevices, profiles)
+
+	result := api.InstancesPostDryRun{
+		Name:            req.Name,
+		Type:            req.Type,
+		Profiles:        make([]string, 0, len(profiles)),
+		ExpandedConfig:  expandedConfig,
+		ExpandedDevices: expandedDevices.CloneNative(),
+		Errors:          []string{},
+	}
+
+	for _, profile := range profiles {
+		result.Profiles = append(result.Profiles, profile.Name)
+	}
+
+	if targetMemberInfo != nil {
+		result.Location = targetMemberInfo.Name
+	} else if s.ServerClustered {
+		result.Location = s.ServerName
+	}
+
+	// Run the same checks as the instance creation, collecting all failures.
+	err = instance.ValidConfig(s.OS, req.Config, false, instanceType)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid config: %v", err))
+	}
+
+	err = instance.ValidDevices(s, p, instanceType, localDevices, nil)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid devices: %v", err))
+	}
+
+	err = instance.ValidConfig(s.OS, expandedConfig, true, instancetype.Any)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid expanded config: %v", err))
+	}
+
+	err = instance.ValidDevices(s, p, instanceType, localDevices, expandedDevices)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Invalid expanded devices: %v", err))
+	}
+
+	// Copies default to the storage pool of their source.
+	if req.Source.Type != "copy" {
+		storagePool, _, _, _, resp := instanceFindStoragePool(context.TODO(), s, p.Name, req)
+		if resp != nil || storagePool == "" {
+			result.Errors = append(result.Errors, "Can't find a storage pool for the instance to use")
+		}
+	}
+
+	return response.SyncResponse(true, result)
+}
+
 func instanceFindStoragePool(ctx context.Context, s *state.State, projectName string, req *api.InstancesPost) (string, string, string, map[string]string, response.Response) {
 	// Grab the container's root device if one is specified
 	storagePool := ""
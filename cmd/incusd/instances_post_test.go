@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+func (suite *containerTestSuite) TestInstancesPost_DryRunUpload() {
+	for _, uploadType := range []string{"", "disk"} {
+		body := strings.NewReader("not a backup")
+		req := httptest.NewRequest(http.MethodPost, "/1.0/instances?dry-run=true", body)
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("X-Incus-type", uploadType)
+		req.Header.Set("X-Incus-instance", `{"name": "c1", "source": {"type": "disk"}}`)
+
+		resp := instancesPost(suite.d, req)
+
+		rec := httptest.NewRecorder()
+		err := resp.Render(rec)
+		suite.Req.Nil(err)
+		suite.Equal(http.StatusBadRequest, rec.Code, "Dry-run upload of type %q should be refused", uploadType)
+
+		// Nothing should have been consumed from the upload.
+		suite.Equal(len("not a backup"), body.Len())
+	}
+}
@@ -114,31 +114,8 @@ func instancesPut(d *Daemon, r *http.Request) response.Response {
 			continue
 		}
 
-		switch action {
-		case internalInstance.Freeze:
-			if !inst.IsRunning() {
-				continue
-			}
-
-		case internalInstance.Restart:
-			if !inst.IsRunning() {
-				continue
-			}
-
-		case internalInstance.Start:
-			if inst.IsRunning() {
-				continue
-			}
-
-		case internalInstance.Stop:
-			if !inst.IsRunning() {
-				continue
-			}
-
-		case internalInstance.Unfreeze:
-			if !inst.IsFrozen() {
-				continue
-			}
+		if !instanceStateChangeNeeded(action, inst.IsRunning(), inst.IsFrozen()) {
+			continue
 		}
 
 		instances = append(instances, inst)
@@ -275,3 +252,130 @@ func instancesPut(d *Daemon, r *http.Request) response.Response {
 
 	return operations.OperationResponse(op)
 }
+
+// swagger:operation PUT /1.0/instances/state instances instances_state_put
+//
+//	Bulk state update of a list of instances
+//
+//	Changes the running state of the listed instances concurrently as a single operation.
+//	The operation metadata reports the outcome for each of the instances under the `instances` key.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: state
+//	    description: Instances and state
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/InstancesStatePut"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func instancesStatePut(d *Daemon, r *http.Request) response.Response {
+	projectName := request.ProjectParam(r)
+
+	// Don't mess with instances while in setup mode.
+	<-d.waitReady.Done()
+
+	s := d.State()
+
+	req := api.InstancesStatePut{}
+	req.State.Timeout = -1
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if len(req.Instances) == 0 {
+		return response.BadRequest(fmt.Errorf("No instances provided"))
+	}
+
+	// Determine operation type.
+	opType, err := instanceActionToOptype(req.State.Action)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	userHasPermission, err := s.Authorizer.GetPermissionChecker(r.Context(), r, auth.EntitlementCanUpdateState, auth.ObjectTypeInstance)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	resources := map[string][]api.URL{}
+	seen := map[string]bool{}
+	for _, instName := range req.Instances {
+		if seen[instName] {
+			return response.BadRequest(fmt.Errorf("Instance %q is listed more than once", instName))
+		}
+
+		seen[instName] = true
+
+		if !userHasPermission(auth.ObjectInstance(projectName, instName)) {
+			return response.Forbidden(fmt.Errorf("Not allowed to change the state of instance %q", instName))
+		}
+
+		resources["instances"] = append(resources["instances"], *api.NewURL().Path(version.APIVersion, "instances", instName).Project(projectName))
+	}
+
+	do := func(op *operations.Operation) error {
+		results := map[string]api.InstancesStateResult{}
+		failures := map[string]error{}
+		resultsLock := sync.Mutex{}
+		wgAction := sync.WaitGroup{}
+
+		for _, instName := range req.Instances {
+			wgAction.Add(1)
+			go func(instName string) {
+				defer wgAction.Done()
+
+				changed, err := instanceStateChange(s, r, op, projectName, instName, req.State)
+
+				resultsLock.Lock()
+				defer resultsLock.Unlock()
+
+				if err != nil {
+					failures[instName] = err
+					results[instName] = api.InstancesStateResult{Status: "Failure", Error: err.Error()}
+				} else if !changed {
+					results[instName] = api.InstancesStateResult{Status: "Skipped"}
+				} else {
+					results[instName] = api.InstancesStateResult{Status: "Success"}
+				}
+
+				// Report the progress so far.
+				metadata := make(map[string]api.InstancesStateResult, len(results))
+				for k, v := range results {
+					metadata[k] = v
+				}
+
+				_ = op.UpdateMetadata(map[string]any{"instances": metadata})
+			}(instName)
+		}
+
+		wgAction.Wait()
+
+		return coalesceErrors(true, failures)
+	}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassTask, opType, resources, nil, do, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// instanceSnapshotCreate creates a snapshot of an instance, on whichever cluster member it's located.
+func instanceSnapshotCreate(s *state.State, r *http.Request, op *operations.Operation, projectName string, instName string, req api.InstanceSnapshotsPost) error {
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, instName, r, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		remoteOp, err := client.CreateInstanceSnapshot(instName, req)
+		if err != nil {
+			return err
+		}
+
+		return remoteOp.Wait()
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, instName)
+	if err != nil {
+		return err
+	}
+
+	var expiry time.Time
+	if req.ExpiresAt != nil {
+		expiry = *req.ExpiresAt
+	} else {
+		expiry, err = internalInstance.GetExpiry(time.Now(), inst.ExpandedConfig()["snapshots.expiry"])
+		if err != nil {
+			return err
+		}
+	}
+
+	inst.SetOperation(op)
+
+	return inst.Snapshot(req.Name, expiry, req.Stateful)
+}
+
+// instanceSnapshotDelete deletes a snapshot of an instance, on whichever cluster member it's located.
+func instanceSnapshotDelete(s *state.State, r *http.Request, projectName string, instName string, snapName string) error {
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, instName, r, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		remoteOp, err := client.DeleteInstanceSnapshot(instName, snapName)
+		if err != nil {
+			return err
+		}
+
+		return remoteOp.Wait()
+	}
+
+	snap, err := instance.LoadByProjectAndName(s, projectName, instName+internalInstance.SnapshotDelimiter+snapName)
+	if err != nil {
+		return err
+	}
+
+	return snap.Delete(true)
+}
+
+// instanceQuiesce flushes the filesystem buffers of a running instance through its agent, on whichever cluster
+// member it's located. Stopped and frozen instances are left alone.
+func instanceQuiesce(s *state.State, r *http.Request, projectName string, instName string) error {
+	post := api.InstanceExecPost{Command: []string{"sync"}}
+
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, instName, r, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		inst, _, err := client.GetInstance(instName)
+		if err != nil {
+			return err
+		}
+
+		if inst.StatusCode != api.Running {
+			return nil
+		}
+
+		remoteOp, err := client.ExecInstance(instName, post, nil)
+		if err != nil {
+			return err
+		}
+
+		err = remoteOp.Wait()
+		if err != nil {
+			return err
+		}
+
+		exitStatus, ok := remoteOp.Get().Metadata["return"].(float64)
+		if ok && exitStatus != 0 {
+			return fmt.Errorf("Filesystem sync exited with status %d", int(exitStatus))
+		}
+
+		return nil
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, instName)
+	if err != nil {
+		return err
+	}
+
+	if !inst.IsRunning() || inst.IsFrozen() {
+		return nil
+	}
+
+	cmd, err := inst.Exec(post, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	exitStatus, err := cmd.Wait()
+	if err != nil {
+		return err
+	}
+
+	if exitStatus != 0 {
+		return fmt.Errorf("Filesystem sync exited with status %d", exitStatus)
+	}
+
+	return nil
+}
+
+// instancesSnapshotPrepare checks that snapshots are allowed in the project and, if no snapshot name was
+// requested, picks one which is free on all the instances.
+func instancesSnapshotPrepare(ctx context.Context, s *state.State, projectName string, instNames []string, req *api.InstanceSnapshotsPost) error {
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), projectName)
+		if err != nil {
+			return err
+		}
+
+		p, err := dbProject.ToAPI(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		err = project.AllowSnapshotCreation(p)
+		if err != nil {
+			return err
+		}
+
+		if req.Name == "" {
+			next := 0
+			for _, instName := range instNames {
+				next = max(next, tx.GetNextInstanceSnapshotIndex(ctx, projectName, instName, "snap%d"))
+			}
+
+			req.Name = fmt.Sprintf("snap%d", next)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = validate.IsURLSegmentSafe(req.Name)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid snapshot name: %v", err)
+	}
+
+	return nil
+}
+
+// instancesSnapshotCreate creates a snapshot with the same name of all the given instances concurrently, optionally
+// quiescing all of them first. If any snapshot fails, the snapshots which were created are deleted again.
+func instancesSnapshotCreate(s *state.State, r *http.Request, op *operations.Operation, projectName string, instNames []string, req api.InstanceSnapshotsPost, quiesce bool) error {
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	failures := map[string]error{}
+	failuresLock := sync.Mutex{}
+	wgAction := sync.WaitGroup{}
+
+	// Run the action on all the instances and wait for all of them to be done.
+	run := func(action func(instName string) error) {
+		for _, instName := range instNames {
+			wgAction.Add(1)
+			go func(instName string) {
+				defer wgAction.Done()
+
+				err := action(instName)
+				if err != nil {
+					failuresLock.Lock()
+					failures[instName] = err
+					failuresLock.Unlock()
+				}
+			}(instName)
+		}
+
+		wgAction.Wait()
+	}
+
+	if quiesce {
+		run(func(instName string) error {
+			return instanceQuiesce(s, r, projectName, instName)
+		})
+	}
+
+	if len(failures) == 0 {
+		run(func(instName string) error {
+			err := instanceSnapshotCreate(s, r, op, projectName, instName, req)
+			if err != nil {
+				return err
+			}
+
+			failuresLock.Lock()
+			defer failuresLock.Unlock()
+
+			reverter.Add(func() {
+				err := instanceSnapshotDelete(s, r, projectName, instName, req.Name)
+				if err != nil {
+					logger.Warn("Failed deleting snapshot after failed bulk snapshot", logger.Ctx{"project": projectName, "instance": instName, "snapshot": req.Name, "err": err})
+				}
+			})
+
+			return nil
+		})
+	}
+
+	if len(failures) > 0 {
+		var errorMsg strings.Builder
+		errorMsg.WriteString("The following instances failed to snapshot:\n")
+		for instName, err := range failures {
+			fmt.Fprintf(&errorMsg, " - Instance: %s: %v\n", instName, err)
+		}
+
+		return fmt.Errorf("%s", errorMsg.String())
+	}
+
+	reverter.Success()
+
+	return nil
+}
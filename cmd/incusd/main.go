@@ -41,7 +41,7 @@ func (c *cmdGlobal) Run(cmd *cobra.Command, args []string) error {
 
 	// Set logging global variables
 	daemon.Debug = c.flagLogDebug
-	rsync.Debug = c.flagLogDebug
+	rsync.Debug.Store(c.flagLogDebug)
 	daemon.Verbose = c.flagLogVerbose
 
 	// Set debug for the operations package
@@ -109,6 +109,10 @@ func main() {
 	callhookCmd := cmdCallhook{global: &globalCmd}
 	app.AddCommand(callhookCmd.Command())
 
+	// forkcheckpoint sub-command
+	forkcheckpointCmd := cmdForkcheckpoint{global: &globalCmd}
+	app.AddCommand(forkcheckpointCmd.Command())
+
 	// forkconsole sub-command
 	forkconsoleCmd := cmdForkconsole{global: &globalCmd}
 	app.AddCommand(forkconsoleCmd.Command())
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/cowsql/go-cowsql/client"
 	"github.com/spf13/cobra"
@@ -26,6 +28,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/sys"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/termios"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 type cmdCluster struct {
@@ -47,6 +50,10 @@ func (c *cmdCluster) Command() *cobra.Command {
 	recover := cmdClusterRecoverFromQuorumLoss{global: c.global}
 	cmd.AddCommand(recover.Command())
 
+	// Recover from a database backup.
+	recoverFromDBBackup := cmdClusterRecoverFromDBBackup{global: c.global}
+	cmd.AddCommand(recoverFromDBBackup.Command())
+
 	// Remove a raft node.
 	removeRaftNode := cmdClusterRemoveRaftNode{global: c.global}
 	cmd.AddCommand(removeRaftNode.Command())
@@ -441,6 +448,188 @@ Do you want to proceed? (yes/no): `)
 	return nil
 }
 
+type cmdClusterRecoverFromDBBackup struct {
+	global             *cmdGlobal
+	flagNonInteractive bool
+}
+
+func (c *cmdClusterRecoverFromDBBackup) Command() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Use = "recover-from-db-backup <path>"
+	cmd.Short = "Bootstrap the database from a database backup"
+
+	cmd.RunE = c.Run
+
+	cmd.Flags().BoolVarP(&c.flagNonInteractive, "quiet", "q", false, "Don't require user confirmation")
+
+	return cmd
+}
+
+func (c *cmdClusterRecoverFromDBBackup) Run(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		_ = cmd.Help()
+		return fmt.Errorf("Missing required arguments")
+	}
+
+	// Make sure that the daemon is not running.
+	_, err := incus.ConnectIncusUnix("", nil)
+	if err == nil {
+		return fmt.Errorf("The daemon is running, please stop it first.")
+	}
+
+	queries, err := readDatabaseBackup(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation unless --quiet was passed.
+	if !c.flagNonInteractive {
+		err := c.promptConfirmation()
+		if err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Join(sys.DefaultOS().VarDir, "database")
+
+	patchPath := filepath.Join(dir, "patch.global.sql")
+	if util.PathExists(patchPath) {
+		return fmt.Errorf("Pending global database queries found in %q, please start the daemon first", patchPath)
+	}
+
+	database, err := db.OpenNode(dir, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to open local database: %w", err)
+	}
+
+	defer func() { _ = database.Close() }()
+
+	var info *db.RaftNode
+	err = database.Transaction(context.TODO(), func(ctx context.Context, tx *db.NodeTx) error {
+		info, err = node.DetermineRaftNode(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to determine node role: %w", err)
+	}
+
+	if info == nil {
+		return fmt.Errorf("This server has no database role")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Keep the current database state around for later inspection.
+	globalDir := filepath.Join(dir, "global")
+	oldGlobalDir := fmt.Sprintf("%s.%s", globalDir, time.Now().UTC().Format("20060102T150405Z"))
+
+	if util.PathExists(globalDir) {
+		err = os.Rename(globalDir, oldGlobalDir)
+		if err != nil {
+			return fmt.Errorf("Failed to move the current global database: %w", err)
+		}
+
+		reverter.Add(func() {
+			_ = os.RemoveAll(globalDir)
+			_ = os.Rename(oldGlobalDir, globalDir)
+		})
+	}
+
+	err = os.Mkdir(globalDir, 0700)
+	if err != nil {
+		return fmt.Errorf("Failed to create the global database directory: %w", err)
+	}
+
+	// Make this server the only database member of a clustered setup.
+	if info.Address != "" {
+		err = cluster.Recover(database)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The queries get applied by the daemon on the fresh global database when it next starts.
+	err = os.WriteFile(patchPath, []byte(queries), 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write the global database queries: %w", err)
+	}
+
+	reverter.Success()
+
+	if util.PathExists(oldGlobalDir) {
+		fmt.Printf("The previous global database was moved to %q.\n", oldGlobalDir)
+	}
+
+	fmt.Println("The database will be restored when the daemon is next started.")
+
+	return nil
+}
+
+func (c *cmdClusterRecoverFromDBBackup) promptConfirmation() error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Print(`You should run this command only if the global database can't be recovered
+by other means and the daemon is stopped on all cluster members.
+
+This will replace the global database with the content of the backup, making
+this server the only database member of the cluster. Any change made since
+the backup was taken will be lost.
+
+The other cluster members will have to be force-removed and joined again.
+
+See https://linuxcontainers.org/incus/docs/main/howto/cluster_recover/#recover-from-a-database-backup for more info.
+
+Do you want to proceed? (yes/no): `)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSuffix(input, "\n")
+
+	if !slices.Contains([]string{"yes"}, strings.ToLower(input)) {
+		return fmt.Errorf("Recover operation aborted")
+	}
+
+	return nil
+}
+
+// readDatabaseBackup reads a global database dump, as produced by the automatic database backups, and returns
+// the queries which re-create the database from scratch.
+func readDatabaseBackup(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("Failed to open database backup: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return "", fmt.Errorf("Failed to decompress database backup: %w", err)
+		}
+
+		defer func() { _ = gz.Close() }()
+
+		r = gz
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read database backup: %w", err)
+	}
+
+	// The queries get applied within a transaction, so strip the dump's own transaction handling.
+	queries, ok := strings.CutPrefix(string(content), "PRAGMA foreign_keys=OFF;\nBEGIN TRANSACTION;\n")
+	if ok {
+		queries, ok = strings.CutSuffix(queries, "COMMIT;\n")
+	}
+
+	if !ok || !strings.Contains(queries, "CREATE TABLE schema") {
+		return "", fmt.Errorf("%q isn't a valid global database backup", path)
+	}
+
+	return queries, nil
+}
+
 type cmdClusterRemoveRaftNode struct {
 	global             *cmdGlobal
 	flagNonInteractive bool
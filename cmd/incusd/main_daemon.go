@@ -60,6 +60,7 @@ func (c *cmdDaemon) Run(cmd *cobra.Command, args []string) error {
 	conf := defaultDaemonConfig()
 	conf.Group = c.flagGroup
 	conf.Trace = c.global.flagLogTrace
+	conf.Syslog = c.global.flagLogSyslog
 	d := newDaemon(conf, sys.DefaultOS())
 
 	sigCh := make(chan os.Signal, 1)
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	liblxc "github.com/lxc/go-lxc"
+	"github.com/spf13/cobra"
+)
+
+type cmdForkcheckpoint struct {
+	global *cmdGlobal
+}
+
+func (c *cmdForkcheckpoint) Command() *cobra.Command {
+	// Main subcommand
+	cmd := &cobra.Command{}
+	cmd.Use = "forkcheckpoint <container name> <containers path> <config> <images path> <preserve> <ghost limit> <action script>"
+	cmd.Short = "Dump the state of the container"
+	cmd.Long = `Description:
+  Dump the state of the container
+
+  This internal command is used to dump the state of the container from
+  a separate process, allowing for CRIU to be run with its own environment.
+`
+	cmd.RunE = c.Run
+	cmd.Hidden = true
+
+	return cmd
+}
+
+func (c *cmdForkcheckpoint) Run(cmd *cobra.Command, args []string) error {
+	// Quick checks.
+	if len(args) != 7 {
+		_ = cmd.Help()
+
+		if len(args) == 0 {
+			return nil
+		}
+
+		return fmt.Errorf("Missing required arguments")
+	}
+
+	// Only root should run this
+	if os.Geteuid() != 0 {
+		return fmt.Errorf("This must be run as root")
+	}
+
+	name := args[0]
+	lxcpath := args[1]
+	configPath := args[2]
+	imagesDir := args[3]
+
+	preservesInodes, err := strconv.ParseBool(args[4])
+	if err != nil {
+		return err
+	}
+
+	ghostLimit, err := strconv.ParseUint(args[5], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	actionScript := args[6]
+
+	d, err := liblxc.NewContainer(name, lxcpath)
+	if err != nil {
+		return err
+	}
+
+	err = d.LoadConfigFile(configPath)
+	if err != nil {
+		return fmt.Errorf("Failed loading config file %q: %w", configPath, err)
+	}
+
+	return d.Migrate(liblxc.MIGRATE_DUMP, liblxc.MigrateOptions{
+		Directory:       imagesDir,
+		Verbose:         true,
+		Stop:            true,
+		PreservesInodes: preservesInodes,
+		GhostLimit:      ghostLimit,
+		ActionScript:    actionScript,
+	})
+}
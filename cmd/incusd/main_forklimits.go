@@ -94,6 +94,8 @@ func (c *cmdForklimits) Run(cmd *cobra.Command, _ []string) error {
 
 		if limit.name == "memlock" {
 			resource = unix.RLIMIT_MEMLOCK
+		} else if limit.name == "core" {
+			resource = unix.RLIMIT_CORE
 		} else {
 			return fmt.Errorf("Unsupported limit type: %q", limit.name)
 		}
@@ -71,6 +71,16 @@ static void forkdonetdetach(char *file) {
 	// Jump back to Go for the rest
 }
 
+static void forkdonetaddress(char *file) {
+	// Attach to the network namespace.
+	if (dosetns_file(file, "net") < 0) {
+		fprintf(stderr, "Failed setns to container network namespace: %s\n", strerror(errno));
+		_exit(1);
+	}
+
+	// Jump back to Go for the rest
+}
+
 void forknet(void)
 {
 	char *command = NULL;
@@ -114,6 +124,9 @@ void forknet(void)
 
 	if (strcmp(command, "detach") == 0)
 		forkdonetdetach(cur);
+
+	if (strcmp(command, "address") == 0)
+		forkdonetaddress(cur);
 }
 */
 import "C"
@@ -165,6 +178,13 @@ func (c *cmdForknet) Command() *cobra.Command {
 	cmdDetach.RunE = c.RunDetach
 	cmd.AddCommand(cmdDetach)
 
+	// address
+	cmdAddress := &cobra.Command{}
+	cmdAddress.Use = "address <netns file> <ifname> <add|delete> <address>"
+	cmdAddress.Args = cobra.ExactArgs(4)
+	cmdAddress.RunE = c.RunAddress
+	cmd.AddCommand(cmdAddress)
+
 	// Workaround for subcommand usage errors. See: https://github.com/spf13/cobra/issues/706
 	cmd.Args = cobra.NoArgs
 	cmd.Run = func(cmd *cobra.Command, args []string) { _ = cmd.Usage() }
@@ -266,3 +286,37 @@ func (c *cmdForknet) RunDetach(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func (c *cmdForknet) RunAddress(cmd *cobra.Command, args []string) error {
+	ifName := args[1]
+	action := args[2]
+	address := args[3]
+
+	if ifName == "" {
+		return fmt.Errorf("ifname argument is required")
+	}
+
+	ipAddr, _, err := net.ParseCIDR(address)
+	if err != nil {
+		return fmt.Errorf("Invalid address %q: %w", address, err)
+	}
+
+	addr := &ip.Addr{
+		DevName: ifName,
+		Address: address,
+		Family:  ip.FamilyV4,
+	}
+
+	if ipAddr.To4() == nil {
+		addr.Family = ip.FamilyV6
+	}
+
+	switch action {
+	case "add":
+		return addr.Add()
+	case "delete":
+		return addr.Delete()
+	}
+
+	return fmt.Errorf("Unknown address action %q", action)
+}
@@ -139,6 +139,7 @@ type migrationSourceWs struct {
 	migrationFields
 
 	clusterMoveSourceName string
+	liveMigration         *api.InstancePostLiveMigration
 
 	pushCertificate  string
 	pushOperationURL string
@@ -146,6 +146,7 @@ func (s *migrationSourceWs) Do(state *state.State, migrateOp *operations.Operati
 			ClusterMoveSourceName: s.clusterMoveSourceName,
 		},
 		AllowInconsistent: s.allowInconsistent,
+		LiveMigration:     s.liveMigration,
 	})
 	if err != nil {
 		l.Error("Failed migration on source", logger.Ctx{"err": err})
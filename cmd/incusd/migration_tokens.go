@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lxc/incus/v6/client"
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+var migrationTokensCmd = APIEndpoint{
+	Path: "migration-tokens",
+
+	Post: APIEndpointAction{Handler: migrationTokensPost, AccessHandler: allowPermission(auth.ObjectTypeProject, auth.EntitlementCanCreateInstances)},
+}
+
+var migrationTokenInstancesCmd = APIEndpoint{
+	Path: "migration-tokens/instances",
+
+	Post: APIEndpointAction{Handler: migrationTokenInstancesPost, AllowUntrusted: true},
+}
+
+// swagger:operation POST /1.0/migration-tokens instances migration_tokens_post
+//
+//	Issue a migration token
+//
+//	Issues a single-use token allowing another server to push an instance into the project,
+//	without having to trust its certificate.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: token
+//	    description: Migration token request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/MigrationTokensPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func migrationTokensPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	// Parse the request.
+	req := api.MigrationTokensPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Name != "" {
+		err = instance.ValidName(req.Name, false)
+		if err != nil {
+			return response.BadRequest(err)
+		}
+	}
+
+	localHTTPSAddress := s.LocalConfig.HTTPSAddress()
+	if localHTTPSAddress == "" {
+		return response.BadRequest(fmt.Errorf("Can't issue token when server isn't listening on network"))
+	}
+
+	// Get all addresses the server is listening on. This is encoded in the migration token,
+	// so that the source server will not have to be told the target address.
+	addresses, err := localUtil.ListenAddresses(localHTTPSAddress)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	secret, err := internalUtil.RandomHexString(32)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	// Include the fingerprint of the network certificate so the source server can validate the target
+	// without any prior trust relationship.
+	fingerprint, err := localtls.CertFingerprintStr(string(s.Endpoints.NetworkPublicKey()))
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	meta := map[string]any{
+		"secret":      secret,
+		"fingerprint": fingerprint,
+		"addresses":   addresses,
+		"project":     projectName,
+		"name":        req.Name,
+	}
+
+	// If tokens should expire, add the expiry date to the op's metadata.
+	expiry := s.GlobalConfig.RemoteTokenExpiry()
+	if expiry != "" {
+		expiresAt, err := internalInstance.GetExpiry(time.Now(), expiry)
+		if err != nil {
+			return response.InternalError(err)
+		}
+
+		meta["expiresAt"] = expiresAt
+	}
+
+	op, err := operations.OperationCreate(s, projectName, operations.OperationClassToken, operationtype.MigrationToken, nil, meta, nil, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// migrationTokenValid searches for a migration token matching the secret and instance name.
+// As tokens are single use, a matching token is cancelled before being returned.
+func migrationTokenValid(s *state.State, r *http.Request, projectName string, secret string, name string) (*api.Operation, error) {
+	ops, err := operationsGetByType(s, r, projectName, operationtype.MigrationToken)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting migration token operations: %w", err)
+	}
+
+	var foundOp *api.Operation
+	for _, op := range ops {
+		if op.StatusCode != api.Running {
+			continue // Tokens are single use, so if cancelled but not deleted yet its not available.
+		}
+
+		opSecret, ok := op.Metadata["secret"]
+		if !ok {
+			continue
+		}
+
+		if opSecret == secret {
+			foundOp = op
+			break
+		}
+	}
+
+	if foundOp == nil {
+		return nil, api.StatusErrorf(http.StatusForbidden, "Invalid migration token")
+	}
+
+	// Check that the token allows for this instance before consuming it.
+	tokenName, _ := foundOp.Metadata["name"].(string)
+	if tokenName != "" && tokenName != name {
+		return nil, api.StatusErrorf(http.StatusForbidden, "Migration token is restricted to instance %q", tokenName)
+	}
+
+	// Token is single-use, so cancel it now.
+	err = operationCancel(s, r, projectName, foundOp)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to cancel operation %q: %w", foundOp.ID, err)
+	}
+
+	// Check if token has expired. Tokens held by other cluster members have their expiry serialized.
+	var expiry time.Time
+	switch expiresAt := foundOp.Metadata["expiresAt"].(type) {
+	case time.Time:
+		expiry = expiresAt
+	case string:
+		expiry, err = time.Parse(time.RFC3339Nano, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !expiry.IsZero() && time.Now().After(expiry) {
+		return nil, api.StatusErrorf(http.StatusForbidden, "Token has expired")
+	}
+
+	return foundOp, nil
+}
+
+// swagger:operation POST /1.0/migration-tokens/instances instances migration_token_instances_post
+//
+//	Create an instance using a migration token
+//
+//	Creates a new instance which the source server then pushes as an untrusted user.
+//	In this mode, the `secret` property must be set to the secret of a valid migration token.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: instance
+//	    description: Instance request
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/MigrationTokenInstancesPost"
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func migrationTokenInstancesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName := request.ProjectParam(r)
+
+	// Parse the request.
+	req := api.MigrationTokenInstancesPost{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Secret == "" {
+		return response.Forbidden(nil)
+	}
+
+	// Only allow for the instance to be pushed from the source server.
+	if req.Source.Type != "migration" || req.Source.Mode != "push" || req.Source.Refresh {
+		return response.BadRequest(fmt.Errorf("Migration tokens can only be used to push new instances"))
+	}
+
+	_, err = migrationTokenValid(s, r, projectName, req.Secret, req.Name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Create the instance the same way as a regular instance creation in the project.
+	body, err := json.Marshal(req.InstancesPost)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	createReq, err := migrationTokenCreateRequest(r, projectName, body)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return instancesPost(d, createReq)
+}
+
+// migrationTokenCreateRequest builds the instance creation request for an instance pushed with a migration token.
+// The request is built from scratch so that none of the untrusted client's headers or query parameters (such as the
+// user agent of cluster notifications, which skips the project restrictions) are passed on to instancesPost.
+func migrationTokenCreateRequest(r *http.Request, projectName string, body []byte) (*http.Request, error) {
+	u := api.NewURL().Path(version.APIVersion, "instances").Project(projectName)
+
+	createReq, err := http.NewRequestWithContext(r.Context(), http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	createReq.Header.Set("Content-Type", "application/json")
+	createReq.RemoteAddr = r.RemoteAddr
+	createReq.Host = r.Host
+	createReq.TLS = r.TLS
+
+	return createReq, nil
+}
+
+// migrationTokenConnect connects to the target server of a migration token, validating its certificate against
+// the fingerprint in the token. It returns the client along with the certificate and the address it connected to.
+func migrationTokenConnect(s *state.State, token *api.MigrationToken) (incus.InstanceServer, string, string, error) {
+	var errs []error
+	for _, addr := range token.Addresses {
+		cert, err := localtls.GetRemoteCertificate("https://"+addr, version.UserAgent)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		certDigest := localtls.CertFingerprint(cert)
+		if token.Fingerprint != certDigest {
+			errs = append(errs, fmt.Errorf("Certificate fingerprint mismatch between migration token and %q", addr))
+			continue
+		}
+
+		certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+		args := &incus.ConnectionArgs{
+			TLSServerCert: certPEM,
+			UserAgent:     version.UserAgent,
+			Proxy:         s.Proxy,
+			SkipGetServer: true,
+		}
+
+		target, err := incus.ConnectIncus("https://"+addr, args)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		return target.UseProject(token.Project), certPEM, addr, nil
+	}
+
+	return nil, "", "", fmt.Errorf("Failed connecting to migration target: %v", errs)
+}
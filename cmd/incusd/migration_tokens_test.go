@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	clusterRequest "github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func (suite *containerTestSuite) TestMigrationTokenInstancesPost_Restricted() {
+	err := suite.d.db.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		id, err := cluster.CreateProject(ctx, tx.Tx(), cluster.Project{Name: "restricted"})
+		if err != nil {
+			return err
+		}
+
+		return cluster.CreateProjectConfig(ctx, tx.Tx(), id, map[string]string{"restricted": "true"})
+	})
+	suite.Req.Nil(err)
+
+	op, err := operations.OperationCreate(suite.d.State(), "restricted", operations.OperationClassToken, operationtype.MigrationToken, nil, map[string]any{"secret": "foo", "project": "restricted"}, nil, nil, nil, nil)
+	suite.Req.Nil(err)
+	suite.Req.Nil(op.Start())
+
+	req := api.MigrationTokenInstancesPost{Secret: "foo"}
+	req.Name = "c1"
+	req.Type = api.InstanceTypeContainer
+	req.Source = api.InstanceSource{Type: "migration", Mode: "push"}
+	req.Config = map[string]string{"security.privileged": "true"}
+
+	body, err := json.Marshal(req)
+	suite.Req.Nil(err)
+
+	// The client headers must not allow for the project restrictions to be bypassed.
+	r := httptest.NewRequest(http.MethodPost, "/1.0/migration-tokens/instances?project=restricted&target=foo", strings.NewReader(string(body)))
+	r.Header.Set("User-Agent", clusterRequest.UserAgentNotifier)
+
+	resp := migrationTokenInstancesPost(suite.d, r)
+
+	rec := httptest.NewRecorder()
+	err = resp.Render(rec)
+	suite.Req.Nil(err)
+	suite.NotEqual(http.StatusAccepted, rec.Code)
+	suite.Contains(rec.Body.String(), "Privileged containers are forbidden")
+}
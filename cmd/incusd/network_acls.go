@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -14,7 +15,9 @@ import (
 	"github.com/lxc/incus/v6/internal/server/auth"
 	clusterRequest "github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/network"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
@@ -49,6 +52,12 @@ var networkACLLogCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: networkACLLogGet, AccessHandler: allowPermission(auth.ObjectTypeNetworkACL, auth.EntitlementCanView, "name")},
 }
 
+var networkACLEffectiveCmd = APIEndpoint{
+	Path: "network-acls/{name}/effective",
+
+	Get: APIEndpointAction{Handler: networkACLEffectiveGet, AccessHandler: allowPermission(auth.ObjectTypeNetworkACL, auth.EntitlementCanView, "name")},
+}
+
 // API endpoints.
 
 // swagger:operation GET /1.0/network-acls network-acls network_acls_get
@@ -684,3 +693,183 @@ func networkACLLogGet(d *Daemon, r *http.Request) response.Response {
 
 	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
 }
+
+// swagger:operation GET /1.0/network-acls/{name}/effective network-acls network_acl_effective_get
+//
+//	Get the effective rules of the network ACL for an instance
+//
+//	Gets the rules applied to the traffic of each NIC of the instance using the ACL, either directly or through
+//	its network. The rules of all the ACLs applied to the NIC are listed in the order they're evaluated in,
+//	followed by the default rule catching the unmatched traffic.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: query
+//	    name: instance
+//	    description: Instance name
+//	    type: string
+//	    example: c1
+//	  - in: query
+//	    name: device
+//	    description: NIC device name (all the NICs using the ACL if empty)
+//	    type: string
+//	    example: eth0
+//	responses:
+//	  "200":
+//	    description: Effective rules
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of instance NICs with their effective rules
+//	          items:
+//	            $ref: "#/definitions/NetworkACLEffective"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkACLEffectiveGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instProjectName := request.ProjectParam(r)
+	projectName, _, err := project.NetworkProject(s.DB.Cluster, instProjectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	aclName, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	instName := request.QueryParam(r, "instance")
+	if instName == "" {
+		return response.BadRequest(fmt.Errorf("Instance name is required"))
+	}
+
+	deviceName := request.QueryParam(r, "device")
+
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectInstance(instProjectName, instName), auth.EntitlementCanView)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	_, err = acl.LoadByName(s, projectName, aclName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, instProjectName, instName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Cache the ACLs shared by several NICs.
+	aclInfos := map[string]*api.NetworkACL{}
+	loadACL := func(name string) (*api.NetworkACL, error) {
+		aclInfo, found := aclInfos[name]
+		if found {
+			return aclInfo, nil
+		}
+
+		netACL, err := acl.LoadByName(s, projectName, name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading ACL %q: %w", name, err)
+		}
+
+		aclInfos[name] = netACL.Info()
+
+		return aclInfos[name], nil
+	}
+
+	effective := []api.NetworkACLEffective{}
+
+	for _, dev := range inst.ExpandedDevices().Sorted() {
+		if dev.Config["type"] != "nic" || dev.Config["network"] == "" {
+			continue
+		}
+
+		if deviceName != "" && dev.Name != deviceName {
+			continue
+		}
+
+		n, err := network.LoadByName(s, projectName, dev.Config["network"])
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed loading network %q: %w", dev.Config["network"], err))
+		}
+
+		// Bridge networks only support network assigned ACLs, while OVN NICs combine their own ACLs with the
+		// ones of their network and can override the default rule.
+		var aclNames []string
+		configs := []map[string]string{n.Config()}
+
+		switch n.Type() {
+		case "bridge", "mesh":
+			aclNames = util.SplitNTrimSpace(n.Config()["security.acls"], ",", -1, true)
+		case "ovn":
+			aclNames = util.SplitNTrimSpace(dev.Config["security.acls"], ",", -1, true)
+			for _, name := range util.SplitNTrimSpace(n.Config()["security.acls"], ",", -1, true) {
+				if !slices.Contains(aclNames, name) {
+					aclNames = append(aclNames, name)
+				}
+			}
+
+			configs = append([]map[string]string{dev.Config}, configs...)
+		default:
+			continue
+		}
+
+		if !slices.Contains(aclNames, aclName) {
+			continue
+		}
+
+		nicACLs := make([]*api.NetworkACL, 0, len(aclNames))
+		for _, name := range aclNames {
+			aclInfo, err := loadACL(name)
+			if err != nil {
+				return response.SmartError(err)
+			}
+
+			nicACLs = append(nicACLs, aclInfo)
+		}
+
+		effective = append(effective, api.NetworkACLEffective{
+			Device:  dev.Name,
+			Network: n.Name(),
+			ACLs:    aclNames,
+			Ingress: acl.EffectiveRules("ingress", nicACLs, configs...),
+			Egress:  acl.EffectiveRules("egress", nicACLs, configs...),
+		})
+	}
+
+	if len(effective) == 0 {
+		return response.NotFound(fmt.Errorf("Network ACL %q isn't applied to any NIC of instance %q", aclName, instName))
+	}
+
+	return response.SyncResponse(true, effective)
+}
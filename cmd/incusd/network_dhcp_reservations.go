@@ -0,0 +1,514 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	clusterRequest "github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/request"
+	"github.com/lxc/incus/v6/internal/server/response"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+var networkDHCPReservationsCmd = APIEndpoint{
+	Path: "networks/{networkName}/dhcp-reservations",
+
+	Get:  APIEndpointAction{Handler: networkDHCPReservationsGet, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanView, "networkName")},
+	Post: APIEndpointAction{Handler: networkDHCPReservationsPost, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+}
+
+var networkDHCPReservationCmd = APIEndpoint{
+	Path: "networks/{networkName}/dhcp-reservations/{hwaddr}",
+
+	Delete: APIEndpointAction{Handler: networkDHCPReservationDelete, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+	Get:    APIEndpointAction{Handler: networkDHCPReservationGet, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanView, "networkName")},
+	Put:    APIEndpointAction{Handler: networkDHCPReservationPut, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+	Patch:  APIEndpointAction{Handler: networkDHCPReservationPut, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+}
+
+// networkDHCPReservationLoad loads the network from the request and checks it supports DHCP reservations.
+func networkDHCPReservationLoad(d *Daemon, r *http.Request) (network.Network, error) {
+	s := d.State()
+
+	projectName, reqProject, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return nil, err
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading network: %w", err)
+	}
+
+	// Check if project allows access to network.
+	if !project.NetworkAllowed(reqProject.Config, networkName, n.IsManaged()) {
+		return nil, api.StatusErrorf(http.StatusNotFound, "Network not found")
+	}
+
+	if !n.Info().DHCPReservations {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Network driver %q does not support DHCP reservations", n.Type())
+	}
+
+	return n, nil
+}
+
+// networkDHCPReservationHwaddr returns the normalised MAC address from the request URL.
+func networkDHCPReservationHwaddr(r *http.Request) (string, error) {
+	hwaddr, err := url.PathUnescape(mux.Vars(r)["hwaddr"])
+	if err != nil {
+		return "", err
+	}
+
+	mac, err := net.ParseMAC(hwaddr)
+	if err != nil {
+		return "", api.StatusErrorf(http.StatusBadRequest, "Invalid MAC address %q", hwaddr)
+	}
+
+	return mac.String(), nil
+}
+
+// API endpoints
+
+// swagger:operation GET /1.0/networks/{networkName}/dhcp-reservations network-dhcp-reservations network_dhcp_reservations_get
+//
+//  Get the network DHCP reservations
+//
+//  Returns a list of network DHCP reservations (URLs).
+//
+//  ---
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//  responses:
+//    "200":
+//      description: API endpoints
+//      schema:
+//        type: object
+//        description: Sync response
+//        properties:
+//          type:
+//            type: string
+//            description: Response type
+//            example: sync
+//          status:
+//            type: string
+//            description: Status description
+//            example: Success
+//          status_code:
+//            type: integer
+//            description: Status code
+//            example: 200
+//          metadata:
+//            type: array
+//            description: List of endpoints
+//            items:
+//              type: string
+//            example: |-
+//              [
+//                "/1.0/networks/mybr0/dhcp-reservations/00:16:3e:c4:32:77",
+//                "/1.0/networks/mybr0/dhcp-reservations/00:16:3e:c4:32:78"
+//              ]
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation GET /1.0/networks/{networkName}/dhcp-reservations?recursion=1 network-dhcp-reservations network_dhcp_reservations_get_recursion1
+//
+//	Get the network DHCP reservations
+//
+//	Returns a list of network DHCP reservations (structs).
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of network DHCP reservations
+//	          items:
+//	            $ref: "#/definitions/NetworkDHCPReservation"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkDHCPReservationsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	n, err := networkDHCPReservationLoad(d, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var reservations []api.NetworkDHCPReservation
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		reservations, err = tx.GetNetworkDHCPReservations(ctx, n.ID())
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network DHCP reservations: %w", err))
+	}
+
+	if localUtil.IsRecursionRequest(r) {
+		return response.SyncResponse(true, reservations)
+	}
+
+	reservationURLs := make([]string, 0, len(reservations))
+	for _, reservation := range reservations {
+		reservationURLs = append(reservationURLs, fmt.Sprintf("/%s/networks/%s/dhcp-reservations/%s", version.APIVersion, url.PathEscape(n.Name()), url.PathEscape(reservation.Hwaddr)))
+	}
+
+	return response.SyncResponse(true, reservationURLs)
+}
+
+// swagger:operation POST /1.0/networks/{networkName}/dhcp-reservations network-dhcp-reservations network_dhcp_reservations_post
+//
+//	Add a network DHCP reservation
+//
+//	Creates a new network DHCP reservation.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: reservation
+//	    description: DHCP reservation
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/NetworkDHCPReservationsPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkDHCPReservationsPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	n, err := networkDHCPReservationLoad(d, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Parse the request into a record.
+	req := api.NetworkDHCPReservationsPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req.Normalise() // So we handle the request in normalised/canonical form.
+
+	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
+
+	err = n.DHCPReservationCreate(req, clientType)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating DHCP reservation: %w", err))
+	}
+
+	lc := lifecycle.NetworkDHCPReservationCreated.Event(n, req.Hwaddr, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(n.Project(), lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/networks/{networkName}/dhcp-reservations/{hwaddr} network-dhcp-reservations network_dhcp_reservation_delete
+//
+//	Delete the network DHCP reservation
+//
+//	Removes the network DHCP reservation.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkDHCPReservationDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	n, err := networkDHCPReservationLoad(d, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	hwaddr, err := networkDHCPReservationHwaddr(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
+
+	err = n.DHCPReservationDelete(hwaddr, clientType)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed deleting DHCP reservation: %w", err))
+	}
+
+	s.Events.SendLifecycle(n.Project(), lifecycle.NetworkDHCPReservationDeleted.Event(n, hwaddr, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
+
+// swagger:operation GET /1.0/networks/{networkName}/dhcp-reservations/{hwaddr} network-dhcp-reservations network_dhcp_reservation_get
+//
+//	Get the network DHCP reservation
+//
+//	Gets a specific network DHCP reservation.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: DHCP reservation
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/NetworkDHCPReservation"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkDHCPReservationGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	n, err := networkDHCPReservationLoad(d, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	hwaddr, err := networkDHCPReservationHwaddr(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var reservation *api.NetworkDHCPReservation
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		reservation, err = tx.GetNetworkDHCPReservation(ctx, n.ID(), hwaddr)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponseETag(true, reservation, reservation.Etag())
+}
+
+// swagger:operation PATCH /1.0/networks/{networkName}/dhcp-reservations/{hwaddr} network-dhcp-reservations network_dhcp_reservation_patch
+//
+//  Partially update the network DHCP reservation
+//
+//  Updates a subset of the network DHCP reservation configuration.
+//
+//  ---
+//  consumes:
+//    - application/json
+//  produces:
+//    - application/json
+//  parameters:
+//    - in: query
+//      name: project
+//      description: Project name
+//      type: string
+//      example: default
+//    - in: body
+//      name: reservation
+//      description: DHCP reservation configuration
+//      required: true
+//      schema:
+//        $ref: "#/definitions/NetworkDHCPReservationPut"
+//  responses:
+//    "200":
+//      $ref: "#/responses/EmptySyncResponse"
+//    "400":
+//      $ref: "#/responses/BadRequest"
+//    "403":
+//      $ref: "#/responses/Forbidden"
+//    "412":
+//      $ref: "#/responses/PreconditionFailed"
+//    "500":
+//      $ref: "#/responses/InternalServerError"
+
+// swagger:operation PUT /1.0/networks/{networkName}/dhcp-reservations/{hwaddr} network-dhcp-reservations network_dhcp_reservation_put
+//
+//	Update the network DHCP reservation
+//
+//	Updates the entire network DHCP reservation configuration.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: reservation
+//	    description: DHCP reservation configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/NetworkDHCPReservationPut"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "412":
+//	    $ref: "#/responses/PreconditionFailed"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkDHCPReservationPut(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	n, err := networkDHCPReservationLoad(d, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	hwaddr, err := networkDHCPReservationHwaddr(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var reservation *api.NetworkDHCPReservation
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		reservation, err = tx.GetNetworkDHCPReservation(ctx, n.ID(), hwaddr)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Validate the ETag.
+	err = localUtil.EtagCheck(r, reservation.Etag())
+	if err != nil {
+		return response.PreconditionFailed(err)
+	}
+
+	// Start from the current values when partially updating the reservation.
+	req := api.NetworkDHCPReservationPut{}
+	if r.Method == http.MethodPatch {
+		req = reservation.Writable()
+	}
+
+	// Decode the request.
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	req.Normalise() // So we handle the request in normalised/canonical form.
+
+	clientType := clusterRequest.UserAgentClientType(r.Header.Get("User-Agent"))
+
+	err = n.DHCPReservationUpdate(hwaddr, req, clientType)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed updating DHCP reservation: %w", err))
+	}
+
+	s.Events.SendLifecycle(n.Project(), lifecycle.NetworkDHCPReservationUpdated.Event(n, hwaddr, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// networkGatewayChassis tracks the chassis last seen hosting the gateway of each OVN network, indexed by network ID.
+var networkGatewayChassis = sync.Map{}
+
+// networkGatewayForget forgets the gateway chassis of the networks which aren't in the list.
+func networkGatewayForget(keep map[int64]bool) {
+	networkGatewayChassis.Range(func(key any, value any) bool {
+		if !keep[key.(int64)] {
+			networkGatewayChassis.Delete(key)
+		}
+
+		return true
+	})
+}
+
+func networkGatewayTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		if s.OVNNB == nil {
+			return
+		}
+
+		// In a cluster, the gateways are watched by the leader.
+		if s.ServerClustered {
+			leader, err := d.gateway.LeaderAddress()
+			if err != nil {
+				logger.Error("Failed getting cluster leader for network gateway watch", logger.Ctx{"err": err})
+				return
+			}
+
+			if leader != s.LocalConfig.ClusterAddress() {
+				networkGatewayForget(nil)
+				return
+			}
+		}
+
+		var projectNetworks map[string]map[int64]api.Network
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			projectNetworks, err = tx.GetCreatedNetworks(ctx)
+
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed loading networks for gateway watch", logger.Ctx{"err": err})
+			return
+		}
+
+		seen := map[int64]bool{}
+
+		for projectName, networks := range projectNetworks {
+			for networkID, netInfo := range networks {
+				if netInfo.Type != "ovn" {
+					continue
+				}
+
+				seen[networkID] = true
+
+				n, err := network.LoadByName(s, projectName, netInfo.Name)
+				if err != nil {
+					continue
+				}
+
+				state, err := n.State()
+				if err != nil || state.OVN == nil {
+					continue
+				}
+
+				previous, loaded := networkGatewayChassis.Swap(networkID, state.OVN.Chassis)
+				if !loaded || previous.(string) == state.OVN.Chassis {
+					continue
+				}
+
+				logger.Info("Network gateway moved", logger.Ctx{"project": projectName, "network": netInfo.Name, "chassis": state.OVN.Chassis, "previous": previous})
+
+				s.Events.SendLifecycle(projectName, lifecycle.NetworkGatewayChanged.Event(n, nil, map[string]any{
+					"chassis":  state.OVN.Chassis,
+					"previous": previous,
+				}))
+			}
+		}
+
+		// Forget about the deleted networks.
+		networkGatewayForget(seen)
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := 10 * time.Second
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
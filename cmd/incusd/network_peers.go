@@ -16,6 +16,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/internal/version"
 	"github.com/lxc/incus/v6/shared/api"
@@ -37,6 +38,19 @@ var networkPeerCmd = APIEndpoint{
 	Patch:  APIEndpointAction{Handler: networkPeerPut, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
 }
 
+var networkPeerRequestsCmd = APIEndpoint{
+	Path: "networks/{networkName}/peer-requests",
+
+	Get: APIEndpointAction{Handler: networkPeerRequestsGet, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanView, "networkName")},
+}
+
+var networkPeerRequestCmd = APIEndpoint{
+	Path: "networks/{networkName}/peer-requests/{sourceProject}/{sourceNetwork}",
+
+	Delete: APIEndpointAction{Handler: networkPeerRequestDelete, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+	Post:   APIEndpointAction{Handler: networkPeerRequestPost, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+}
+
 // API endpoints
 
 // swagger:operation GET /1.0/networks/{networkName}/peers network-peers network_peers_get
@@ -568,3 +582,275 @@ func networkPeerPut(d *Daemon, r *http.Request) response.Response {
 
 	return response.EmptySyncResponse
 }
+
+// swagger:operation GET /1.0/networks/{networkName}/peer-requests network-peers network_peer_requests_get
+//
+//	Get the network peering requests
+//
+//	Returns a list of pending network peerings requested by other networks.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    description: API endpoints
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          type: array
+//	          description: List of network peering requests
+//	          items:
+//	            $ref: "#/definitions/NetworkPeerRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkPeerRequestsGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName, reqProject, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+	}
+
+	// Check if project allows access to network.
+	if !project.NetworkAllowed(reqProject.Config, networkName, n.IsManaged()) {
+		return response.SmartError(api.StatusErrorf(http.StatusNotFound, "Network not found"))
+	}
+
+	if !n.Info().Peering {
+		return response.BadRequest(fmt.Errorf("Network driver %q does not support peering", n.Type()))
+	}
+
+	var requests []api.NetworkPeerRequest
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		requests, err = tx.GetNetworkPeerRequests(ctx, n.Project(), n.Name())
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network peering requests: %w", err))
+	}
+
+	return response.SyncResponse(true, requests)
+}
+
+// networkPeerRequestLoad loads the network and the pending peering request from the source network targeting it.
+func networkPeerRequestLoad(s *state.State, r *http.Request) (network.Network, *api.NetworkPeerRequest, error) {
+	projectName, reqProject, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sourceProject, err := url.PathUnescape(mux.Vars(r)["sourceProject"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sourceNetwork, err := url.PathUnescape(mux.Vars(r)["sourceNetwork"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed loading network: %w", err)
+	}
+
+	// Check if project allows access to network.
+	if !project.NetworkAllowed(reqProject.Config, networkName, n.IsManaged()) {
+		return nil, nil, api.StatusErrorf(http.StatusNotFound, "Network not found")
+	}
+
+	if !n.Info().Peering {
+		return nil, nil, api.StatusErrorf(http.StatusBadRequest, "Network driver %q does not support peering", n.Type())
+	}
+
+	var requests []api.NetworkPeerRequest
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		requests, err = tx.GetNetworkPeerRequests(ctx, n.Project(), n.Name())
+
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed loading network peering requests: %w", err)
+	}
+
+	for _, peerRequest := range requests {
+		if peerRequest.SourceProject == sourceProject && peerRequest.SourceNetwork == sourceNetwork {
+			return n, &peerRequest, nil
+		}
+	}
+
+	return nil, nil, api.StatusErrorf(http.StatusNotFound, "Network peering request not found")
+}
+
+// swagger:operation POST /1.0/networks/{networkName}/peer-requests/{sourceProject}/{sourceNetwork} network-peers network_peer_request_post
+//
+//	Approve the network peering request
+//
+//	Creates the mutual network peering requested by the source network.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: peer
+//	    description: Peer
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/NetworkPeerRequestPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkPeerRequestPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	n, peerRequest, err := networkPeerRequestLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Parse the request.
+	req := api.NetworkPeerRequestPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Default to the name used by the requesting network.
+	if req.Name == "" {
+		req.Name = peerRequest.Name
+	}
+
+	peer := api.NetworkPeersPost{
+		NetworkPeerPut: req.NetworkPeerPut,
+		Name:           req.Name,
+		Type:           "local",
+		TargetProject:  peerRequest.SourceProject,
+		TargetNetwork:  peerRequest.SourceNetwork,
+	}
+
+	err = n.PeerCreate(peer)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed creating peer: %w", err))
+	}
+
+	lc := lifecycle.NetworkPeerCreated.Event(n, peer.Name, request.CreateRequestor(r), nil)
+	s.Events.SendLifecycle(n.Project(), lc)
+
+	return response.SyncResponseLocation(true, nil, lc.Source)
+}
+
+// swagger:operation DELETE /1.0/networks/{networkName}/peer-requests/{sourceProject}/{sourceNetwork} network-peers network_peer_request_delete
+//
+//	Reject the network peering request
+//
+//	Removes the pending network peering from the source network.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkPeerRequestDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	_, peerRequest, err := networkPeerRequestLoad(s, r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	sourceNet, err := network.LoadByName(s, peerRequest.SourceProject, peerRequest.SourceNetwork)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading source network: %w", err))
+	}
+
+	err = sourceNet.PeerDelete(peerRequest.Name)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed deleting peer: %w", err))
+	}
+
+	s.Events.SendLifecycle(sourceNet.Project(), lifecycle.NetworkPeerDeleted.Event(sourceNet, peerRequest.Name, request.CreateRequestor(r), nil))
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/network"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// networkUplinkWarningMessage returns the warning message for an uplink network, or an empty string if the usage
+// of all its OVN ranges is below the threshold.
+func networkUplinkWarningMessage(n network.Network, threshold int64) (string, error) {
+	uplink, err := n.UplinkState()
+	if err != nil {
+		return "", err
+	}
+
+	if uplink == nil {
+		return "", nil
+	}
+
+	for _, family := range []string{"inet", "inet6"} {
+		var total, used uint64
+		for _, r := range uplink.Ranges {
+			if r.Family != family {
+				continue
+			}
+
+			total += r.Total
+			used += r.Used
+		}
+
+		// Compare without overflowing on large IPv6 ranges.
+		if total == 0 || float64(used)*100 < float64(total)*float64(threshold) {
+			continue
+		}
+
+		key := "ipv4.ovn.ranges"
+		if family == "inet6" {
+			key = "ipv6.ovn.ranges"
+		}
+
+		return fmt.Sprintf("Network %q has %d of the %d addresses of its %q allocated to OVN networks", n.Name(), used, total, key), nil
+	}
+
+	return "", nil
+}
+
+// networkUplinkWarningsUpdate raises a warning for each uplink network whose OVN ranges are more than the configured
+// percentage allocated and resolves the warnings of the uplinks which are back below it.
+// In a cluster, the uplinks are checked by the leader, the other members resolving the warnings they raised before.
+func networkUplinkWarningsUpdate(ctx context.Context, d *Daemon) error {
+	s := d.State()
+	typeCode := warningtype.NetworkOVNRangesThresholdExceeded
+
+	threshold := s.GlobalConfig.NetworkOVNRangesWarningThreshold()
+	if threshold <= 0 {
+		return warnings.ResolveWarningsByLocalNodeAndType(s.DB.Cluster, typeCode)
+	}
+
+	if s.ServerClustered {
+		leader, err := d.gateway.LeaderAddress()
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster leader: %w", err)
+		}
+
+		if leader != s.LocalConfig.ClusterAddress() {
+			return warnings.ResolveWarningsByLocalNodeAndType(s.DB.Cluster, typeCode)
+		}
+	}
+
+	var networkNames []string
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		// Uplink networks are in the default project.
+		networkNames, err = tx.GetCreatedNetworkNamesByProject(ctx, api.ProjectDefaultName)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading networks: %w", err)
+	}
+
+	for _, networkName := range networkNames {
+		n, err := network.LoadByName(s, api.ProjectDefaultName, networkName)
+		if err != nil {
+			logger.Warn("Failed loading network", logger.Ctx{"network": networkName, "err": err})
+			continue
+		}
+
+		message, err := networkUplinkWarningMessage(n, threshold)
+		if err != nil {
+			logger.Warn("Failed getting uplink network usage", logger.Ctx{"network": networkName, "err": err})
+			continue
+		}
+
+		if message == "" {
+			err = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, n.Project(), typeCode, dbCluster.TypeNetwork, int(n.ID()))
+			if err != nil {
+				logger.Warn("Failed resolving uplink network warning", logger.Ctx{"network": networkName, "err": err})
+			}
+
+			continue
+		}
+
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpsertWarningLocalNode(ctx, n.Project(), dbCluster.TypeNetwork, int(n.ID()), typeCode, message)
+		})
+		if err != nil {
+			logger.Warn("Failed raising uplink network warning", logger.Ctx{"network": networkName, "err": err})
+		}
+	}
+
+	return nil
+}
+
+func networkUplinkWarningsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := networkUplinkWarningsUpdate(ctx, d)
+		if err != nil {
+			logger.Error("Failed updating uplink network warnings", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(15 * time.Minute)
+}
@@ -62,6 +62,12 @@ var networkCmd = APIEndpoint{
 	Put:    APIEndpointAction{Handler: networkPut, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
 }
 
+var networkGatewayCmd = APIEndpoint{
+	Path: "networks/{networkName}/gateway",
+
+	Post: APIEndpointAction{Handler: networkGatewayPost, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanEdit, "networkName")},
+}
+
 var networkLeasesCmd = APIEndpoint{
 	Path: "networks/{networkName}/leases",
 
@@ -74,6 +80,12 @@ var networkStateCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: networkStateGet, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanView, "networkName")},
 }
 
+var networkTraceCmd = APIEndpoint{
+	Path: "networks/{networkName}/trace",
+
+	Post: APIEndpointAction{Handler: networkTracePost, AccessHandler: allowPermission(auth.ObjectTypeNetwork, auth.EntitlementCanView, "networkName")},
+}
+
 // API endpoints
 
 // swagger:operation GET /1.0/networks networks networks_get
@@ -1919,3 +1931,241 @@ func networkStateGet(d *Daemon, r *http.Request) response.Response {
 
 	return response.SyncResponse(true, state)
 }
+
+// swagger:operation POST /1.0/networks/{name}/trace networks networks_trace_post
+//
+//	Trace a packet through the network
+//
+//	Traces the path of a packet sent by an instance NIC connected to the network and returns the verdict.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: trace
+//	    description: Packet to trace
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/NetworkTracePost"
+//	responses:
+//	  "200":
+//	    description: Packet trace
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/NetworkTrace"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkTracePost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	instProjectName := request.ProjectParam(r)
+	projectName, _, err := project.NetworkProject(s.DB.Cluster, instProjectName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.NetworkTracePost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Instance == "" {
+		return response.BadRequest(fmt.Errorf("Instance name is required"))
+	}
+
+	if net.ParseIP(req.Destination) == nil {
+		return response.BadRequest(fmt.Errorf("Invalid destination address %q", req.Destination))
+	}
+
+	if req.Protocol == "" {
+		req.Protocol = "tcp"
+	}
+
+	switch req.Protocol {
+	case "tcp", "udp":
+		if req.DestinationPort == 0 || req.DestinationPort > 65535 {
+			return response.BadRequest(fmt.Errorf("Invalid destination port %d", req.DestinationPort))
+		}
+
+		if req.SourcePort == 0 {
+			req.SourcePort = 49152
+		} else if req.SourcePort > 65535 {
+			return response.BadRequest(fmt.Errorf("Invalid source port %d", req.SourcePort))
+		}
+
+	case "icmp4", "icmp6":
+		req.SourcePort = 0
+		req.DestinationPort = 0
+	default:
+		return response.BadRequest(fmt.Errorf("Invalid protocol %q", req.Protocol))
+	}
+
+	err = s.Authorizer.CheckPermission(r.Context(), r, auth.ObjectInstance(instProjectName, req.Instance), auth.EntitlementCanView)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// The trace relies on the state of the NIC, so run it on the member hosting the instance.
+	client, err := cluster.ConnectIfInstanceIsRemote(s, instProjectName, req.Instance, r, instancetype.Any)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if client != nil {
+		result, err := client.UseProject(instProjectName).TraceNetwork(networkName, req)
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		return response.SyncResponse(true, result)
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, instProjectName, req.Instance)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	// Find the NIC connected to the network.
+	found := false
+	for _, dev := range inst.ExpandedDevices().Sorted() {
+		if dev.Config["type"] != "nic" || dev.Config["network"] != n.Name() {
+			continue
+		}
+
+		if req.Device == "" || req.Device == dev.Name {
+			req.Device = dev.Name
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return response.BadRequest(fmt.Errorf("Instance %q has no matching NIC connected to network %q", req.Instance, n.Name()))
+	}
+
+	result, err := n.Trace(inst, req)
+	if err != nil {
+		if errors.Is(err, network.ErrNotImplemented) {
+			return response.NotImplemented(fmt.Errorf("Network driver %q does not support packet tracing", n.Type()))
+		}
+
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// swagger:operation POST /1.0/networks/{name}/gateway networks networks_gateway_post
+//
+//	Move the network gateway
+//
+//	Moves the gateway of an OVN network to another chassis of its chassis group.
+//	The move lasts until the network is next started on the chassis.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: gateway
+//	    description: Chassis to move the gateway to
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/NetworkGatewayPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func networkGatewayPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	projectName, _, err := project.NetworkProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	networkName, err := url.PathUnescape(mux.Vars(r)["networkName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.NetworkGatewayPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if req.Chassis == "" {
+		return response.BadRequest(fmt.Errorf("Chassis name is required"))
+	}
+
+	n, err := network.LoadByName(s, projectName, networkName)
+	if err != nil {
+		return response.SmartError(fmt.Errorf("Failed loading network: %w", err))
+	}
+
+	err = n.SetGatewayChassis(req.Chassis)
+	if err != nil {
+		if errors.Is(err, network.ErrNotImplemented) {
+			return response.NotImplemented(fmt.Errorf("Network driver %q does not support moving the gateway", n.Type()))
+		}
+
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"sort"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -51,6 +52,12 @@ var profileCmd = APIEndpoint{
 	Put:    APIEndpointAction{Handler: profilePut, AccessHandler: allowPermission(auth.ObjectTypeProfile, auth.EntitlementCanEdit, "name")},
 }
 
+var profilePreviewCmd = APIEndpoint{
+	Path: "profiles/{name}/preview",
+
+	Get: APIEndpointAction{Handler: profilePreviewGet, AccessHandler: allowPermission(auth.ObjectTypeProfile, auth.EntitlementCanEdit, "name")},
+}
+
 // swagger:operation GET /1.0/profiles profiles profiles_get
 //
 //  Get the profiles
@@ -242,6 +249,17 @@ func profileUsedBy(ctx context.Context, tx *db.ClusterTx, profile dbCluster.Prof
 		usedBy[i] = apiInst.URL(version.APIVersion, inst.Project).String()
 	}
 
+	// Profiles inheriting from this profile.
+	children, err := dbCluster.GetProfileChildren(ctx, tx.Tx(), profile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		apiProfile := &api.Profile{Name: child}
+		usedBy = append(usedBy, apiProfile.URL(version.APIVersion, profile.Project).String())
+	}
+
 	return usedBy, nil
 }
 
@@ -348,7 +366,12 @@ func profilesPost(d *Daemon, r *http.Request) response.Response {
 			return err
 		}
 
-		return err
+		err = profileValidateParents(ctx, tx, p.Name, req.Name, req.Parents)
+		if err != nil {
+			return err
+		}
+
+		return dbCluster.UpdateProfileParents(ctx, tx.Tx(), p.Name, int(id), req.Parents)
 	})
 	if err != nil {
 		return response.SmartError(fmt.Errorf("Error inserting %q into database: %w", req.Name, err))
@@ -445,7 +468,7 @@ func profileGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
-	etag := []any{resp.Config, resp.Description, resp.Devices}
+	etag := []any{resp.Config, resp.Description, resp.Devices, resp.Parents}
 	return response.SyncResponseETag(true, resp, etag)
 }
 
@@ -532,7 +555,7 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Validate the ETag.
-	etag := []any{profile.Config, profile.Description, profile.Devices}
+	etag := []any{profile.Config, profile.Description, profile.Devices, profile.Parents}
 	err = localUtil.EtagCheck(r, etag)
 	if err != nil {
 		return response.PreconditionFailed(err)
@@ -567,6 +590,132 @@ func profilePut(d *Daemon, r *http.Request) response.Response {
 	return response.SmartError(err)
 }
 
+// swagger:operation GET /1.0/profiles/{name}/preview profiles profile_preview_get
+//
+//	Preview a profile update
+//
+//	Returns the instances whose effective configuration or devices would be changed by
+//	the supplied profile configuration, along with whether a restart is needed for the
+//	changes to take effect. The profile itself isn't modified.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: project
+//	    description: Project name
+//	    type: string
+//	    example: default
+//	  - in: body
+//	    name: profile
+//	    description: Proposed profile configuration
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/ProfilePut"
+//	responses:
+//	  "200":
+//	    description: Profile update preview
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProfilePreview"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func profilePreviewGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	p, err := project.ProfileProject(s.DB.Cluster, request.ProjectParam(r))
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.ProfilePut{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	// Quick checks.
+	err = instance.ValidConfig(s.OS, req.Config, false, instancetype.Any)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = instance.ValidDevices(s, *p, instancetype.Any, deviceConfig.NewDevices(req.Devices), nil)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := dbCluster.GetProfileID(ctx, tx.Tx(), p.Name, name)
+		if err != nil {
+			return err
+		}
+
+		return profileValidateParents(ctx, tx, p.Name, name, req.Parents)
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	insts, projects, err := getProfileInstancesInfo(r.Context(), s.DB.Cluster, p.Name, name)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	preview := api.ProfilePreview{Instances: []api.ProfilePreviewInstance{}}
+	for _, inst := range insts {
+		instPreview, err := profilePreviewInstance(r.Context(), s, p.Name, name, req, inst, *projects[inst.Project])
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed previewing changes to instance %q in project %q: %w", inst.Name, inst.Project, err))
+		}
+
+		if len(instPreview.Config) == 0 && len(instPreview.Devices) == 0 {
+			continue
+		}
+
+		preview.Instances = append(preview.Instances, *instPreview)
+	}
+
+	sort.Slice(preview.Instances, func(i, j int) bool {
+		if preview.Instances[i].Project != preview.Instances[j].Project {
+			return preview.Instances[i].Project < preview.Instances[j].Project
+		}
+
+		return preview.Instances[i].Name < preview.Instances[j].Name
+	})
+
+	return response.SyncResponse(true, preview)
+}
+
 // swagger:operation PATCH /1.0/profiles/{name} profiles profile_patch
 //
 //	Partially update the profile
@@ -637,7 +786,7 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 	}
 
 	// Validate the ETag.
-	etag := []any{profile.Config, profile.Description, profile.Devices}
+	etag := []any{profile.Config, profile.Description, profile.Devices, profile.Parents}
 	err = localUtil.EtagCheck(r, etag)
 	if err != nil {
 		return response.PreconditionFailed(err)
@@ -681,6 +830,11 @@ func profilePatch(d *Daemon, r *http.Request) response.Response {
 		}
 	}
 
+	// Get Parents.
+	if req.Parents == nil {
+		req.Parents = profile.Parents
+	}
+
 	// Get Devices.
 	if req.Devices == nil {
 		req.Devices = profile.Devices
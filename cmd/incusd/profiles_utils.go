@@ -3,12 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"sort"
 
 	internalInstance "github.com/lxc/incus/v6/internal/instance"
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/device"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/drivers"
 	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -43,45 +48,23 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 	}
 
 	// Check if the root disk device's pool would be changed or removed and prevent that if there are instances
-	// using that root disk device.
-	oldProfileRootDiskDeviceKey, oldProfileRootDiskDevice, _ := internalInstance.GetRootDiskDevice(profile.Devices)
-	_, newProfileRootDiskDevice, _ := internalInstance.GetRootDiskDevice(req.Devices)
-	if len(insts) > 0 && oldProfileRootDiskDevice["pool"] != "" && newProfileRootDiskDevice["pool"] == "" || (oldProfileRootDiskDevice["pool"] != newProfileRootDiskDevice["pool"]) {
-		// Check for instances using the device.
-		for _, inst := range insts {
-			// Check if the device is locally overridden.
-			k, v, _ := internalInstance.GetRootDiskDevice(inst.Devices.CloneNative())
-			if k != "" && v["pool"] != "" {
-				continue
-			}
-
-			err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
-				// Check what profile the device comes from by working backwards along the profiles list.
-				for i := len(inst.Profiles) - 1; i >= 0; i-- {
-					_, profile, err := tx.GetProfile(ctx, p.Name, inst.Profiles[i].Name)
-					if err != nil {
-						return err
-					}
+	// using that root disk device, either directly or through a profile inheriting from this one.
+	for _, inst := range insts {
+		// Check if the device is locally overridden.
+		k, v, _ := internalInstance.GetRootDiskDevice(inst.Devices.CloneNative())
+		if k != "" && v["pool"] != "" {
+			continue
+		}
 
-					// Check if we find a match for the device.
-					_, ok := profile.Devices[oldProfileRootDiskDeviceKey]
-					if ok {
-						// Found the profile.
-						if inst.Profiles[i].Name == profileName {
-							// If it's the current profile, then we can't modify that root device.
-							return fmt.Errorf("At least one instance relies on this profile's root disk device")
-						}
-
-						// If it's not, then move on to the next instance.
-						break
-					}
-				}
+		newProfiles, err := profileInstanceProfilesWith(ctx, s, p.Name, profileName, req, inst.Profiles)
+		if err != nil {
+			return err
+		}
 
-				return nil
-			})
-			if err != nil {
-				return err
-			}
+		_, oldRootDiskDevice, _ := internalInstance.GetRootDiskDevice(db.ExpandInstanceDevices(inst.Devices, inst.Profiles).CloneNative())
+		_, newRootDiskDevice, _ := internalInstance.GetRootDiskDevice(db.ExpandInstanceDevices(inst.Devices, newProfiles).CloneNative())
+		if oldRootDiskDevice["pool"] != "" && oldRootDiskDevice["pool"] != newRootDiskDevice["pool"] {
+			return fmt.Errorf("At least one instance relies on this profile's root disk device")
 		}
 	}
 
@@ -116,6 +99,16 @@ func doProfileUpdate(ctx context.Context, s *state.State, p api.Project, profile
 			return err
 		}
 
+		err = profileValidateParents(ctx, tx, p.Name, profileName, req.Parents)
+		if err != nil {
+			return err
+		}
+
+		err = cluster.UpdateProfileParents(ctx, tx.Tx(), p.Name, int(id), req.Parents)
+		if err != nil {
+			return err
+		}
+
 		newProfiles, err := cluster.GetProfilesIfEnabled(ctx, tx.Tx(), p.Name, []string{profileName})
 		if err != nil {
 			return err
@@ -181,10 +174,18 @@ func doProfileUpdateCluster(ctx context.Context, s *state.State, projectName str
 				// doProfileUpdateInstance will detect the changes and apply them.
 				inst.Profiles[i].Config = old.Config
 				inst.Profiles[i].Devices = old.Devices
+				inst.Profiles[i].Parents = old.Parents
 				break
 			}
 		}
 
+		// Same for the profiles inheriting the old config and devices.
+		err = profileExpandWith(ctx, s, projectName, profileName, old, inst.Profiles)
+		if err != nil {
+			failures[&inst] = err
+			continue
+		}
+
 		err := doProfileUpdateInstance(ctx, s, inst, *projects[inst.Project])
 		if err != nil {
 			failures[&inst] = err
@@ -203,6 +204,224 @@ func doProfileUpdateCluster(ctx context.Context, s *state.State, projectName str
 	return nil
 }
 
+// profileExpandWith re-computes the expanded config and devices of the given profiles using the supplied
+// version of the updated profile.
+func profileExpandWith(ctx context.Context, s *state.State, projectName string, profileName string, put api.ProfilePut, profiles []api.Profile) error {
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		getProfile := func(name string) (*api.Profile, error) {
+			if name == profileName {
+				return &api.Profile{Name: name, ProfilePut: put}, nil
+			}
+
+			profile, err := cluster.GetProfile(ctx, tx.Tx(), projectName, name)
+			if err != nil {
+				return nil, err
+			}
+
+			return profile.ToAPI(ctx, tx.Tx())
+		}
+
+		for i := range profiles {
+			if len(profiles[i].Parents) == 0 {
+				profiles[i].ExpandedConfig = nil
+				profiles[i].ExpandedDevices = nil
+				continue
+			}
+
+			var err error
+			profiles[i].ExpandedConfig, profiles[i].ExpandedDevices, err = cluster.ExpandProfile(profiles[i], getProfile)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// profileInstanceProfilesWith returns a copy of an instance's profiles using the supplied version of the
+// updated profile, with the profiles inheriting from it expanded accordingly.
+func profileInstanceProfilesWith(ctx context.Context, s *state.State, projectName string, profileName string, put api.ProfilePut, profiles []api.Profile) ([]api.Profile, error) {
+	newProfiles := slices.Clone(profiles)
+	for i := range newProfiles {
+		if newProfiles[i].Name == profileName {
+			newProfiles[i].ProfilePut = put
+		}
+	}
+
+	err := profileExpandWith(ctx, s, projectName, profileName, put, newProfiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProfiles, nil
+}
+
+// profileValidateParents checks that the parent profiles exist and don't introduce an inheritance loop.
+func profileValidateParents(ctx context.Context, tx *db.ClusterTx, projectName string, profileName string, parents []string) error {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	descendants, err := cluster.GetProfileDescendants(ctx, tx.Tx(), projectName, profileName)
+	if err != nil {
+		return err
+	}
+
+	for i, parent := range parents {
+		if slices.Contains(parents[:i], parent) {
+			return api.StatusErrorf(http.StatusBadRequest, "Parent profile %q is listed more than once", parent)
+		}
+
+		if parent == profileName || slices.Contains(descendants, parent) {
+			return api.StatusErrorf(http.StatusBadRequest, "Parent profile %q would cause an inheritance loop", parent)
+		}
+
+		_, err := cluster.GetProfileID(ctx, tx.Tx(), projectName, parent)
+		if err != nil {
+			if api.StatusErrorCheck(err, http.StatusNotFound) {
+				return api.StatusErrorf(http.StatusBadRequest, "Parent profile %q doesn't exist", parent)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// profilePreviewInstance computes the changes the supplied version of the profile would make to the
+// effective configuration and devices of the instance.
+func profilePreviewInstance(ctx context.Context, s *state.State, projectName string, profileName string, req api.ProfilePut, args db.InstanceArgs, p api.Project) (*api.ProfilePreviewInstance, error) {
+	// Build the instance's profile list using the proposed version of the profile.
+	newProfiles, err := profileInstanceProfilesWith(ctx, s, projectName, profileName, req, args.Profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	inst, err := instance.Load(s, args, p)
+	if err != nil {
+		return nil, err
+	}
+
+	// The state of instances on other members isn't known, so assume they are running.
+	isRunning := true
+	if args.Node == "" || args.Node == s.ServerName {
+		isRunning = inst.IsRunning()
+	}
+
+	preview := &api.ProfilePreviewInstance{
+		Name:     args.Name,
+		Project:  args.Project,
+		Location: args.Node,
+		Config:   []api.ProfilePreviewConfigChange{},
+		Devices:  []api.ProfilePreviewDeviceChange{},
+	}
+
+	// Config changes.
+	oldConfig := db.ExpandInstanceConfig(args.Config, args.Profiles)
+	newConfig := db.ExpandInstanceConfig(args.Config, newProfiles)
+
+	configKeys := make([]string, 0, len(oldConfig)+len(newConfig))
+	for key := range oldConfig {
+		configKeys = append(configKeys, key)
+	}
+
+	for key := range newConfig {
+		_, found := oldConfig[key]
+		if !found {
+			configKeys = append(configKeys, key)
+		}
+	}
+
+	sort.Strings(configKeys)
+
+	for _, key := range configKeys {
+		if oldConfig[key] == newConfig[key] {
+			continue
+		}
+
+		liveUpdatable := drivers.ContainerConfigKeyLiveUpdatable(key)
+		if args.Type == instancetype.VM {
+			liveUpdatable = drivers.VMConfigKeyLiveUpdatable(key)
+		}
+
+		preview.Config = append(preview.Config, api.ProfilePreviewConfigChange{
+			Key:             key,
+			OldValue:        oldConfig[key],
+			NewValue:        newConfig[key],
+			RestartRequired: isRunning && !liveUpdatable,
+		})
+	}
+
+	// Device changes.
+	oldDevices := db.ExpandInstanceDevices(args.Devices, args.Profiles)
+	newDevices := db.ExpandInstanceDevices(args.Devices, newProfiles)
+
+	removeDevices, addDevices, updateDevices, _ := oldDevices.Update(newDevices, func(oldDevice deviceConfig.Device, newDevice deviceConfig.Device) []string {
+		oldDevType, err := device.LoadByType(s, args.Project, oldDevice)
+		if err != nil {
+			return []string{} // Couldn't create Device, so this cannot be an update.
+		}
+
+		newDevType, err := device.LoadByType(s, args.Project, newDevice)
+		if err != nil {
+			return []string{} // Couldn't create Device, so this cannot be an update.
+		}
+
+		return newDevType.UpdatableFields(oldDevType)
+	})
+
+	canHotPlug := func(name string, conf deviceConfig.Device) bool {
+		volatileGet := func() map[string]string { return map[string]string{} }
+		volatileSet := func(map[string]string) error { return nil }
+
+		dev, _ := device.New(inst, s, name, conf.Clone(), volatileGet, volatileSet)
+
+		return dev != nil && dev.CanHotPlug()
+	}
+
+	deviceNames := make([]string, 0, len(removeDevices)+len(addDevices)+len(updateDevices))
+	for _, devices := range []deviceConfig.Devices{removeDevices, addDevices, updateDevices} {
+		for name := range devices {
+			if !slices.Contains(deviceNames, name) {
+				deviceNames = append(deviceNames, name)
+			}
+		}
+	}
+
+	sort.Strings(deviceNames)
+
+	for _, name := range deviceNames {
+		removeDevice, removed := removeDevices[name]
+		addDevice, added := addDevices[name]
+
+		change := api.ProfilePreviewDeviceChange{Name: name, Change: "updated"}
+		if removed && !added {
+			change.Change = "removed"
+		} else if added && !removed {
+			change.Change = "added"
+		}
+
+		// Devices which can't be hot-plugged are only removed or added when the instance starts.
+		if isRunning && ((removed && !canHotPlug(name, removeDevice)) || (added && !canHotPlug(name, addDevice))) {
+			change.RestartRequired = true
+		}
+
+		preview.Devices = append(preview.Devices, change)
+	}
+
+	for _, change := range preview.Config {
+		preview.RestartRequired = preview.RestartRequired || change.RestartRequired
+	}
+
+	for _, change := range preview.Devices {
+		preview.RestartRequired = preview.RestartRequired || change.RestartRequired
+	}
+
+	return preview, nil
+}
+
 // Profile update of a single instance.
 func doProfileUpdateInstance(ctx context.Context, s *state.State, args db.InstanceArgs, p api.Project) error {
 	profileNames := make([]string, 0, len(args.Profiles))
@@ -253,8 +472,32 @@ func getProfileInstancesInfo(ctx context.Context, dbCluster *db.Cluster, project
 		var err error
 
 		projectInstNames, err = tx.GetInstancesWithProfile(ctx, projectName, profileName)
+		if err != nil {
+			return err
+		}
 
-		return err
+		// Include the instances using profiles which inherit from this one.
+		descendants, err := cluster.GetProfileDescendants(ctx, tx.Tx(), projectName, profileName)
+		if err != nil {
+			return err
+		}
+
+		for _, descendant := range descendants {
+			descendantInstNames, err := tx.GetInstancesWithProfile(ctx, projectName, descendant)
+			if err != nil {
+				return err
+			}
+
+			for instProject, instNames := range descendantInstNames {
+				for _, instName := range instNames {
+					if !slices.Contains(projectInstNames[instProject], instName) {
+						projectInstNames[instProject] = append(projectInstNames[instProject], instName)
+					}
+				}
+			}
+		}
+
+		return nil
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to query instances with profile %q: %w", profileName, err)
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	internalInstance "github.com/lxc/incus/v6/internal/instance"
+	"github.com/lxc/incus/v6/internal/server/cluster"
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// projectCleanupVolume is a custom storage volume due for removal by the project cleanup.
+type projectCleanupVolume struct {
+	pool   string
+	volume *db.StorageVolume
+}
+
+// projectCleanupImage is an image due for removal by the project cleanup.
+type projectCleanupImage struct {
+	id   int
+	info *api.Image
+}
+
+// projectCleanupResources are the resources of a project due for removal by its cleanup policy.
+type projectCleanupResources struct {
+	instances []db.InstanceArgs
+	volumes   []projectCleanupVolume
+	images    []projectCleanupImage
+}
+
+// toAPI returns the URLs of the resources due for removal.
+func (r *projectCleanupResources) toAPI(projectName string) *api.ProjectCleanup {
+	cleanup := api.ProjectCleanup{
+		Instances:      []string{},
+		StorageVolumes: []string{},
+		Images:         []string{},
+	}
+
+	for _, inst := range r.instances {
+		cleanup.Instances = append(cleanup.Instances, api.NewURL().Path(version.APIVersion, "instances", inst.Name).Project(projectName).String())
+	}
+
+	for _, vol := range r.volumes {
+		cleanup.StorageVolumes = append(cleanup.StorageVolumes, api.NewURL().Path(version.APIVersion, "storage-pools", vol.pool, "volumes", db.StoragePoolVolumeTypeNameCustom, vol.volume.Name).Project(projectName).Target(vol.volume.Location).String())
+	}
+
+	for _, img := range r.images {
+		cleanup.Images = append(cleanup.Images, api.NewURL().Path(version.APIVersion, "images", img.info.Fingerprint).Project(projectName).String())
+	}
+
+	return &cleanup
+}
+
+// projectCleanupCutoff returns the date before which the resources covered by the given project cleanup key
+// are due for removal. A zero date is returned if the key isn't set.
+func projectCleanupCutoff(config map[string]string, key string) (time.Time, error) {
+	if config[key] == "" {
+		return time.Time{}, nil
+	}
+
+	days, err := strconv.ParseInt(config[key], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %q: %w", key, err)
+	}
+
+	if days <= 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Now().Add(-time.Duration(days) * 24 * time.Hour), nil
+}
+
+// projectCleanupFind returns the resources of the project which are due for removal according to its cleanup policy.
+func projectCleanupFind(ctx context.Context, s *state.State, p *api.Project) (*projectCleanupResources, error) {
+	instancesCutoff, err := projectCleanupCutoff(p.Config, "cleanup.instances.stopped_expiry")
+	if err != nil {
+		return nil, err
+	}
+
+	volumesCutoff, err := projectCleanupCutoff(p.Config, "cleanup.volumes.unused_expiry")
+	if err != nil {
+		return nil, err
+	}
+
+	imagesCutoff, err := projectCleanupCutoff(p.Config, "cleanup.images.unused_expiry")
+	if err != nil {
+		return nil, err
+	}
+
+	resources := &projectCleanupResources{}
+	imageProject := project.ImageProjectFromRecord(p)
+	usedImages := map[string]bool{}
+	var volumes []projectCleanupVolume
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		// Look for stopped instances, also recording which images are in use by instances of any project.
+		err := tx.InstanceList(ctx, func(dbInst db.InstanceArgs, instProject api.Project) error {
+			if project.ImageProjectFromRecord(&instProject) == imageProject && dbInst.Config["volatile.base_image"] != "" {
+				usedImages[dbInst.Config["volatile.base_image"]] = true
+			}
+
+			if instancesCutoff.IsZero() || dbInst.Project != p.Name {
+				return nil
+			}
+
+			config := db.ExpandInstanceConfig(dbInst.Config, dbInst.Profiles)
+			if util.IsTrue(config["security.protection.delete"]) {
+				return nil
+			}
+
+			powerState := config["volatile.last_state.power"]
+			if powerState != "" && powerState != instance.PowerStateStopped {
+				return nil
+			}
+
+			// Instances which were never started are considered from their creation.
+			lastUsed := dbInst.LastUsedDate
+			if lastUsed.IsZero() {
+				lastUsed = dbInst.CreationDate
+			}
+
+			if lastUsed.After(instancesCutoff) {
+				return nil
+			}
+
+			resources.instances = append(resources.instances, dbInst)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Look for old custom volumes, their usage being checked afterwards.
+		if !volumesCutoff.IsZero() && project.StorageVolumeProjectFromRecord(p, db.StoragePoolVolumeTypeCustom) == p.Name {
+			poolNames, err := tx.GetStoragePoolNames(ctx)
+			if err != nil && !response.IsNotFoundError(err) {
+				return err
+			}
+
+			volumeType := db.StoragePoolVolumeTypeCustom
+			filter := db.StorageVolumeFilter{Type: &volumeType, Project: &p.Name}
+
+			for _, poolName := range poolNames {
+				poolID, err := tx.GetStoragePoolID(ctx, poolName)
+				if err != nil {
+					return err
+				}
+
+				dbVolumes, err := tx.GetStoragePoolVolumes(ctx, poolID, false, filter)
+				if err != nil {
+					return fmt.Errorf("Failed loading storage volumes of pool %q: %w", poolName, err)
+				}
+
+				for _, dbVolume := range dbVolumes {
+					if internalInstance.IsSnapshot(dbVolume.Name) || dbVolume.CreatedAt.After(volumesCutoff) {
+						continue
+					}
+
+					volumes = append(volumes, projectCleanupVolume{pool: poolName, volume: dbVolume})
+				}
+			}
+		}
+
+		// Look for unused images without aliases.
+		if !imagesCutoff.IsZero() && imageProject == p.Name {
+			images, err := dbCluster.GetImages(ctx, tx.Tx(), dbCluster.ImageFilter{Project: &p.Name})
+			if err != nil {
+				return fmt.Errorf("Failed loading images: %w", err)
+			}
+
+			for _, image := range images {
+				if usedImages[image.Fingerprint] {
+					continue
+				}
+
+				lastUsed := image.UploadDate
+				if image.LastUseDate.Valid && !image.LastUseDate.Time.IsZero() {
+					lastUsed = image.LastUseDate.Time
+				}
+
+				if lastUsed.After(imagesCutoff) {
+					continue
+				}
+
+				id, info, err := tx.GetImage(ctx, image.Fingerprint, dbCluster.ImageFilter{Project: &p.Name})
+				if err != nil {
+					return err
+				}
+
+				if len(info.Aliases) > 0 {
+					continue
+				}
+
+				resources.images = append(resources.images, projectCleanupImage{id: id, info: info})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vol := range volumes {
+		usedBy, err := storagePoolVolumeUsedByGet(s, p.Name, vol.pool, vol.volume)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(usedBy) > 0 {
+			continue
+		}
+
+		resources.volumes = append(resources.volumes, vol)
+	}
+
+	return resources, nil
+}
+
+// projectCleanupDeleteInstance deletes a stopped instance, on the cluster member it's located on.
+func projectCleanupDeleteInstance(s *state.State, projectName string, dbInst db.InstanceArgs) error {
+	client, err := cluster.ConnectIfInstanceIsRemote(s, projectName, dbInst.Name, nil, dbInst.Type)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		op, err := client.UseProject(projectName).DeleteInstance(dbInst.Name)
+		if err != nil {
+			return err
+		}
+
+		return op.Wait()
+	}
+
+	inst, err := instance.LoadByProjectAndName(s, projectName, dbInst.Name)
+	if err != nil {
+		return err
+	}
+
+	// The instance may have been started since the cleanup started.
+	if inst.IsRunning() {
+		return fmt.Errorf("Instance is running")
+	}
+
+	return inst.Delete(false)
+}
+
+// projectCleanupDeleteVolume deletes an unused custom volume, on the cluster member it's located on.
+func projectCleanupDeleteVolume(s *state.State, op *operations.Operation, projectName string, vol projectCleanupVolume) error {
+	client, err := cluster.ConnectIfVolumeIsRemote(s, vol.pool, projectName, vol.volume.Name, db.StoragePoolVolumeTypeCustom, s.Endpoints.NetworkCert(), s.ServerCert(), nil)
+	if err != nil {
+		return err
+	}
+
+	if client != nil {
+		return client.UseProject(projectName).DeleteStoragePoolVolume(vol.pool, db.StoragePoolVolumeTypeNameCustom, vol.volume.Name)
+	}
+
+	pool, err := storagePools.LoadByName(s, vol.pool)
+	if err != nil {
+		return err
+	}
+
+	return pool.DeleteCustomVolume(projectName, vol.volume.Name, op)
+}
+
+// projectCleanupRun deletes the resources of the project which are due for removal.
+// Failures are logged and don't prevent the removal of the other resources.
+func projectCleanupRun(ctx context.Context, s *state.State, op *operations.Operation, projectName string, resources *projectCleanupResources) error {
+	// Delete the instances first, as they may be the last users of the volumes and images.
+	for _, dbInst := range resources.instances {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := projectCleanupDeleteInstance(s, projectName, dbInst)
+		if err != nil {
+			logger.Warn("Failed deleting stopped instance", logger.Ctx{"project": projectName, "instance": dbInst.Name, "err": err})
+			continue
+		}
+
+		logger.Info("Deleted stopped instance", logger.Ctx{"project": projectName, "instance": dbInst.Name})
+	}
+
+	for _, vol := range resources.volumes {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := projectCleanupDeleteVolume(s, op, projectName, vol)
+		if err != nil {
+			logger.Warn("Failed deleting unused storage volume", logger.Ctx{"project": projectName, "pool": vol.pool, "volume": vol.volume.Name, "err": err})
+			continue
+		}
+
+		logger.Info("Deleted unused storage volume", logger.Ctx{"project": projectName, "pool": vol.pool, "volume": vol.volume.Name})
+	}
+
+	for _, img := range resources.images {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err := doImageDelete(s, op, projectName, img.id, img.info, false)
+		if err != nil {
+			logger.Warn("Failed deleting unused image", logger.Ctx{"project": projectName, "fingerprint": img.info.Fingerprint, "err": err})
+			continue
+		}
+
+		logger.Info("Deleted unused image", logger.Ctx{"project": projectName, "fingerprint": img.info.Fingerprint})
+	}
+
+	return nil
+}
+
+func projectCleanupTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// In a cluster, the cleanups are driven by the leader.
+		if s.ServerClustered {
+			leader, err := d.gateway.LeaderAddress()
+			if err != nil {
+				logger.Error("Failed getting cluster leader for project cleanup", logger.Ctx{"err": err})
+				return
+			}
+
+			if leader != s.LocalConfig.ClusterAddress() {
+				return
+			}
+		}
+
+		var projects []api.Project
+
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			dbProjects, err := dbCluster.GetProjects(ctx, tx.Tx())
+			if err != nil {
+				return err
+			}
+
+			for _, dbProject := range dbProjects {
+				p, err := dbProject.ToAPI(ctx, tx.Tx())
+				if err != nil {
+					return err
+				}
+
+				schedule := p.Config["cleanup.schedule"]
+				if schedule == "" || !snapshotIsScheduledNow(schedule, int64(dbProject.ID)) {
+					continue
+				}
+
+				projects = append(projects, *p)
+			}
+
+			return nil
+		})
+		if err != nil {
+			logger.Error("Failed loading projects for cleanup", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, p := range projects {
+			resources, err := projectCleanupFind(ctx, s, &p)
+			if err != nil {
+				logger.Error("Failed looking for resources to clean up", logger.Ctx{"project": p.Name, "err": err})
+				continue
+			}
+
+			if len(resources.instances) == 0 && len(resources.volumes) == 0 && len(resources.images) == 0 {
+				continue
+			}
+
+			opRun := func(op *operations.Operation) error {
+				return projectCleanupRun(ctx, s, op, p.Name, resources)
+			}
+
+			opResources := map[string][]api.URL{}
+			opResources["projects"] = []api.URL{*api.NewURL().Path(version.APIVersion, "projects", p.Name)}
+
+			op, err := operations.OperationCreate(s, p.Name, operations.OperationClassTask, operationtype.ProjectCleanup, opResources, nil, opRun, nil, nil, nil)
+			if err != nil {
+				logger.Error("Failed creating project cleanup operation", logger.Ctx{"project": p.Name, "err": err})
+				continue
+			}
+
+			logger.Info("Cleaning up project", logger.Ctx{"project": p.Name})
+
+			err = op.Start()
+			if err != nil {
+				logger.Error("Failed starting project cleanup operation", logger.Ctx{"project": p.Name, "err": err})
+				continue
+			}
+
+			err = op.Wait(ctx)
+			if err != nil {
+				logger.Error("Failed cleaning up project", logger.Ctx{"project": p.Name, "err": err})
+				continue
+			}
+
+			logger.Info("Done cleaning up project", logger.Ctx{"project": p.Name})
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
+
+// swagger:operation GET /1.0/projects/{name}/cleanup projects project_cleanup_get
+//
+//	Get the project cleanup report
+//
+//	Lists the resources that the cleanup policy of the project would remove if it ran now, without removing them.
+//
+//	---
+//	produces:
+//	  - application/json
+//	responses:
+//	  "200":
+//	    description: Project cleanup report
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ProjectCleanup"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func projectCleanupGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	var p *api.Project
+
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		dbProject, err := dbCluster.GetProject(ctx, tx.Tx(), name)
+		if err != nil {
+			return err
+		}
+
+		p, err = dbProject.ToAPI(ctx, tx.Tx())
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	resources, err := projectCleanupFind(r.Context(), s, p)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, resources.toAPI(name))
+}
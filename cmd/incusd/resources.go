@@ -7,10 +7,13 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/hugepages"
+	"github.com/lxc/incus/v6/internal/server/request"
 	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/response"
 	storagePools "github.com/lxc/incus/v6/internal/server/storage"
 	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
 )
 
 var api10ResourcesCmd = APIEndpoint{
@@ -19,6 +22,12 @@ var api10ResourcesCmd = APIEndpoint{
 	Get: APIEndpointAction{Handler: api10ResourcesGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewResources)},
 }
 
+var api10ResourcesStateCmd = APIEndpoint{
+	Path: "resources/state",
+
+	Get: APIEndpointAction{Handler: api10ResourcesStateGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanViewResources)},
+}
+
 var storagePoolResourcesCmd = APIEndpoint{
 	Path: "storage-pools/{name}/resources",
 
@@ -40,6 +49,11 @@ var storagePoolResourcesCmd = APIEndpoint{
 //	    description: Cluster member name
 //	    type: string
 //	    example: server01
+//	  - in: query
+//	    name: checks
+//	    description: Include the host compatibility checks
+//	    type: boolean
+//	    example: true
 //	responses:
 //	  "200":
 //	    description: Hardware resources
@@ -80,6 +94,73 @@ func api10ResourcesGet(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	// Account for the huge pages reserved by instances being started.
+	hugepages.Adjust(res.Memory.Hugepages)
+
+	// Include the host compatibility checks if requested.
+	if util.IsTrue(request.QueryParam(r, "checks")) {
+		res.Checks = resourcesChecks(s)
+	}
+
+	return response.SyncResponse(true, res)
+}
+
+// swagger:operation GET /1.0/resources/state server resources_state_get
+//
+//	Get system utilization information
+//
+//	Gets the live CPU, memory, disk and network utilization of the server.
+//	Rates are computed over the time elapsed since the previous request, sampling at most once per second.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: System utilization
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/ResourcesState"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func api10ResourcesStateGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	// Get the local utilization.
+	res, err := resources.GetState()
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	return response.SyncResponse(true, res)
 }
 
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/cgroup"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// resourcesCheck returns a check result, using the message only when the check didn't pass.
+func resourcesCheck(name string, description string, status string, message string) api.ResourcesCheck {
+	check := api.ResourcesCheck{
+		Name:        name,
+		Description: description,
+		Status:      status,
+	}
+
+	if status != api.ResourcesCheckStatusPass {
+		check.Message = message
+	}
+
+	return check
+}
+
+// resourcesCheckStatus returns the pass status if ok is true and the fallback status otherwise.
+func resourcesCheckStatus(ok bool, fallback string) string {
+	if ok {
+		return api.ResourcesCheckStatusPass
+	}
+
+	return fallback
+}
+
+// resourcesChecks reports the availability of the kernel and platform features used by the server.
+func resourcesChecks(s *state.State) []api.ResourcesCheck {
+	checks := []api.ResourcesCheck{}
+
+	// Idmapped mounts.
+	checks = append(checks, resourcesCheck("idmapped_mounts", "Kernel support for idmapped mounts",
+		resourcesCheckStatus(s.OS.IdmappedMounts, api.ResourcesCheckStatusWarn),
+		"Container filesystems will be shifted instead of using idmapped mounts"))
+
+	// io_uring.
+	checks = append(checks, resourcesChecksIOUring(s))
+
+	// Cgroups.
+	cgStatus := api.ResourcesCheckStatusWarn
+	switch s.OS.CGInfo.Layout {
+	case cgroup.CgroupsUnified:
+		cgStatus = api.ResourcesCheckStatusPass
+	case cgroup.CgroupsDisabled:
+		cgStatus = api.ResourcesCheckStatusFail
+	}
+
+	checks = append(checks, resourcesCheck("cgroup_v2", "Unified cgroup hierarchy (cgroup2)", cgStatus,
+		"The cgroup layout is \""+s.OS.CGInfo.Mode()+"\""))
+
+	controllers := []struct {
+		name     string
+		resource cgroup.Resource
+		message  string
+	}{
+		{"blkio", cgroup.Blkio, "Disk I/O limits will be ignored"},
+		{"cpu", cgroup.CPU, "CPU time limits will be ignored"},
+		{"cpuset", cgroup.CPUSet, "CPU pinning will be ignored"},
+		{"devices", cgroup.Devices, "Device access control won't work"},
+		{"freezer", cgroup.Freezer, "Pausing and resuming containers won't work"},
+		{"hugetlb", cgroup.Hugetlb, "Hugepage limits will be ignored"},
+		{"memory", cgroup.Memory, "Memory limits will be ignored"},
+		{"memory_swap", cgroup.MemorySwap, "Swap limits will be ignored"},
+		{"pids", cgroup.Pids, "Process limits will be ignored"},
+	}
+
+	for _, controller := range controllers {
+		checks = append(checks, resourcesCheck("cgroup_"+controller.name, "Cgroup "+controller.name+" controller",
+			resourcesCheckStatus(s.OS.CGInfo.Supports(controller.resource, nil), api.ResourcesCheckStatusWarn),
+			controller.message))
+	}
+
+	// Virtualization.
+	checks = append(checks, resourcesCheck("kvm", "Hardware virtualization (/dev/kvm)",
+		resourcesCheckStatus(util.PathExists("/dev/kvm"), api.ResourcesCheckStatusWarn),
+		"Virtual machines can't be run"))
+
+	checks = append(checks, resourcesCheck("vhost_vsock", "Virtual machine sockets (/dev/vhost-vsock)",
+		resourcesCheckStatus(util.PathExists("/dev/vhost-vsock"), api.ResourcesCheckStatusWarn),
+		"The agent of virtual machines won't be reachable"))
+
+	// Security modules.
+	checks = append(checks, resourcesCheck("apparmor", "AppArmor confinement of the instances",
+		resourcesCheckStatus(s.OS.AppArmorAvailable, api.ResourcesCheckStatusWarn),
+		"Instances won't be confined by AppArmor"))
+
+	checks = append(checks, resourcesChecksSELinux())
+
+	return checks
+}
+
+// resourcesChecksIOUring checks whether io_uring is available to the server.
+func resourcesChecksIOUring(s *state.State) api.ResourcesCheck {
+	name := "io_uring"
+	description := "Kernel support for io_uring"
+
+	// Recent kernels allow restricting io_uring through sysctl.
+	content, err := os.ReadFile("/proc/sys/kernel/io_uring_disabled")
+	if err == nil {
+		switch strings.TrimSpace(string(content)) {
+		case "0":
+			return resourcesCheck(name, description, api.ResourcesCheckStatusPass, "")
+		case "1":
+			return resourcesCheck(name, description, api.ResourcesCheckStatusWarn, "io_uring is restricted to privileged processes by the kernel.io_uring_disabled sysctl")
+		default:
+			return resourcesCheck(name, description, api.ResourcesCheckStatusWarn, "io_uring is disabled by the kernel.io_uring_disabled sysctl")
+		}
+	}
+
+	// Virtual machines only use io_uring starting with 5.13 due to issues with earlier kernels.
+	minVer, _ := version.NewDottedVersion("5.13.0")
+	if s.OS.KernelVersion.Compare(minVer) < 0 {
+		return resourcesCheck(name, description, api.ResourcesCheckStatusWarn, "io_uring requires a kernel version 5.13 or later")
+	}
+
+	return resourcesCheck(name, description, api.ResourcesCheckStatusPass, "")
+}
+
+// resourcesChecksSELinux checks whether SELinux is enforcing on the host.
+func resourcesChecksSELinux() api.ResourcesCheck {
+	name := "selinux"
+	description := "SELinux policy enforcement"
+
+	content, err := os.ReadFile("/sys/fs/selinux/enforce")
+	if err != nil || strings.TrimSpace(string(content)) != "1" {
+		return resourcesCheck(name, description, api.ResourcesCheckStatusPass, "")
+	}
+
+	return resourcesCheck(name, description, api.ResourcesCheckStatusWarn, "SELinux is enforcing, its policy must allow the server and its instances")
+}
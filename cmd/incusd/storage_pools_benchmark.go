@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/response"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var storagePoolBenchmarkCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/benchmark",
+
+	Post: APIEndpointAction{Handler: storagePoolBenchmarkPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/benchmark storage storage_pool_benchmark_post
+//
+//	Benchmark the storage pool
+//
+//	Measures the sequential throughput and random IOPS of the storage pool on the cluster member using a temporary volume.
+//	The result is returned in the operation metadata and recorded in the `volatile.benchmark.*` keys of the pool.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolBenchmarkPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	if pool.Status() != api.StoragePoolStatusCreated {
+		return response.BadRequest(errors.New("Cannot benchmark a storage pool that isn't fully created"))
+	}
+
+	run := func(op *operations.Operation) error {
+		result, err := pool.Benchmark(op)
+		if err != nil {
+			return err
+		}
+
+		// Record the result in the member specific config of the pool.
+		err = storagePoolVolatileSet(context.TODO(), s, pool.Name(), storagePools.BenchmarkConfig(result))
+		if err != nil {
+			logger.Warn("Failed recording storage pool benchmark", logger.Ctx{"pool": pool.Name(), "err": err})
+		}
+
+		return op.UpdateMetadata(result)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["storage_pools"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", pool.Name())}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StoragePoolBenchmark, resources, nil, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
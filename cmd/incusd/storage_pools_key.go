@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/response"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+var storagePoolKeyCmd = APIEndpoint{
+	Path: "storage-pools/{poolName}/key",
+
+	Get:  APIEndpointAction{Handler: storagePoolKeyGet, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanView, "poolName")},
+	Post: APIEndpointAction{Handler: storagePoolKeyPost, AccessHandler: allowPermission(auth.ObjectTypeStoragePool, auth.EntitlementCanEdit, "poolName")},
+}
+
+// swagger:operation GET /1.0/storage-pools/{poolName}/key storage storage_pool_key_get
+//
+//	Get the storage pool encryption key status
+//
+//	Gets the status of the encryption key of the storage pool on the cluster member.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Encryption key status
+//	    schema:
+//	      type: object
+//	      description: Sync response
+//	      properties:
+//	        type:
+//	          type: string
+//	          description: Response type
+//	          example: sync
+//	        status:
+//	          type: string
+//	          description: Status description
+//	          example: Success
+//	        status_code:
+//	          type: integer
+//	          description: Status code
+//	          example: 200
+//	        metadata:
+//	          $ref: "#/definitions/StoragePoolKey"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolKeyGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	key, err := pool.GetKey()
+	if err != nil {
+		if errors.Is(err, storageDrivers.ErrNotSupported) {
+			return response.NotImplemented(fmt.Errorf("Storage pool driver %q doesn't support encryption key management", pool.Driver().Info().Name))
+		}
+
+		return response.SmartError(err)
+	}
+
+	return response.SyncResponse(true, key)
+}
+
+// swagger:operation POST /1.0/storage-pools/{poolName}/key storage storage_pool_key_post
+//
+//	Manage the storage pool encryption key
+//
+//	Loads, unloads or changes the encryption key of the storage pool on the cluster member.
+//	Once its key is loaded, an unavailable storage pool is brought back online.
+//
+//	---
+//	consumes:
+//	  - application/json
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	  - in: body
+//	    name: key
+//	    description: Key action
+//	    required: true
+//	    schema:
+//	      $ref: "#/definitions/StoragePoolKeyPost"
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "400":
+//	    $ref: "#/responses/BadRequest"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func storagePoolKeyPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	poolName, err := url.PathUnescape(mux.Vars(r)["poolName"])
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	req := api.StoragePoolKeyPost{}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	pool, err := storagePools.LoadByName(s, poolName)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	switch req.Action {
+	case "load":
+		err = pool.LoadKey(req.Key)
+	case "unload":
+		err = pool.UnloadKey()
+	case "change":
+		if req.NewKey == "" {
+			return response.BadRequest(fmt.Errorf("A new key is required"))
+		}
+
+		err = pool.ChangeKey(req.NewKey)
+	default:
+		return response.BadRequest(fmt.Errorf("Invalid key action %q", req.Action))
+	}
+
+	if err != nil {
+		if errors.Is(err, storageDrivers.ErrNotSupported) {
+			return response.NotImplemented(fmt.Errorf("Storage pool driver %q doesn't support encryption key management", pool.Driver().Info().Name))
+		}
+
+		return response.SmartError(err)
+	}
+
+	logger.Info("Updated storage pool encryption key", logger.Ctx{"pool": pool.Name(), "action": req.Action})
+
+	// Bring the pool online now that its key is available.
+	if req.Action == "load" {
+		_, err = pool.Mount()
+		if err != nil {
+			return response.SmartError(fmt.Errorf("Failed mounting storage pool: %w", err))
+		}
+
+		_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolUnvailable, cluster.TypeStoragePool, int(pool.ID()))
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// storagePoolMaintenanceRunning tracks the maintenance runs in progress, so that slow scrubs don't pile up.
+var storagePoolMaintenanceRunning = sync.Map{}
+
+// storagePoolMaintenanceActions lists the maintenance actions which can be scheduled through the
+// <driver>.<action>.schedule config keys of the pools.
+var storagePoolMaintenanceActions = []string{"scrub", "balance"}
+
+// storagePoolMaintenanceRun runs a maintenance action on the pool, recording its outcome in the pool config and
+// in warnings.
+func storagePoolMaintenanceRun(s *state.State, pool storagePools.Pool, action string, op *operations.Operation) error {
+	l := logger.AddContext(logger.Ctx{"pool": pool.Name(), "action": action})
+	l.Info("Running storage pool maintenance")
+
+	start := time.Now()
+	volatile := map[string]string{}
+	var errorCount int64
+	var err error
+
+	switch action {
+	case "scrub":
+		errorCount, err = pool.Scrub(op)
+		volatile[storagePools.ScrubConfigLastRun] = storagePools.MaintenanceDate(start)
+		volatile[storagePools.ScrubConfigLastStatus] = storagePools.MaintenanceStatus(err)
+		volatile[storagePools.ScrubConfigLastErrors] = strconv.FormatInt(errorCount, 10)
+	case "balance":
+		err = pool.Balance(op)
+		volatile[storagePools.BalanceConfigLastRun] = storagePools.MaintenanceDate(start)
+		volatile[storagePools.BalanceConfigLastStatus] = storagePools.MaintenanceStatus(err)
+	default:
+		return fmt.Errorf("Unknown storage pool maintenance action %q", action)
+	}
+
+	recordErr := storagePoolVolatileSet(context.TODO(), s, pool.Name(), volatile)
+	if recordErr != nil {
+		l.Warn("Failed recording storage pool maintenance", logger.Ctx{"err": recordErr})
+	}
+
+	// Raise or resolve the warnings.
+	if action == "scrub" && err == nil {
+		if errorCount > 0 {
+			storagePoolMaintenanceWarn(s, pool, warningtype.StoragePoolScrubErrors, fmt.Sprintf("Scrub of storage pool %q found %d errors", pool.Name(), errorCount))
+		} else {
+			_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolScrubErrors, dbCluster.TypeStoragePool, int(pool.ID()))
+		}
+	}
+
+	if err != nil {
+		storagePoolMaintenanceWarn(s, pool, warningtype.StoragePoolMaintenanceFailed, fmt.Sprintf("Failed running %s on storage pool %q: %v", action, pool.Name(), err))
+		return fmt.Errorf("Failed running %s on storage pool %q: %w", action, pool.Name(), err)
+	}
+
+	_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningtype.StoragePoolMaintenanceFailed, dbCluster.TypeStoragePool, int(pool.ID()))
+	l.Info("Finished storage pool maintenance", logger.Ctx{"duration": time.Since(start).Round(time.Second)})
+
+	return nil
+}
+
+// storagePoolMaintenanceWarn raises a warning about the pool on the local member.
+func storagePoolMaintenanceWarn(s *state.State, pool storagePools.Pool, warningType warningtype.Type, message string) {
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		return tx.UpsertWarningLocalNode(ctx, "", dbCluster.TypeStoragePool, int(pool.ID()), warningType, message)
+	})
+	if err != nil {
+		logger.Warn("Failed creating storage pool maintenance warning", logger.Ctx{"pool": pool.Name(), "err": err})
+	}
+}
+
+// storagePoolMaintenanceTask runs the scheduled maintenance of the storage pools of this member (minutely check
+// of their cron expressions).
+func storagePoolMaintenanceTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		var poolNames []string
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed loading storage pools for scheduled maintenance", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, poolName := range poolNames {
+			pool, err := storagePools.LoadByName(s, poolName)
+			if err != nil {
+				logger.Error("Failed loading storage pool for scheduled maintenance", logger.Ctx{"pool": poolName, "err": err})
+				continue
+			}
+
+			if pool.Status() != api.StoragePoolStatusCreated {
+				continue
+			}
+
+			for _, action := range storagePoolMaintenanceActions {
+				action := action
+
+				schedule := pool.Driver().Config()[fmt.Sprintf("%s.%s.schedule", pool.Driver().Info().Name, action)]
+				if schedule == "" || !snapshotIsScheduledNow(schedule, pool.ID()) {
+					continue
+				}
+
+				key := fmt.Sprintf("%d/%s", pool.ID(), action)
+				_, loaded := storagePoolMaintenanceRunning.LoadOrStore(key, struct{}{})
+				if loaded {
+					logger.Warn("Skipping storage pool maintenance still running from its previous run", logger.Ctx{"pool": pool.Name(), "action": action})
+					continue
+				}
+
+				opRun := func(op *operations.Operation) error {
+					defer storagePoolMaintenanceRunning.Delete(key)
+
+					return storagePoolMaintenanceRun(s, pool, action, op)
+				}
+
+				resources := map[string][]api.URL{}
+				resources["storage_pools"] = []api.URL{*api.NewURL().Path(version.APIVersion, "storage-pools", pool.Name())}
+
+				op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.StoragePoolMaintenance, resources, nil, opRun, nil, nil, nil)
+				if err != nil {
+					storagePoolMaintenanceRunning.Delete(key)
+					logger.Error("Failed creating storage pool maintenance operation", logger.Ctx{"pool": pool.Name(), "action": action, "err": err})
+					continue
+				}
+
+				err = op.Start()
+				if err != nil {
+					storagePoolMaintenanceRunning.Delete(key)
+					logger.Error("Failed starting storage pool maintenance operation", logger.Ctx{"pool": pool.Name(), "action": action, "err": err})
+				}
+			}
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
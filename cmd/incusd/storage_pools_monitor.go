@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// storagePoolMonitorTask checks the health of the storage pools of this member, letting the drivers remediate what
+// they can (e.g. extending LVM thin pools) and raising warnings for the rest.
+func storagePoolMonitorTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		var poolNames []string
+		err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			poolNames, err = tx.GetCreatedStoragePoolNames(ctx)
+
+			return err
+		})
+		if err != nil {
+			logger.Error("Failed loading storage pools for monitoring", logger.Ctx{"err": err})
+			return
+		}
+
+		for _, poolName := range poolNames {
+			pool, err := storagePools.LoadByName(s, poolName)
+			if err != nil {
+				logger.Error("Failed loading storage pool for monitoring", logger.Ctx{"pool": poolName, "err": err})
+				continue
+			}
+
+			if pool.Status() != api.StoragePoolStatusCreated {
+				continue
+			}
+
+			alert, err := pool.Monitor()
+			if err != nil {
+				if !errors.Is(err, storageDrivers.ErrNotSupported) {
+					logger.Warn("Failed monitoring storage pool", logger.Ctx{"pool": pool.Name(), "err": err})
+				}
+
+				continue
+			}
+
+			// Raise the warning matching the alert and resolve the other ones.
+			raise := warningtype.Undefined
+			if alert != nil {
+				raise = warningtype.StoragePoolUsageHigh
+				if alert.Critical {
+					raise = warningtype.StoragePoolUsageCritical
+				}
+
+				storagePoolMaintenanceWarn(s, pool, raise, alert.Message)
+			}
+
+			for _, warningType := range []warningtype.Type{warningtype.StoragePoolUsageHigh, warningtype.StoragePoolUsageCritical} {
+				if warningType == raise {
+					continue
+				}
+
+				_ = warnings.ResolveWarningsByLocalNodeAndProjectAndTypeAndEntity(s.DB.Cluster, "", warningType, dbCluster.TypeStoragePool, int(pool.ID()))
+			}
+		}
+	}
+
+	first := true
+	schedule := func() (time.Duration, error) {
+		interval := time.Minute
+
+		if first {
+			first = false
+			return interval, task.ErrSkip
+		}
+
+		return interval, nil
+	}
+
+	return f, schedule
+}
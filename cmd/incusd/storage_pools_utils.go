@@ -190,3 +190,24 @@ func dbStoragePoolDeleteAndUpdateCache(ctx context.Context, s *state.State, pool
 
 	return err
 }
+
+// storagePoolVolatileSet merges the volatile keys into the config of the storage pool on the local member.
+func storagePoolVolatileSet(ctx context.Context, s *state.State, poolName string, volatile map[string]string) error {
+	return s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		_, dbPool, _, err := tx.GetStoragePoolInAnyState(ctx, poolName)
+		if err != nil {
+			return err
+		}
+
+		config := make(map[string]string, len(dbPool.Config)+len(volatile))
+		for k, v := range dbPool.Config {
+			config[k] = v
+		}
+
+		for k, v := range volatile {
+			config[k] = v
+		}
+
+		return tx.UpdateStoragePool(ctx, dbPool.Name, dbPool.Description, config)
+	})
+}
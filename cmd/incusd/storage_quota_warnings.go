@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/warningtype"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	storageDrivers "github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/internal/server/task"
+	localUtil "github.com/lxc/incus/v6/internal/server/util"
+	"github.com/lxc/incus/v6/internal/server/warnings"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/units"
+)
+
+// storageQuotaEntity identifies the entity a quota warning is attached to.
+type storageQuotaEntity struct {
+	typeCode int
+	id       int
+}
+
+// storageQuotaWarning represents a volume using more than the quota warning threshold.
+type storageQuotaWarning struct {
+	projectName string
+	message     string
+	event       api.EventLifecycle
+}
+
+// storageQuotaWarningNew returns the warning for a volume, or nil if its usage is below the threshold.
+func storageQuotaWarningNew(vol storageDrivers.Volume, projectName string, usage *storagePools.VolumeUsage, threshold int64) *storageQuotaWarning {
+	// Volumes without a quota can't be checked.
+	if usage.Total <= 0 {
+		return nil
+	}
+
+	percent := usage.Used * 100 / usage.Total
+	if percent < threshold {
+		return nil
+	}
+
+	return &storageQuotaWarning{
+		projectName: projectName,
+		message:     fmt.Sprintf("Volume %q of type %q on pool %q is using %d%% of its quota (%s of %s)", vol.Name(), vol.Type(), vol.Pool(), percent, units.GetByteSizeStringIEC(usage.Used, 2), units.GetByteSizeStringIEC(usage.Total, 2)),
+		event: lifecycle.StorageVolumeQuotaWarning.Event(vol, string(vol.Type()), projectName, nil, map[string]any{
+			"type":      vol.Type(),
+			"usage":     usage.Used,
+			"quota":     usage.Total,
+			"threshold": threshold,
+		}),
+	}
+}
+
+// storageQuotaCheckInstances checks the root disk usage of the instances on this member.
+// The checked instances are added to the map along with their warning (nil if below the threshold).
+func storageQuotaCheckInstances(s *state.State, threshold int64, checked map[storageQuotaEntity]*storageQuotaWarning) error {
+	instances, err := instance.LoadNodeAll(s, instancetype.Any)
+	if err != nil {
+		return fmt.Errorf("Failed loading instances: %w", err)
+	}
+
+	for _, inst := range instances {
+		if inst.IsSnapshot() {
+			continue
+		}
+
+		pool, err := storagePools.LoadByInstance(s, inst)
+		if err != nil {
+			logger.Warn("Failed loading instance storage pool", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			continue
+		}
+
+		usage, err := pool.GetInstanceUsage(inst)
+		if err != nil {
+			if !errors.Is(err, storageDrivers.ErrNotSupported) {
+				logger.Warn("Failed getting instance disk usage", logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "err": err})
+			}
+
+			continue
+		}
+
+		volType, err := storagePools.InstanceTypeToVolumeType(inst.Type())
+		if err != nil {
+			continue
+		}
+
+		vol := pool.GetVolume(volType, storagePools.InstanceContentType(inst), inst.Name(), nil)
+		checked[storageQuotaEntity{typeCode: dbCluster.TypeInstance, id: inst.ID()}] = storageQuotaWarningNew(vol, inst.Project().Name, usage, threshold)
+	}
+
+	return nil
+}
+
+// storageQuotaCheckCustomVolumes checks the usage of the custom volumes on this member.
+// Volumes on remote pools are checked by a single online cluster member.
+// The checked volumes are added to the map along with their warning (nil if below the threshold).
+func storageQuotaCheckCustomVolumes(ctx context.Context, s *state.State, threshold int64, checked map[storageQuotaEntity]*storageQuotaWarning) error {
+	var volumes []db.StorageVolumeArgs
+	var memberCount int
+	var onlineMemberIDs []int64
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		volumes, err = tx.GetStoragePoolVolumesWithType(ctx, db.StoragePoolVolumeTypeCustom, true)
+		if err != nil {
+			return fmt.Errorf("Failed getting custom volumes: %w", err)
+		}
+
+		members, err := tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		memberCount = len(members)
+
+		// Filter to online members.
+		for _, member := range members {
+			if member.IsOffline(s.GlobalConfig.OfflineThreshold()) {
+				continue
+			}
+
+			onlineMemberIDs = append(onlineMemberIDs, member.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	localMemberID := s.DB.Cluster.GetNodeID()
+	pools := map[string]storagePools.Pool{}
+
+	for _, v := range volumes {
+		// If there are multiple cluster members, a stable random member is chosen to check remote volumes.
+		if v.NodeID < 0 && memberCount > 1 {
+			selectedMemberID, err := localUtil.GetStableRandomInt64FromList(v.ID, onlineMemberIDs)
+			if err != nil || selectedMemberID != localMemberID {
+				continue
+			}
+		}
+
+		pool, ok := pools[v.PoolName]
+		if !ok {
+			pool, err = storagePools.LoadByName(s, v.PoolName)
+			if err != nil {
+				logger.Warn("Failed loading storage pool", logger.Ctx{"pool": v.PoolName, "err": err})
+				continue
+			}
+
+			pools[v.PoolName] = pool
+		}
+
+		usage, err := pool.GetCustomVolumeUsage(v.ProjectName, v.Name)
+		if err != nil {
+			if !errors.Is(err, storageDrivers.ErrNotSupported) {
+				logger.Warn("Failed getting custom volume usage", logger.Ctx{"project": v.ProjectName, "pool": v.PoolName, "volume": v.Name, "err": err})
+			}
+
+			continue
+		}
+
+		// The content type isn't relevant to the warning and its event.
+		vol := pool.GetVolume(storageDrivers.VolumeTypeCustom, storageDrivers.ContentTypeFS, v.Name, nil)
+		checked[storageQuotaEntity{typeCode: dbCluster.TypeStorageVolume, id: int(v.ID)}] = storageQuotaWarningNew(vol, v.ProjectName, usage, threshold)
+	}
+
+	return nil
+}
+
+// storageQuotaWarningsUpdate raises a warning for each volume on this member using more than the configured
+// percentage of its quota and resolves this member's other warnings, including the ones of deleted volumes.
+// A lifecycle event is emitted whenever a volume goes above the threshold.
+func storageQuotaWarningsUpdate(ctx context.Context, s *state.State) error {
+	typeCode := warningtype.StorageVolumeQuotaThresholdExceeded
+
+	threshold := s.GlobalConfig.StorageQuotaWarningThreshold()
+	if threshold <= 0 {
+		return warnings.ResolveWarningsByLocalNodeAndType(s.DB.Cluster, typeCode)
+	}
+
+	checked := map[storageQuotaEntity]*storageQuotaWarning{}
+
+	err := storageQuotaCheckInstances(s, threshold, checked)
+	if err != nil {
+		return err
+	}
+
+	err = storageQuotaCheckCustomVolumes(ctx, s, threshold, checked)
+	if err != nil {
+		return err
+	}
+
+	var newWarnings []*storageQuotaWarning
+
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		localName, err := tx.GetLocalNodeName(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting local member name: %w", err)
+		}
+
+		existing, err := dbCluster.GetWarnings(ctx, tx.Tx(), dbCluster.WarningFilter{TypeCode: &typeCode, Node: &localName})
+		if err != nil {
+			return fmt.Errorf("Failed getting warnings: %w", err)
+		}
+
+		// Resolve the warnings of the volumes which are back below the threshold, as well as the ones of
+		// the volumes which no longer exist or are no longer checked by this member.
+		active := map[storageQuotaEntity]bool{}
+		for _, w := range existing {
+			if w.Status == warningtype.StatusResolved {
+				continue
+			}
+
+			entity := storageQuotaEntity{typeCode: w.EntityTypeCode, id: w.EntityID}
+
+			if checked[entity] != nil {
+				active[entity] = true
+				continue
+			}
+
+			err = tx.UpdateWarningStatus(w.UUID, warningtype.StatusResolved)
+			if err != nil {
+				return err
+			}
+		}
+
+		for entity, warning := range checked {
+			if warning == nil {
+				continue
+			}
+
+			err = tx.UpsertWarning(ctx, localName, warning.projectName, entity.typeCode, entity.id, typeCode, warning.message)
+			if err != nil {
+				return err
+			}
+
+			if !active[entity] {
+				newWarnings = append(newWarnings, warning)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range newWarnings {
+		s.Events.SendLifecycle(warning.projectName, warning.event)
+	}
+
+	return nil
+}
+
+func storageQuotaWarningsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := storageQuotaWarningsUpdate(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed updating storage quota warnings", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(15 * time.Minute)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/internal/server/task"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+func storageVolumesSharingTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		s := d.State()
+
+		// Volumes can only be shared through NFS within a cluster.
+		if !s.ServerClustered {
+			return
+		}
+
+		err := storagePools.VolumeSharingNFSSync(ctx, s)
+		if err != nil {
+			logger.Error("Failed updating NFS exports of custom volumes", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Every(time.Minute)
+}
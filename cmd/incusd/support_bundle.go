@@ -0,0 +1,425 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v2"
+
+	"github.com/lxc/incus/v6/internal/server/auth"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	instanceDrivers "github.com/lxc/incus/v6/internal/server/instance/drivers"
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/internal/server/response"
+	"github.com/lxc/incus/v6/internal/server/state"
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// supportBundleExpiry is how long generated support bundles are kept around for.
+const supportBundleExpiry = 24 * time.Hour
+
+var supportBundlesCmd = APIEndpoint{
+	Path: "admin/support-bundle",
+
+	Post: APIEndpointAction{Handler: supportBundlesPost, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+var supportBundleCmd = APIEndpoint{
+	Path: "admin/support-bundle/{name}",
+
+	Get:    APIEndpointAction{Handler: supportBundleGet, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+	Delete: APIEndpointAction{Handler: supportBundleDelete, AccessHandler: allowPermission(auth.ObjectTypeServer, auth.EntitlementCanEdit)},
+}
+
+// swagger:operation POST /1.0/admin/support-bundle server support_bundle_post
+//
+//	Generate a support bundle
+//
+//	Gathers the recent daemon logs, the server configuration (with secrets redacted), the warnings,
+//	the versions, the hardware resources and the recently failed operations into a tarball.
+//	The tarball can be downloaded once the operation completes, its name is included in the operation metadata.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "202":
+//	    $ref: "#/responses/Operation"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func supportBundlesPost(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	// When clustered, use the member name, otherwise use the hostname.
+	serverName := s.ServerName
+	if !s.ServerClustered {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return response.SmartError(err)
+		}
+
+		serverName = hostname
+	}
+
+	name := fmt.Sprintf("incus-support-%s-%s.tar.gz", serverName, time.Now().UTC().Format("20060102-150405"))
+
+	run := func(op *operations.Operation) error {
+		err := supportBundlesPrune()
+		if err != nil {
+			logger.Warn("Failed removing expired support bundles", logger.Ctx{"err": err})
+		}
+
+		return supportBundleGenerate(context.TODO(), s, name)
+	}
+
+	resources := map[string][]api.URL{}
+	resources["support-bundle"] = []api.URL{*api.NewURL().Path(version.APIVersion, "admin", "support-bundle", name)}
+
+	metadata := map[string]any{"name": name}
+
+	op, err := operations.OperationCreate(s, "", operations.OperationClassTask, operationtype.SupportBundle, resources, metadata, run, nil, nil, r)
+	if err != nil {
+		return response.InternalError(err)
+	}
+
+	return operations.OperationResponse(op)
+}
+
+// swagger:operation GET /1.0/admin/support-bundle/{name} server support_bundle_get
+//
+//	Download a support bundle
+//
+//	Downloads a previously generated support bundle tarball.
+//
+//	---
+//	produces:
+//	  - application/octet-stream
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    description: Raw support bundle data
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func supportBundleGet(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	path, err := supportBundlePath(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	ent := response.FileResponseEntry{
+		Identifier: filepath.Base(path),
+		Path:       path,
+		Filename:   filepath.Base(path),
+	}
+
+	return response.FileResponse(r, []response.FileResponseEntry{ent}, nil)
+}
+
+// swagger:operation DELETE /1.0/admin/support-bundle/{name} server support_bundle_delete
+//
+//	Delete a support bundle
+//
+//	Removes a previously generated support bundle tarball.
+//
+//	---
+//	produces:
+//	  - application/json
+//	parameters:
+//	  - in: query
+//	    name: target
+//	    description: Cluster member name
+//	    type: string
+//	    example: server01
+//	responses:
+//	  "200":
+//	    $ref: "#/responses/EmptySyncResponse"
+//	  "403":
+//	    $ref: "#/responses/Forbidden"
+//	  "404":
+//	    $ref: "#/responses/NotFound"
+//	  "500":
+//	    $ref: "#/responses/InternalServerError"
+func supportBundleDelete(d *Daemon, r *http.Request) response.Response {
+	s := d.State()
+
+	// If a target was specified, forward the request to the relevant node.
+	resp := forwardedResponseIfTargetIsRemote(s, r)
+	if resp != nil {
+		return resp
+	}
+
+	path, err := supportBundlePath(r)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	err = os.Remove(path)
+	if err != nil {
+		return response.SmartError(err)
+	}
+
+	return response.EmptySyncResponse
+}
+
+// supportBundlePath returns the path of the existing support bundle referenced by the request.
+func supportBundlePath(r *http.Request) (string, error) {
+	name, err := url.PathUnescape(mux.Vars(r)["name"])
+	if err != nil {
+		return "", err
+	}
+
+	if name != filepath.Base(name) || !strings.HasPrefix(name, "incus-support-") {
+		return "", api.StatusErrorf(http.StatusBadRequest, "Invalid support bundle name %q", name)
+	}
+
+	path := internalUtil.VarPath("support-bundles", name)
+	_, err = os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", api.StatusErrorf(http.StatusNotFound, "Support bundle %q not found", name)
+		}
+
+		return "", err
+	}
+
+	return path, nil
+}
+
+// supportBundlesPrune removes the support bundles older than supportBundleExpiry.
+func supportBundlesPrune() error {
+	entries, err := os.ReadDir(internalUtil.VarPath("support-bundles"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		if time.Since(info.ModTime()) < supportBundleExpiry {
+			continue
+		}
+
+		err = os.Remove(internalUtil.VarPath("support-bundles", entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// supportBundleRedacted returns whether the value of a server configuration key is a secret.
+func supportBundleRedacted(key string) bool {
+	fields := strings.Split(key, ".")
+	last := fields[len(fields)-1]
+
+	return last == "password" || last == "secret" || last == "token" || strings.HasSuffix(last, "_key")
+}
+
+// supportBundleGenerate writes a support bundle with the given name for the local server.
+func supportBundleGenerate(ctx context.Context, s *state.State, name string) error {
+	files := map[string]any{}
+
+	// Versions.
+	env := api.ServerEnvironment{
+		Kernel:             s.OS.Uname.Sysname,
+		KernelArchitecture: s.OS.Uname.Machine,
+		KernelVersion:      s.OS.Uname.Release,
+		OSName:             s.OS.ReleaseInfo["NAME"],
+		OSVersion:          s.OS.ReleaseInfo["VERSION_ID"],
+		Server:             "incus",
+		ServerVersion:      version.Version,
+		ServerClustered:    s.ServerClustered,
+		ServerName:         s.ServerName,
+		Firewall:           s.Firewall.String(),
+	}
+
+	for _, driver := range instanceDrivers.DriverStatuses() {
+		if !driver.Supported {
+			continue
+		}
+
+		env.Driver = strings.TrimPrefix(env.Driver+" | "+driver.Info.Name, " | ")
+		env.DriverVersion = strings.TrimPrefix(env.DriverVersion+" | "+driver.Info.Version, " | ")
+	}
+
+	_, usedStorageDrivers := readStoragePoolDriversCache()
+	for driver, driverVersion := range usedStorageDrivers {
+		env.Storage = strings.TrimPrefix(env.Storage+" | "+driver, " | ")
+		env.StorageVersion = strings.TrimPrefix(env.StorageVersion+" | "+driverVersion, " | ")
+	}
+
+	files["version.yaml"] = map[string]any{
+		"api_version":    version.APIVersion,
+		"api_extensions": version.APIExtensions,
+		"environment":    env,
+	}
+
+	// Configuration.
+	config, err := daemonConfigRender(s)
+	if err != nil {
+		return fmt.Errorf("Failed getting server configuration: %w", err)
+	}
+
+	for key := range config {
+		if supportBundleRedacted(key) {
+			config[key] = "REDACTED"
+		}
+	}
+
+	files["config.yaml"] = config
+
+	// Warnings.
+	var warnings []api.Warning
+	err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		dbWarnings, err := cluster.GetWarnings(ctx, tx.Tx())
+		if err != nil {
+			return err
+		}
+
+		for _, w := range dbWarnings {
+			warnings = append(warnings, w.ToAPI())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed getting warnings: %w", err)
+	}
+
+	files["warnings.yaml"] = warnings
+
+	// Resources.
+	res, err := resources.GetResources()
+	if err != nil {
+		return fmt.Errorf("Failed getting resources: %w", err)
+	}
+
+	files["resources.yaml"] = res
+
+	// Recently failed operations.
+	files["operations.yaml"] = operations.RecentFailures()
+
+	// Write the tarball.
+	err = os.MkdirAll(internalUtil.VarPath("support-bundles"), 0700)
+	if err != nil {
+		return err
+	}
+
+	path := internalUtil.VarPath("support-bundles", name)
+	f, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(path + ".tmp")
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	prefix := strings.TrimSuffix(name, ".tar.gz")
+
+	addFile := func(fileName string, content []byte) error {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    filepath.Join(prefix, fileName),
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tw.Write(content)
+		return err
+	}
+
+	for fileName, data := range files {
+		content, err := yaml.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("Failed rendering %q: %w", fileName, err)
+		}
+
+		err = addFile(fileName, content)
+		if err != nil {
+			return fmt.Errorf("Failed writing %q: %w", fileName, err)
+		}
+	}
+
+	err = addFile("incusd.log", logger.RecentLogs())
+	if err != nil {
+		return fmt.Errorf("Failed writing %q: %w", "incusd.log", err)
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	err = gz.Close()
+	if err != nil {
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(path+".tmp", path)
+}
@@ -16,7 +16,7 @@ func autoRemoveExpiredTokens(ctx context.Context, s *state.State) {
 
 	for _, op := range operations.Clone() {
 		// Only consider token operations
-		if op.Type() != operationtype.ClusterJoinToken && op.Type() != operationtype.CertificateAddToken {
+		if op.Type() != operationtype.ClusterJoinToken && op.Type() != operationtype.CertificateAddToken && op.Type() != operationtype.MigrationToken {
 			continue
 		}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -187,7 +188,7 @@ func warningsGet(d *Daemon, r *http.Request) response.Response {
 		for i, w := range dbWarnings {
 			warning := w.ToAPI()
 			warning.EntityURL, err = getWarningEntityURL(ctx, tx.Tx(), &w)
-			if err != nil {
+			if err != nil && !errors.Is(err, db.ErrUnknownEntityID) {
 				return err
 			}
 
@@ -278,7 +279,7 @@ func warningGet(d *Daemon, r *http.Request) response.Response {
 		resp = dbWarning.ToAPI()
 
 		resp.EntityURL, err = getWarningEntityURL(ctx, tx.Tx(), dbWarning)
-		if err != nil {
+		if err != nil && !errors.Is(err, db.ErrUnknownEntityID) {
 			return err
 		}
 
@@ -295,7 +296,7 @@ func warningGet(d *Daemon, r *http.Request) response.Response {
 //
 //	Partially update the warning
 //
-//	Updates a subset of the warning status.
+//	Updates a subset of the warning status and severity override.
 //
 //	---
 //	consumes:
@@ -319,14 +320,14 @@ func warningGet(d *Daemon, r *http.Request) response.Response {
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func warningPatch(d *Daemon, r *http.Request) response.Response {
-	return warningPut(d, r)
+	return doWarningUpdate(d, r, true)
 }
 
 // swagger:operation PUT /1.0/warnings/{uuid} warnings warning_put
 //
 //	Update the warning
 //
-//	Updates the warning status.
+//	Updates the warning status and severity override.
 //
 //	---
 //	consumes:
@@ -350,6 +351,12 @@ func warningPatch(d *Daemon, r *http.Request) response.Response {
 //	  "500":
 //	    $ref: "#/responses/InternalServerError"
 func warningPut(d *Daemon, r *http.Request) response.Response {
+	return doWarningUpdate(d, r, false)
+}
+
+// doWarningUpdate updates the status and severity override of a warning.
+// When patching, the fields missing from the request are left unchanged.
+func doWarningUpdate(d *Daemon, r *http.Request, patch bool) response.Response {
 	s := d.State()
 
 	id, err := url.PathUnescape(mux.Vars(r)["id"])
@@ -357,40 +364,82 @@ func warningPut(d *Daemon, r *http.Request) response.Response {
 		return response.SmartError(err)
 	}
 
+	var current *cluster.Warning
+	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		current, err = cluster.GetWarning(ctx, tx.Tx(), id)
+
+		return err
+	})
+	if err != nil {
+		return response.SmartError(err)
+	}
+
 	req := api.WarningPut{}
+	if patch {
+		req = current.ToAPI().WarningPut
+	}
 
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		return response.BadRequest(err)
 	}
 
-	// Currently, we only allow changing the status to acknowledged or new.
 	status, ok := warningtype.StatusTypes[req.Status]
 	if !ok {
 		// Invalid status
-		return response.BadRequest(fmt.Errorf("Invalid warning type %q", req.Status))
+		return response.BadRequest(fmt.Errorf("Invalid warning status %q", req.Status))
+	}
+
+	// Only allow changing the status to acknowledged or new.
+	if status != current.Status && status != warningtype.StatusAcknowledged && status != warningtype.StatusNew {
+		return response.Forbidden(fmt.Errorf(`Status may only be set to "acknowledged" or "new"`))
 	}
 
-	if status != warningtype.StatusAcknowledged && status != warningtype.StatusNew {
-		return response.Forbidden(fmt.Errorf(`Status may only be set to "acknowledge" or "new"`))
+	var severity warningtype.Severity
+	if req.SeverityOverride != "" {
+		severity, ok = warningtype.SeverityTypes[req.SeverityOverride]
+		if !ok {
+			return response.BadRequest(fmt.Errorf("Invalid warning severity %q", req.SeverityOverride))
+		}
 	}
 
+	requestor := request.CreateRequestor(r)
+
 	err = s.DB.Cluster.Transaction(r.Context(), func(ctx context.Context, tx *db.ClusterTx) error {
+		if severity != current.Severity {
+			err := tx.UpdateWarningSeverity(id, severity)
+			if err != nil {
+				return err
+			}
+		}
+
+		if status == current.Status {
+			return nil
+		}
+
 		err := tx.UpdateWarningStatus(id, status)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		// Keep track of who acknowledged the warning.
+		acknowledgedBy := ""
+		if status == warningtype.StatusAcknowledged {
+			acknowledgedBy = requestor.Username
+		}
+
+		return tx.UpdateWarningAcknowledgedBy(id, acknowledgedBy)
 	})
 	if err != nil {
 		return response.SmartError(err)
 	}
 
-	if status == warningtype.StatusAcknowledged {
-		s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.WarningAcknowledged.Event(id, request.CreateRequestor(r), nil))
-	} else {
-		s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.WarningReset.Event(id, request.CreateRequestor(r), nil))
+	if status != current.Status {
+		if status == warningtype.StatusAcknowledged {
+			s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.WarningAcknowledged.Event(id, requestor, nil))
+		} else {
+			s.Events.SendLifecycle(api.ProjectDefaultName, lifecycle.WarningReset.Event(id, requestor, nil))
+		}
 	}
 
 	return response.EmptySyncResponse
@@ -500,6 +549,69 @@ func pruneResolvedWarnings(ctx context.Context, s *state.State) error {
 	return nil
 }
 
+func autoResolveWarningsTask(d *Daemon) (task.Func, task.Schedule) {
+	f := func(ctx context.Context) {
+		err := autoResolveWarnings(ctx, d.State())
+		if err != nil {
+			logger.Error("Failed auto-resolving warnings", logger.Ctx{"err": err})
+		}
+	}
+
+	return f, task.Hourly()
+}
+
+// autoResolveWarnings resolves the warnings matching the configured auto-resolution rules.
+func autoResolveWarnings(ctx context.Context, s *state.State) error {
+	resolveAfter := s.GlobalConfig.WarningsAutoResolveAfter()
+	resolveDeletedEntities := s.GlobalConfig.WarningsAutoResolveDeletedEntities()
+
+	if resolveAfter <= 0 && !resolveDeletedEntities {
+		return nil
+	}
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		warnings, err := cluster.GetWarnings(ctx, tx.Tx())
+		if err != nil {
+			return fmt.Errorf("Failed to get warnings: %w", err)
+		}
+
+		for _, w := range warnings {
+			if w.Status == warningtype.StatusResolved {
+				continue
+			}
+
+			resolve := resolveAfter > 0 && time.Since(w.LastSeenDate) >= resolveAfter
+
+			if !resolve && resolveDeletedEntities && w.EntityTypeCode != -1 && w.EntityID != -1 {
+				_, err := tx.GetURIFromEntity(ctx, w.EntityTypeCode, w.EntityID)
+				if err != nil {
+					if !errors.Is(err, db.ErrUnknownEntityID) && !errors.Is(err, sql.ErrNoRows) && !api.StatusErrorCheck(err, http.StatusNotFound) {
+						return fmt.Errorf("Failed to get entity of warning %q: %w", w.UUID, err)
+					}
+
+					resolve = true
+				}
+			}
+
+			if !resolve {
+				continue
+			}
+
+			err = tx.UpdateWarningStatus(w.UUID, warningtype.StatusResolved)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to resolve warnings: %w", err)
+	}
+
+	return nil
+}
+
 // getWarningEntityURL fetches the entity corresponding to the warning from the database, and generates a URL.
 func getWarningEntityURL(ctx context.Context, tx *sql.Tx, warning *cluster.Warning) (string, error) {
 	if warning.EntityID == -1 || warning.EntityTypeCode == -1 {
@@ -0,0 +1,242 @@
+// Package asciicast implements reading and writing of asciicast v2 terminal recordings.
+package asciicast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the asciicast format version produced by this package.
+const Version = 2
+
+// EventOutput is the event type for data written to the terminal.
+const EventOutput = "o"
+
+// EventResize is the event type for terminal size changes.
+const EventResize = "r"
+
+// Header is the first line of an asciicast recording.
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Event is a single entry of an asciicast recording.
+type Event struct {
+	Time float64
+	Type string
+	Data string
+}
+
+// MarshalJSON encodes the event as a JSON array.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time, e.Type, e.Data})
+}
+
+// UnmarshalJSON decodes the event from a JSON array.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	fields := []any{}
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+
+	if len(fields) != 3 {
+		return fmt.Errorf("Invalid event, expected 3 fields but got %d", len(fields))
+	}
+
+	var ok bool
+
+	e.Time, ok = fields[0].(float64)
+	if !ok {
+		return errors.New("Invalid event time")
+	}
+
+	e.Type, ok = fields[1].(string)
+	if !ok {
+		return errors.New("Invalid event type")
+	}
+
+	e.Data, ok = fields[2].(string)
+	if !ok {
+		return errors.New("Invalid event data")
+	}
+
+	return nil
+}
+
+// Writer records terminal output as asciicast events.
+type Writer struct {
+	w       io.Writer
+	start   time.Time
+	pending []byte
+	mu      sync.Mutex
+}
+
+// NewWriter writes the header and returns a Writer recording events relative to now.
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	header.Version = Version
+	if header.Timestamp == 0 {
+		header.Timestamp = time.Now().Unix()
+	}
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{w: w, start: time.Now()}, nil
+}
+
+// Write records p as an output event.
+// Incomplete UTF-8 sequences at the end of p are held back until the next write.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.pending, p...)
+
+	// Find where the last complete UTF-8 sequence ends.
+	end := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+
+		if !utf8.FullRune(data[i:]) {
+			end = i
+		}
+
+		break
+	}
+
+	w.pending = append([]byte{}, data[end:]...)
+	if end == 0 {
+		return len(p), nil
+	}
+
+	err := w.writeEvent(EventOutput, string(data[:end]))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close records any incomplete UTF-8 sequence held back by Write, replacing its invalid bytes.
+// It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	data := strings.ToValidUTF8(string(w.pending), string(utf8.RuneError))
+	w.pending = nil
+
+	return w.writeEvent(EventOutput, data)
+}
+
+// WriteResize records a terminal size change.
+func (w *Writer) WriteResize(width int, height int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.writeEvent(EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+func (w *Writer) writeEvent(eventType string, data string) error {
+	event := Event{
+		Time: time.Since(w.start).Seconds(),
+		Type: eventType,
+		Data: data,
+	}
+
+	out, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(append(out, '\n'))
+
+	return err
+}
+
+// Reader reads events from an asciicast recording.
+type Reader struct {
+	Header Header
+
+	scanner *bufio.Scanner
+}
+
+// NewReader parses the recording header and returns a Reader for its events.
+func NewReader(r io.Reader) (*Reader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		err := scanner.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, fmt.Errorf("Failed reading recording header: %w", err)
+	}
+
+	reader := &Reader{scanner: scanner}
+
+	err := json.Unmarshal(scanner.Bytes(), &reader.Header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing recording header: %w", err)
+	}
+
+	if reader.Header.Version != Version {
+		return nil, fmt.Errorf("Unsupported recording version %d", reader.Header.Version)
+	}
+
+	return reader, nil
+}
+
+// Next returns the next event of the recording or io.EOF when done.
+func (r *Reader) Next() (*Event, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		event := Event{}
+		err := json.Unmarshal(line, &event)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing recording event: %w", err)
+		}
+
+		return &event, nil
+	}
+
+	err := r.scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
@@ -0,0 +1,118 @@
+package asciicast
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	w, err := NewWriter(buf, Header{Width: 80, Height: 24, Command: "bash"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Split a multi-byte character across two writes.
+	euro := []byte("€")
+	_, err = w.Write(append([]byte("price: "), euro[:1]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.Write(euro[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.WriteResize(100, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Header.Version != Version || r.Header.Width != 80 || r.Header.Command != "bash" {
+		t.Fatalf("Unexpected header: %+v", r.Header)
+	}
+
+	expected := []Event{
+		{Type: EventOutput, Data: "price: "},
+		{Type: EventOutput, Data: "€"},
+		{Type: EventResize, Data: "100x40"},
+	}
+
+	for _, exp := range expected {
+		event, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.Type != exp.Type || event.Data != exp.Data {
+			t.Errorf("Expected event %q %q, got %q %q", exp.Type, exp.Data, event.Type, event.Data)
+		}
+	}
+
+	_, err = r.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+}
+
+func TestWriterClose(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	w, err := NewWriter(buf, Header{Width: 80, Height: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// End the output with an incomplete multi-byte character.
+	_, err = w.Write(append([]byte("price: "), []byte("€")[:2]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"price: ", "\uFFFD"} {
+		event, err := r.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.Type != EventOutput || event.Data != expected {
+			t.Errorf("Expected output %q, got %q %q", expected, event.Type, event.Data)
+		}
+	}
+
+	_, err = r.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+}
+
+func TestReaderInvalid(t *testing.T) {
+	_, err := NewReader(bytes.NewBufferString(`{"version": 1}` + "\n"))
+	if err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+
+	_, err = NewReader(bytes.NewBufferString(""))
+	if err == nil {
+		t.Error("Expected error for empty recording")
+	}
+}
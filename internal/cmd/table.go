@@ -85,3 +85,30 @@ type Column struct {
 	// "data" slice that is passed into RenderSlice.
 	DataFunc func(any) (string, error)
 }
+
+// RenderObject renders a single object, such as the response of an informational command, in a structured format
+// (JSON or YAML). The object is rendered using the same fields as in the API, so that its schema only evolves
+// along with the API extensions.
+func RenderObject(format string, obj any) error {
+	switch format {
+	case TableFormatJSON:
+		enc := json.NewEncoder(os.Stdout)
+
+		err := enc.Encode(obj)
+		if err != nil {
+			return err
+		}
+
+	case TableFormatYAML:
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s", out)
+	default:
+		return fmt.Errorf(i18n.G("Invalid format %q"), format)
+	}
+
+	return nil
+}
@@ -3,12 +3,15 @@ package instance
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/units"
+	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -26,6 +29,52 @@ var HugePageSizeKeys = [...]string{"limits.hugepages.64KB", "limits.hugepages.1M
 // HugePageSizeSuffix contains the list of known hugepage size suffixes.
 var HugePageSizeSuffix = [...]string{"64KB", "1MB", "2MB", "1GB"}
 
+// IsReadyCheck validates the readiness check of an instance.
+func IsReadyCheck(value string) error {
+	if value == "running" || value == "ready" || value == "agent" {
+		return nil
+	}
+
+	address, found := strings.CutPrefix(value, "tcp:")
+	if !found {
+		return fmt.Errorf("Invalid readiness check %q, must be one of running, ready, agent or tcp:<address>:<port>", value)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || host == "" {
+		return fmt.Errorf("Invalid TCP readiness check address %q", address)
+	}
+
+	return validate.IsNetworkPort(port)
+}
+
+// IsProbe validates a readiness or liveness probe of an instance.
+func IsProbe(value string) error {
+	if strings.HasPrefix(value, "tcp:") {
+		return IsReadyCheck(value)
+	}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("Invalid HTTP probe URL %q", value)
+		}
+
+		return nil
+	}
+
+	command, found := strings.CutPrefix(value, "exec:")
+	if !found {
+		return fmt.Errorf("Invalid probe %q, must be one of tcp:<address>:<port>, an HTTP URL or exec:<command>", value)
+	}
+
+	if strings.TrimSpace(command) == "" {
+		return fmt.Errorf("Missing command in probe %q", value)
+	}
+
+	return nil
+}
+
 // InstanceConfigKeysAny is a map of config key to validator. (keys applying to containers AND virtual machines).
 var InstanceConfigKeysAny = map[string]func(value string) error{
 	// gendoc:generate(entity=instance, group=boot, key=boot.autostart)
@@ -54,6 +103,136 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What order to start the instances in
 	"boot.autostart.priority": validate.Optional(validate.IsInt64),
 
+	// gendoc:generate(entity=instance, group=boot, key=boot.depends_on)
+	// Comma-separated list of instances of the same project that must be ready before this instance is auto-started.
+	// The dependencies that get auto-started are started first, regardless of their {config:option}`instance-boot:boot.autostart.priority`,
+	// and their readiness is checked as set by their {config:option}`instance-boot:boot.ready_check`.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  shortdesc: Instances to wait for before auto-starting the instance
+	"boot.depends_on": validate.Optional(validate.IsListOf(validate.IsHostname)),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.ready_check)
+	// How to check that the instance is ready when other instances depend on it:
+	//
+	// - `running`: the instance is running
+	// - `ready`: the instance reported itself as ready through `/dev/incus`
+	// - `agent`: the agent of the virtual machine is reachable (the instance is running for containers)
+	// - `tcp:<address>:<port>`: a TCP connection to the address and port succeeds
+	// ---
+	//  type: string
+	//  defaultdesc: `running`
+	//  liveupdate: no
+	//  shortdesc: How to check that the instance is ready for its dependents
+	"boot.ready_check": validate.Optional(IsReadyCheck),
+
+	// gendoc:generate(entity=instance, group=boot, key=boot.ready_timeout)
+	// The number of seconds dependent instances wait for the instance to be ready before being started regardless.
+	// ---
+	//  type: integer
+	//  defaultdesc: 120
+	//  liveupdate: no
+	//  shortdesc: How long to wait for the instance to be ready
+	"boot.ready_timeout": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.readiness)
+	// Probe run by the server to check that the instance is ready to serve:
+	//
+	// - `tcp:<address>:<port>`: a TCP connection to the address and port succeeds
+	// - `http://<address>[:<port>]/<path>` or `https://...`: an HTTP request to the URL returns a 2xx or 3xx status
+	// - `exec:<command>`: the command run in the instance exits with status 0
+	//
+	// The instance is marked as ready while the probe passes.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Readiness probe of the instance
+	"probe.readiness": validate.Optional(IsProbe),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.readiness.interval)
+	// The number of seconds between two runs of the probe.
+	// ---
+	//  type: integer
+	//  defaultdesc: 10
+	//  liveupdate: yes
+	//  shortdesc: Interval between the runs of the readiness probe
+	"probe.readiness.interval": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.readiness.timeout)
+	// The number of seconds after which a run of the probe fails.
+	// ---
+	//  type: integer
+	//  defaultdesc: 5
+	//  liveupdate: yes
+	//  shortdesc: Timeout of the readiness probe
+	"probe.readiness.timeout": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.readiness.failure_threshold)
+	// The number of consecutive failed runs after which the probe is considered failing.
+	// ---
+	//  type: integer
+	//  defaultdesc: 3
+	//  liveupdate: yes
+	//  shortdesc: Failures before the readiness probe fails
+	"probe.readiness.failure_threshold": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.readiness.success_threshold)
+	// The number of consecutive successful runs after which the probe is considered passing.
+	// ---
+	//  type: integer
+	//  defaultdesc: 1
+	//  liveupdate: yes
+	//  shortdesc: Successes before the readiness probe passes
+	"probe.readiness.success_threshold": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.liveness)
+	// Probe run by the server to check that the instance is still healthy, in the same format as
+	// {config:option}`instance-probes:probe.readiness`.
+	//
+	// Once the probe passed, the instance is restarted when it fails again.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Liveness probe of the instance
+	"probe.liveness": validate.Optional(IsProbe),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.liveness.interval)
+	// The number of seconds between two runs of the probe.
+	// ---
+	//  type: integer
+	//  defaultdesc: 10
+	//  liveupdate: yes
+	//  shortdesc: Interval between the runs of the liveness probe
+	"probe.liveness.interval": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.liveness.timeout)
+	// The number of seconds after which a run of the probe fails.
+	// ---
+	//  type: integer
+	//  defaultdesc: 5
+	//  liveupdate: yes
+	//  shortdesc: Timeout of the liveness probe
+	"probe.liveness.timeout": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.liveness.failure_threshold)
+	// The number of consecutive failed runs after which the probe is considered failing.
+	// ---
+	//  type: integer
+	//  defaultdesc: 3
+	//  liveupdate: yes
+	//  shortdesc: Failures before the liveness probe fails
+	"probe.liveness.failure_threshold": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=probes, key=probe.liveness.success_threshold)
+	// The number of consecutive successful runs after which the probe is considered passing.
+	// ---
+	//  type: integer
+	//  defaultdesc: 1
+	//  liveupdate: yes
+	//  shortdesc: Successes before the liveness probe passes
+	"probe.liveness.success_threshold": validate.Optional(validate.IsUint32),
+
 	// gendoc:generate(entity=instance, group=boot, key=boot.stop.priority)
 	// The instance with the highest value is shut down first.
 	// ---
@@ -89,7 +268,17 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  liveupdate: no
 	//  condition: If supported by image
 	//  shortdesc: Network configuration for `cloud-init`
-	"cloud-init.network-config": validate.Optional(validate.IsYAML),
+	"cloud-init.network-config": validate.Optional(isCloudInitTemplateOr(validate.IsYAML)),
+
+	// gendoc:generate(entity=instance, group=cloud-init, key=cloud-init.templating)
+	// When enabled, the `cloud-init` keys are rendered as templates before being passed to the instance.
+	// See {ref}`cloud-init-templating` for more information.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  shortdesc: Whether to render the `cloud-init` configuration as templates
+	"cloud-init.templating": validate.Optional(validate.IsBool),
 
 	// gendoc:generate(entity=instance, group=cloud-init, key=cloud-init.user-data)
 	// The content is used as seed value for `cloud-init`.
@@ -99,7 +288,7 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  liveupdate: no
 	//  condition: If supported by image
 	//  shortdesc: User data for `cloud-init`
-	"cloud-init.user-data": validate.Optional(validate.IsCloudInitUserData),
+	"cloud-init.user-data": validate.Optional(isCloudInitTemplateOr(validate.IsCloudInitUserData)),
 
 	// gendoc:generate(entity=instance, group=cloud-init, key=cloud-init.vendor-data)
 	// The content is used as seed value for `cloud-init`.
@@ -109,7 +298,7 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  liveupdate: no
 	//  condition: If supported by image
 	//  shortdesc: Vendor data for `cloud-init`
-	"cloud-init.vendor-data": validate.Optional(validate.IsCloudInitUserData),
+	"cloud-init.vendor-data": validate.Optional(isCloudInitTemplateOr(validate.IsCloudInitUserData)),
 
 	// gendoc:generate(entity=instance, group=cloud-init, key=user.network-config)
 	//
@@ -166,6 +355,36 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: What to do when evacuating the instance
 	"cluster.evacuate": validate.Optional(validate.IsOneOf("auto", "migrate", "live-migrate", "stop", "stateful-stop", "force-stop")),
 
+	// gendoc:generate(entity=instance, group=miscellaneous, key=expiry.ttl)
+	// Specify an expression like `1M 2H 3d 4w 5m 6y`, counted from the creation of the instance.
+	// Once expired, the instance is stopped and deleted.
+	//
+	// See {ref}`instances-expiry` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: empty
+	//  liveupdate: yes
+	//  shortdesc: When the instance is to be deleted
+	"expiry.ttl": func(value string) error {
+		// Validate expression
+		_, err := GetExpiry(time.Time{}, value)
+		return err
+	},
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=expiry.warning)
+	// Specify an expression like `1M 2H 3d 4w 5m 6y`.
+	// An `instance-expiring` lifecycle event is emitted that long before the instance gets deleted.
+	// ---
+	//  type: string
+	//  defaultdesc: `10M`
+	//  liveupdate: yes
+	//  shortdesc: How long before its expiry to warn about the instance deletion
+	"expiry.warning": func(value string) error {
+		// Validate expression
+		_, err := GetExpiry(time.Time{}, value)
+		return err
+	},
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu)
 	// A number or a specific range of CPUs to expose to the instance.
 	//
@@ -177,6 +396,19 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Which CPUs to expose to the instance
 	"limits.cpu": validate.Optional(validate.IsValidCPUSet),
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.allocation)
+	// How the CPUs of the instance are picked.
+	// With `manual`, the CPUs are set through `limits.cpu` and `limits.cpu.nodes`.
+	// With `numa-auto`, Incus pins the instance to as many CPUs as set in `limits.cpu`, close to its GPUs and NICs and not used by other instances.
+	//
+	// See {ref}`instance-options-limits-cpu-allocation` for more information.
+	// ---
+	//  type: string
+	//  defaultdesc: `manual`
+	//  liveupdate: yes (containers only)
+	//  shortdesc: How to pick the instance CPUs
+	"limits.cpu.allocation": validate.Optional(validate.IsOneOf("manual", "numa-auto")),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.nodes)
 	// A comma-separated list of NUMA node IDs or ranges to place the instance CPUs on.
 	// Alternatively, the value `balanced` may be used to have Incus pick the least busy NUMA node on startup.
@@ -239,6 +471,17 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 		return nil
 	},
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.freeze_priority)
+	// Specify an integer between 0 and 10.
+	// When {config:option}`server-miscellaneous:instances.memory_pressure.freeze_threshold` is set, instances with this option
+	// get frozen under host memory pressure, the ones with the lowest value first, and unfrozen in the reverse order once the pressure subsides.
+	// Instances without this option are never frozen.
+	// ---
+	//  type: integer
+	//  liveupdate: yes
+	//  shortdesc: Order in which the instance is frozen under host memory pressure
+	"limits.memory.freeze_priority": validate.Optional(validate.IsPriority),
+
 	// gendoc:generate(entity=instance, group=migration, key=migration.stateful)
 	// Enabling this option prevents the use of some features that are incompatible with it.
 	// ---
@@ -267,6 +510,27 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Raw idmap configuration
 	"raw.idmap": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=security, key=security.exec.allowed_commands)
+	// Entries are glob patterns matched against the command name, or against the full command path if they contain a `/`.
+	// Entries containing a `/` never match commands given without an absolute path.
+	// When set, only matching commands can be run through the exec API.
+	// See {ref}`run-commands-policy` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Comma-separated list of commands allowed to be run through the exec API
+	"security.exec.allowed_commands": validate.Optional(validate.IsListOf(isExecCommandPattern)),
+
+	// gendoc:generate(entity=instance, group=security, key=security.exec.denied_commands)
+	// Entries are glob patterns matched against the command name, or against the full command path if they contain a `/`.
+	// Commands given without an absolute path are matched against the last element of entries containing a `/`.
+	// See {ref}`run-commands-policy` for more information.
+	// ---
+	//  type: string
+	//  liveupdate: yes
+	//  shortdesc: Comma-separated list of commands which can't be run through the exec API
+	"security.exec.denied_commands": validate.Optional(validate.IsListOf(isExecCommandPattern)),
+
 	// gendoc:generate(entity=instance, group=security, key=security.guestapi)
 	// See {ref}`dev-incus` for more information.
 	// ---
@@ -359,6 +623,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: The original cluster group for the instance
 	"volatile.cluster.group": validate.IsAny,
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.cpu.allocation)
+	// The CPUs that were picked for the instance by the automatic CPU allocation.
+	// ---
+	//  type: string
+	//  shortdesc: Instance CPU allocation
+	"volatile.cpu.allocation": validate.Optional(validate.IsValidCPUSet),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.cpu.nodes)
 	// The NUMA node that was selected for the instance.
 	// ---
@@ -387,6 +658,13 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 	//  shortdesc: Instance marked itself as ready
 	"volatile.last_state.ready": validate.IsBool,
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.memory_pressure.frozen)
+	//
+	// ---
+	//  type: bool
+	//  shortdesc: Instance frozen because of host memory pressure
+	"volatile.memory_pressure.frozen": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.uuid)
 	// The instance UUID is globally unique across all servers and projects.
 	// ---
@@ -405,6 +683,27 @@ var InstanceConfigKeysAny = map[string]func(value string) error{
 
 // InstanceConfigKeysContainer is a map of config key to validator. (keys applying to containers only).
 var InstanceConfigKeysContainer = map[string]func(value string) error{
+	// gendoc:generate(entity=instance, group=miscellaneous, key=console.log.history)
+	// The console output of each boot is kept in a separate log file.
+	// This controls how many of the previous boots are kept around after a restart or crash.
+	// ---
+	//  type: integer
+	//  defaultdesc: `1`
+	//  liveupdate: yes
+	//  condition: container
+	//  shortdesc: Number of previous boots to keep the console log for
+	"console.log.history": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=miscellaneous, key=console.log.size)
+	// Once reached, the console log of the current boot is truncated and starts over.
+	// ---
+	//  type: string
+	//  defaultdesc: `auto`
+	//  liveupdate: no
+	//  condition: container
+	//  shortdesc: Size limit of the console log of each boot
+	"console.log.size": validate.Optional(validate.Or(validate.IsOneOf("auto"), validate.IsSize)),
+
 	// gendoc:generate(entity=instance, group=resource-limits, key=limits.cpu.allowance)
 	// To control how much of the CPU can be used, specify either a percentage (`50%`) for a soft limit
 	// or a chunk of time (`25ms/100ms`) for a hard limit.
@@ -781,7 +1080,7 @@ var InstanceConfigKeysContainer = map[string]func(value string) error{
 	// ---
 	//  type: bool
 	//  defaultdesc: `false`
-	//  liveupdate: no
+	//  liveupdate: yes
 	//  condition: container
 	//  shortdesc: Whether to allow BPF programs
 	"security.syscalls.intercept.bpf.devices": validate.Optional(validate.IsBool),
@@ -907,6 +1206,62 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to back the instance using huge pages
 	"limits.memory.hugepages": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=resource-limits, key=limits.memory.hotplug)
+	// When set to a value larger than {config:option}`instance-resource-limits:limits.memory`, a `virtio-mem` device is added to the VM so that its memory can be grown at runtime up to this size.
+	// This is only supported on `x86_64` and can't be combined with {config:option}`instance-resource-limits:limits.memory.hugepages`.
+	// The hotplugged memory is attached to the first NUMA node of the VM and follows its {config:option}`instance-resource-limits:limits.cpu.nodes` restrictions.
+	// ---
+	//  type: string
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Maximum memory size the VM can grow to while running
+	"limits.memory.hotplug": validate.Optional(validate.IsSize),
+
+	// gendoc:generate(entity=instance, group=migration, key=migration.stateful.max_downtime)
+	// Maximum time in milliseconds the instance may be paused at the end of a live migration.
+	// A higher value lets busy instances converge faster.
+	// ---
+	//  type: integer
+	//  defaultdesc: `300` (QEMU default)
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Maximum downtime of a live migration
+	"migration.stateful.max_downtime": validate.Optional(validate.IsUint32),
+
+	// gendoc:generate(entity=instance, group=migration, key=migration.stateful.bandwidth)
+	// Maximum bandwidth used to transfer the memory of the instance during a live migration, in bytes per second.
+	// Various suffixes are supported (see {ref}`instances-limit-units`).
+	// ---
+	//  type: string
+	//  defaultdesc: QEMU default
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Maximum bandwidth of a live migration
+	"migration.stateful.bandwidth": validate.Optional(validate.IsSize),
+
+	// gendoc:generate(entity=instance, group=migration, key=migration.stateful.auto_converge)
+	// When the memory of the instance gets dirtied faster than it can be transferred, throttle down its CPUs
+	// so that the live migration can complete.
+	// ---
+	//  type: bool
+	//  defaultdesc: `true`
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Whether to throttle the instance for a live migration to converge
+	"migration.stateful.auto_converge": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=migration, key=migration.stateful.postcopy)
+	// When the memory transfer of a live migration still hasn't converged after three full passes, switch over to
+	// the target right away and fetch the remaining memory pages from the source as the instance accesses them.
+	// This bounds the duration of the migration, but the instance is lost if the connection fails during that phase.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: yes
+	//  condition: virtual machine
+	//  shortdesc: Whether to switch a non-converging live migration to post-copy
+	"migration.stateful.postcopy": validate.Optional(validate.IsBool),
+
 	// Caller is responsible for full validation of any raw.* value.
 
 	// gendoc:generate(entity=instance, group=raw, key=raw.qemu)
@@ -997,6 +1352,56 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: The guest owner's `base64`-encoded session blob
 	"security.sev.session.data": validate.Optional(validate.IsAny),
 
+	// gendoc:generate(entity=instance, group=security, key=security.sev.snp)
+	// This requires {config:option}`instance-security:security.sev` to be enabled and the VM to boot from a stateless firmware with {config:option}`instance-security:security.secureboot` disabled.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether AMD SEV-SNP (Secure Nested Paging) is enabled for this VM
+	"security.sev.snp": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=security, key=security.tdx)
+	// This requires the VM to boot from a stateless firmware with {config:option}`instance-security:security.secureboot` disabled.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether Intel TDX (Trust Domain Extensions) is enabled for this VM
+	"security.tdx": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=spice, key=spice.audio)
+	// The sound card is exposed to the SPICE clients through the SPICE audio channels.
+	// ---
+	//  type: bool
+	//  defaultdesc: `false`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether to add a sound card to the VM
+	"spice.audio": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=spice, key=spice.clipboard)
+	// This requires the SPICE agent to be running in the VM.
+	// ---
+	//  type: bool
+	//  defaultdesc: `true`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether the clipboard is shared with the SPICE clients
+	"spice.clipboard": validate.Optional(validate.IsBool),
+
+	// gendoc:generate(entity=instance, group=spice, key=spice.enabled)
+	// When disabled, the VM has no graphical (`vga`) console and no SPICE channels (agent, folder sharing, USB redirection and audio).
+	// ---
+	//  type: bool
+	//  defaultdesc: `true`
+	//  liveupdate: no
+	//  condition: virtual machine
+	//  shortdesc: Whether the graphical console is available
+	"spice.enabled": validate.Optional(validate.IsBool),
+
 	// gendoc:generate(entity=instance, group=miscellaneous, key=user.*)
 	// User keys can be used in search.
 	// ---
@@ -1015,6 +1420,13 @@ var InstanceConfigKeysVM = map[string]func(value string) error{
 	//  shortdesc: Whether to use the name and MTU of the default network interfaces
 	"agent.nic_config": validate.Optional(validate.IsBool),
 
+	// gendoc:generate(entity=instance, group=volatile, key=volatile.agent.features)
+	// An empty value means that the agent didn't report its features and is assumed to support all of them.
+	// ---
+	//  type: string
+	//  shortdesc: Comma-separated list of features reported by the instance agent
+	"volatile.agent.features": validate.IsAny,
+
 	// gendoc:generate(entity=instance, group=volatile, key=volatile.apply_nvram)
 	//
 	// ---
@@ -1093,6 +1505,24 @@ func ConfigKeyChecker(key string, instanceType api.InstanceType) (func(value str
 			return validate.IsAny, nil
 		}
 
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.ipam.ipv4.id)
+		// The identifier of the IPv4 address allocated from the external IPAM of the network.
+		// ---
+		//  type: string
+		//  shortdesc: External IPAM IPv4 allocation ID
+		if strings.HasSuffix(key, ".ipam.ipv4.id") {
+			return validate.IsAny, nil
+		}
+
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.ipam.ipv6.id)
+		// The identifier of the IPv6 address allocated from the external IPAM of the network.
+		// ---
+		//  type: string
+		//  shortdesc: External IPAM IPv6 allocation ID
+		if strings.HasSuffix(key, ".ipam.ipv6.id") {
+			return validate.IsAny, nil
+		}
+
 		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.mig.uuid)
 		// The NVIDIA MIG instance UUID.
 		// ---
@@ -1120,6 +1550,33 @@ func ConfigKeyChecker(key string, instanceType api.InstanceType) (func(value str
 			return validate.IsAny, nil
 		}
 
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.tasks.<name>.last_run)
+		// The time at which the scheduled task last ran.
+		// ---
+		//  type: string
+		//  shortdesc: Last run of the scheduled task
+		if strings.HasPrefix(key, "volatile.tasks.") && strings.HasSuffix(key, ".last_run") {
+			return validate.IsAny, nil
+		}
+
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.tasks.<name>.last_status)
+		// The exit code of the last run of the scheduled task, or `-1` if it couldn't be run.
+		// ---
+		//  type: integer
+		//  shortdesc: Exit code of the scheduled task
+		if strings.HasPrefix(key, "volatile.tasks.") && strings.HasSuffix(key, ".last_status") {
+			return validate.IsInt64, nil
+		}
+
+		// gendoc:generate(entity=instance, group=volatile, key=volatile.tasks.<name>.last_output)
+		// The path of the recorded standard output of the last run of the scheduled task, as listed under `/1.0/instances/<name>/logs/exec-output`.
+		// ---
+		//  type: string
+		//  shortdesc: Output of the scheduled task
+		if strings.HasPrefix(key, "volatile.tasks.") && strings.HasSuffix(key, ".last_output") {
+			return validate.IsAny, nil
+		}
+
 		// gendoc:generate(entity=instance, group=volatile, key=volatile.<name>.last_state.created)
 		// Possible values are `true` or `false`.
 		// ---
@@ -1264,6 +1721,61 @@ func ConfigKeyChecker(key string, instanceType api.InstanceType) (func(value str
 		return validate.IsAny, nil
 	}
 
+	if strings.HasPrefix(key, "tasks.") {
+		// gendoc:generate(entity=instance, group=tasks, key=tasks.<name>.schedule)
+		// Specify either a cron expression (`<minute> <hour> <dom> <month> <dow>`) or a comma-separated list of schedule aliases (`@hourly`, `@daily`, `@midnight`, `@weekly`, `@monthly`, `@annually`, `@yearly`).
+		// The task only runs while the instance is running.
+		// ---
+		//  type: string
+		//  liveupdate: yes
+		//  shortdesc: Schedule of the task
+		if strings.HasSuffix(key, ".schedule") {
+			return validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly", "@never"}), nil
+		}
+
+		// gendoc:generate(entity=instance, group=tasks, key=tasks.<name>.command)
+		// The command is run through `/bin/sh -c` inside of the instance, the same way as `incus exec`.
+		// ---
+		//  type: string
+		//  liveupdate: yes
+		//  shortdesc: Command to run
+		if strings.HasSuffix(key, ".command") {
+			return validate.IsNotEmpty, nil
+		}
+
+		// gendoc:generate(entity=instance, group=tasks, key=tasks.<name>.user)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `0`
+		//  liveupdate: yes
+		//  shortdesc: User ID to run the command as
+		if strings.HasSuffix(key, ".user") {
+			return validate.Optional(validate.IsUint32), nil
+		}
+
+		// gendoc:generate(entity=instance, group=tasks, key=tasks.<name>.group)
+		//
+		// ---
+		//  type: integer
+		//  defaultdesc: `0`
+		//  liveupdate: yes
+		//  shortdesc: Group ID to run the command as
+		if strings.HasSuffix(key, ".group") {
+			return validate.Optional(validate.IsUint32), nil
+		}
+
+		// gendoc:generate(entity=instance, group=tasks, key=tasks.<name>.cwd)
+		//
+		// ---
+		//  type: string
+		//  liveupdate: yes
+		//  shortdesc: Directory to run the command in
+		if strings.HasSuffix(key, ".cwd") {
+			return validate.Optional(validate.IsAbsFilePath), nil
+		}
+	}
+
 	if strings.HasPrefix(key, "image.") {
 		return validate.IsAny, nil
 	}
@@ -1419,3 +1931,50 @@ func InstanceIncludeWhenCopying(configKey string, remoteCopy bool) bool {
 
 	return true // Keep all other keys.
 }
+
+// cloudInitTemplateValidators holds the validators of the cloud-init keys which may be rendered as templates.
+var cloudInitTemplateValidators = map[string]func(value string) error{
+	"cloud-init.network-config": validate.IsYAML,
+	"cloud-init.user-data":      validate.IsCloudInitUserData,
+	"cloud-init.vendor-data":    validate.IsCloudInitUserData,
+}
+
+// isCloudInitTemplate returns whether the value contains template tags.
+func isCloudInitTemplate(value string) bool {
+	return strings.Contains(value, "{{") || strings.Contains(value, "{%")
+}
+
+// isCloudInitTemplateOr skips the provided validator for values containing template tags.
+// Whether those are rendered depends on cloud-init.templating, which may come from a different
+// profile, so ValidCloudInitConfig re-validates them against the expanded configuration.
+func isCloudInitTemplateOr(validator func(value string) error) func(value string) error {
+	return func(value string) error {
+		if isCloudInitTemplate(value) {
+			return nil
+		}
+
+		return validator(value)
+	}
+}
+
+// ValidCloudInitConfig validates the cloud-init keys of an expanded configuration.
+// Values containing template tags are only accepted when cloud-init.templating is enabled.
+func ValidCloudInitConfig(config map[string]string) error {
+	if util.IsTrue(config["cloud-init.templating"]) {
+		return nil
+	}
+
+	for key, validator := range cloudInitTemplateValidators {
+		value := config[key]
+		if value == "" || !isCloudInitTemplate(value) {
+			continue
+		}
+
+		err := validator(value)
+		if err != nil {
+			return fmt.Errorf("Invalid value for config option %q (cloud-init.templating is disabled): %w", key, err)
+		}
+	}
+
+	return nil
+}
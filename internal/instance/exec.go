@@ -0,0 +1,70 @@
+package instance
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// ExecCommandAllowed checks a command against the security.exec.allowed_commands and
+// security.exec.denied_commands policies of an instance.
+//
+// Entries are glob patterns. Those containing a "/" are matched against the full command path,
+// others against the command name only. Command paths are cleaned before matching, but symlinks
+// in the instance aren't resolved.
+//
+// Commands without an absolute path are resolved through the PATH of the instance, so their
+// location isn't known. A denied entry containing a "/" is then matched against the command name
+// using the last element of the entry, while an allowed entry containing a "/" never matches.
+func ExecCommandAllowed(config map[string]string, command []string) error {
+	if len(command) == 0 || command[0] == "" {
+		return fmt.Errorf("No command provided")
+	}
+
+	target := command[0]
+	isAbs := path.IsAbs(target)
+	if strings.Contains(target, "/") {
+		target = path.Clean(target)
+	}
+
+	matches := func(key string, deny bool) bool {
+		for _, entry := range util.SplitNTrimSpace(config[key], ",", -1, true) {
+			var match bool
+			if !strings.Contains(entry, "/") {
+				match, _ = path.Match(entry, path.Base(target))
+			} else if isAbs {
+				match, _ = path.Match(path.Clean(entry), target)
+			} else if deny {
+				match, _ = path.Match(path.Base(entry), path.Base(target))
+			}
+
+			if match {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if matches("security.exec.denied_commands", true) {
+		return fmt.Errorf("Command %q is denied by the instance exec policy", command[0])
+	}
+
+	if config["security.exec.allowed_commands"] != "" && !matches("security.exec.allowed_commands", false) {
+		return fmt.Errorf("Command %q isn't allowed by the instance exec policy", command[0])
+	}
+
+	return nil
+}
+
+// isExecCommandPattern validates an entry of the exec policy configuration keys.
+func isExecCommandPattern(value string) error {
+	_, err := path.Match(value, "")
+	if err != nil {
+		return fmt.Errorf("Invalid command pattern: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package instance
+
+import (
+	"testing"
+)
+
+func TestExecCommandAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed string
+		denied  string
+		command string
+		allow   bool
+	}{
+		{"no policy", "", "", "/bin/bash", true},
+		{"denied path", "", "/bin/bash", "/bin/bash", false},
+		{"denied path with double slash", "", "/bin/bash", "/bin//bash", false},
+		{"denied path with dot", "", "/bin/bash", "/bin/./bash", false},
+		{"denied path with parent", "", "/bin/bash", "/usr/../bin/bash", false},
+		{"denied path with bare name", "", "/bin/bash", "bash", false},
+		{"denied path with relative path", "", "/bin/bash", "./bash", false},
+		{"denied path other command", "", "/bin/bash", "/bin/sh", true},
+		{"denied glob with bare name", "", "/usr/local/bin/*", "foo", false},
+		{"denied name", "", "bash", "/usr/bin/bash", false},
+		{"denied name with bare name", "", "bash", "bash", false},
+		{"allowed name", "systemctl", "", "/usr/bin/systemctl", true},
+		{"allowed name with bare name", "systemctl", "", "systemctl", true},
+		{"allowed name other command", "systemctl", "", "/bin/bash", false},
+		{"allowed glob", "/usr/local/bin/*", "", "/usr/local/bin/tool", true},
+		{"allowed glob with parent escape", "/usr/local/bin/*", "", "/usr/local/bin/../../bin/bash", false},
+		{"allowed glob with double slash", "/usr/local/bin/*", "", "/usr/local//bin/tool", true},
+		{"allowed path with bare name", "/usr/local/bin/*", "", "tool", false},
+		{"denied takes precedence", "bash", "/bin/bash", "/bin/bash", false},
+		{"empty command", "", "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]string{
+				"security.exec.allowed_commands": test.allowed,
+				"security.exec.denied_commands":  test.denied,
+			}
+
+			err := ExecCommandAllowed(config, []string{test.command})
+			if test.allow && err != nil {
+				t.Errorf("Expected %q to be allowed, got: %v", test.command, err)
+			} else if !test.allow && err == nil {
+				t.Errorf("Expected %q to be refused", test.command)
+			}
+		})
+	}
+}
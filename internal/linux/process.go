@@ -0,0 +1,60 @@
+package linux
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ProcessIO represents the I/O counters of a process.
+type ProcessIO struct {
+	ReadBytes     uint64
+	WrittenBytes  uint64
+	ReadSyscalls  uint64
+	WriteSyscalls uint64
+}
+
+// GetProcessIO parses /proc/PID/io for the given process.
+func GetProcessIO(pid int64) (*ProcessIO, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/io", pid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	processIO := &ProcessIO{}
+
+	scan := bufio.NewScanner(f)
+	for scan.Scan() {
+		key, value, found := strings.Cut(scan.Text(), ":")
+		if !found {
+			continue
+		}
+
+		count, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing %q: %w", key, err)
+		}
+
+		switch key {
+		case "rchar":
+			processIO.ReadBytes = count
+		case "wchar":
+			processIO.WrittenBytes = count
+		case "syscr":
+			processIO.ReadSyscalls = count
+		case "syscw":
+			processIO.WriteSyscalls = count
+		}
+	}
+
+	err = scan.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return processIO, nil
+}
@@ -15,6 +15,9 @@ const ZFSFeatureMigrationHeader = "migration_header"
 // ZFSFeatureZvolFilesystems indicates migration can send/recv zvols.
 const ZFSFeatureZvolFilesystems = "header_zvol_filesystems"
 
+// RsyncFeatureBlockStream indicates block-backed volumes can be sent as a raw block stream rather than using rsync.
+const RsyncFeatureBlockStream = "block_stream"
+
 // GetRsyncFeaturesSlice returns a slice of strings representing the supported RSYNC features.
 func (m *MigrationHeader) GetRsyncFeaturesSlice() []string {
 	features := []string{}
@@ -38,6 +41,10 @@ func (m *MigrationHeader) GetRsyncFeaturesSlice() []string {
 		if m.RsyncFeatures.Bidirectional != nil && *m.RsyncFeatures.Bidirectional {
 			features = append(features, "bidirectional")
 		}
+
+		if m.RsyncFeatures.BlockStream != nil && *m.RsyncFeatures.BlockStream {
+			features = append(features, RsyncFeatureBlockStream)
+		}
 	}
 
 	return features
@@ -18,10 +18,27 @@ type ValidateVolume struct {
 	Pool          string `json:"pool" yaml:"pool"`                   // Pool the volume belongs to.
 }
 
+// ValidateNetwork provides info about a missing network that the recovery validation scan found.
+type ValidateNetwork struct {
+	Name              string `json:"name" yaml:"name"`                           // Name of network.
+	Type              string `json:"type" yaml:"type"`                           // Type of network.
+	Project           string `json:"project" yaml:"project"`                     // Project the network belongs to.
+	ForwardCount      int    `json:"forwardCount" yaml:"forwardCount"`           // Count of forwards found for network.
+	LoadBalancerCount int    `json:"loadBalancerCount" yaml:"loadBalancerCount"` // Count of load balancers found for network.
+}
+
+// ValidateNetworkACL provides info about a missing network ACL that the recovery validation scan found.
+type ValidateNetworkACL struct {
+	Name    string `json:"name" yaml:"name"`       // Name of network ACL.
+	Project string `json:"project" yaml:"project"` // Project the network ACL belongs to.
+}
+
 // ValidateResult returns the result of the validation scan.
 type ValidateResult struct {
-	UnknownVolumes   []ValidateVolume // Volumes that could be imported.
-	DependencyErrors []string         // Errors that are preventing import from proceeding.
+	UnknownVolumes     []ValidateVolume     // Volumes that could be imported.
+	UnknownNetworks    []ValidateNetwork    // Networks that could be imported.
+	UnknownNetworkACLs []ValidateNetworkACL // Network ACLs that could be imported.
+	DependencyErrors   []string             // Errors that are preventing import from proceeding.
 }
 
 // ImportPost is used to initiate a recovert import.
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +23,7 @@ import (
 )
 
 // Debug controls additional debugging in rsync output.
-var Debug bool
+var Debug atomic.Bool
 
 // RunWrapper is an optional function that's used to wrap rsync, useful for confinement like AppArmor.
 var RunWrapper func(cmd *exec.Cmd, source string, destination string) (func(), error)
@@ -70,7 +71,7 @@ func LocalCopy(source string, dest string, bwlimit string, xattrs bool, rsyncArg
 	}
 
 	rsyncVerbosity := "-q"
-	if Debug {
+	if Debug.Load() {
 		rsyncVerbosity = "-vi"
 	}
 
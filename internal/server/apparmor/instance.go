@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/lxc/incus/v6/internal/server/cgroup"
@@ -140,15 +142,89 @@ func instanceProfileGenerate(sysOS *sys.OS, inst instance, extraBinaries []strin
 	return nil
 }
 
+// projectRulesPerms matches the permissions of a file rule.
+var projectRulesPerms = regexp.MustCompile(`^[rwaklmixpPcCuU]+$`)
+
+// ValidateProjectRules checks that the AppArmor rules set on a project only contain plain rules.
+// Anything which could affect more than the profile of the project's instances (includes, nested
+// profiles, hats or profile transitions) is rejected.
+func ValidateProjectRules(rules string) error {
+	for _, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+
+		// AppArmor treats "#include" as an include directive rather than a comment.
+		if strings.HasPrefix(strings.ReplaceAll(line, " ", ""), "#include") {
+			return fmt.Errorf("Includes aren't allowed: %q", line)
+		}
+
+		// Skip empty lines and comments.
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Only keep the rule itself for the checks below.
+		rule, _, _ := strings.Cut(line, "#")
+		rule = strings.TrimSpace(rule)
+
+		if !strings.HasSuffix(rule, ",") {
+			return fmt.Errorf("Rules must end with a comma: %q", line)
+		}
+
+		// Braces are only allowed for alternations within paths, not for blocks.
+		if strings.Count(rule, "{") != strings.Count(rule, "}") || strings.Contains(rule, "{ ") || strings.Contains(rule, " }") {
+			return fmt.Errorf("Blocks, nested profiles and hats aren't allowed: %q", line)
+		}
+
+		fields := strings.Fields(strings.TrimSuffix(rule, ","))
+		if len(fields) > 0 && slices.Contains([]string{"include", "profile", "hat", "change_profile", "change_hat", "abi", "alias"}, fields[0]) {
+			return fmt.Errorf("%q rules aren't allowed: %q", fields[0], line)
+		}
+
+		// Don't allow escaping the confinement of the instance.
+		if strings.Contains(rule, "->") || slices.Contains(fields, "unconfined") {
+			return fmt.Errorf("Profile transitions aren't allowed: %q", line)
+		}
+
+		for _, field := range fields {
+			if projectRulesPerms.MatchString(field) && strings.Contains(strings.ToLower(field), "ux") {
+				return fmt.Errorf("Unconfined execution isn't allowed: %q", line)
+			}
+		}
+	}
+
+	return nil
+}
+
+// indentRules indents the given rules for inclusion in a profile.
+func indentRules(rules string) string {
+	content := ""
+	for _, line := range strings.Split(strings.Trim(rules, "\n"), "\n") {
+		content += fmt.Sprintf("  %s\n", line)
+	}
+
+	return content
+}
+
 // instanceProfile generates the AppArmor profile template from the given instance.
 func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (string, error) {
 	// Prepare raw.apparmor.
 	rawContent := ""
 	rawApparmor, ok := inst.ExpandedConfig()["raw.apparmor"]
 	if ok {
-		for _, line := range strings.Split(strings.Trim(rawApparmor, "\n"), "\n") {
-			rawContent += fmt.Sprintf("  %s\n", line)
+		rawContent = indentRules(rawApparmor)
+	}
+
+	// Prepare the project rules.
+	projectContent := ""
+	projectRules := inst.Project().Config["security.apparmor"]
+	if projectRules != "" {
+		// The rules were validated when set, but check again in case they predate the validation.
+		err := ValidateProjectRules(projectRules)
+		if err != nil {
+			return "", fmt.Errorf("Invalid AppArmor rules in project %q: %w", inst.Project().Name, err)
 		}
+
+		projectContent = indentRules(projectRules)
 	}
 
 	// Check for features.
@@ -186,6 +262,8 @@ func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (stri
 			"name":                InstanceProfileName(inst),
 			"namespace":           InstanceNamespaceName(inst),
 			"nesting":             util.IsTrue(inst.ExpandedConfig()["security.nesting"]),
+			"project":             inst.Project().Name,
+			"project_raw":         projectContent,
 			"raw":                 rawContent,
 			"unprivileged":        util.IsFalseOrEmpty(inst.ExpandedConfig()["security.privileged"]) || sysOS.RunningInUserNS,
 		})
@@ -249,6 +327,8 @@ func instanceProfile(sysOS *sys.OS, inst instance, extraBinaries []string) (stri
 			"runPath":        inst.RunPath(),
 			"name":           InstanceProfileName(inst),
 			"path":           path,
+			"project":        inst.Project().Name,
+			"project_raw":    projectContent,
 			"raw":            rawContent,
 			"edk2Paths":      edk2Paths,
 			"agentPath":      agentPath,
@@ -556,6 +556,12 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
   mount options=(ro,remount) /**,
 {{- end }}
 
+{{- if .project_raw }}
+
+  ### Project: {{ .project }} (security.apparmor)
+{{ .project_raw }}
+{{- end }}
+
 {{- if .raw }}
 
   ### Configuration: raw.apparmor
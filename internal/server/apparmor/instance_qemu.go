@@ -89,6 +89,12 @@ profile "{{ .name }}" flags=(attach_disconnected,mediate_deleted) {
 {{- end }}
 {{- end }}
 
+{{- if .project_raw }}
+
+  ### Project: {{ .project }} (security.apparmor)
+{{ .project_raw }}
+{{- end }}
+
 {{- if .raw }}
 
   ### Configuration: raw.apparmor
@@ -32,11 +32,12 @@ const (
 	EntitlementCanViewEvents                Entitlement = "can_view_events"
 
 	// Instance entitlements.
-	EntitlementCanUpdateState   Entitlement = "can_update_state"
-	EntitlementCanConnectSFTP   Entitlement = "can_connect_sftp"
-	EntitlementCanAccessFiles   Entitlement = "can_access_files"
-	EntitlementCanAccessConsole Entitlement = "can_access_console"
-	EntitlementCanExec          Entitlement = "can_exec"
+	EntitlementCanUpdateState    Entitlement = "can_update_state"
+	EntitlementCanConnectSFTP    Entitlement = "can_connect_sftp"
+	EntitlementCanAccessFiles    Entitlement = "can_access_files"
+	EntitlementCanAccessConsole  Entitlement = "can_access_console"
+	EntitlementCanExec           Entitlement = "can_exec"
+	EntitlementCanCaptureTraffic Entitlement = "can_capture_traffic"
 
 	// Instance and storage volume entitlements.
 	EntitlementCanManageSnapshots Entitlement = "can_manage_snapshots"
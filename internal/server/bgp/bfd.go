@@ -0,0 +1,502 @@
+package bgp
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// BFD session states (RFC 5880 section 4.1).
+const (
+	bfdStateAdminDown uint8 = 0
+	bfdStateDown      uint8 = 1
+	bfdStateInit      uint8 = 2
+	bfdStateUp        uint8 = 3
+)
+
+// BFD diagnostic codes (RFC 5880 section 4.1).
+const (
+	bfdDiagNone              uint8 = 0
+	bfdDiagTimeExpired       uint8 = 1
+	bfdDiagNeighborSignalled uint8 = 3
+)
+
+// BFD control packet flags.
+const (
+	bfdFlagPoll  uint8 = 0x20
+	bfdFlagFinal uint8 = 0x10
+)
+
+// Single-hop BFD control packets are received on this port (RFC 5881 section 4).
+const bfdPort = 3784
+
+// bfdPacketLength is the length of a BFD control packet without authentication.
+const bfdPacketLength = 24
+
+// Timer settings used for all sessions.
+const (
+	bfdDetectMult      = 3
+	bfdMinInterval     = 300 * time.Millisecond
+	bfdSlowInterval    = time.Second // Used while the session isn't up (RFC 5880 section 6.8.3).
+	bfdSourcePortFirst = 49152
+	bfdSourcePortLast  = 65535
+)
+
+// bfdStateNames maps the BFD session states to their name.
+var bfdStateNames = map[uint8]string{
+	bfdStateAdminDown: "admin_down",
+	bfdStateDown:      "down",
+	bfdStateInit:      "init",
+	bfdStateUp:        "up",
+}
+
+// bfdPacket represents a BFD control packet.
+type bfdPacket struct {
+	diag               uint8
+	state              uint8
+	flags              uint8
+	detectMult         uint8
+	myDiscriminator    uint32
+	yourDiscriminator  uint32
+	desiredMinTx       uint32 // Microseconds.
+	requiredMinRx      uint32 // Microseconds.
+	requiredMinEchoRx  uint32 // Microseconds.
+	authenticationUsed bool
+}
+
+// marshal returns the wire format of the packet.
+func (p *bfdPacket) marshal() []byte {
+	buf := make([]byte, bfdPacketLength)
+	buf[0] = 1<<5 | p.diag&0x1f
+	buf[1] = p.state<<6 | p.flags&0x3f
+	buf[2] = p.detectMult
+	buf[3] = bfdPacketLength
+	binary.BigEndian.PutUint32(buf[4:], p.myDiscriminator)
+	binary.BigEndian.PutUint32(buf[8:], p.yourDiscriminator)
+	binary.BigEndian.PutUint32(buf[12:], p.desiredMinTx)
+	binary.BigEndian.PutUint32(buf[16:], p.requiredMinRx)
+	binary.BigEndian.PutUint32(buf[20:], p.requiredMinEchoRx)
+
+	return buf
+}
+
+// bfdParsePacket parses and validates a BFD control packet (RFC 5880 section 6.8.6).
+func bfdParsePacket(buf []byte) (*bfdPacket, error) {
+	if len(buf) < bfdPacketLength {
+		return nil, fmt.Errorf("Packet too short")
+	}
+
+	if buf[0]>>5 != 1 {
+		return nil, fmt.Errorf("Unsupported version %d", buf[0]>>5)
+	}
+
+	length := int(buf[3])
+	if length < bfdPacketLength || length > len(buf) {
+		return nil, fmt.Errorf("Invalid length %d", length)
+	}
+
+	p := &bfdPacket{
+		diag:               buf[0] & 0x1f,
+		state:              buf[1] >> 6,
+		flags:              buf[1] & 0x3f,
+		detectMult:         buf[2],
+		myDiscriminator:    binary.BigEndian.Uint32(buf[4:]),
+		yourDiscriminator:  binary.BigEndian.Uint32(buf[8:]),
+		desiredMinTx:       binary.BigEndian.Uint32(buf[12:]),
+		requiredMinRx:      binary.BigEndian.Uint32(buf[16:]),
+		requiredMinEchoRx:  binary.BigEndian.Uint32(buf[20:]),
+		authenticationUsed: buf[1]&0x04 != 0,
+	}
+
+	if p.detectMult == 0 {
+		return nil, fmt.Errorf("Invalid detection multiplier")
+	}
+
+	if p.flags&bfdFlagPoll != 0 && p.flags&bfdFlagFinal != 0 {
+		return nil, fmt.Errorf("Both poll and final flags are set")
+	}
+
+	if p.myDiscriminator == 0 {
+		return nil, fmt.Errorf("Invalid discriminator")
+	}
+
+	if p.yourDiscriminator == 0 && p.state != bfdStateDown && p.state != bfdStateAdminDown {
+		return nil, fmt.Errorf("Missing remote discriminator")
+	}
+
+	if p.authenticationUsed {
+		return nil, fmt.Errorf("Authentication isn't supported")
+	}
+
+	return p, nil
+}
+
+// bfdSession represents an asynchronous mode BFD session with a single peer.
+type bfdSession struct {
+	address net.IP
+	conn    *net.UDPConn
+	onDown  func()
+
+	localDiscriminator  uint32
+	remoteDiscriminator uint32
+	state               uint8
+	remoteState         uint8
+	diag                uint8
+	remoteMinRx         time.Duration
+	remoteDesiredMinTx  time.Duration
+	remoteDetectMult    uint8
+	lastReceived        time.Time
+	polling             bool
+
+	stop chan struct{}
+	mu   sync.Mutex
+}
+
+// desiredMinTx returns the local transmit interval, slowed down while the session isn't up.
+func (b *bfdSession) desiredMinTx() time.Duration {
+	if b.state != bfdStateUp {
+		return bfdSlowInterval
+	}
+
+	return bfdMinInterval
+}
+
+// send transmits a control packet to the peer.
+func (b *bfdSession) send(flags uint8) {
+	p := bfdPacket{
+		diag:              b.diag,
+		state:             b.state,
+		flags:             flags,
+		detectMult:        bfdDetectMult,
+		myDiscriminator:   b.localDiscriminator,
+		yourDiscriminator: b.remoteDiscriminator,
+		desiredMinTx:      uint32(b.desiredMinTx().Microseconds()),
+		requiredMinRx:     uint32(bfdMinInterval.Microseconds()),
+	}
+
+	if b.polling && flags&bfdFlagFinal == 0 {
+		p.flags |= bfdFlagPoll
+	}
+
+	_, err := b.conn.Write(p.marshal())
+	if err != nil {
+		logger.Debug("Failed sending BFD packet", logger.Ctx{"peer": b.address.String(), "err": err})
+	}
+}
+
+// setState changes the session state, notifying of the session going down.
+func (b *bfdSession) setState(state uint8, diag uint8) {
+	if b.state == state {
+		return
+	}
+
+	logger.Info("BFD session state changed", logger.Ctx{"peer": b.address.String(), "old": bfdStateNames[b.state], "new": bfdStateNames[state]})
+
+	wasUp := b.state == bfdStateUp
+	b.state = state
+	b.diag = diag
+
+	// The transmit interval changes when going up or down so let the peer know.
+	b.polling = true
+
+	if state == bfdStateDown {
+		b.remoteDiscriminator = 0
+	}
+
+	if wasUp && b.onDown != nil {
+		go b.onDown()
+	}
+}
+
+// receive processes a control packet received from the peer (RFC 5880 section 6.8.6).
+func (b *bfdSession) receive(p *bfdPacket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remoteDiscriminator = p.myDiscriminator
+	b.remoteState = p.state
+	b.remoteMinRx = time.Duration(p.requiredMinRx) * time.Microsecond
+	b.remoteDesiredMinTx = time.Duration(p.desiredMinTx) * time.Microsecond
+	b.remoteDetectMult = p.detectMult
+	b.lastReceived = time.Now()
+
+	// End of a poll sequence.
+	if p.flags&bfdFlagFinal != 0 {
+		b.polling = false
+	}
+
+	if p.state == bfdStateAdminDown {
+		if b.state != bfdStateDown {
+			b.setState(bfdStateDown, bfdDiagNeighborSignalled)
+		}
+	} else {
+		switch b.state {
+		case bfdStateDown:
+			if p.state == bfdStateDown {
+				b.setState(bfdStateInit, bfdDiagNone)
+			} else if p.state == bfdStateInit {
+				b.setState(bfdStateUp, bfdDiagNone)
+			}
+
+		case bfdStateInit:
+			if p.state == bfdStateInit || p.state == bfdStateUp {
+				b.setState(bfdStateUp, bfdDiagNone)
+			}
+
+		case bfdStateUp:
+			if p.state == bfdStateDown {
+				b.setState(bfdStateDown, bfdDiagNeighborSignalled)
+			}
+		}
+	}
+
+	// Reply to a poll sequence right away.
+	if p.flags&bfdFlagPoll != 0 {
+		b.send(bfdFlagFinal)
+	}
+}
+
+// run periodically transmits control packets and checks for the peer going silent.
+func (b *bfdSession) run() {
+	for {
+		b.mu.Lock()
+
+		// Check the detection time.
+		if b.state == bfdStateInit || b.state == bfdStateUp {
+			detectTime := time.Duration(b.remoteDetectMult) * max(bfdMinInterval, b.remoteDesiredMinTx)
+			if time.Since(b.lastReceived) > detectTime {
+				b.setState(bfdStateDown, bfdDiagTimeExpired)
+			}
+		}
+
+		// Don't send periodic packets when the peer asked for none.
+		if b.remoteMinRx > 0 || b.remoteState == bfdStateDown || b.lastReceived.IsZero() {
+			b.send(0)
+		}
+
+		// Jitter the interval by up to 25% (RFC 5880 section 6.8.7).
+		interval := max(b.desiredMinTx(), b.remoteMinRx)
+		interval = interval * time.Duration(75+rand.Intn(26)) / 100
+
+		b.mu.Unlock()
+
+		select {
+		case <-b.stop:
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// bfdServer handles the BFD sessions with the BGP peers.
+type bfdServer struct {
+	conn     *net.UDPConn
+	sessions map[string]*bfdSession
+
+	mu sync.Mutex
+}
+
+// bfdSetTTL sets the outgoing TTL and requests the received TTL on a socket (RFC 5881 section 5).
+func bfdSetTTL(network string, address string, c syscall.RawConn) error {
+	var sockErr error
+
+	err := c.Control(func(fd uintptr) {
+		for _, opt := range [][2]int{{unix.IPPROTO_IP, unix.IP_TTL}, {unix.IPPROTO_IP, unix.IP_RECVTTL}, {unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS}, {unix.IPPROTO_IPV6, unix.IPV6_RECVHOPLIMIT}} {
+			value := 255
+			if opt[1] == unix.IP_RECVTTL || opt[1] == unix.IPV6_RECVHOPLIMIT {
+				value = 1
+			}
+
+			err := unix.SetsockoptInt(int(fd), opt[0], opt[1], value)
+			if err != nil && (opt[0] == unix.IPPROTO_IP) == (network == "udp4") {
+				sockErr = err
+				return
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return sockErr
+}
+
+// listen processes the control packets received from the peers.
+func (d *bfdServer) listen(conn *net.UDPConn) {
+	buf := make([]byte, 1500)
+	oob := make([]byte, 128)
+
+	for {
+		n, oobn, _, addr, err := conn.ReadMsgUDP(buf, oob)
+		if err != nil {
+			return
+		}
+
+		// Only accept packets from directly connected peers.
+		msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+		if err == nil {
+			ttl := -1
+			for _, msg := range msgs {
+				if (msg.Header.Level == unix.IPPROTO_IP && msg.Header.Type == unix.IP_TTL) || (msg.Header.Level == unix.IPPROTO_IPV6 && msg.Header.Type == unix.IPV6_HOPLIMIT) {
+					if len(msg.Data) >= 4 {
+						ttl = int(binary.NativeEndian.Uint32(msg.Data))
+					}
+				}
+			}
+
+			if ttl >= 0 && ttl != 255 {
+				continue
+			}
+		}
+
+		p, err := bfdParsePacket(buf[:n])
+		if err != nil {
+			logger.Debug("Invalid BFD packet", logger.Ctx{"peer": addr.IP.String(), "err": err})
+			continue
+		}
+
+		d.mu.Lock()
+		var session *bfdSession
+		for _, s := range d.sessions {
+			if (p.yourDiscriminator != 0 && s.localDiscriminator == p.yourDiscriminator) || (p.yourDiscriminator == 0 && s.address.Equal(addr.IP)) {
+				session = s
+				break
+			}
+		}
+
+		d.mu.Unlock()
+
+		if session != nil {
+			session.receive(p)
+		}
+	}
+}
+
+// addSession starts a BFD session with the peer, onDown is called when an up session goes down.
+func (d *bfdServer) addSession(address net.IP, onDown func()) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.sessions == nil {
+		d.sessions = map[string]*bfdSession{}
+	}
+
+	_, found := d.sessions[address.String()]
+	if found {
+		return nil
+	}
+
+	// Start the listener with the first session.
+	if d.conn == nil {
+		lc := net.ListenConfig{Control: bfdSetTTL}
+		pc, err := lc.ListenPacket(context.Background(), "udp", fmt.Sprintf(":%d", bfdPort))
+		if err != nil {
+			return fmt.Errorf("Failed starting BFD listener: %w", err)
+		}
+
+		conn, ok := pc.(*net.UDPConn)
+		if !ok {
+			_ = pc.Close()
+			return fmt.Errorf("Unexpected BFD listener type %T", pc)
+		}
+
+		d.conn = conn
+		go d.listen(d.conn)
+	}
+
+	// Connect from a port in the range required by RFC 5881 section 4.
+	network := "udp4"
+	if address.To4() == nil {
+		network = "udp6"
+	}
+
+	var conn *net.UDPConn
+	dialer := net.Dialer{Control: bfdSetTTL}
+	offset := rand.Intn(bfdSourcePortLast - bfdSourcePortFirst + 1)
+	for i := 0; i <= bfdSourcePortLast-bfdSourcePortFirst; i++ {
+		dialer.LocalAddr = &net.UDPAddr{Port: bfdSourcePortFirst + (offset+i)%(bfdSourcePortLast-bfdSourcePortFirst+1)}
+
+		c, err := dialer.Dial(network, net.JoinHostPort(address.String(), fmt.Sprintf("%d", bfdPort)))
+		if err == nil {
+			conn = c.(*net.UDPConn)
+			break
+		}
+	}
+
+	if conn == nil {
+		return fmt.Errorf("Failed finding a free BFD source port")
+	}
+
+	// Pick a unique local discriminator.
+	var discriminator uint32
+	for discriminator == 0 {
+		discriminator = rand.Uint32()
+		for _, s := range d.sessions {
+			if s.localDiscriminator == discriminator {
+				discriminator = 0
+				break
+			}
+		}
+	}
+
+	session := &bfdSession{
+		address:            address,
+		conn:               conn,
+		onDown:             onDown,
+		localDiscriminator: discriminator,
+		state:              bfdStateDown,
+		remoteState:        bfdStateDown,
+		stop:               make(chan struct{}),
+	}
+
+	d.sessions[address.String()] = session
+	go session.run()
+
+	return nil
+}
+
+// removeSession stops the BFD session with the peer.
+func (d *bfdServer) removeSession(address net.IP) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	session, found := d.sessions[address.String()]
+	if !found {
+		return
+	}
+
+	close(session.stop)
+	_ = session.conn.Close()
+	delete(d.sessions, address.String())
+
+	// Stop the listener with the last session.
+	if len(d.sessions) == 0 && d.conn != nil {
+		_ = d.conn.Close()
+		d.conn = nil
+	}
+}
+
+// sessionState returns the state of the BFD session with the peer.
+func (d *bfdServer) sessionState(address net.IP) string {
+	d.mu.Lock()
+	session, found := d.sessions[address.String()]
+	d.mu.Unlock()
+
+	if !found {
+		return ""
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	return bfdStateNames[session.state]
+}
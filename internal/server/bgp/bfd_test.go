@@ -0,0 +1,52 @@
+package bgp
+
+import (
+	"testing"
+)
+
+func TestBFDPacket(t *testing.T) {
+	p := bfdPacket{
+		diag:              bfdDiagTimeExpired,
+		state:             bfdStateInit,
+		flags:             bfdFlagPoll,
+		detectMult:        3,
+		myDiscriminator:   1,
+		yourDiscriminator: 2,
+		desiredMinTx:      300000,
+		requiredMinRx:     300000,
+	}
+
+	parsed, err := bfdParsePacket(p.marshal())
+	if err != nil {
+		t.Fatalf("Failed parsing packet: %v", err)
+	}
+
+	if *parsed != p {
+		t.Fatalf("Parsed packet %+v doesn't match %+v", *parsed, p)
+	}
+}
+
+func TestBFDPacketInvalid(t *testing.T) {
+	valid := bfdPacket{state: bfdStateDown, detectMult: 3, myDiscriminator: 1}
+
+	tests := []struct {
+		name   string
+		packet func() []byte
+	}{
+		{"Short", func() []byte { return valid.marshal()[:20] }},
+		{"Version", func() []byte { buf := valid.marshal(); buf[0] = 2 << 5; return buf }},
+		{"DetectMult", func() []byte { p := valid; p.detectMult = 0; return p.marshal() }},
+		{"Discriminator", func() []byte { p := valid; p.myDiscriminator = 0; return p.marshal() }},
+		{"PollFinal", func() []byte { p := valid; p.flags = bfdFlagPoll | bfdFlagFinal; return p.marshal() }},
+		{"RemoteDiscriminator", func() []byte { p := valid; p.state = bfdStateUp; return p.marshal() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bfdParsePacket(tt.packet())
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/google/uuid"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/ports"
 	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
@@ -29,6 +32,8 @@ type Server struct {
 	paths    map[string]path
 	peers    map[string]peer
 
+	bfd bfdServer
+
 	mu sync.Mutex
 }
 
@@ -43,6 +48,7 @@ type peer struct {
 	asn      uint32
 	password string
 	holdtime uint64
+	bfd      bool
 	count    int
 }
 
@@ -134,7 +140,7 @@ func (s *Server) start(address string, asn uint32, routerID net.IP) error {
 
 	// Add any existing peers.
 	for _, peer := range s.peers {
-		err := s.addPeer(peer.address, peer.asn, peer.password, peer.holdtime)
+		err := s.addPeer(peer.address, peer.asn, peer.password, peer.holdtime, peer.bfd)
 		if err != nil {
 			return err
 		}
@@ -390,16 +396,16 @@ func (s *Server) removePrefixByUUID(pathUUID string) error {
 	return nil
 }
 
-// AddPeer adds a new BGP peer.
-func (s *Server) AddPeer(address net.IP, asn uint32, password string, holdTime uint64) error {
+// AddPeer adds a new BGP peer, optionally monitored with BFD.
+func (s *Server) AddPeer(address net.IP, asn uint32, password string, holdTime uint64, bfd bool) error {
 	// Locking.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	return s.addPeer(address, asn, password, holdTime)
+	return s.addPeer(address, asn, password, holdTime, bfd)
 }
 
-func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime uint64) error {
+func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime uint64, bfd bool) error {
 	// Look for an existing peer.
 	bgpPeer, bgpPeerExists := s.peers[address.String()]
 	if bgpPeerExists {
@@ -411,6 +417,10 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 			return fmt.Errorf("Peer %q already used but with a different password", address)
 		}
 
+		if bgpPeer.bfd != bfd {
+			return fmt.Errorf("Peer %q already used but with a different BFD setting", address)
+		}
+
 		// Re-use the existing entry.
 		bgpPeer.count++
 		s.peers[address.String()] = bgpPeer
@@ -480,6 +490,26 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 		}
 	}
 
+	// Start monitoring the peer, dropping the BGP session as soon as BFD detects a failure.
+	if bfd {
+		err := s.bfd.addSession(address, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			if s.bgp == nil {
+				return
+			}
+
+			err := s.bgp.ResetPeer(context.Background(), &bgpAPI.ResetPeerRequest{Address: address.String(), Communication: "BFD session down"})
+			if err != nil {
+				logger.Warn("Failed resetting BGP peer after BFD failure", logger.Ctx{"peer": address.String(), "err": err})
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// Add the peer to the list.
 	if bgpPeerExists {
 		bgpPeer.count++
@@ -490,6 +520,7 @@ func (s *Server) addPeer(address net.IP, asn uint32, password string, holdTime u
 			asn:      asn,
 			password: password,
 			holdtime: holdTime,
+			bfd:      bfd,
 			count:    1,
 		}
 	}
@@ -524,6 +555,7 @@ func (s *Server) removePeer(address net.IP) error {
 	// Update peer list.
 	if bgpPeer.count == 1 {
 		// Delete the peer.
+		s.bfd.removeSession(address)
 		delete(s.peers, address.String())
 	} else {
 		// Decrease refcount.
@@ -533,3 +565,72 @@ func (s *Server) removePeer(address net.IP) error {
 
 	return nil
 }
+
+// Status returns the state of the BGP server and its peers.
+func (s *Server) Status() (*api.BGPStatus, error) {
+	// Locking.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := api.BGPStatus{
+		Running: s.bgp != nil && s.address != "",
+		Address: s.address,
+		ASN:     s.asn,
+		Peers:   []api.BGPStatusPeer{},
+	}
+
+	if s.routerID != nil {
+		status.RouterID = s.routerID.String()
+	}
+
+	// Get the session details from the BGP server.
+	sessions := map[string]*bgpAPI.Peer{}
+	if status.Running {
+		err := s.bgp.ListPeer(context.Background(), &bgpAPI.ListPeerRequest{EnableAdvertised: true}, func(p *bgpAPI.Peer) {
+			if p.Conf != nil {
+				sessions[net.ParseIP(p.Conf.NeighborAddress).String()] = p
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, peer := range s.peers {
+		entry := api.BGPStatusPeer{
+			Address:  peer.address.String(),
+			ASN:      peer.asn,
+			State:    "idle",
+			BFDState: s.bfd.sessionState(peer.address),
+		}
+
+		session, found := sessions[peer.address.String()]
+		if found {
+			if session.State != nil {
+				entry.State = strings.ToLower(session.State.SessionState.String())
+			}
+
+			if session.State != nil && session.State.SessionState == bgpAPI.PeerState_ESTABLISHED && session.Timers != nil && session.Timers.State != nil && session.Timers.State.Uptime != nil {
+				entry.EstablishedAt = session.Timers.State.Uptime.AsTime()
+			}
+
+			for _, afiSafi := range session.AfiSafis {
+				if afiSafi.State == nil {
+					continue
+				}
+
+				entry.PrefixesAdvertised += afiSafi.State.Advertised
+				entry.PrefixesReceived += afiSafi.State.Received
+				entry.PrefixesAccepted += afiSafi.State.Accepted
+			}
+		}
+
+		status.Peers = append(status.Peers, entry)
+	}
+
+	sort.Slice(status.Peers, func(i, j int) bool {
+		return status.Peers[i].Address < status.Peers[j].Address
+	})
+
+	return &status, nil
+}
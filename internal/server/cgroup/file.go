@@ -62,6 +62,20 @@ func NewFileReadWriter(pid int, unifiedCapable bool) (*CGroup, error) {
 	return cg, nil
 }
 
+// NewFileReadWriterPath returns a CGroup instance using the filesystem as its backend for the unified cgroup at path.
+func NewFileReadWriterPath(path string) (*CGroup, error) {
+	rw := fileReadWriter{}
+	rw.paths = map[string]string{"unified": path}
+
+	cg, err := New(&rw)
+	if err != nil {
+		return nil, err
+	}
+
+	cg.UnifiedCapable = true
+	return cg, nil
+}
+
 type fileReadWriter struct {
 	paths map[string]string
 }
@@ -44,6 +44,16 @@ func (c *Config) BackupsCompressionAlgorithm() string {
 	return c.m.GetString("backups.compression_algorithm")
 }
 
+// BackupsDatabaseIntervalHours returns the interval in hours between automatic database backups.
+func (c *Config) BackupsDatabaseIntervalHours() int64 {
+	return c.m.GetInt64("backups.database.interval")
+}
+
+// BackupsDatabaseRetention returns the number of automatic database backups to keep.
+func (c *Config) BackupsDatabaseRetention() int64 {
+	return c.m.GetInt64("backups.database.retention")
+}
+
 // MetricsAuthentication checks whether metrics API requires authentication.
 func (c *Config) MetricsAuthentication() bool {
 	return c.m.GetBool("core.metrics_authentication")
@@ -54,6 +64,11 @@ func (c *Config) BGPASN() int64 {
 	return c.m.GetInt64("core.bgp_asn")
 }
 
+// DebugSlowQueryThreshold returns the minimum duration of the database queries recorded in the slow query statistics.
+func (c *Config) DebugSlowQueryThreshold() time.Duration {
+	return time.Duration(c.m.GetInt64("core.debug_slow_query_threshold")) * time.Millisecond
+}
+
 // HTTPSAllowedHeaders returns the relevant CORS setting.
 func (c *Config) HTTPSAllowedHeaders() string {
 	return c.m.GetString("core.https_allowed_headers")
@@ -80,6 +95,18 @@ func (c *Config) TrustCACertificates() bool {
 	return c.m.GetBool("core.trust_ca_certificates")
 }
 
+// CAOCSP returns whether client certificates signed by the CA are checked
+// against their OCSP responder.
+func (c *Config) CAOCSP() bool {
+	return c.m.GetBool("core.ca_ocsp")
+}
+
+// CAOCSPStrict returns whether client certificates signed by the CA are
+// rejected when their OCSP responder can't be queried.
+func (c *Config) CAOCSPStrict() bool {
+	return c.m.GetBool("core.ca_ocsp_strict")
+}
+
 // ProxyHTTPS returns the configured HTTPS proxy, if any.
 func (c *Config) ProxyHTTPS() string {
 	return c.m.GetString("core.proxy_https")
@@ -157,6 +184,11 @@ func (c *Config) ImagesCompressionAlgorithm() string {
 	return c.m.GetString("images.compression_algorithm")
 }
 
+// ImagesAutoRebuildEphemeral returns whether or not to rebuild the ephemeral instances when their image is refreshed.
+func (c *Config) ImagesAutoRebuildEphemeral() bool {
+	return c.m.GetBool("images.auto_rebuild_ephemeral")
+}
+
 // ImagesAutoUpdateCached returns whether or not to auto update cached images.
 func (c *Config) ImagesAutoUpdateCached() bool {
 	return c.m.GetBool("images.auto_update_cached")
@@ -172,6 +204,23 @@ func (c *Config) ImagesRemoteCacheExpiryDays() int64 {
 	return c.m.GetInt64("images.remote_cache_expiry")
 }
 
+// InstancesCrashDumpsRetention returns the number of crash dumps to keep per instance.
+func (c *Config) InstancesCrashDumpsRetention() int64 {
+	return c.m.GetInt64("instances.crash_dumps.retention")
+}
+
+// InstancesHostShutdownTimeout returns the overall time to wait for instances to shut down cleanly when the host shuts down.
+func (c *Config) InstancesHostShutdownTimeout() time.Duration {
+	n := c.m.GetInt64("instances.host_shutdown_timeout")
+	return time.Duration(n) * time.Second
+}
+
+// InstancesMemoryPressureThresholds returns the host memory pressure above which instances get frozen and below
+// which they get unfrozen again.
+func (c *Config) InstancesMemoryPressureThresholds() (int64, int64) {
+	return c.m.GetInt64("instances.memory_pressure.freeze_threshold"), c.m.GetInt64("instances.memory_pressure.unfreeze_threshold")
+}
+
 // InstancesNICHostname returns hostname mode to use for instance NICs.
 func (c *Config) InstancesNICHostname() string {
 	return c.m.GetString("instances.nic.host_name")
@@ -182,6 +231,38 @@ func (c *Config) InstancesPlacementScriptlet() string {
 	return c.m.GetString("instances.placement.scriptlet")
 }
 
+// InstancesSessionLimits returns the maximum number of concurrent exec and console sessions per identity and per instance.
+func (c *Config) InstancesSessionLimits() (int64, int64) {
+	return c.m.GetInt64("instances.sessions.max_per_identity"), c.m.GetInt64("instances.sessions.max_per_instance")
+}
+
+// InstancesSessionIdleTimeout returns the time after which an inactive exec or console session is closed.
+func (c *Config) InstancesSessionIdleTimeout() time.Duration {
+	n := c.m.GetInt64("instances.sessions.idle_timeout")
+	return time.Duration(n) * time.Second
+}
+
+// NetworkOVNRangesWarningThreshold returns the percentage of the OVN ranges of an uplink network above which a warning is raised.
+func (c *Config) NetworkOVNRangesWarningThreshold() int64 {
+	return c.m.GetInt64("network.ovn.ranges_warning_threshold")
+}
+
+// StorageQuotaWarningThreshold returns the percentage of a volume quota above which a warning is raised.
+func (c *Config) StorageQuotaWarningThreshold() int64 {
+	return c.m.GetInt64("storage.quota_warning_threshold")
+}
+
+// WarningsAutoResolveAfter returns the time after which a warning that didn't occur again is resolved.
+// It returns 0 if such warnings shouldn't be resolved automatically.
+func (c *Config) WarningsAutoResolveAfter() time.Duration {
+	return time.Duration(c.m.GetInt64("warnings.auto_resolve.after")) * 24 * time.Hour
+}
+
+// WarningsAutoResolveDeletedEntities returns whether to resolve the warnings referencing deleted entities.
+func (c *Config) WarningsAutoResolveDeletedEntities() bool {
+	return c.m.GetBool("warnings.auto_resolve.deleted_entities")
+}
+
 // LokiServer returns all the Loki settings needed to connect to a server.
 func (c *Config) LokiServer() (string, string, string, string, string, string, []string, []string) {
 	var types []string
@@ -243,6 +324,12 @@ func (c *Config) OpenFGA() (apiURL string, apiToken string, storeID string) {
 	return c.m.GetString("openfga.api.url"), c.m.GetString("openfga.api.token"), c.m.GetString("openfga.store.id")
 }
 
+// OperationsLimit returns the maximum number of concurrent operations of the group (backups, image_downloads or
+// migrations) on each server, 0 meaning no limit.
+func (c *Config) OperationsLimit(group string) int64 {
+	return c.m.GetInt64("operations.max_" + group)
+}
+
 // Dump current configuration keys and their values. Keys with values matching
 // their defaults are omitted.
 func (c *Config) Dump() map[string]string {
@@ -327,6 +414,25 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Compression algorithm to use for backups
 	"backups.compression_algorithm": {Default: "gzip", Validator: validate.IsCompressionAlgorithm},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=backups.database.interval)
+	// Specify the interval in hours at which each server dumps the cluster database into its backups directory.
+	// To disable the automatic database backups, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `24`
+	//  shortdesc: Interval at which to back up the cluster database
+	"backups.database.interval": {Type: config.Int64, Default: "24", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=backups.database.retention)
+	// Specify the number of automatic database backups to keep on each server.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `7`
+	//  shortdesc: Number of database backups to keep
+	"backups.database.retention": {Type: config.Int64, Default: "7", Validator: validate.IsInRange(1, 1000)},
+
 	// gendoc:generate(entity=server, group=cluster, key=cluster.offline_threshold)
 	// Specify the number of seconds after which an unresponsive member is considered offline.
 	// ---
@@ -402,6 +508,17 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: BGP Autonomous System Number for the local server
 	"core.bgp_asn": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 4294967294))},
 
+	// gendoc:generate(entity=server, group=core, key=core.debug_slow_query_threshold)
+	// Specify the duration in milliseconds above which a query to the cluster database is recorded in the slow query statistics.
+	// The statistics are kept in memory by each server and can be shown with `incus admin sql global .stats`.
+	// To disable the statistics, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Threshold for recording slow database queries
+	"core.debug_slow_query_threshold": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
 	// gendoc:generate(entity=server, group=core, key=core.https_allowed_headers)
 	//
 	// ---
@@ -496,6 +613,36 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Whether to automatically trust clients signed by the CA
 	"core.trust_ca_certificates": {Type: config.Bool, Default: "false"},
 
+	// gendoc:generate(entity=server, group=core, key=core.ca_ocsp)
+	// When enabled, client certificates signed by the CA are checked against the OCSP responder listed in them.
+	// The responses are cached until their next update.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to check client certificates signed by the CA using OCSP
+	"core.ca_ocsp": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=core, key=core.ca_ocsp_strict)
+	// When enabled, client certificates signed by the CA are rejected when their OCSP responder can't be queried.
+	// Otherwise, they're trusted unless revoked through the CA revocation list.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to reject client certificates when their OCSP responder can't be queried
+	"core.ca_ocsp_strict": {Type: config.Bool, Default: "false"},
+
+	// gendoc:generate(entity=server, group=images, key=images.auto_rebuild_ephemeral)
+	// When an image is refreshed, the ephemeral instances created from its previous version are rebuilt from the new one.
+	// Running instances are stopped, rebuilt and started again.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to automatically rebuild ephemeral instances when their image is refreshed
+	"images.auto_rebuild_ephemeral": {Type: config.Bool, Default: "false"},
+
 	// gendoc:generate(entity=server, group=images, key=images.auto_update_cached)
 	//
 	// ---
@@ -540,6 +687,53 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: When an unused cached remote image is flushed
 	"images.remote_cache_expiry": {Type: config.Int64, Default: "10"},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.crash_dumps.retention)
+	// When the QEMU process of a virtual machine crashes, its core dump and logs are captured into the instance's
+	// log directory. This option sets the number of crash dumps that are kept per instance, the oldest ones being removed.
+	// To disable the capture, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `3`
+	//  shortdesc: Number of crash dumps to keep per instance
+	"instances.crash_dumps.retention": {Type: config.Int64, Default: "3", Validator: validate.Optional(validate.IsUint32)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.host_shutdown_timeout)
+	// Specify the overall number of seconds to wait for the instances to shut down cleanly when the host shuts down.
+	// Instances are shut down according to their `boot.stop.priority` and each of them is given at most its `boot.host_shutdown_timeout`.
+	// Once this budget is exhausted, the remaining instances are forcefully stopped.
+	// To not limit the overall shutdown time, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Overall time budget to shut down instances
+	"instances.host_shutdown_timeout": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.memory_pressure.freeze_threshold)
+	// Specify the host memory pressure, as the percentage of time some tasks were stalled waiting for memory over the
+	// last 10 seconds (`some avg10` in `/proc/pressure/memory`), above which running instances get frozen.
+	// Only instances with {config:option}`instance-resource-limits:limits.memory.freeze_priority` set are frozen,
+	// one at a time and lowest priority first, until the pressure goes down.
+	// To disable the policy, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Memory pressure above which instances get frozen
+	"instances.memory_pressure.freeze_threshold": {Type: config.Int64, Default: "0", Validator: validate.Optional(validate.IsInRange(0, 100))},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.memory_pressure.unfreeze_threshold)
+	// Specify the host memory pressure below which the instances frozen because of memory pressure get unfrozen again,
+	// one at a time and highest priority first.
+	// It should be lower than {config:option}`server-miscellaneous:instances.memory_pressure.freeze_threshold`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `5`
+	//  shortdesc: Memory pressure below which instances get unfrozen
+	"instances.memory_pressure.unfreeze_threshold": {Type: config.Int64, Default: "5", Validator: validate.Optional(validate.IsInRange(0, 100))},
+
 	// gendoc:generate(entity=server, group=miscellaneous, key=instances.nic.host_name)
 	// Possible values are `random` and `mac`.
 	//
@@ -561,6 +755,41 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Instance placement scriptlet for automatic instance placement
 	"instances.placement.scriptlet": {Validator: validate.Optional(scriptletLoad.InstancePlacementValidate)},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.sessions.idle_timeout)
+	// Specify the number of seconds after which an interactive exec or console session that didn't see any input or
+	// output is closed. For exec sessions, the command is killed.
+	// To not close inactive sessions, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Time after which inactive exec and console sessions are closed
+	"instances.sessions.idle_timeout": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.sessions.max_per_identity)
+	// Specify the maximum number of concurrent exec and console sessions a single identity can have on each server.
+	// Sessions are counted by each cluster member separately, so an identity can have this many sessions on every member.
+	// Further sessions are rejected until existing ones end.
+	// To not limit the number of sessions, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent exec and console sessions per identity
+	"instances.sessions.max_per_identity": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=instances.sessions.max_per_instance)
+	// Specify the maximum number of concurrent exec and console sessions a single instance can have.
+	// Sessions are counted by the cluster member running the instance.
+	// Further sessions are rejected until existing ones end.
+	// To not limit the number of sessions, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent exec and console sessions per instance
+	"instances.sessions.max_per_instance": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
 	// gendoc:generate(entity=server, group=loki, key=loki.auth.username)
 	//
 	// ---
@@ -685,6 +914,39 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: OpenID Connect claim to use as the username
 	"oidc.claim": {},
 
+	// gendoc:generate(entity=server, group=miscellaneous, key=operations.max_backups)
+	// Specify the maximum number of instance, volume and bucket backups that can be created concurrently on each server.
+	// Further backups are queued until running ones complete.
+	// To not limit the number of backups, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent backups per server
+	"operations.max_backups": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=operations.max_image_downloads)
+	// Specify the maximum number of images that can be downloaded concurrently on each server.
+	// Further downloads are queued until running ones complete.
+	// To not limit the number of downloads, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent image downloads per server
+	"operations.max_image_downloads": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=operations.max_migrations)
+	// Specify the maximum number of instances and volumes that can be migrated away concurrently from each server.
+	// Further migrations are queued until running ones complete.
+	// To not limit the number of migrations, set this option to `0`.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: Maximum number of concurrent outgoing migrations per server
+	"operations.max_migrations": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
 	// OVN networking global keys.
 
 	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovn.integration_bridge)
@@ -731,6 +993,47 @@ var ConfigSchema = config.Schema{
 	//  defaultdesc: Content of `/etc/ovn/key_host` if present
 	//  shortdesc: OVN SSL client key
 	"network.ovn.client_key": {Default: ""},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=network.ovn.ranges_warning_threshold)
+	// When the OVN networks using an uplink network were allocated more than this percentage of the addresses
+	// of its `ipv4.ovn.ranges` or `ipv6.ovn.ranges`, a warning is raised.
+	// Set this option to `0` to disable the check.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `90`
+	//  shortdesc: Percentage of the OVN ranges of an uplink above which to warn
+	"network.ovn.ranges_warning_threshold": {Type: config.Int64, Default: "90", Validator: validate.IsInRange(0, 100)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=storage.quota_warning_threshold)
+	// When an instance root disk or a custom storage volume with a quota uses more than this percentage of it,
+	// a warning is raised and a `storage-volume-quota-warning` lifecycle event is emitted.
+	// Set this option to `0` to disable the check.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `90`
+	//  shortdesc: Percentage of a volume quota above which to warn
+	"storage.quota_warning_threshold": {Type: config.Int64, Default: "90", Validator: validate.IsInRange(0, 100)},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=warnings.auto_resolve.after)
+	// Specify the number of days after which a warning that didn't occur again is automatically resolved.
+	// Set this option to `0` to keep such warnings until they are resolved by Incus or deleted.
+	// ---
+	//  type: integer
+	//  scope: global
+	//  defaultdesc: `0`
+	//  shortdesc: When to resolve warnings that stopped occurring
+	"warnings.auto_resolve.after": {Type: config.Int64, Default: "0", Validator: validate.IsUint32},
+
+	// gendoc:generate(entity=server, group=miscellaneous, key=warnings.auto_resolve.deleted_entities)
+	// If enabled, warnings referencing an entity (instance, network, storage volume, ...) that no longer exists are automatically resolved.
+	// ---
+	//  type: bool
+	//  scope: global
+	//  defaultdesc: `true`
+	//  shortdesc: Whether to resolve warnings about deleted entities
+	"warnings.auto_resolve.deleted_entities": {Type: config.Bool, Default: "true"},
 }
 
 func expiryValidator(value string) error {
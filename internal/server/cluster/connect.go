@@ -149,19 +149,27 @@ func ConnectIfVolumeIsRemote(s *state.State, poolName string, projectName string
 			return nil, err
 		}
 
-		remoteInstance, err := storagePools.VolumeUsedByExclusiveRemoteInstancesWithProfiles(s, poolName, projectName, &dbVolume.StorageVolume)
-		if err != nil {
-			return nil, fmt.Errorf("Failed checking if volume %q is available: %w", volumeName, err)
+		// Volumes shared through NFS are handled by the cluster member exporting them.
+		memberName := storagePools.VolumeSharingNFSMember(dbVolume.Config)
+		if memberName == "" {
+			remoteInstance, err := storagePools.VolumeUsedByExclusiveRemoteInstancesWithProfiles(s, poolName, projectName, &dbVolume.StorageVolume)
+			if err != nil {
+				return nil, fmt.Errorf("Failed checking if volume %q is available: %w", volumeName, err)
+			}
+
+			if remoteInstance != nil {
+				memberName = remoteInstance.Node
+			}
 		}
 
-		if remoteInstance != nil {
+		if memberName != "" {
 			var instNode db.NodeInfo
 			err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
-				instNode, err = tx.GetNodeByName(ctx, remoteInstance.Node)
+				instNode, err = tx.GetNodeByName(ctx, memberName)
 				return err
 			})
 			if err != nil {
-				return nil, fmt.Errorf("Failed getting cluster member info for %q: %w", remoteInstance.Node, err)
+				return nil, fmt.Errorf("Failed getting cluster member info for %q: %w", memberName, err)
 			}
 
 			// Replace node list with instance's cluster member node (which might be local member).
@@ -45,6 +45,7 @@ type Certificate struct {
 	Certificate string
 	Restricted  bool
 	Description string
+	ExpiryDate  sql.NullTime
 }
 
 // CertificateFilter specifies potential query parameter fields.
@@ -80,6 +81,10 @@ func (cert *Certificate) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Certificat
 	resp.Type = cert.ToAPIType()
 	resp.Description = cert.Description
 
+	if cert.ExpiryDate.Valid {
+		resp.ExpiresAt = cert.ExpiryDate.Time
+	}
+
 	projects, err := GetCertificateProjects(ctx, tx, cert.ID)
 	if err != nil {
 		return nil, err
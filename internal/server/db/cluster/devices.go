@@ -53,6 +53,7 @@ const (
 	TypeUnixHotplug = DeviceType(9)
 	TypeTPM         = DeviceType(10)
 	TypePCI         = DeviceType(11)
+	TypeWatchdog    = DeviceType(12)
 )
 
 func (t DeviceType) String() string {
@@ -81,6 +82,8 @@ func (t DeviceType) String() string {
 		return "tpm"
 	case TypePCI:
 		return "pci"
+	case TypeWatchdog:
+		return "watchdog"
 	}
 
 	return ""
@@ -113,6 +116,8 @@ func NewDeviceType(t string) (DeviceType, error) {
 		return TypeTPM, nil
 	case "pci":
 		return TypePCI, nil
+	case "watchdog":
+		return TypeWatchdog, nil
 	default:
 		return -1, fmt.Errorf("Invalid device type %s", t)
 	}
@@ -5,7 +5,11 @@ package cluster
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/lxc/incus/v6/internal/server/db/query"
 	"github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -52,7 +56,36 @@ type ProfileFilter struct {
 }
 
 // ToAPI returns a cluster Profile as an API struct.
+// The expanded configuration and devices are filled in when the profile has parent profiles.
 func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Profile, error) {
+	profile, err := p.toAPIRaw(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(profile.Parents) == 0 {
+		return profile, nil
+	}
+
+	getProfile := func(name string) (*api.Profile, error) {
+		parent, err := GetProfile(ctx, tx, p.Project, name)
+		if err != nil {
+			return nil, err
+		}
+
+		return parent.toAPIRaw(ctx, tx)
+	}
+
+	profile.ExpandedConfig, profile.ExpandedDevices, err = ExpandProfile(*profile, getProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// toAPIRaw returns a cluster Profile as an API struct, without resolving its parent profiles.
+func (p *Profile) toAPIRaw(ctx context.Context, tx *sql.Tx) (*api.Profile, error) {
 	config, err := GetProfileConfig(ctx, tx, p.ID)
 	if err != nil {
 		return nil, err
@@ -63,12 +96,18 @@ func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Profile, error) {
 		return nil, err
 	}
 
+	parents, err := GetProfileParents(ctx, tx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	profile := &api.Profile{
 		Name: p.Name,
 		ProfilePut: api.ProfilePut{
 			Description: p.Description,
 			Config:      config,
 			Devices:     DevicesToAPI(devices),
+			Parents:     parents,
 		},
 		Project: p.Project,
 	}
@@ -76,6 +115,121 @@ func (p *Profile) ToAPI(ctx context.Context, tx *sql.Tx) (*api.Profile, error) {
 	return profile, nil
 }
 
+// ExpandProfile returns the config and devices of the profile merged on top of the ones of its parent profiles.
+// Parents are applied in order, so later parents override earlier ones and the profile itself overrides all of them.
+// Devices are overridden as a whole. The getProfile function is used to retrieve the parent profiles.
+func ExpandProfile(profile api.Profile, getProfile func(name string) (*api.Profile, error)) (map[string]string, map[string]map[string]string, error) {
+	var expand func(profile api.Profile, seen []string) (map[string]string, map[string]map[string]string, error)
+
+	expand = func(profile api.Profile, seen []string) (map[string]string, map[string]map[string]string, error) {
+		if slices.Contains(seen, profile.Name) {
+			return nil, nil, fmt.Errorf("Profile %q is part of an inheritance loop", profile.Name)
+		}
+
+		seen = append(seen, profile.Name)
+
+		expandedConfig := map[string]string{}
+		expandedDevices := map[string]map[string]string{}
+
+		for _, parentName := range profile.Parents {
+			parent, err := getProfile(parentName)
+			if err != nil {
+				return nil, nil, fmt.Errorf("Failed loading parent profile %q: %w", parentName, err)
+			}
+
+			parentConfig, parentDevices, err := expand(*parent, seen)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			maps.Copy(expandedConfig, parentConfig)
+			maps.Copy(expandedDevices, parentDevices)
+		}
+
+		maps.Copy(expandedConfig, profile.Config)
+		maps.Copy(expandedDevices, profile.Devices)
+
+		return expandedConfig, expandedDevices, nil
+	}
+
+	return expand(profile, nil)
+}
+
+// GetProfileParents returns the names of the parent profiles of the profile with the given ID, in application order.
+func GetProfileParents(ctx context.Context, tx *sql.Tx, profileID int) ([]string, error) {
+	q := `SELECT profiles.name FROM profiles_parents
+		JOIN profiles ON profiles.id = profiles_parents.parent_id
+		WHERE profiles_parents.profile_id = ?
+		ORDER BY profiles_parents.apply_order`
+
+	return query.SelectStrings(ctx, tx, q, profileID)
+}
+
+// GetProfileChildren returns the names of the profiles which directly inherit from the profile with the given ID.
+func GetProfileChildren(ctx context.Context, tx *sql.Tx, profileID int) ([]string, error) {
+	q := `SELECT profiles.name FROM profiles_parents
+		JOIN profiles ON profiles.id = profiles_parents.profile_id
+		WHERE profiles_parents.parent_id = ?
+		ORDER BY profiles.name`
+
+	return query.SelectStrings(ctx, tx, q, profileID)
+}
+
+// GetProfileDescendants returns the names of all the profiles which inherit, directly or not,
+// from the given profile.
+func GetProfileDescendants(ctx context.Context, tx *sql.Tx, projectName string, name string) ([]string, error) {
+	descendants := []string{}
+	pending := []string{name}
+
+	for len(pending) > 0 {
+		id, err := GetProfileID(ctx, tx, projectName, pending[0])
+		if err != nil {
+			return nil, err
+		}
+
+		pending = pending[1:]
+
+		children, err := GetProfileChildren(ctx, tx, int(id))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, child := range children {
+			if child == name || slices.Contains(descendants, child) {
+				continue
+			}
+
+			descendants = append(descendants, child)
+			pending = append(pending, child)
+		}
+	}
+
+	return descendants, nil
+}
+
+// UpdateProfileParents replaces the parent profiles of the profile with the given ID.
+// Parents are looked up in the same project as the profile.
+func UpdateProfileParents(ctx context.Context, tx *sql.Tx, projectName string, profileID int, parents []string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM profiles_parents WHERE profile_id = ?", profileID)
+	if err != nil {
+		return fmt.Errorf("Failed removing profile parents: %w", err)
+	}
+
+	for i, parent := range parents {
+		parentID, err := GetProfileID(ctx, tx, projectName, parent)
+		if err != nil {
+			return fmt.Errorf("Failed loading parent profile %q: %w", parent, err)
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO profiles_parents (profile_id, parent_id, apply_order) VALUES (?, ?, ?)", profileID, parentID, i)
+		if err != nil {
+			return fmt.Errorf("Failed adding parent profile %q: %w", parent, err)
+		}
+	}
+
+	return nil
+}
+
 // GetProfilesIfEnabled returns the profiles from the given project, or the
 // default project if "features.profiles" is not set.
 func GetProfilesIfEnabled(ctx context.Context, tx *sql.Tx, projectName string, names []string) ([]Profile, error) {
@@ -110,6 +264,9 @@ func ExpandInstanceConfig(config map[string]string, profiles []api.Profile) map[
 	profileConfigs := make([]map[string]string, len(profiles))
 	for i, profile := range profiles {
 		profileConfigs[i] = profile.Config
+		if profile.ExpandedConfig != nil {
+			profileConfigs[i] = profile.ExpandedConfig
+		}
 	}
 
 	for i := range profileConfigs {
@@ -135,6 +292,9 @@ func ExpandInstanceDevices(devices config.Devices, profiles []api.Profile) confi
 	profileDevices := make([]config.Devices, len(profiles))
 	for i, profile := range profiles {
 		profileDevices[i] = config.NewDevices(profile.Devices)
+		if profile.ExpandedDevices != nil {
+			profileDevices[i] = config.NewDevices(profile.ExpandedDevices)
+		}
 	}
 
 	for i := range profileDevices {
@@ -0,0 +1,53 @@
+//go:build linux && cgo && !agent
+
+package cluster_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+func TestExpandProfile(t *testing.T) {
+	profiles := map[string]api.Profile{
+		"base": {Name: "base", ProfilePut: api.ProfilePut{
+			Config:  map[string]string{"limits.cpu": "1", "security.nesting": "true"},
+			Devices: map[string]map[string]string{"root": {"type": "disk", "pool": "default", "path": "/"}},
+		}},
+		"big": {Name: "big", ProfilePut: api.ProfilePut{
+			Config: map[string]string{"limits.cpu": "8"},
+		}},
+		"web": {Name: "web", ProfilePut: api.ProfilePut{
+			Config:  map[string]string{"limits.memory": "4GiB"},
+			Devices: map[string]map[string]string{"root": {"type": "disk", "pool": "fast", "path": "/"}},
+			Parents: []string{"base", "big"},
+		}},
+	}
+
+	getProfile := func(name string) (*api.Profile, error) {
+		profile, ok := profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("Profile %q not found", name)
+		}
+
+		return &profile, nil
+	}
+
+	config, devices, err := cluster.ExpandProfile(profiles["web"], getProfile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"limits.cpu": "8", "limits.memory": "4GiB", "security.nesting": "true"}, config)
+	assert.Equal(t, map[string]map[string]string{"root": {"type": "disk", "pool": "fast", "path": "/"}}, devices)
+
+	// Inheritance loops are rejected.
+	base := profiles["base"]
+	base.Parents = []string{"web"}
+	profiles["base"] = base
+
+	_, _, err = cluster.ExpandProfile(profiles["web"], getProfile)
+	assert.Error(t, err)
+}
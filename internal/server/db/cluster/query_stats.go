@@ -0,0 +1,271 @@
+package cluster
+
+import (
+	"cmp"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cowsqlDriver "github.com/cowsql/go-cowsql/driver"
+
+	internalSQL "github.com/lxc/incus/v6/internal/sql"
+)
+
+// queryStatsMaxPatterns bounds the number of distinct query patterns kept in the statistics.
+const queryStatsMaxPatterns = 1000
+
+// queryStatsThreshold is the minimum duration of the queries recorded in the statistics.
+// A zero value disables the statistics.
+var queryStatsThreshold atomic.Int64
+
+var queryStatsMu sync.Mutex
+var queryStats = map[string]*internalSQL.SQLQueryStats{}
+
+var queryStatsLiteral = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+var queryStatsList = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+var queryStatsSpace = regexp.MustCompile(`\s+`)
+
+// SetSlowQueryThreshold sets the minimum duration of the queries recorded in the slow query statistics, a zero
+// threshold disables them. The existing statistics are cleared.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	queryStatsMu.Lock()
+	defer queryStatsMu.Unlock()
+
+	queryStatsThreshold.Store(int64(threshold))
+	queryStats = map[string]*internalSQL.SQLQueryStats{}
+}
+
+// GetSlowQueryStats returns the slow query statistics of the cluster database, slowest first.
+func GetSlowQueryStats() []internalSQL.SQLQueryStats {
+	queryStatsMu.Lock()
+	defer queryStatsMu.Unlock()
+
+	stats := make([]internalSQL.SQLQueryStats, 0, len(queryStats))
+	for _, s := range queryStats {
+		stats = append(stats, *s)
+	}
+
+	slices.SortFunc(stats, func(a internalSQL.SQLQueryStats, b internalSQL.SQLQueryStats) int {
+		return cmp.Compare(b.TotalTime, a.TotalTime)
+	})
+
+	return stats
+}
+
+// queryPattern returns the query with its literal values replaced by placeholders, so that similar queries
+// are grouped together.
+func queryPattern(query string) string {
+	pattern := queryStatsLiteral.ReplaceAllString(query, "?")
+	pattern = queryStatsList.ReplaceAllString(pattern, "?")
+	pattern = queryStatsSpace.ReplaceAllString(pattern, " ")
+
+	return strings.TrimSpace(pattern)
+}
+
+// queryRecord adds the query to the statistics if it took longer than the threshold.
+func queryRecord(query string, start time.Time) {
+	threshold := time.Duration(queryStatsThreshold.Load())
+	if threshold <= 0 {
+		return
+	}
+
+	duration := time.Since(start)
+	if duration < threshold {
+		return
+	}
+
+	pattern := queryPattern(query)
+
+	queryStatsMu.Lock()
+	defer queryStatsMu.Unlock()
+
+	stats, ok := queryStats[pattern]
+	if !ok {
+		if len(queryStats) >= queryStatsMaxPatterns {
+			return
+		}
+
+		stats = &internalSQL.SQLQueryStats{Pattern: pattern}
+		queryStats[pattern] = stats
+	}
+
+	stats.Count++
+	stats.TotalTime += duration
+	stats.MaxTime = max(stats.MaxTime, duration)
+}
+
+// statsDriver wraps the cowsql driver to record the duration of the queries.
+type statsDriver struct {
+	driver *cowsqlDriver.Driver
+}
+
+// Open returns a new connection to the database.
+func (d *statsDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatsConn(conn)
+}
+
+// OpenConnector returns a connector for the database.
+func (d *statsDriver) OpenConnector(name string) (driver.Connector, error) {
+	connector, err := d.driver.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statsConnector{connector: connector, driver: d}, nil
+}
+
+// statsConnector wraps the cowsql connector to return connections recording the duration of the queries.
+type statsConnector struct {
+	connector driver.Connector
+	driver    *statsDriver
+}
+
+// Connect returns a new connection to the database.
+func (c *statsConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatsConn(conn)
+}
+
+// Driver returns the underlying driver of the connector.
+func (c *statsConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// statsConn wraps a cowsql connection to record the duration of the queries.
+type statsConn struct {
+	conn *cowsqlDriver.Conn
+}
+
+// newStatsConn wraps the cowsql connection, closing it if it isn't one.
+func newStatsConn(conn driver.Conn) (driver.Conn, error) {
+	cowsqlConn, ok := conn.(*cowsqlDriver.Conn)
+	if !ok {
+		_ = conn.Close()
+		return nil, fmt.Errorf("Unexpected database connection type %T", conn)
+	}
+
+	return &statsConn{conn: cowsqlConn}, nil
+}
+
+// PrepareContext returns a prepared statement, bound to this connection.
+func (c *statsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	cowsqlStmt, ok := stmt.(*cowsqlDriver.Stmt)
+	if !ok {
+		_ = stmt.Close()
+		return nil, fmt.Errorf("Unexpected database statement type %T", stmt)
+	}
+
+	return &statsStmt{stmt: cowsqlStmt, query: query}, nil
+}
+
+// Prepare returns a prepared statement, bound to this connection.
+func (c *statsConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// ExecContext is an optional interface that may be implemented by a Conn.
+func (c *statsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	defer queryRecord(query, time.Now())
+
+	return c.conn.ExecContext(ctx, query, args)
+}
+
+// Exec is an optional interface that may be implemented by a Conn.
+func (c *statsConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+	defer queryRecord(query, time.Now())
+
+	return c.conn.Exec(query, args)
+}
+
+// QueryContext is an optional interface that may be implemented by a Conn.
+func (c *statsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	defer queryRecord(query, time.Now())
+
+	return c.conn.QueryContext(ctx, query, args)
+}
+
+// Query is an optional interface that may be implemented by a Conn.
+func (c *statsConn) Query(query string, args []driver.Value) (driver.Rows, error) {
+	defer queryRecord(query, time.Now())
+
+	return c.conn.Query(query, args)
+}
+
+// BeginTx starts and returns a new transaction.
+func (c *statsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+// Begin starts and returns a new transaction.
+func (c *statsConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+// Close invalidates and potentially stops any current prepared statements and transactions.
+func (c *statsConn) Close() error {
+	return c.conn.Close()
+}
+
+// statsStmt wraps a cowsql prepared statement to record the duration of its executions.
+type statsStmt struct {
+	stmt  *cowsqlDriver.Stmt
+	query string
+}
+
+// Close closes the statement.
+func (s *statsStmt) Close() error {
+	return s.stmt.Close()
+}
+
+// NumInput returns the number of placeholder parameters.
+func (s *statsStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// ExecContext executes a query that doesn't return rows.
+func (s *statsStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	defer queryRecord(s.query, time.Now())
+
+	return s.stmt.ExecContext(ctx, args)
+}
+
+// Exec executes a query that doesn't return rows.
+func (s *statsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	defer queryRecord(s.query, time.Now())
+
+	return s.stmt.Exec(args)
+}
+
+// QueryContext executes a query that may return rows.
+func (s *statsStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	defer queryRecord(s.query, time.Now())
+
+	return s.stmt.QueryContext(ctx, args)
+}
+
+// Query executes a query that may return rows.
+func (s *statsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	defer queryRecord(s.query, time.Now())
+
+	return s.stmt.Query(args)
+}
@@ -14,6 +14,7 @@ CREATE TABLE certificates (
     certificate TEXT NOT NULL,
     restricted INTEGER NOT NULL DEFAULT 0,
     description TEXT NOT NULL DEFAULT "",
+    expiry_date DATETIME,
     UNIQUE (fingerprint)
 );
 CREATE TABLE "certificates_projects" (
@@ -149,6 +150,46 @@ CREATE TABLE "instances_devices_config" (
     FOREIGN KEY (instance_device_id) REFERENCES "instances_devices" (id) ON DELETE CASCADE,
     UNIQUE (instance_device_id, key)
 );
+CREATE TABLE "instances_groups" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (project_id, name),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_groups_instances" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_group_id INTEGER NOT NULL,
+    instance_id INTEGER NOT NULL,
+    UNIQUE (instance_group_id, instance_id),
+    FOREIGN KEY (instance_group_id) REFERENCES "instances_groups" (id) ON DELETE CASCADE,
+    FOREIGN KEY (instance_id) REFERENCES "instances" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_network_usage" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    instance_id INTEGER NOT NULL,
+    instance_name TEXT NOT NULL,
+    period TEXT NOT NULL,
+    bytes_received INTEGER NOT NULL DEFAULT 0,
+    bytes_sent INTEGER NOT NULL DEFAULT 0,
+    packets_received INTEGER NOT NULL DEFAULT 0,
+    packets_sent INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (instance_id, period),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_network_usage_counters" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_id INTEGER NOT NULL,
+    interface TEXT NOT NULL,
+    bytes_received INTEGER NOT NULL DEFAULT 0,
+    bytes_sent INTEGER NOT NULL DEFAULT 0,
+    packets_received INTEGER NOT NULL DEFAULT 0,
+    packets_sent INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (instance_id, interface),
+    FOREIGN KEY (instance_id) REFERENCES "instances" (id) ON DELETE CASCADE
+);
 CREATE INDEX instances_node_id_idx ON instances (node_id);
 CREATE TABLE "instances_profiles" (
     id INTEGER primary key AUTOINCREMENT NOT NULL,
@@ -202,6 +243,25 @@ CREATE TABLE "instances_snapshots_devices_config" (
     FOREIGN KEY (instance_snapshot_device_id) REFERENCES "instances_snapshots_devices" (id) ON DELETE CASCADE,
     UNIQUE (instance_snapshot_device_id, key)
 );
+CREATE TABLE "instances_templates" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    type TEXT NOT NULL,
+    devices TEXT NOT NULL,
+    profiles TEXT NOT NULL,
+    UNIQUE (project_id, name),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_templates_config" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_template_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    UNIQUE (instance_template_id, key),
+    FOREIGN KEY (instance_template_id) REFERENCES "instances_templates" (id) ON DELETE CASCADE
+);
 CREATE TABLE "networks" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
     project_id INTEGER NOT NULL,
@@ -240,6 +300,17 @@ CREATE TABLE "networks_config" (
     FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE,
     FOREIGN KEY (node_id) REFERENCES "nodes" (id) ON DELETE CASCADE
 );
+CREATE TABLE "networks_dhcp_reservations" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    network_id INTEGER NOT NULL,
+    hwaddr TEXT NOT NULL,
+    description TEXT NOT NULL,
+    ipv4_address TEXT NOT NULL,
+    ipv6_address TEXT NOT NULL,
+    hostname TEXT NOT NULL,
+    UNIQUE (network_id, hwaddr),
+    FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE
+);
 CREATE TABLE "networks_forwards" (
 	id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
 	network_id INTEGER NOT NULL,
@@ -444,6 +515,15 @@ CREATE TABLE "profiles_devices_config" (
     UNIQUE (profile_device_id, key),
     FOREIGN KEY (profile_device_id) REFERENCES "profiles_devices" (id) ON DELETE CASCADE
 );
+CREATE TABLE "profiles_parents" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    profile_id INTEGER NOT NULL,
+    parent_id INTEGER NOT NULL,
+    apply_order INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (profile_id, parent_id),
+    FOREIGN KEY (profile_id) REFERENCES "profiles" (id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES "profiles" (id) ON DELETE CASCADE
+);
 CREATE INDEX profiles_project_id_idx ON profiles (project_id);
 CREATE TABLE "projects" (
     id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
@@ -643,11 +723,13 @@ CREATE TABLE "warnings" (
 	updated_date DATETIME,
 	last_message TEXT NOT NULL,
 	count INTEGER NOT NULL,
+    severity INTEGER NOT NULL DEFAULT 0,
+    acknowledged_by TEXT NOT NULL DEFAULT "",
 	UNIQUE (uuid),
 	FOREIGN KEY (node_id) REFERENCES "nodes"(id) ON DELETE CASCADE,
 	FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
 );
 CREATE UNIQUE INDEX warnings_unique_node_id_project_id_entity_type_code_entity_id_type_code ON warnings(IFNULL(node_id, -1), IFNULL(project_id, -1), entity_type_code, entity_id, type_code);
 
-INSERT INTO schema (version, updated_at) VALUES (73, strftime("%s"))
+INSERT INTO schema (version, updated_at) VALUES (80, strftime("%s"))
 `
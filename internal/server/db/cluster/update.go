@@ -110,6 +110,178 @@ var updates = map[int]schema.Update{
 	71: updateFromV70,
 	72: updateFromV71,
 	73: updateFromV72,
+	74: updateFromV73,
+	75: updateFromV74,
+	76: updateFromV75,
+	77: updateFromV76,
+	78: updateFromV77,
+	79: updateFromV78,
+	80: updateFromV79,
+}
+
+// updateFromV79 adds support for instance templates.
+func updateFromV79(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "instances_templates" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    type TEXT NOT NULL,
+    devices TEXT NOT NULL,
+    profiles TEXT NOT NULL,
+    UNIQUE (project_id, name),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_templates_config" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_template_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    UNIQUE (instance_template_id, key),
+    FOREIGN KEY (instance_template_id) REFERENCES "instances_templates" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding instance templates support: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV78 adds an expiry date to certificates.
+func updateFromV78(ctx context.Context, tx *sql.Tx) error {
+	q := `ALTER TABLE certificates ADD COLUMN expiry_date DATETIME;`
+
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding certificate expiry date column: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV77 adds support for instance groups.
+func updateFromV77(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "instances_groups" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    UNIQUE (project_id, name),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_groups_instances" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_group_id INTEGER NOT NULL,
+    instance_id INTEGER NOT NULL,
+    UNIQUE (instance_group_id, instance_id),
+    FOREIGN KEY (instance_group_id) REFERENCES "instances_groups" (id) ON DELETE CASCADE,
+    FOREIGN KEY (instance_id) REFERENCES "instances" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding instance groups support: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV76 adds severity overrides and acknowledgment tracking to warnings.
+func updateFromV76(ctx context.Context, tx *sql.Tx) error {
+	q := `
+ALTER TABLE warnings ADD COLUMN severity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE warnings ADD COLUMN acknowledged_by TEXT NOT NULL DEFAULT "";
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding warning severity and acknowledgment columns: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV75 adds support for instance network usage accounting.
+func updateFromV75(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "instances_network_usage" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    project_id INTEGER NOT NULL,
+    instance_id INTEGER NOT NULL,
+    instance_name TEXT NOT NULL,
+    period TEXT NOT NULL,
+    bytes_received INTEGER NOT NULL DEFAULT 0,
+    bytes_sent INTEGER NOT NULL DEFAULT 0,
+    packets_received INTEGER NOT NULL DEFAULT 0,
+    packets_sent INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (instance_id, period),
+    FOREIGN KEY (project_id) REFERENCES "projects" (id) ON DELETE CASCADE
+);
+CREATE TABLE "instances_network_usage_counters" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    instance_id INTEGER NOT NULL,
+    interface TEXT NOT NULL,
+    bytes_received INTEGER NOT NULL DEFAULT 0,
+    bytes_sent INTEGER NOT NULL DEFAULT 0,
+    packets_received INTEGER NOT NULL DEFAULT 0,
+    packets_sent INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (instance_id, interface),
+    FOREIGN KEY (instance_id) REFERENCES "instances" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding instance network usage support: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV74 adds support for network DHCP reservations.
+func updateFromV74(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "networks_dhcp_reservations" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    network_id INTEGER NOT NULL,
+    hwaddr TEXT NOT NULL,
+    description TEXT NOT NULL,
+    ipv4_address TEXT NOT NULL,
+    ipv6_address TEXT NOT NULL,
+    hostname TEXT NOT NULL,
+    UNIQUE (network_id, hwaddr),
+    FOREIGN KEY (network_id) REFERENCES "networks" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding network DHCP reservations support: %w", err)
+	}
+
+	return nil
+}
+
+// updateFromV73 adds support for profile inheritance.
+func updateFromV73(ctx context.Context, tx *sql.Tx) error {
+	q := `
+CREATE TABLE "profiles_parents" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+    profile_id INTEGER NOT NULL,
+    parent_id INTEGER NOT NULL,
+    apply_order INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (profile_id, parent_id),
+    FOREIGN KEY (profile_id) REFERENCES "profiles" (id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES "profiles" (id) ON DELETE CASCADE
+);
+`
+	_, err := tx.Exec(q)
+	if err != nil {
+		return fmt.Errorf("Failed adding profile inheritance support: %w", err)
+	}
+
+	return nil
 }
 
 // updateFromV72 removes the openfga.store.model_id server config key.
@@ -47,6 +47,8 @@ type Warning struct {
 	UpdatedDate    time.Time
 	LastMessage    string
 	Count          int
+	Severity       warningtype.Severity
+	AcknowledgedBy string
 }
 
 // WarningFilter specifies potential query parameter fields.
@@ -65,18 +67,26 @@ type WarningFilter struct {
 func (w Warning) ToAPI() api.Warning {
 	typeCode := warningtype.Type(w.TypeCode)
 
+	// Use the severity override if any, the default severity of the warning type otherwise.
+	severity := w.Severity
+	if severity == 0 {
+		severity = typeCode.Severity()
+	}
+
 	return api.Warning{
 		WarningPut: api.WarningPut{
-			Status: warningtype.Statuses[warningtype.Status(w.Status)],
+			Status:           warningtype.Statuses[warningtype.Status(w.Status)],
+			SeverityOverride: warningtype.Severities[w.Severity],
 		},
-		UUID:        w.UUID,
-		Location:    w.Node,
-		Project:     w.Project,
-		Type:        warningtype.TypeNames[typeCode],
-		Count:       w.Count,
-		FirstSeenAt: w.FirstSeenDate,
-		LastSeenAt:  w.LastSeenDate,
-		LastMessage: w.LastMessage,
-		Severity:    warningtype.Severities[typeCode.Severity()],
+		UUID:           w.UUID,
+		Location:       w.Node,
+		Project:        w.Project,
+		Type:           warningtype.TypeNames[typeCode],
+		Count:          w.Count,
+		FirstSeenAt:    w.FirstSeenDate,
+		LastSeenAt:     w.LastSeenDate,
+		LastMessage:    w.LastMessage,
+		Severity:       warningtype.Severities[severity],
+		AcknowledgedBy: w.AcknowledgedBy,
 	}
 }
@@ -194,6 +194,10 @@ func (c *ClusterTx) GetURIFromEntity(ctx context.Context, entityType int, entity
 			return "", fmt.Errorf("Failed to get operation: More than one operation matches")
 		}
 
+		if len(ops) == 0 {
+			return "", ErrUnknownEntityID
+		}
+
 		op := ops[0]
 
 		uri = fmt.Sprintf(cluster.EntityURIs[entityType], op.UUID)
@@ -0,0 +1,202 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetInstanceGroups returns the instance groups of the given project, ordered by name.
+// If names are specified, then the search is restricted to those groups.
+func (c *ClusterTx) GetInstanceGroups(ctx context.Context, projectName string, names ...string) ([]api.InstanceGroup, error) {
+	var q *strings.Builder = &strings.Builder{}
+	args := []any{projectName}
+
+	q.WriteString(`
+	SELECT instances_groups.id, instances_groups.name, instances_groups.description
+	FROM instances_groups
+	JOIN projects ON projects.id = instances_groups.project_id
+	WHERE projects.name = ?
+	`)
+
+	if len(names) > 0 {
+		q.WriteString("AND instances_groups.name IN " + query.Params(len(names)) + " ")
+		for _, name := range names {
+			args = append(args, name)
+		}
+	}
+
+	q.WriteString("ORDER BY instances_groups.name")
+
+	ids := []int64{}
+	groups := []api.InstanceGroup{}
+	err := query.Scan(ctx, c.tx, q.String(), func(scan func(dest ...any) error) error {
+		var id int64
+		group := api.InstanceGroup{Project: projectName}
+
+		err := scan(&id, &group.Name, &group.Description)
+		if err != nil {
+			return err
+		}
+
+		ids = append(ids, id)
+		groups = append(groups, group)
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range groups {
+		groups[i].Instances, err = instanceGroupInstances(ctx, c.tx, ids[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+// GetInstanceGroup returns the ID and the instance group with the given name in the given project.
+func (c *ClusterTx) GetInstanceGroup(ctx context.Context, projectName string, name string) (int64, *api.InstanceGroup, error) {
+	var id int64
+	group := api.InstanceGroup{Name: name, Project: projectName}
+
+	q := `
+	SELECT instances_groups.id, instances_groups.description
+	FROM instances_groups
+	JOIN projects ON projects.id = instances_groups.project_id
+	WHERE projects.name = ? AND instances_groups.name = ?
+	`
+
+	err := c.tx.QueryRowContext(ctx, q, projectName, name).Scan(&id, &group.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, nil, api.StatusErrorf(http.StatusNotFound, "Instance group not found")
+		}
+
+		return -1, nil, err
+	}
+
+	group.Instances, err = instanceGroupInstances(ctx, c.tx, id)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return id, &group, nil
+}
+
+// instanceGroupInstances returns the names of the instances in the instance group with the given ID.
+func instanceGroupInstances(ctx context.Context, tx *sql.Tx, id int64) ([]string, error) {
+	q := `
+	SELECT instances.name
+	FROM instances_groups_instances
+	JOIN instances ON instances.id = instances_groups_instances.instance_id
+	WHERE instances_groups_instances.instance_group_id = ?
+	ORDER BY instances.name
+	`
+
+	return query.SelectStrings(ctx, tx, q, id)
+}
+
+// instanceGroupInstancesSet replaces the instances of the instance group with the given ID.
+func instanceGroupInstancesSet(ctx context.Context, tx *sql.Tx, projectName string, id int64, instances []string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM instances_groups_instances WHERE instance_group_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range instances {
+		res, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO instances_groups_instances (instance_group_id, instance_id)
+		SELECT ?, instances.id
+		FROM instances
+		JOIN projects ON projects.id = instances.project_id
+		WHERE projects.name = ? AND instances.name = ?
+		`, id, projectName, name)
+		if err != nil {
+			return fmt.Errorf("Failed adding instance %q to group: %w", name, err)
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		// Duplicates are ignored, so check whether the instance exists at all.
+		if rowsAffected <= 0 {
+			var count int
+			err = tx.QueryRowContext(ctx, `
+			SELECT COUNT(*)
+			FROM instances
+			JOIN projects ON projects.id = instances.project_id
+			WHERE projects.name = ? AND instances.name = ?
+			`, projectName, name).Scan(&count)
+			if err != nil {
+				return err
+			}
+
+			if count == 0 {
+				return api.StatusErrorf(http.StatusBadRequest, "Instance %q not found", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateInstanceGroup creates a new instance group.
+func (c *ClusterTx) CreateInstanceGroup(ctx context.Context, projectName string, info *api.InstanceGroupsPost) (int64, error) {
+	result, err := c.tx.ExecContext(ctx, `
+		INSERT INTO instances_groups (project_id, name, description)
+		VALUES ((SELECT id FROM projects WHERE name = ? LIMIT 1), ?, ?)
+		`, projectName, info.Name, info.Description)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	err = instanceGroupInstancesSet(ctx, c.tx, projectName, id, info.Instances)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// UpdateInstanceGroup updates the instance group with the given ID.
+func (c *ClusterTx) UpdateInstanceGroup(ctx context.Context, projectName string, id int64, info *api.InstanceGroupPut) error {
+	_, err := c.tx.ExecContext(ctx, "UPDATE instances_groups SET description = ? WHERE id = ?", info.Description, id)
+	if err != nil {
+		return err
+	}
+
+	return instanceGroupInstancesSet(ctx, c.tx, projectName, id, info.Instances)
+}
+
+// RenameInstanceGroup renames the instance group with the given ID.
+func (c *ClusterTx) RenameInstanceGroup(ctx context.Context, id int64, newName string) error {
+	_, err := c.tx.ExecContext(ctx, "UPDATE instances_groups SET name = ? WHERE id = ?", newName, id)
+
+	return err
+}
+
+// DeleteInstanceGroup deletes the instance group with the given ID.
+func (c *ClusterTx) DeleteInstanceGroup(ctx context.Context, id int64) error {
+	_, err := c.tx.ExecContext(ctx, "DELETE FROM instances_groups WHERE id = ?", id)
+
+	return err
+}
@@ -0,0 +1,220 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// networkUsageDelta returns the amount transferred since the last counter value.
+// A counter lower than its last value means the interface was recreated (instance restart), in which case
+// everything counted since is new.
+func networkUsageDelta(counter int64, lastCounter int64) int64 {
+	if counter < lastCounter {
+		return counter
+	}
+
+	return counter - lastCounter
+}
+
+// UpdateInstanceNetworkUsage accounts the current network counters of an instance in the specified period.
+// The counters are tracked per host interface and compared with the last ones recorded for the same interface,
+// only the difference being added to the usage of the period. The last counters of interfaces which are gone
+// are removed.
+func (c *ClusterTx) UpdateInstanceNetworkUsage(ctx context.Context, projectName string, instanceID int, instanceName string, period string, counters map[string]api.NetworkUsageCounters) error {
+	projectID, err := cluster.GetProjectID(ctx, c.tx, projectName)
+	if err != nil {
+		return err
+	}
+
+	// Get the last recorded counters of each interface.
+	lastCounters := map[string]api.NetworkUsageCounters{}
+	err = query.Scan(ctx, c.tx, `
+		SELECT interface, bytes_received, bytes_sent, packets_received, packets_sent
+		FROM instances_network_usage_counters
+		WHERE instance_id = ?
+		`, func(scan func(dest ...any) error) error {
+		var name string
+		var last api.NetworkUsageCounters
+
+		err := scan(&name, &last.BytesReceived, &last.BytesSent, &last.PacketsReceived, &last.PacketsSent)
+		if err != nil {
+			return err
+		}
+
+		lastCounters[name] = last
+
+		return nil
+	}, instanceID)
+	if err != nil {
+		return err
+	}
+
+	delta := api.NetworkUsageCounters{}
+	for name, counter := range counters {
+		last := lastCounters[name]
+
+		delta.BytesReceived += networkUsageDelta(counter.BytesReceived, last.BytesReceived)
+		delta.BytesSent += networkUsageDelta(counter.BytesSent, last.BytesSent)
+		delta.PacketsReceived += networkUsageDelta(counter.PacketsReceived, last.PacketsReceived)
+		delta.PacketsSent += networkUsageDelta(counter.PacketsSent, last.PacketsSent)
+
+		_, err = c.tx.ExecContext(ctx, `
+			INSERT INTO instances_network_usage_counters (instance_id, interface, bytes_received, bytes_sent, packets_received, packets_sent)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (instance_id, interface) DO UPDATE SET
+				bytes_received = excluded.bytes_received,
+				bytes_sent = excluded.bytes_sent,
+				packets_received = excluded.packets_received,
+				packets_sent = excluded.packets_sent
+			`, instanceID, name, counter.BytesReceived, counter.BytesSent, counter.PacketsReceived, counter.PacketsSent)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Forget the interfaces which are gone.
+	for name := range lastCounters {
+		_, ok := counters[name]
+		if ok {
+			continue
+		}
+
+		_, err = c.tx.ExecContext(ctx, "DELETE FROM instances_network_usage_counters WHERE instance_id = ? AND interface = ?", instanceID, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Add the difference to the usage of the period, keeping track of the latest name of the instance.
+	_, err = c.tx.ExecContext(ctx, `
+		INSERT INTO instances_network_usage (project_id, instance_id, instance_name, period, bytes_received, bytes_sent, packets_received, packets_sent)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (instance_id, period) DO UPDATE SET
+			project_id = excluded.project_id,
+			instance_name = excluded.instance_name,
+			bytes_received = bytes_received + excluded.bytes_received,
+			bytes_sent = bytes_sent + excluded.bytes_sent,
+			packets_received = packets_received + excluded.packets_received,
+			packets_sent = packets_sent + excluded.packets_sent
+		`, projectID, instanceID, instanceName, period, delta.BytesReceived, delta.BytesSent, delta.PacketsReceived, delta.PacketsSent)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetInstanceNetworkUsage returns the network usage of an instance for each period, optionally filtered to the
+// specified periods.
+func (c *ClusterTx) GetInstanceNetworkUsage(ctx context.Context, instanceID int, periods ...string) ([]api.InstanceNetworkUsage, error) {
+	var q strings.Builder
+
+	q.WriteString(`
+	SELECT period, bytes_received, bytes_sent, packets_received, packets_sent
+	FROM instances_network_usage
+	WHERE instance_id = ?
+	`)
+
+	args := []any{instanceID}
+
+	if len(periods) > 0 {
+		q.WriteString("AND period IN " + query.Params(len(periods)) + " ")
+		for _, period := range periods {
+			args = append(args, period)
+		}
+	}
+
+	q.WriteString("ORDER BY period")
+
+	usages := []api.InstanceNetworkUsage{}
+	err := query.Scan(ctx, c.tx, q.String(), func(scan func(dest ...any) error) error {
+		var usage api.InstanceNetworkUsage
+
+		err := scan(&usage.Period, &usage.BytesReceived, &usage.BytesSent, &usage.PacketsReceived, &usage.PacketsSent)
+		if err != nil {
+			return err
+		}
+
+		usages = append(usages, usage)
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
+
+// GetProjectNetworkUsage returns the network usage of the instances of a project for each period, optionally
+// filtered to the specified periods.
+func (c *ClusterTx) GetProjectNetworkUsage(ctx context.Context, projectName string, periods ...string) ([]api.ProjectNetworkUsage, error) {
+	var q strings.Builder
+
+	// Instances are listed under their current name or, once deleted, under their last known name.
+	q.WriteString(`
+	SELECT instances_network_usage.period, COALESCE(instances.name, instances_network_usage.instance_name), instances_network_usage.bytes_received, instances_network_usage.bytes_sent, instances_network_usage.packets_received, instances_network_usage.packets_sent
+	FROM instances_network_usage
+	JOIN projects ON projects.id = instances_network_usage.project_id
+	LEFT JOIN instances ON instances.id = instances_network_usage.instance_id
+	WHERE projects.name = ?
+	`)
+
+	args := []any{projectName}
+
+	if len(periods) > 0 {
+		q.WriteString("AND instances_network_usage.period IN " + query.Params(len(periods)) + " ")
+		for _, period := range periods {
+			args = append(args, period)
+		}
+	}
+
+	q.WriteString("ORDER BY instances_network_usage.period")
+
+	usages := []api.ProjectNetworkUsage{}
+	err := query.Scan(ctx, c.tx, q.String(), func(scan func(dest ...any) error) error {
+		var period string
+		var instanceName string
+		var counters api.NetworkUsageCounters
+
+		err := scan(&period, &instanceName, &counters.BytesReceived, &counters.BytesSent, &counters.PacketsReceived, &counters.PacketsSent)
+		if err != nil {
+			return err
+		}
+
+		// Rows are ordered by period, so start a new entry whenever the period changes.
+		if len(usages) == 0 || usages[len(usages)-1].Period != period {
+			usages = append(usages, api.ProjectNetworkUsage{
+				Period:    period,
+				Instances: map[string]api.NetworkUsageCounters{},
+			})
+		}
+
+		usage := &usages[len(usages)-1]
+		usage.BytesReceived += counters.BytesReceived
+		usage.BytesSent += counters.BytesSent
+		usage.PacketsReceived += counters.PacketsReceived
+		usage.PacketsSent += counters.PacketsSent
+
+		// Deleted instances may share their name with another instance.
+		total := usage.Instances[instanceName]
+		total.BytesReceived += counters.BytesReceived
+		total.BytesSent += counters.BytesSent
+		total.PacketsReceived += counters.PacketsReceived
+		total.PacketsSent += counters.PacketsSent
+		usage.Instances[instanceName] = total
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages, nil
+}
@@ -0,0 +1,230 @@
+//go:build linux && cgo && !agent
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/db/query"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// GetInstanceTemplates returns the instance templates of the given project, ordered by name.
+// If names are specified, then the search is restricted to those templates.
+func (c *ClusterTx) GetInstanceTemplates(ctx context.Context, projectName string, names ...string) ([]api.InstanceTemplate, error) {
+	var q *strings.Builder = &strings.Builder{}
+	args := []any{projectName}
+
+	q.WriteString(`
+	SELECT instances_templates.id, instances_templates.name, instances_templates.description, instances_templates.type, instances_templates.devices, instances_templates.profiles
+	FROM instances_templates
+	JOIN projects ON projects.id = instances_templates.project_id
+	WHERE projects.name = ?
+	`)
+
+	if len(names) > 0 {
+		q.WriteString("AND instances_templates.name IN " + query.Params(len(names)) + " ")
+		for _, name := range names {
+			args = append(args, name)
+		}
+	}
+
+	q.WriteString("ORDER BY instances_templates.name")
+
+	ids := []int64{}
+	templates := []api.InstanceTemplate{}
+	err := query.Scan(ctx, c.tx, q.String(), func(scan func(dest ...any) error) error {
+		var id int64
+		var devicesJSON string
+		var profilesJSON string
+		template := api.InstanceTemplate{Project: projectName}
+
+		err := scan(&id, &template.Name, &template.Description, &template.Type, &devicesJSON, &profilesJSON)
+		if err != nil {
+			return err
+		}
+
+		err = instanceTemplateUnmarshal(&template, devicesJSON, profilesJSON)
+		if err != nil {
+			return err
+		}
+
+		ids = append(ids, id)
+		templates = append(templates, template)
+
+		return nil
+	}, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		templates[i].Config, err = query.SelectConfig(ctx, c.tx, "instances_templates_config", "instance_template_id=?", ids[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// GetInstanceTemplate returns the ID and the instance template with the given name in the given project.
+func (c *ClusterTx) GetInstanceTemplate(ctx context.Context, projectName string, name string) (int64, *api.InstanceTemplate, error) {
+	var id int64
+	var devicesJSON string
+	var profilesJSON string
+	template := api.InstanceTemplate{Name: name, Project: projectName}
+
+	q := `
+	SELECT instances_templates.id, instances_templates.description, instances_templates.type, instances_templates.devices, instances_templates.profiles
+	FROM instances_templates
+	JOIN projects ON projects.id = instances_templates.project_id
+	WHERE projects.name = ? AND instances_templates.name = ?
+	`
+
+	err := c.tx.QueryRowContext(ctx, q, projectName, name).Scan(&id, &template.Description, &template.Type, &devicesJSON, &profilesJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, nil, api.StatusErrorf(http.StatusNotFound, "Instance template not found")
+		}
+
+		return -1, nil, err
+	}
+
+	err = instanceTemplateUnmarshal(&template, devicesJSON, profilesJSON)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	template.Config, err = query.SelectConfig(ctx, c.tx, "instances_templates_config", "instance_template_id=?", id)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return id, &template, nil
+}
+
+// instanceTemplateUnmarshal fills the devices and profiles of an instance template from their JSON encoding.
+func instanceTemplateUnmarshal(template *api.InstanceTemplate, devicesJSON string, profilesJSON string) error {
+	err := json.Unmarshal([]byte(devicesJSON), &template.Devices)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling devices: %w", err)
+	}
+
+	err = json.Unmarshal([]byte(profilesJSON), &template.Profiles)
+	if err != nil {
+		return fmt.Errorf("Failed unmarshalling profiles: %w", err)
+	}
+
+	return nil
+}
+
+// instanceTemplateMarshal returns the JSON encoding of the devices and profiles of an instance template.
+func instanceTemplateMarshal(info *api.InstanceTemplatePut) (string, string, error) {
+	devicesJSON, err := json.Marshal(info.Devices)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed marshalling devices: %w", err)
+	}
+
+	profilesJSON, err := json.Marshal(info.Profiles)
+	if err != nil {
+		return "", "", fmt.Errorf("Failed marshalling profiles: %w", err)
+	}
+
+	return string(devicesJSON), string(profilesJSON), nil
+}
+
+// instanceTemplateConfigAdd inserts the config keys of an instance template.
+func instanceTemplateConfigAdd(tx *sql.Tx, id int64, config map[string]string) error {
+	stmt, err := tx.Prepare(`
+	INSERT INTO instances_templates_config
+	(instance_template_id, key, value)
+	VALUES(?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = stmt.Close() }()
+
+	for k, v := range config {
+		if v == "" {
+			continue
+		}
+
+		_, err = stmt.Exec(id, k, v)
+		if err != nil {
+			return fmt.Errorf("Failed inserting config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// CreateInstanceTemplate creates a new instance template.
+func (c *ClusterTx) CreateInstanceTemplate(ctx context.Context, projectName string, name string, info *api.InstanceTemplatePut) (int64, error) {
+	devicesJSON, profilesJSON, err := instanceTemplateMarshal(info)
+	if err != nil {
+		return -1, err
+	}
+
+	result, err := c.tx.ExecContext(ctx, `
+		INSERT INTO instances_templates (project_id, name, description, type, devices, profiles)
+		VALUES ((SELECT id FROM projects WHERE name = ? LIMIT 1), ?, ?, ?, ?, ?)
+		`, projectName, name, info.Description, string(info.Type), devicesJSON, profilesJSON)
+	if err != nil {
+		return -1, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+
+	err = instanceTemplateConfigAdd(c.tx, id, info.Config)
+	if err != nil {
+		return -1, err
+	}
+
+	return id, nil
+}
+
+// UpdateInstanceTemplate updates the instance template with the given ID.
+func (c *ClusterTx) UpdateInstanceTemplate(ctx context.Context, id int64, info *api.InstanceTemplatePut) error {
+	devicesJSON, profilesJSON, err := instanceTemplateMarshal(info)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.tx.ExecContext(ctx, "UPDATE instances_templates SET description = ?, type = ?, devices = ?, profiles = ? WHERE id = ?", info.Description, string(info.Type), devicesJSON, profilesJSON, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.tx.ExecContext(ctx, "DELETE FROM instances_templates_config WHERE instance_template_id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	return instanceTemplateConfigAdd(c.tx, id, info.Config)
+}
+
+// RenameInstanceTemplate renames the instance template with the given ID.
+func (c *ClusterTx) RenameInstanceTemplate(ctx context.Context, id int64, newName string) error {
+	_, err := c.tx.ExecContext(ctx, "UPDATE instances_templates SET name = ? WHERE id = ?", newName, id)
+
+	return err
+}
+
+// DeleteInstanceTemplate deletes the instance template with the given ID.
+func (c *ClusterTx) DeleteInstanceTemplate(ctx context.Context, id int64) error {
+	_, err := c.tx.ExecContext(ctx, "DELETE FROM instances_templates WHERE id = ?", id)
+
+	return err
+}
@@ -165,7 +165,7 @@ func (c *ClusterTx) GetNetworkACLNameAndProjectWithID(ctx context.Context, netwo
 	var networkACLName string
 	var projectName string
 
-	q := `SELECT networks_acls.name, projects.name FROM networks_acls JOIN projects ON projects.id=networks.project_id WHERE networks_acls.id=?`
+	q := `SELECT networks_acls.name, projects.name FROM networks_acls JOIN projects ON projects.id=networks_acls.project_id WHERE networks_acls.id=?`
 
 	err := c.tx.QueryRowContext(ctx, q, networkACLID).Scan(&networkACLName, &projectName)
 	if err != nil {
@@ -209,6 +209,19 @@ func networkACLConfig(ctx context.Context, tx *ClusterTx, id int64, acl *api.Net
 
 // CreateNetworkACL creates a new Network ACL.
 func (c *ClusterTx) CreateNetworkACL(ctx context.Context, projectName string, info *api.NetworkACLsPost) (int64, error) {
+	return c.createNetworkACL(ctx, nil, projectName, info)
+}
+
+// CreateNetworkACLWithID creates a new Network ACL using the specified ID.
+// This is used when recovering Network ACLs whose ID is referenced outside of the database.
+func (c *ClusterTx) CreateNetworkACLWithID(ctx context.Context, id int64, projectName string, info *api.NetworkACLsPost) error {
+	_, err := c.createNetworkACL(ctx, id, projectName, info)
+
+	return err
+}
+
+// createNetworkACL creates a new Network ACL, a nil fixedID lets the database pick the ID.
+func (c *ClusterTx) createNetworkACL(ctx context.Context, fixedID any, projectName string, info *api.NetworkACLsPost) (int64, error) {
 	var err error
 	var ingressJSON, egressJSON []byte
 
@@ -228,9 +241,9 @@ func (c *ClusterTx) CreateNetworkACL(ctx context.Context, projectName string, in
 
 	// Insert a new Network ACL record.
 	result, err := c.tx.ExecContext(ctx, `
-			INSERT INTO networks_acls (project_id, name, description, ingress, egress)
-			VALUES ((SELECT id FROM projects WHERE name = ? LIMIT 1), ?, ?, ?, ?)
-		`, projectName, info.Name, info.Description, string(ingressJSON), string(egressJSON))
+			INSERT INTO networks_acls (id, project_id, name, description, ingress, egress)
+			VALUES (?, (SELECT id FROM projects WHERE name = ? LIMIT 1), ?, ?, ?, ?)
+		`, fixedID, projectName, info.Name, info.Description, string(ingressJSON), string(egressJSON))
 	if err != nil {
 		return -1, err
 	}
@@ -419,6 +419,62 @@ func (c *ClusterTx) GetNetworkPeerNames(ctx context.Context, networkID int64) (m
 	return peers, nil
 }
 
+// GetNetworkPeerRequests returns the pending local peerings from other networks that target the given network,
+// ordered by requesting project and network names.
+func (c *ClusterTx) GetNetworkPeerRequests(ctx context.Context, projectName string, networkName string) ([]api.NetworkPeerRequest, error) {
+	q := `
+	SELECT
+		networks_peers.id,
+		networks_peers.name,
+		networks_peers.description,
+		projects.name,
+		networks.name
+	FROM networks_peers
+	JOIN networks ON networks.id = networks_peers.network_id
+	JOIN projects ON projects.id = networks.project_id
+	WHERE networks_peers.type = ?
+		AND networks_peers.target_network_id IS NULL
+		AND networks_peers.target_network_project = ?
+		AND networks_peers.target_network_name = ?
+	ORDER BY projects.name, networks.name
+	`
+
+	var peerIDs []int64
+	requests := []api.NetworkPeerRequest{}
+
+	err := query.Scan(ctx, c.tx, q, func(scan func(dest ...any) error) error {
+		var peerID int64
+		var request api.NetworkPeerRequest
+
+		err := scan(&peerID, &request.Name, &request.Description, &request.SourceProject, &request.SourceNetwork)
+		if err != nil {
+			return err
+		}
+
+		peerIDs = append(peerIDs, peerID)
+		requests = append(requests, request)
+
+		return nil
+	}, networkPeerTypeLocal, projectName, networkName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Populate config.
+	for i, peerID := range peerIDs {
+		var peer api.NetworkPeer
+
+		err = networkPeerConfig(ctx, c, peerID, &peer)
+		if err != nil {
+			return nil, err
+		}
+
+		requests[i].Config = peer.Config
+	}
+
+	return requests, nil
+}
+
 // GetNetworkPeersURLByIntegration returns a slice of API paths for the peers using the integration.
 func (c *ClusterTx) GetNetworkPeersURLByIntegration(ctx context.Context, networkIntegration string) ([]string, error) {
 	q := `
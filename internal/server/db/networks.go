@@ -565,6 +565,7 @@ const (
 	NetworkTypeSriov                       // Network type sriov.
 	NetworkTypeOVN                         // Network type ovn.
 	NetworkTypePhysical                    // Network type physical.
+	NetworkTypeMesh                        // Network type mesh.
 )
 
 // NetworkNode represents a network node.
@@ -692,6 +693,8 @@ func networkFillType(network *api.Network, netType NetworkType) {
 		network.Type = "ovn"
 	case NetworkTypePhysical:
 		network.Type = "physical"
+	case NetworkTypeMesh:
+		network.Type = "mesh"
 	default:
 		network.Type = "" // Unknown
 	}
@@ -729,9 +732,22 @@ func (c *ClusterTx) getNetworkConfig(ctx context.Context, tx *ClusterTx, network
 
 // CreateNetwork creates a new network.
 func (c *ClusterTx) CreateNetwork(ctx context.Context, projectName string, name string, description string, netType NetworkType, config map[string]string) (int64, error) {
+	return c.createNetwork(ctx, nil, projectName, name, description, netType, config)
+}
+
+// CreateNetworkWithID creates a new network using the specified ID.
+// This is used when recovering networks whose ID is referenced outside of the database.
+func (c *ClusterTx) CreateNetworkWithID(ctx context.Context, id int64, projectName string, name string, description string, netType NetworkType, config map[string]string) error {
+	_, err := c.createNetwork(ctx, id, projectName, name, description, netType, config)
+
+	return err
+}
+
+// createNetwork creates a new network, a nil fixedID lets the database pick the ID.
+func (c *ClusterTx) createNetwork(ctx context.Context, fixedID any, projectName string, name string, description string, netType NetworkType, config map[string]string) (int64, error) {
 	// Insert a new network record with state networkCreated.
-	result, err := c.tx.ExecContext(ctx, "INSERT INTO networks (project_id, name, description, state, type) VALUES ((SELECT id FROM projects WHERE name = ?), ?, ?, ?, ?)",
-		projectName, name, description, networkCreated, netType)
+	result, err := c.tx.ExecContext(ctx, "INSERT INTO networks (id, project_id, name, description, state, type) VALUES (?, (SELECT id FROM projects WHERE name = ?), ?, ?, ?, ?)",
+		fixedID, projectName, name, description, networkCreated, netType)
 	if err != nil {
 		return -1, err
 	}
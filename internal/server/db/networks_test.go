@@ -105,3 +105,23 @@ func TestNetworksCreatePending_NonExistingNode(t *testing.T) {
 	err := tx.CreatePendingNetwork(context.Background(), "buzz", api.ProjectDefaultName, "network1", "", db.NetworkTypeBridge, map[string]string{})
 	require.True(t, response.IsNotFoundError(err))
 }
+
+// A network can be created with a specific ID, after which IDs keep being allocated automatically.
+func TestCreateNetworkWithID(t *testing.T) {
+	tx, cleanup := db.NewTestClusterTx(t)
+	defer cleanup()
+
+	err := tx.CreateNetworkWithID(context.Background(), 10, api.ProjectDefaultName, "network1", "", db.NetworkTypeOVN, map[string]string{})
+	require.NoError(t, err)
+
+	networkID, err := tx.GetNetworkID(context.Background(), api.ProjectDefaultName, "network1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), networkID)
+
+	err = tx.CreateNetworkWithID(context.Background(), 10, api.ProjectDefaultName, "network2", "", db.NetworkTypeOVN, map[string]string{})
+	require.Error(t, err)
+
+	networkID, err = tx.CreateNetwork(context.Background(), api.ProjectDefaultName, "network2", "", db.NetworkTypeOVN, map[string]string{})
+	require.NoError(t, err)
+	assert.Greater(t, networkID, int64(10))
+}
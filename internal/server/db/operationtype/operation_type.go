@@ -79,6 +79,12 @@ const (
 	BucketBackupRemove
 	BucketBackupRename
 	BucketBackupRestore
+	InstancesShutdown
+	MigrationToken
+	SupportBundle
+	ProjectCleanup
+	StoragePoolBenchmark
+	StoragePoolMaintenance
 )
 
 // Description return a human-readable description of the operation type.
@@ -210,6 +216,18 @@ func (t Type) Description() string {
 		return "Renaming bucket backup"
 	case BucketBackupRestore:
 		return "Restoring bucket backup"
+	case InstancesShutdown:
+		return "Shutting down instances"
+	case MigrationToken:
+		return "Migration token"
+	case SupportBundle:
+		return "Generating support bundle"
+	case ProjectCleanup:
+		return "Cleaning up project"
+	case StoragePoolBenchmark:
+		return "Benchmarking storage pool"
+	case StoragePoolMaintenance:
+		return "Running storage pool maintenance"
 	default:
 		return "Executing operation"
 	}
@@ -253,6 +271,8 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 
 	case InstanceCreate:
 		return auth.ObjectTypeProject, auth.EntitlementCanCreateInstances
+	case MigrationToken:
+		return auth.ObjectTypeProject, auth.EntitlementCanCreateInstances
 	case InstanceUpdate:
 		return auth.ObjectTypeInstance, auth.EntitlementCanEdit
 	case InstanceRename:
@@ -300,6 +320,16 @@ func (t Type) Permission() (auth.ObjectType, auth.Entitlement) {
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanManageBackups
 	case BucketBackupRestore:
 		return auth.ObjectTypeStorageVolume, auth.EntitlementCanEdit
+
+	case SupportBundle:
+		return auth.ObjectTypeServer, auth.EntitlementCanEdit
+
+	case ProjectCleanup:
+		return auth.ObjectTypeProject, auth.EntitlementCanEdit
+	case StoragePoolBenchmark:
+		return auth.ObjectTypeStoragePool, auth.EntitlementCanEdit
+	case StoragePoolMaintenance:
+		return auth.ObjectTypeStoragePool, auth.EntitlementCanEdit
 	}
 
 	return "", ""
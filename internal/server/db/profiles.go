@@ -155,6 +155,9 @@ func ExpandInstanceConfig(config map[string]string, profiles []api.Profile) map[
 	profileConfigs := make([]map[string]string, len(profiles))
 	for i, profile := range profiles {
 		profileConfigs[i] = profile.Config
+		if profile.ExpandedConfig != nil {
+			profileConfigs[i] = profile.ExpandedConfig
+		}
 	}
 
 	for i := range profileConfigs {
@@ -180,6 +183,9 @@ func ExpandInstanceDevices(devices deviceConfig.Devices, profiles []api.Profile)
 	profileDevices := make([]deviceConfig.Devices, len(profiles))
 	for i, profile := range profiles {
 		profileDevices[i] = deviceConfig.NewDevices(profile.Devices)
+		if profile.ExpandedDevices != nil {
+			profileDevices[i] = deviceConfig.NewDevices(profile.ExpandedDevices)
+		}
 	}
 
 	for i := range profileDevices {
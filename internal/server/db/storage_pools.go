@@ -881,6 +881,16 @@ var NodeSpecificStorageConfig = []string{
 	"source",
 	"source.wipe",
 	"volatile.initial_source",
+	"volatile.benchmark.sequential_read",
+	"volatile.benchmark.sequential_write",
+	"volatile.benchmark.random_read",
+	"volatile.benchmark.random_write",
+	"volatile.benchmark.date",
+	"volatile.scrub.last_run",
+	"volatile.scrub.last_status",
+	"volatile.scrub.last_errors",
+	"volatile.balance.last_run",
+	"volatile.balance.last_status",
 	"zfs.pool_name",
 	"lvm.thinpool_name",
 	"lvm.vg_name",
@@ -73,6 +73,12 @@ func (c *ClusterTx) UpsertWarning(ctx context.Context, nodeName string, projectN
 		newStatus := warnings[0].Status
 		if newStatus == warningtype.StatusResolved {
 			newStatus = warningtype.StatusNew
+
+			// The previous acknowledgment doesn't apply to the new occurrence.
+			err = c.UpdateWarningAcknowledgedBy(warnings[0].UUID, "")
+			if err != nil {
+				return err
+			}
 		}
 
 		err = c.UpdateWarningState(warnings[0].UUID, message, newStatus)
@@ -122,6 +128,47 @@ func (c *ClusterTx) UpdateWarningStatus(UUID string, status warningtype.Status)
 	return nil
 }
 
+// UpdateWarningSeverity sets the severity override of the warning with the given UUID.
+// A zero severity restores the default severity of the warning type.
+func (c *ClusterTx) UpdateWarningSeverity(UUID string, severity warningtype.Severity) error {
+	str := "UPDATE warnings SET severity=?, updated_date=? WHERE uuid=?"
+	res, err := c.tx.Exec(str, severity, time.Now(), UUID)
+	if err != nil {
+		return fmt.Errorf("Failed to update warning severity for warning %q: %w", UUID, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to get affected rows to update warning severity %q: %w", UUID, err)
+	}
+
+	if rowsAffected == 0 {
+		return api.StatusErrorf(http.StatusNotFound, "Warning not found")
+	}
+
+	return nil
+}
+
+// UpdateWarningAcknowledgedBy records who acknowledged the warning with the given UUID.
+func (c *ClusterTx) UpdateWarningAcknowledgedBy(UUID string, username string) error {
+	str := "UPDATE warnings SET acknowledged_by=? WHERE uuid=?"
+	res, err := c.tx.Exec(str, username, UUID)
+	if err != nil {
+		return fmt.Errorf("Failed to update warning acknowledgment for warning %q: %w", UUID, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to get affected rows to update warning acknowledgment %q: %w", UUID, err)
+	}
+
+	if rowsAffected == 0 {
+		return api.StatusErrorf(http.StatusNotFound, "Warning not found")
+	}
+
+	return nil
+}
+
 // UpdateWarningState updates the warning message and status with the given ID.
 func (c *ClusterTx) UpdateWarningState(UUID string, message string, status warningtype.Status) error {
 	str := "UPDATE warnings SET last_message=?, last_seen_date=?, updated_date=?, status = ?, count=count+1 WHERE uuid=?"
@@ -56,34 +56,55 @@ const (
 	StoragePoolUnvailable
 	// UnableToUpdateClusterCertificate represents the unable to update cluster certificate warning.
 	UnableToUpdateClusterCertificate
+	// StorageVolumeQuotaThresholdExceeded represents a storage volume using more than the configured share of its quota.
+	StorageVolumeQuotaThresholdExceeded
+	// InstanceImageOutdated represents an instance whose image was refreshed since it was created.
+	InstanceImageOutdated
+	// NetworkOVNRangesThresholdExceeded represents an uplink network using more than the configured share of its OVN ranges.
+	NetworkOVNRangesThresholdExceeded
+	// StoragePoolScrubErrors represents a storage pool whose last scrub found data integrity errors.
+	StoragePoolScrubErrors
+	// StoragePoolMaintenanceFailed represents a scheduled storage pool maintenance run that failed.
+	StoragePoolMaintenanceFailed
+	// StoragePoolUsageHigh represents a storage pool running out of space.
+	StoragePoolUsageHigh
+	// StoragePoolUsageCritical represents a storage pool about to run out of space.
+	StoragePoolUsageCritical
 )
 
 // TypeNames associates a warning code to its name.
 var TypeNames = map[Type]string{
-	Undefined:                         "Undefined warning",
-	MissingCGroupBlkio:                "Couldn't find the CGroup blkio",
-	MissingCGroupBlkioWeight:          "Couldn't find the CGroup blkio.weight",
-	MissingCGroupCPUController:        "Couldn't find the CGroup CPU controller",
-	MissingCGroupCPUsetController:     "Couldn't find the CGroup CPUset controller",
-	MissingCGroupCPUacctController:    "Couldn't find the CGroup CPUacct controller",
-	MissingCGroupDevicesController:    "Couldn't find the CGroup devices controller",
-	MissingCGroupFreezerController:    "Couldn't find the CGroup freezer controller",
-	MissingCGroupHugetlbController:    "Couldn't find the CGroup hugetlb controller",
-	MissingCGroupMemoryController:     "Couldn't find the CGroup memory controller",
-	MissingCGroupPidsController:       "Couldn't find the CGroup pids controller",
-	MissingCGroupMemorySwapAccounting: "Couldn't find the CGroup memory swap accounting",
-	ClusterTimeSkew:                   "Time skew detected between leader and local",
-	AppArmorNotAvailable:              "AppArmor support has been disabled",
-	MissingVirtiofsd:                  "Missing virtiofsd",
-	AppArmorDisabledDueToRawDnsmasq:   "Skipping AppArmor for dnsmasq due to raw.dnsmasq being set",
-	LargerIPv6PrefixThanSupported:     "IPv6 networks with a prefix larger than 64 aren't properly supported by dnsmasq",
-	ProxyBridgeNetfilterNotEnabled:    "Proxy bridge netfilter not enabled",
-	NetworkUnvailable:                 "Network unavailable",
-	OfflineClusterMember:              "Offline cluster member",
-	InstanceAutostartFailure:          "Failed to autostart instance",
-	InstanceTypeNotOperational:        "Instance type not operational",
-	StoragePoolUnvailable:             "Storage pool unavailable",
-	UnableToUpdateClusterCertificate:  "Unable to update cluster certificate",
+	Undefined:                           "Undefined warning",
+	MissingCGroupBlkio:                  "Couldn't find the CGroup blkio",
+	MissingCGroupBlkioWeight:            "Couldn't find the CGroup blkio.weight",
+	MissingCGroupCPUController:          "Couldn't find the CGroup CPU controller",
+	MissingCGroupCPUsetController:       "Couldn't find the CGroup CPUset controller",
+	MissingCGroupCPUacctController:      "Couldn't find the CGroup CPUacct controller",
+	MissingCGroupDevicesController:      "Couldn't find the CGroup devices controller",
+	MissingCGroupFreezerController:      "Couldn't find the CGroup freezer controller",
+	MissingCGroupHugetlbController:      "Couldn't find the CGroup hugetlb controller",
+	MissingCGroupMemoryController:       "Couldn't find the CGroup memory controller",
+	MissingCGroupPidsController:         "Couldn't find the CGroup pids controller",
+	MissingCGroupMemorySwapAccounting:   "Couldn't find the CGroup memory swap accounting",
+	ClusterTimeSkew:                     "Time skew detected between leader and local",
+	AppArmorNotAvailable:                "AppArmor support has been disabled",
+	MissingVirtiofsd:                    "Missing virtiofsd",
+	AppArmorDisabledDueToRawDnsmasq:     "Skipping AppArmor for dnsmasq due to raw.dnsmasq being set",
+	LargerIPv6PrefixThanSupported:       "IPv6 networks with a prefix larger than 64 aren't properly supported by dnsmasq",
+	ProxyBridgeNetfilterNotEnabled:      "Proxy bridge netfilter not enabled",
+	NetworkUnvailable:                   "Network unavailable",
+	OfflineClusterMember:                "Offline cluster member",
+	InstanceAutostartFailure:            "Failed to autostart instance",
+	InstanceTypeNotOperational:          "Instance type not operational",
+	StoragePoolUnvailable:               "Storage pool unavailable",
+	UnableToUpdateClusterCertificate:    "Unable to update cluster certificate",
+	StorageVolumeQuotaThresholdExceeded: "Storage volume nearing its quota",
+	InstanceImageOutdated:               "Instance image is outdated",
+	NetworkOVNRangesThresholdExceeded:   "Uplink network nearing exhaustion of its OVN ranges",
+	StoragePoolScrubErrors:              "Storage pool scrub found errors",
+	StoragePoolMaintenanceFailed:        "Storage pool maintenance failed",
+	StoragePoolUsageHigh:                "Storage pool running out of space",
+	StoragePoolUsageCritical:            "Storage pool about to run out of space",
 }
 
 // Severity returns the severity of the warning type.
@@ -137,6 +158,20 @@ func (t Type) Severity() Severity {
 		return SeverityHigh
 	case UnableToUpdateClusterCertificate:
 		return SeverityLow
+	case StorageVolumeQuotaThresholdExceeded:
+		return SeverityModerate
+	case InstanceImageOutdated:
+		return SeverityLow
+	case NetworkOVNRangesThresholdExceeded:
+		return SeverityModerate
+	case StoragePoolScrubErrors:
+		return SeverityHigh
+	case StoragePoolMaintenanceFailed:
+		return SeverityModerate
+	case StoragePoolUsageHigh:
+		return SeverityModerate
+	case StoragePoolUsageCritical:
+		return SeverityHigh
 	}
 
 	return SeverityLow
@@ -86,3 +86,13 @@ type device interface {
 type NICState interface {
 	State() (*api.InstanceStateNetwork, error)
 }
+
+// NICOVNState provides the ability to access the state of the OVN logical switch port backing a NIC.
+type NICOVNState interface {
+	OVNState() (*api.InstanceStateNetworkOVN, error)
+}
+
+// NICHostRoutes provides the ability to list the routes to a NIC installed on the host.
+type NICHostRoutes interface {
+	HostRoutes() ([]string, error)
+}
@@ -81,6 +81,8 @@ func newByType(state *state.State, projectName string, conf deviceConfig.Device)
 		dev = &tpm{}
 	case "pci":
 		dev = &pci{}
+	case "watchdog":
+		dev = &watchdog{}
 	}
 
 	// Check a valid device type has been found.
@@ -2,6 +2,7 @@ package device
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -200,6 +201,61 @@ func diskCephRbdMap(clusterName string, userName string, poolName string, volume
 	return strings.TrimSpace(devPath), nil
 }
 
+// diskCephRbdQoSSettings maps the Ceph QoS options of disk devices to the librbd settings they control.
+var diskCephRbdQoSSettings = map[string]string{
+	"ceph.qos.iops_limit": "rbd_qos_iops_limit",
+	"ceph.qos.iops_burst": "rbd_qos_iops_burst",
+	"ceph.qos.bps_limit":  "rbd_qos_bps_limit",
+	"ceph.qos.bps_burst":  "rbd_qos_bps_burst",
+}
+
+// diskCephRbdQoSApply updates the image level librbd settings of the RBD image to match the given values, removing
+// the settings with an empty value.
+func diskCephRbdQoSApply(clusterName string, userName string, poolName string, imageName string, settings map[string]string) error {
+	rbdArgs := []string{"--id", userName, "--cluster", clusterName, "--pool", poolName}
+
+	out, err := subprocess.RunCommand("rbd", append(rbdArgs, "config", "image", "list", "--format", "json", imageName)...)
+	if err != nil {
+		return err
+	}
+
+	var config []struct {
+		Name   string `json:"name"`
+		Value  string `json:"value"`
+		Source string `json:"source"`
+	}
+
+	err = json.Unmarshal([]byte(out), &config)
+	if err != nil {
+		return fmt.Errorf("Failed parsing RBD image config: %w", err)
+	}
+
+	current := map[string]string{}
+	for _, entry := range config {
+		if entry.Source == "image" {
+			current[entry.Name] = entry.Value
+		}
+	}
+
+	for setting, value := range settings {
+		if value == current[setting] {
+			continue
+		}
+
+		if value == "" {
+			_, err = subprocess.RunCommand("rbd", append(rbdArgs, "config", "image", "remove", imageName, setting)...)
+		} else {
+			_, err = subprocess.RunCommand("rbd", append(rbdArgs, "config", "image", "set", imageName, setting, value)...)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func diskCephRbdUnmap(deviceName string) error {
 	unmapImageName := deviceName
 	busyCount := 0
@@ -421,12 +477,24 @@ func DiskVMVirtfsProxyStop(pidPath string) error {
 	return nil
 }
 
+// DiskVirtiofsdOptions represents the tuning options of a virtiofsd process.
+type DiskVirtiofsdOptions struct {
+	// Cache is the disk device cache mode (none, metadata or unsafe).
+	Cache string
+
+	// Writeback enables writeback caching in the guest.
+	Writeback bool
+
+	// Threads is the size of the thread pool (0 uses the virtiofsd default).
+	Threads int
+}
+
 // DiskVMVirtiofsdStart starts a new virtiofsd process.
 // If the idmaps slice is supplied then the proxy process is run inside a user namespace using the supplied maps.
 // Returns UnsupportedError error if the host system or instance does not support virtiosfd, returns normal error
 // type if process cannot be started for other reasons.
 // Returns revert function and listener file handle on success.
-func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath string, pidPath string, logPath string, sharePath string, idmaps []idmap.Entry, cacheOption string) (func(), net.Listener, error) {
+func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath string, pidPath string, logPath string, sharePath string, idmaps []idmap.Entry, opts DiskVirtiofsdOptions) (func(), net.Listener, error) {
 	revert := revert.New()
 	defer revert.Fail()
 
@@ -467,6 +535,10 @@ func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath st
 		return nil, nil, UnsupportedError{"SEV unsupported"}
 	}
 
+	if util.IsTrue(inst.ExpandedConfig()["security.tdx"]) {
+		return nil, nil, UnsupportedError{"TDX unsupported"}
+	}
+
 	// Trickery to handle paths > 107 chars.
 	socketFileDir, err := os.Open(filepath.Dir(socketPath))
 	if err != nil {
@@ -499,7 +571,8 @@ func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath st
 
 	defer func() { _ = unixFile.Close() }()
 
-	switch cacheOption {
+	var cacheOption string
+	switch opts.Cache {
 	case "metadata":
 		cacheOption = "metadata"
 	case "unsafe":
@@ -510,6 +583,15 @@ func DiskVMVirtiofsdStart(execPath string, inst instance.Instance, socketPath st
 
 	// Start the virtiofsd process in non-daemon mode.
 	args := []string{"--fd=3", fmt.Sprintf("--cache=%s", cacheOption), "-o", fmt.Sprintf("source=%s", sharePath)}
+
+	if opts.Writeback {
+		args = append(args, "--writeback")
+	}
+
+	if opts.Threads > 0 {
+		args = append(args, fmt.Sprintf("--thread-pool-size=%d", opts.Threads))
+	}
+
 	proc, err := subprocess.NewProcess(cmd, args, logPath, logPath)
 	if err != nil {
 		return nil, nil, err
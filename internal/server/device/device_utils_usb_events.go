@@ -9,6 +9,7 @@ import (
 
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/lifecycle"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/logger"
 )
@@ -93,6 +94,26 @@ func USBRunHandlers(state *state.State, event *USBEvent) {
 				logger.Error("USB event instance handler failed", logger.Ctx{"err": err, "project": projectName, "instance": instanceName, "device": deviceName})
 				continue
 			}
+
+			if !instance.IsRunning() {
+				continue
+			}
+
+			// Let clients know about the USB device being plugged or unplugged.
+			action := lifecycle.InstanceDeviceAttached
+			if event.Action == "remove" {
+				action = lifecycle.InstanceDeviceDetached
+			}
+
+			state.Events.SendLifecycle(projectName, action.Event(instance, map[string]any{
+				"device":    deviceName,
+				"type":      "usb",
+				"vendorid":  event.Vendor,
+				"productid": event.Product,
+				"serial":    event.Serial,
+				"busnum":    event.BusNum,
+				"devnum":    event.DevNum,
+			}))
 		}
 	}
 }
@@ -50,6 +50,14 @@ const diskSourceAgent = "agent:config"
 // the QEMU driver.
 const DiskVirtiofsdSockMountOpt = "virtiofsdSock"
 
+// DiskVirtiofsQueuesMountOpt indicates the mount option prefix used to provide the number of virtio-fs
+// request queues to the qemu driver.
+const DiskVirtiofsQueuesMountOpt = "virtiofsQueues"
+
+// DiskVirtiofsQueueSizeMountOpt indicates the mount option prefix used to provide the virtio-fs request
+// queue size to the qemu driver.
+const DiskVirtiofsQueueSizeMountOpt = "virtiofsQueueSize"
+
 // DiskFileDescriptorMountPrefix indicates the mount dev path is using a file descriptor rather than a normal path.
 // The Mount.DevPath field will be expected to be in the format: "fd:<fdNum>:<devPath>".
 // It still includes the original dev path so that the instance driver can perform additional probing of the path
@@ -121,7 +129,12 @@ func (d *disk) sourceIsCeph() bool {
 
 // CanHotPlug returns whether the device can be managed whilst the instance is running.
 func (d *disk) CanHotPlug() bool {
-	// All disks can be hot-plugged.
+	// Namespaces can't be hot-plugged into a shared NVMe controller.
+	if d.config["io.nvme.controller"] != "" {
+		return false
+	}
+
+	// All other disks can be hot-plugged.
 	return true
 }
 
@@ -175,6 +188,20 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		return nil
 	}
 
+	// QEMU requires virtio queue sizes to be a power of two.
+	validateQueueSize := func(input string) error {
+		size, err := strconv.ParseUint(input, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Invalid queue size %q: %w", input, err)
+		}
+
+		if size == 0 || size&(size-1) != 0 {
+			return fmt.Errorf("Queue size must be a power of two")
+		}
+
+		return nil
+	}
+
 	rules := map[string]func(string) error{
 		// gendoc:generate(entity=devices, group=disk, key=required)
 		//
@@ -303,6 +330,38 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: The user name of the Ceph cluster (required for Ceph or CephFS sources)
 		"ceph.user_name": validate.IsAny,
 
+		// gendoc:generate(entity=devices, group=disk, key=ceph.qos.iops_limit)
+		//
+		// ---
+		//  type: integer
+		//  required: no
+		//  shortdesc: Ceph RBD QoS limit in IOPS (only for VM disks backed by a Ceph RBD source or a custom volume on a Ceph pool)
+		"ceph.qos.iops_limit": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=devices, group=disk, key=ceph.qos.iops_burst)
+		//
+		// ---
+		//  type: integer
+		//  required: no
+		//  shortdesc: Ceph RBD QoS burst in IOPS allowed above `ceph.qos.iops_limit`
+		"ceph.qos.iops_burst": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=devices, group=disk, key=ceph.qos.bps_limit)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: Ceph RBD QoS limit in byte/s (various suffixes supported, see {ref}`instances-limit-units`, only for VM disks backed by a Ceph RBD source or a custom volume on a Ceph pool)
+		"ceph.qos.bps_limit": validate.Optional(validate.IsSize),
+
+		// gendoc:generate(entity=devices, group=disk, key=ceph.qos.bps_burst)
+		//
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: Ceph RBD QoS burst in byte/s allowed above `ceph.qos.bps_limit`
+		"ceph.qos.bps_burst": validate.Optional(validate.IsSize),
+
 		// gendoc:generate(entity=devices, group=disk, key=boot.priority)
 		//
 		// ---
@@ -356,6 +415,51 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		//  required: no
 		//  shortdesc: Only for VMs: Override the bus for the device
 		"io.bus": validate.Optional(validate.IsOneOf("nvme", "virtio-blk", "virtio-scsi", "auto", "9p", "virtiofs")),
+
+		// gendoc:generate(entity=devices, group=disk, key=io.nvme.controller)
+		// All the disks of the instance using the same controller name are exposed to the guest as namespaces of a single NVMe controller, numbered in the order in which the disk devices are started.
+		// Such disks can't be hot-plugged.
+		// ---
+		//  type: string
+		//  required: no
+		//  shortdesc: Only for VMs: Name of a shared NVMe controller exposing the disk as one of its namespaces (requires `io.bus=nvme`)
+		"io.nvme.controller": validate.IsAny,
+
+		// gendoc:generate(entity=devices, group=disk, key=virtiofs.writeback)
+		// When enabled, `virtiofsd` lets the guest kernel cache writes and flush them back asynchronously.
+		// This significantly speeds up workloads doing many small writes but requires a cache mode other than `none`.
+		// ---
+		//  type: bool
+		//  default: `false`
+		//  required: no
+		//  shortdesc: Only for VMs: Enable writeback caching for `virtiofs` shares
+		"virtiofs.writeback": validate.Optional(validate.IsBool),
+
+		// gendoc:generate(entity=devices, group=disk, key=virtiofs.threads)
+		//
+		// ---
+		//  type: integer
+		//  required: no
+		//  shortdesc: Only for VMs: Size of the `virtiofsd` thread pool handling requests
+		"virtiofs.threads": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=devices, group=disk, key=virtiofs.queues)
+		//
+		// ---
+		//  type: integer
+		//  default: `1`
+		//  required: no
+		//  shortdesc: Only for VMs: Number of request queues exposed by the `virtiofs` device
+		"virtiofs.queues": validate.Optional(validate.IsUint32),
+
+		// gendoc:generate(entity=devices, group=disk, key=virtiofs.queue_size)
+		// The value must be a power of two.
+		// ---
+		//  type: integer
+		//  default: `1024`
+		//  required: no
+		//  shortdesc: Only for VMs: Size of each request queue of the `virtiofs` device
+		"virtiofs.queue_size": validate.Optional(validateQueueSize),
 	}
 
 	err := d.config.Validate(rules)
@@ -371,6 +475,28 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		return fmt.Errorf("IO cache configuration cannot be applied to containers")
 	}
 
+	for _, key := range []string{"virtiofs.writeback", "virtiofs.threads", "virtiofs.queues", "virtiofs.queue_size"} {
+		if d.config[key] == "" {
+			continue
+		}
+
+		if instConf.Type() == instancetype.Container {
+			return fmt.Errorf("Virtiofs configuration cannot be applied to containers")
+		}
+
+		if d.config["io.bus"] == "9p" {
+			return fmt.Errorf("Virtiofs configuration cannot be used with the 9p bus")
+		}
+	}
+
+	if util.IsTrue(d.config["virtiofs.writeback"]) && (d.config["io.cache"] == "" || d.config["io.cache"] == "none") {
+		return fmt.Errorf("Virtiofs writeback requires the metadata or unsafe cache mode")
+	}
+
+	if d.config["virtiofs.queues"] == "0" {
+		return fmt.Errorf("At least one virtiofs request queue is required")
+	}
+
 	if d.config["required"] != "" && d.config["optional"] != "" {
 		return fmt.Errorf(`Cannot use both "required" and deprecated "optional" properties at the same time`)
 	}
@@ -403,11 +529,26 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 		return fmt.Errorf("Recursive read-only bind-mounts aren't currently supported by the kernel")
 	}
 
+	if d.config["io.nvme.controller"] != "" && d.config["io.bus"] != "nvme" {
+		return fmt.Errorf("The io.nvme.controller option requires io.bus=nvme")
+	}
+
 	// Check ceph options are only used when ceph or cephfs type source is specified.
 	if !(d.sourceIsCeph() || d.sourceIsCephFs()) && (d.config["ceph.cluster_name"] != "" || d.config["ceph.user_name"] != "") {
 		return fmt.Errorf("Invalid options ceph.cluster_name/ceph.user_name for source %q", d.config["source"])
 	}
 
+	// Check Ceph QoS options are only used on disks attached through librbd.
+	if d.hasCephQoS() {
+		if instConf.Type() == instancetype.Container {
+			return fmt.Errorf("Ceph QoS options are only supported for virtual machines")
+		}
+
+		if d.config["path"] == "/" || !(d.sourceIsCeph() || d.config["pool"] != "") {
+			return fmt.Errorf("Ceph QoS options are only supported for Ceph RBD sources and custom volumes")
+		}
+	}
+
 	// Check no other devices also have the same path as us. Use LocalDevices for this check so
 	// that we can check before the config is expanded or when a profile is being checked.
 	// Don't take into account the device names, only count active devices that point to the
@@ -520,6 +661,10 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 				return fmt.Errorf("Pool %q is pending", d.config["pool"])
 			}
 
+			if d.hasCephQoS() && d.pool.Driver().Info().Name != "ceph" {
+				return fmt.Errorf("Ceph QoS options are only supported for custom volumes on Ceph pools")
+			}
+
 			// Custom volume validation.
 			if d.config["source"] != "" && d.config["path"] != "/" {
 				if storageProjectName == "" {
@@ -542,13 +687,20 @@ func (d *disk) validateConfig(instConf instance.ConfigReader) error {
 				}
 
 				// Check storage volume is available to mount on this cluster member.
-				remoteInstance, err := storagePools.VolumeUsedByExclusiveRemoteInstancesWithProfiles(d.state, d.config["pool"], storageProjectName, &dbVolume.StorageVolume)
-				if err != nil {
-					return fmt.Errorf("Failed checking if custom volume is exclusively attached to another instance: %w", err)
-				}
+				// Volumes shared through NFS can be used from any cluster member.
+				if storagePools.VolumeSharingNFSMember(dbVolume.Config) == "" {
+					remoteInstance, err := storagePools.VolumeUsedByExclusiveRemoteInstancesWithProfiles(d.state, d.config["pool"], storageProjectName, &dbVolume.StorageVolume)
+					if err != nil {
+						return fmt.Errorf("Failed checking if custom volume is exclusively attached to another instance: %w", err)
+					}
+
+					if remoteInstance != nil && remoteInstance.ID != instConf.ID() {
+						if dbVolume.ContentType == db.StoragePoolVolumeContentTypeNameFS && d.pool.Driver().Info().Name == "ceph" {
+							return fmt.Errorf("Custom volume is already attached to instance %q on cluster member %q (set \"sharing.mode=nfs\" on the volume to share it between cluster members)", remoteInstance.Name, remoteInstance.Node)
+						}
 
-				if remoteInstance != nil && remoteInstance.ID != instConf.ID() {
-					return fmt.Errorf("Custom volume is already attached to an instance on a different node")
+						return fmt.Errorf("Custom volume is already attached to an instance on a different node")
+					}
 				}
 
 				// Check that block volumes are *only* attached to VM instances.
@@ -1008,6 +1160,11 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 		opts = append(opts, fmt.Sprintf("bus=%s", d.config["io.bus"]))
 	}
 
+	// Allow the user to share an NVMe controller between disks.
+	if d.config["io.nvme.controller"] != "" {
+		opts = append(opts, fmt.Sprintf("nvme.controller=%s", d.config["io.nvme.controller"]))
+	}
+
 	// Allow the user to override the caching mode.
 	if d.config["io.cache"] != "" {
 		opts = append(opts, fmt.Sprintf("cache=%s", d.config["io.cache"]))
@@ -1113,6 +1270,12 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 			fields := strings.SplitN(d.config["source"], ":", 2)
 			fields = strings.SplitN(fields[1], "/", 2)
 			clusterName, userName := d.cephCreds()
+
+			err := d.applyCephQoS(clusterName, userName, fields[0], fields[1])
+			if err != nil {
+				return nil, err
+			}
+
 			runConf.Mounts = []deviceConfig.MountEntryItem{
 				{
 					DevPath: DiskGetRBDFormat(clusterName, userName, fields[0], fields[1]),
@@ -1177,6 +1340,18 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 						clusterName = storageDrivers.CephDefaultUser
 					}
 
+					rbdContentType := storageDrivers.ContentTypeBlock
+					if contentType == db.StoragePoolVolumeContentTypeISO {
+						rbdContentType = storageDrivers.ContentTypeISO
+					}
+
+					vol := storageDrivers.NewVolume(nil, "", storageDrivers.VolumeTypeCustom, rbdContentType, project.StorageVolume(storageProjectName, d.config["source"]), nil, nil)
+
+					err = d.applyCephQoS(clusterName, userName, poolName, storageDrivers.CephGetRBDImageName(vol, "", false))
+					if err != nil {
+						return nil, err
+					}
+
 					mount := deviceConfig.MountEntryItem{
 						DevPath: DiskGetRBDFormat(clusterName, userName, poolName, d.config["source"]),
 						DevName: d.name,
@@ -1272,7 +1447,19 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 					logPath := filepath.Join(d.inst.LogPath(), fmt.Sprintf("disk.%s.log", d.name))
 					_ = os.Remove(logPath) // Remove old log if needed.
 
-					revertFunc, unixListener, err := DiskVMVirtiofsdStart(d.state.OS.ExecPath, d.inst, sockPath, pidPath, logPath, mount.DevPath, rawIDMaps.Entries, d.config["io.cache"])
+					virtiofsdOpts := DiskVirtiofsdOptions{
+						Cache:     d.config["io.cache"],
+						Writeback: util.IsTrue(d.config["virtiofs.writeback"]),
+					}
+
+					if d.config["virtiofs.threads"] != "" {
+						virtiofsdOpts.Threads, err = strconv.Atoi(d.config["virtiofs.threads"])
+						if err != nil {
+							return err
+						}
+					}
+
+					revertFunc, unixListener, err := DiskVMVirtiofsdStart(d.state.OS.ExecPath, d.inst, sockPath, pidPath, logPath, mount.DevPath, rawIDMaps.Entries, virtiofsdOpts)
 					if err != nil {
 						if busOption == "virtiofs" {
 							return err
@@ -1314,6 +1501,15 @@ func (d *disk) startVM() (*deviceConfig.RunConfig, error) {
 					// QEMU driver also setup the virtio-fs share.
 					mount.Opts = append(mount.Opts, fmt.Sprintf("%s=%s", DiskVirtiofsdSockMountOpt, sockPath))
 
+					// Pass the virtio-fs device tuning to the QEMU driver the same way.
+					if d.config["virtiofs.queues"] != "" {
+						mount.Opts = append(mount.Opts, fmt.Sprintf("%s=%s", DiskVirtiofsQueuesMountOpt, d.config["virtiofs.queues"]))
+					}
+
+					if d.config["virtiofs.queue_size"] != "" {
+						mount.Opts = append(mount.Opts, fmt.Sprintf("%s=%s", DiskVirtiofsQueueSizeMountOpt, d.config["virtiofs.queue_size"]))
+					}
+
 					return nil
 				}()
 				if err != nil {
@@ -1707,13 +1903,6 @@ func (d *disk) mountPoolVolume() (func(), string, *storagePools.MountInfo, error
 	volStorageName := project.StorageVolume(storageProjectName, volumeName)
 	srcPath = storageDrivers.GetVolumeMountPath(d.config["pool"], storageDrivers.VolumeTypeCustom, volStorageName)
 
-	mountInfo, err = d.pool.MountCustomVolume(storageProjectName, volumeName, nil)
-	if err != nil {
-		return nil, "", nil, fmt.Errorf("Failed mounting storage volume %q of type %q on storage pool %q: %w", volumeName, volumeTypeName, d.pool.Name(), err)
-	}
-
-	revert.Add(func() { _, _ = d.pool.UnmountCustomVolume(storageProjectName, volumeName, nil) })
-
 	var dbVolume *db.StorageVolume
 	err = d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
 		dbVolume, err = tx.GetStoragePoolVolume(ctx, d.pool.ID(), storageProjectName, db.StoragePoolVolumeTypeCustom, volumeName, true)
@@ -1723,6 +1912,28 @@ func (d *disk) mountPoolVolume() (func(), string, *storagePools.MountInfo, error
 		return nil, "", nil, fmt.Errorf("Failed to fetch local storage volume record: %w", err)
 	}
 
+	// Volumes shared through NFS are mounted from the cluster member exporting them.
+	nfsMember := storagePools.VolumeSharingNFSMember(dbVolume.Config)
+	if nfsMember != "" && nfsMember != d.state.ServerName {
+		_, err = storagePools.VolumeSharingNFSMount(context.TODO(), d.state, d.pool.Name(), storageProjectName, volumeName, nfsMember)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		revert.Add(func() {
+			_ = storagePools.VolumeSharingNFSUnmount(context.TODO(), d.pool.Name(), storageProjectName, volumeName)
+		})
+
+		mountInfo = &storagePools.MountInfo{}
+	} else {
+		mountInfo, err = d.pool.MountCustomVolume(storageProjectName, volumeName, nil)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("Failed mounting storage volume %q of type %q on storage pool %q: %w", volumeName, volumeTypeName, d.pool.Name(), err)
+		}
+
+		revert.Add(func() { _, _ = d.pool.UnmountCustomVolume(storageProjectName, volumeName, nil) })
+	}
+
 	if d.inst.Type() == instancetype.Container {
 		if dbVolume.ContentType == db.StoragePoolVolumeContentTypeNameFS {
 			err = d.storagePoolVolumeAttachShift(storageProjectName, d.pool.Name(), volumeName, db.StoragePoolVolumeTypeCustom, srcPath)
@@ -2162,10 +2373,24 @@ func (d *disk) postStop() error {
 			return err
 		}
 
-		_, err = d.pool.UnmountCustomVolume(storageProjectName, d.config["source"], nil)
-		if err != nil && !errors.Is(err, storageDrivers.ErrInUse) {
+		dbVolume, err := storagePools.VolumeDBGet(d.pool, storageProjectName, d.config["source"], storageDrivers.VolumeTypeCustom)
+		if err != nil {
 			return err
 		}
+
+		// Volumes shared through NFS are mounted from the cluster member exporting them.
+		nfsMember := storagePools.VolumeSharingNFSMember(dbVolume.Config)
+		if nfsMember != "" && nfsMember != d.state.ServerName {
+			err = storagePools.VolumeSharingNFSUnmount(context.TODO(), d.pool.Name(), storageProjectName, d.config["source"])
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err = d.pool.UnmountCustomVolume(storageProjectName, d.config["source"], nil)
+			if err != nil && !errors.Is(err, storageDrivers.ErrInUse) {
+				return err
+			}
+		}
 	}
 
 	if d.sourceIsCeph() {
@@ -2559,25 +2784,41 @@ func (d *disk) generateVMAgentDrive() (string, error) {
 		return "", err
 	}
 
-	// Include the most likely agent.
+	// Include the most likely agent as well as the agents for the other supported guest operating systems.
 	if util.PathExists(os.Getenv("INCUS_AGENT_PATH")) {
-		agentInstallPath := filepath.Join(scratchDir, "incus-agent")
+		installAgent := func(srcName string, dstName string) error {
+			agentInstallPath := filepath.Join(scratchDir, dstName)
 
-		os.Remove(agentInstallPath)
+			os.Remove(agentInstallPath)
 
-		err = internalUtil.FileCopy(filepath.Join(os.Getenv("INCUS_AGENT_PATH"), fmt.Sprintf("incus-agent.linux.%s", d.state.OS.Uname.Machine)), agentInstallPath)
-		if err != nil {
-			return "", err
+			err := internalUtil.FileCopy(filepath.Join(os.Getenv("INCUS_AGENT_PATH"), srcName), agentInstallPath)
+			if err != nil {
+				return err
+			}
+
+			err = os.Chmod(agentInstallPath, 0500)
+			if err != nil {
+				return err
+			}
+
+			return os.Chown(agentInstallPath, 0, 0)
 		}
 
-		err = os.Chmod(agentInstallPath, 0500)
+		err = installAgent(fmt.Sprintf("incus-agent.linux.%s", d.state.OS.Uname.Machine), "incus-agent")
 		if err != nil {
 			return "", err
 		}
 
-		err = os.Chown(agentInstallPath, 0, 0)
-		if err != nil {
-			return "", err
+		for _, osName := range []string{"freebsd", "openbsd"} {
+			agentName := fmt.Sprintf("incus-agent.%s.%s", osName, d.state.OS.Uname.Machine)
+			if !util.PathExists(filepath.Join(os.Getenv("INCUS_AGENT_PATH"), agentName)) {
+				continue
+			}
+
+			err = installAgent(agentName, fmt.Sprintf("incus-agent.%s", osName))
+			if err != nil {
+				return "", err
+			}
 		}
 	}
 
@@ -2610,7 +2851,10 @@ func (d *disk) generateVMConfigDrive() (string, error) {
 		return "", err
 	}
 
-	instanceConfig := d.inst.ExpandedConfig()
+	instanceConfig, err := instance.CloudInitConfig(d.inst)
+	if err != nil {
+		return "", err
+	}
 
 	// Use an empty vendor-data file if no custom vendor-data supplied.
 	vendorData, ok := instanceConfig["cloud-init.vendor-data"]
@@ -2677,6 +2921,43 @@ local-hostname: %s
 	return isoPath, nil
 }
 
+// hasCephQoS returns true if any of the Ceph QoS options is set.
+func (d *disk) hasCephQoS() bool {
+	for key := range diskCephRbdQoSSettings {
+		if d.config[key] != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyCephQoS records the Ceph QoS options in the config of the RBD image, where librbd picks them up when QEMU
+// opens it. The settings of options which are no longer set are removed from the image.
+func (d *disk) applyCephQoS(clusterName string, userName string, poolName string, imageName string) error {
+	settings := make(map[string]string, len(diskCephRbdQoSSettings))
+	for key, setting := range diskCephRbdQoSSettings {
+		value := d.config[key]
+		if value != "" && strings.HasPrefix(key, "ceph.qos.bps_") {
+			bytes, err := units.ParseByteSizeString(value)
+			if err != nil {
+				return fmt.Errorf("Invalid value %q for %q: %w", value, key, err)
+			}
+
+			value = strconv.FormatInt(bytes, 10)
+		}
+
+		settings[setting] = value
+	}
+
+	err := diskCephRbdQoSApply(clusterName, userName, poolName, imageName, settings)
+	if err != nil {
+		return fmt.Errorf("Failed applying Ceph QoS settings to RBD image %q: %w", imageName, err)
+	}
+
+	return nil
+}
+
 // cephCreds returns cluster name and user name to use for ceph disks.
 func (d *disk) cephCreds() (string, string) {
 	// Apply the ceph configuration.
@@ -75,6 +75,11 @@ func (d *gpuPhysical) validateConfig(instConf instance.ConfigReader) error {
 	return nil
 }
 
+// CanHotPlug returns whether the device can be managed whilst the instance is running. Returns true.
+func (d *gpuPhysical) CanHotPlug() bool {
+	return true
+}
+
 // validateEnvironment checks the runtime environment for correctness.
 func (d *gpuPhysical) validateEnvironment() error {
 	if d.inst.Type() == instancetype.VM && util.IsTrue(d.inst.ExpandedConfig()["migration.stateful"]) {
@@ -281,6 +286,12 @@ func (d *gpuPhysical) startVM() (*deviceConfig.RunConfig, error) {
 			{Key: "pciSlotName", Value: saveData["last_state.pci.slot.name"]},
 		}...)
 
+	// Provide the IOMMU group so the device can be attached to a running VM.
+	pciIOMMUGroup, err := pcidev.DeviceIOMMUGroup(pciDev.SlotName)
+	if err == nil {
+		runConf.GPUDevice = append(runConf.GPUDevice, deviceConfig.RunConfigItem{Key: "pciIOMMUGroup", Value: fmt.Sprintf("%d", pciIOMMUGroup)})
+	}
+
 	err = d.volatileSet(saveData)
 	if err != nil {
 		return nil, err
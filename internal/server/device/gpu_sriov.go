@@ -63,6 +63,11 @@ func (d *gpuSRIOV) validateConfig(instConf instance.ConfigReader) error {
 	return nil
 }
 
+// CanHotPlug returns whether the device can be managed whilst the instance is running. Returns true.
+func (d *gpuSRIOV) CanHotPlug() bool {
+	return true
+}
+
 // validateEnvironment checks the runtime environment for correctness.
 func (d *gpuSRIOV) validateEnvironment() error {
 	if d.inst.Type() == instancetype.VM && util.IsTrue(d.inst.ExpandedConfig()["migration.stateful"]) {
@@ -113,6 +118,12 @@ func (d *gpuSRIOV) Start() (*deviceConfig.RunConfig, error) {
 		{Key: "pciSlotName", Value: vfPCIDev.SlotName},
 	}...)
 
+	// Provide the IOMMU group so the device can be attached to a running VM.
+	pciIOMMUGroup, err := pcidev.DeviceIOMMUGroup(vfPCIDev.SlotName)
+	if err == nil {
+		runConf.GPUDevice = append(runConf.GPUDevice, deviceConfig.RunConfigItem{Key: "pciIOMMUGroup", Value: fmt.Sprintf("%d", pciIOMMUGroup)})
+	}
+
 	return &runConf, nil
 }
 
@@ -98,8 +98,8 @@ func (d *nicBridged) validateConfig(instConf instance.ConfigReader) error {
 			return fmt.Errorf("Specified network is not fully created")
 		}
 
-		if n.Type() != "bridge" {
-			return fmt.Errorf("Specified network must be of type bridge")
+		if n.Type() != "bridge" && n.Type() != "mesh" {
+			return fmt.Errorf("Specified network must be of type bridge or mesh")
 		}
 
 		netConfig := n.Config()
@@ -368,6 +368,35 @@ func (d *nicBridged) checkAddressConflict() error {
 		networkName = d.network.Name()
 	}
 
+	// Check the addresses aren't reserved for another device.
+	if d.network != nil && d.network.Info().DHCPReservations {
+		var reservations []api.NetworkDHCPReservation
+
+		err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			reservations, err = tx.GetNetworkDHCPReservations(ctx, d.network.ID())
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading network DHCP reservations: %w", err)
+		}
+
+		for _, reservation := range reservations {
+			reservedMAC, _ := net.ParseMAC(reservation.Hwaddr)
+			if ourNICMAC != nil && bytes.Equal(ourNICMAC, reservedMAC) {
+				return api.StatusErrorf(http.StatusConflict, "MAC address %q already used by a DHCP reservation", reservation.Hwaddr)
+			}
+
+			for key, reservedAddress := range map[string]string{"ipv4.address": reservation.IPv4Address, "ipv6.address": reservation.IPv6Address} {
+				if ourNICIPs[key] != nil && ourNICIPs[key].Equal(net.ParseIP(reservedAddress)) {
+					return api.StatusErrorf(http.StatusConflict, "IP address %q already reserved for %q", reservedAddress, reservation.Hwaddr)
+				}
+			}
+		}
+	}
+
 	// Bridge networks are always in the default project.
 	return network.UsedByInstanceDevices(d.state, api.ProjectDefaultName, networkName, "bridge", func(inst db.InstanceArgs, nicName string, nicConfig map[string]string) error {
 		// Skip our own device. This avoids triggering duplicate device errors during
@@ -531,6 +560,14 @@ func (d *nicBridged) Start() (*deviceConfig.RunConfig, error) {
 				return nil, fmt.Errorf("Failed creating DHCP static allocation: %w", err)
 			}
 		}
+
+		// Allocate the addresses from the network's external IPAM (if configured).
+		if d.network.Config()["ipam.driver"] != "" {
+			err = d.allocateIPAM()
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Apply host-side routes to bridge interface.
@@ -864,8 +901,15 @@ func (d *nicBridged) Remove() error {
 			}
 		}
 
+		// Release the addresses allocated from the network's external IPAM (if configured).
+		// Failing to do so mustn't prevent the device from being removed.
+		err := d.releaseIPAM()
+		if err != nil {
+			d.logger.Warn("Failed releasing IPAM addresses", logger.Ctx{"err": err})
+		}
+
 		// Remove dnsmasq config if it exists (doesn't return error if file is missing).
-		err := dnsmasq.RemoveStaticEntry(d.config["parent"], d.inst.Project().Name, d.inst.Name(), d.Name())
+		err = dnsmasq.RemoveStaticEntry(d.config["parent"], d.inst.Project().Name, d.inst.Name(), d.Name())
 		if err != nil {
 			return err
 		}
@@ -903,9 +947,10 @@ func (d *nicBridged) rebuildDnsmasqEntry() error {
 		ipv6Address = ""
 	}
 
-	// If IP filtering is enabled, and no static IP in config, check if there is already a
+	// If IP filtering or an external IPAM is enabled, and no static IP in config, check if there is already a
 	// dynamically assigned static IP in dnsmasq config and write that back out in new config.
-	if (util.IsTrue(d.config["security.ipv4_filtering"]) && ipv4Address == "") || (util.IsTrue(d.config["security.ipv6_filtering"]) && ipv6Address == "") {
+	usesIPAM := d.network.Config()["ipam.driver"] != ""
+	if ((util.IsTrue(d.config["security.ipv4_filtering"]) || usesIPAM) && ipv4Address == "") || ((util.IsTrue(d.config["security.ipv6_filtering"]) || usesIPAM) && ipv6Address == "") {
 		deviceStaticFileName := dnsmasq.StaticAllocationFileName(d.inst.Project().Name, d.inst.Name(), d.Name())
 		_, curIPv4, curIPv6, err := dnsmasq.DHCPStaticAllocation(d.config["parent"], deviceStaticFileName)
 		if err != nil && !os.IsNotExist(err) {
@@ -935,6 +980,149 @@ func (d *nicBridged) rebuildDnsmasqEntry() error {
 	return nil
 }
 
+// allocateIPAM allocates the addresses of the device from the network's external IPAM.
+// Only addresses which aren't statically configured and would otherwise be handed out by DHCP (or are needed for
+// IP filtering) are allocated. The IPAM is contacted before taking the allocation lock so that a slow IPAM doesn't
+// hold up the allocations of other devices, and the IDs of the allocations are recorded so that they can be found
+// again after the instance or the network is renamed.
+func (d *nicBridged) allocateIPAM() error {
+	mac, err := net.ParseMAC(d.config["hwaddr"])
+	if err != nil {
+		return fmt.Errorf("Invalid hwaddr: %w", err)
+	}
+
+	opts := &dhcpalloc.Options{
+		ProjectName: d.inst.Project().Name,
+		HostName:    d.inst.Name(),
+		DeviceName:  d.Name(),
+		HostMAC:     mac,
+		Network:     d.network,
+	}
+
+	deviceStaticFileName := dnsmasq.StaticAllocationFileName(d.inst.Project().Name, d.inst.Name(), d.Name())
+	_, curIPv4, curIPv6, err := dnsmasq.DHCPStaticAllocation(d.config["parent"], deviceStaticFileName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	v := d.volatileGet()
+	saveData := map[string]string{}
+
+	allocate := func(subnet *net.IPNet, current net.IP, key string) (net.IP, error) {
+		if subnet == nil || (current != nil && subnet.Contains(current)) {
+			return nil, nil // DHCP not available or address already allocated.
+		}
+
+		allocation, err := dhcpalloc.AllocateIPAM(opts, subnet, v[key])
+		if err != nil {
+			return nil, err
+		}
+
+		revert.Add(func() { _ = dhcpalloc.ReleaseIPAM(opts, allocation.IP, allocation.ID) })
+		saveData[key] = allocation.ID
+
+		return allocation.IP, nil
+	}
+
+	var IPv4, IPv6 net.IP
+
+	if d.config["ipv4.address"] == "" {
+		IPv4, err = allocate(opts.Network.DHCPv4Subnet(), curIPv4.IP, "ipam.ipv4.id")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Addresses are only handed out by DHCPv6 in stateful mode.
+	if d.config["ipv6.address"] == "" && (util.IsTrue(d.network.Config()["ipv6.dhcp.stateful"]) || util.IsTrue(d.config["security.ipv6_filtering"])) {
+		IPv6, err = allocate(opts.Network.DHCPv6Subnet(), curIPv6.IP, "ipam.ipv6.id")
+		if err != nil {
+			return err
+		}
+	}
+
+	if IPv4 == nil && IPv6 == nil {
+		return nil
+	}
+
+	err = dhcpalloc.AllocateTask(opts, func(t *dhcpalloc.Transaction) error {
+		if IPv4 != nil {
+			err := t.SetIPv4(IPv4)
+			if err != nil {
+				return err
+			}
+		}
+
+		if IPv6 != nil {
+			err := t.SetIPv6(IPv6)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed recording IPAM addresses: %w", err)
+	}
+
+	err = d.volatileSet(saveData)
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+
+	return nil
+}
+
+// releaseIPAM releases the dynamically allocated addresses of the device from the network's external IPAM.
+func (d *nicBridged) releaseIPAM() error {
+	if d.network == nil || d.network.Config()["ipam.driver"] == "" {
+		return nil
+	}
+
+	deviceStaticFileName := dnsmasq.StaticAllocationFileName(d.inst.Project().Name, d.inst.Name(), d.Name())
+	mac, IPv4Alloc, IPv6Alloc, err := dnsmasq.DHCPStaticAllocation(d.config["parent"], deviceStaticFileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	opts := &dhcpalloc.Options{
+		ProjectName: d.inst.Project().Name,
+		HostName:    d.inst.Name(),
+		DeviceName:  d.Name(),
+		HostMAC:     mac,
+		Network:     d.network,
+	}
+
+	v := d.volatileGet()
+
+	// Statically configured addresses aren't managed by the IPAM.
+	if IPv4Alloc.IP != nil && IPv4Alloc.IP.String() != d.config["ipv4.address"] {
+		err = dhcpalloc.ReleaseIPAM(opts, IPv4Alloc.IP, v["ipam.ipv4.id"])
+		if err != nil {
+			return err
+		}
+	}
+
+	if IPv6Alloc.IP != nil && IPv6Alloc.IP.String() != d.config["ipv6.address"] {
+		err = dhcpalloc.ReleaseIPAM(opts, IPv6Alloc.IP, v["ipam.ipv6.id"])
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.volatileSet(map[string]string{"ipam.ipv4.id": "", "ipam.ipv6.id": ""})
+}
+
 // setupHostFilters applies any host side network filters.
 // Returns a revert fail function that can be used to undo this function if a subsequent step fails.
 func (d *nicBridged) setupHostFilters(oldConfig deviceConfig.Device) (revert.Hook, error) {
@@ -45,6 +45,7 @@ type ovnNet interface {
 	InstanceDevicePortStop(ovsExternalOVNPort ovn.OVNSwitchPort, opts *network.OVNInstanceNICStopOpts) error
 	InstanceDevicePortRemove(instanceUUID string, deviceName string, deviceConfig deviceConfig.Device) error
 	InstanceDevicePortIPs(instanceUUID string, deviceName string) ([]net.IP, error)
+	InstanceDevicePortState(instanceUUID string, deviceName string) (*api.InstanceStateNetworkOVN, error)
 }
 
 type nicOVN struct {
@@ -1136,6 +1137,11 @@ func (d *nicOVN) State() (*api.InstanceStateNetwork, error) {
 	return &network, nil
 }
 
+// OVNState returns the state of the OVN logical switch port backing the NIC.
+func (d *nicOVN) OVNState() (*api.InstanceStateNetworkOVN, error) {
+	return d.network.InstanceDevicePortState(d.inst.LocalConfig()["volatile.uuid"], d.name)
+}
+
 // Register sets up anything needed on startup.
 func (d *nicOVN) Register() error {
 	err := bgpAddPrefix(&d.deviceCommon, d.network, d.config)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/network"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
 	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
@@ -38,12 +40,23 @@ func (d *nicRouted) CanHotPlug() bool {
 // UpdatableFields returns a list of fields that can be updated without triggering a device remove & add.
 func (d *nicRouted) UpdatableFields(oldDevice Type) []string {
 	// Check old and new device types match.
-	_, match := oldDevice.(*nicRouted)
+	oldRouted, match := oldDevice.(*nicRouted)
 	if !match {
 		return []string{}
 	}
 
-	return []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority"}
+	fields := []string{"limits.ingress", "limits.egress", "limits.max", "limits.priority"}
+
+	// Addresses and routes can be changed live as long as the IP families in use stay the same, as those
+	// decide the host side gateway addresses and the default gateways of the instance.
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		addressKey := fmt.Sprintf("%s.address", keyPrefix)
+		if (oldRouted.config[addressKey] == "") == (d.config[addressKey] == "") {
+			fields = append(fields, addressKey, fmt.Sprintf("%s.routes", keyPrefix))
+		}
+	}
+
+	return fields
 }
 
 // validateConfig checks the supplied config for correctness.
@@ -161,9 +174,10 @@ func (d *nicRouted) validateEnvironment() error {
 				return fmt.Errorf("Routed mode requires sysctl net.ipv6.conf.%s.forwarding=1", "all")
 			}
 
-			// net.ipv6.conf.all.proxy_ndp=1 is needed otherwise unicast neighbour solicitations are .
-			// rejected This causes periodic latency spikes every 15-20s as the neighbour has to resort
+			// net.ipv6.conf.all.proxy_ndp=1 is needed otherwise unicast neighbour solicitations are
+			// rejected. This causes periodic latency spikes every 15-20s as the neighbour has to resort
 			// to using multicast NDP resolution and expires the previous neighbour entry.
+			// It is enabled automatically as the neighbour proxy entries are useless without it.
 			ipv6ProxyNdpPath := fmt.Sprintf("net/ipv6/conf/%s/proxy_ndp", "all")
 			sysctlVal, err = localUtil.SysctlGet(ipv6ProxyNdpPath)
 			if err != nil {
@@ -171,7 +185,10 @@ func (d *nicRouted) validateEnvironment() error {
 			}
 
 			if sysctlVal != "1\n" {
-				return fmt.Errorf("Routed mode requires sysctl net.ipv6.conf.%s.proxy_ndp=1", "all")
+				err = d.enableProxyNdp("all")
+				if err != nil {
+					return err
+				}
 			}
 		}
 
@@ -209,8 +226,10 @@ func (d *nicRouted) validateEnvironment() error {
 			}
 
 			if sysctlVal != "1\n" {
-				// Replace . in parent name with / for sysctl formatting.
-				return fmt.Errorf("Routed mode requires sysctl net.ipv6.conf.%s.proxy_ndp=1", strings.Replace(d.effectiveParentName, ".", "/", -1))
+				err = d.enableProxyNdp(d.effectiveParentName)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -218,20 +237,30 @@ func (d *nicRouted) validateEnvironment() error {
 	return nil
 }
 
-// checkIPAvailability checks using ARP and NDP neighbour probes whether any of the NIC's IPs are already in use.
-func (d *nicRouted) checkIPAvailability(parent string) error {
+// enableProxyNdp enables IPv6 neighbour proxying on the interface (or "all").
+func (d *nicRouted) enableProxyNdp(ifName string) error {
+	ipv6ProxyNdpPath := fmt.Sprintf("net/ipv6/conf/%s/proxy_ndp", ifName)
+	err := localUtil.SysctlSet(ipv6ProxyNdpPath, "1")
+	if err != nil {
+		return fmt.Errorf("Error setting net sysctl %s: %w", ipv6ProxyNdpPath, err)
+	}
+
+	d.logger.Info("Enabled IPv6 neighbour proxying", logger.Ctx{"interface": ifName})
+
+	return nil
+}
+
+// checkIPAvailability checks using ARP and NDP neighbour probes whether any of the IPs (keyed by IP family)
+// are already in use.
+func (d *nicRouted) checkIPAvailability(parent string, ips map[string][]string) error {
 	var addresses []net.IP
 
-	if util.IsTrueOrEmpty(d.config["ipv4.neighbor_probe"]) {
-		ipv4Addrs := util.SplitNTrimSpace(d.config["ipv4.address"], ",", -1, true)
-		for _, addr := range ipv4Addrs {
-			addresses = append(addresses, net.ParseIP(addr))
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		if util.IsFalse(d.config[fmt.Sprintf("%s.neighbor_probe", keyPrefix)]) {
+			continue
 		}
-	}
 
-	if util.IsTrueOrEmpty(d.config["ipv6.neighbor_probe"]) {
-		ipv6Addrs := util.SplitNTrimSpace(d.config["ipv6.address"], ",", -1, true)
-		for _, addr := range ipv6Addrs {
+		for _, addr := range ips[keyPrefix] {
 			addresses = append(addresses, net.ParseIP(addr))
 		}
 	}
@@ -304,7 +333,10 @@ func (d *nicRouted) Start() (*deviceConfig.RunConfig, error) {
 	}
 
 	if d.effectiveParentName != "" {
-		err := d.checkIPAvailability(d.effectiveParentName)
+		err := d.checkIPAvailability(d.effectiveParentName, map[string][]string{
+			"ipv4": util.SplitNTrimSpace(d.config["ipv4.address"], ",", -1, true),
+			"ipv6": util.SplitNTrimSpace(d.config["ipv6.address"], ",", -1, true),
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -563,11 +595,11 @@ func (d *nicRouted) setupParentSysctls(parentName string) error {
 	return nil
 }
 
-// Update returns an error as most devices do not support live updates without being restarted.
+// Update applies the host side limits and the address and route changes to a running instance.
 func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) error {
 	v := d.volatileGet()
 
-	// If instance is running, apply host side limits.
+	// If instance is running, apply host side limits and addresses.
 	if isRunning {
 		err := d.validateEnvironment()
 		if err != nil {
@@ -582,11 +614,266 @@ func (d *nicRouted) Update(oldDevices deviceConfig.Devices, isRunning bool) erro
 		if err != nil {
 			return err
 		}
+
+		err = d.updateAddresses(oldDevices[d.name])
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// updateAddresses adds and removes the host side routes and neighbour proxy entries (and for containers the
+// addresses inside the instance) of the addresses and routes which changed since the old config.
+func (d *nicRouted) updateAddresses(oldConfig deviceConfig.Device) error {
+	revert := revert.New()
+	defer revert.Fail()
+
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		subnetSize := 32
+		ipFamilyArg := ip.FamilyV4
+		if keyPrefix == "ipv6" {
+			subnetSize = 128
+			ipFamilyArg = ip.FamilyV6
+		}
+
+		addressKey := fmt.Sprintf("%s.address", keyPrefix)
+		routesKey := fmt.Sprintf("%s.routes", keyPrefix)
+		hostTable := d.config[fmt.Sprintf("%s.host_table", keyPrefix)]
+
+		oldAddresses := util.SplitNTrimSpace(oldConfig[addressKey], ",", -1, true)
+		newAddresses := util.SplitNTrimSpace(d.config[addressKey], ",", -1, true)
+		oldRoutes := util.SplitNTrimSpace(oldConfig[routesKey], ",", -1, true)
+		newRoutes := util.SplitNTrimSpace(d.config[routesKey], ",", -1, true)
+
+		if len(newRoutes) > 0 && len(newAddresses) == 0 {
+			return fmt.Errorf("%s requires %s to be set", routesKey, addressKey)
+		}
+
+		// The routes go via the first address so need replacing when it changes.
+		viaChanged := len(oldAddresses) > 0 && len(newAddresses) > 0 && oldAddresses[0] != newAddresses[0]
+
+		// Remove the routes before the addresses they go through.
+		for _, routeStr := range oldRoutes {
+			if !viaChanged && slices.Contains(newRoutes, routeStr) {
+				continue
+			}
+
+			r := ip.Route{
+				DevName: d.config["host_name"],
+				Route:   routeStr,
+				Table:   "main",
+				Family:  ipFamilyArg,
+			}
+
+			err := r.Delete()
+			if err != nil {
+				d.logger.Warn("Failed removing route", logger.Ctx{"route": routeStr, "err": err})
+			}
+		}
+
+		for _, addrStr := range oldAddresses {
+			if slices.Contains(newAddresses, addrStr) {
+				continue
+			}
+
+			if d.inst.Type() == instancetype.Container {
+				err := d.instanceAddress("delete", addrStr, subnetSize)
+				if err != nil {
+					return fmt.Errorf("Failed removing address %q from the instance: %w", addrStr, err)
+				}
+			}
+
+			if d.effectiveParentName != "" {
+				np := ip.NeighProxy{
+					DevName: d.effectiveParentName,
+					Addr:    net.ParseIP(addrStr),
+				}
+
+				_ = np.Delete()
+			}
+
+			for _, table := range []string{"main", hostTable} {
+				if table == "" {
+					continue
+				}
+
+				r := ip.Route{
+					DevName: d.config["host_name"],
+					Route:   fmt.Sprintf("%s/%d", addrStr, subnetSize),
+					Table:   table,
+					Family:  ipFamilyArg,
+				}
+
+				err := r.Delete()
+				if err != nil {
+					d.logger.Warn("Failed removing host route", logger.Ctx{"route": r.Route, "table": table, "err": err})
+				}
+			}
+		}
+
+		addedAddresses := []string{}
+		for _, addrStr := range newAddresses {
+			if !slices.Contains(oldAddresses, addrStr) {
+				addedAddresses = append(addedAddresses, addrStr)
+			}
+		}
+
+		if d.effectiveParentName != "" && len(addedAddresses) > 0 {
+			err := d.checkIPAvailability(d.effectiveParentName, map[string][]string{keyPrefix: addedAddresses})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, addrStr := range addedAddresses {
+			for _, table := range []string{"main", hostTable} {
+				if table == "" {
+					continue
+				}
+
+				r := ip.Route{
+					DevName: d.config["host_name"],
+					Route:   fmt.Sprintf("%s/%d", addrStr, subnetSize),
+					Table:   table,
+					Family:  ipFamilyArg,
+				}
+
+				err := r.Add()
+				if err != nil {
+					return fmt.Errorf("Failed adding host route %q to table %q: %w", r.Route, r.Table, err)
+				}
+
+				revert.Add(func() { _ = r.Delete() })
+			}
+
+			if d.effectiveParentName != "" {
+				np := ip.NeighProxy{
+					DevName: d.effectiveParentName,
+					Addr:    net.ParseIP(addrStr),
+				}
+
+				err := np.Add()
+				if err != nil {
+					return fmt.Errorf("Failed adding neighbour proxy %q to %q: %w", np.Addr.String(), np.DevName, err)
+				}
+
+				revert.Add(func() { _ = np.Delete() })
+			}
+
+			if d.inst.Type() == instancetype.Container {
+				err := d.instanceAddress("add", addrStr, subnetSize)
+				if err != nil {
+					return fmt.Errorf("Failed adding address %q to the instance: %w", addrStr, err)
+				}
+
+				revert.Add(func() { _ = d.instanceAddress("delete", addrStr, subnetSize) })
+			}
+		}
+
+		for _, routeStr := range newRoutes {
+			if !viaChanged && slices.Contains(oldRoutes, routeStr) {
+				continue
+			}
+
+			r := ip.Route{
+				DevName: d.config["host_name"],
+				Route:   routeStr,
+				Table:   "main",
+				Family:  ipFamilyArg,
+				Via:     newAddresses[0],
+			}
+
+			err := r.Add()
+			if err != nil {
+				return fmt.Errorf("Failed adding route %q: %w", r.Route, err)
+			}
+
+			revert.Add(func() { _ = r.Delete() })
+		}
+	}
+
+	revert.Success()
+	return nil
+}
+
+// instanceAddress adds or removes an address on the NIC inside the container's network namespace.
+func (d *nicRouted) instanceAddress(action string, addrStr string, subnetSize int) error {
+	pid := d.inst.InitPID()
+	if pid <= 0 {
+		return fmt.Errorf("Instance isn't running")
+	}
+
+	_, err := subprocess.RunCommand(
+		d.state.OS.ExecPath,
+		"forknet",
+		"address",
+		"--",
+		fmt.Sprintf("/proc/%d/ns/net", pid),
+		d.config["name"],
+		action,
+		fmt.Sprintf("%s/%d", addrStr, subnetSize),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HostRoutes returns the routes to the NIC installed on the host, including those in the custom host tables.
+func (d *nicRouted) HostRoutes() ([]string, error) {
+	hostName := d.volatileGet()["host_name"]
+	if hostName == "" {
+		return nil, nil
+	}
+
+	routes := []string{}
+	for _, keyPrefix := range []string{"ipv4", "ipv6"} {
+		if d.config[fmt.Sprintf("%s.address", keyPrefix)] == "" {
+			continue
+		}
+
+		ipFamilyArg := ip.FamilyV4
+		if keyPrefix == "ipv6" {
+			ipFamilyArg = ip.FamilyV6
+		}
+
+		for _, table := range []string{"main", d.config[fmt.Sprintf("%s.host_table", keyPrefix)]} {
+			if table == "" {
+				continue
+			}
+
+			r := ip.Route{
+				DevName: hostName,
+				Table:   table,
+				Family:  ipFamilyArg,
+			}
+
+			tableRoutes, err := r.Show()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, route := range tableRoutes {
+				// Skip the automatic link-local route of the host side interface.
+				if strings.HasPrefix(route, "fe80::/64") {
+					continue
+				}
+
+				if table != "main" {
+					route = fmt.Sprintf("%s table %s", route, table)
+				}
+
+				routes = append(routes, route)
+			}
+		}
+	}
+
+	return routes, nil
+}
+
 // Stop is run when the device is removed from the instance.
 func (d *nicRouted) Stop() (*deviceConfig.RunConfig, error) {
 	// Populate device config with volatile fields (hwaddr and host_name) if needed.
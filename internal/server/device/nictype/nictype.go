@@ -40,7 +40,7 @@ func NICType(s *state.State, deviceProjectName string, d deviceConfig.Device) (s
 
 			var nicType string
 			switch netInfo.Type {
-			case "bridge":
+			case "bridge", "mesh":
 				nicType = "bridged"
 			case "macvlan":
 				nicType = "macvlan"
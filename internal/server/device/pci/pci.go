@@ -199,3 +199,33 @@ func DeviceIOMMUGroup(slotName string) (uint64, error) {
 
 	return iommuGroup, nil
 }
+
+// DeviceRelatedFunctions returns the slot names of the other functions of a PCI device which share its IOMMU group.
+func DeviceRelatedFunctions(slotName string) ([]string, error) {
+	return iommuGroupRelatedFunctions(filepath.Join("/sys/bus/pci/devices", slotName, "iommu_group", "devices"), slotName)
+}
+
+// iommuGroupRelatedFunctions returns the entries of the IOMMU group devices directory which are other functions
+// of the same PCI device as the slot name.
+func iommuGroupRelatedFunctions(iommuGroupPath string, slotName string) ([]string, error) {
+	relatedSlotNames := []string{}
+	if !util.PathExists(iommuGroupPath) {
+		return relatedSlotNames, nil
+	}
+
+	// Strip the function number to get the address of the device.
+	prefix, _, _ := strings.Cut(slotName, ".")
+
+	entries, err := os.ReadDir(iommuGroupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix+".") && entry.Name() != slotName {
+			relatedSlotNames = append(relatedSlotNames, entry.Name())
+		}
+	}
+
+	return relatedSlotNames, nil
+}
@@ -0,0 +1,37 @@
+package pci
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIOMMUGroupRelatedFunctions(t *testing.T) {
+	iommuGroupPath := t.TempDir()
+
+	for _, slotName := range []string{"0000:01:00.0", "0000:01:00.1", "0000:01:00.2", "0000:02:00.0", "0000:01:001.0"} {
+		err := os.WriteFile(filepath.Join(iommuGroupPath, slotName), nil, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	related, err := iommuGroupRelatedFunctions(iommuGroupPath, "0000:01:00.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0000:01:00.1", "0000:01:00.2"}, related)
+
+	related, err = iommuGroupRelatedFunctions(iommuGroupPath, "0000:01:00.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0000:01:00.0", "0000:01:00.2"}, related)
+
+	related, err = iommuGroupRelatedFunctions(iommuGroupPath, "0000:02:00.0")
+	assert.NoError(t, err)
+	assert.Empty(t, related)
+
+	// Devices without an IOMMU group have no related functions.
+	related, err = iommuGroupRelatedFunctions(filepath.Join(iommuGroupPath, "missing"), "0000:01:00.0")
+	assert.NoError(t, err)
+	assert.Empty(t, related)
+}
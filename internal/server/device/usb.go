@@ -107,6 +107,14 @@ func (d *usb) validateConfig(instConf instance.ConfigReader) error {
 		//  shortdesc: Whether this device is required to start the instance (the default is `false`, and all devices can be hotplugged)
 		"required": validate.Optional(validate.IsBool),
 
+		// gendoc:generate(entity=devices, group=usb, key=hotplug)
+		//
+		// ---
+		//  type: bool
+		//  defaultdesc: `true`
+		//  shortdesc: Whether matching USB devices plugged or unplugged while the instance is running are attached or detached automatically
+		"hotplug": validate.Optional(validate.IsBool),
+
 		// gendoc:generate(entity=devices, group=usb, key=busnum)
 		//
 		// ---
@@ -130,8 +138,19 @@ func (d *usb) validateConfig(instConf instance.ConfigReader) error {
 	return nil
 }
 
+// isHotplug indicates whether matching USB devices are attached and detached while the instance is running.
+func (d *usb) isHotplug() bool {
+	// Defaults to hotplug.
+	return !util.IsFalse(d.config["hotplug"])
+}
+
 // Register is run after the device is started or on daemon startup.
 func (d *usb) Register() error {
+	// Only watch for USB events when hotplugging is enabled.
+	if !d.isHotplug() {
+		return nil
+	}
+
 	// Extract variables needed to run the event hook so that the reference to this device
 	// struct is not needed to be kept in memory.
 	devicesPath := d.inst.DevicesPath()
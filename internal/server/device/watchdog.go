@@ -0,0 +1,66 @@
+package device
+
+import (
+	"fmt"
+
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+type watchdog struct {
+	deviceCommon
+}
+
+// CanMigrate returns whether the device can be migrated to any other cluster member.
+func (d *watchdog) CanMigrate() bool {
+	return true
+}
+
+// validateConfig checks the supplied config for correctness.
+func (d *watchdog) validateConfig(instConf instance.ConfigReader) error {
+	if !instanceSupported(instConf.Type(), instancetype.VM) {
+		return ErrUnsupportedDevType
+	}
+
+	rules := map[string]func(string) error{
+		"model":  validate.Optional(validate.IsOneOf("i6300esb", "ib700")),
+		"action": validate.Optional(validate.IsOneOf("restart", "stop", "pause", "none")),
+	}
+
+	err := d.config.Validate(rules)
+	if err != nil {
+		return fmt.Errorf("Failed to validate config: %w", err)
+	}
+
+	for name, dev := range instConf.ExpandedDevices() {
+		if name != d.name && dev["type"] == "watchdog" {
+			return fmt.Errorf("Only one watchdog device can be added to an instance")
+		}
+	}
+
+	return nil
+}
+
+// Start is run when the device is added to the instance.
+func (d *watchdog) Start() (*deviceConfig.RunConfig, error) {
+	model := d.config["model"]
+	if model == "" {
+		model = "i6300esb"
+	}
+
+	runConf := deviceConfig.RunConfig{
+		WatchdogDevice: []deviceConfig.RunConfigItem{
+			{Key: "devName", Value: d.name},
+			{Key: "model", Value: model},
+		},
+	}
+
+	return &runConf, nil
+}
+
+// Stop is run when the device is removed from the instance.
+func (d *watchdog) Stop() (*deviceConfig.RunConfig, error) {
+	return &deviceConfig.RunConfig{}, nil
+}
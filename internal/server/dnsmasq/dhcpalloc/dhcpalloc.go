@@ -2,6 +2,7 @@ package dhcpalloc
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/lxc/incus/v6/internal/iprange"
 	"github.com/lxc/incus/v6/internal/server/dnsmasq"
+	"github.com/lxc/incus/v6/internal/server/network/ipam"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/util"
@@ -22,6 +24,9 @@ import (
 // ErrDHCPNotSupported indicates network doesn't support DHCP for this IP protocol.
 var ErrDHCPNotSupported error = errors.New("Network doesn't support DHCP")
 
+// ErrIPAMNotAllocated indicates no address was allocated from the network's external IPAM for the host.
+var ErrIPAMNotAllocated error = errors.New("No address allocated from the external IPAM")
+
 // DHCPValidIP returns whether an IP fits inside one of the supplied DHCP ranges and subnet.
 func DHCPValidIP(subnet *net.IPNet, ranges []iprange.Range, IP net.IP) bool {
 	inSubnet := subnet.Contains(IP)
@@ -97,6 +102,16 @@ type Options struct {
 	Network     Network
 }
 
+// IPAMRequest returns the external IPAM request for the host in the given subnet.
+func (o *Options) IPAMRequest(subnet *net.IPNet) ipam.Request {
+	return ipam.Request{
+		Subnet:      subnet,
+		Hwaddr:      o.HostMAC,
+		Hostname:    o.HostName,
+		Description: fmt.Sprintf("%s/%s/%s", o.ProjectName, o.HostName, o.DeviceName),
+	}
+}
+
 // Transaction is a locked transaction of the dnsmasq config files that allows IP allocations for a host.
 type Transaction struct {
 	opts              *Options
@@ -107,6 +122,7 @@ type Transaction struct {
 	allocationsDHCPv6 map[[16]byte]dnsmasq.DHCPAllocation
 	allocatedIPv4     net.IP
 	allocatedIPv6     net.IP
+	external          bool
 }
 
 // AllocateIPv4 allocate an IPv4 static DHCP allocation.
@@ -127,6 +143,10 @@ func (t *Transaction) AllocateIPv4() (net.IP, error) {
 	// we'll need to generate a new one.
 	if t.allocatedIPv4 != nil {
 		ranges := t.opts.Network.DHCPv4Ranges()
+		if t.external {
+			ranges = nil // Ranges only apply to local allocations.
+		}
+
 		if !DHCPValidIP(dhcpSubnet, ranges, t.allocatedIPv4.To4()) {
 			t.allocatedIPv4 = nil // We need a new IP allocated.
 		}
@@ -134,6 +154,10 @@ func (t *Transaction) AllocateIPv4() (net.IP, error) {
 
 	// Allocate a new IPv4 address if needed.
 	if t.allocatedIPv4 == nil {
+		if t.external {
+			return nil, ErrIPAMNotAllocated
+		}
+
 		t.allocatedIPv4, err = t.getDHCPFreeIPv4(t.allocationsDHCPv4, t.opts.HostName, t.opts.HostMAC)
 		if err != nil {
 			return nil, err
@@ -161,6 +185,10 @@ func (t *Transaction) AllocateIPv6() (net.IP, error) {
 	// we'll need to generate a new one.
 	if t.allocatedIPv6 != nil {
 		ranges := t.opts.Network.DHCPv6Ranges()
+		if t.external {
+			ranges = nil // Ranges only apply to local allocations.
+		}
+
 		if !DHCPValidIP(dhcpSubnet, ranges, t.allocatedIPv6.To16()) {
 			t.allocatedIPv6 = nil // We need a new IP allocated.
 		}
@@ -168,6 +196,10 @@ func (t *Transaction) AllocateIPv6() (net.IP, error) {
 
 	// Allocate a new IPv6 address if needed.
 	if t.allocatedIPv6 == nil {
+		if t.external {
+			return nil, ErrIPAMNotAllocated
+		}
+
 		t.allocatedIPv6, err = t.getDHCPFreeIPv6(t.allocationsDHCPv6, t.opts.HostName, t.opts.HostMAC)
 		if err != nil {
 			return nil, err
@@ -177,6 +209,30 @@ func (t *Transaction) AllocateIPv6() (net.IP, error) {
 	return t.allocatedIPv6, nil
 }
 
+// SetIPv4 records an IPv4 address allocated from the network's external IPAM as the static DHCP allocation.
+func (t *Transaction) SetIPv4(IP net.IP) error {
+	dhcpSubnet := t.opts.Network.DHCPv4Subnet()
+	if dhcpSubnet == nil || !dhcpSubnet.Contains(IP) {
+		return fmt.Errorf("Address %q isn't part of the DHCPv4 subnet", IP.String())
+	}
+
+	t.allocatedIPv4 = IP
+
+	return nil
+}
+
+// SetIPv6 records an IPv6 address allocated from the network's external IPAM as the static DHCP allocation.
+func (t *Transaction) SetIPv6(IP net.IP) error {
+	dhcpSubnet := t.opts.Network.DHCPv6Subnet()
+	if dhcpSubnet == nil || !dhcpSubnet.Contains(IP) {
+		return fmt.Errorf("Address %q isn't part of the DHCPv6 subnet", IP.String())
+	}
+
+	t.allocatedIPv6 = IP
+
+	return nil
+}
+
 // getDHCPFreeIPv4 attempts to find a free IPv4 address for the device.
 // It first checks whether there is an existing allocation for the instance.
 // If no previous allocation, then a free IP is picked from the ranges configured.
@@ -355,6 +411,9 @@ func AllocateTask(opts *Options, f func(*Transaction) error) error {
 	t.allocatedIPv4 = t.currentDHCPv4.IP
 	t.allocatedIPv6 = t.currentDHCPv6.IP
 
+	// New allocations are delegated to the network's external IPAM (if configured).
+	t.external = opts.Network.Config()["ipam.driver"] != ""
+
 	// Get all existing allocations in network if leases file exists. If not then we will detect this later
 	// due to the existing allocations maps being nil.
 	if util.PathExists(internalUtil.VarPath("networks", opts.Network.Name(), "dnsmasq.leases")) {
@@ -410,3 +469,66 @@ func AllocateTask(opts *Options, f func(*Transaction) error) error {
 
 	return nil
 }
+
+// AllocateIPAM requests an address in the subnet from the network's external IPAM, reusing the allocation with
+// the given ID if it still exists. This contacts the IPAM and so must be called outside of AllocateTask, the
+// returned address then being recorded in the transaction with SetIPv4 or SetIPv6.
+func AllocateIPAM(opts *Options, subnet *net.IPNet, id string) (*ipam.Allocation, error) {
+	ipamDriver, err := ipam.Load(opts.Network.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	if ipamDriver == nil {
+		return nil, fmt.Errorf("Network %q doesn't use an external IPAM", opts.Network.Name())
+	}
+
+	req := opts.IPAMRequest(subnet)
+	req.ID = id
+
+	allocation, err := ipamDriver.Allocate(context.TODO(), req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed allocating address from IPAM: %w", err)
+	}
+
+	// The address must not be the one used by Incus on the network.
+	networkAddress := opts.Network.Config()["ipv4.address"]
+	if allocation.IP.To4() == nil {
+		networkAddress = opts.Network.Config()["ipv6.address"]
+	}
+
+	ip, _, err := net.ParseCIDR(networkAddress)
+	if err == nil && allocation.IP.Equal(ip) {
+		return nil, fmt.Errorf("IPAM allocated the network address %q, make sure it is reserved in the IPAM", allocation.IP.String())
+	}
+
+	return allocation, nil
+}
+
+// ReleaseIPAM releases an address of a host, along with its allocation ID, from the network's external IPAM
+// (if configured).
+func ReleaseIPAM(opts *Options, IP net.IP, id string) error {
+	ipamDriver, err := ipam.Load(opts.Network.Config())
+	if err != nil || ipamDriver == nil {
+		return err
+	}
+
+	subnet := opts.Network.DHCPv4Subnet()
+	if IP.To4() == nil {
+		subnet = opts.Network.DHCPv6Subnet()
+	}
+
+	if subnet == nil || !subnet.Contains(IP) {
+		return nil
+	}
+
+	req := opts.IPAMRequest(subnet)
+	req.ID = id
+
+	err = ipamDriver.Release(context.TODO(), req, IP)
+	if err != nil {
+		return fmt.Errorf("Failed releasing address %q from IPAM: %w", IP.String(), err)
+	}
+
+	return nil
+}
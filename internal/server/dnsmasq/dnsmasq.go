@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -13,12 +14,16 @@ import (
 	"github.com/lxc/incus/v6/internal/server/project"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
 	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
 )
 
 const staticAllocationDeviceSeparator = "."
 
+// reservationFilePrefix can't appear in instance names, keeping reservation files apart from instance ones.
+const reservationFilePrefix = "@"
+
 // DHCPAllocation represents an IP allocation from dnsmasq.
 type DHCPAllocation struct {
 	IP             net.IP
@@ -71,6 +76,61 @@ func RemoveStaticEntry(network string, projectName string, instanceName string,
 	return nil
 }
 
+// SyncReservationEntries writes the dhcp-host lines for the network DHCP reservations and removes those of
+// reservations which no longer exist.
+func SyncReservationEntries(network string, reservations []api.NetworkDHCPReservation) error {
+	hostsPath := internalUtil.VarPath("networks", network, "dnsmasq.hosts")
+
+	wanted := map[string]string{}
+	for _, reservation := range reservations {
+		line := strings.ToLower(reservation.Hwaddr)
+
+		if reservation.IPv4Address != "" {
+			line += fmt.Sprintf(",%s", reservation.IPv4Address)
+		}
+
+		if reservation.IPv6Address != "" {
+			line += fmt.Sprintf(",[%s]", reservation.IPv6Address)
+		}
+
+		if reservation.Hostname != "" {
+			line += fmt.Sprintf(",%s", reservation.Hostname)
+		}
+
+		wanted[ReservationFileName(reservation.Hwaddr)] = line + "\n"
+	}
+
+	entries, err := os.ReadDir(hostsPath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), reservationFilePrefix) {
+			continue
+		}
+
+		_, found := wanted[entry.Name()]
+		if found {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(hostsPath, entry.Name()))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	for fileName, content := range wanted {
+		err = os.WriteFile(filepath.Join(hostsPath, fileName), []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Kill kills dnsmasq for a particular network (or optionally reloads it).
 func Kill(name string, reload bool) error {
 	pidPath := internalUtil.VarPath("networks", name, "dnsmasq.pid")
@@ -279,3 +339,8 @@ func StaticAllocationFileName(projectName string, instanceName string, deviceNam
 
 	return strings.Join([]string{project.Instance(projectName, instanceName), escapedDeviceName}, staticAllocationDeviceSeparator)
 }
+
+// ReservationFileName returns the file name to use for a dnsmasq network DHCP reservation.
+func ReservationFileName(hwaddr string) string {
+	return reservationFilePrefix + strings.ToLower(hwaddr)
+}
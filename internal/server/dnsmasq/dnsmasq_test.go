@@ -1,9 +1,15 @@
 package dnsmasq
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/api"
 )
 
 func Test_staticAllocationFileName(t *testing.T) {
@@ -13,3 +19,38 @@ func Test_staticAllocationFileName(t *testing.T) {
 	fileName := StaticAllocationFileName(projectName, instanceName, deviceName)
 	assert.Equal(t, "test.project_test-instance.test-.--_----.device", fileName)
 }
+
+func Test_SyncReservationEntries(t *testing.T) {
+	t.Setenv("INCUS_DIR", t.TempDir())
+
+	hostsPath := internalUtil.VarPath("networks", "incusbr0", "dnsmasq.hosts")
+	require.NoError(t, os.MkdirAll(hostsPath, 0755))
+
+	// Instance allocations and stale reservations.
+	require.NoError(t, os.WriteFile(filepath.Join(hostsPath, StaticAllocationFileName("default", "c1", "eth0")), []byte("00:16:3e:00:00:01,10.0.0.2,c1\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(hostsPath, ReservationFileName("00:16:3e:00:00:02")), []byte("00:16:3e:00:00:02,10.0.0.3\n"), 0644))
+
+	err := SyncReservationEntries("incusbr0", []api.NetworkDHCPReservation{
+		{Hwaddr: "00:16:3E:00:00:03", NetworkDHCPReservationPut: api.NetworkDHCPReservationPut{IPv4Address: "10.0.0.4", IPv6Address: "fd42::4", Hostname: "printer"}},
+	})
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(hostsPath)
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"c1.eth0", "@00:16:3e:00:00:03"}, names)
+
+	content, err := os.ReadFile(filepath.Join(hostsPath, "@00:16:3e:00:00:03"))
+	require.NoError(t, err)
+	assert.Equal(t, "00:16:3e:00:00:03,10.0.0.4,[fd42::4],printer\n", string(content))
+
+	_, ipv4, ipv6, err := DHCPStaticAllocation("incusbr0", "@00:16:3e:00:00:03")
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.4", ipv4.IP.String())
+	assert.Equal(t, "fd42::4", ipv6.IP.String())
+}
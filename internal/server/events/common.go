@@ -19,6 +19,15 @@ type serverCommon struct {
 	lock    sync.Mutex
 }
 
+// SetLogLevel sets which logging events from other members get forwarded.
+func (s *serverCommon) SetLogLevel(debug bool, verbose bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.debug = debug
+	s.verbose = verbose
+}
+
 // listenerCommon describes a common event listener.
 type listenerCommon struct {
 	EventListenerConnection
@@ -140,11 +140,16 @@ func (s *Server) Inject(event api.Event, eventSource EventSource) {
 			return
 		}
 
-		if !s.debug && logEntry.Level == "debug" {
+		s.lock.Lock()
+		debug := s.debug
+		verbose := s.verbose
+		s.lock.Unlock()
+
+		if !debug && logEntry.Level == "debug" {
 			return
 		}
 
-		if !s.debug && !s.verbose && logEntry.Level == "info" {
+		if !debug && !verbose && logEntry.Level == "info" {
 			return
 		}
 	}
@@ -49,3 +49,13 @@ type AddressForward struct {
 	ListenPorts   []uint64
 	TargetPorts   []uint64
 }
+
+// AddressForwardCounters represents the traffic counters of a firewall address forward rule.
+type AddressForwardCounters struct {
+	ListenAddress net.IP
+	Protocol      string
+	ListenPorts   []uint64
+	Connections   uint64 // Number of connections forwarded.
+	Packets       uint64 // Packets in both directions.
+	Bytes         uint64 // Bytes in both directions.
+}
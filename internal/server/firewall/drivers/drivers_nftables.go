@@ -374,7 +374,7 @@ func (d Nftables) NetworkClear(networkName string, _ bool, _ []uint) error {
 	removeChains := []string{
 		"fwd", "pstrt", "in", "out", // Chains used for network operation rules.
 		"aclin", "aclout", "aclfwd", "acl", // Chains used by ACL rules.
-		"fwdprert", "fwdout", "fwdpstrt", // Chains used by Address Forward rules.
+		"fwdprert", "fwdout", "fwdpstrt", "fwdcnt", // Chains used by Address Forward rules.
 		"egress", // Chains added for limits.priority option
 	}
 
@@ -1039,6 +1039,7 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 						"listenAddress": listenAddressStr,
 						"listenPorts":   portRangeStr(listenPortRange, "-"),
 						"targetDest":    targetDest,
+						"comment":       fmt.Sprintf("%s %s %s", listenAddressStr, rule.Protocol, portRangeStr(listenPortRange, "-")),
 					})
 				}
 			} else {
@@ -1053,6 +1054,7 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 					"listenAddress": listenAddressStr,
 					"targetDest":    targetDest,
 					"targetHost":    targetAddressStr,
+					"comment":       listenAddressStr,
 				})
 
 				snatRules = append(snatRules, map[string]any{
@@ -1081,12 +1083,17 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 			return fmt.Errorf("Failed running %q template: %w", nftablesNetProxyNAT.Name(), err)
 		}
 
+		err = nftablesNetForwardCounters.Execute(config, tplFields)
+		if err != nil {
+			return fmt.Errorf("Failed running %q template: %w", nftablesNetForwardCounters.Name(), err)
+		}
+
 		err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(config.String()), nil, "nft", "-f", "-")
 		if err != nil {
 			return err
 		}
 	} else {
-		err := d.removeChains([]string{"inet", "ip", "ip6"}, networkName, "fwdprert", "fwdout", "fwdpstrt")
+		err := d.removeChains([]string{"inet", "ip", "ip6"}, networkName, "fwdprert", "fwdout", "fwdpstrt", "fwdcnt")
 		if err != nil {
 			return fmt.Errorf("Failed clearing nftables forward rules for network %q: %w", networkName, err)
 		}
@@ -1094,3 +1101,104 @@ func (d Nftables) NetworkApplyForwards(networkName string, rules []AddressForwar
 
 	return nil
 }
+
+// NetworkForwardCounters returns the traffic counters of the network address forward rules.
+func (d Nftables) NetworkForwardCounters(networkName string) ([]AddressForwardCounters, error) {
+	// Use -nn flags to avoid doing DNS lookups of IPs mentioned in any rules.
+	output, err := subprocess.RunCommand("nft", "--json", "-nn", "list", "table", "inet", nftablesNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("Failed listing nftables rules: %w", err)
+	}
+
+	v := &struct {
+		Nftables []struct {
+			Rule *struct {
+				Chain   string                       `json:"chain"`
+				Comment string                       `json:"comment"`
+				Expr    []map[string]json.RawMessage `json:"expr"`
+			} `json:"rule"`
+		} `json:"nftables"`
+	}{}
+
+	err = json.Unmarshal([]byte(output), v)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing nftables rules: %w", err)
+	}
+
+	natChains := []string{"fwdprert" + nftablesChainSeparator + networkName, "fwdout" + nftablesChainSeparator + networkName}
+	countChain := "fwdcnt" + nftablesChainSeparator + networkName
+
+	var counters []AddressForwardCounters
+	ruleCounters := map[string]int{}
+
+	for _, item := range v.Nftables {
+		rule := item.Rule
+		if rule == nil || rule.Comment == "" || (rule.Chain != countChain && !slices.Contains(natChains, rule.Chain)) {
+			continue
+		}
+
+		// Extract the counter values.
+		counter := struct {
+			Packets uint64 `json:"packets"`
+			Bytes   uint64 `json:"bytes"`
+		}{}
+
+		for _, expr := range rule.Expr {
+			value, found := expr["counter"]
+			if found {
+				err = json.Unmarshal(value, &counter)
+				if err != nil {
+					return nil, fmt.Errorf("Failed parsing nftables counter: %w", err)
+				}
+
+				break
+			}
+		}
+
+		// The rule comment identifies the listen address, protocol and listen ports of the rule.
+		i, found := ruleCounters[rule.Comment]
+		if !found {
+			fields := strings.Fields(rule.Comment)
+
+			ruleCounter := AddressForwardCounters{
+				ListenAddress: net.ParseIP(fields[0]),
+			}
+
+			if len(fields) == 3 {
+				ruleCounter.Protocol = fields[1]
+
+				portFirst, portLast, _ := strings.Cut(fields[2], "-")
+				first, err := strconv.ParseUint(portFirst, 10, 16)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid listen port in rule comment %q: %w", rule.Comment, err)
+				}
+
+				last := first
+				if portLast != "" {
+					last, err = strconv.ParseUint(portLast, 10, 16)
+					if err != nil {
+						return nil, fmt.Errorf("Invalid listen port in rule comment %q: %w", rule.Comment, err)
+					}
+				}
+
+				for port := first; port <= last; port++ {
+					ruleCounter.ListenPorts = append(ruleCounter.ListenPorts, port)
+				}
+			}
+
+			counters = append(counters, ruleCounter)
+			i = len(counters) - 1
+			ruleCounters[rule.Comment] = i
+		}
+
+		// NAT rules only see the first packet of each connection.
+		if rule.Chain == countChain {
+			counters[i].Packets += counter.Packets
+			counters[i].Bytes += counter.Bytes
+		} else {
+			counters[i].Connections += counter.Packets
+		}
+	}
+
+	return counters, nil
+}
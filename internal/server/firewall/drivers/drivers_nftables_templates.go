@@ -89,14 +89,14 @@ table {{.family}} {{.namespace}} {
 	chain {{.chainPrefix}}prert{{.chainSeparator}}{{.label}} {
 		type nat hook prerouting priority -100; policy accept;
 		{{- range .dnatRules}}
-		{{.ipFamily}} daddr {{.listenAddress}} {{if .protocol}}{{.protocol}} dport {{.listenPorts}}{{end}} dnat to {{.targetDest}}
+		{{.ipFamily}} daddr {{.listenAddress}} {{if .protocol}}{{.protocol}} dport {{.listenPorts}}{{end}} {{if .comment}}counter {{end}}dnat to {{.targetDest}}{{if .comment}} comment "{{.comment}}"{{end}}
 		{{- end}}
 	}
 
 	chain {{.chainPrefix}}out{{.chainSeparator}}{{.label}} {
 		type nat hook output priority -100; policy accept;
 		{{- range .dnatRules}}
-		{{.ipFamily}} daddr {{.listenAddress}} {{if .protocol}}{{.protocol}} dport {{.listenPorts}}{{end}} dnat to {{.targetDest}}
+		{{.ipFamily}} daddr {{.listenAddress}} {{if .protocol}}{{.protocol}} dport {{.listenPorts}}{{end}} {{if .comment}}counter {{end}}dnat to {{.targetDest}}{{if .comment}} comment "{{.comment}}"{{end}}
 		{{- end}}
 	}
 
@@ -109,6 +109,22 @@ table {{.family}} {{.namespace}} {
 }
 `))
 
+// nftablesNetForwardCounters counts the traffic of each address forward rule after it has been DNATed.
+var nftablesNetForwardCounters = template.Must(template.New("nftablesNetForwardCounters").Parse(`
+add table {{.family}} {{.namespace}}
+add chain {{.family}} {{.namespace}} fwdcnt{{.chainSeparator}}{{.label}} {type filter hook forward priority -1; policy accept;}
+flush chain {{.family}} {{.namespace}} fwdcnt{{.chainSeparator}}{{.label}}
+
+table {{.family}} {{.namespace}} {
+	chain fwdcnt{{.chainSeparator}}{{.label}} {
+		type filter hook forward priority -1; policy accept;
+		{{- range .dnatRules}}
+		ct original {{.ipFamily}} daddr {{.listenAddress}} {{if .protocol}}meta l4proto {{.protocol}} ct original proto-dst {{.listenPorts}} {{end}}counter accept comment "{{.comment}}"
+		{{- end}}
+	}
+}
+`))
+
 var nftablesNetACLSetup = template.Must(template.New("nftablesNetACLSetup").Parse(`
 add table {{.family}} {{.namespace}}
 add chain {{.family}} {{.namespace}} acl{{.chainSeparator}}{{.networkName}}
@@ -1650,3 +1650,8 @@ func (d Xtables) NetworkApplyForwards(networkName string, rules []AddressForward
 	reverter.Success()
 	return nil
 }
+
+// NetworkForwardCounters isn't supported by the xtables driver.
+func (d Xtables) NetworkForwardCounters(networkName string) ([]AddressForwardCounters, error) {
+	return nil, fmt.Errorf("Address forward counters aren't supported with the xtables firewall driver")
+}
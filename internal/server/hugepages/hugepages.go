@@ -0,0 +1,83 @@
+package hugepages
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/server/resources"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/units"
+)
+
+// reservations holds the huge pages reserved by instances being started, in bytes by page size.
+// Those aren't yet accounted for by the kernel.
+var reservations = map[uint64]uint64{}
+
+// reservationsMu is used to access reservations safely.
+var reservationsMu sync.Mutex
+
+// PageSize returns the size of the huge pages provided by the hugetlbfs mount at the given path.
+func PageSize(path string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	err := unix.Statfs(path, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("Failed getting huge page size of %q: %w", path, err)
+	}
+
+	return uint64(stat.Bsize), nil
+}
+
+// Reserve reserves huge pages of the given size until the returned function is called, which should happen once
+// the memory is actually allocated. An error is returned if not enough huge pages are available.
+func Reserve(pageSize uint64, size uint64) (func(), error) {
+	reservationsMu.Lock()
+	defer reservationsMu.Unlock()
+
+	hugepages, err := resources.GetHugepages()
+	if err != nil {
+		return nil, err
+	}
+
+	// Round up to whole pages.
+	size = (size + pageSize - 1) / pageSize * pageSize
+
+	var free uint64
+	for _, entry := range hugepages {
+		if entry.Size == pageSize {
+			free = entry.Free
+			break
+		}
+	}
+
+	free -= min(free, reservations[pageSize])
+	if size > free {
+		return nil, fmt.Errorf("Not enough free huge pages of %s, %s required but only %s available", units.GetByteSizeStringIEC(int64(pageSize), 0), units.GetByteSizeStringIEC(int64(size), 2), units.GetByteSizeStringIEC(int64(free), 2))
+	}
+
+	reservations[pageSize] += size
+
+	release := sync.OnceFunc(func() {
+		reservationsMu.Lock()
+		defer reservationsMu.Unlock()
+
+		reservations[pageSize] -= size
+	})
+
+	return release, nil
+}
+
+// Adjust accounts for the huge pages reserved by instances being started in the given huge pages usage.
+func Adjust(hugepages []api.ResourcesMemoryHugepages) {
+	reservationsMu.Lock()
+	defer reservationsMu.Unlock()
+
+	for i, entry := range hugepages {
+		reserved := min(entry.Free, reservations[entry.Size])
+
+		hugepages[i].Reserved += reserved
+		hugepages[i].Free -= reserved
+	}
+}
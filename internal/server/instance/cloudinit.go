@@ -0,0 +1,96 @@
+package instance
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/flosch/pongo2"
+
+	"github.com/lxc/incus/v6/internal/server/template"
+	"github.com/lxc/incus/v6/shared/osarch"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// cloudInitConfigKeys lists the configuration keys holding cloud-init data.
+var cloudInitConfigKeys = []string{
+	"cloud-init.network-config",
+	"cloud-init.user-data",
+	"cloud-init.vendor-data",
+	"user.meta-data",
+	"user.network-config",
+	"user.user-data",
+	"user.vendor-data",
+}
+
+// CloudInitConfig returns the expanded configuration of the instance with its cloud-init data rendered.
+// When cloud-init.templating is enabled, the cloud-init keys are rendered as pongo2 templates with access
+// to the instance, its expanded configuration and its project configuration.
+func CloudInitConfig(inst Instance) (map[string]string, error) {
+	config := maps.Clone(inst.ExpandedConfig())
+	if !util.IsTrue(config["cloud-init.templating"]) {
+		return config, nil
+	}
+
+	arch, err := osarch.ArchitectureName(inst.Architecture())
+	if err != nil {
+		arch = ""
+	}
+
+	instanceMeta := map[string]string{
+		"name":         inst.Name(),
+		"project":      inst.Project().Name,
+		"type":         inst.Type().String(),
+		"architecture": arch,
+		"location":     inst.Location(),
+	}
+
+	projectMeta := map[string]any{
+		"name":   inst.Project().Name,
+		"config": inst.Project().Config,
+	}
+
+	configGet := func(confKey, confDefault *pongo2.Value) *pongo2.Value {
+		val, ok := inst.ExpandedConfig()[confKey.String()]
+		if !ok {
+			return confDefault
+		}
+
+		return pongo2.AsValue(strings.TrimRight(val, "\r\n"))
+	}
+
+	// Templates have no access to the filesystem.
+	tplSet := pongo2.NewSet(fmt.Sprintf("%s-cloud-init", inst.Name()), template.NullLoader{})
+	for _, tag := range []string{"extends", "import", "include", "ssi"} {
+		err = tplSet.BanTag(tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range cloudInitConfigKeys {
+		value, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		tpl, err := tplSet.FromString("{% autoescape off %}" + value + "{% endautoescape %}")
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing template %q: %w", key, err)
+		}
+
+		rendered, err := tpl.Execute(pongo2.Context{
+			"instance":   instanceMeta,
+			"project":    projectMeta,
+			"config":     inst.ExpandedConfig(),
+			"config_get": configGet,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed rendering template %q: %w", key, err)
+		}
+
+		config[key] = rendered
+	}
+
+	return config, nil
+}
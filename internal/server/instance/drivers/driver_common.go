@@ -2,11 +2,14 @@ package drivers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"sort"
 	"strconv"
@@ -242,6 +245,214 @@ func (d *common) Backups() ([]backup.InstanceBackup, error) {
 	return backups, nil
 }
 
+// crashHistorySize is the number of crashes kept in the crash history of an instance.
+const crashHistorySize = 100
+
+// crashHistoryLock serializes the updates of the crash history files.
+var crashHistoryLock sync.Mutex
+
+// Crashes returns the crash history of the instance, oldest first.
+func (d *common) Crashes() ([]api.InstanceCrash, error) {
+	crashes := []api.InstanceCrash{}
+
+	content, err := os.ReadFile(filepath.Join(d.LogPath(), "crashes.json"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return crashes, nil
+		}
+
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &crashes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing crash history: %w", err)
+	}
+
+	return crashes, nil
+}
+
+// RecordCrash adds the crash to the crash history of the instance and emits the matching lifecycle event.
+func (d *common) RecordCrash(crash api.InstanceCrash) error {
+	crashHistoryLock.Lock()
+	defer crashHistoryLock.Unlock()
+
+	crashes, err := d.Crashes()
+	if err != nil {
+		return err
+	}
+
+	crashes = append(crashes, crash)
+	if len(crashes) > crashHistorySize {
+		crashes = crashes[len(crashes)-crashHistorySize:]
+	}
+
+	content, err := json.Marshal(crashes)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(d.LogPath(), "crashes.json"), content, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed writing crash history: %w", err)
+	}
+
+	if crash.Type == api.InstanceCrashOOMKill {
+		d.logger.Warn("Processes killed by the out of memory killer", logger.Ctx{"count": crash.Count})
+		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceOOMKilled.Event(d, map[string]any{"count": crash.Count}))
+	} else {
+		d.logger.Warn("Instance crashed", logger.Ctx{"type": crash.Type, "details": crash.Details})
+		d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceCrashed.Event(d, map[string]any{"type": crash.Type, "details": crash.Details}))
+	}
+
+	return nil
+}
+
+// crashDumpPrefix is the prefix of the files captured into the log directory of an instance when its process crashes.
+const crashDumpPrefix = "crash_"
+
+// crashCorePatternSpecifierRegex matches the specifiers of the kernel core pattern.
+var crashCorePatternSpecifierRegex = regexp.MustCompile(`%.`)
+
+// captureCrashDump captures the core dump of the crashed process along with copies of the log files into the log
+// directory of the instance, removes the captures beyond the configured retention and returns the captured files.
+func (d *common) captureCrashDump(pid int, logFiles []string) ([]string, error) {
+	retention := d.state.GlobalConfig.InstancesCrashDumpsRetention()
+	if retention <= 0 {
+		return nil, nil
+	}
+
+	prefix := crashDumpPrefix + time.Now().UTC().Format("20060102-150405")
+	files := []string{}
+
+	for _, logFile := range logFiles {
+		name := prefix + "." + filepath.Base(logFile)
+
+		err := internalUtil.FileCopy(logFile, filepath.Join(d.LogPath(), name))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("Failed copying log file %q: %w", logFile, err)
+		}
+
+		files = append(files, name)
+	}
+
+	name := prefix + ".core"
+	err := crashCoreDump(pid, filepath.Join(d.LogPath(), name))
+	if err != nil {
+		// Core dumps may well be disabled on the host, keep the logs regardless.
+		d.logger.Warn("Failed retrieving core dump", logger.Ctx{"pid": pid, "err": err})
+	} else {
+		files = append(files, name)
+	}
+
+	err = d.pruneCrashDumps(retention)
+	if err != nil {
+		return nil, fmt.Errorf("Failed removing old crash dumps: %w", err)
+	}
+
+	return files, nil
+}
+
+// pruneCrashDumps removes the oldest crash dumps of the instance so that at most retention of them are kept.
+func (d *common) pruneCrashDumps(retention int64) error {
+	entries, err := os.ReadDir(d.LogPath())
+	if err != nil {
+		return err
+	}
+
+	// The entries are sorted by name, so the timestamps in the names put the oldest captures first.
+	captures := []string{}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), crashDumpPrefix) {
+			continue
+		}
+
+		capture, _, _ := strings.Cut(entry.Name(), ".")
+		if !slices.Contains(captures, capture) {
+			captures = append(captures, capture)
+		}
+	}
+
+	if int64(len(captures)) <= retention {
+		return nil
+	}
+
+	expired := captures[:len(captures)-int(retention)]
+	for _, entry := range entries {
+		capture, _, _ := strings.Cut(entry.Name(), ".")
+		if !slices.Contains(expired, capture) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(d.LogPath(), entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// crashCoreDump retrieves the core dump of the crashed process into the target file. Core dumps handled by
+// systemd-coredump are retrieved through coredumpctl, others are looked for where the kernel core pattern puts them.
+func crashCoreDump(pid int, target string) error {
+	content, err := os.ReadFile("/proc/sys/kernel/core_pattern")
+	if err != nil {
+		return err
+	}
+
+	pattern := strings.TrimSpace(string(content))
+
+	if strings.HasPrefix(pattern, "|") {
+		if !strings.Contains(pattern, "systemd-coredump") {
+			return fmt.Errorf("Unsupported core dump handler %q", pattern)
+		}
+
+		// The core dump is processed asynchronously, so give systemd-coredump some time to store it.
+		for i := 0; i < 10; i++ {
+			_, err = subprocess.RunCommand("coredumpctl", "dump", "--output", target, strconv.Itoa(pid))
+			if err == nil {
+				return os.Chmod(target, 0600)
+			}
+
+			time.Sleep(time.Second)
+		}
+
+		return err
+	}
+
+	path := strings.ReplaceAll(pattern, "%p", strconv.Itoa(pid))
+	if path == pattern {
+		usePID, _ := os.ReadFile("/proc/sys/kernel/core_uses_pid")
+		if strings.TrimSpace(string(usePID)) == "1" {
+			path += "." + strconv.Itoa(pid)
+		}
+	}
+
+	// The other specifiers can't be resolved once the process is gone, match them with wildcards instead.
+	path = crashCorePatternSpecifierRegex.ReplaceAllString(path, "*")
+
+	// Relative paths are relative to the working directory of the process, "/" for daemonized processes.
+	if !filepath.IsAbs(path) {
+		path = filepath.Join("/", path)
+	}
+
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("No core dump found matching %q", path)
+	}
+
+	return internalUtil.FileMove(matches[len(matches)-1], target)
+}
+
 // DeferTemplateApply records a template trigger to apply on next instance start.
 func (d *common) DeferTemplateApply(trigger instance.TemplateTrigger) error {
 	// Avoid over-writing triggers that have already been set.
@@ -774,6 +985,32 @@ func (d *common) updateProgress(progress string) {
 	}
 }
 
+// updateDeviceStatus records the hotplug status of a device (and any error) in the operation metadata.
+func (d *common) updateDeviceStatus(deviceName string, status string, err error) {
+	if d.op == nil {
+		return
+	}
+
+	meta := d.op.Metadata()
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	devices, ok := meta["devices"].(map[string]any)
+	if !ok {
+		devices = make(map[string]any)
+	}
+
+	entry := map[string]any{"status": status}
+	if err != nil {
+		entry["error"] = err.Error()
+	}
+
+	devices[deviceName] = entry
+	meta["devices"] = devices
+	_ = d.op.UpdateMetadata(meta)
+}
+
 // insertConfigkey function attempts to insert the instance config key into the database. If the insert fails
 // then the database is queried to check whether another query inserted the same key. If the key is still
 // unpopulated then the insert querty is retried until it succeeds or a retry limit is reached.
@@ -1276,10 +1513,15 @@ func (d *common) devicesUpdate(inst instance.Instance, removeDevices deviceConfi
 		// If a device was returned from deviceLoad even if validation fails, then try to stop and remove.
 		if dev != nil {
 			if instanceRunning {
+				d.updateDeviceStatus(dev.Name(), "detaching", nil)
+
 				err = dm.deviceStop(dev, instanceRunning, "")
 				if err != nil {
+					d.updateDeviceStatus(dev.Name(), "failed", err)
 					return fmt.Errorf("Failed to stop device %q: %w", dev.Name(), err)
 				}
+
+				d.updateDeviceStatus(dev.Name(), "detached", nil)
 			}
 
 			err = d.deviceRemove(dev, instanceRunning)
@@ -1336,11 +1578,16 @@ func (d *common) devicesUpdate(inst instance.Instance, removeDevices deviceConfi
 				return fmt.Errorf("Failed pre-start check for device %q: %w", dev.Name(), err)
 			}
 
+			d.updateDeviceStatus(dev.Name(), "attaching", nil)
+
 			_, err := dm.deviceStart(dev, instanceRunning)
 			if err != nil && err != device.ErrUnsupportedDevType {
+				d.updateDeviceStatus(dev.Name(), "failed", err)
 				return fmt.Errorf("Failed to start device %q: %w", dev.Name(), err)
 			}
 
+			d.updateDeviceStatus(dev.Name(), "attached", nil)
+
 			revert.Add(func() { _ = dm.deviceStop(dev, instanceRunning, "") })
 		}
 	}
@@ -1524,6 +1771,241 @@ func (d *common) setNUMANode() error {
 	return d.VolatileSet(map[string]string{"volatile.cpu.nodes": fmt.Sprintf("%d", node)})
 }
 
+// cpuAllocationDeviceNodes returns the NUMA nodes of the GPUs, NICs and PCI devices passed to the instance, the
+// most used first.
+func (d *common) cpuAllocationDeviceNodes() ([]int64, error) {
+	var gpus *api.ResourcesGPU
+	var nics *api.ResourcesNetwork
+	var err error
+
+	usage := map[int64]int{}
+	for _, dev := range d.expandedDevices.Sorted() {
+		switch dev.Config["type"] {
+		case "gpu":
+			if dev.Config["pci"] == "" && dev.Config["id"] == "" {
+				continue
+			}
+
+			if gpus == nil {
+				gpus, err = resources.GetGPU()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for _, card := range gpus.Cards {
+				if card.PCIAddress == dev.Config["pci"] || (card.DRM != nil && dev.Config["id"] == strconv.FormatUint(card.DRM.ID, 10)) {
+					usage[int64(card.NUMANode)]++
+				}
+			}
+
+		case "nic", "infiniband":
+			if dev.Config["parent"] == "" {
+				continue
+			}
+
+			if nics == nil {
+				nics, err = resources.GetNetwork()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for _, card := range nics.Cards {
+				for _, port := range card.Ports {
+					if port.ID == dev.Config["parent"] {
+						usage[int64(card.NUMANode)]++
+					}
+				}
+			}
+
+		case "pci":
+			numaNode, err := os.ReadFile(filepath.Join("/sys/bus/pci/devices", dev.Config["address"], "numa_node"))
+			if err != nil {
+				continue
+			}
+
+			node, err := strconv.ParseInt(strings.TrimSpace(string(numaNode)), 10, 64)
+			if err == nil && node >= 0 {
+				usage[node]++
+			}
+		}
+	}
+
+	nodes := make([]int64, 0, len(usage))
+	for node := range usage {
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if usage[nodes[i]] != usage[nodes[j]] {
+			return usage[nodes[i]] > usage[nodes[j]]
+		}
+
+		return nodes[i] < nodes[j]
+	})
+
+	return nodes, nil
+}
+
+// allocateCPUs picks the CPU threads of an instance using automatic NUMA-aware allocation, avoiding those used
+// by other running instances, and records them in volatile.cpu.allocation.
+func (d *common) allocateCPUs() error {
+	muNUMA.Lock()
+	defer muNUMA.Unlock()
+
+	count := 1
+	if d.expandedConfig["limits.cpu"] != "" {
+		var err error
+		count, err = strconv.Atoi(d.expandedConfig["limits.cpu"])
+		if err != nil {
+			return fmt.Errorf("Automatic CPU allocation requires limits.cpu to be a number of CPUs: %w", err)
+		}
+	}
+
+	req := resources.CPUAllocation{Count: count}
+
+	if d.expandedConfig["limits.cpu.nodes"] != "" {
+		nodes, err := resources.ParseNumaNodeSet(d.expandedConfig["limits.cpu.nodes"])
+		if err != nil {
+			return err
+		}
+
+		req.Nodes = nodes
+	}
+
+	deviceNodes, err := d.cpuAllocationDeviceNodes()
+	if err != nil {
+		return fmt.Errorf("Failed getting NUMA nodes of devices: %w", err)
+	}
+
+	req.DeviceNodes = deviceNodes
+
+	// Get all local instances.
+	insts, err := instance.LoadNodeAll(d.state, instancetype.Any)
+	if err != nil {
+		return err
+	}
+
+	// Record the CPU threads pinned by the other running or starting instances.
+	for _, inst := range insts {
+		// Ignore ourselves.
+		if inst.ID() == d.id {
+			continue
+		}
+
+		if !inst.IsRunning() && operationlock.Get(inst.Project().Name, inst.Name()) == nil {
+			continue
+		}
+
+		conf := inst.ExpandedConfig()
+
+		cpus := conf["limits.cpu"]
+		if conf["limits.cpu.allocation"] == "numa-auto" {
+			cpus = conf["volatile.cpu.allocation"]
+		}
+
+		// Ignore instances without any CPU pinning.
+		_, err := strconv.Atoi(cpus)
+		if cpus == "" || err == nil {
+			continue
+		}
+
+		pins, err := resources.ParseCpuset(cpus)
+		if err != nil {
+			continue
+		}
+
+		req.Used = append(req.Used, pins...)
+	}
+
+	// Get the CPU information.
+	cpu, err := resources.GetCPU()
+	if err != nil {
+		return err
+	}
+
+	pins, err := resources.AllocateCPUs(cpu, req)
+	if err != nil {
+		return fmt.Errorf("Failed allocating CPUs: %w", err)
+	}
+
+	return d.VolatileSet(map[string]string{"volatile.cpu.allocation": resources.FormatCpuset(pins)})
+}
+
+// networkStateDetails adds the host side interface details, the OVN logical switch port state and the host routes
+// of routed NICs to the NIC state. The OVN state is only added when ovnState is true as it requires querying the
+// OVN databases.
+func (d *common) networkStateDetails(inst instance.Instance, networks map[string]api.InstanceStateNetwork, ovnState bool) {
+	for name, network := range networks {
+		if network.HostName == "" {
+			continue
+		}
+
+		dropReasons, err := resources.GetNetworkDropReasons(network.HostName)
+		if err == nil && len(dropReasons) > 0 {
+			network.DropReasons = dropReasons
+		}
+
+		queues, err := resources.GetNetworkQueues(network.HostName)
+		if err == nil {
+			network.Queues = queues
+		}
+
+		network.LinkSpeed, network.LinkDuplex = resources.GetNetworkLink(network.HostName)
+		networks[name] = network
+	}
+
+	for devName, devConfig := range d.expandedDevices {
+		if devConfig["type"] != "nic" {
+			continue
+		}
+
+		nicType, err := nictype.NICType(d.state, d.project.Name, devConfig)
+		if err != nil || (nicType != "routed" && (nicType != "ovn" || !ovnState || d.state.OVNNB == nil)) {
+			continue
+		}
+
+		dev, err := d.deviceLoad(inst, devName, devConfig)
+		if err != nil {
+			continue
+		}
+
+		// Match on hwaddr as the interface name inside the instance can differ from the device name.
+		hwaddr := devConfig["hwaddr"]
+		if hwaddr == "" {
+			hwaddr = d.localConfig[fmt.Sprintf("volatile.%s.hwaddr", devName)]
+		}
+
+		for name, network := range networks {
+			if network.Hwaddr != hwaddr {
+				continue
+			}
+
+			switch nic := dev.(type) {
+			case device.NICOVNState:
+				network.OVN, err = nic.OVNState()
+				if err != nil {
+					d.logger.Warn("Failed getting OVN port state", logger.Ctx{"device": devName, "err": err})
+					break
+				}
+
+				networks[name] = network
+			case device.NICHostRoutes:
+				network.HostRoutes, err = nic.HostRoutes()
+				if err != nil {
+					d.logger.Warn("Failed getting NIC host routes", logger.Ctx{"device": devName, "err": err})
+					break
+				}
+
+				networks[name] = network
+			}
+
+			break
+		}
+	}
+}
+
 // Gets the process starting time.
 func (d *common) processStartedAt(pid int) (time.Time, error) {
 	file, err := os.Stat(fmt.Sprintf("/proc/%d", pid))
@@ -0,0 +1,37 @@
+package drivers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/internal/server/operations"
+)
+
+func TestUpdateDeviceStatus(t *testing.T) {
+	// Without an operation, there is nothing to record.
+	d := &common{}
+	d.updateDeviceStatus("gpu0", "attaching", nil)
+
+	op, err := operations.OperationCreate(nil, "default", operations.OperationClassTask, operationtype.InstanceUpdate, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create operation: %v", err)
+	}
+
+	d.op = op
+	d.updateDeviceStatus("gpu0", "attaching", nil)
+	d.updateDeviceStatus("gpu1", "attaching", nil)
+	d.updateDeviceStatus("gpu1", "failed", fmt.Errorf("No free IOMMU group"))
+	d.updateDeviceStatus("gpu0", "attached", nil)
+
+	expected := map[string]any{
+		"gpu0": map[string]any{"status": "attached"},
+		"gpu1": map[string]any{"status": "failed", "error": "No free IOMMU group"},
+	}
+
+	devices := op.Metadata()["devices"]
+	if !reflect.DeepEqual(devices, expected) {
+		t.Errorf("Expected device status %v, got %v", expected, devices)
+	}
+}
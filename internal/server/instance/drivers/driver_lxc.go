@@ -706,7 +706,17 @@ func (d *lxc) initLXC(config bool) (*liblxc.Container, error) {
 			return nil, err
 		}
 
-		err = lxcSetConfigItem(cc, "lxc.console.size", "auto")
+		consoleLogSize := "auto"
+		if d.expandedConfig["console.log.size"] != "" && d.expandedConfig["console.log.size"] != "auto" {
+			size, err := units.ParseByteSizeString(d.expandedConfig["console.log.size"])
+			if err != nil {
+				return nil, err
+			}
+
+			consoleLogSize = strconv.FormatInt(size, 10)
+		}
+
+		err = lxcSetConfigItem(cc, "lxc.console.size", consoleLogSize)
 		if err != nil {
 			return nil, err
 		}
@@ -1933,6 +1943,14 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 	}
 
+	// Pick the CPUs if needed.
+	if d.expandedConfig["limits.cpu.allocation"] == "numa-auto" {
+		err := d.allocateCPUs()
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
 	// Check if idmap needs changing.
 	if !d.IsPrivileged() {
 		nextMap, err := d.NextIdmap()
@@ -2023,6 +2041,17 @@ func (d *lxc) startCommon() (string, []func() error, error) {
 		}
 	}
 
+	// Keep the console log of the previous boots.
+	consoleLogHistory := 1
+	if d.expandedConfig["console.log.history"] != "" {
+		consoleLogHistory, _ = strconv.Atoi(d.expandedConfig["console.log.history"])
+	}
+
+	err = internalUtil.RotateFile(d.ConsoleBufferLogPath(), consoleLogHistory)
+	if err != nil {
+		return "", nil, fmt.Errorf("Failed rotating console log: %w", err)
+	}
+
 	// Wait for any file operations to complete.
 	// This is to avoid having an active mount by forkfile and so all file operations
 	// from this point will use the container's namespace rather than a chroot.
@@ -3370,7 +3399,7 @@ func (d *lxc) RenderFull(hostInterfaces []net.Interface) (*api.InstanceFull, any
 	ct := api.InstanceFull{Instance: *base.(*api.Instance)}
 
 	// Add the ContainerState
-	ct.State, err = d.renderState(ct.StatusCode, hostInterfaces)
+	ct.State, err = d.renderState(ct.StatusCode, hostInterfaces, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -3414,7 +3443,8 @@ func (d *lxc) RenderFull(hostInterfaces []net.Interface) (*api.InstanceFull, any
 }
 
 // renderState renders just the running state of the instance.
-func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interface) (*api.InstanceState, error) {
+// The OVN state of the NICs is only included when ovnState is true as it requires querying the OVN databases.
+func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interface, ovnState bool) (*api.InstanceState, error) {
 	status := api.InstanceState{
 		Status:     statusCode.String(),
 		StatusCode: statusCode,
@@ -3429,6 +3459,7 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 		status.CPU = d.cpuState()
 		status.Memory = d.memoryState()
 		status.Network = d.networkState(hostInterfaces)
+		d.networkStateDetails(d, status.Network, ovnState)
 		status.Pid = int64(pid)
 		status.Processes = processesState
 
@@ -3447,7 +3478,7 @@ func (d *lxc) renderState(statusCode api.StatusCode, hostInterfaces []net.Interf
 
 // RenderState renders just the running state of the instance.
 func (d *lxc) RenderState(hostInterfaces []net.Interface) (*api.InstanceState, error) {
-	return d.renderState(d.statusCode(), hostInterfaces)
+	return d.renderState(d.statusCode(), hostInterfaces, true)
 }
 
 // snapshot creates a snapshot of the instance.
@@ -4137,6 +4168,40 @@ func (d *lxc) CGroupSet(key string, value string) error {
 	return nil
 }
 
+// ContainerConfigKeyLiveUpdatable returns whether a change to the given config key takes effect on a
+// running container, rather than on its next start.
+func ContainerConfigKeyLiveUpdatable(key string) bool {
+	// Keys which are only applied when the container starts.
+	restartKeys := []string{
+		"console.log.size",
+		"raw.idmap",
+		"raw.lxc",
+		"raw.seccomp",
+		"security.guestapi.images",
+		"security.privileged",
+	}
+
+	restartKeyPrefixes := []string{
+		"environment.",
+		"limits.kernel.",
+		"linux.sysctl.",
+		"nvidia.",
+		"security.idmap.",
+		"security.syscalls.",
+	}
+
+	if slices.Contains(restartKeys, key) {
+		return false
+	}
+
+	// The options of the interception handlers are read whenever a system call is handled.
+	if util.StringHasPrefix(key, "security.syscalls.intercept.bpf.", "security.syscalls.intercept.mount.") {
+		return true
+	}
+
+	return !util.StringHasPrefix(key, restartKeyPrefixes...)
+}
+
 // Update applies updated config.
 func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 	unlock, err := d.updateBackupFileLock(context.Background())
@@ -4480,6 +4545,19 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 
 		// Invalidate the idmap cache.
 		d.idmapset = nil
+
+		// Report conflicts with other containers now rather than when starting the container.
+		if userRequested && !d.IsPrivileged() {
+			err = d.idmapValidate()
+			if err != nil {
+				return err
+			}
+		}
+	} else if userRequested && !d.IsPrivileged() && d.idmapDisksAdded(addDevices) {
+		err = d.idmapValidate()
+		if err != nil {
+			return err
+		}
 	}
 
 	isRunning := d.IsRunning()
@@ -4735,7 +4813,15 @@ func (d *lxc) Update(args db.InstanceArgs, userRequested bool) error {
 						}
 					}
 				}
-			} else if key == "limits.cpu" || key == "limits.cpu.nodes" {
+			} else if key == "limits.cpu" || key == "limits.cpu.nodes" || key == "limits.cpu.allocation" {
+				// Pick new CPUs if needed.
+				if d.expandedConfig["limits.cpu.allocation"] == "numa-auto" {
+					err := d.allocateCPUs()
+					if err != nil {
+						return err
+					}
+				}
+
 				// Trigger a scheduler re-run
 				cgroup.TaskSchedulerTrigger("container", d.name, "changed")
 			} else if key == "limits.cpu.priority" || key == "limits.cpu.allowance" {
@@ -5257,6 +5343,62 @@ func (d *lxc) migrationSendCheckForPreDumpSupport() (bool, int) {
 	return usePreDumps, maxIterations
 }
 
+// migrationCheckLazyPagesSupport checks if CRIU supports transferring memory pages lazily.
+func (d *lxc) migrationCheckLazyPagesSupport() bool {
+	_, err := subprocess.RunCommand("criu", "check", "--feature", "lazy_pages")
+	return err == nil
+}
+
+// migrationLazyPagesEnv writes the CRIU configuration for a lazy pages dump or restore and returns the
+// environment pointing CRIU to it.
+func (d *lxc) migrationLazyPagesEnv(args *instance.CriuMigrationArgs) ([]string, error) {
+	config := "lazy-pages\n"
+	if args.Cmd == liblxc.MIGRATE_DUMP {
+		// Serve the memory pages to the target through the local page server.
+		config += fmt.Sprintf("address 127.0.0.1\nport %d\n", args.LazyPort)
+	}
+
+	configPath := filepath.Join(args.StateDir, "lazy-pages.conf")
+	err := os.WriteFile(configPath, []byte(config), 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed writing CRIU lazy pages configuration: %w", err)
+	}
+
+	return append(os.Environ(), fmt.Sprintf("CRIU_CONFIG_FILE=%s", configPath)), nil
+}
+
+// migrationLazyPagesPort returns a free local port for the CRIU page server.
+func (d *lxc) migrationLazyPagesPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return -1, err
+	}
+
+	defer func() { _ = listener.Close() }()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// migrationLazyPagesProxy forwards the memory pages between the local CRIU connection and the state
+// connection until either side is done.
+func (d *lxc) migrationLazyPagesProxy(conn net.Conn, stateConn io.ReadWriteCloser) error {
+	defer func() { _ = conn.Close() }()
+
+	errCh := make(chan error, 2)
+
+	go func() {
+		_, err := io.Copy(conn, stateConn)
+		errCh <- err
+	}()
+
+	go func() {
+		_, err := io.Copy(stateConn, conn)
+		errCh <- err
+	}()
+
+	return <-errCh
+}
+
 func (d *lxc) migrationSendWriteActionScript(directory string, operation string, secret string, execPath string) error {
 	script := fmt.Sprintf(`#!/bin/sh -e
 if [ "$CRTOOLS_SCRIPT_ACTION" = "post-dump" ]; then
@@ -5331,6 +5473,15 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 		offerUsePreDumps, maxDumpIterations = d.migrationSendCheckForPreDumpSupport()
 		offerHeader.Predump = proto.Bool(offerUsePreDumps)
 		offerHeader.Criu = migration.CRIUType_CRIU_RSYNC.Enum()
+
+		// Offer to transfer the memory lazily after the restore if requested.
+		if args.LiveMigration != nil && args.LiveMigration.LazyPages {
+			if d.migrationCheckLazyPagesSupport() {
+				offerHeader.LazyPages = proto.Bool(true)
+			} else {
+				d.logger.Warn("CRIU doesn't support lazy pages, falling back to transferring the memory before the restore")
+			}
+		}
 	} else {
 		offerHeader.Predump = proto.Bool(false)
 
@@ -5486,6 +5637,7 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 		defer d.logger.Debug("Migrate send transfer finished")
 
 		var err error
+		var lazyPagesDone chan error
 
 		d.logger.Debug("Starting storage migration phase")
 
@@ -5519,6 +5671,16 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 				return err
 			}
 
+			lazyPages := respHeader.GetLazyPages()
+			lazyPort := 0
+			if lazyPages {
+				lazyPort, err = d.migrationLazyPagesPort()
+				if err != nil {
+					_ = os.RemoveAll(checkpointDir)
+					return fmt.Errorf("Failed allocating CRIU page server port: %w", err)
+				}
+			}
+
 			if liblxc.RuntimeLiblxcVersionAtLeast(liblxc.Version(), 2, 0, 4) {
 				// What happens below is slightly convoluted. Due to various complications
 				// with networking, there's no easy way for criu to exit and leave the
@@ -5530,7 +5692,10 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 				// This script then blocks until the migration operation either finishes
 				// successfully or fails, and exits 1 or 0, which causes criu to either
 				// leave the container running or kill it as we asked.
+				// With lazy pages, the memory is only served once the action script is done, so the
+				// script can't wait for the restore to succeed and the container is always killed.
 				dumpDone := make(chan bool, 1)
+				actionScriptDone := make(chan struct{})
 				actionScriptOpSecret, err := internalUtil.RandomHexString(32)
 				if err != nil {
 					_ = os.RemoveAll(checkpointDir)
@@ -5545,6 +5710,15 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 					nil,
 					nil,
 					func(op *operations.Operation) error {
+						if lazyPages {
+							select {
+							case <-actionScriptDone:
+							case <-restoreSuccess:
+							}
+
+							return nil
+						}
+
 						result := <-restoreSuccess
 						if !result {
 							return fmt.Errorf("restore failed, failing CRIU")
@@ -5569,6 +5743,7 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 						}
 
 						dumpDone <- true
+						close(actionScriptDone)
 
 						closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
 						return c.WriteMessage(websocket.CloseMessage, closeMsg)
@@ -5643,6 +5818,8 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 						DumpDir:      "final",
 						StateDir:     checkpointDir,
 						Function:     "migration",
+						LazyPages:    lazyPages,
+						LazyPort:     lazyPort,
 					}
 
 					// Do the final CRIU dump. This is needs no special handling if
@@ -5689,6 +5866,14 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 				return err
 			}
 
+			// Serve the memory pages to the target while it restores the container.
+			if lazyPages {
+				lazyPagesDone = make(chan error, 1)
+				go func() {
+					lazyPagesDone <- d.migrationSendLazyPages(ctx, lazyPort, stateConn)
+				}()
+			}
+
 			d.logger.Debug("Finished live migration phase")
 		}
 
@@ -5710,6 +5895,15 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 			d.logger.Debug("Finished final storage migration phase")
 		}
 
+		if lazyPagesDone != nil {
+			err = <-lazyPagesDone
+			if err != nil {
+				return fmt.Errorf("Failed sending lazy pages: %w", err)
+			}
+
+			d.logger.Debug("Finished lazy pages transfer")
+		}
+
 		return nil
 	})
 
@@ -5732,6 +5926,27 @@ func (d *lxc) MigrateSend(args instance.MigrateSendArgs) error {
 	}
 }
 
+// migrationSendLazyPages connects to the local CRIU page server and forwards the memory pages to the target.
+func (d *lxc) migrationSendLazyPages(ctx context.Context, port int, stateConn io.ReadWriteCloser) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// The page server is only started once the dump is complete.
+	var dialer net.Dialer
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			return d.migrationLazyPagesProxy(conn, stateConn)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Failed connecting to CRIU page server: %w", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
 type preDumpLoopArgs struct {
 	stateConn     io.ReadWriteCloser
 	checkpointDir string
@@ -5944,6 +6159,11 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 		return err
 	}
 
+	// The block stream requires the volume to be idle, so can't be used for live migrations.
+	if args.Live {
+		respTypes = localMigration.RemoveFeature(respTypes, migration.RsyncFeatureBlockStream)
+	}
+
 	// The migration header to be sent back to source with our target options.
 	// Convert response type to response header and copy snapshot info into it.
 	respHeader := localMigration.TypesToHeader(respTypes...)
@@ -6015,7 +6235,11 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 		offerHeader.SnapshotNames = syncSnapshotNames
 	}
 
-	if offerHeader.GetPredump() {
+	if offerHeader.GetLazyPages() && d.migrationCheckLazyPagesSupport() {
+		// If the other side wants lazy pages and if this side supports it, let's use it instead of pre-dumps.
+		respHeader.LazyPages = proto.Bool(true)
+		respHeader.Predump = proto.Bool(false)
+	} else if offerHeader.GetPredump() {
 		// If the other side wants pre-dump and if this side supports it, let's use it.
 		respHeader.Predump = proto.Bool(true)
 	} else {
@@ -6329,6 +6553,15 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 				return err
 			}
 
+			// Start receiving the memory pages from the source.
+			var lazyPagesWait func() error
+			if respHeader.GetLazyPages() {
+				lazyPagesWait, err = d.migrationReceiveLazyPages(ctx, filepath.Join(imagesDir, "final"), stateConn)
+				if err != nil {
+					return fmt.Errorf("Failed starting CRIU lazy pages daemon: %w", err)
+				}
+			}
+
 			criuMigrationArgs := instance.CriuMigrationArgs{
 				Cmd:          liblxc.MIGRATE_RESTORE,
 				StateDir:     imagesDir,
@@ -6337,6 +6570,7 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 				ActionScript: false,
 				DumpDir:      "final",
 				PreDumpDir:   "",
+				LazyPages:    lazyPagesWait != nil,
 			}
 
 			// Currently we only do a single CRIU pre-dump so we can hardcode "final"
@@ -6346,6 +6580,16 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 				return err
 			}
 
+			// The restored container keeps faulting in its memory until all pages are received.
+			if lazyPagesWait != nil {
+				err = lazyPagesWait()
+				if err != nil {
+					return fmt.Errorf("Failed receiving lazy pages: %w", err)
+				}
+
+				d.logger.Debug("Finished lazy pages transfer")
+			}
+
 			return nil
 		})
 	}
@@ -6401,6 +6645,85 @@ func (d *lxc) MigrateReceive(args instance.MigrateReceiveArgs) error {
 	}
 }
 
+// criuGhostLimit is the maximum size of deleted files to be dumped by CRIU.
+// TODO: make this configurable? Ultimately I think we don't
+// want to do that; what we really want to do is have "modes"
+// of criu operation where one is "make this succeed" and the
+// other is "make this fast". Anyway, for now, let's choose a
+// really big size so it almost always succeeds, even if it is
+// slow.
+const criuGhostLimit = uint64(256 * 1024 * 1024)
+
+// migrationReceiveLazyPages starts the CRIU lazy pages daemon, fetching the memory pages from the source, and
+// returns a function waiting for all of them to be received.
+func (d *lxc) migrationReceiveLazyPages(ctx context.Context, imagesDir string, stateConn io.ReadWriteCloser) (func() error, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = listener.Close() }()
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	cmd := exec.CommandContext(ctx, "criu", "lazy-pages", "--page-server", "--address", "127.0.0.1", "--port", fmt.Sprintf("%d", port), "--images-dir", imagesDir)
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	cmdDone := make(chan error, 1)
+	go func() {
+		cmdDone <- cmd.Wait()
+	}()
+
+	revert.Add(func() { _ = cmd.Process.Kill() })
+
+	// Wait for the daemon to connect to the page server and forward it to the source.
+	err = listener.(*net.TCPListener).SetDeadline(time.Now().Add(30 * time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("Failed waiting for CRIU lazy pages daemon: %w", err)
+	}
+
+	proxyDone := make(chan error, 1)
+	go func() {
+		proxyDone <- d.migrationLazyPagesProxy(conn, stateConn)
+	}()
+
+	revert.Add(func() { _ = conn.Close() })
+
+	// Wait for the daemon to be ready to serve the restore.
+	socketPath := filepath.Join(imagesDir, "lazy-pages.socket")
+	timeout := time.After(30 * time.Second)
+	for !util.PathExists(socketPath) {
+		select {
+		case err := <-cmdDone:
+			return nil, fmt.Errorf("CRIU lazy pages daemon exited early: %w", err)
+		case <-timeout:
+			return nil, fmt.Errorf("Timed out waiting for CRIU lazy pages daemon")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	revert.Success()
+
+	return func() error {
+		err := <-cmdDone
+		if err != nil {
+			return err
+		}
+
+		return <-proxyDone
+	}, nil
+}
+
 // Migrate migrates the instance to another node.
 func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 	ctxMap := logger.Ctx{
@@ -6411,6 +6734,7 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 		"actionscript": args.ActionScript,
 		"predumpdir":   args.PreDumpDir,
 		"features":     args.Features,
+		"lazypages":    args.LazyPages,
 		"stop":         args.Stop}
 
 	_, err := exec.LookPath("criu")
@@ -6450,6 +6774,15 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 	finalStateDir := args.StateDir
 	var migrateErr error
 
+	// Lazy pages are configured through a CRIU configuration file as liblxc has no support for them.
+	var criuEnv []string
+	if args.LazyPages {
+		criuEnv, err = d.migrationLazyPagesEnv(args)
+		if err != nil {
+			return err
+		}
+	}
+
 	/* For restore, we need an extra fork so that we daemonize the monitor
 	 * instead of having it be a child. So let's hijack the command
 	 * here and do the extra fork.
@@ -6504,7 +6837,10 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 			finalStateDir = fmt.Sprintf("%s/%s", args.StateDir, args.DumpDir)
 		}
 
-		_, migrateErr = subprocess.RunCommand(
+		_, _, migrateErr = subprocess.RunCommandSplit(
+			context.TODO(),
+			criuEnv,
+			nil,
 			d.state.OS.ExecPath,
 			"forkmigrate",
 			d.name,
@@ -6528,6 +6864,31 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 				return err
 			}
 		}
+	} else if args.LazyPages {
+		if args.DumpDir != "" {
+			finalStateDir = fmt.Sprintf("%s/%s", args.StateDir, args.DumpDir)
+		}
+
+		script := ""
+		if args.ActionScript {
+			script = filepath.Join(args.StateDir, "action.sh")
+		}
+
+		// The lazy dump has to run in a separate process to get its own CRIU environment.
+		_, _, migrateErr = subprocess.RunCommandSplit(
+			context.TODO(),
+			criuEnv,
+			nil,
+			d.state.OS.ExecPath,
+			"forkcheckpoint",
+			d.name,
+			d.state.OS.LxcPath,
+			filepath.Join(d.LogPath(), "lxc.conf"),
+			finalStateDir,
+			fmt.Sprintf("%v", preservesInodes),
+			fmt.Sprintf("%d", criuGhostLimit),
+			script,
+		)
 	} else {
 		// Load the go-lxc struct
 		var cc *liblxc.Container
@@ -6557,21 +6918,13 @@ func (d *lxc) migrate(args *instance.CriuMigrationArgs) error {
 			finalStateDir = fmt.Sprintf("%s/%s", args.StateDir, args.DumpDir)
 		}
 
-		// TODO: make this configurable? Ultimately I think we don't
-		// want to do that; what we really want to do is have "modes"
-		// of criu operation where one is "make this succeed" and the
-		// other is "make this fast". Anyway, for now, let's choose a
-		// really big size so it almost always succeeds, even if it is
-		// slow.
-		ghostLimit := uint64(256 * 1024 * 1024)
-
 		opts := liblxc.MigrateOptions{
 			Stop:            args.Stop,
 			Directory:       finalStateDir,
 			Verbose:         true,
 			PreservesInodes: preservesInodes,
 			ActionScript:    script,
-			GhostLimit:      ghostLimit,
+			GhostLimit:      criuGhostLimit,
 		}
 
 		if args.PreDumpDir != "" {
@@ -6667,6 +7020,12 @@ func (d *lxc) templateApplyNow(trigger instance.TemplateTrigger) error {
 		containerMeta["privileged"] = "false"
 	}
 
+	// Render the cloud-init configuration
+	cloudInitConfig, err := instance.CloudInitConfig(d)
+	if err != nil {
+		return err
+	}
+
 	// Go through the templates
 	for tplPath, tpl := range metadata.Templates {
 		err = func(tplPath string, tpl *api.ImageMetadataTemplate) error {
@@ -6779,7 +7138,7 @@ func (d *lxc) templateApplyNow(trigger instance.TemplateTrigger) error {
 			}
 
 			configGet := func(confKey, confDefault *pongo2.Value) *pongo2.Value {
-				val, ok := d.expandedConfig[confKey.String()]
+				val, ok := cloudInitConfig[confKey.String()]
 				if !ok {
 					return confDefault
 				}
@@ -7293,16 +7652,144 @@ func (d *lxc) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, st
 
 	d.logger.Debug("Retrieved PID of executing child process", logger.Ctx{"attachedPid": attachedPid})
 
+	cgroupPath := ""
+	if req.LimitsCPU != "" || req.LimitsMemory != "" {
+		cgroupPath, err = d.execLimits(int(attachedPid), req.LimitsCPU, req.LimitsMemory)
+		if err != nil {
+			_ = unix.Kill(int(attachedPid), unix.SIGKILL)
+			_ = cmd.Wait()
+			return nil, fmt.Errorf("Failed applying exec limits: %w", err)
+		}
+	}
+
 	d.state.Events.SendLifecycle(d.project.Name, lifecycle.InstanceExec.Event(d, logger.Ctx{"command": req.Command}))
 
 	instCmd := &lxcCmd{
 		cmd:              &cmd,
 		attachedChildPid: int(attachedPid),
+		cgroupPath:       cgroupPath,
 	}
 
 	return instCmd, nil
 }
 
+// execLimits moves the executing command into a transient sub-cgroup of the container applying the CPU and memory
+// limits, returning the path of the sub-cgroup to remove once the command is done.
+func (d *lxc) execLimits(pid int, cpuLimit string, memoryLimit string) (string, error) {
+	if d.state.OS.CGInfo.Layout != cgroup.CgroupsUnified {
+		return "", fmt.Errorf("Exec limits require a host using the unified cgroup hierarchy")
+	}
+
+	// Locate the container's cgroup.
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", d.InitPID()))
+	if err != nil {
+		return "", err
+	}
+
+	parentPath := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		path, ok := strings.CutPrefix(strings.TrimSpace(line), "0::")
+		if ok {
+			parentPath = filepath.Join("/sys/fs/cgroup", strings.TrimSuffix(path, "/init.scope"))
+			break
+		}
+	}
+
+	if parentPath == "" {
+		return "", fmt.Errorf("Couldn't find the cgroup of the container")
+	}
+
+	// Delegate the controllers to the sub-cgroups. This fails when processes of the container are directly in its
+	// cgroup, which is only avoided by init systems managing their own cgroups (such as systemd).
+	controllers := []string{}
+	if cpuLimit != "" {
+		controllers = append(controllers, "+cpu")
+	}
+
+	if memoryLimit != "" {
+		controllers = append(controllers, "+memory")
+	}
+
+	err = os.WriteFile(filepath.Join(parentPath, "cgroup.subtree_control"), []byte(strings.Join(controllers, " ")), 0600)
+	if err != nil {
+		return "", fmt.Errorf("Failed enabling the cgroup controllers of the container (its init system must run processes in sub-cgroups): %w", err)
+	}
+
+	cgroupPath := filepath.Join(parentPath, fmt.Sprintf(".incus-exec-%d", pid))
+	err = os.Mkdir(cgroupPath, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() { _ = os.Remove(cgroupPath) })
+
+	cg, err := cgroup.NewFileReadWriterPath(cgroupPath)
+	if err != nil {
+		return "", err
+	}
+
+	if cpuLimit != "" {
+		cpuShares, cpuCfsQuota, cpuCfsPeriod, err := cgroup.ParseCPU(cpuLimit, "")
+		if err != nil {
+			return "", err
+		}
+
+		err = cg.SetCPUShare(cpuShares)
+		if err != nil {
+			return "", err
+		}
+
+		err = cg.SetCPUCfsLimit(cpuCfsPeriod, cpuCfsQuota)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if memoryLimit != "" {
+		var limit int64
+		if strings.HasSuffix(memoryLimit, "%") {
+			percent, err := strconv.ParseInt(strings.TrimSuffix(memoryLimit, "%"), 10, 64)
+			if err != nil {
+				return "", err
+			}
+
+			parent, err := cgroup.NewFileReadWriterPath(parentPath)
+			if err != nil {
+				return "", err
+			}
+
+			memoryTotal, err := parent.GetEffectiveMemoryLimit()
+			if err != nil {
+				return "", err
+			}
+
+			limit = (memoryTotal / 100) * percent
+		} else {
+			limit, err = units.ParseByteSizeString(memoryLimit)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		err = cg.SetMemoryLimit(limit)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = os.WriteFile(filepath.Join(cgroupPath, "cgroup.procs"), []byte(strconv.Itoa(pid)), 0600)
+	if err != nil {
+		return "", fmt.Errorf("Failed moving the command into its cgroup: %w", err)
+	}
+
+	revert.Success()
+
+	return cgroupPath, nil
+}
+
 func (d *lxc) cpuState() api.InstanceStateCPU {
 	cpu := api.InstanceStateCPU{}
 
@@ -8360,6 +8847,25 @@ func (d *lxc) Info() instance.Info {
 	}
 }
 
+// OOMKills returns the number of processes of the running container killed by the out of memory killer.
+func (d *lxc) OOMKills() (int64, error) {
+	if !d.IsRunning() {
+		return -1, ErrInstanceIsStopped
+	}
+
+	cc, err := d.initLXC(false)
+	if err != nil {
+		return -1, err
+	}
+
+	cg, err := d.cgroup(cc, true)
+	if err != nil {
+		return -1, err
+	}
+
+	return cg.GetOOMKills()
+}
+
 func (d *lxc) Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, error) {
 	out := metrics.NewMetricSet(map[string]string{"project": d.project.Name, "name": d.name, "type": instancetype.Container.String()})
 
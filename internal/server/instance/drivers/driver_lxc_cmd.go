@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"os"
 	"os/exec"
 
 	"golang.org/x/sys/unix"
@@ -13,6 +14,7 @@ import (
 type lxcCmd struct {
 	attachedChildPid int
 	cmd              *exec.Cmd
+	cgroupPath       string // Transient cgroup applying the exec limits, if any.
 }
 
 // PID returns the attached child's process ID.
@@ -35,6 +37,11 @@ func (c *lxcCmd) Signal(sig unix.Signal) error {
 func (c *lxcCmd) Wait() (int, error) {
 	exitStatus, err := linux.ExitStatus(c.cmd.Wait())
 
+	// Remove the transient cgroup, which is kept if processes started by the command are still running.
+	if c.cgroupPath != "" {
+		_ = os.Remove(c.cgroupPath)
+	}
+
 	// Convert special exit statuses into errors.
 	switch exitStatus {
 	case 127:
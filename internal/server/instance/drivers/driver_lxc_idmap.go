@@ -0,0 +1,277 @@
+package drivers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/server/db"
+	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	"github.com/lxc/incus/v6/internal/server/instance"
+	"github.com/lxc/incus/v6/internal/server/instance/instancetype"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/state"
+	storagePools "github.com/lxc/incus/v6/internal/server/storage"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/idmap"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// idmapRange returns the first host ID and the number of host IDs mapped into the container.
+// Privileged containers don't map any ID and get a zero range.
+func idmapRange(s *state.State, inst instance.Instance) (int64, int64, error) {
+	if inst.IsPrivileged() || s.OS.IdmapSet == nil || len(s.OS.IdmapSet.Entries) == 0 {
+		return 0, 0, nil
+	}
+
+	config := inst.ExpandedConfig()
+
+	size, err := idmapSize(s, config["security.idmap.isolated"], config["security.idmap.size"])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Non-isolated containers all use the default map.
+	if util.IsFalseOrEmpty(config["security.idmap.isolated"]) {
+		return s.OS.IdmapSet.Entries[0].HostID, size, nil
+	}
+
+	base := int64(0)
+	if config["volatile.idmap.base"] != "" {
+		base, err = strconv.ParseInt(config["volatile.idmap.base"], 10, 64)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return base, size, nil
+}
+
+// idmapRangeString returns a human readable form of a range of host IDs.
+func idmapRangeString(base int64, size int64) string {
+	if size == 0 {
+		return "no ID mapping"
+	}
+
+	return fmt.Sprintf("host IDs %d-%d", base, base+size-1)
+}
+
+// idmapConflicts returns a description of each conflict between the given range of host IDs for the container and
+// the other containers, either because the ranges of isolated containers overlap or because a custom volume is
+// shared with a container using a different range.
+func idmapConflicts(s *state.State, inst instance.Instance, isolated bool, base int64, size int64) ([]string, error) {
+	conflicts := []string{}
+
+	if isolated && size > 0 {
+		// Check that the range is part of the IDs allocated to the daemon.
+		host := s.OS.IdmapSet.Entries[0]
+		if base < host.HostID || base+size > host.HostID+host.MapRange {
+			conflicts = append(conflicts, fmt.Sprintf("Host IDs %d-%d are outside of the host IDs %d-%d allocated to Incus", base, base+size-1, host.HostID, host.HostID+host.MapRange-1))
+		}
+
+		cts, err := instance.LoadNodeAll(s, instancetype.Container)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ct := range cts {
+			if ct.Project().Name == inst.Project().Name && ct.Name() == inst.Name() {
+				continue
+			}
+
+			if ct.IsPrivileged() || util.IsFalseOrEmpty(ct.ExpandedConfig()["security.idmap.isolated"]) {
+				continue
+			}
+
+			ctBase, ctSize, err := idmapRange(s, ct)
+			if err != nil {
+				return nil, err
+			}
+
+			// Identical ranges are used on purpose to share volumes between containers.
+			if ctBase == base && ctSize == size {
+				continue
+			}
+
+			if base < ctBase+ctSize && ctBase < base+size {
+				conflicts = append(conflicts, fmt.Sprintf("Host IDs %d-%d overlap with %s of instance %q in project %q", base, base+size-1, idmapRangeString(ctBase, ctSize), ct.Name(), ct.Project().Name))
+			}
+		}
+	}
+
+	// Check the containers sharing custom volumes, as those get shifted to the ID map of the container using them.
+	for _, dev := range inst.ExpandedDevices().Sorted() {
+		if dev.Config["type"] != "disk" || dev.Config["pool"] == "" || dev.Config["source"] == "" {
+			continue
+		}
+
+		volumeName := strings.TrimPrefix(dev.Config["source"], db.StoragePoolVolumeTypeNameCustom+"/")
+		if strings.Contains(volumeName, "/") {
+			continue
+		}
+
+		pool, err := storagePools.LoadByName(s, dev.Config["pool"])
+		if err != nil {
+			return nil, err
+		}
+
+		storageProjectName, err := project.StorageVolumeProject(s.DB.Cluster, inst.Project().Name, db.StoragePoolVolumeTypeCustom)
+		if err != nil {
+			return nil, err
+		}
+
+		var dbVolume *db.StorageVolume
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			dbVolume, err = tx.GetStoragePoolVolume(ctx, pool.ID(), storageProjectName, db.StoragePoolVolumeTypeCustom, volumeName, true)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed loading custom volume %q on pool %q: %w", volumeName, pool.Name(), err)
+		}
+
+		// Volumes mounted through idmapped mounts or without any mapping don't get shifted.
+		if util.IsTrue(dbVolume.Config["security.shifted"]) || util.IsTrue(dbVolume.Config["security.unmapped"]) || dbVolume.ContentType != db.StoragePoolVolumeContentTypeNameFS {
+			continue
+		}
+
+		err = storagePools.VolumeUsedByInstanceDevices(s, pool.Name(), storageProjectName, &dbVolume.StorageVolume, true, func(dbInst db.InstanceArgs, p api.Project, usedByDevices []string) error {
+			if dbInst.Type != instancetype.Container || (dbInst.Project == inst.Project().Name && dbInst.Name == inst.Name()) {
+				return nil
+			}
+
+			ct, err := instance.Load(s, dbInst, p)
+			if err != nil {
+				return err
+			}
+
+			ctBase, ctSize, err := idmapRange(s, ct)
+			if err != nil {
+				return err
+			}
+
+			if ctBase != base || ctSize != size {
+				conflicts = append(conflicts, fmt.Sprintf("Custom volume %q on pool %q is shared with instance %q in project %q which uses %s instead of %s", volumeName, pool.Name(), ct.Name(), ct.Project().Name, idmapRangeString(ctBase, ctSize), idmapRangeString(base, size)))
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return conflicts, nil
+}
+
+// idmapEntries converts an ID map to its API representation.
+func idmapEntries(set *idmap.Set) []api.InstanceIdmapEntry {
+	entries := []api.InstanceIdmapEntry{}
+	if set == nil {
+		return entries
+	}
+
+	for _, entry := range set.Entries {
+		entryType := "both"
+		if !entry.IsUID {
+			entryType = "gid"
+		} else if !entry.IsGID {
+			entryType = "uid"
+		}
+
+		entries = append(entries, api.InstanceIdmapEntry{
+			Type:   entryType,
+			NSID:   entry.NSID,
+			HostID: entry.HostID,
+			Range:  entry.MapRange,
+		})
+	}
+
+	return entries
+}
+
+// Idmap returns the ID map of the container along with its conflicts with other containers.
+func (d *lxc) Idmap() (*api.InstanceIdmap, error) {
+	info := api.InstanceIdmap{
+		Current:   []api.InstanceIdmapEntry{},
+		Next:      []api.InstanceIdmapEntry{},
+		Conflicts: []string{},
+	}
+
+	info.Isolated = util.IsTrue(d.expandedConfig["security.idmap.isolated"])
+
+	// Privileged containers don't use an ID map.
+	if d.IsPrivileged() {
+		return &info, nil
+	}
+
+	current, err := d.CurrentIdmap()
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := d.NextIdmap()
+	if err != nil {
+		return nil, err
+	}
+
+	info.Current = idmapEntries(current)
+	info.Next = idmapEntries(next)
+
+	base, size, err := idmapRange(d.state, d)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Isolated {
+		info.Base = base
+		info.Size = size
+	}
+
+	info.Conflicts, err = idmapConflicts(d.state, d, info.Isolated, base, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// idmapDisksAdded returns whether any of the devices is a disk backed by a storage pool volume.
+func (d *lxc) idmapDisksAdded(devices deviceConfig.Devices) bool {
+	for _, dev := range devices {
+		if dev["type"] == "disk" && dev["pool"] != "" && dev["source"] != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// idmapValidate checks the next ID map of the container for conflicts with other containers.
+func (d *lxc) idmapValidate() error {
+	isolated := util.IsTrue(d.expandedConfig["security.idmap.isolated"])
+
+	size, err := idmapSize(d.state, d.expandedConfig["security.idmap.isolated"], d.expandedConfig["security.idmap.size"])
+	if err != nil {
+		return err
+	}
+
+	base := d.state.OS.IdmapSet.Entries[0].HostID
+	if isolated {
+		base, err = strconv.ParseInt(d.localConfig["volatile.idmap.base"], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Failed parsing ID map base: %w", err)
+		}
+	}
+
+	conflicts, err := idmapConflicts(d.state, d, isolated, base, size)
+	if err != nil {
+		return fmt.Errorf("Failed checking ID map conflicts: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("ID map conflicts with other containers: %s", strings.Join(conflicts, "; "))
+	}
+
+	return nil
+}
@@ -55,6 +55,8 @@ import (
 	"github.com/lxc/incus/v6/internal/server/device"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
 	"github.com/lxc/incus/v6/internal/server/device/nictype"
+	pcidev "github.com/lxc/incus/v6/internal/server/device/pci"
+	"github.com/lxc/incus/v6/internal/server/hugepages"
 	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/edk2"
 	"github.com/lxc/incus/v6/internal/server/instance/drivers/qmp"
@@ -100,6 +102,9 @@ const QEMUDefaultMemSize = "1GiB"
 // qemuSerialChardevName is used to communicate state with QEMU via QMP.
 const qemuSerialChardevName = "qemu_serial-chardev"
 
+// qemuAudioName is the audio backend of the sound card, sending its audio to the SPICE clients.
+const qemuAudioName = "qemu_audio"
+
 // qemuPCIDeviceIDStart is the first PCI slot used for user configurable devices.
 const qemuPCIDeviceIDStart = 4
 
@@ -112,6 +117,9 @@ const qemuNetDevIDPrefix = "incus_"
 // qemuBlockDevIDPrefix used as part of the name given QEMU blockdevs generated from user added devices.
 const qemuBlockDevIDPrefix = "incus_"
 
+// qemuNVMeControllerIDPrefix used as part of the name given to NVMe controllers shared by several disk devices.
+const qemuNVMeControllerIDPrefix = "qemu_nvme-"
+
 // qemuMigrationNBDExportName is the name of the disk device export by the migration NBD server.
 const qemuMigrationNBDExportName = "incus_root"
 
@@ -119,6 +127,16 @@ const qemuMigrationNBDExportName = "incus_root"
 // 4 are reserved, and the other 4 can be used for any USB device.
 const qemuSparseUSBPorts = 8
 
+// qemuWatchdogActions maps the actions of watchdog devices to the QEMU watchdog actions.
+// Resets are turned into reboots by the reboot action.
+var qemuWatchdogActions = map[string]string{
+	"":        "reset",
+	"restart": "reset",
+	"stop":    "poweroff",
+	"pause":   "pause",
+	"none":    "none",
+}
+
 var errQemuAgentOffline = fmt.Errorf("VM agent isn't currently running")
 
 type monitorHook func(m *qmp.Monitor) error
@@ -391,7 +409,7 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 	state := d.state
 
 	return func(event string, data map[string]any) {
-		if !slices.Contains([]string{qmp.EventVMShutdown, qmp.EventAgentStarted}, event) {
+		if !slices.Contains([]string{qmp.EventVMShutdown, qmp.EventAgentStarted, qmp.EventGuestPanicked, qmp.EventWatchdog}, event) {
 			return // Don't bother loading the instance from DB if we aren't going to handle the event.
 		}
 
@@ -424,6 +442,42 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 				d.logger.Warn("Failed to advertise vsock address to instance agent", logger.Ctx{"err": err})
 				return
 			}
+		} else if event == qmp.EventGuestPanicked {
+			crash := api.InstanceCrash{
+				Type:      api.InstanceCrashPanic,
+				Timestamp: time.Now().UTC(),
+				Details:   map[string]string{},
+			}
+
+			action, ok := data["action"].(string)
+			if ok {
+				crash.Details["action"] = action
+			}
+
+			info, ok := data["info"].(map[string]any)
+			if ok {
+				for k, v := range info {
+					crash.Details[k] = fmt.Sprintf("%v", v)
+				}
+			}
+
+			err = d.RecordCrash(crash)
+			if err != nil {
+				d.logger.Error("Failed recording guest panic", logger.Ctx{"err": err})
+				return
+			}
+		} else if event == qmp.EventWatchdog {
+			action, _ := data["action"].(string)
+
+			err = d.RecordCrash(api.InstanceCrash{
+				Type:      api.InstanceCrashWatchdog,
+				Timestamp: time.Now().UTC(),
+				Details:   map[string]string{"action": action},
+			})
+			if err != nil {
+				d.logger.Error("Failed recording watchdog expiry", logger.Ctx{"err": err})
+				return
+			}
 		} else if event == qmp.EventVMShutdown {
 			target := "stop"
 			entry, ok := data["reason"]
@@ -431,13 +485,28 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 				target = "reboot"
 			}
 
+			crashPID := 0
 			if entry == qmp.EventVMShutdownReasonDisconnect {
 				d.logger.Warn("Instance stopped", logger.Ctx{"target": target, "reason": data["reason"]})
+
+				// The QEMU process going away outside of an operation stopping it means that it crashed.
+				op := operationlock.Get(d.Project().Name, d.Name())
+				if op == nil || !op.ActionMatch(operationlock.ActionStart, operationlock.ActionStop, operationlock.ActionRestart, operationlock.ActionRestore) {
+					pidStr, err := os.ReadFile(d.pidFilePath())
+					if err == nil {
+						crashPID, _ = strconv.Atoi(strings.TrimSpace(string(pidStr)))
+					}
+				}
 			} else {
 				d.logger.Debug("Instance stopped", logger.Ctx{"target": target, "reason": data["reason"]})
 			}
 
 			err = d.onStop(target)
+
+			if crashPID > 0 {
+				d.onProcessCrash(crashPID)
+			}
+
 			if err != nil {
 				d.logger.Error("Failed to cleanly stop instance", logger.Ctx{"err": err})
 				return
@@ -446,6 +515,29 @@ func (d *qemu) getMonitorEventHandler() func(event string, data map[string]any)
 	}
 }
 
+// onProcessCrash captures the core dump and logs of the crashed QEMU process and records the crash.
+func (d *qemu) onProcessCrash(pid int) {
+	files, err := d.captureCrashDump(pid, []string{d.EarlyLogFilePath(), d.LogFilePath()})
+	if err != nil {
+		d.logger.Error("Failed capturing crash dump", logger.Ctx{"pid": pid, "err": err})
+	}
+
+	crash := api.InstanceCrash{
+		Type:      api.InstanceCrashProcess,
+		Timestamp: time.Now().UTC(),
+		Details:   map[string]string{"pid": strconv.Itoa(pid)},
+	}
+
+	if len(files) > 0 {
+		crash.Details["files"] = strings.Join(files, ",")
+	}
+
+	err = d.RecordCrash(crash)
+	if err != nil {
+		d.logger.Error("Failed recording process crash", logger.Ctx{"err": err})
+	}
+}
+
 // mount the instance's config volume if needed.
 func (d *qemu) mount() (*storagePools.MountInfo, error) {
 	var pool storagePools.Pool
@@ -856,24 +948,25 @@ func (d *qemu) restoreState(monitor *qmp.Monitor) error {
 			defer func() { _ = filesystemConn.Close() }()
 		}
 
+		// Post-copy must be allowed on both sides of the migration.
+		postcopy := util.IsTrue(d.expandedConfig["migration.stateful.postcopy"])
+		if postcopy {
+			err := monitor.MigrateSetCapabilities(map[string]bool{"postcopy-ram": true})
+			if err != nil {
+				return fmt.Errorf("Failed setting migration capabilities: %w", err)
+			}
+		}
+
 		// Receive checkpoint from QEMU process on source.
 		d.logger.Debug("Stateful migration checkpoint receive starting")
-		pipeRead, pipeWrite, err := os.Pipe()
+		stateFile, stateCleanup, err := d.migrationStateChannel(stateConn, false, postcopy)
 		if err != nil {
 			return err
 		}
 
-		go func() {
-			_, err := io.Copy(pipeWrite, stateConn)
-			if err != nil {
-				d.logger.Warn("Failed reading from state connection", logger.Ctx{"err": err})
-			}
+		defer stateCleanup()
 
-			_ = pipeRead.Close()
-			_ = pipeWrite.Close()
-		}()
-
-		err = d.restoreStateHandle(context.Background(), monitor, pipeRead)
+		err = d.restoreStateHandle(context.Background(), monitor, stateFile)
 		if err != nil {
 			return fmt.Errorf("Failed restoring checkpoint from source: %w", err)
 		}
@@ -1019,6 +1112,29 @@ func (d *qemu) validateStartup(stateful bool, statusCode api.StatusCode) error {
 		return fmt.Errorf("Secure boot can't be enabled while CSM is turned on. Please set security.secureboot=false on the instance")
 	}
 
+	// Ensure the confidential computing options are consistent.
+	if util.IsTrue(d.expandedConfig["security.sev.snp"]) && util.IsFalseOrEmpty(d.expandedConfig["security.sev"]) {
+		return fmt.Errorf("AMD SEV-SNP requires security.sev to be enabled")
+	}
+
+	if util.IsTrue(d.expandedConfig["security.tdx"]) && util.IsTrue(d.expandedConfig["security.sev"]) {
+		return fmt.Errorf("Intel TDX and AMD SEV can't be enabled at the same time")
+	}
+
+	if d.usesStatelessFirmware() {
+		if util.IsTrueOrEmpty(d.expandedConfig["security.secureboot"]) {
+			return fmt.Errorf("Secure boot can't be enabled on AMD SEV-SNP or Intel TDX guests. Please set security.secureboot=false on the instance")
+		}
+
+		if util.IsTrue(d.expandedConfig["security.csm"]) {
+			return fmt.Errorf("CSM can't be enabled on AMD SEV-SNP or Intel TDX guests")
+		}
+
+		if util.IsTrue(d.expandedConfig["migration.stateful"]) {
+			return fmt.Errorf("Stateful migration can't be enabled on AMD SEV-SNP or Intel TDX guests")
+		}
+	}
+
 	// gendoc:generate(entity=image, group=requirements, key=requirements.cdrom_agent)
 	//
 	// ---
@@ -1135,6 +1251,26 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		}
 	}
 
+	// Pick the CPUs if needed.
+	if d.expandedConfig["limits.cpu.allocation"] == "numa-auto" {
+		err := d.allocateCPUs()
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+	}
+
+	// Reserve the huge pages backing the memory until it's allocated.
+	if util.IsTrue(d.expandedConfig["limits.memory.hugepages"]) {
+		release, err := d.reserveHugepages()
+		if err != nil {
+			op.Done(err)
+			return err
+		}
+
+		defer release()
+	}
+
 	// Ensure the correct vhost_vsock kernel module is loaded before establishing the vsock.
 	err = linux.LoadModule("vhost_vsock")
 	if err != nil {
@@ -1202,6 +1338,11 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		volatileSet["volatile.vsock_id"] = newVsockID
 	}
 
+	// Forget the features of the previous agent, they get reported again once it starts.
+	if d.localConfig["volatile.agent.features"] != "" {
+		volatileSet["volatile.agent.features"] = ""
+	}
+
 	// Generate UUID if not present (do this before UpdateBackupFile() call).
 	instUUID := d.localConfig["volatile.uuid"]
 	if instUUID == "" {
@@ -1390,7 +1531,12 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 	}
 
 	// Get CPU information.
-	cpuInfo, err := d.cpuTopology(d.expandedConfig["limits.cpu"])
+	cpuLimit := d.expandedConfig["limits.cpu"]
+	if d.expandedConfig["limits.cpu.allocation"] == "numa-auto" {
+		cpuLimit = d.expandedConfig["volatile.cpu.allocation"]
+	}
+
+	cpuInfo, err := d.cpuTopology(cpuLimit)
 	if err != nil {
 		return err
 	}
@@ -1439,11 +1585,19 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		"-no-user-config",
 		"-sandbox", "on,obsolete=deny,elevateprivileges=allow,spawn=allow,resourcecontrol=deny",
 		"-readconfig", confFile,
-		"-spice", d.spiceCmdlineConfig(),
 		"-pidfile", d.pidFilePath(),
 		"-D", d.LogFilePath(),
 	}
 
+	if d.spiceEnabled() {
+		qemuCmd = append(qemuCmd, "-spice", d.spiceCmdlineConfig())
+
+	}
+
+	if d.spiceAudioEnabled() {
+		qemuCmd = append(qemuCmd, "-audiodev", fmt.Sprintf("spice,id=%s", qemuAudioName))
+	}
+
 	// If stateful, restore now.
 	if stateful {
 		if d.stateful {
@@ -1554,6 +1708,11 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		forkLimitsCmd = append(forkLimitsCmd, "limit=memlock:unlimited:unlimited")
 	}
 
+	if d.state.GlobalConfig.InstancesCrashDumpsRetention() > 0 {
+		// Allow the process to dump core so that crashes can be captured.
+		forkLimitsCmd = append(forkLimitsCmd, "limit=core:unlimited:unlimited")
+	}
+
 	for i := range fdFiles {
 		// Pass through any file descriptors as 3+i (as first 3 file descriptors are taken as standard).
 		forkLimitsCmd = append(forkLimitsCmd, fmt.Sprintf("fd=%d", 3+i))
@@ -1699,6 +1858,13 @@ func (d *qemu) start(stateful bool, op *operationlock.InstanceOperation) error {
 		"panic":    "pause",    // Pause on panics to allow investigation.
 	}
 
+	// Apply the action of the watchdog device.
+	for _, dev := range d.expandedDevices.Sorted() {
+		if dev.Config["type"] == "watchdog" {
+			actions["watchdog"] = qemuWatchdogActions[dev.Config["action"]]
+		}
+	}
+
 	err = monitor.SetAction(actions)
 	if err != nil {
 		op.Done(err)
@@ -1837,7 +2003,22 @@ func (d *qemu) setupSEV(fdFiles *[]*os.File) (*qemuSevOpts, error) {
 		sevOpts.sessionDataFD = fmt.Sprintf("/proc/self/fd/%d", sessionDataFD)
 	}
 
-	if util.IsTrue(d.expandedConfig["security.sev.policy.es"]) {
+	if util.IsTrue(d.expandedConfig["security.sev.snp"]) {
+		_, sevSNP := info.Features["sev-snp"]
+		if !sevSNP {
+			return nil, errors.New("AMD SEV-SNP is not supported by the host")
+		}
+
+		if sevOpts.dhCertFD != "" {
+			return nil, errors.New("AMD SEV session data can't be used with SEV-SNP")
+		}
+
+		// This bit mask is used to specify a SEV-SNP guest policy. '0x30000' allows SMT (bit 16) and sets the
+		// reserved bit 17. The details of the available policies can be found in the SEV-SNP firmware ABI
+		// specification (see chapter 4.3).
+		sevOpts.snp = true
+		sevOpts.policy = "0x30000"
+	} else if util.IsTrue(d.expandedConfig["security.sev.policy.es"]) {
 		_, sevES := info.Features["sev-es"]
 		if sevES {
 			// This bit mask is used to specify a guest policy. '0x5' is for SEV-ES. The details of the available policies can be found in the link below (see chapter 3)
@@ -1854,6 +2035,46 @@ func (d *qemu) setupSEV(fdFiles *[]*os.File) (*qemuSevOpts, error) {
 	return sevOpts, nil
 }
 
+func (d *qemu) setupTDX() error {
+	if d.architecture != osarch.ARCH_64BIT_INTEL_X86 {
+		return errors.New("Intel TDX support is only available on x86_64 systems")
+	}
+
+	info := DriverStatuses()[instancetype.VM].Info
+	_, tdxFound := info.Features["tdx"]
+	if !tdxFound {
+		return errors.New("Intel TDX is not supported by the host")
+	}
+
+	return nil
+}
+
+// confidentialType returns the confidential computing technology used by the VM (empty if none).
+func (d *qemu) confidentialType() string {
+	if util.IsTrue(d.expandedConfig["security.tdx"]) {
+		return "tdx"
+	}
+
+	if util.IsTrue(d.expandedConfig["security.sev"]) {
+		if util.IsTrue(d.expandedConfig["security.sev.snp"]) {
+			return "sev-snp"
+		}
+
+		if util.IsTrue(d.expandedConfig["security.sev.policy.es"]) {
+			return "sev-es"
+		}
+
+		return "sev"
+	}
+
+	return ""
+}
+
+// usesStatelessFirmware returns whether the VM must boot from a read-only firmware image without NVRAM.
+func (d *qemu) usesStatelessFirmware() bool {
+	return slices.Contains([]string{"sev-snp", "tdx"}, d.confidentialType())
+}
+
 // getAgentConnectionInfo returns the connection info the agent needs to connect to the server.
 func (d *qemu) getAgentConnectionInfo() (*agentAPI.API10Put, error) {
 	addr := d.state.Endpoints.VsockAddress()
@@ -1905,6 +2126,46 @@ func (d *qemu) advertiseVsockAddress() error {
 		return fmt.Errorf("Failed sending host vsock information to the agent: %w", err)
 	}
 
+	// Record the features supported by the agent.
+	resp, _, err := agent.RawQuery("GET", "/1.0", nil, "")
+	if err != nil {
+		return fmt.Errorf("Failed getting agent information: %w", err)
+	}
+
+	agentInfo := agentAPI.API10Get{}
+	err = json.Unmarshal(resp.Metadata, &agentInfo)
+	if err != nil {
+		return fmt.Errorf("Failed parsing agent information: %w", err)
+	}
+
+	features := strings.Join(agentInfo.Features, ",")
+	if features != d.localConfig["volatile.agent.features"] {
+		err = d.VolatileSet(map[string]string{"volatile.agent.features": features})
+		if err != nil {
+			return fmt.Errorf("Failed recording agent features: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// agentHasFeature returns whether the running agent supports the given feature.
+// Agents which didn't report their features are assumed to support all of them.
+func (d *qemu) agentHasFeature(name string) bool {
+	features := d.localConfig["volatile.agent.features"]
+	if features == "" {
+		return true
+	}
+
+	return slices.Contains(strings.Split(features, ","), name)
+}
+
+// agentRequireFeature returns an error if the running agent doesn't support the given feature.
+func (d *qemu) agentRequireFeature(name string) error {
+	if !d.agentHasFeature(name) {
+		return api.StatusErrorf(http.StatusNotImplemented, "The instance agent doesn't support %q on this guest operating system", name)
+	}
+
 	return nil
 }
 
@@ -2090,6 +2351,14 @@ func (d *qemu) deviceStart(dev device.Device, instanceRunning bool) (*deviceConf
 				}
 			}
 
+			// Attach GPU if requested.
+			if len(runConf.GPUDevice) > 0 {
+				err = d.deviceAttachGPU(dev.Name(), runConf.GPUDevice)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			for _, mount := range runConf.Mounts {
 				if mount.FSType == "9p" {
 					err = d.deviceAttachPath(dev.Name(), configCopy, mount)
@@ -2215,6 +2484,19 @@ func (d *qemu) deviceAttachPath(deviceName string, configCopy map[string]string,
 		"id":      deviceID,
 	}
 
+	virtiofsQueues, virtiofsQueueSize, err := qemuVirtiofsQueueOpts(mount.Opts)
+	if err != nil {
+		return err
+	}
+
+	if virtiofsQueues > 0 {
+		qemuDev["num-request-queues"] = strconv.Itoa(virtiofsQueues)
+	}
+
+	if virtiofsQueueSize > 0 {
+		qemuDev["queue-size"] = strconv.Itoa(virtiofsQueueSize)
+	}
+
 	err = monitor.AddDevice(qemuDev)
 	if err != nil {
 		return fmt.Errorf("Failed to add the virtiofs device: %w", err)
@@ -2384,6 +2666,186 @@ func (d *qemu) deviceAttachNIC(deviceName string, configCopy map[string]string,
 	return nil
 }
 
+// deviceAttachGPU live attaches a GPU to a running instance through PCI passthrough.
+func (d *qemu) deviceAttachGPU(deviceName string, gpuConfig []deviceConfig.RunConfigItem) error {
+	var pciSlotName, pciIOMMUGroup, vgpu string
+	for _, gpuItem := range gpuConfig {
+		if gpuItem.Key == "pciSlotName" {
+			pciSlotName = gpuItem.Value
+		} else if gpuItem.Key == "pciIOMMUGroup" {
+			pciIOMMUGroup = gpuItem.Value
+		} else if gpuItem.Key == "vgpu" {
+			vgpu = gpuItem.Value
+		}
+	}
+
+	if vgpu != "" {
+		return fmt.Errorf("Mediated GPU devices cannot be attached to a running instance")
+	}
+
+	if pciSlotName == "" {
+		return fmt.Errorf("Device didn't provide a PCI slot name to use")
+	}
+
+	_, qemuBus, err := d.qemuArchConfig(d.architecture)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains([]string{"pcie", "pci"}, qemuBus) {
+		return fmt.Errorf("GPU devices can only be attached to a running instance on PCI based systems")
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	// Check if the agent is running.
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return err
+	}
+
+	// QEMU has already dropped its privileges so needs access to the VFIO group.
+	if d.state.OS.UnprivUser != "" {
+		if pciIOMMUGroup == "" {
+			return fmt.Errorf("No PCI IOMMU group supplied")
+		}
+
+		vfioGroupFile := fmt.Sprintf("/dev/vfio/%s", pciIOMMUGroup)
+		err := os.Chown(vfioGroupFile, int(d.state.OS.UnprivUID), -1)
+		if err != nil {
+			return fmt.Errorf("Failed to chown vfio group device %q: %w", vfioGroupFile, err)
+		}
+
+		reverter.Add(func() { _ = os.Chown(vfioGroupFile, 0, -1) })
+	}
+
+	// Figure out a hotplug slot.
+	pciDevID := qemuPCIDeviceIDStart
+
+	// Iterate through all the instance devices in the same sorted order as is used when allocating the
+	// boot time devices in order to find the PCI bus slot device we would have used at boot time.
+	// Then attempt to use that same device, assuming it is available.
+	for _, dev := range d.expandedDevices.Sorted() {
+		if dev.Name == deviceName {
+			break // Found our device.
+		}
+
+		pciDevID++
+	}
+
+	pciDeviceName := fmt.Sprintf("%s%d", busDevicePortPrefix, pciDevID)
+	d.logger.Debug("Using PCI bus device to hotplug GPU into", logger.Ctx{"device": deviceName, "port": pciDeviceName})
+
+	// Find any other functions of the card sharing its IOMMU group.
+	relatedSlotNames, err := pcidev.DeviceRelatedFunctions(pciSlotName)
+	if err != nil {
+		return err
+	}
+
+	for _, qemuDev := range qemuGPUHotplugDevices(deviceName, pciDeviceName, pciSlotName, relatedSlotNames) {
+		err = monitor.AddDevice(qemuDev)
+		if err != nil {
+			return fmt.Errorf("Failed to add GPU function %q: %w", qemuDev["host"], err)
+		}
+
+		deviceID := qemuDev["id"]
+		reverter.Add(func() { _ = monitor.RemoveDevice(deviceID) })
+	}
+
+	reverter.Success()
+	return nil
+}
+
+// qemuGPUHotplugDevices returns the QEMU devices needed to pass through a GPU and its related functions into the
+// PCI bus device. The related functions come first as adding function 0 triggers the hotplug of the slot.
+func qemuGPUHotplugDevices(deviceName string, pciDeviceName string, pciSlotName string, relatedSlotNames []string) []map[string]string {
+	qemuDevs := make([]map[string]string, 0, len(relatedSlotNames)+1)
+
+	for i, slotName := range relatedSlotNames {
+		devAddr := fmt.Sprintf("00.%d", i+1)
+		qemuDevs = append(qemuDevs, map[string]string{
+			"driver": "vfio-pci",
+			"bus":    pciDeviceName,
+			"addr":   devAddr,
+			"host":   slotName,
+			"id":     fmt.Sprintf("%s%s_%s", qemuDeviceIDPrefix, deviceName, devAddr),
+		})
+	}
+
+	qemuDev := map[string]string{
+		"driver": "vfio-pci",
+		"bus":    pciDeviceName,
+		"addr":   "00.0",
+		"host":   pciSlotName,
+		"id":     fmt.Sprintf("%s%s", qemuDeviceIDPrefix, deviceName),
+	}
+
+	if len(relatedSlotNames) > 0 {
+		qemuDev["multifunction"] = "on"
+	}
+
+	return append(qemuDevs, qemuDev)
+}
+
+// deviceDetachGPU detaches a GPU device from a running instance.
+func (d *qemu) deviceDetachGPU(deviceName string) error {
+	// Check if the agent is running.
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return err
+	}
+
+	deviceID := fmt.Sprintf("%s%s", qemuDeviceIDPrefix, deviceName)
+
+	// pciDeviceExists checks if the GPU or any of its related functions still exist as a bridged PCI device.
+	pciDeviceExists := func() (bool, error) {
+		pciDevs, err := monitor.QueryPCI()
+		if err != nil {
+			return false, err
+		}
+
+		for _, pciDev := range pciDevs {
+			for _, bridgeDev := range pciDev.Bridge.Devices {
+				if bridgeDev.DevID == deviceID || strings.HasPrefix(bridgeDev.DevID, deviceID+"_") {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+
+	// Removing function 0 unplugs the whole slot, including the related functions.
+	err = monitor.RemoveDevice(deviceID)
+	if err != nil {
+		return fmt.Errorf("Failed removing GPU device: %w", err)
+	}
+
+	// Wait until the device is actually removed (or we timeout waiting) so it can be handed back to the host.
+	waitDuration := time.Duration(time.Second * time.Duration(10))
+	waitUntil := time.Now().Add(waitDuration)
+	for {
+		devExists, err := pciDeviceExists()
+		if err != nil {
+			return fmt.Errorf("Failed getting PCI devices to check for GPU detach: %w", err)
+		}
+
+		if !devExists {
+			break
+		}
+
+		if time.Now().After(waitUntil) {
+			return fmt.Errorf("Failed to detach GPU after %v", waitDuration)
+		}
+
+		d.logger.Debug("Waiting for GPU device to be detached", logger.Ctx{"device": deviceName})
+		time.Sleep(time.Second * time.Duration(2))
+	}
+
+	return nil
+}
+
 // deviceStop loads a new device and calls its Stop() function.
 func (d *qemu) deviceStop(dev device.Device, instanceRunning bool, _ string) error {
 	configCopy := dev.Config()
@@ -2415,6 +2877,14 @@ func (d *qemu) deviceStop(dev device.Device, instanceRunning bool, _ string) err
 			}
 		}
 
+		// Detach GPU from running instance.
+		if configCopy["type"] == "gpu" {
+			err = d.deviceDetachGPU(dev.Name())
+			if err != nil {
+				return err
+			}
+		}
+
 		// Detach USB from running instance.
 		if configCopy["type"] == "usb" && runConf != nil {
 			for _, usbDev := range runConf.USBDevice {
@@ -2541,7 +3011,24 @@ func (d *qemu) spicePath() string {
 }
 
 func (d *qemu) spiceCmdlineConfig() string {
-	return fmt.Sprintf("unix=on,disable-ticketing=on,addr=%s", d.spicePath())
+	config := fmt.Sprintf("unix=on,disable-ticketing=on,addr=%s", d.spicePath())
+
+	if util.IsFalse(d.expandedConfig["spice.clipboard"]) {
+		config += ",disable-copy-paste=on"
+	}
+
+	return config
+}
+
+// spiceEnabled returns whether the VM exposes SPICE (graphical console, agent, USB redirection and audio channels).
+func (d *qemu) spiceEnabled() bool {
+	return util.IsTrueOrEmpty(d.expandedConfig["spice.enabled"])
+}
+
+// spiceAudioEnabled returns whether the VM has a sound card whose audio is sent to the SPICE clients.
+func (d *qemu) spiceAudioEnabled() bool {
+	// s390x doesn't have PCI sound cards.
+	return d.spiceEnabled() && util.IsTrue(d.expandedConfig["spice.audio"]) && d.architecture != osarch.ARCH_64BIT_S390_BIG_ENDIAN
 }
 
 // generateConfigShare generates the config share directory that will be exported to the VM via
@@ -2848,6 +3335,12 @@ func (d *qemu) templateApplyNow(trigger instance.TemplateTrigger, path string) e
 		instanceMeta["ephemeral"] = "false"
 	}
 
+	// Render the cloud-init configuration.
+	cloudInitConfig, err := instance.CloudInitConfig(d)
+	if err != nil {
+		return err
+	}
+
 	// Go through the templates.
 	for tplPath, tpl := range metadata.Templates {
 		err = func(tplPath string, tpl *api.ImageMetadataTemplate) error {
@@ -2894,7 +3387,7 @@ func (d *qemu) templateApplyNow(trigger instance.TemplateTrigger, path string) e
 			}
 
 			configGet := func(confKey, confDefault *pongo2.Value) *pongo2.Value {
-				val, ok := d.expandedConfig[confKey.String()]
+				val, ok := cloudInitConfig[confKey.String()]
 				if !ok {
 					return confDefault
 				}
@@ -2977,7 +3470,7 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 
 	cfg := qemuBase(&qemuBaseOpts{d.Architecture()})
 
-	err := d.addCPUMemoryConfig(&cfg, cpuInfo)
+	cpuOpts, err := d.addCPUMemoryConfig(&cfg, cpuInfo)
 	if err != nil {
 		return "", nil, err
 	}
@@ -2994,6 +3487,26 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 	// Allow disabling the UEFI firmware.
 	if slices.Contains(rawOptions, "-bios") || slices.Contains(rawOptions, "-kernel") {
 		d.logger.Warn("Starting VM without default firmware (-bios or -kernel in raw.qemu)")
+	} else if d.architectureSupportsUEFI(d.architecture) && d.usesStatelessFirmware() {
+		// Confidential guests can't use a writable NVRAM, so load a stateless firmware image instead.
+		var firmware string
+		for _, firmwarePair := range edk2.GetArchitectureFirmwarePairsForUsage(d.architecture, edk2.STATELESS) {
+			if util.PathExists(firmwarePair.Code) {
+				firmware = firmwarePair.Code
+				break
+			}
+		}
+
+		if firmware == "" {
+			return "", nil, fmt.Errorf("Unable to locate a stateless firmware for confidential guests")
+		}
+
+		for i := range cfg {
+			if cfg[i].name == "machine" {
+				cfg[i].entries = append(cfg[i].entries, cfgEntry{"firmware", firmware})
+				break
+			}
+		}
 	} else if d.architectureSupportsUEFI(d.architecture) {
 		// Open the UEFI NVRAM file and pass it via file descriptor to QEMU.
 		// This is so the QEMU process can still read/write the file after it has dropped its user privs.
@@ -3088,6 +3601,11 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 
 	cfg = append(cfg, qemuTablet(&tabletOpts)...)
 
+	// Report guest panics (through the GUEST_PANICKED event).
+	if d.architecture == osarch.ARCH_64BIT_INTEL_X86 {
+		cfg = append(cfg, qemuPVPanic()...)
+	}
+
 	// Existing vsock ID from volatile.
 	vsockID, err := d.getVsockID()
 	if err != nil {
@@ -3118,6 +3636,7 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 		},
 		charDevName:      qemuSerialChardevName,
 		ringbufSizeBytes: qmp.RingbufSize,
+		spice:            d.spiceEnabled(),
 	}
 
 	cfg = append(cfg, qemuSerial(&serialOpts)...)
@@ -3130,11 +3649,56 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 			devAddr:       devAddr,
 			multifunction: multi,
 			ports:         qemuSparseUSBPorts,
+			spice:         d.spiceEnabled(),
 		}
 
 		cfg = append(cfg, qemuUSB(&usbOpts)...)
 	}
 
+	// Hotpluggable memory (uses the last spare function of the generic group).
+	hotplugSizeMB, err := d.memoryHotplugSizeMB()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if hotplugSizeMB > 0 {
+		devBus, devAddr, multi = bus.allocate(busFunctionGroupGeneric)
+		memoryHotplugOpts := qemuMemoryHotplugOpts{
+			dev: qemuDevOpts{
+				busName:       bus.name,
+				devBus:        devBus,
+				devAddr:       devAddr,
+				multifunction: multi,
+			},
+			sizeMB:              hotplugSizeMB,
+			qemuMemObjectFormat: cpuOpts.qemuMemObjectFormat,
+		}
+
+		// Attach the memory to the first guest NUMA node and bind it to the same host NUMA nodes as that node's memory.
+		if len(cpuOpts.cpuNumaHostNodes) > 0 {
+			memoryHotplugOpts.hostNodes = []int64{int64(cpuOpts.cpuNumaHostNodes[0])}
+		} else {
+			memoryHotplugOpts.hostNodes = cpuOpts.memoryHostNodes
+		}
+
+		cfg = append(cfg, qemuMemoryHotplug(&memoryHotplugOpts)...)
+	}
+
+	if d.spiceAudioEnabled() {
+		devBus, devAddr, multi = bus.allocate(busFunctionGroupNone)
+		soundOpts := qemuSoundOpts{
+			dev: qemuDevOpts{
+				busName:       bus.name,
+				devBus:        devBus,
+				devAddr:       devAddr,
+				multifunction: multi,
+			},
+			audioName: qemuAudioName,
+		}
+
+		cfg = append(cfg, qemuSound(&soundOpts)...)
+	}
+
 	if util.IsTrue(d.expandedConfig["security.csm"]) {
 		// Allocate a regular entry to keep things aligned normally (avoid NICs getting a different name).
 		_, _, _ = bus.allocate(busFunctionGroupNone)
@@ -3208,6 +3772,23 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 		}
 	}
 
+	// If user has requested Intel TDX, check if supported and add to QEMU config.
+	if util.IsTrue(d.expandedConfig["security.tdx"]) {
+		err := d.setupTDX()
+		if err != nil {
+			return "", nil, err
+		}
+
+		for i := range cfg {
+			if cfg[i].name == "machine" {
+				cfg[i].entries = append(cfg[i].entries, cfgEntry{"confidential-guest-support", "tdx0"}, cfgEntry{"kernel-irqchip", "split"})
+				break
+			}
+		}
+
+		cfg = append(cfg, qemuTDX()...)
+	}
+
 	if util.IsTrue(d.expandedConfig["security.csm"]) {
 		// Allocate a regular entry to keep things aligned normally (avoid NICs getting a different name).
 		_, _, _ = bus.allocate(busFunctionGroupNone)
@@ -3244,6 +3825,9 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 	// Record the mounts we are going to do inside the VM using the agent.
 	agentMounts := []instancetype.VMAgentMount{}
 
+	// Track the shared NVMe controllers and the last namespace ID allocated on each.
+	nvmeNamespaces := map[string]int{}
+
 	// These devices are sorted so that NICs are added first to ensure that the first NIC can use the 5th
 	// PCIe bus port and will be consistently named enp5s0 for compatibility with network configuration in our
 	// existing VM images. Even on non-PCIe busses having NICs first means that their names won't change when
@@ -3265,8 +3849,56 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 					break
 				}
 
+				// Check if the drive is a namespace of a shared NVMe controller.
+				nvmeController := ""
+				if busName == "nvme" {
+					for _, opt := range drive.Opts {
+						if !strings.HasPrefix(opt, "nvme.controller=") {
+							continue
+						}
+
+						nvmeController = strings.TrimPrefix(opt, "nvme.controller=")
+						break
+					}
+				}
+
 				qemuDev := make(map[string]string)
-				if slices.Contains([]string{"nvme", "virtio-blk"}, busName) {
+				if nvmeController != "" {
+					controllerID := qemuNVMeControllerIDPrefix + linux.PathNameEncode(nvmeController)
+
+					_, found := nvmeNamespaces[controllerID]
+					if !found {
+						// Allocate a PCI(e) port for the controller the first time it is used.
+						devBus, devAddr, multi := bus.allocate(busFunctionGroupNone)
+
+						controllerDev := map[string]string{
+							"driver": "nvme",
+							"id":     controllerID,
+							"serial": controllerID,
+							"bus":    devBus,
+							"addr":   devAddr,
+						}
+
+						if multi {
+							controllerDev["multifunction"] = "on"
+						}
+
+						monHooks = append(monHooks, func(m *qmp.Monitor) error {
+							err := m.AddDevice(controllerDev)
+							if err != nil {
+								return fmt.Errorf("Failed adding NVMe controller %q: %w", nvmeController, err)
+							}
+
+							return nil
+						})
+					}
+
+					nvmeNamespaces[controllerID]++
+
+					// Attach the drive as the next namespace of the controller.
+					qemuDev["bus"] = controllerID
+					qemuDev["nsid"] = strconv.Itoa(nvmeNamespaces[controllerID])
+				} else if slices.Contains([]string{"nvme", "virtio-blk"}, busName) {
 					// Allocate a PCI(e) port and write it to the config file so QMP can "hotplug" the
 					// drive into it later.
 					devBus, devAddr, multi := bus.allocate(busFunctionGroupNone)
@@ -3356,6 +3988,14 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 				return "", nil, err
 			}
 		}
+
+		// Add watchdog device.
+		if len(runConf.WatchdogDevice) > 0 {
+			err = d.addWatchdogDeviceConfig(&cfg, bus, runConf.WatchdogDevice)
+			if err != nil {
+				return "", nil, err
+			}
+		}
 	}
 
 	// VM generation ID is only available on x86.
@@ -3392,8 +4032,8 @@ func (d *qemu) generateQemuConfigFile(cpuInfo *cpuTopology, mountInfo *storagePo
 }
 
 // addCPUMemoryConfig adds the qemu config required for setting the number of virtualised CPUs and memory.
-// If sb is nil then no config is written.
-func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error {
+// If sb is nil then no config is written. The resulting CPU and memory layout is returned.
+func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) (*qemuCPUOpts, error) {
 	// Figure out what memory object layout we're going to use.
 	// Before v6.0 or if version unknown, we use the "repeated" format, otherwise we use "indexed" format.
 	qemuMemObjectFormat := "repeated"
@@ -3439,7 +4079,7 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 			// Parse the NUMA restriction.
 			numaNodeSet, err := resources.ParseNumaNodeSet(numaNodes)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			cpuOpts.memoryHostNodes = numaNodeSet
@@ -3501,14 +4141,14 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 
 	memSizeBytes, err := units.ParseByteSizeString(memSize)
 	if err != nil {
-		return fmt.Errorf("limits.memory invalid: %w", err)
+		return nil, fmt.Errorf("limits.memory invalid: %w", err)
 	}
 
 	cpuOpts.hugepages = ""
 	if util.IsTrue(d.expandedConfig["limits.memory.hugepages"]) {
 		hugetlb, err := localUtil.HugepagesPath()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		cpuOpts.hugepages = hugetlb
@@ -3519,12 +4159,89 @@ func (d *qemu) addCPUMemoryConfig(cfg *[]cfgSection, cpuInfo *cpuTopology) error
 	nodeMemory := int64(memSizeMB / int64(len(hostNodes)))
 	cpuOpts.memory = nodeMemory
 
+	// Determine the maximum memory size reachable through hotplug.
+	hotplugSizeMB, err := d.memoryHotplugSizeMB()
+	if err != nil {
+		return nil, err
+	}
+
 	if cfg != nil {
-		*cfg = append(*cfg, qemuMemory(&qemuMemoryOpts{memSizeMB})...)
+		*cfg = append(*cfg, qemuMemory(&qemuMemoryOpts{memSizeMB: memSizeMB, maxMemSizeMB: memSizeMB + hotplugSizeMB})...)
 		*cfg = append(*cfg, qemuCPU(&cpuOpts, cpuPinning)...)
 	}
 
-	return nil
+	return &cpuOpts, nil
+}
+
+// reserveHugepages checks that enough huge pages are available to back the instance memory and reserves them.
+func (d *qemu) reserveHugepages() (func(), error) {
+	memSize := d.expandedConfig["limits.memory"]
+	if memSize == "" {
+		memSize = QEMUDefaultMemSize
+	}
+
+	memSizeBytes, err := units.ParseByteSizeString(memSize)
+	if err != nil {
+		return nil, fmt.Errorf("limits.memory invalid: %w", err)
+	}
+
+	hugetlb, err := localUtil.HugepagesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize, err := hugepages.PageSize(hugetlb)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := hugepages.Reserve(pageSize, uint64(memSizeBytes))
+	if err != nil {
+		return nil, fmt.Errorf("Failed reserving memory on %q: %w", d.state.ServerName, err)
+	}
+
+	return release, nil
+}
+
+// memoryHotplugSizeMB returns the amount of memory (in MiB) which can be hotplugged on top of limits.memory.
+func (d *qemu) memoryHotplugSizeMB() (int64, error) {
+	maxSize := d.expandedConfig["limits.memory.hotplug"]
+	if maxSize == "" {
+		return 0, nil
+	}
+
+	if d.architecture != osarch.ARCH_64BIT_INTEL_X86 {
+		return 0, fmt.Errorf("Memory hotplug is only supported on x86_64")
+	}
+
+	if util.IsTrue(d.expandedConfig["limits.memory.hugepages"]) {
+		return 0, fmt.Errorf("Memory hotplug can't be used with limits.memory.hugepages")
+	}
+
+	memSize := d.expandedConfig["limits.memory"]
+	if memSize == "" {
+		memSize = QEMUDefaultMemSize
+	}
+
+	memSizeBytes, err := units.ParseByteSizeString(memSize)
+	if err != nil {
+		return 0, fmt.Errorf("limits.memory invalid: %w", err)
+	}
+
+	maxSizeBytes, err := units.ParseByteSizeString(maxSize)
+	if err != nil {
+		return 0, fmt.Errorf("limits.memory.hotplug invalid: %w", err)
+	}
+
+	if maxSizeBytes <= memSizeBytes {
+		return 0, nil
+	}
+
+	// The virtio-mem device works in 2MiB blocks.
+	hotplugSizeMB := (maxSizeBytes - memSizeBytes) / 1024 / 1024
+	hotplugSizeMB -= hotplugSizeMB % 2
+
+	return hotplugSizeMB, nil
 }
 
 // addFileDescriptor adds a file path to the list of files to open and pass file descriptor to qemu.
@@ -3612,6 +4329,11 @@ func (d *qemu) addDriveDirConfig(cfg *[]cfgSection, bus *qemuBus, fdFiles *[]*os
 		}
 	}
 
+	virtiofsQueues, virtiofsQueueSize, err := qemuVirtiofsQueueOpts(driveConf.Opts)
+	if err != nil {
+		return err
+	}
+
 	// If there is a virtiofsd socket path setup the virtio-fs share.
 	if virtiofsdSockPath != "" {
 		if !util.PathExists(virtiofsdSockPath) {
@@ -3628,10 +4350,12 @@ func (d *qemu) addDriveDirConfig(cfg *[]cfgSection, bus *qemuBus, fdFiles *[]*os
 				devAddr:       devAddr,
 				multifunction: multi,
 			},
-			devName:  driveConf.DevName,
-			mountTag: mountTag,
-			path:     virtiofsdSockPath,
-			protocol: "virtio-fs",
+			devName:   driveConf.DevName,
+			mountTag:  mountTag,
+			path:      virtiofsdSockPath,
+			protocol:  "virtio-fs",
+			queues:    virtiofsQueues,
+			queueSize: virtiofsQueueSize,
 		}
 		*cfg = append(*cfg, qemuDriveDir(&driveDirVirtioOpts)...)
 	}
@@ -3666,6 +4390,32 @@ func (d *qemu) addDriveDirConfig(cfg *[]cfgSection, bus *qemuBus, fdFiles *[]*os
 	return nil
 }
 
+// qemuVirtiofsQueueOpts extracts the virtio-fs queue tuning passed by the disk device through the mount options.
+func qemuVirtiofsQueueOpts(opts []string) (int, int, error) {
+	var queues, queueSize int
+
+	for _, opt := range opts {
+		key, value, found := strings.Cut(opt, "=")
+		if !found {
+			continue
+		}
+
+		var err error
+		switch key {
+		case device.DiskVirtiofsQueuesMountOpt:
+			queues, err = strconv.Atoi(value)
+		case device.DiskVirtiofsQueueSizeMountOpt:
+			queueSize, err = strconv.Atoi(value)
+		}
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("Invalid virtio-fs option %q: %w", opt, err)
+		}
+	}
+
+	return queues, queueSize, nil
+}
+
 // addDriveConfig adds the qemu config required for adding a supplementary drive.
 func (d *qemu) addDriveConfig(qemuDev map[string]string, bootIndexes map[string]int, driveConf deviceConfig.MountEntryItem) (monitorHook, error) {
 	aioMode := "native" // Use native kernel async IO and O_DIRECT by default.
@@ -3928,6 +4678,10 @@ func (d *qemu) addDriveConfig(qemuDev map[string]string, bootIndexes map[string]
 		} else if media == "cdrom" {
 			qemuDev["driver"] = "scsi-cd"
 		}
+	} else if bus == "nvme" && qemuDev["nsid"] != "" {
+		// Namespaces are identified by their ID on the controller rather than by a serial.
+		qemuDev["driver"] = "nvme-ns"
+		delete(qemuDev, "serial")
 	} else if slices.Contains([]string{"nvme", "virtio-blk"}, bus) {
 		if qemuDev["bus"] == "" {
 			// Figure out a hotplug slot.
@@ -4547,6 +5301,48 @@ func (d *qemu) addTPMDeviceConfig(cfg *[]cfgSection, tpmConfig []deviceConfig.Ru
 	return nil
 }
 
+// addWatchdogDeviceConfig adds the qemu config required for adding a watchdog device.
+func (d *qemu) addWatchdogDeviceConfig(cfg *[]cfgSection, bus *qemuBus, watchdogConfig []deviceConfig.RunConfigItem) error {
+	var devName, model string
+
+	for _, watchdogItem := range watchdogConfig {
+		if watchdogItem.Key == "devName" {
+			devName = watchdogItem.Value
+		} else if watchdogItem.Key == "model" {
+			model = watchdogItem.Value
+		}
+	}
+
+	watchdogOpts := qemuWatchdogOpts{
+		devName: devName,
+		model:   model,
+	}
+
+	switch model {
+	case "i6300esb":
+		if !slices.Contains([]string{"pcie", "pci"}, bus.name) {
+			return fmt.Errorf("The %q watchdog requires a PCI bus", model)
+		}
+
+		devBus, devAddr, multi := bus.allocate(fmt.Sprintf("incus_%s", devName))
+		watchdogOpts.dev = qemuDevOpts{
+			busName:       bus.name,
+			devBus:        devBus,
+			devAddr:       devAddr,
+			multifunction: multi,
+		}
+
+	case "ib700":
+		if d.architecture != osarch.ARCH_64BIT_INTEL_X86 {
+			return fmt.Errorf("The %q watchdog is only supported on x86_64", model)
+		}
+	}
+
+	*cfg = append(*cfg, qemuWatchdog(&watchdogOpts)...)
+
+	return nil
+}
+
 func (d *qemu) addVmgenDeviceConfig(cfg *[]cfgSection, guid string) error {
 	vmgenIDOpts := qemuVmgenIDOpts{
 		guid: guid,
@@ -4807,34 +5603,31 @@ func (d *qemu) snapshot(name string, expiry time.Time, stateful bool) error {
 			return err
 		}
 
-		// Dump the state.
-		err = d.saveState(monitor)
+		// Pause the VM so that its memory and disk state match.
+		err = monitor.Pause()
 		if err != nil {
-			return err
+			return fmt.Errorf("Failed pausing the instance: %w", err)
 		}
-	}
 
-	// Create the snapshot.
-	err = d.snapshotCommon(d, name, expiry, stateful)
-	if err != nil {
-		return err
-	}
+		// Resume the VM once the disk state has been saved, or on failure, and clear the state from the main volume.
+		defer func() {
+			_ = os.Remove(d.StatePath())
 
-	// Resume the VM once the disk state has been saved.
-	if stateful {
-		// Remove the state from the main volume.
-		err = os.Remove(d.StatePath())
-		if err != nil {
-			return err
-		}
+			err := monitor.Start()
+			if err != nil {
+				d.logger.Warn("Failed resuming instance after stateful snapshot", logger.Ctx{"err": err})
+			}
+		}()
 
-		err = monitor.Start()
+		// Dump the state.
+		err = d.saveState(monitor)
 		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	// Create the snapshot.
+	return d.snapshotCommon(d, name, expiry, stateful)
 }
 
 // Snapshot takes a new snapshot.
@@ -5433,52 +6226,9 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	}
 
 	if isRunning {
-		// Only certain keys can be changed on a running VM.
-		liveUpdateKeys := []string{
-			"cluster.evacuate",
-			"limits.memory",
-			"security.agent.metrics",
-			"security.csm",
-			"security.protection.delete",
-			"security.guestapi",
-			"security.secureboot",
-		}
-
-		liveUpdateKeyPrefixes := []string{
-			"boot.",
-			"cloud-init.",
-			"environment.",
-			"image.",
-			"snapshots.",
-			"user.",
-			"volatile.",
-		}
-
-		isLiveUpdatable := func(key string) bool {
-			// Skip container config keys for VMs
-			_, ok := internalInstance.InstanceConfigKeysContainer[key]
-			if ok {
-				return true
-			}
-
-			if key == "limits.cpu" {
-				return d.architectureSupportsCPUHotplug()
-			}
-
-			if slices.Contains(liveUpdateKeys, key) {
-				return true
-			}
-
-			if util.StringHasPrefix(key, liveUpdateKeyPrefixes...) {
-				return true
-			}
-
-			return false
-		}
-
 		// Check only keys that support live update have changed.
 		for _, key := range changedConfig {
-			if !isLiveUpdatable(key) {
+			if !VMConfigKeyLiveUpdatable(key) {
 				return fmt.Errorf("Key %q cannot be updated when VM is running", key)
 			}
 		}
@@ -5488,6 +6238,10 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 			value := d.expandedConfig[key]
 
 			if key == "limits.cpu" {
+				if d.expandedConfig["limits.cpu.allocation"] == "numa-auto" {
+					return fmt.Errorf("Cannot update key %q when using automatic CPU allocation and the VM is running", key)
+				}
+
 				oldValue := oldExpandedConfig["limits.cpu"]
 
 				if oldValue != "" {
@@ -5690,7 +6444,8 @@ func (d *qemu) Update(args db.InstanceArgs, userRequested bool) error {
 	return nil
 }
 
-// updateMemoryLimit live updates the VM's memory limit by reszing the balloon device.
+// updateMemoryLimit live updates the VM's memory limit by resizing the balloon device or,
+// when growing past the boot time size, the hotpluggable memory device.
 func (d *qemu) updateMemoryLimit(newLimit string) error {
 	if newLimit == "" {
 		return nil
@@ -5728,10 +6483,55 @@ func (d *qemu) updateMemoryLimit(newLimit string) error {
 
 	curSizeMB := curSizeBytes / 1024 / 1024
 
+	// Look for the hotpluggable memory device.
+	var memoryDevice *qmp.VirtioMemDevice
+	if d.expandedConfig["limits.memory.hotplug"] != "" {
+		memoryDevices, err := monitor.GetVirtioMemDevices()
+		if err != nil {
+			return err
+		}
+
+		if len(memoryDevices) > 0 {
+			memoryDevice = &memoryDevices[0]
+		}
+	}
+
+	if baseSizeMB < newSizeMB {
+		if memoryDevice == nil {
+			return fmt.Errorf("Cannot increase memory size beyond boot time size when VM is running without limits.memory.hotplug (Boot time size %dMiB, new size %dMiB)", baseSizeMB, newSizeMB)
+		}
+
+		// Round the hotplugged size up to the device's block size.
+		hotplugSizeBytes := newSizeBytes - baseSizeBytes
+		if memoryDevice.BlockSize > 0 && hotplugSizeBytes%memoryDevice.BlockSize != 0 {
+			hotplugSizeBytes += memoryDevice.BlockSize - hotplugSizeBytes%memoryDevice.BlockSize
+		}
+
+		if hotplugSizeBytes > memoryDevice.MaxSize {
+			return fmt.Errorf("Cannot increase memory size beyond limits.memory.hotplug when VM is running (Maximum size %dMiB, new size %dMiB)", (baseSizeBytes+memoryDevice.MaxSize)/1024/1024, newSizeMB)
+		}
+
+		// Release any boot time memory held by the balloon.
+		if curSizeMB != baseSizeMB {
+			err = monitor.SetMemoryBalloonSizeBytes(baseSizeBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		return monitor.SetVirtioMemRequestedSize(memoryDevice.ID, hotplugSizeBytes)
+	}
+
+	// Unplug any hotplugged memory before shrinking the boot time memory.
+	if memoryDevice != nil && memoryDevice.RequestedSize > 0 {
+		err = monitor.SetVirtioMemRequestedSize(memoryDevice.ID, 0)
+		if err != nil {
+			return err
+		}
+	}
+
 	if curSizeMB == newSizeMB {
 		return nil
-	} else if baseSizeMB < newSizeMB {
-		return fmt.Errorf("Cannot increase memory size beyond boot time size when VM is running (Boot time size %dMiB, new size %dMiB)", baseSizeMB, newSizeMB)
 	}
 
 	// Set effective memory size.
@@ -6507,7 +7307,12 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 				defer instanceRefClear(d)
 			}
 
-			err = d.migrateSendLive(pool, args.ClusterMoveSourceName, blockSize, filesystemConn, stateConn, volSourceArgs)
+			tuning, err := d.migrationTuning(args.LiveMigration)
+			if err != nil {
+				return err
+			}
+
+			err = d.migrateSendLive(pool, args.ClusterMoveSourceName, blockSize, filesystemConn, stateConn, volSourceArgs, tuning)
 			if err != nil {
 				return err
 			}
@@ -6520,28 +7325,191 @@ func (d *qemu) MigrateSend(args instance.MigrateSendArgs) error {
 				}
 			}
 
-			err = pool.MigrateInstance(d, filesystemConn, volSourceArgs, d.op)
+			err = pool.MigrateInstance(d, filesystemConn, volSourceArgs, d.op)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	// Wait for routines to finish and collect first error.
+	{
+		err := g.Wait()
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// migrateSendLive performs live migration send process.
+// qemuMigrationTuning holds the effective tuning of a live migration.
+type qemuMigrationTuning struct {
+	maxDowntime  int64 // Milliseconds, 0 for the QEMU default.
+	bandwidth    int64 // Bytes per second, 0 for the QEMU default.
+	autoConverge bool
+	postcopy     bool
+}
+
+// migrationTuning returns the tuning of a live migration from the instance configuration, with the overrides of
+// the migration request applied.
+func (d *qemu) migrationTuning(override *api.InstancePostLiveMigration) (*qemuMigrationTuning, error) {
+	tuning := &qemuMigrationTuning{
+		autoConverge: util.IsTrueOrEmpty(d.expandedConfig["migration.stateful.auto_converge"]),
+		postcopy:     util.IsTrue(d.expandedConfig["migration.stateful.postcopy"]),
+	}
+
+	maxDowntime := d.expandedConfig["migration.stateful.max_downtime"]
+	bandwidth := d.expandedConfig["migration.stateful.bandwidth"]
+
+	if override != nil {
+		if override.MaxDowntime > 0 {
+			maxDowntime = strconv.FormatInt(override.MaxDowntime, 10)
+		}
+
+		if override.Bandwidth != "" {
+			bandwidth = override.Bandwidth
+		}
+
+		if override.AutoConverge != nil {
+			tuning.autoConverge = *override.AutoConverge
+		}
+	}
+
+	var err error
+
+	if maxDowntime != "" {
+		tuning.maxDowntime, err = strconv.ParseInt(maxDowntime, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid live migration maximum downtime %q: %w", maxDowntime, err)
+		}
+	}
+
+	if bandwidth != "" {
+		tuning.bandwidth, err = units.ParseByteSizeString(bandwidth)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid live migration bandwidth %q: %w", bandwidth, err)
+		}
+	}
+
+	return tuning, nil
+}
+
+// migrationStatusUpdate reports the progress of the memory transfer of a live migration in the operation metadata.
+func (d *qemu) migrationStatusUpdate(status *qmp.MigrationStatus) {
+	if d.op == nil || status.RAM == nil || status.RAM.Total == 0 {
+		return
+	}
+
+	meta := d.op.Metadata()
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+
+	ram := status.RAM
+	meta["memory_progress"] = fmt.Sprintf("%d%% (%s/s, %d dirty pages/s, pass %d)", (ram.Total-ram.Remaining)*100/ram.Total, units.GetByteSizeString(int64(ram.Mbps*1000*1000/8), 2), ram.DirtyPagesRate, ram.DirtySyncCount)
+	meta["live_migration"] = map[string]any{
+		"status":             status.Status,
+		"expected_downtime":  status.ExpectedDowntime,
+		"memory_total":       ram.Total,
+		"memory_remaining":   ram.Remaining,
+		"memory_transferred": ram.Transferred,
+		"dirty_pages_rate":   ram.DirtyPagesRate,
+		"dirty_sync_count":   ram.DirtySyncCount,
+		"page_size":          ram.PageSize,
+		"mbps":               ram.Mbps,
+	}
+
+	_ = d.op.UpdateMetadata(meta)
+}
+
+// migrationMonitor reports the progress of a live migration until the context is cancelled and switches it to
+// post-copy if enabled and the memory transfer doesn't converge.
+func (d *qemu) migrationMonitor(ctx context.Context, monitor *qmp.Monitor, tuning *qemuMigrationTuning) {
+	postcopy := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+
+		status, err := monitor.QueryMigrate()
+		if err != nil {
+			return
+		}
+
+		d.migrationStatusUpdate(status)
+
+		// Switch to post-copy after three full passes over the memory of the instance.
+		if tuning.postcopy && !postcopy && status.Status == "active" && status.RAM != nil && status.RAM.DirtySyncCount >= 3 {
+			err = monitor.MigrateStartPostcopy()
+			if err != nil {
+				d.logger.Warn("Failed switching live migration to post-copy", logger.Ctx{"err": err})
+				continue
+			}
+
+			d.logger.Info("Switched non-converging live migration to post-copy", logger.Ctx{"dirtyPagesRate": status.RAM.DirtyPagesRate})
+			postcopy = true
+		}
+	}
+}
+
+// migrationStateChannel returns the file handle to pass to QEMU for the migration state transfer over conn.
+// Post-copy needs a return path for the target to request memory pages, so a socket is used in that case.
+func (d *qemu) migrationStateChannel(conn io.ReadWriteCloser, send bool, postcopy bool) (*os.File, func(), error) {
+	if !postcopy {
+		pipeRead, pipeWrite, err := os.Pipe()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cleanup := func() {
+			_ = pipeRead.Close()
+			_ = pipeWrite.Close()
+		}
+
+		if send {
+			go func() { _, _ = io.Copy(conn, pipeRead) }()
+			return pipeWrite, cleanup, nil
+		}
+
+		go func() {
+			_, err := io.Copy(pipeWrite, conn)
 			if err != nil {
-				return err
+				d.logger.Warn("Failed reading from state connection", logger.Ctx{"err": err})
 			}
-		}
 
-		return nil
-	})
+			cleanup()
+		}()
 
-	// Wait for routines to finish and collect first error.
-	{
-		err := g.Wait()
-		if err != nil {
-			return err
-		}
+		return pipeRead, cleanup, nil
+	}
 
-		return nil
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	local := os.NewFile(uintptr(fds[0]), "migration-state-local")
+	remote := os.NewFile(uintptr(fds[1]), "migration-state-remote")
+
+	cleanup := func() {
+		_ = local.Close()
+		_ = remote.Close()
 	}
+
+	go func() { _, _ = io.Copy(conn, local) }()
+	go func() { _, _ = io.Copy(local, conn) }()
+
+	return remote, cleanup, nil
 }
 
-// migrateSendLive performs live migration send process.
-func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName string, rootDiskSize int64, filesystemConn io.ReadWriteCloser, stateConn io.ReadWriteCloser, volSourceArgs *localMigration.VolumeSourceArgs) error {
+func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName string, rootDiskSize int64, filesystemConn io.ReadWriteCloser, stateConn io.ReadWriteCloser, volSourceArgs *localMigration.VolumeSourceArgs, tuning *qemuMigrationTuning) error {
 	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
 	if err != nil {
 		return err
@@ -6562,7 +7530,10 @@ func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName str
 		// Setup migration capabilities.
 		capabilities := map[string]bool{
 			// Automatically throttle down the guest to speed up convergence of RAM migration.
-			"auto-converge": true,
+			"auto-converge": tuning.autoConverge,
+
+			// Allow switching to post-copy if the RAM migration doesn't converge.
+			"postcopy-ram": tuning.postcopy,
 
 			// Allow the migration to be paused after the source qemu releases the block devices but
 			// before the serialisation of the device state, to avoid a race condition between
@@ -6668,7 +7639,10 @@ func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName str
 		// Still set some options for shared storage.
 		capabilities := map[string]bool{
 			// Automatically throttle down the guest to speed up convergence of RAM migration.
-			"auto-converge": true,
+			"auto-converge": tuning.autoConverge,
+
+			// Allow switching to post-copy if the RAM migration doesn't converge.
+			"postcopy-ram": tuning.postcopy,
 		}
 
 		err = monitor.MigrateSetCapabilities(capabilities)
@@ -6677,6 +7651,23 @@ func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName str
 		}
 	}
 
+	// Apply the limits of the RAM migration.
+	parameters := map[string]any{}
+	if tuning.maxDowntime > 0 {
+		parameters["downtime-limit"] = tuning.maxDowntime
+	}
+
+	if tuning.bandwidth > 0 {
+		parameters["max-bandwidth"] = tuning.bandwidth
+	}
+
+	if len(parameters) > 0 {
+		err = monitor.MigrateSetParameters(parameters)
+		if err != nil {
+			return fmt.Errorf("Failed setting migration parameters: %w", err)
+		}
+	}
+
 	// Perform storage transfer while instance is still running.
 	// For shared storage the storage driver will likely not do much here, but we still call it anyway for the
 	// sense checks it performs.
@@ -6797,23 +7788,24 @@ func (d *qemu) migrateSendLive(pool storagePools.Pool, clusterMoveSourceName str
 	d.logger.Debug("Stateful migration checkpoint send starting")
 
 	// Send checkpoint to QEMU process on target. This will pause the guest OS (if not already paused).
-	pipeRead, pipeWrite, err := os.Pipe()
+	stateFile, stateCleanup, err := d.migrationStateChannel(stateConn, true, tuning.postcopy)
 	if err != nil {
 		return err
 	}
 
-	defer func() {
-		_ = pipeRead.Close()
-		_ = pipeWrite.Close()
-	}()
-
-	go func() { _, _ = io.Copy(stateConn, pipeRead) }()
+	defer stateCleanup()
 
-	err = d.saveStateHandle(monitor, pipeWrite)
+	err = d.saveStateHandle(monitor, stateFile)
 	if err != nil {
 		return fmt.Errorf("Failed starting state transfer to target: %w", err)
 	}
 
+	// Report the progress of the RAM migration.
+	monitorCtx, monitorCancel := context.WithCancel(context.Background())
+	defer monitorCancel()
+
+	go d.migrationMonitor(monitorCtx, monitor, tuning)
+
 	// Non-shared storage snapshot transfer finalization.
 	if !sharedStorage {
 		// Wait until state transfer has reached pre-switchover state (the guest OS will remain paused).
@@ -6946,6 +7938,11 @@ func (d *qemu) MigrateReceive(args instance.MigrateReceiveArgs) error {
 		return err
 	}
 
+	// The block stream requires the volume to be idle, so can't be used for live migrations.
+	if args.Live {
+		respTypes = localMigration.RemoveFeature(respTypes, migration.RsyncFeatureBlockStream)
+	}
+
 	// The migration header to be sent back to source with our target options.
 	// Convert response type to response header and copy snapshot info into it.
 	respHeader := localMigration.TypesToHeader(respTypes...)
@@ -7343,6 +8340,11 @@ func (d *qemu) FileSFTPConn() (net.Conn, error) {
 		return nil, fmt.Errorf("Instance is not running")
 	}
 
+	err := d.agentRequireFeature(agentAPI.FeatureFiles)
+	if err != nil {
+		return nil, err
+	}
+
 	// Connect to the agent.
 	client, err := d.getAgentClient()
 	if err != nil {
@@ -7434,6 +8436,10 @@ func (d *qemu) Console(protocol string) (*os.File, chan error, error) {
 	case instance.ConsoleTypeConsole:
 		path = d.consolePath()
 	case instance.ConsoleTypeVGA:
+		if !d.spiceEnabled() {
+			return nil, nil, fmt.Errorf("VGA console is disabled on this instance")
+		}
+
 		path = d.spicePath()
 	default:
 		return nil, nil, fmt.Errorf("Unknown protocol %q", protocol)
@@ -7465,6 +8471,18 @@ func (d *qemu) Exec(req api.InstanceExecPost, stdin *os.File, stdout *os.File, s
 	revert := revert.New()
 	defer revert.Fail()
 
+	err := d.agentRequireFeature(agentAPI.FeatureExec)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Interactive {
+		err = d.agentRequireFeature(agentAPI.FeatureExecInteractive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	client, err := d.getAgentClient()
 	if err != nil {
 		return nil, err
@@ -7621,7 +8639,7 @@ func (d *qemu) RenderFull(hostInterfaces []net.Interface) (*api.InstanceFull, an
 	vmState := api.InstanceFull{Instance: *base.(*api.Instance)}
 
 	// Add the InstanceState.
-	vmState.State, err = d.renderState(vmState.StatusCode)
+	vmState.State, err = d.renderState(vmState.StatusCode, false)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7665,14 +8683,15 @@ func (d *qemu) RenderFull(hostInterfaces []net.Interface) (*api.InstanceFull, an
 }
 
 // renderState returns just state info about the instance.
-func (d *qemu) renderState(statusCode api.StatusCode) (*api.InstanceState, error) {
+// The OVN state of the NICs is only included when ovnState is true as it requires querying the OVN databases.
+func (d *qemu) renderState(statusCode api.StatusCode, ovnState bool) (*api.InstanceState, error) {
 	var err error
 
 	status := &api.InstanceState{}
 	pid, _ := d.pid()
 
 	if d.isRunningStatusCode(statusCode) {
-		if d.agentMetricsEnabled() {
+		if d.agentMetricsEnabled() && d.agentHasFeature(agentAPI.FeatureState) {
 			// Try and get state info from agent.
 			status, err = d.agentGetState()
 			if err != nil {
@@ -7723,6 +8742,8 @@ func (d *qemu) renderState(statusCode api.StatusCode) (*api.InstanceState, error
 			}
 		}
 
+		d.networkStateDetails(d, status.Network, ovnState)
+
 		status.Pid = int64(pid)
 		status.StartedAt, err = d.processStartedAt(d.InitPID())
 		if err != nil {
@@ -7737,12 +8758,14 @@ func (d *qemu) renderState(statusCode api.StatusCode) (*api.InstanceState, error
 		d.logger.Warn("Error getting disk usage", logger.Ctx{"err": err})
 	}
 
+	d.addVirtiofsState(status)
+
 	return status, nil
 }
 
 // RenderState returns just state info about the instance.
 func (d *qemu) RenderState(hostInterfaces []net.Interface) (*api.InstanceState, error) {
-	return d.renderState(d.statusCode())
+	return d.renderState(d.statusCode(), true)
 }
 
 // diskState gets disk usage info.
@@ -7772,6 +8795,50 @@ func (d *qemu) diskState() (map[string]api.InstanceStateDisk, error) {
 	return disk, nil
 }
 
+// addVirtiofsState adds the I/O statistics of the running virtiofsd processes to the disk state.
+func (d *qemu) addVirtiofsState(status *api.InstanceState) {
+	for _, dev := range d.expandedDevices.Sorted() {
+		if dev.Config["type"] != "disk" || dev.Config["path"] == "/" {
+			continue
+		}
+
+		pidPath := filepath.Join(d.DevicesPath(), fmt.Sprintf("virtio-fs.%s.pid", dev.Name))
+		if !util.PathExists(pidPath) {
+			continue
+		}
+
+		proc, err := subprocess.ImportProcess(pidPath)
+		if err != nil {
+			continue
+		}
+
+		pid, err := proc.GetPid()
+		if err != nil {
+			continue
+		}
+
+		processIO, err := linux.GetProcessIO(pid)
+		if err != nil {
+			d.logger.Debug("Failed getting virtiofsd statistics", logger.Ctx{"device": dev.Name, "err": err})
+			continue
+		}
+
+		if status.Disk == nil {
+			status.Disk = map[string]api.InstanceStateDisk{}
+		}
+
+		diskState := status.Disk[dev.Name]
+		diskState.Virtiofs = &api.InstanceStateDiskVirtiofs{
+			ReadBytes:     processIO.ReadBytes,
+			ReadSyscalls:  processIO.ReadSyscalls,
+			WrittenBytes:  processIO.WrittenBytes,
+			WriteSyscalls: processIO.WriteSyscalls,
+		}
+
+		status.Disk[dev.Name] = diskState
+	}
+}
+
 // agentGetState connects to the agent inside of the VM and does
 // an API call to get the current state.
 func (d *qemu) agentGetState() (*api.InstanceState, error) {
@@ -7795,6 +8862,227 @@ func (d *qemu) agentGetState() (*api.InstanceState, error) {
 	return status, nil
 }
 
+// AgentRunning returns whether the agent of the VM is reachable.
+func (d *qemu) AgentRunning() bool {
+	client, err := d.getAgentClient()
+	if err != nil {
+		return false
+	}
+
+	agent, err := incus.ConnectIncusHTTP(nil, client)
+	if err != nil {
+		return false
+	}
+
+	defer agent.Disconnect()
+
+	_, _, err = agent.GetServer()
+
+	return err == nil
+}
+
+// ConsoleScreenshot returns a PNG screenshot of the graphical output of the VM.
+func (d *qemu) ConsoleScreenshot() ([]byte, error) {
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance isn't running")
+	}
+
+	monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+	if err != nil {
+		return nil, err
+	}
+
+	// QEMU runs unprivileged, so pass it the file to write to.
+	f, err := os.CreateTemp("", "incus_screenshot_")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	info, err := monitor.SendFileWithFDSet("screenshot", f, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed sending screenshot file descriptor: %w", err)
+	}
+
+	defer func() { _ = monitor.RemoveFDFromFDSet("screenshot") }()
+
+	err = monitor.Screendump(fmt.Sprintf("/dev/fdset/%d", info.ID))
+	if err != nil {
+		return nil, fmt.Errorf("Failed taking screenshot: %w", err)
+	}
+
+	return os.ReadFile(f.Name())
+}
+
+// Attestation returns the confidential computing state of the VM, including its launch measurement when available.
+func (d *qemu) Attestation() (*api.InstanceAttestation, error) {
+	confidentialType := d.confidentialType()
+	if confidentialType == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance isn't a confidential guest")
+	}
+
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance isn't running")
+	}
+
+	attestation := &api.InstanceAttestation{
+		Type: confidentialType,
+	}
+
+	switch confidentialType {
+	case "sev":
+		attestation.Policy = "0x1"
+	case "sev-es":
+		attestation.Policy = "0x5"
+	case "sev-snp":
+		attestation.Policy = "0x30000"
+	}
+
+	// The launch measurement is only exposed to the host for SEV and SEV-ES guests.
+	// For SEV-SNP and TDX guests, it's part of the attestation report generated by the guest.
+	if slices.Contains([]string{"sev", "sev-es"}, confidentialType) {
+		monitor, err := qmp.Connect(d.monitorPath(), qemuSerialChardevName, d.getMonitorEventHandler())
+		if err != nil {
+			return nil, err
+		}
+
+		attestation.Measurement, err = monitor.SEVLaunchMeasurement()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return attestation, nil
+}
+
+// AttestationReport requests an attestation report bound to the provided nonce from the guest.
+func (d *qemu) AttestationReport(nonce []byte) ([]byte, error) {
+	if !d.usesStatelessFirmware() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "Attestation reports are only available for AMD SEV-SNP and Intel TDX guests")
+	}
+
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance isn't running")
+	}
+
+	err := d.agentRequireFeature(agentAPI.FeatureAttestation)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := d.getAgentClient()
+	if err != nil {
+		return nil, err
+	}
+
+	agentArgs := &incus.ConnectionArgs{SkipGetServer: true}
+	agent, err := incus.ConnectIncusHTTP(agentArgs, client)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to agent: %w", err)
+	}
+
+	defer agent.Disconnect()
+
+	resp, _, err := agent.RawQuery("POST", "/1.0/attestation", agentAPI.AttestationReportPost{Nonce: nonce}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	report := agentAPI.AttestationReport{}
+	err = json.Unmarshal(resp.Metadata, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report.Report, nil
+}
+
+// agentUsersQuery sends a user management request to the agent.
+func (d *qemu) agentUsersQuery(method string, path string, data any) (*api.Response, error) {
+	if !d.IsRunning() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance isn't running")
+	}
+
+	err := d.agentRequireFeature(agentAPI.FeatureUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := d.getAgentClient()
+	if err != nil {
+		return nil, err
+	}
+
+	agentArgs := &incus.ConnectionArgs{SkipGetServer: true}
+	agent, err := incus.ConnectIncusHTTP(agentArgs, client)
+	if err != nil {
+		return nil, fmt.Errorf("Failed connecting to agent: %w", err)
+	}
+
+	defer agent.Disconnect()
+
+	resp, _, err := agent.RawQuery(method, path, data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Users returns the regular users of the guest (and root).
+func (d *qemu) Users() ([]api.InstanceUser, error) {
+	resp, err := d.agentUsersQuery("GET", "/1.0/users", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []api.InstanceUser{}
+	err = json.Unmarshal(resp.Metadata, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// User returns the user of the guest with the given name.
+func (d *qemu) User(name string) (*api.InstanceUser, error) {
+	resp, err := d.agentUsersQuery("GET", "/1.0/users/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user := api.InstanceUser{}
+	err = json.Unmarshal(resp.Metadata, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UserCreate creates a user in the guest.
+func (d *qemu) UserCreate(req api.InstanceUsersPost) error {
+	_, err := d.agentUsersQuery("POST", "/1.0/users", req)
+	return err
+}
+
+// UserUpdate sets the password, SSH keys and sudo access of a user of the guest.
+func (d *qemu) UserUpdate(name string, req api.InstanceUserPut) error {
+	_, err := d.agentUsersQuery("PUT", "/1.0/users/"+url.PathEscape(name), req)
+	return err
+}
+
+// UserDelete deletes a user of the guest along with its home directory.
+func (d *qemu) UserDelete(name string) error {
+	_, err := d.agentUsersQuery("DELETE", "/1.0/users/"+url.PathEscape(name), nil)
+	return err
+}
+
 // IsRunning returns whether or not the instance is running.
 func (d *qemu) IsRunning() bool {
 	return d.isRunningStatusCode(d.statusCode())
@@ -8575,10 +9863,26 @@ func (d *qemu) checkFeatures(hostArch int, qemuPath string) (map[string]any, err
 				} else if strings.TrimSpace(string(sevES)) == "Y" {
 					features["sev-es"] = struct{}{}
 				}
+
+				// Check if the SEV-SNP extension is enabled.
+				sevSNP, err := os.ReadFile("/sys/module/kvm_amd/parameters/sev_snp")
+				if err != nil {
+					logger.Debug("Failed querying SEV-SNP capability during VM feature check", logger.Ctx{"err": err})
+				} else if strings.TrimSpace(string(sevSNP)) == "Y" {
+					features["sev-snp"] = struct{}{}
+				}
 			}
 		}
 	}
 
+	// Check if Intel TDX is enabled.
+	if hostArch == osarch.ARCH_64BIT_INTEL_X86 {
+		tdx, err := os.ReadFile("/sys/module/kvm_intel/parameters/tdx")
+		if err == nil && strings.TrimSpace(string(tdx)) == "Y" {
+			features["tdx"] = struct{}{}
+		}
+	}
+
 	// Check if vhost-net accelerator (for NIC CPU offloading) is available.
 	if util.PathExists("/dev/vhost-net") {
 		features["vhost_net"] = struct{}{}
@@ -8603,7 +9907,7 @@ func (d *qemu) Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, erro
 		return nil, ErrInstanceIsStopped
 	}
 
-	if d.agentMetricsEnabled() {
+	if d.agentMetricsEnabled() && d.agentHasFeature(agentAPI.FeatureMetrics) {
 		metrics, err := d.getAgentMetrics()
 		if err != nil {
 			if !errors.Is(err, errQemuAgentOffline) {
@@ -8880,6 +10184,53 @@ func (d *qemu) setCPUs(count int) error {
 	return nil
 }
 
+// VMConfigKeyLiveUpdatable returns whether the given config key can be changed on a running VM.
+func VMConfigKeyLiveUpdatable(key string) bool {
+	// Only certain keys can be changed on a running VM.
+	liveUpdateKeys := []string{
+		"cluster.evacuate",
+		"limits.memory",
+		"limits.memory.freeze_priority",
+		"migration.stateful.auto_converge",
+		"migration.stateful.bandwidth",
+		"migration.stateful.max_downtime",
+		"migration.stateful.postcopy",
+		"security.agent.metrics",
+		"security.csm",
+		"security.protection.delete",
+		"security.guestapi",
+		"security.secureboot",
+	}
+
+	liveUpdateKeyPrefixes := []string{
+		"boot.",
+		"cloud-init.",
+		"environment.",
+		"image.",
+		"snapshots.",
+		"tasks.",
+		"user.",
+		"volatile.",
+	}
+
+	// Skip container config keys for VMs
+	_, ok := internalInstance.InstanceConfigKeysContainer[key]
+	if ok {
+		return true
+	}
+
+	if key == "limits.cpu" {
+		_, found := DriverStatuses()[instancetype.VM].Info.Features["cpu_hotplug"]
+		return found
+	}
+
+	if slices.Contains(liveUpdateKeys, key) {
+		return true
+	}
+
+	return util.StringHasPrefix(key, liveUpdateKeyPrefixes...)
+}
+
 func (d *qemu) architectureSupportsCPUHotplug() bool {
 	// Check supported features.
 	info := DriverStatuses()[instancetype.VM].Info
@@ -38,6 +38,7 @@ func TestQemuConfigTemplates(t *testing.T) {
 			type = "q35"
 			accel = "kvm"
 			usb = "off"
+			dump-guest-core = "off"
 
 			[global]
 			driver = "ICH9-LPC"
@@ -60,6 +61,7 @@ func TestQemuConfigTemplates(t *testing.T) {
 			gic-version = "max"
 			accel = "kvm"
 			usb = "off"
+			dump-guest-core = "off"
 
 			[boot-opts]
 			strict = "on"`,
@@ -72,6 +74,7 @@ func TestQemuConfigTemplates(t *testing.T) {
 			cap-large-decr = "off"
 			accel = "kvm"
 			usb = "off"
+			dump-guest-core = "off"
 
 			[boot-opts]
 			strict = "on"`,
@@ -83,6 +86,7 @@ func TestQemuConfigTemplates(t *testing.T) {
 			type = "s390-ccw-virtio"
 			accel = "kvm"
 			usb = "off"
+			dump-guest-core = "off"
 
 			[boot-opts]
 			strict = "on"`,
@@ -98,27 +102,96 @@ func TestQemuConfigTemplates(t *testing.T) {
 			opts     qemuMemoryOpts
 			expected string
 		}{{
-			qemuMemoryOpts{4096},
+			qemuMemoryOpts{4096, 0},
 			`# Memory
 			[memory]
 			size = "4096M"`,
 		}, {
-			qemuMemoryOpts{8192},
+			qemuMemoryOpts{8192, 0},
 			`# Memory
 			[memory]
 			size = "8192M"`,
+		}, {
+			qemuMemoryOpts{4096, 16384},
+			`# Memory
+			[memory]
+			size = "4096M"
+			maxmem = "16384M"
+			slots = "1"`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuMemory(&tc.opts))
 		}
 	})
 
+	t.Run("qemu_memory_hotplug", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuMemoryHotplugOpts
+			expected string
+		}{{
+			qemuMemoryHotplugOpts{qemuDevOpts{"pcie", "qemu_pcie0", "00.7", true}, 12288, 0, nil, "indexed"},
+			`# Hotpluggable memory
+			[object "qemu_memory_hotplug_mem"]
+			qom-type = "memory-backend-memfd"
+			size = "12288M"
+			share = "on"
+
+			[device "qemu_memory_hotplug"]
+			driver = "virtio-mem-pci"
+			bus = "qemu_pcie0"
+			addr = "00.7"
+			multifunction = "on"
+			memdev = "qemu_memory_hotplug_mem"
+			node = "0"
+			requested-size = "0"`,
+		}, {
+			qemuMemoryHotplugOpts{qemuDevOpts{"pcie", "qemu_pcie0", "00.7", true}, 4096, 0, []int64{1, 3}, "indexed"},
+			`# Hotpluggable memory
+			[object "qemu_memory_hotplug_mem"]
+			qom-type = "memory-backend-memfd"
+			size = "4096M"
+			share = "on"
+			policy = "bind"
+			host-nodes.0 = "1"
+			host-nodes.1 = "3"
+
+			[device "qemu_memory_hotplug"]
+			driver = "virtio-mem-pci"
+			bus = "qemu_pcie0"
+			addr = "00.7"
+			multifunction = "on"
+			memdev = "qemu_memory_hotplug_mem"
+			node = "0"
+			requested-size = "0"`,
+		}, {
+			qemuMemoryHotplugOpts{qemuDevOpts{"pci", "qemu_pcie0", "00.7", false}, 2048, 0, []int64{2}, "repeated"},
+			`# Hotpluggable memory
+			[object "qemu_memory_hotplug_mem"]
+			qom-type = "memory-backend-memfd"
+			size = "2048M"
+			share = "on"
+			policy = "bind"
+			host-nodes = "2"
+
+			[device "qemu_memory_hotplug"]
+			driver = "virtio-mem-pci"
+			bus = "qemu_pcie0"
+			addr = "00.7"
+			memdev = "qemu_memory_hotplug_mem"
+			node = "0"
+			requested-size = "0"`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuMemoryHotplug(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_serial", func(t *testing.T) {
 		testCases := []struct {
 			opts     qemuSerialOpts
 			expected string
 		}{{
-			qemuSerialOpts{qemuDevOpts{"pci", "qemu_pcie0", "00.5", false}, "qemu_serial-chardev", 32},
+			qemuSerialOpts{qemuDevOpts{"pci", "qemu_pcie0", "00.5", false}, "qemu_serial-chardev", 32, true},
 			`# Virtual serial bus
 			[device "dev-qemu_serial"]
 			driver = "virtio-serial-pci"
@@ -163,6 +236,30 @@ func TestQemuConfigTemplates(t *testing.T) {
 			chardev = "qemu_spicedir-chardev"
 			bus = "dev-qemu_serial.0"
 			`,
+		}, {
+			qemuSerialOpts{qemuDevOpts{"pci", "qemu_pcie0", "00.5", false}, "qemu_serial-chardev", 32, false},
+			`# Virtual serial bus
+			[device "dev-qemu_serial"]
+			driver = "virtio-serial-pci"
+			bus = "qemu_pcie0"
+			addr = "00.5"
+
+			# Serial identifier
+			[chardev "qemu_serial-chardev"]
+			backend = "ringbuf"
+			size = "32B"
+
+			[device "qemu_serial"]
+			driver = "virtserialport"
+			name = "org.linuxcontainers.incus"
+			chardev = "qemu_serial-chardev"
+			bus = "dev-qemu_serial.0"
+
+			[device "qemu_serial_legacy"]
+			driver = "virtserialport"
+			name = "org.linuxcontainers.lxd"
+			bus = "dev-qemu_serial.0"
+			`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuSerial(&tc.opts))
@@ -408,6 +505,44 @@ func TestQemuConfigTemplates(t *testing.T) {
 		}
 	})
 
+	t.Run("qemu_pvpanic", func(t *testing.T) {
+		runTest(`# Panic notification
+			[device "qemu_pvpanic"]
+			driver = "pvpanic"
+			`, qemuPVPanic())
+	})
+
+	t.Run("qemu_watchdog", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuWatchdogOpts
+			expected string
+		}{{
+			qemuWatchdogOpts{
+				dev:     qemuDevOpts{"pcie", "qemu_pcie5", "00.0", false},
+				devName: "wd0",
+				model:   "i6300esb",
+			},
+			`# Watchdog ("wd0" device)
+			[device "dev-incus_wd0"]
+			driver = "i6300esb"
+			bus = "qemu_pcie5"
+			addr = "00.0"
+			`,
+		}, {
+			qemuWatchdogOpts{
+				devName: "wd0",
+				model:   "ib700",
+			},
+			`# Watchdog ("wd0" device)
+			[device "dev-incus_wd0"]
+			driver = "ib700"
+			`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuWatchdog(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_cpu", func(t *testing.T) {
 		testCases := []struct {
 			opts     qemuCPUOpts
@@ -879,6 +1014,29 @@ func TestQemuConfigTemplates(t *testing.T) {
 			addr = "10.2"
 			tag = "vtag"
 			chardev = "incus_vfs"`,
+		}, {
+			qemuDriveDirOpts{
+				dev:       qemuDevOpts{"pcie", "qemu_pcie1", "10.2", false},
+				path:      "/dev/virtio",
+				devName:   "vfs",
+				mountTag:  "vtag",
+				protocol:  "virtio-fs",
+				queues:    4,
+				queueSize: 512,
+			},
+			`# vfs drive (virtio-fs)
+			[chardev "incus_vfs"]
+			backend = "socket"
+			path = "/dev/virtio"
+
+			[device "dev-incus_vfs-virtio-fs"]
+			driver = "vhost-user-fs-pci"
+			bus = "qemu_pcie1"
+			addr = "10.2"
+			tag = "vtag"
+			chardev = "incus_vfs"
+			num-request-queues = "4"
+			queue-size = "512"`,
 		}, {
 			qemuDriveDirOpts{
 				dev:      qemuDevOpts{"ccw", "qemu_pcie0", "00.0", true},
@@ -1020,6 +1178,7 @@ func TestQemuConfigTemplates(t *testing.T) {
 				devAddr:       "00.0",
 				multifunction: true,
 				ports:         3,
+				spice:         true,
 			},
 			`# USB controller
 			[device "qemu_usb"]
@@ -1053,12 +1212,50 @@ func TestQemuConfigTemplates(t *testing.T) {
 			[device "qemu_spice-usb3"]
 			driver = "usb-redir"
 			chardev = "qemu_spice-usb-chardev3"`,
+		}, {
+			qemuUSBOpts{
+				devBus:        "qemu_pcie1",
+				devAddr:       "00.0",
+				multifunction: true,
+				ports:         3,
+			},
+			`# USB controller
+			[device "qemu_usb"]
+			driver = "qemu-xhci"
+			bus = "qemu_pcie1"
+			addr = "00.0"
+			multifunction = "on"
+			p2 = "3"
+			p3 = "3"`,
 		}}
 		for _, tc := range testCases {
 			runTest(tc.expected, qemuUSB(&tc.opts))
 		}
 	})
 
+	t.Run("qemu_sound", func(t *testing.T) {
+		testCases := []struct {
+			opts     qemuSoundOpts
+			expected string
+		}{{
+			qemuSoundOpts{qemuDevOpts{"pcie", "qemu_pcie4", "00.0", false}, "qemu_audio"},
+			`# Sound card
+			[device "qemu_sound"]
+			driver = "intel-hda"
+			bus = "qemu_pcie4"
+			addr = "00.0"
+
+			[device "qemu_sound-codec"]
+			driver = "hda-duplex"
+			bus = "qemu_sound.0"
+			audiodev = "qemu_audio"
+			`,
+		}}
+		for _, tc := range testCases {
+			runTest(tc.expected, qemuSound(&tc.opts))
+		}
+	})
+
 	t.Run("qemu_tpm", func(t *testing.T) {
 		testCases := []struct {
 			opts     qemuTPMOpts
@@ -85,6 +85,7 @@ func qemuBase(opts *qemuBaseOpts) []cfgSection {
 			{key: "cap-large-decr", value: capLargeDecr},
 			{key: "accel", value: "kvm"},
 			{key: "usb", value: "off"},
+			{key: "dump-guest-core", value: "off"},
 		},
 	}}
 
@@ -115,14 +116,70 @@ func qemuBase(opts *qemuBaseOpts) []cfgSection {
 }
 
 type qemuMemoryOpts struct {
-	memSizeMB int64
+	memSizeMB    int64
+	maxMemSizeMB int64
 }
 
 func qemuMemory(opts *qemuMemoryOpts) []cfgSection {
+	entries := []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}}
+
+	if opts.maxMemSizeMB > opts.memSizeMB {
+		entries = append(entries, cfgEntry{key: "maxmem", value: fmt.Sprintf("%dM", opts.maxMemSizeMB)})
+		entries = append(entries, cfgEntry{key: "slots", value: "1"})
+	}
+
 	return []cfgSection{{
 		name:    "memory",
 		comment: "Memory",
-		entries: []cfgEntry{{key: "size", value: fmt.Sprintf("%dM", opts.memSizeMB)}},
+		entries: entries,
+	}}
+}
+
+type qemuMemoryHotplugOpts struct {
+	dev                 qemuDevOpts
+	sizeMB              int64
+	node                uint64
+	hostNodes           []int64
+	qemuMemObjectFormat string
+}
+
+func qemuMemoryHotplug(opts *qemuMemoryHotplugOpts) []cfgSection {
+	entriesOpts := qemuDevEntriesOpts{
+		dev:     opts.dev,
+		pciName: "virtio-mem-pci",
+	}
+
+	memEntries := []cfgEntry{
+		{key: "qom-type", value: "memory-backend-memfd"},
+		{key: "size", value: fmt.Sprintf("%dM", opts.sizeMB)},
+		{key: "share", value: "on"},
+	}
+
+	// Restrict the memory to the host NUMA nodes of the guest NUMA node.
+	if len(opts.hostNodes) > 0 {
+		memEntries = append(memEntries, cfgEntry{key: "policy", value: "bind"})
+
+		for index, element := range opts.hostNodes {
+			hostNodesKey := "host-nodes"
+			if opts.qemuMemObjectFormat == "indexed" {
+				hostNodesKey = fmt.Sprintf("host-nodes.%d", index)
+			}
+
+			memEntries = append(memEntries, cfgEntry{key: hostNodesKey, value: fmt.Sprintf("%d", element)})
+		}
+	}
+
+	return []cfgSection{{
+		name:    `object "qemu_memory_hotplug_mem"`,
+		comment: "Hotpluggable memory",
+		entries: memEntries,
+	}, {
+		name: `device "qemu_memory_hotplug"`,
+		entries: append(qemuDeviceEntries(&entriesOpts),
+			cfgEntry{key: "memdev", value: "qemu_memory_hotplug_mem"},
+			cfgEntry{key: "node", value: fmt.Sprintf("%d", opts.node)},
+			cfgEntry{key: "requested-size", value: "0"},
+		),
 	}}
 }
 
@@ -163,6 +220,7 @@ type qemuSerialOpts struct {
 	dev              qemuDevOpts
 	charDevName      string
 	ringbufSizeBytes int
+	spice            bool
 }
 
 func qemuSerial(opts *qemuSerialOpts) []cfgSection {
@@ -172,7 +230,7 @@ func qemuSerial(opts *qemuSerialOpts) []cfgSection {
 		ccwName: "virtio-serial-ccw",
 	}
 
-	return []cfgSection{{
+	sections := []cfgSection{{
 		name:    `device "dev-qemu_serial"`,
 		comment: "Virtual serial bus",
 		entries: qemuDeviceEntries(&entriesOpts),
@@ -204,7 +262,13 @@ func qemuSerial(opts *qemuSerialOpts) []cfgSection {
 			{key: "name", value: "org.linuxcontainers.lxd"},
 			{key: "bus", value: "dev-qemu_serial.0"},
 		},
-	}, {
+	}}
+
+	if !opts.spice {
+		return sections
+	}
+
+	return append(sections, []cfgSection{{
 		name:    `chardev "qemu_spice-chardev"`,
 		comment: "Spice agent",
 		entries: []cfgEntry{
@@ -234,7 +298,7 @@ func qemuSerial(opts *qemuSerialOpts) []cfgSection {
 			{key: "chardev", value: "qemu_spicedir-chardev"},
 			{key: "bus", value: "dev-qemu_serial.0"},
 		},
-	}}
+	}}...)
 }
 
 type qemuPCIeOpts struct {
@@ -312,6 +376,7 @@ func qemuRNG(opts *qemuDevOpts) []cfgSection {
 }
 
 type qemuSevOpts struct {
+	snp             bool
 	cbitpos         int
 	reducedPhysBits int
 	policy          string
@@ -320,8 +385,13 @@ type qemuSevOpts struct {
 }
 
 func qemuSEV(opts *qemuSevOpts) []cfgSection {
+	qomType := "sev-guest"
+	if opts.snp {
+		qomType = "sev-snp-guest"
+	}
+
 	entries := []cfgEntry{
-		{key: "qom-type", value: "sev-guest"},
+		{key: "qom-type", value: qomType},
 		{key: "cbitpos", value: fmt.Sprintf("%d", opts.cbitpos)},
 		{key: "reduced-phys-bits", value: fmt.Sprintf("%d", opts.reducedPhysBits)},
 		{key: "policy", value: opts.policy},
@@ -338,6 +408,16 @@ func qemuSEV(opts *qemuSevOpts) []cfgSection {
 	}}
 }
 
+func qemuTDX() []cfgSection {
+	return []cfgSection{{
+		name:    `object "tdx0"`,
+		comment: "Trust Domain Extensions",
+		entries: []cfgEntry{
+			{key: "qom-type", value: "tdx-guest"},
+		},
+	}}
+}
+
 type qemuVsockOpts struct {
 	dev     qemuDevOpts
 	vsockFD int
@@ -415,6 +495,16 @@ func qemuTablet(opts *qemuDevOpts) []cfgSection {
 	}}
 }
 
+func qemuPVPanic() []cfgSection {
+	return []cfgSection{{
+		name:    `device "qemu_pvpanic"`,
+		comment: "Panic notification",
+		entries: []cfgEntry{
+			{key: "driver", value: "pvpanic"},
+		},
+	}}
+}
+
 type qemuNumaEntry struct {
 	node   uint64
 	socket uint64
@@ -663,6 +753,8 @@ type qemuHostDriveOpts struct {
 	sockFd        string
 	readonly      bool
 	protocol      string
+	queues        int
+	queueSize     int
 }
 
 func qemuHostDrive(opts *qemuHostDriveOpts) []cfgSection {
@@ -714,6 +806,14 @@ func qemuHostDrive(opts *qemuHostDriveOpts) []cfgSection {
 			{key: "tag", value: opts.mountTag},
 			{key: "chardev", value: opts.name},
 		}
+
+		if opts.queues > 0 {
+			extraDeviceEntries = append(extraDeviceEntries, cfgEntry{key: "num-request-queues", value: fmt.Sprintf("%d", opts.queues)})
+		}
+
+		if opts.queueSize > 0 {
+			extraDeviceEntries = append(extraDeviceEntries, cfgEntry{key: "queue-size", value: fmt.Sprintf("%d", opts.queueSize)})
+		}
 	} else {
 		return []cfgSection{}
 	}
@@ -751,26 +851,30 @@ func qemuDriveConfig(opts *qemuDriveConfigOpts) []cfgSection {
 }
 
 type qemuDriveDirOpts struct {
-	dev      qemuDevOpts
-	devName  string
-	mountTag string
-	path     string
-	protocol string
-	proxyFD  int
-	readonly bool
+	dev       qemuDevOpts
+	devName   string
+	mountTag  string
+	path      string
+	protocol  string
+	proxyFD   int
+	readonly  bool
+	queues    int
+	queueSize int
 }
 
 func qemuDriveDir(opts *qemuDriveDirOpts) []cfgSection {
 	return qemuHostDrive(&qemuHostDriveOpts{
-		dev:      opts.dev,
-		name:     fmt.Sprintf("incus_%s", opts.devName),
-		comment:  fmt.Sprintf("%s drive (%s)", opts.devName, opts.protocol),
-		mountTag: opts.mountTag,
-		protocol: opts.protocol,
-		fsdriver: "proxy",
-		readonly: opts.readonly,
-		path:     opts.path,
-		sockFd:   fmt.Sprintf("%d", opts.proxyFD),
+		dev:       opts.dev,
+		name:      fmt.Sprintf("incus_%s", opts.devName),
+		comment:   fmt.Sprintf("%s drive (%s)", opts.devName, opts.protocol),
+		mountTag:  opts.mountTag,
+		protocol:  opts.protocol,
+		fsdriver:  "proxy",
+		readonly:  opts.readonly,
+		path:      opts.path,
+		sockFd:    fmt.Sprintf("%d", opts.proxyFD),
+		queues:    opts.queues,
+		queueSize: opts.queueSize,
 	})
 }
 
@@ -838,6 +942,7 @@ type qemuUSBOpts struct {
 	devAddr       string
 	multifunction bool
 	ports         int
+	spice         bool
 }
 
 func qemuUSB(opts *qemuUSBOpts) []cfgSection {
@@ -860,6 +965,10 @@ func qemuUSB(opts *qemuUSBOpts) []cfgSection {
 		}...),
 	}}
 
+	if !opts.spice {
+		return sections
+	}
+
 	for i := 1; i <= 3; i++ {
 		chardev := fmt.Sprintf("qemu_spice-usb-chardev%d", i)
 		sections = append(sections, []cfgSection{{
@@ -880,6 +989,31 @@ func qemuUSB(opts *qemuUSBOpts) []cfgSection {
 	return sections
 }
 
+type qemuSoundOpts struct {
+	dev       qemuDevOpts
+	audioName string
+}
+
+func qemuSound(opts *qemuSoundOpts) []cfgSection {
+	deviceOpts := qemuDevEntriesOpts{
+		dev:     opts.dev,
+		pciName: "intel-hda",
+	}
+
+	return []cfgSection{{
+		name:    `device "qemu_sound"`,
+		comment: "Sound card",
+		entries: qemuDeviceEntries(&deviceOpts),
+	}, {
+		name: `device "qemu_sound-codec"`,
+		entries: []cfgEntry{
+			{key: "driver", value: "hda-duplex"},
+			{key: "bus", value: "qemu_sound.0"},
+			{key: "audiodev", value: opts.audioName},
+		},
+	}}
+}
+
 type qemuTPMOpts struct {
 	devName string
 	path    string
@@ -910,6 +1044,34 @@ func qemuTPM(opts *qemuTPMOpts) []cfgSection {
 	}}
 }
 
+type qemuWatchdogOpts struct {
+	dev     qemuDevOpts
+	devName string
+	model   string
+}
+
+func qemuWatchdog(opts *qemuWatchdogOpts) []cfgSection {
+	entries := []cfgEntry{
+		{key: "driver", value: opts.model},
+	}
+
+	// The i6300esb watchdog is a PCI device.
+	if opts.model == "i6300esb" {
+		deviceOpts := qemuDevEntriesOpts{
+			dev:     opts.dev,
+			pciName: opts.model,
+		}
+
+		entries = qemuDeviceEntries(&deviceOpts)
+	}
+
+	return []cfgSection{{
+		name:    fmt.Sprintf(`device "%s%s"`, qemuDeviceIDPrefix, opts.devName),
+		comment: fmt.Sprintf(`Watchdog ("%s" device)`, opts.devName),
+		entries: entries,
+	}}
+}
+
 type qemuVmgenIDOpts struct {
 	guid string
 }
@@ -0,0 +1,42 @@
+package drivers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQemuGPUHotplugDevices(t *testing.T) {
+	tests := []struct {
+		name             string
+		pciSlotName      string
+		relatedSlotNames []string
+		expected         []map[string]string
+	}{
+		{
+			name:        "single function",
+			pciSlotName: "0000:01:00.0",
+			expected: []map[string]string{
+				{"driver": "vfio-pci", "bus": "qemu_pcie6", "addr": "00.0", "host": "0000:01:00.0", "id": "dev-incus_gpu0"},
+			},
+		},
+		{
+			name:             "multiple functions",
+			pciSlotName:      "0000:01:00.0",
+			relatedSlotNames: []string{"0000:01:00.1", "0000:01:00.2"},
+			expected: []map[string]string{
+				{"driver": "vfio-pci", "bus": "qemu_pcie6", "addr": "00.1", "host": "0000:01:00.1", "id": "dev-incus_gpu0_00.1"},
+				{"driver": "vfio-pci", "bus": "qemu_pcie6", "addr": "00.2", "host": "0000:01:00.2", "id": "dev-incus_gpu0_00.2"},
+				{"driver": "vfio-pci", "bus": "qemu_pcie6", "addr": "00.0", "host": "0000:01:00.0", "id": "dev-incus_gpu0", "multifunction": "on"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			qemuDevs := qemuGPUHotplugDevices("gpu0", "qemu_pcie6", test.pciSlotName, test.relatedSlotNames)
+			if !reflect.DeepEqual(qemuDevs, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, qemuDevs)
+			}
+		})
+	}
+}
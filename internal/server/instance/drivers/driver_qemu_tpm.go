@@ -0,0 +1,232 @@
+package drivers
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// tpmStateMagic identifies an exported TPM state, followed by the scrypt salt, the AES-GCM nonce and the
+// encrypted tarball of the swtpm state directory.
+var tpmStateMagic = []byte("INCUSTPM\x01")
+
+const (
+	tpmStateSaltSize = 16
+	tpmStateMaxSize  = 64 * 1024 * 1024
+)
+
+// tpmStatePath returns the path of the swtpm state directory of a TPM device.
+func (d *qemu) tpmStatePath(devName string) string {
+	return filepath.Join(d.Path(), "tpm."+devName)
+}
+
+// tpmAccess prepares the state of a TPM device for access and returns a function to call once done with it.
+func (d *qemu) tpmAccess(devName string, write bool) (func(), error) {
+	dev, ok := d.expandedDevices[devName]
+	if !ok || dev["type"] != "tpm" {
+		return nil, api.StatusErrorf(http.StatusNotFound, "TPM device %q not found", devName)
+	}
+
+	isRunning := d.IsRunning()
+	if write && isRunning {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance must be stopped to modify its TPM state")
+	}
+
+	// Running instances already have their config volume mounted.
+	cleanup := func() {}
+	if !isRunning {
+		_, err := d.mount()
+		if err != nil {
+			return nil, err
+		}
+
+		cleanup = func() { _ = d.unmount() }
+	}
+
+	return cleanup, nil
+}
+
+// tpmStateKey derives the encryption key of an exported TPM state from its passphrase.
+func tpmStateKey(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// TPMState returns the state of a TPM device, encrypted with the given passphrase.
+func (d *qemu) TPMState(devName string, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "A passphrase is required to export the TPM state")
+	}
+
+	cleanup, err := d.tpmAccess(devName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	statePath := d.tpmStatePath(devName)
+	entries, err := os.ReadDir(statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, api.StatusErrorf(http.StatusNotFound, "TPM device %q has no state yet", devName)
+		}
+
+		return nil, fmt.Errorf("Failed reading TPM state: %w", err)
+	}
+
+	// Only the regular files hold state, the control socket is skipped.
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(statePath, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading TPM state: %w", err)
+		}
+
+		err = tw.WriteHeader(&tar.Header{Name: entry.Name(), Mode: 0600, Size: int64(len(data))})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tw.Write(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, tpmStateSaltSize)
+	_, err = rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := tpmStateKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append(append(append([]byte{}, tpmStateMagic...), salt...), nonce...)
+
+	return aead.Seal(header, nonce, buf.Bytes(), tpmStateMagic), nil
+}
+
+// TPMStateUpdate replaces the state of a TPM device with a previously exported one.
+func (d *qemu) TPMStateUpdate(devName string, data []byte, passphrase string) error {
+	if passphrase == "" {
+		return api.StatusErrorf(http.StatusBadRequest, "A passphrase is required to import the TPM state")
+	}
+
+	cleanup, err := d.tpmAccess(devName, true)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	if !bytes.HasPrefix(data, tpmStateMagic) || len(data) < len(tpmStateMagic)+tpmStateSaltSize {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid TPM state")
+	}
+
+	data = data[len(tpmStateMagic):]
+	aead, err := tpmStateKey(passphrase, data[:tpmStateSaltSize])
+	if err != nil {
+		return err
+	}
+
+	data = data[tpmStateSaltSize:]
+	if len(data) < aead.NonceSize() {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid TPM state")
+	}
+
+	content, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], tpmStateMagic)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed decrypting TPM state, wrong passphrase?")
+	}
+
+	// Extract the new state next to the current one before swapping them.
+	statePath := d.tpmStatePath(devName)
+	newPath := statePath + ".new"
+
+	_ = os.RemoveAll(newPath)
+	err = os.Mkdir(newPath, 0700)
+	if err != nil {
+		return fmt.Errorf("Failed creating TPM state directory: %w", err)
+	}
+
+	defer func() { _ = os.RemoveAll(newPath) }()
+
+	tr := tar.NewReader(bytes.NewReader(content))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid TPM state: %v", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Name != filepath.Base(hdr.Name) || strings.HasPrefix(hdr.Name, ".") || hdr.Size > tpmStateMaxSize {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid TPM state entry %q", hdr.Name)
+		}
+
+		f, err := os.OpenFile(filepath.Join(newPath, hdr.Name), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+		if err != nil {
+			return fmt.Errorf("Failed writing TPM state: %w", err)
+		}
+
+		_, err = io.Copy(f, tr)
+		closeErr := f.Close()
+		if err != nil || closeErr != nil {
+			return fmt.Errorf("Failed writing TPM state: %w", errors.Join(err, closeErr))
+		}
+	}
+
+	if util.PathExists(statePath) {
+		err = os.RemoveAll(statePath)
+		if err != nil {
+			return fmt.Errorf("Failed removing TPM state: %w", err)
+		}
+	}
+
+	return os.Rename(newPath, statePath)
+}
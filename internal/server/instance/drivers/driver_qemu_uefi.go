@@ -0,0 +1,299 @@
+package drivers
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/instance/drivers/edk2"
+	"github.com/lxc/incus/v6/internal/server/instance/operationlock"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// nvramAccess prepares the instance's NVRAM for access and returns a function to call once done with it.
+func (d *qemu) nvramAccess(write bool) (func(), error) {
+	if !d.architectureSupportsUEFI(d.architecture) {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance doesn't use UEFI firmware")
+	}
+
+	if d.usesStatelessFirmware() {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance uses a stateless firmware without NVRAM")
+	}
+
+	// Hold the operation lock so the instance can't be started or stopped while its NVRAM is accessed.
+	op, err := operationlock.CreateWaitGet(d.Project().Name, d.Name(), operationlock.ActionUpdate, nil, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create instance NVRAM operation: %w", err)
+	}
+
+	revert := revert.New()
+	defer revert.Fail()
+
+	revert.Add(func() { op.Done(nil) })
+
+	isRunning := d.IsRunning()
+	if write && isRunning {
+		return nil, api.StatusErrorf(http.StatusBadRequest, "The instance must be stopped to modify its NVRAM")
+	}
+
+	// Running instances already have their config volume mounted.
+	if !isRunning {
+		_, err := d.mount()
+		if err != nil {
+			return nil, err
+		}
+
+		revert.Add(func() { _ = d.unmount() })
+	}
+
+	// Apply any pending NVRAM re-generation so changes aren't lost on next start.
+	if !isRunning && (!util.PathExists(d.nvramPath()) || util.IsTrue(d.localConfig["volatile.apply_nvram"])) {
+		err := d.setupNvram()
+		if err != nil {
+			return nil, err
+		}
+
+		if d.localConfig["volatile.apply_nvram"] != "" {
+			err = d.VolatileSet(map[string]string{"volatile.apply_nvram": ""})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cleanup := revert.Clone().Fail
+	revert.Success()
+
+	return cleanup, nil
+}
+
+// nvramWrite replaces the content of the instance's NVRAM file.
+func (d *qemu) nvramWrite(data []byte) error {
+	// Resolve the symlink to keep the firmware selection intact.
+	nvramPath, err := filepath.EvalSymlinks(d.nvramPath())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(nvramPath, data, 0600)
+}
+
+// nvramStore parses the variable store of the instance's NVRAM.
+func (d *qemu) nvramStore() (*edk2.VariableStore, error) {
+	data, err := os.ReadFile(d.nvramPath())
+	if err != nil {
+		return nil, fmt.Errorf("Failed reading NVRAM: %w", err)
+	}
+
+	store, err := edk2.ParseVariableStore(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing NVRAM: %w", err)
+	}
+
+	return store, nil
+}
+
+// NVRAM returns the raw content of the instance's NVRAM.
+func (d *qemu) NVRAM() ([]byte, error) {
+	cleanup, err := d.nvramAccess(false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	return os.ReadFile(d.nvramPath())
+}
+
+// NVRAMUpdate replaces the instance's NVRAM with a previously exported one.
+func (d *qemu) NVRAMUpdate(data []byte) error {
+	cleanup, err := d.nvramAccess(true)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	current, err := os.ReadFile(d.nvramPath())
+	if err != nil {
+		return fmt.Errorf("Failed reading NVRAM: %w", err)
+	}
+
+	if len(data) != len(current) {
+		return api.StatusErrorf(http.StatusBadRequest, "NVRAM size mismatch, expected %d bytes but got %d", len(current), len(data))
+	}
+
+	_, err = edk2.ParseVariableStore(data)
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Invalid NVRAM: %v", err)
+	}
+
+	return d.nvramWrite(data)
+}
+
+// UEFIVars returns the UEFI variables stored in the instance's NVRAM.
+func (d *qemu) UEFIVars() (*api.InstanceUEFIVars, error) {
+	cleanup, err := d.nvramAccess(false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer cleanup()
+
+	store, err := d.nvramStore()
+	if err != nil {
+		return nil, err
+	}
+
+	vars := api.InstanceUEFIVars{Variables: make(map[string]api.InstanceUEFIVariable, len(store.Variables))}
+	for _, v := range store.Variables {
+		vars.Variables[v.Key()] = api.InstanceUEFIVariable{
+			Data:       hex.EncodeToString(v.Data),
+			Attributes: v.Attributes,
+		}
+	}
+
+	return &vars, nil
+}
+
+// UEFIVarsUpdate replaces the UEFI variables stored in the instance's NVRAM.
+// Variables missing from the new set are removed.
+func (d *qemu) UEFIVarsUpdate(vars api.InstanceUEFIVars) error {
+	// Validate the new variables before touching the NVRAM.
+	newVars := make(map[string]edk2.Variable, len(vars.Variables))
+	for key, v := range vars.Variables {
+		// Keys are in the "<name>-<vendor GUID>" form and GUIDs are 36 characters long.
+		if len(key) < 38 || key[len(key)-37] != '-' {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid UEFI variable key %q", key)
+		}
+
+		data, err := hex.DecodeString(v.Data)
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid data for UEFI variable %q: %v", key, err)
+		}
+
+		_, err = uuid.Parse(key[len(key)-36:])
+		if err != nil {
+			return api.StatusErrorf(http.StatusBadRequest, "Invalid vendor GUID for UEFI variable %q: %v", key, err)
+		}
+
+		newVars[strings.ToLower(key)] = edk2.Variable{
+			Name:       key[:len(key)-37],
+			VendorGUID: strings.ToLower(key[len(key)-36:]),
+			Attributes: v.Attributes,
+			Data:       data,
+		}
+	}
+
+	cleanup, err := d.nvramAccess(true)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	store, err := d.nvramStore()
+	if err != nil {
+		return err
+	}
+
+	// Keep the existing variables order and authentication data.
+	variables := make([]edk2.Variable, 0, len(newVars))
+	for _, v := range store.Variables {
+		newVar, ok := newVars[strings.ToLower(v.Key())]
+		if !ok {
+			continue
+		}
+
+		newVar.MonotonicCount = v.MonotonicCount
+		newVar.Timestamp = v.Timestamp
+		newVar.PubKeyIndex = v.PubKeyIndex
+		variables = append(variables, newVar)
+		delete(newVars, strings.ToLower(v.Key()))
+	}
+
+	keys := make([]string, 0, len(newVars))
+	for key := range newVars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		variables = append(variables, newVars[key])
+	}
+
+	store.Variables = variables
+
+	data, err := store.Bytes()
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed updating UEFI variables: %v", err)
+	}
+
+	return d.nvramWrite(data)
+}
+
+// UEFIEnrollCertificate adds a certificate to one of the secure boot signature databases (db or KEK).
+func (d *qemu) UEFIEnrollCertificate(database string, cert *x509.Certificate) error {
+	var vendorGUID string
+	switch database {
+	case "db":
+		vendorGUID = edk2.ImageSecurityDatabaseGUID
+	case "KEK":
+		vendorGUID = edk2.GlobalVariableGUID
+	default:
+		return api.StatusErrorf(http.StatusBadRequest, "Unsupported signature database %q", database)
+	}
+
+	cleanup, err := d.nvramAccess(true)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	store, err := d.nvramStore()
+	if err != nil {
+		return err
+	}
+
+	variable := edk2.Variable{
+		Name:       database,
+		VendorGUID: vendorGUID,
+		Attributes: edk2.AttributeNonVolatile | edk2.AttributeBootServiceAccess | edk2.AttributeRuntimeAccess | edk2.AttributeTimeBasedAuthenticatedWriteAccess,
+	}
+
+	existing := store.Get(database, vendorGUID)
+	if existing != nil {
+		// Nothing to do if the certificate is already enrolled.
+		if bytes.Contains(existing.Data, cert.Raw) {
+			return nil
+		}
+
+		variable = *existing
+	}
+
+	signatureList, err := edk2.NewX509SignatureList(uuid.New().String(), cert.Raw)
+	if err != nil {
+		return err
+	}
+
+	variable.Data = append(bytes.Clone(variable.Data), signatureList...)
+	store.Set(variable)
+
+	data, err := store.Bytes()
+	if err != nil {
+		return api.StatusErrorf(http.StatusBadRequest, "Failed enrolling certificate: %v", err)
+	}
+
+	return d.nvramWrite(data)
+}
@@ -31,6 +31,9 @@ const (
 
 	// CSM is a firmware with the UEFI Compatibility Support Module enabled to boot BIOS-only operating systems.
 	CSM
+
+	// STATELESS is a firmware combining code and variables in a single read-only image (used for confidential guests).
+	STATELESS
 )
 
 var architectureInstallations = map[int][]Installation{
@@ -64,6 +67,10 @@ var architectureInstallations = map[int][]Installation{
 				{Code: "OVMF_CODE.CSM.fd", Vars: "OVMF_VARS.CSM.fd"},
 				{Code: "OVMF_CODE.csm.fd", Vars: "OVMF_VARS.fd"},
 			},
+			STATELESS: {
+				{Code: "OVMF.inteltdx.fd", Vars: "OVMF.inteltdx.fd"},
+				{Code: "OVMF.fd", Vars: "OVMF.fd"},
+			},
 		},
 	}, {
 		Path: "/usr/share/qemu",
@@ -76,6 +83,9 @@ var architectureInstallations = map[int][]Installation{
 				{Code: "ovmf-x86_64-ms-4m-vars.bin", Vars: "ovmf-x86_64-ms-4m-code.bin"},
 				{Code: "ovmf-x86_64-ms-code.bin", Vars: "ovmf-x86_64-ms-vars.bin"},
 			},
+			STATELESS: {
+				{Code: "ovmf-x86_64.bin", Vars: "ovmf-x86_64.bin"},
+			},
 		},
 	}},
 	osarch.ARCH_64BIT_ARMV8_LITTLE_ENDIAN: {{
@@ -0,0 +1,326 @@
+package edk2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Well known vendor GUIDs.
+const (
+	// GlobalVariableGUID is the vendor GUID of the UEFI global variables (PK, KEK, ...).
+	GlobalVariableGUID = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+
+	// ImageSecurityDatabaseGUID is the vendor GUID of the secure boot signature databases (db, dbx).
+	ImageSecurityDatabaseGUID = "d719b2cb-3d3a-4596-a3bc-dad00e67656f"
+
+	// CertX509GUID is the signature type of X.509 certificates in signature lists.
+	CertX509GUID = "a5c059a1-94e4-4aa7-87b5-ab155c2bf072"
+)
+
+// Variable attributes.
+const (
+	// AttributeNonVolatile marks a variable as persisted across reboots.
+	AttributeNonVolatile uint32 = 0x01
+
+	// AttributeBootServiceAccess makes a variable accessible during boot services.
+	AttributeBootServiceAccess uint32 = 0x02
+
+	// AttributeRuntimeAccess makes a variable accessible at runtime.
+	AttributeRuntimeAccess uint32 = 0x04
+
+	// AttributeTimeBasedAuthenticatedWriteAccess requires writes to be signed (used by secure boot variables).
+	AttributeTimeBasedAuthenticatedWriteAccess uint32 = 0x20
+)
+
+const (
+	varStoreAuthenticatedGUID = "aaf32c78-947b-439a-a180-2e144ec37792"
+	varStoreGUID              = "ddcf3616-3275-4164-98b6-fe85707ffe7d"
+
+	varStoreHeaderSize       = 28
+	varHeaderSize            = 32
+	varAuthenticatedHeadSize = 60
+	varStartID               = 0x55aa
+	varAdded                 = 0x3f
+	varInDeletedTransition   = 0xfe
+)
+
+// Variable represents a single UEFI variable.
+type Variable struct {
+	Name       string
+	VendorGUID string
+	Attributes uint32
+	Data       []byte
+
+	// Only used by authenticated variable stores.
+	MonotonicCount uint64
+	Timestamp      [16]byte
+	PubKeyIndex    uint32
+}
+
+// Key returns the unique key of the variable in the "<name>-<vendor GUID>" form.
+func (v Variable) Key() string {
+	return v.Name + "-" + v.VendorGUID
+}
+
+// VariableStore represents the EDK2 variable store found in a firmware vars (NVRAM) file.
+type VariableStore struct {
+	Variables []Variable
+
+	raw           []byte
+	start         int
+	end           int
+	authenticated bool
+}
+
+// ParseVariableStore parses the content of an EDK2 NVRAM file.
+func ParseVariableStore(raw []byte) (*VariableStore, error) {
+	// Firmware volume header.
+	if len(raw) < 56 || string(raw[40:44]) != "_FVH" {
+		return nil, fmt.Errorf("Invalid firmware volume header")
+	}
+
+	storeOffset := int(binary.LittleEndian.Uint16(raw[48:50]))
+	if storeOffset+varStoreHeaderSize > len(raw) {
+		return nil, fmt.Errorf("Invalid firmware volume header length")
+	}
+
+	// Variable store header.
+	store := &VariableStore{raw: raw}
+
+	switch guidToString(raw[storeOffset : storeOffset+16]) {
+	case varStoreAuthenticatedGUID:
+		store.authenticated = true
+	case varStoreGUID:
+		store.authenticated = false
+	default:
+		return nil, fmt.Errorf("Unsupported variable store format")
+	}
+
+	storeSize := int(binary.LittleEndian.Uint32(raw[storeOffset+16 : storeOffset+20]))
+	if storeSize < varStoreHeaderSize || storeOffset+storeSize > len(raw) {
+		return nil, fmt.Errorf("Invalid variable store size")
+	}
+
+	store.start = align4(storeOffset + varStoreHeaderSize)
+	store.end = storeOffset + storeSize
+
+	// Variables.
+	headerSize := store.headerSize()
+	offset := store.start
+	for offset+headerSize <= store.end {
+		header := raw[offset : offset+headerSize]
+		if binary.LittleEndian.Uint16(header[0:2]) != varStartID {
+			break
+		}
+
+		state := header[2]
+
+		var v Variable
+		var nameSize, dataSize int
+		v.Attributes = binary.LittleEndian.Uint32(header[4:8])
+		if store.authenticated {
+			v.MonotonicCount = binary.LittleEndian.Uint64(header[8:16])
+			copy(v.Timestamp[:], header[16:32])
+			v.PubKeyIndex = binary.LittleEndian.Uint32(header[32:36])
+			nameSize = int(binary.LittleEndian.Uint32(header[36:40]))
+			dataSize = int(binary.LittleEndian.Uint32(header[40:44]))
+			v.VendorGUID = guidToString(header[44:60])
+		} else {
+			nameSize = int(binary.LittleEndian.Uint32(header[8:12]))
+			dataSize = int(binary.LittleEndian.Uint32(header[12:16]))
+			v.VendorGUID = guidToString(header[16:32])
+		}
+
+		if nameSize < 0 || dataSize < 0 || offset+headerSize+nameSize+dataSize > store.end {
+			return nil, fmt.Errorf("Invalid variable at offset %d", offset)
+		}
+
+		next := align4(offset + headerSize + nameSize + dataSize)
+
+		// Skip deleted variables.
+		if state != varAdded && state != varAdded&varInDeletedTransition {
+			offset = next
+			continue
+		}
+
+		nameStart := offset + headerSize
+		v.Name = decodeUTF16(raw[nameStart : nameStart+nameSize])
+		v.Data = bytes.Clone(raw[nameStart+nameSize : nameStart+nameSize+dataSize])
+
+		// Variables in deleted transition are superseded by their updated copy.
+		if state != varAdded && store.Get(v.Name, v.VendorGUID) != nil {
+			offset = next
+			continue
+		}
+
+		store.Set(v)
+
+		offset = next
+	}
+
+	return store, nil
+}
+
+// Get returns the variable with the given name and vendor GUID, or nil if it doesn't exist.
+func (s *VariableStore) Get(name string, vendorGUID string) *Variable {
+	for i := range s.Variables {
+		if s.Variables[i].Name == name && strings.EqualFold(s.Variables[i].VendorGUID, vendorGUID) {
+			return &s.Variables[i]
+		}
+	}
+
+	return nil
+}
+
+// Set adds a variable to the store or replaces an existing one with the same name and vendor GUID.
+func (s *VariableStore) Set(v Variable) {
+	v.VendorGUID = strings.ToLower(v.VendorGUID)
+
+	existing := s.Get(v.Name, v.VendorGUID)
+	if existing != nil {
+		*existing = v
+		return
+	}
+
+	s.Variables = append(s.Variables, v)
+}
+
+// Bytes returns the NVRAM file content with the variable store rewritten from the current variables.
+func (s *VariableStore) Bytes() ([]byte, error) {
+	raw := bytes.Clone(s.raw)
+	region := raw[s.start:s.end]
+	for i := range region {
+		region[i] = 0xff
+	}
+
+	headerSize := s.headerSize()
+	offset := s.start
+	for _, v := range s.Variables {
+		guid, err := guidFromString(v.VendorGUID)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid vendor GUID of variable %q: %w", v.Name, err)
+		}
+
+		name := encodeUTF16(v.Name)
+		if offset+headerSize+len(name)+len(v.Data) > s.end {
+			return nil, fmt.Errorf("Not enough space left in the variable store")
+		}
+
+		header := raw[offset : offset+headerSize]
+		binary.LittleEndian.PutUint16(header[0:2], varStartID)
+		header[2] = varAdded
+		header[3] = 0
+		binary.LittleEndian.PutUint32(header[4:8], v.Attributes)
+		if s.authenticated {
+			binary.LittleEndian.PutUint64(header[8:16], v.MonotonicCount)
+			copy(header[16:32], v.Timestamp[:])
+			binary.LittleEndian.PutUint32(header[32:36], v.PubKeyIndex)
+			binary.LittleEndian.PutUint32(header[36:40], uint32(len(name)))
+			binary.LittleEndian.PutUint32(header[40:44], uint32(len(v.Data)))
+			copy(header[44:60], guid)
+		} else {
+			binary.LittleEndian.PutUint32(header[8:12], uint32(len(name)))
+			binary.LittleEndian.PutUint32(header[12:16], uint32(len(v.Data)))
+			copy(header[16:32], guid)
+		}
+
+		offset += headerSize
+		offset += copy(raw[offset:], name)
+		offset += copy(raw[offset:], v.Data)
+		offset = align4(offset)
+	}
+
+	return raw, nil
+}
+
+// NewX509SignatureList returns an EFI signature list holding a single DER encoded X.509 certificate.
+func NewX509SignatureList(ownerGUID string, der []byte) ([]byte, error) {
+	signatureType, err := guidFromString(CertX509GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := guidFromString(ownerGUID)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid owner GUID: %w", err)
+	}
+
+	signatureSize := 16 + len(der)
+	buf := make([]byte, 28+signatureSize)
+	copy(buf[0:16], signatureType)
+	binary.LittleEndian.PutUint32(buf[16:20], uint32(len(buf)))
+	binary.LittleEndian.PutUint32(buf[20:24], 0)
+	binary.LittleEndian.PutUint32(buf[24:28], uint32(signatureSize))
+	copy(buf[28:44], owner)
+	copy(buf[44:], der)
+
+	return buf, nil
+}
+
+func (s *VariableStore) headerSize() int {
+	if s.authenticated {
+		return varAuthenticatedHeadSize
+	}
+
+	return varHeaderSize
+}
+
+func align4(offset int) int {
+	return (offset + 3) &^ 3
+}
+
+// guidToString converts a binary (mixed-endian) EFI GUID to its string representation.
+func guidToString(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x", binary.LittleEndian.Uint32(b[0:4]), binary.LittleEndian.Uint16(b[4:6]), binary.LittleEndian.Uint16(b[6:8]), b[8:10], b[10:16])
+}
+
+// guidFromString converts a string GUID to its binary (mixed-endian) EFI representation.
+func guidFromString(s string) ([]byte, error) {
+	fields := strings.Split(s, "-")
+	if len(fields) != 5 || len(fields[0]) != 8 || len(fields[1]) != 4 || len(fields[2]) != 4 || len(fields[3]) != 4 || len(fields[4]) != 12 {
+		return nil, fmt.Errorf("Invalid GUID %q", s)
+	}
+
+	raw, err := hex.DecodeString(strings.Join(fields, ""))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GUID %q: %w", s, err)
+	}
+
+	b := make([]byte, 16)
+	binary.LittleEndian.PutUint32(b[0:4], binary.BigEndian.Uint32(raw[0:4]))
+	binary.LittleEndian.PutUint16(b[4:6], binary.BigEndian.Uint16(raw[4:6]))
+	binary.LittleEndian.PutUint16(b[6:8], binary.BigEndian.Uint16(raw[6:8]))
+	copy(b[8:16], raw[8:16])
+
+	return b, nil
+}
+
+// decodeUTF16 converts a NUL terminated UTF-16LE string.
+func decodeUTF16(b []byte) string {
+	chars := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i : i+2])
+		if c == 0 {
+			break
+		}
+
+		chars = append(chars, c)
+	}
+
+	return string(utf16.Decode(chars))
+}
+
+// encodeUTF16 converts a string to NUL terminated UTF-16LE.
+func encodeUTF16(s string) []byte {
+	chars := append(utf16.Encode([]rune(s)), 0)
+	b := make([]byte, len(chars)*2)
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(b[i*2:], c)
+	}
+
+	return b
+}
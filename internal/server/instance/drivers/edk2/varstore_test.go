@@ -0,0 +1,97 @@
+package edk2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// newTestVarsFile returns an empty authenticated EDK2 vars file.
+func newTestVarsFile(t *testing.T) []byte {
+	raw := bytes.Repeat([]byte{0xff}, 4096)
+
+	// Firmware volume header.
+	copy(raw[0:16], make([]byte, 16))
+	copy(raw[40:44], "_FVH")
+	binary.LittleEndian.PutUint16(raw[48:50], 0x48)
+
+	// Variable store header.
+	guid, err := guidFromString(varStoreAuthenticatedGUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copy(raw[0x48:0x58], guid)
+	binary.LittleEndian.PutUint32(raw[0x58:0x5c], 2048)
+
+	return raw
+}
+
+func TestVariableStore(t *testing.T) {
+	store, err := ParseVariableStore(newTestVarsFile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.Variables) != 0 {
+		t.Fatalf("Expected an empty store, got %d variables", len(store.Variables))
+	}
+
+	store.Set(Variable{Name: "BootOrder", VendorGUID: GlobalVariableGUID, Attributes: 0x07, Data: []byte{0x01, 0x00}})
+	store.Set(Variable{Name: "db", VendorGUID: "D719B2CB-3D3A-4596-A3BC-DAD00E67656F", Attributes: 0x27, Data: []byte("cert")})
+	store.Set(Variable{Name: "BootOrder", VendorGUID: GlobalVariableGUID, Attributes: 0x07, Data: []byte{0x02, 0x00}})
+
+	raw, err := store.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = ParseVariableStore(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.Variables) != 2 {
+		t.Fatalf("Expected 2 variables, got %d", len(store.Variables))
+	}
+
+	bootOrder := store.Get("BootOrder", GlobalVariableGUID)
+	if bootOrder == nil || !bytes.Equal(bootOrder.Data, []byte{0x02, 0x00}) {
+		t.Fatalf("Unexpected BootOrder variable: %+v", bootOrder)
+	}
+
+	db := store.Get("db", ImageSecurityDatabaseGUID)
+	if db == nil || db.Attributes != 0x27 || string(db.Data) != "cert" {
+		t.Fatalf("Unexpected db variable: %+v", db)
+	}
+
+	if db.Key() != "db-"+ImageSecurityDatabaseGUID {
+		t.Fatalf("Unexpected variable key %q", db.Key())
+	}
+
+	// Overflowing the store must fail.
+	store.Set(Variable{Name: "Large", VendorGUID: GlobalVariableGUID, Data: make([]byte, 4096)})
+	_, err = store.Bytes()
+	if err == nil {
+		t.Fatal("Expected an error when overflowing the variable store")
+	}
+}
+
+func TestNewX509SignatureList(t *testing.T) {
+	list, err := NewX509SignatureList(GlobalVariableGUID, []byte("der"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if guidToString(list[0:16]) != CertX509GUID {
+		t.Fatalf("Unexpected signature type %q", guidToString(list[0:16]))
+	}
+
+	if binary.LittleEndian.Uint32(list[16:20]) != uint32(len(list)) || len(list) != 28+16+3 {
+		t.Fatalf("Unexpected signature list size %d", len(list))
+	}
+
+	if guidToString(list[28:44]) != GlobalVariableGUID || string(list[44:]) != "der" {
+		t.Fatal("Unexpected signature data")
+	}
+}
@@ -32,6 +32,24 @@ type AddFdInfo struct {
 	FD int `json:"fd"`
 }
 
+// MigrationRAMStatus contains the RAM transfer statistics of a migration.
+type MigrationRAMStatus struct {
+	Transferred    int64   `json:"transferred"`
+	Remaining      int64   `json:"remaining"`
+	Total          int64   `json:"total"`
+	DirtyPagesRate int64   `json:"dirty-pages-rate"`
+	DirtySyncCount int64   `json:"dirty-sync-count"`
+	PageSize       int64   `json:"page-size"`
+	Mbps           float64 `json:"mbps"`
+}
+
+// MigrationStatus contains information about the current migration.
+type MigrationStatus struct {
+	Status           string              `json:"status"`
+	ExpectedDowntime int64               `json:"expected-downtime"`
+	RAM              *MigrationRAMStatus `json:"ram"`
+}
+
 // CPUInstanceProperties contains CPU instance properties.
 type CPUInstanceProperties struct {
 	NodeID    int `json:"node-id,omitempty"`
@@ -61,6 +79,15 @@ type HotpluggableCPU struct {
 	Props CPUInstanceProperties `json:"props"`
 }
 
+// VirtioMemDevice contains information about a virtio-mem device.
+type VirtioMemDevice struct {
+	ID            string `json:"id"`
+	Size          int64  `json:"size"`
+	MaxSize       int64  `json:"max-size"`
+	BlockSize     int64  `json:"block-size"`
+	RequestedSize int64  `json:"requested-size"`
+}
+
 // QueryCPUs returns a list of CPUs.
 func (m *Monitor) QueryCPUs() ([]CPU, error) {
 	// Prepare the response.
@@ -252,6 +279,40 @@ func (m *Monitor) RemoveFDFromFDSet(name string) error {
 	return nil
 }
 
+// MigrateSetParameters sets the parameters used during migration.
+func (m *Monitor) MigrateSetParameters(params map[string]any) error {
+	err := m.run("migrate-set-parameters", params, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MigrateStartPostcopy switches a running migration to post-copy mode.
+func (m *Monitor) MigrateStartPostcopy() error {
+	err := m.run("migrate-start-postcopy", nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// QueryMigrate returns the status of the current migration.
+func (m *Monitor) QueryMigrate() (*MigrationStatus, error) {
+	var resp struct {
+		Return MigrationStatus `json:"return"`
+	}
+
+	err := m.run("query-migrate", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Return, nil
+}
+
 // MigrateSetCapabilities sets the capabilities used during migration.
 func (m *Monitor) MigrateSetCapabilities(caps map[string]bool) error {
 	var args struct {
@@ -465,6 +526,44 @@ func (m *Monitor) SetMemoryBalloonSizeBytes(sizeBytes int64) error {
 	return m.run("balloon", args, nil)
 }
 
+// GetVirtioMemDevices returns the virtio-mem devices attached to the VM.
+func (m *Monitor) GetVirtioMemDevices() ([]VirtioMemDevice, error) {
+	// Prepare the response.
+	var resp struct {
+		Return []struct {
+			Type string          `json:"type"`
+			Data VirtioMemDevice `json:"data"`
+		} `json:"return"`
+	}
+
+	err := m.run("query-memory-devices", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []VirtioMemDevice{}
+	for _, device := range resp.Return {
+		if device.Type != "virtio-mem" {
+			continue
+		}
+
+		devices = append(devices, device.Data)
+	}
+
+	return devices, nil
+}
+
+// SetVirtioMemRequestedSize sets the amount of memory in bytes that the virtio-mem device should provide to the guest.
+func (m *Monitor) SetVirtioMemRequestedSize(deviceID string, sizeBytes int64) error {
+	args := map[string]any{
+		"path":     "/machine/peripheral/" + deviceID,
+		"property": "requested-size",
+		"value":    sizeBytes,
+	}
+
+	return m.run("qom-set", args, nil)
+}
+
 // AddBlockDevice adds a block device.
 func (m *Monitor) AddBlockDevice(blockDev map[string]any, device map[string]string) error {
 	revert := revert.New()
@@ -770,6 +869,23 @@ func (m *Monitor) SEVCapabilities() (AMDSEVCapabilities, error) {
 	return resp.Return, nil
 }
 
+// SEVLaunchMeasurement returns the base64-encoded launch measurement of an AMD SEV guest.
+func (m *Monitor) SEVLaunchMeasurement() (string, error) {
+	// Prepare the response
+	var resp struct {
+		Return struct {
+			Data string `json:"data"`
+		} `json:"return"`
+	}
+
+	err := m.run("query-sev-launch-measure", nil, &resp)
+	if err != nil {
+		return "", fmt.Errorf("Failed querying SEV launch measurement: %w", err)
+	}
+
+	return resp.Return.Data, nil
+}
+
 // NBDServerStart starts internal NBD server and returns a connection to it.
 func (m *Monitor) NBDServerStart() (net.Conn, error) {
 	var args struct {
@@ -1015,6 +1131,24 @@ func (m *Monitor) Eject(id string) error {
 	return nil
 }
 
+// Screendump writes a PNG screenshot of the display to the file.
+func (m *Monitor) Screendump(filename string) error {
+	var args struct {
+		Filename string `json:"filename"`
+		Format   string `json:"format"`
+	}
+
+	args.Filename = filename
+	args.Format = "png"
+
+	err := m.run("screendump", args, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // SetBlockThrottle applies an I/O limit on a disk.
 func (m *Monitor) SetBlockThrottle(id string, bytesRead int, bytesWrite int, iopsRead int, iopsWrite int) error {
 	var args struct {
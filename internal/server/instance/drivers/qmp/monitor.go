@@ -28,6 +28,12 @@ var EventVMShutdown = "SHUTDOWN"
 // EventVMShutdownReasonDisconnect is used as the reason when the shutdown event is triggered by a QMP disconnect.
 var EventVMShutdownReasonDisconnect = "disconnect"
 
+// EventGuestPanicked is the event sent when the guest reports a panic.
+var EventGuestPanicked = "GUEST_PANICKED"
+
+// EventWatchdog is the event sent when the watchdog device of the guest expires.
+var EventWatchdog = "WATCHDOG"
+
 // EventDiskEjected is used to indicate that a disk device was ejected by the guest.
 var EventDiskEjected = "DEVICE_TRAY_MOVED"
 
@@ -169,6 +169,10 @@ type Instance interface {
 	DeferTemplateApply(trigger TemplateTrigger) error
 
 	Metrics(hostInterfaces []net.Interface) (*metrics.MetricSet, error)
+
+	// Crash reporting.
+	Crashes() ([]api.InstanceCrash, error)
+	RecordCrash(crash api.InstanceCrash) error
 }
 
 // Container interface is for container specific functions.
@@ -182,6 +186,8 @@ type Container interface {
 	InsertSeccompUnixDevice(prefix string, m deviceConfig.Device, pid int) error
 	DevptsFd() (*os.File, error)
 	IdmappedStorage(path string, fstype string) idmap.IdmapStorageType
+	Idmap() (*api.InstanceIdmap, error)
+	OOMKills() (int64, error)
 }
 
 // VM interface is for VM specific functions.
@@ -189,6 +195,25 @@ type VM interface {
 	Instance
 
 	AgentCertificate() *x509.Certificate
+	AgentRunning() bool
+	ConsoleScreenshot() ([]byte, error)
+	Attestation() (*api.InstanceAttestation, error)
+	AttestationReport(nonce []byte) ([]byte, error)
+
+	Users() ([]api.InstanceUser, error)
+	User(name string) (*api.InstanceUser, error)
+	UserCreate(req api.InstanceUsersPost) error
+	UserUpdate(name string, req api.InstanceUserPut) error
+	UserDelete(name string) error
+
+	NVRAM() ([]byte, error)
+	NVRAMUpdate(data []byte) error
+	UEFIVars() (*api.InstanceUEFIVars, error)
+	UEFIVarsUpdate(vars api.InstanceUEFIVars) error
+
+	TPMState(devName string, passphrase string) ([]byte, error)
+	TPMStateUpdate(devName string, data []byte, passphrase string) error
+	UEFIEnrollCertificate(database string, cert *x509.Certificate) error
 }
 
 // CriuMigrationArgs arguments for CRIU migration.
@@ -202,6 +227,8 @@ type CriuMigrationArgs struct {
 	PreDumpDir   string
 	Features     liblxc.CriuFeatures
 	Op           *operationlock.InstanceOperation
+	LazyPages    bool
+	LazyPort     int
 }
 
 // Info represents information about an instance driver.
@@ -230,6 +257,7 @@ type MigrateSendArgs struct {
 	MigrateArgs
 
 	AllowInconsistent bool
+	LiveMigration     *api.InstancePostLiveMigration
 }
 
 // MigrateReceiveArgs represent arguments for instance migration receive.
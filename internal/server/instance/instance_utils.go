@@ -92,6 +92,13 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		}
 	}
 
+	if expanded {
+		err := instance.ValidCloudInitConfig(config)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, rawSeccomp := config["raw.seccomp"]
 	_, isAllow, err := exclusiveConfigKeys("security.syscalls.allow", "security.syscalls.whitelist", config)
 	if err != nil {
@@ -134,6 +141,34 @@ func ValidConfig(sysOS *sys.OS, config map[string]string, expanded bool, instanc
 		return fmt.Errorf("No uid/gid allocation configured. In this mode, only privileged containers are supported")
 	}
 
+	if expanded {
+		for k := range config {
+			if !strings.HasPrefix(k, "tasks.") {
+				continue
+			}
+
+			taskName := strings.TrimPrefix(k[:strings.LastIndex(k, ".")], "tasks.")
+			if taskName == "" || strings.Contains(taskName, ".") {
+				return fmt.Errorf("Invalid task name in %q", k)
+			}
+
+			if config["tasks."+taskName+".schedule"] == "" || config["tasks."+taskName+".command"] == "" {
+				return fmt.Errorf("Task %q requires both tasks.%s.schedule and tasks.%s.command to be set", taskName, taskName, taskName)
+			}
+		}
+	}
+
+	if expanded && config["limits.cpu.allocation"] == "numa-auto" {
+		if config["limits.cpu.nodes"] == "balanced" {
+			return fmt.Errorf("limits.cpu.allocation=numa-auto is incompatible with limits.cpu.nodes=balanced")
+		}
+
+		_, err := strconv.Atoi(config["limits.cpu"])
+		if err != nil && (config["limits.cpu"] != "" || instanceType == instancetype.Container) {
+			return fmt.Errorf("limits.cpu.allocation=numa-auto requires limits.cpu to be a number of CPUs")
+		}
+	}
+
 	if util.IsTrue(config["security.privileged"]) && util.IsTrue(config["nvidia.runtime"]) {
 		return fmt.Errorf("nvidia.runtime is incompatible with privileged containers")
 	}
@@ -568,7 +603,7 @@ func ResolveImage(ctx context.Context, tx *db.ClusterTx, projectName string, sou
 // A nil list indicates that we can't tell at this stage, typically for private images.
 func SuitableArchitectures(ctx context.Context, s *state.State, tx *db.ClusterTx, projectName string, sourceInst *cluster.Instance, sourceImageRef string, req api.InstancesPost) ([]int, error) {
 	// Handle cases where the architecture is already provided.
-	if slices.Contains([]string{"migration", "none"}, req.Source.Type) && req.Architecture != "" {
+	if slices.Contains([]string{"disk", "migration", "none"}, req.Source.Type) && req.Architecture != "" {
 		id, err := osarch.ArchitectureId(req.Architecture)
 		if err != nil {
 			return nil, err
@@ -582,8 +617,8 @@ func SuitableArchitectures(ctx context.Context, s *state.State, tx *db.ClusterTx
 		return nil, api.StatusErrorf(http.StatusBadRequest, "An architecture must be specified in migration requests")
 	}
 
-	// For none, allow any architecture.
-	if req.Source.Type == "none" {
+	// For none and disk, allow any architecture.
+	if req.Source.Type == "none" || req.Source.Type == "disk" {
 		return []int{}, nil
 	}
 
@@ -700,6 +735,11 @@ func ValidName(instanceName string, isSnapshot bool) error {
 			return fmt.Errorf("The character %q is reserved for snapshots", instance.SnapshotDelimiter)
 		}
 
+		// Used by the bulk state change endpoint.
+		if instanceName == "state" {
+			return fmt.Errorf("The name %q is reserved", instanceName)
+		}
+
 		err := validate.IsHostname(instanceName)
 		if err != nil {
 			return fmt.Errorf("Invalid instance name: %w", err)
@@ -22,6 +22,16 @@ func (a *Addr) Add() error {
 	return nil
 }
 
+// Delete deletes protocol address.
+func (a *Addr) Delete() error {
+	_, err := subprocess.RunCommand("ip", a.Family, "addr", "delete", "dev", a.DevName, a.Address)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Flush flushes protocol addresses.
 func (a *Addr) Flush() error {
 	cmd := []string{}
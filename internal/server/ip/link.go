@@ -421,6 +421,27 @@ func (l *Link) BridgeVLANDelete(vid string, self bool) error {
 	return nil
 }
 
+// BridgeFdbAppend appends a forwarding database entry sending the traffic for the MAC address to the remote
+// destination of the tunnel device.
+func (l *Link) BridgeFdbAppend(hwaddr string, dst string) error {
+	_, err := subprocess.RunCommand("bridge", "fdb", "append", hwaddr, "dev", l.Name, "dst", dst)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BridgeFdbDelete removes a forwarding database entry of the tunnel device.
+func (l *Link) BridgeFdbDelete(hwaddr string, dst string) error {
+	_, err := subprocess.RunCommand("bridge", "fdb", "delete", hwaddr, "dev", l.Name, "dst", dst)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // BridgeLinkSetIsolated sets bridge 'isolated' attribute on a port.
 func (l *Link) BridgeLinkSetIsolated(isolated bool) error {
 	isolatedState := "on"
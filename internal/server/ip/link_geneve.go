@@ -0,0 +1,24 @@
+package ip
+
+// Geneve represents arguments for link of type geneve.
+type Geneve struct {
+	Link
+	GeneveID string
+	Remote   string
+	DstPort  string
+}
+
+// additionalArgs generates geneve specific arguments.
+func (g *Geneve) additionalArgs() []string {
+	args := []string{"id", g.GeneveID, "remote", g.Remote}
+	if g.DstPort != "" {
+		args = append(args, "dstport", g.DstPort)
+	}
+
+	return args
+}
+
+// Add adds new virtual link.
+func (g *Geneve) Add() error {
+	return g.Link.add("geneve", g.additionalArgs())
+}
@@ -99,7 +99,18 @@ func (r *Route) Replace(routes []string) error {
 // Show lists routes.
 func (r *Route) Show() ([]string, error) {
 	routes := []string{}
-	out, err := subprocess.RunCommand("ip", r.Family, "route", "show", "dev", r.DevName, "proto", r.Proto)
+
+	cmd := []string{r.Family, "route", "show"}
+	if r.Table != "" {
+		cmd = append(cmd, "table", r.Table)
+	}
+
+	cmd = append(cmd, "dev", r.DevName)
+	if r.Proto != "" {
+		cmd = append(cmd, "proto", r.Proto)
+	}
+
+	out, err := subprocess.RunCommand("ip", cmd...)
 	if err != nil {
 		return routes, err
 	}
@@ -12,6 +12,7 @@ type CertificateAction string
 const (
 	CertificateCreated = CertificateAction(api.EventLifecycleCertificateCreated)
 	CertificateDeleted = CertificateAction(api.EventLifecycleCertificateDeleted)
+	CertificateExpired = CertificateAction(api.EventLifecycleCertificateExpired)
 	CertificateUpdated = CertificateAction(api.EventLifecycleCertificateUpdated)
 )
 
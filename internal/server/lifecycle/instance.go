@@ -28,15 +28,33 @@ const (
 	InstanceResumed          = InstanceAction(api.EventLifecycleInstanceResumed)
 	InstanceRestored         = InstanceAction(api.EventLifecycleInstanceRestored)
 	InstanceDeleted          = InstanceAction(api.EventLifecycleInstanceDeleted)
+	InstanceDeviceAttached   = InstanceAction(api.EventLifecycleInstanceDeviceAttached)
+	InstanceDeviceDetached   = InstanceAction(api.EventLifecycleInstanceDeviceDetached)
 	InstanceRenamed          = InstanceAction(api.EventLifecycleInstanceRenamed)
 	InstanceUpdated          = InstanceAction(api.EventLifecycleInstanceUpdated)
 	InstanceExec             = InstanceAction(api.EventLifecycleInstanceExec)
+	InstanceExecDenied       = InstanceAction(api.EventLifecycleInstanceExecDenied)
 	InstanceConsole          = InstanceAction(api.EventLifecycleInstanceConsole)
 	InstanceConsoleRetrieved = InstanceAction(api.EventLifecycleInstanceConsoleRetrieved)
 	InstanceConsoleReset     = InstanceAction(api.EventLifecycleInstanceConsoleReset)
 	InstanceFileRetrieved    = InstanceAction(api.EventLifecycleInstanceFileRetrieved)
 	InstanceFilePushed       = InstanceAction(api.EventLifecycleInstanceFilePushed)
 	InstanceFileDeleted      = InstanceAction(api.EventLifecycleInstanceFileDeleted)
+	InstanceUserCreated      = InstanceAction(api.EventLifecycleInstanceUserCreated)
+	InstanceUserUpdated      = InstanceAction(api.EventLifecycleInstanceUserUpdated)
+	InstanceUserDeleted      = InstanceAction(api.EventLifecycleInstanceUserDeleted)
+
+	InstanceMemoryPressureFrozen   = InstanceAction(api.EventLifecycleInstanceMemoryPressureFrozen)
+	InstanceMemoryPressureUnfrozen = InstanceAction(api.EventLifecycleInstanceMemoryPressureUnfrozen)
+
+	InstanceCrashed   = InstanceAction(api.EventLifecycleInstanceCrashed)
+	InstanceOOMKilled = InstanceAction(api.EventLifecycleInstanceOOMKilled)
+
+	InstanceProbeFailed = InstanceAction(api.EventLifecycleInstanceProbeFailed)
+
+	InstanceImageOutdated = InstanceAction(api.EventLifecycleInstanceImageOutdated)
+
+	InstanceExpiring = InstanceAction(api.EventLifecycleInstanceExpiring)
 )
 
 // Event creates the lifecycle event for an action on an instance.
@@ -0,0 +1,29 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// InstanceSFTPAction represents a lifecycle event action for instance SFTP sessions.
+type InstanceSFTPAction string
+
+// All supported lifecycle events for instance SFTP sessions.
+const (
+	InstanceSFTPConnected    = InstanceSFTPAction(api.EventLifecycleInstanceSFTPConnected)
+	InstanceSFTPDisconnected = InstanceSFTPAction(api.EventLifecycleInstanceSFTPDisconnected)
+)
+
+// Event creates the lifecycle event for an action on an instance SFTP session.
+func (a InstanceSFTPAction) Event(inst instance, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "instances", inst.Name(), "sftp").Project(inst.Project().Name)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+		Name:      inst.Name(),
+		Project:   inst.Project().Name,
+	}
+}
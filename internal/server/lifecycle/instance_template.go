@@ -0,0 +1,29 @@
+package lifecycle
+
+import (
+	"github.com/lxc/incus/v6/internal/version"
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// InstanceTemplateAction represents a lifecycle event action for instance templates.
+type InstanceTemplateAction string
+
+// All supported lifecycle events for instance templates.
+const (
+	InstanceTemplateCreated = InstanceTemplateAction(api.EventLifecycleInstanceTemplateCreated)
+	InstanceTemplateDeleted = InstanceTemplateAction(api.EventLifecycleInstanceTemplateDeleted)
+	InstanceTemplateUpdated = InstanceTemplateAction(api.EventLifecycleInstanceTemplateUpdated)
+	InstanceTemplateRenamed = InstanceTemplateAction(api.EventLifecycleInstanceTemplateRenamed)
+)
+
+// Event creates the lifecycle event for an action on an instance template.
+func (a InstanceTemplateAction) Event(name string, projectName string, requestor *api.EventLifecycleRequestor, ctx map[string]any) api.EventLifecycle {
+	u := api.NewURL().Path(version.APIVersion, "instance-templates", name).Project(projectName)
+
+	return api.EventLifecycle{
+		Action:    string(a),
+		Source:    u.String(),
+		Context:   ctx,
+		Requestor: requestor,
+	}
+}
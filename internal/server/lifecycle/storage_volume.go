@@ -22,6 +22,8 @@ const (
 	StorageVolumeUpdated  = StorageVolumeAction(api.EventLifecycleStorageVolumeUpdated)
 	StorageVolumeRenamed  = StorageVolumeAction(api.EventLifecycleStorageVolumeRenamed)
 	StorageVolumeRestored = StorageVolumeAction(api.EventLifecycleStorageVolumeRestored)
+
+	StorageVolumeQuotaWarning = StorageVolumeAction(api.EventLifecycleStorageVolumeQuotaWarning)
 )
 
 // Event creates the lifecycle event for an action on a storage volume.
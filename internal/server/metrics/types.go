@@ -96,6 +96,14 @@ const (
 	OperationsTotal
 	// WarningsTotal represents the number of active warnings.
 	WarningsTotal
+	// TLSHandshakesRejectedTotal represents the number of TLS handshakes rejected because of a revoked client certificate.
+	TLSHandshakesRejectedTotal
+	// SessionsActive represents the number of active exec and console sessions.
+	SessionsActive
+	// SessionsRejectedTotal represents the number of exec and console sessions rejected because of the session limits.
+	SessionsRejectedTotal
+	// SessionsIdleClosedTotal represents the number of exec and console sessions closed because of the idle timeout.
+	SessionsIdleClosedTotal
 	// UptimeSeconds represents the daemon uptime in seconds.
 	UptimeSeconds
 	// GoGoroutines represents the number of goroutines that currently exist..
@@ -210,6 +218,10 @@ var MetricNames = map[MetricType]string{
 	NetworkTransmitPacketsTotal: "incus_network_transmit_packets_total",
 	OperationsTotal:             "incus_operations_total",
 	ProcsTotal:                  "incus_procs_total",
+	SessionsActive:              "incus_sessions_active",
+	SessionsIdleClosedTotal:     "incus_sessions_idle_closed_total",
+	SessionsRejectedTotal:       "incus_sessions_rejected_total",
+	TLSHandshakesRejectedTotal:  "incus_tls_handshakes_rejected_total",
 	UptimeSeconds:               "incus_uptime_seconds",
 	WarningsTotal:               "incus_warnings_total",
 }
@@ -278,6 +290,10 @@ var MetricHeaders = map[MetricType]string{
 	NetworkTransmitPacketsTotal: "# HELP incus_network_transmit_packets_total The amount of transmitted packets on a given interface.",
 	OperationsTotal:             "# HELP incus_operations_total The number of running operations",
 	ProcsTotal:                  "# HELP incus_procs_total The number of running processes.",
+	SessionsActive:              "# HELP incus_sessions_active The number of active exec and console sessions.",
+	SessionsIdleClosedTotal:     "# HELP incus_sessions_idle_closed_total The number of exec and console sessions closed because of the idle timeout.",
+	SessionsRejectedTotal:       "# HELP incus_sessions_rejected_total The number of exec and console sessions rejected because of the session limits.",
+	TLSHandshakesRejectedTotal:  "# HELP incus_tls_handshakes_rejected_total The number of TLS handshakes rejected because of a revoked client certificate.",
 	UptimeSeconds:               "# HELP incus_uptime_seconds The daemon uptime in seconds.",
 	WarningsTotal:               "# HELP incus_warnings_total The number of active warnings.",
 }
@@ -147,6 +147,7 @@ func TypesToHeader(types ...Type) *migration.MigrationHeader {
 			Delete:        &missingFeature,
 			Compress:      &missingFeature,
 			Bidirectional: &missingFeature,
+			BlockStream:   &missingFeature,
 		}
 
 		for _, feature := range t.Features {
@@ -158,6 +159,8 @@ func TypesToHeader(types ...Type) *migration.MigrationHeader {
 				features.Compress = &hasFeature
 			} else if feature == "bidirectional" {
 				features.Bidirectional = &hasFeature
+			} else if feature == migration.RsyncFeatureBlockStream {
+				features.BlockStream = &hasFeature
 			}
 		}
 
@@ -196,6 +199,9 @@ func MatchTypes(offer *migration.MigrationHeader, fallbackType migration.Migrati
 				offeredFeatures = offer.GetBtrfsFeaturesSlice()
 			} else if offerFSType == migration.MigrationFSType_RSYNC {
 				offeredFeatures = offer.GetRsyncFeaturesSlice()
+			} else if offerFSType == migration.MigrationFSType_BLOCK_AND_RSYNC && slices.Contains(offer.GetRsyncFeaturesSlice(), migration.RsyncFeatureBlockStream) {
+				// Only the block stream feature applies to the block part of the transfer.
+				offeredFeatures = []string{migration.RsyncFeatureBlockStream}
 			}
 
 			// Find common features in both our type and offered type.
@@ -244,6 +250,17 @@ func MatchTypes(offer *migration.MigrationHeader, fallbackType migration.Migrati
 	return matchedTypes, nil
 }
 
+// RemoveFeature removes an optional feature from the supplied types.
+func RemoveFeature(types []Type, feature string) []Type {
+	for i := range types {
+		types[i].Features = slices.DeleteFunc(slices.Clone(types[i].Features), func(f string) bool {
+			return f == feature
+		})
+	}
+
+	return types
+}
+
 func progressWrapperRender(op *operations.Operation, key string, description string, progressInt int64, speedInt int64) {
 	meta := op.Metadata()
 	if meta == nil {
@@ -0,0 +1,73 @@
+package acl
+
+import (
+	"fmt"
+
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// EffectiveRules returns the rules of the ACLs applied to the traffic of a NIC in the specified direction, in the
+// order they are evaluated in: drop rules first, then reject and allow rules, and lastly the default rule which
+// catches the unmatched traffic. Disabled rules are left out.
+// The default rule is taken from the first of the configs setting it, falling back to "reject" and not logged.
+func EffectiveRules(direction string, aclInfos []*api.NetworkACL, configs ...map[string]string) []api.NetworkACLEffectiveRule {
+	actionOrder := []string{"drop", "reject", "allow", "allow-stateless"}
+	rulesByAction := make(map[string][]api.NetworkACLEffectiveRule, len(actionOrder))
+
+	for _, aclInfo := range aclInfos {
+		rules := aclInfo.Ingress
+		if direction == "egress" {
+			rules = aclInfo.Egress
+		}
+
+		for _, rule := range rules {
+			if rule.State == "disabled" {
+				continue
+			}
+
+			rulesByAction[rule.Action] = append(rulesByAction[rule.Action], api.NetworkACLEffectiveRule{
+				NetworkACLRule: rule,
+				ACL:            aclInfo.Name,
+			})
+		}
+	}
+
+	effective := []api.NetworkACLEffectiveRule{}
+	for _, action := range actionOrder {
+		effective = append(effective, rulesByAction[action]...)
+	}
+
+	// Add the automatic default rule.
+	defaultAction := "reject"
+	defaultLogged := false
+
+	actionKey := fmt.Sprintf("security.acls.default.%s.action", direction)
+	loggedKey := fmt.Sprintf("security.acls.default.%s.logged", direction)
+
+	for _, config := range configs {
+		if config[actionKey] != "" {
+			defaultAction = config[actionKey]
+			break
+		}
+	}
+
+	for _, config := range configs {
+		if config[loggedKey] != "" {
+			defaultLogged = util.IsTrue(config[loggedKey])
+			break
+		}
+	}
+
+	defaultRule := api.NetworkACLRule{
+		Action:      defaultAction,
+		Description: "Default rule",
+		State:       "enabled",
+	}
+
+	if defaultLogged {
+		defaultRule.State = "logged"
+	}
+
+	return append(effective, api.NetworkACLEffectiveRule{NetworkACLRule: defaultRule})
+}
@@ -117,7 +117,7 @@ func UsedBy(s *state.State, aclProjectName string, usageFunc func(ctx context.Co
 		}
 
 		for _, networkName := range networkNames {
-			_, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, networkName)
+			networkID, network, _, err := tx.GetNetworkInAnyState(ctx, aclProjectName, networkName)
 			if err != nil {
 				return fmt.Errorf("Failed to get network config for %q: %w", networkName, err)
 			}
@@ -137,6 +137,34 @@ func UsedBy(s *state.State, aclProjectName string, usageFunc func(ctx context.Co
 					return err
 				}
 			}
+
+			if network.Type != "ovn" {
+				continue
+			}
+
+			// Find the network's peers using the ACLs.
+			peers, err := tx.GetNetworkPeers(ctx, networkID)
+			if err != nil {
+				return fmt.Errorf("Failed loading peers for network %q: %w", networkName, err)
+			}
+
+			for _, peer := range peers {
+				peerACLNames := util.SplitNTrimSpace(peer.Config["security.acls"], ",", -1, true)
+				matchedACLNames := []string{}
+				for _, peerACLName := range peerACLNames {
+					if slices.Contains(matchACLNames, peerACLName) {
+						matchedACLNames = append(matchedACLNames, peerACLName)
+					}
+				}
+
+				if len(matchedACLNames) > 0 {
+					// Call usageFunc with a list of matched ACLs and info about the network peer.
+					err := usageFunc(ctx, tx, matchedACLNames, db.NetworkPeer{NetworkName: network.Name, PeerName: peer.Name}, "", nil)
+					if err != nil {
+						return err
+					}
+				}
+			}
 		}
 
 		// Look for profiles. Next cheapest to do.
@@ -302,7 +330,7 @@ type NetworkACLUsage struct {
 
 // NetworkUsage populates the provided aclNets map with networks that are using any of the specified ACLs.
 func NetworkUsage(s *state.State, aclProjectName string, aclNames []string, aclNets map[string]NetworkACLUsage) error {
-	supportedNetTypes := []string{"bridge", "ovn"}
+	supportedNetTypes := []string{"bridge", "mesh", "ovn"}
 
 	// Find all networks and instance/profile NICs that use any of the specified Network ACLs.
 	err := UsedBy(s, aclProjectName, func(ctx context.Context, tx *db.ClusterTx, matchedACLNames []string, usageType any, _ string, nicConfig map[string]string) error {
@@ -345,6 +373,8 @@ func NetworkUsage(s *state.State, aclProjectName string, aclNames []string, aclN
 
 		case *api.NetworkACL:
 			return nil // Nothing to do for ACL rules referencing us.
+		case db.NetworkPeer:
+			return nil // Network peer rules are applied directly to the peer's network port group.
 		default:
 			return fmt.Errorf("Unrecognised usage type %T", u)
 		}
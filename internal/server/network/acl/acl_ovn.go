@@ -33,6 +33,10 @@ const ovnACLPriorityPortGroupAllow = 300
 const ovnACLPriorityPortGroupReject = 400
 const ovnACLPriorityPortGroupDrop = 500
 
+// Network peer rules have precedence over all other rules so they can't be bypassed by network or instance ACLs.
+const ovnACLPriorityPeerDefaultAction = 600
+const ovnACLPriorityPeerDrop = 610
+
 // ovnACLPortGroupPrefix prefix used when naming ACL related port groups in OVN.
 const ovnACLPortGroupPrefix = "incus_acl"
 
@@ -263,6 +267,8 @@ func OVNEnsureACLs(s *state.State, l logger.Logger, client *ovn.NB, aclProjectNa
 		if err != nil {
 			return nil, fmt.Errorf("Failed applying ACL rules to port group %q for security ACL %q setup: %w", portGroupName, aclStatus.name, err)
 		}
+
+		ovnUpdateRecoveryHint(l, client, aclProjectName, aclNameIDs[aclStatus.name], aclStatus.aclInfo, portGroupName)
 	}
 
 	// Create any missing per-ACL-per-network port groups for existing ACL port groups, and apply the ACL rules
@@ -293,6 +299,8 @@ func OVNEnsureACLs(s *state.State, l logger.Logger, client *ovn.NB, aclProjectNa
 			if err != nil {
 				return nil, fmt.Errorf("Failed applying ACL rules to port group %q for security ACL %q setup: %w", portGroupName, aclStatus.name, err)
 			}
+
+			ovnUpdateRecoveryHint(l, client, aclProjectName, aclNameIDs[aclStatus.name], aclStatus.aclInfo, portGroupName)
 		}
 	}
 
@@ -333,6 +341,38 @@ func ovnAddReferencedACLs(info *api.NetworkACL, referencedACLNames map[string]st
 	}
 }
 
+// OVNACLRecoveryHint is stored on the OVN port group of an ACL so that the ACL can be recovered if the
+// database is lost.
+type OVNACLRecoveryHint struct {
+	ID      int64          `json:"id"`
+	Project string         `json:"project"`
+	ACL     api.NetworkACL `json:"acl"`
+}
+
+// ovnUpdateRecoveryHint stores the recovery hint for an ACL on its port group.
+// Failures are only logged as the hint isn't needed for the ACL to function.
+func ovnUpdateRecoveryHint(l logger.Logger, client *ovn.NB, aclProjectName string, aclID int64, aclInfo *api.NetworkACL, portGroupName ovn.OVNPortGroup) {
+	hint := OVNACLRecoveryHint{
+		ID:      aclID,
+		Project: aclProjectName,
+		ACL:     *aclInfo,
+	}
+
+	// Usage is recomputed when the ACL is loaded.
+	hint.ACL.UsedBy = nil
+
+	hintJSON, err := json.Marshal(hint)
+	if err != nil {
+		l.Warn("Failed encoding ACL recovery hint", logger.Ctx{"networkACL": aclInfo.Name, "err": err})
+		return
+	}
+
+	err = client.UpdatePortGroupRecoveryHint(context.TODO(), portGroupName, string(hintJSON))
+	if err != nil {
+		l.Warn("Failed storing ACL recovery hint", logger.Ctx{"networkACL": aclInfo.Name, "portGroup": portGroupName, "err": err})
+	}
+}
+
 // ovnApplyToPortGroup applies the rules in the specified ACL to the specified port group.
 func ovnApplyToPortGroup(l logger.Logger, client *ovn.NB, aclInfo *api.NetworkACL, portGroupName ovn.OVNPortGroup, aclNameIDs map[string]int64, aclNets map[string]NetworkACLUsage, peerTargetNetIDs map[db.NetworkPeer]int64) error {
 	// Create slice for port group rules that has the capacity for ingress and egress rules, plus default rule.
@@ -495,6 +535,18 @@ func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, por
 	}
 
 	// Add protocol filters.
+	matchParts = append(matchParts, ovnRuleProtocolToOVNACLMatch(rule)...)
+
+	// Populate the Match field with the generated match parts.
+	portGroupRule.Match = fmt.Sprintf("(%s)", strings.Join(matchParts, ") && ("))
+
+	return portGroupRule, networkSpecific, networkPeersNeeded, nil
+}
+
+// ovnRuleProtocolToOVNACLMatch converts the protocol, port and ICMP criteria of a rule into OVN match parts.
+func ovnRuleProtocolToOVNACLMatch(rule *api.NetworkACLRule) []string {
+	var matchParts []string
+
 	if slices.Contains([]string{"tcp", "udp"}, rule.Protocol) {
 		matchParts = append(matchParts, rule.Protocol)
 
@@ -517,10 +569,7 @@ func ovnRuleCriteriaToOVNACLRule(direction string, rule *api.NetworkACLRule, por
 		}
 	}
 
-	// Populate the Match field with the generated match parts.
-	portGroupRule.Match = fmt.Sprintf("(%s)", strings.Join(matchParts, ") && ("))
-
-	return portGroupRule, networkSpecific, networkPeersNeeded, nil
+	return matchParts
 }
 
 // ovnRulePortToOVNACLMatch converts protocol (tcp/udp), direction (src/dst) and port criteria list into an OVN
@@ -639,6 +688,210 @@ func ovnRuleSubjectToOVNACLMatch(direction string, aclNameIDs map[string]int64,
 	return strings.Join(fieldParts, " || "), networkSpecific, networkPeersNeeded, nil
 }
 
+// ovnPeerRules returns the rules restricting the traffic arriving from a network peer to the one allowed by the
+// ingress rules of the supplied ACLs. Traffic from the peer's address set that isn't allowed by any rule is dropped.
+// Only IP based subjects are supported, as the rules aren't tied to any ACL port group.
+func ovnPeerRules(portGroupName ovn.OVNPortGroup, peerAddrSetPrefix ovn.OVNAddressSet, aclInfos ...*api.NetworkACL) ([]ovn.OVNACLRule, error) {
+	peerMatch := fmt.Sprintf("outport == @%s && (ip4.src == $%s_ip4 || ip6.src == $%s_ip6)", portGroupName, peerAddrSetPrefix, peerAddrSetPrefix)
+
+	var rules []ovn.OVNACLRule
+	var allowMatches []string
+
+	for _, aclInfo := range aclInfos {
+		for _, rule := range aclInfo.Ingress {
+			if rule.State == "disabled" {
+				continue
+			}
+
+			var matchParts []string
+
+			for _, subject := range []struct{ direction, criteria string }{{"src", rule.Source}, {"dst", rule.Destination}} {
+				if subject.criteria == "" {
+					continue
+				}
+
+				subjectCriteria := util.SplitNTrimSpace(subject.criteria, ",", -1, false)
+				for _, subjectCriterion := range subjectCriteria {
+					if validate.IsNetworkRange(subjectCriterion) != nil && validate.IsNetworkAddress(subjectCriterion) != nil && validate.IsNetwork(subjectCriterion) != nil {
+						return nil, fmt.Errorf("Network peer ACL %q uses unsupported subject %q (only IP addresses, ranges and subnets are supported)", aclInfo.Name, subjectCriterion)
+					}
+				}
+
+				match, _, _, err := ovnRuleSubjectToOVNACLMatch(subject.direction, nil, nil, subjectCriteria...)
+				if err != nil {
+					return nil, err
+				}
+
+				matchParts = append(matchParts, match)
+			}
+
+			matchParts = append(matchParts, ovnRuleProtocolToOVNACLMatch(&rule)...)
+
+			match := "1"
+			if len(matchParts) > 0 {
+				match = fmt.Sprintf("(%s)", strings.Join(matchParts, ") && ("))
+			}
+
+			switch rule.Action {
+			case "allow", "allow-stateless":
+				allowMatches = append(allowMatches, match)
+			case "reject", "drop":
+				rules = append(rules, ovn.OVNACLRule{
+					Direction: "to-lport",
+					Action:    rule.Action,
+					Log:       rule.State == "logged",
+					Priority:  ovnACLPriorityPeerDrop,
+					Match:     fmt.Sprintf("%s && %s", peerMatch, match),
+				})
+			}
+		}
+	}
+
+	// Drop any other traffic from the peer. Allowed traffic falls through to the network and instance ACLs.
+	defaultMatch := peerMatch
+	if len(allowMatches) > 0 {
+		defaultMatch = fmt.Sprintf("%s && !(%s)", peerMatch, strings.Join(allowMatches, " || "))
+	}
+
+	rules = append(rules, ovn.OVNACLRule{
+		Direction: "to-lport",
+		Action:    "drop",
+		Priority:  ovnACLPriorityPeerDefaultAction,
+		Match:     defaultMatch,
+	})
+
+	return rules, nil
+}
+
+// ovnLoadNetworkPeerACLs loads the specified ACLs used by a network peer.
+func ovnLoadNetworkPeerACLs(s *state.State, aclProjectName string, aclNames []string) ([]*api.NetworkACL, error) {
+	aclInfos := make([]*api.NetworkACL, 0, len(aclNames))
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		for _, aclName := range aclNames {
+			_, aclInfo, err := tx.GetNetworkACL(ctx, aclProjectName, aclName)
+			if err != nil {
+				return fmt.Errorf("Failed loading network ACL %q: %w", aclName, err)
+			}
+
+			aclInfos = append(aclInfos, aclInfo)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return aclInfos, nil
+}
+
+// OVNValidateNetworkPeerACLs checks that the rules of the specified ACLs can be applied to a network peer.
+func OVNValidateNetworkPeerACLs(s *state.State, aclProjectName string, aclNames []string) error {
+	aclInfos, err := ovnLoadNetworkPeerACLs(s, aclProjectName, aclNames)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovnPeerRules("", "", aclInfos...)
+
+	return err
+}
+
+// OVNApplyNetworkPeerRules applies the ingress rules of the specified ACLs to the traffic arriving on the network
+// from the peer target network. If no ACLs are specified, any existing peer rules are removed.
+func OVNApplyNetworkPeerRules(s *state.State, client *ovn.NB, aclProjectName string, networkID int64, targetNetworkID int64, aclNames []string) error {
+	portGroupName := OVNIntSwitchPortGroupName(networkID)
+	peerAddrSetPrefix := OVNIntSwitchPortGroupAddressSetPrefix(targetNetworkID)
+
+	if len(aclNames) == 0 {
+		err := client.ClearPortGroupPeerACLRules(context.TODO(), portGroupName, peerAddrSetPrefix)
+		if err != nil {
+			return fmt.Errorf("Failed clearing network peer ACL rules: %w", err)
+		}
+
+		return nil
+	}
+
+	aclInfos, err := ovnLoadNetworkPeerACLs(s, aclProjectName, aclNames)
+	if err != nil {
+		return err
+	}
+
+	rules, err := ovnPeerRules(portGroupName, peerAddrSetPrefix, aclInfos...)
+	if err != nil {
+		return err
+	}
+
+	err = client.UpdatePortGroupPeerACLRules(context.TODO(), portGroupName, peerAddrSetPrefix, rules...)
+	if err != nil {
+		return fmt.Errorf("Failed applying network peer ACL rules: %w", err)
+	}
+
+	return nil
+}
+
+// ovnReapplyNetworkPeerRules reapplies the rules of the mutually established network peers using any of the
+// specified ACLs.
+func ovnReapplyNetworkPeerRules(s *state.State, client *ovn.NB, aclProjectName string, aclNames ...string) error {
+	var peers []db.NetworkPeer
+
+	err := UsedBy(s, aclProjectName, func(ctx context.Context, tx *db.ClusterTx, matchedACLNames []string, usageType any, nicName string, nicConfig map[string]string) error {
+		peer, ok := usageType.(db.NetworkPeer)
+		if ok {
+			peers = append(peers, peer)
+		}
+
+		return nil
+	}, aclNames...)
+	if err != nil {
+		return fmt.Errorf("Failed getting ACL network peer usage: %w", err)
+	}
+
+	for _, peer := range peers {
+		var networkID int64
+		var targetNetworkID int64
+		var peerInfo *api.NetworkPeer
+
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			networkID, _, _, err = tx.GetNetworkInAnyState(ctx, aclProjectName, peer.NetworkName)
+			if err != nil {
+				return err
+			}
+
+			_, peerInfo, err = tx.GetNetworkPeer(ctx, networkID, peer.PeerName)
+			if err != nil {
+				return err
+			}
+
+			if peerInfo.Type != "local" || peerInfo.Status != api.NetworkStatusCreated {
+				return nil
+			}
+
+			targetNetworkID, _, _, err = tx.GetNetworkInAnyState(ctx, peerInfo.TargetProject, peerInfo.TargetNetwork)
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading network peer %q of network %q: %w", peer.PeerName, peer.NetworkName, err)
+		}
+
+		// Rules are only applied once the peering is mutual.
+		if peerInfo.Type != "local" || peerInfo.Status != api.NetworkStatusCreated {
+			continue
+		}
+
+		err = OVNApplyNetworkPeerRules(s, client, aclProjectName, networkID, targetNetworkID, util.SplitNTrimSpace(peerInfo.Config["security.acls"], ",", -1, true))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // OVNApplyNetworkBaselineRules applies preset baseline logical switch rules to a allow access to network services.
 func OVNApplyNetworkBaselineRules(client *ovn.NB, switchName ovn.OVNSwitch, routerPortName ovn.OVNSwitchPort, intRouterIPs []*net.IPNet, dnsIPs []net.IP) error {
 	rules := []ovn.OVNACLRule{
@@ -934,6 +1187,8 @@ func OVNPortGroupDeleteIfUnused(s *state.State, l logger.Logger, client *ovn.NB,
 					aclUsedACLS[matchedACLName] = append(aclUsedACLS[matchedACLName], u.Name)
 				}
 			}
+		case db.NetworkPeer:
+			// Network peer rules only use IP subjects, so don't need any ACL port group.
 		default:
 			return fmt.Errorf("Unrecognised usage type %T", u)
 		}
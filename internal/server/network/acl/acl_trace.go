@@ -0,0 +1,136 @@
+package acl
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/iprange"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// TracePacket represents a packet to evaluate against ACL rules.
+// ICMP packets are considered to be echo requests.
+type TracePacket struct {
+	Protocol        string
+	Source          net.IP
+	SourcePort      uint64
+	Destination     net.IP
+	DestinationPort uint64
+}
+
+// TraceRules evaluates the packet against the effective rules (as returned by EffectiveRules) and returns the
+// first rule matching it. Only the IP, CIDR and IP range subjects supported by the firewall drivers are matched.
+func TraceRules(rules []api.NetworkACLEffectiveRule, packet TracePacket) *api.NetworkACLEffectiveRule {
+	for i := range rules {
+		if traceRuleMatches(rules[i].NetworkACLRule, packet) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+// traceRuleMatches returns whether the packet matches all the criteria of the rule.
+func traceRuleMatches(rule api.NetworkACLRule, packet TracePacket) bool {
+	if rule.Protocol != "" && rule.Protocol != packet.Protocol {
+		return false
+	}
+
+	if rule.Source != "" && !traceSubjectMatches(rule.Source, packet.Source) {
+		return false
+	}
+
+	if rule.Destination != "" && !traceSubjectMatches(rule.Destination, packet.Destination) {
+		return false
+	}
+
+	if rule.SourcePort != "" && !tracePortMatches(rule.SourcePort, packet.SourcePort) {
+		return false
+	}
+
+	if rule.DestinationPort != "" && !tracePortMatches(rule.DestinationPort, packet.DestinationPort) {
+		return false
+	}
+
+	if rule.ICMPType != "" {
+		echoRequest := "8"
+		if packet.Protocol == "icmp6" {
+			echoRequest = "128"
+		}
+
+		if rule.ICMPType != echoRequest || (rule.ICMPCode != "" && rule.ICMPCode != "0") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// traceSubjectMatches returns whether the IP matches any of the subjects in the comma separated list.
+func traceSubjectMatches(subjects string, ip net.IP) bool {
+	for _, subject := range util.SplitNTrimSpace(subjects, ",", -1, true) {
+		start, end, found := strings.Cut(subject, "-")
+		if found {
+			startIP := net.ParseIP(start)
+			endIP := net.ParseIP(end)
+			if startIP == nil || endIP == nil {
+				continue
+			}
+
+			if (startIP.To4() == nil) != (ip.To4() == nil) {
+				continue
+			}
+
+			subjectRange := iprange.Range{Start: startIP.To16(), End: endIP.To16()}
+			if subjectRange.ContainsIP(ip.To16()) {
+				return true
+			}
+
+			continue
+		}
+
+		subjectIP := net.ParseIP(subject)
+		if subjectIP != nil {
+			if subjectIP.Equal(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		_, subjectNet, err := net.ParseCIDR(subject)
+		if err == nil && subjectNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tracePortMatches returns whether the port matches any of the ports or port ranges in the comma separated list.
+func tracePortMatches(ports string, port uint64) bool {
+	for _, entry := range util.SplitNTrimSpace(ports, ",", -1, true) {
+		start, end, found := strings.Cut(entry, "-")
+		if !found {
+			end = start
+		}
+
+		startPort, err := strconv.ParseUint(start, 10, 16)
+		if err != nil {
+			continue
+		}
+
+		endPort, err := strconv.ParseUint(end, 10, 16)
+		if err != nil {
+			continue
+		}
+
+		if port >= startPort && port <= endPort {
+			return true
+		}
+	}
+
+	return false
+}
@@ -153,6 +153,13 @@ func (d *common) usedBy(firstOnly bool) ([]string, error) {
 				uri += fmt.Sprintf("?project=%s", d.projectName)
 			}
 
+			usedBy = append(usedBy, uri)
+		case db.NetworkPeer:
+			uri := fmt.Sprintf("/%s/networks/%s/peers/%s", version.APIVersion, u.NetworkName, u.PeerName)
+			if d.projectName != api.ProjectDefaultName {
+				uri += fmt.Sprintf("?project=%s", d.projectName)
+			}
+
 			usedBy = append(usedBy, uri)
 		default:
 			return fmt.Errorf("Unrecognised usage type %T", u)
@@ -631,7 +638,7 @@ func (d *common) Update(config *api.NetworkACLPut, clientType request.ClientType
 		if v.Type == "ovn" {
 			delete(aclNets, k)
 			aclOVNNets[k] = v
-		} else if v.Type != "bridge" {
+		} else if v.Type != "bridge" && v.Type != "mesh" {
 			return fmt.Errorf("Unsupported network ACL type %q", v.Type)
 		}
 	}
@@ -685,6 +692,14 @@ func (d *common) Update(config *api.NetworkACLPut, clientType request.ClientType
 		}
 	}
 
+	// Apply ACL changes to the OVN network peers using the ACL.
+	if clientType == request.ClientTypeNormal && d.state.OVNNB != nil {
+		err = ovnReapplyNetworkPeerRules(d.state, d.state.OVNNB, d.projectName, d.info.Name)
+		if err != nil {
+			return fmt.Errorf("Failed applying ACL to network peers: %w", err)
+		}
+	}
+
 	// Apply ACL changes to non-OVN networks on cluster members.
 	if clientType == request.ClientTypeNormal && len(aclNets) > 0 {
 		// Notify all other nodes to update the network if no target specified.
@@ -27,8 +27,10 @@ import (
 	"github.com/lxc/incus/v6/internal/server/dnsmasq"
 	"github.com/lxc/incus/v6/internal/server/dnsmasq/dhcpalloc"
 	firewallDrivers "github.com/lxc/incus/v6/internal/server/firewall/drivers"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/ip"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
+	"github.com/lxc/incus/v6/internal/server/network/ipam"
 	"github.com/lxc/incus/v6/internal/server/network/ovs"
 	"github.com/lxc/incus/v6/internal/server/project"
 	localUtil "github.com/lxc/incus/v6/internal/server/util"
@@ -59,6 +61,7 @@ func (n *bridge) DBType() db.NetworkType {
 func (n *bridge) Info() Info {
 	info := n.common.Info()
 	info.AddressForwards = true
+	info.DHCPReservations = true
 
 	return info
 }
@@ -205,6 +208,13 @@ func (n *bridge) Validate(config map[string]string) error {
 		"bridge.hwaddr": validate.Optional(validate.IsNetworkMAC),
 		"bridge.mtu":    validate.Optional(validate.IsNetworkMTU),
 
+		"ipam.driver":        validate.Optional(validate.IsOneOf(ipam.Drivers()...)),
+		"ipam.api.url":       validate.Optional(validate.IsRequestURL),
+		"ipam.api.ca_cert":   validate.IsAny,
+		"ipam.auth.token":    validate.IsAny,
+		"ipam.auth.username": validate.IsAny,
+		"ipam.auth.password": validate.IsAny,
+
 		"ipv4.address": validate.Optional(func(value string) error {
 			if validate.IsOneOf("none", "auto")(value) == nil {
 				return nil
@@ -245,6 +255,7 @@ func (n *bridge) Validate(config map[string]string) error {
 		"dns.domain":                           validate.IsAny,
 		"dns.mode":                             validate.Optional(validate.IsOneOf("dynamic", "managed", "none")),
 		"dns.search":                           validate.IsAny,
+		"dns.split_horizon":                    validate.Optional(validate.IsBool),
 		"dns.zone.forward":                     validate.IsAny,
 		"dns.zone.reverse.ipv4":                validate.IsAny,
 		"dns.zone.reverse.ipv6":                validate.IsAny,
@@ -292,6 +303,25 @@ func (n *bridge) Validate(config map[string]string) error {
 				rules[k] = validate.Optional(validate.IsUint8)
 			}
 		}
+
+		// DNS forwarder keys have the forwarder name in their name, extract the suffix.
+		if strings.HasPrefix(k, "dns.forwarders.") {
+			// Validate forwarder name in key.
+			fields := strings.Split(k, ".")
+			if len(fields) != 4 {
+				return fmt.Errorf("Invalid network configuration key: %s", k)
+			}
+
+			forwarderKey := fields[3]
+
+			// Add the correct validation rule for the dynamic field based on last part of key.
+			switch forwarderKey {
+			case "domains":
+				rules[k] = validate.Optional(validate.IsListOf(validate.IsDNSName))
+			case "servers":
+				rules[k] = validate.Optional(validate.IsListOf(validate.IsListenAddress(false, false, false)))
+			}
+		}
 	}
 
 	// Add the BGP validation rules.
@@ -339,6 +369,19 @@ func (n *bridge) Validate(config map[string]string) error {
 		}
 	}
 
+	// Check DNS forwarders are complete.
+	for _, forwarder := range n.dnsForwarders(config) {
+		if len(forwarder.domains) == 0 || len(forwarder.servers) == 0 {
+			return fmt.Errorf("DNS forwarder %q requires both domains and servers to be set", forwarder.name)
+		}
+	}
+
+	// Check the IPAM configuration is complete.
+	_, err = ipam.Load(config)
+	if err != nil {
+		return err
+	}
+
 	// Check using same MAC address on every cluster node is safe.
 	if config["bridge.hwaddr"] != "" {
 		err = n.checkClusterWideMACSafe(config)
@@ -1370,6 +1413,13 @@ func (n *bridge) setup(oldConfig map[string]string) error {
 			dnsmasqCmd = append(dnsmasqCmd, "-S", fmt.Sprintf("/%s/", dnsDomain))
 		}
 
+		// Setup the conditional forwarders.
+		for _, forwarder := range n.dnsForwarders(n.config) {
+			for _, server := range forwarder.servers {
+				dnsmasqCmd = append(dnsmasqCmd, "-S", fmt.Sprintf("/%s/%s", strings.Join(forwarder.domains, "/"), server))
+			}
+		}
+
 		// Create a config file to contain additional config (and to prevent dnsmasq from reading /etc/dnsmasq.conf)
 		err = os.WriteFile(internalUtil.VarPath("networks", n.name, "dnsmasq.raw"), []byte(fmt.Sprintf("%s\n", n.config["raw.dnsmasq"])), 0644)
 		if err != nil {
@@ -1889,7 +1939,7 @@ func (n *bridge) bridgeProjectNetworks(projectNetworks map[string]map[int64]api.
 			network := ni // Local var creating pointer to rather than iterator.
 
 			// Skip non-bridge networks.
-			if network.Type != "bridge" {
+			if network.Type != "bridge" && network.Type != "mesh" {
 				continue
 			}
 
@@ -2433,6 +2483,74 @@ func (n *bridge) ForwardDelete(listenAddress string, clientType request.ClientTy
 	return nil
 }
 
+// ForwardState returns the traffic counters of a network forward.
+// The counters are only available with the nftables firewall driver.
+func (n *bridge) ForwardState(forward api.NetworkForward) (*api.NetworkForwardState, error) {
+	if n.state.Firewall.String() != "nftables" {
+		return nil, ErrNotImplemented
+	}
+
+	listenAddressNet, err := ParseIPToNet(forward.ListenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing address forward listen address %q: %w", forward.ListenAddress, err)
+	}
+
+	portMaps, err := n.forwardValidate(listenAddressNet.IP, &forward.NetworkForwardPut)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := n.state.Firewall.NetworkForwardCounters(n.name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting firewall address forward counters: %w", err)
+	}
+
+	state := api.NetworkForwardState{
+		Ports: make([]api.NetworkForwardStatePort, 0, len(forward.Ports)),
+	}
+
+	if forward.Config["target_address"] != "" {
+		state.DefaultTarget = &api.NetworkForwardStateCounters{}
+	}
+
+	// Port specifications are in the same order as the port maps.
+	for i, portSpec := range forward.Ports {
+		state.Ports = append(state.Ports, api.NetworkForwardStatePort{
+			Protocol:   portSpec.Protocol,
+			ListenPort: portSpec.ListenPort,
+		})
+
+		for _, counter := range counters {
+			if !counter.ListenAddress.Equal(listenAddressNet.IP) || counter.Protocol != portMaps[i].protocol || len(counter.ListenPorts) == 0 {
+				continue
+			}
+
+			// Each firewall rule covers a subset of the listen ports of a single port specification.
+			if !slices.Contains(portMaps[i].listenPorts, counter.ListenPorts[0]) {
+				continue
+			}
+
+			state.Ports[i].Connections += counter.Connections
+			state.Ports[i].Packets += counter.Packets
+			state.Ports[i].Bytes += counter.Bytes
+		}
+	}
+
+	if state.DefaultTarget != nil {
+		for _, counter := range counters {
+			if !counter.ListenAddress.Equal(listenAddressNet.IP) || counter.Protocol != "" {
+				continue
+			}
+
+			state.DefaultTarget.Connections += counter.Connections
+			state.DefaultTarget.Packets += counter.Packets
+			state.DefaultTarget.Bytes += counter.Bytes
+		}
+	}
+
+	return &state, nil
+}
+
 // forwardSetupFirewall applies all network address forwards defined for this network and this member.
 func (n *bridge) forwardSetupFirewall() error {
 	memberSpecific := true // Get all forwards for this cluster member.
@@ -2712,7 +2830,423 @@ func (n *bridge) Leases(projectName string, clientType request.ClientType) ([]ap
 	return leases, nil
 }
 
+// Trace simulates the path of a packet sent by an instance NIC through the bridge and its firewall.
+func (n *bridge) Trace(inst instance.Instance, trace api.NetworkTracePost) (*api.NetworkTrace, error) {
+	// Gather the addresses of the NIC, static ones first.
+	sources := []net.IP{}
+	devConfig := inst.ExpandedDevices()[trace.Device]
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		ip := net.ParseIP(devConfig[key])
+		if ip != nil {
+			sources = append(sources, ip)
+		}
+	}
+
+	leases, err := n.Leases(inst.Project().Name, request.ClientTypeNormal)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting leases: %w", err)
+	}
+
+	hwaddr := inst.ExpandedConfig()[fmt.Sprintf("volatile.%s.hwaddr", trace.Device)]
+	for _, lease := range leases {
+		ip := net.ParseIP(lease.Address)
+		if ip != nil && hwaddr != "" && strings.EqualFold(lease.Hwaddr, hwaddr) {
+			sources = append(sources, ip)
+		}
+	}
+
+	packet, err := n.tracePacket(trace, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.NetworkTrace{
+		Source:  packet.Source.String(),
+		Verdict: "allow",
+		Steps:   []api.NetworkTraceStep{},
+	}
+
+	// Traffic between instances connected to the bridge isn't filtered.
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		_, subnet, _ := net.ParseCIDR(n.config[key])
+		if subnet != nil && subnet.Contains(packet.Destination) {
+			result.Steps = append(result.Steps, api.NetworkTraceStep{
+				Stage:  "bridge",
+				Match:  fmt.Sprintf("Destination in bridge subnet %s", subnet.String()),
+				Action: fmt.Sprintf("Forwarded on %q", n.name),
+			})
+
+			return result, nil
+		}
+	}
+
+	// Check the ACLs applied to the traffic leaving the bridge.
+	aclNames := util.SplitNTrimSpace(n.config["security.acls"], ",", -1, true)
+	if len(aclNames) > 0 {
+		aclInfos := make([]*api.NetworkACL, 0, len(aclNames))
+		for _, aclName := range aclNames {
+			netACL, err := acl.LoadByName(n.state, n.project, aclName)
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading ACL %q: %w", aclName, err)
+			}
+
+			aclInfos = append(aclInfos, netACL.Info())
+		}
+
+		rule := acl.TraceRules(acl.EffectiveRules("egress", aclInfos, n.config), packet)
+
+		match := "Default rule"
+		if rule.ACL != "" {
+			match = fmt.Sprintf("ACL %q rule %q", rule.ACL, traceRuleString(rule.NetworkACLRule))
+		}
+
+		result.Steps = append(result.Steps, api.NetworkTraceStep{
+			Stage:  "acl",
+			Match:  match,
+			Action: rule.Action,
+		})
+
+		if rule.Action == "drop" || rule.Action == "reject" {
+			result.Verdict = rule.Action
+			return result, nil
+		}
+	}
+
+	// Route the traffic through the host.
+	natKey := "ipv4.nat"
+	if packet.Destination.To4() == nil {
+		natKey = "ipv6.nat"
+	}
+
+	action := "Routed by the host"
+	if util.IsTrue(n.config[natKey]) {
+		action = "Routed by the host with source NAT"
+	}
+
+	result.Steps = append(result.Steps, api.NetworkTraceStep{
+		Stage:  "routing",
+		Match:  "Destination outside of the bridge subnets",
+		Action: action,
+	})
+
+	return result, nil
+}
+
+// dhcpReservationValidate validates a DHCP reservation against the network and the existing reservations.
+func (n *bridge) dhcpReservationValidate(hwaddr string, info *api.NetworkDHCPReservationPut) error {
+	err := validate.IsNetworkMAC(hwaddr)
+	if err != nil {
+		return fmt.Errorf("Invalid MAC address %q: %w", hwaddr, err)
+	}
+
+	if info.IPv4Address == "" && info.IPv6Address == "" {
+		return fmt.Errorf("At least one of IPv4 or IPv6 address must be reserved")
+	}
+
+	if info.Hostname != "" {
+		err = validate.IsHostname(info.Hostname)
+		if err != nil {
+			return fmt.Errorf("Invalid host name %q: %w", info.Hostname, err)
+		}
+	}
+
+	checkAddress := func(address string, subnet *net.IPNet, bridgeAddress string, family string) error {
+		if address == "" {
+			return nil
+		}
+
+		if subnet == nil {
+			return fmt.Errorf("%s DHCP isn't enabled on the network", family)
+		}
+
+		ip := net.ParseIP(address)
+		if ip == nil || (family == "IPv4") != (ip.To4() != nil) {
+			return fmt.Errorf("Invalid %s address %q", family, address)
+		}
+
+		if !subnet.Contains(ip) {
+			return fmt.Errorf("%s address %q isn't within the network subnet %q", family, address, subnet.String())
+		}
+
+		bridgeIP, _, _ := net.ParseCIDR(bridgeAddress)
+		if ip.Equal(bridgeIP) {
+			return fmt.Errorf("%s address %q is the network's own address", family, address)
+		}
+
+		return nil
+	}
+
+	err = checkAddress(info.IPv4Address, n.DHCPv4Subnet(), n.config["ipv4.address"], "IPv4")
+	if err != nil {
+		return err
+	}
+
+	err = checkAddress(info.IPv6Address, n.DHCPv6Subnet(), n.config["ipv6.address"], "IPv6")
+	if err != nil {
+		return err
+	}
+
+	// Check the addresses aren't reserved for another device.
+	var reservations []api.NetworkDHCPReservation
+	err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		reservations, err = tx.GetNetworkDHCPReservations(ctx, n.ID())
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading DHCP reservations: %w", err)
+	}
+
+	for _, reservation := range reservations {
+		if reservation.Hwaddr == hwaddr {
+			continue
+		}
+
+		if (info.IPv4Address != "" && reservation.IPv4Address == info.IPv4Address) || (info.IPv6Address != "" && reservation.IPv6Address == info.IPv6Address) {
+			return api.StatusErrorf(http.StatusConflict, "Address already reserved for %q", reservation.Hwaddr)
+		}
+	}
+
+	// Check the addresses aren't statically allocated to an instance on this member.
+	allocationsV4, allocationsV6, err := dnsmasq.DHCPAllAllocations(n.name)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Failed loading DHCP allocations: %w", err)
+	}
+
+	reservationFileName := dnsmasq.ReservationFileName(hwaddr)
+	for _, allocation := range allocationsV4 {
+		if allocation.StaticFileName != "" && allocation.StaticFileName != reservationFileName && allocation.IP.String() == info.IPv4Address {
+			return api.StatusErrorf(http.StatusConflict, "Address %q is already allocated to an instance", info.IPv4Address)
+		}
+	}
+
+	for _, allocation := range allocationsV6 {
+		if allocation.StaticFileName != "" && allocation.StaticFileName != reservationFileName && allocation.IP.String() == info.IPv6Address {
+			return api.StatusErrorf(http.StatusConflict, "Address %q is already allocated to an instance", info.IPv6Address)
+		}
+	}
+
+	return nil
+}
+
+// dhcpReservationApply refreshes the dnsmasq static allocations and, for normal requests, notifies the other
+// cluster members using the notify function.
+func (n *bridge) dhcpReservationApply(clientType request.ClientType, notify func(client incus.InstanceServer) error) error {
+	err := UpdateDNSMasqStatic(n.state, n.name)
+	if err != nil {
+		return fmt.Errorf("Failed applying DHCP reservations: %w", err)
+	}
+
+	if clientType != request.ClientTypeNormal {
+		return nil
+	}
+
+	notifier, err := cluster.NewNotifier(n.state, n.state.Endpoints.NetworkCert(), n.state.ServerCert(), cluster.NotifyAll)
+	if err != nil {
+		return err
+	}
+
+	return notifier(notify)
+}
+
+// DHCPReservationCreate creates a DHCP reservation.
+func (n *bridge) DHCPReservationCreate(reservation api.NetworkDHCPReservationsPost, clientType request.ClientType) error {
+	revert := revert.New()
+	defer revert.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		err := n.dhcpReservationValidate(reservation.Hwaddr, &reservation.NetworkDHCPReservationPut)
+		if err != nil {
+			return err
+		}
+
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			// Check if there is an existing reservation for the same MAC address.
+			_, err := tx.GetNetworkDHCPReservation(ctx, n.ID(), reservation.Hwaddr)
+			if err == nil {
+				return api.StatusErrorf(http.StatusConflict, "A DHCP reservation for that MAC address already exists")
+			}
+
+			_, err = tx.CreateNetworkDHCPReservation(ctx, n.ID(), &reservation)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() {
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.DeleteNetworkDHCPReservation(ctx, n.ID(), reservation.Hwaddr)
+			})
+
+			_ = UpdateDNSMasqStatic(n.state, n.name)
+		})
+	}
+
+	err := n.dhcpReservationApply(clientType, func(client incus.InstanceServer) error {
+		return client.UseProject(n.project).CreateNetworkDHCPReservation(n.name, reservation)
+	})
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
+// DHCPReservationUpdate updates a DHCP reservation.
+func (n *bridge) DHCPReservationUpdate(hwaddr string, req api.NetworkDHCPReservationPut, clientType request.ClientType) error {
+	revert := revert.New()
+	defer revert.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		var curReservation *api.NetworkDHCPReservation
+
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			curReservation, err = tx.GetNetworkDHCPReservation(ctx, n.ID(), hwaddr)
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		err = n.dhcpReservationValidate(hwaddr, &req)
+		if err != nil {
+			return err
+		}
+
+		err = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpdateNetworkDHCPReservation(ctx, n.ID(), hwaddr, &req)
+		})
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() {
+			curPut := curReservation.Writable()
+
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				return tx.UpdateNetworkDHCPReservation(ctx, n.ID(), hwaddr, &curPut)
+			})
+
+			_ = UpdateDNSMasqStatic(n.state, n.name)
+		})
+	}
+
+	err := n.dhcpReservationApply(clientType, func(client incus.InstanceServer) error {
+		return client.UseProject(n.project).UpdateNetworkDHCPReservation(n.name, hwaddr, req, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
+// DHCPReservationDelete deletes a DHCP reservation.
+func (n *bridge) DHCPReservationDelete(hwaddr string, clientType request.ClientType) error {
+	revert := revert.New()
+	defer revert.Fail()
+
+	if clientType == request.ClientTypeNormal {
+		var reservation *api.NetworkDHCPReservation
+
+		err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			var err error
+
+			reservation, err = tx.GetNetworkDHCPReservation(ctx, n.ID(), hwaddr)
+			if err != nil {
+				return err
+			}
+
+			return tx.DeleteNetworkDHCPReservation(ctx, n.ID(), hwaddr)
+		})
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() {
+			newReservation := api.NetworkDHCPReservationsPost{
+				NetworkDHCPReservationPut: reservation.Writable(),
+				Hwaddr:                    reservation.Hwaddr,
+			}
+
+			_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+				_, err := tx.CreateNetworkDHCPReservation(ctx, n.ID(), &newReservation)
+
+				return err
+			})
+
+			_ = UpdateDNSMasqStatic(n.state, n.name)
+		})
+	}
+
+	err := n.dhcpReservationApply(clientType, func(client incus.InstanceServer) error {
+		return client.UseProject(n.project).DeleteNetworkDHCPReservation(n.name, hwaddr)
+	})
+	if err != nil {
+		return err
+	}
+
+	revert.Success()
+	return nil
+}
+
 // UsesDNSMasq indicates if network's config indicates if it needs to use dnsmasq.
 func (n *bridge) UsesDNSMasq() bool {
 	return !slices.Contains([]string{"", "none"}, n.config["ipv4.address"]) || !slices.Contains([]string{"", "none"}, n.config["ipv6.address"])
 }
+
+// bridgeDNSForwarder represents a conditional DNS forwarder.
+type bridgeDNSForwarder struct {
+	name    string
+	domains []string
+	servers []string
+}
+
+// dnsForwarders returns the conditional DNS forwarders from the config, sorted by name.
+// The servers are returned in the dnsmasq address#port format.
+func (n *bridge) dnsForwarders(config map[string]string) []bridgeDNSForwarder {
+	names := []string{}
+	for k := range config {
+		if !strings.HasPrefix(k, "dns.forwarders.") {
+			continue
+		}
+
+		fields := strings.Split(k, ".")
+		if len(fields) != 4 || slices.Contains(names, fields[2]) {
+			continue
+		}
+
+		names = append(names, fields[2])
+	}
+
+	slices.Sort(names)
+
+	forwarders := make([]bridgeDNSForwarder, 0, len(names))
+	for _, name := range names {
+		forwarder := bridgeDNSForwarder{
+			name:    name,
+			domains: util.SplitNTrimSpace(config[fmt.Sprintf("dns.forwarders.%s.domains", name)], ",", -1, true),
+		}
+
+		for _, server := range util.SplitNTrimSpace(config[fmt.Sprintf("dns.forwarders.%s.servers", name)], ",", -1, true) {
+			host, port, err := net.SplitHostPort(server)
+			if err != nil {
+				forwarder.servers = append(forwarder.servers, strings.Trim(server, "[]"))
+				continue
+			}
+
+			forwarder.servers = append(forwarder.servers, fmt.Sprintf("%s#%s", host, port))
+		}
+
+		forwarders = append(forwarders, forwarder)
+	}
+
+	return forwarders
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"slices"
 	"strconv"
@@ -17,6 +18,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
 	dbCluster "github.com/lxc/incus/v6/internal/server/db/cluster"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/network/acl"
 	"github.com/lxc/incus/v6/internal/server/resources"
 	"github.com/lxc/incus/v6/internal/server/state"
@@ -35,6 +37,7 @@ type Info struct {
 	AddressForwards    bool // Indicates if driver supports address forwards.
 	LoadBalancers      bool // Indicates if driver supports load balancers.
 	Peering            bool // Indicates if the driver supports network peering.
+	DHCPReservations   bool // Indicates if driver supports DHCP reservations.
 }
 
 // forwardTarget represents a single port forward target.
@@ -584,6 +587,11 @@ func (n *common) notifyDependentNetworks(changedKeys []string) {
 	}
 }
 
+// Recover is a placeholder for networks that don't support recovery.
+func (n *common) Recover() error {
+	return ErrNotImplemented
+}
+
 // handleDependencyChange is a placeholder for networks that don't need to handle changes from dependent networks.
 func (n *common) handleDependencyChange(netName string, netConfig map[string]string, changedKeys []string) error {
 	return nil
@@ -616,6 +624,8 @@ func (n *common) bgpValidationRules(config map[string]string) (map[string]func(v
 			rules[k] = validate.Optional(validate.IsAny)
 		case "holdtime":
 			rules[k] = validate.Optional(validate.IsInRange(9, 65535))
+		case "bfd":
+			rules[k] = validate.Optional(validate.IsBool)
 		}
 	}
 
@@ -722,7 +732,7 @@ func (n *common) bgpSetupPeers(oldConfig map[string]string) error {
 			}
 		}
 
-		err = n.state.BGP.AddPeer(net.ParseIP(fields[0]), uint32(asn), fields[2], holdTime)
+		err = n.state.BGP.AddPeer(net.ParseIP(fields[0]), uint32(asn), fields[2], holdTime, util.IsTrue(fields[4]))
 		if err != nil {
 			return err
 		}
@@ -823,9 +833,10 @@ func (n *common) bgpGetPeers(config map[string]string) []string {
 		peerASN := config[fmt.Sprintf("bgp.peers.%s.asn", peerName)]
 		peerPassword := config[fmt.Sprintf("bgp.peers.%s.password", peerName)]
 		peerHoldTime := config[fmt.Sprintf("bgp.peers.%s.holdtime", peerName)]
+		peerBFD := config[fmt.Sprintf("bgp.peers.%s.bfd", peerName)]
 
 		if peerAddress != "" && peerASN != "" {
-			peers = append(peers, fmt.Sprintf("%s,%s,%s,%s", peerAddress, peerASN, peerPassword, peerHoldTime))
+			peers = append(peers, fmt.Sprintf("%s,%s,%s,%s,%s", peerAddress, peerASN, peerPassword, peerHoldTime, peerBFD))
 		}
 	}
 
@@ -1009,6 +1020,26 @@ func (n *common) ForwardDelete(listenAddress string, clientType request.ClientTy
 	return ErrNotImplemented
 }
 
+// ForwardState returns ErrNotImplemented for drivers that do not support forward counters.
+func (n *common) ForwardState(forward api.NetworkForward) (*api.NetworkForwardState, error) {
+	return nil, ErrNotImplemented
+}
+
+// DHCPReservationCreate returns ErrNotImplemented for drivers that do not support DHCP reservations.
+func (n *common) DHCPReservationCreate(reservation api.NetworkDHCPReservationsPost, clientType request.ClientType) error {
+	return ErrNotImplemented
+}
+
+// DHCPReservationUpdate returns ErrNotImplemented for drivers that do not support DHCP reservations.
+func (n *common) DHCPReservationUpdate(hwaddr string, newReservation api.NetworkDHCPReservationPut, clientType request.ClientType) error {
+	return ErrNotImplemented
+}
+
+// DHCPReservationDelete returns ErrNotImplemented for drivers that do not support DHCP reservations.
+func (n *common) DHCPReservationDelete(hwaddr string, clientType request.ClientType) error {
+	return ErrNotImplemented
+}
+
 // forwardBGPSetupPrefixes exports external forward addresses as prefixes.
 func (n *common) forwardBGPSetupPrefixes() error {
 	var fwdListenAddresses map[int64]string
@@ -1177,14 +1208,30 @@ func (n *common) loadBalancerValidate(listenAddress net.IP, forward *api.Network
 		}
 	}
 
-	// Look for any unknown config fields.
-	for k := range forward.Config {
+	// Validate the config.
+	loadBalancerConfigKeys := map[string]func(value string) error{
+		"healthcheck":               validate.Optional(validate.IsBool),
+		"healthcheck.interval":      validate.Optional(validate.IsUint32),
+		"healthcheck.timeout":       validate.Optional(validate.IsUint32),
+		"healthcheck.failure_count": validate.Optional(validate.IsUint32),
+		"healthcheck.success_count": validate.Optional(validate.IsUint32),
+	}
+
+	for k, v := range forward.Config {
 		// User keys are not validated.
 		if internalInstance.IsUserConfig(k) {
 			continue
 		}
 
-		return nil, fmt.Errorf("Invalid option %q", k)
+		validator, found := loadBalancerConfigKeys[k]
+		if !found {
+			return nil, fmt.Errorf("Invalid option %q", k)
+		}
+
+		err := validator(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for option %q: %w", k, err)
+		}
 	}
 
 	// Validate port rules.
@@ -1322,6 +1369,11 @@ func (n *common) LoadBalancerDelete(listenAddress string, clientType request.Cli
 	return ErrNotImplemented
 }
 
+// LoadBalancerState returns ErrNotImplemented for drivers that do not support load balancers.
+func (n *common) LoadBalancerState(loadBalancer api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error) {
+	return nil, ErrNotImplemented
+}
+
 // loadBalancerBGPSetupPrefixes exports external load balancer addresses as prefixes.
 func (n *common) loadBalancerBGPSetupPrefixes() error {
 	var listenAddresses map[int64]string
@@ -1401,6 +1453,69 @@ func (n *common) Leases(projectName string, clientType request.ClientType) ([]ap
 	return nil, ErrNotImplemented
 }
 
+// Trace returns ErrNotImplemented for drivers that don't support packet tracing.
+func (n *common) Trace(inst instance.Instance, trace api.NetworkTracePost) (*api.NetworkTrace, error) {
+	return nil, ErrNotImplemented
+}
+
+// SetGatewayChassis returns ErrNotImplemented for drivers that don't support gateway chassis.
+func (n *common) SetGatewayChassis(name string) error {
+	return ErrNotImplemented
+}
+
+// tracePacket returns the packet to trace, sent from the first of the instance NIC addresses which is in the
+// same family as the destination.
+func (n *common) tracePacket(trace api.NetworkTracePost, sources []net.IP) (acl.TracePacket, error) {
+	destination := net.ParseIP(trace.Destination)
+	if destination == nil {
+		return acl.TracePacket{}, fmt.Errorf("Invalid destination address %q", trace.Destination)
+	}
+
+	for _, source := range sources {
+		if (source.To4() == nil) != (destination.To4() == nil) {
+			continue
+		}
+
+		return acl.TracePacket{
+			Protocol:        trace.Protocol,
+			Source:          source,
+			SourcePort:      trace.SourcePort,
+			Destination:     destination,
+			DestinationPort: trace.DestinationPort,
+		}, nil
+	}
+
+	return acl.TracePacket{}, api.StatusErrorf(http.StatusBadRequest, "Instance NIC %q has no address in the family of the destination", trace.Device)
+}
+
+// traceRuleString returns the description of an ACL rule, falling back to its criteria.
+func traceRuleString(rule api.NetworkACLRule) string {
+	if rule.Description != "" {
+		return rule.Description
+	}
+
+	criteria := []string{}
+	for _, criterion := range [][2]string{
+		{"protocol", rule.Protocol},
+		{"source", rule.Source},
+		{"source_port", rule.SourcePort},
+		{"destination", rule.Destination},
+		{"destination_port", rule.DestinationPort},
+		{"icmp_type", rule.ICMPType},
+		{"icmp_code", rule.ICMPCode},
+	} {
+		if criterion[1] != "" {
+			criteria = append(criteria, fmt.Sprintf("%s=%s", criterion[0], criterion[1]))
+		}
+	}
+
+	if len(criteria) == 0 {
+		return "any"
+	}
+
+	return strings.Join(criteria, " ")
+}
+
 // PeerCrete returns ErrNotImplemented for drivers that do not support forwards.
 func (n *common) PeerCreate(forward api.NetworkPeersPost) error {
 	return ErrNotImplemented
@@ -1428,11 +1543,28 @@ func (n *common) peerValidate(peerName string, peer *api.NetworkPeerPut) error {
 	}
 
 	// Look for any unknown config fields.
-	for k := range peer.Config {
+	for k, v := range peer.Config {
 		if k == "target_address" {
 			continue
 		}
 
+		if k == "security.acls" {
+			// Check the ACLs exist in the network's project and can be used to filter peer traffic.
+			aclNames := util.SplitNTrimSpace(v, ",", -1, true)
+
+			err := acl.Exists(n.state, n.Project(), aclNames...)
+			if err != nil {
+				return err
+			}
+
+			err = acl.OVNValidateNetworkPeerACLs(n.state, n.Project(), aclNames)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
 		// User keys are not validated.
 		if internalInstance.IsUserConfig(k) {
 			continue
@@ -1533,7 +1665,134 @@ func (n *common) peerUsedBy(peerName string, firstOnly bool) ([]string, error) {
 }
 
 func (n *common) State() (*api.NetworkState, error) {
-	return resources.GetNetworkState(n.name)
+	state, err := resources.GetNetworkState(n.name)
+	if err != nil {
+		return nil, err
+	}
+
+	state.Uplink, err = n.UplinkState()
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// UplinkState returns the usage of the network as an uplink by OVN networks, or nil if it isn't used as one.
+// The usage of the address ranges reserved for OVN networks only accounts for the OVN router addresses, the
+// forwards and load balancers using addresses from the external routes of the uplink.
+func (n *common) UplinkState() (*api.NetworkStateUplink, error) {
+	// Only managed bridge and physical networks in the default project can be uplinks.
+	if !slices.Contains([]string{"bridge", "physical"}, n.netType) || n.project != api.ProjectDefaultName {
+		return nil, nil
+	}
+
+	var projectNetworks map[string]map[int64]api.Network
+	var externalSubnets []externalSubnetUsage
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		projectNetworks, err = tx.GetCreatedNetworks(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed loading networks: %w", err)
+		}
+
+		externalSubnets, err = n.getExternalSubnetInUse(ctx, tx, n.name, false)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uplink := &api.NetworkStateUplink{
+		Ranges:   []api.NetworkStateUplinkRange{},
+		Networks: []api.NetworkStateUplinkNetwork{},
+	}
+
+	var routerIPs []net.IP
+
+	for projectName, networks := range projectNetworks {
+		for _, netInfo := range networks {
+			if netInfo.Type != "ovn" || netInfo.Config["network"] != n.name {
+				continue
+			}
+
+			usage := api.NetworkStateUplinkNetwork{
+				Project:   projectName,
+				Name:      netInfo.Name,
+				Addresses: []string{},
+			}
+
+			for _, k := range []string{ovnVolatileUplinkIPv4, ovnVolatileUplinkIPv6} {
+				ip := net.ParseIP(netInfo.Config[k])
+				if ip != nil {
+					routerIPs = append(routerIPs, ip)
+					usage.Addresses = append(usage.Addresses, ip.String())
+				}
+			}
+
+			for _, externalSubnet := range externalSubnets {
+				if externalSubnet.networkProject == projectName && externalSubnet.networkName == netInfo.Name {
+					usage.Addresses = append(usage.Addresses, externalSubnet.subnet.IP.String())
+				}
+			}
+
+			uplink.Networks = append(uplink.Networks, usage)
+		}
+	}
+
+	if len(uplink.Networks) == 0 && n.config["ipv4.ovn.ranges"] == "" && n.config["ipv6.ovn.ranges"] == "" {
+		return nil, nil
+	}
+
+	slices.SortFunc(uplink.Networks, func(a api.NetworkStateUplinkNetwork, b api.NetworkStateUplinkNetwork) int {
+		if a.Project != b.Project {
+			return strings.Compare(a.Project, b.Project)
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, family := range []string{"inet", "inet6"} {
+		key := "ipv4.ovn.ranges"
+		if family == "inet6" {
+			key = "ipv6.ovn.ranges"
+		}
+
+		if n.config[key] == "" {
+			continue
+		}
+
+		ipRanges, err := parseIPRanges(n.config[key])
+		if err != nil {
+			return nil, fmt.Errorf("Failed parsing %q: %w", key, err)
+		}
+
+		for _, ipRange := range ipRanges {
+			rangeUsage := api.NetworkStateUplinkRange{
+				Family: family,
+				Range:  ipRange.String(),
+				Total:  ipRangeSize(ipRange),
+			}
+
+			// Compare the addresses in their 16 bytes form.
+			r := iprange.Range{Start: ipRange.Start.To16(), End: ipRange.End.To16()}
+			for _, ip := range routerIPs {
+				if r.ContainsIP(ip.To16()) {
+					rangeUsage.Used++
+				}
+			}
+
+			uplink.Ranges = append(uplink.Ranges, rangeUsage)
+		}
+	}
+
+	return uplink, nil
 }
 
 func (n *common) setUnavailable() {
@@ -0,0 +1,425 @@
+package network
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lxc/incus/v6/internal/revert"
+	"github.com/lxc/incus/v6/internal/server/cluster/request"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/ip"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/util"
+	"github.com/lxc/incus/v6/shared/validate"
+)
+
+// Default UDP ports of the mesh tunnel protocols.
+var meshDefaultPorts = map[string]string{
+	"vxlan":  "4789",
+	"geneve": "6081",
+}
+
+// MAC address of the forwarding database entries flooding the traffic to the VXLAN peers.
+const meshFloodHwaddr = "00:00:00:00:00:00"
+
+// mesh represents a bridge network joined to the same network on other standalone servers through a full mesh of
+// VXLAN or Geneve tunnels.
+type mesh struct {
+	bridge
+}
+
+// DBType returns the network type DB ID.
+func (n *mesh) DBType() db.NetworkType {
+	return db.NetworkTypeMesh
+}
+
+// ValidateName validates network name.
+func (n *mesh) ValidateName(name string) error {
+	err := n.bridge.ValidateName(name)
+	if err != nil {
+		return err
+	}
+
+	// Leave room for the tunnel interface suffix.
+	if len(name) > 10 {
+		return fmt.Errorf("Network name too long for mesh tunnel interfaces (maximum 10 characters)")
+	}
+
+	return nil
+}
+
+// meshBridgeConfig returns a copy of the config without the mesh specific keys.
+func meshBridgeConfig(config map[string]string) map[string]string {
+	bridgeConfig := make(map[string]string, len(config))
+	for k, v := range config {
+		if !strings.HasPrefix(k, "mesh.") {
+			bridgeConfig[k] = v
+		}
+	}
+
+	return bridgeConfig
+}
+
+// FillConfig fills requested config with any default values.
+func (n *mesh) FillConfig(config map[string]string) error {
+	// Leave room for the tunnel headers.
+	if config["bridge.mtu"] == "" {
+		config["bridge.mtu"] = "1400"
+	}
+
+	bridgeConfig := meshBridgeConfig(config)
+
+	err := n.bridge.FillConfig(bridgeConfig)
+	if err != nil {
+		return err
+	}
+
+	maps.Copy(config, bridgeConfig)
+
+	return nil
+}
+
+// Validate network config.
+func (n *mesh) Validate(config map[string]string) error {
+	if n.state != nil && n.state.ServerClustered {
+		return fmt.Errorf("Mesh networks are only supported on standalone servers")
+	}
+
+	rules := map[string]func(value string) error{
+		"mesh.peers":    validate.Optional(validate.IsListOf(validate.IsNetworkAddress)),
+		"mesh.protocol": validate.Optional(validate.IsOneOf("vxlan", "geneve")),
+		"mesh.id":       validate.Optional(validate.IsInRange(1, 16777215)),
+		"mesh.port":     validate.Optional(validate.IsNetworkPort),
+		"mesh.local":    validate.Optional(validate.IsNetworkAddress),
+	}
+
+	for k := range config {
+		if strings.HasPrefix(k, "mesh.") && rules[k] == nil {
+			return fmt.Errorf("Invalid option for network %q option %q", n.name, k)
+		}
+	}
+
+	for k, validator := range rules {
+		err := validator(config[k])
+		if err != nil {
+			return fmt.Errorf("Invalid value for network %q option %q: %w", n.name, k, err)
+		}
+	}
+
+	// All the tunnel endpoints must use the same IP family.
+	var isIPv6 *bool
+	for _, addr := range append(util.SplitNTrimSpace(config["mesh.peers"], ",", -1, true), config["mesh.local"]) {
+		if addr == "" {
+			continue
+		}
+
+		addrIPv6 := net.ParseIP(addr).To4() == nil
+		if isIPv6 != nil && *isIPv6 != addrIPv6 {
+			return fmt.Errorf(`The "mesh.peers" and "mesh.local" addresses must all be of the same IP family`)
+		}
+
+		isIPv6 = &addrIPv6
+	}
+
+	if config["mesh.protocol"] == "geneve" && config["mesh.local"] != "" {
+		return fmt.Errorf(`"mesh.local" is only supported with the "vxlan" protocol`)
+	}
+
+	if config["mesh.protocol"] != "geneve" && isIPv6 != nil && *isIPv6 && config["mesh.local"] == "" {
+		return fmt.Errorf(`"mesh.local" must be set when using IPv6 peers with the "vxlan" protocol`)
+	}
+
+	if config["bridge.driver"] == "openvswitch" {
+		return fmt.Errorf("Mesh networks only support the native bridge driver")
+	}
+
+	return n.bridge.Validate(meshBridgeConfig(config))
+}
+
+// Rename renames a network.
+func (n *mesh) Rename(newName string) error {
+	err := n.bridge.Rename(newName)
+	if err != nil {
+		return err
+	}
+
+	return n.setupMesh()
+}
+
+// Start starts the network.
+func (n *mesh) Start() error {
+	err := n.bridge.Start()
+	if err != nil {
+		return err
+	}
+
+	err = n.setupMesh()
+	if err != nil {
+		n.setUnavailable()
+		return err
+	}
+
+	return nil
+}
+
+// Update updates the network. Changes to the peers are applied live, other changes set up the bridge again.
+func (n *mesh) Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error {
+	n.logger.Debug("Update", logger.Ctx{"clientType": clientType, "newNetwork": newNetwork})
+
+	// Generate the auto config values here as the bridge would validate them without the mesh keys.
+	bridgeConfig := meshBridgeConfig(newNetwork.Config)
+
+	err := n.populateAutoConfig(bridgeConfig)
+	if err != nil {
+		return fmt.Errorf("Failed generating auto config: %w", err)
+	}
+
+	maps.Copy(newNetwork.Config, bridgeConfig)
+
+	dbUpdateNeeded, changedKeys, oldNetwork, err := n.common.configChanged(newNetwork)
+	if err != nil {
+		return err
+	}
+
+	if !dbUpdateNeeded {
+		return nil // Nothing changed.
+	}
+
+	pending := n.Status() == api.NetworkStatusPending || n.LocalStatus() == api.NetworkStatusPending
+
+	if !pending && n.isRunning() && len(changedKeys) == 1 && changedKeys[0] == "mesh.peers" {
+		revert := revert.New()
+		defer revert.Fail()
+
+		err = n.common.update(newNetwork, targetNode, clientType)
+		if err != nil {
+			return err
+		}
+
+		revert.Add(func() { _ = n.common.update(oldNetwork, targetNode, clientType) })
+
+		err = n.updateMeshPeers(oldNetwork.Config)
+		if err != nil {
+			return err
+		}
+
+		revert.Success()
+		return nil
+	}
+
+	err = n.bridge.Update(newNetwork, targetNode, clientType)
+	if err != nil {
+		return err
+	}
+
+	if !pending && len(changedKeys) > 0 && n.isRunning() {
+		return n.setupMesh()
+	}
+
+	return nil
+}
+
+// meshConfig returns the tunnel protocol, ID and port, applying the defaults.
+func (n *mesh) meshConfig() (string, string, string) {
+	protocol := n.config["mesh.protocol"]
+	if protocol == "" {
+		protocol = "vxlan"
+	}
+
+	tunID := n.config["mesh.id"]
+	if tunID == "" {
+		tunID = "1"
+	}
+
+	port := n.config["mesh.port"]
+	if port == "" {
+		port = meshDefaultPorts[protocol]
+	}
+
+	return protocol, tunID, port
+}
+
+// meshPeers returns the peers from the config, leaving out the addresses of this server so that the same list
+// can be used on all of them.
+func (n *mesh) meshPeers(config map[string]string) []string {
+	localAddrs, _ := net.InterfaceAddrs()
+
+	peers := []string{}
+	for _, peer := range util.SplitNTrimSpace(config["mesh.peers"], ",", -1, true) {
+		peerIP := net.ParseIP(peer)
+
+		local := slices.ContainsFunc(localAddrs, func(addr net.Addr) bool {
+			ipNet, ok := addr.(*net.IPNet)
+			return ok && ipNet.IP.Equal(peerIP)
+		})
+
+		if !local {
+			peers = append(peers, peer)
+		}
+	}
+
+	return peers
+}
+
+// meshInterfaces returns the tunnel interfaces of the mesh.
+func (n *mesh) meshInterfaces() ([]string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	ifaceRegex := regexp.MustCompile(fmt.Sprintf(`^%s-(mesh|m[0-9]+)$`, regexp.QuoteMeta(n.name)))
+
+	names := []string{}
+	for _, iface := range ifaces {
+		if ifaceRegex.MatchString(iface.Name) {
+			names = append(names, iface.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// setupMesh (re)creates the tunnel interfaces to the peers and attaches them to the bridge.
+// VXLAN uses a single interface flooding the traffic to all the peers through forwarding database entries,
+// while Geneve uses an isolated bridge port per peer so that the traffic of a peer isn't sent to the others.
+func (n *mesh) setupMesh() error {
+	// If we are in mock mode, just no-op.
+	if n.state.OS.MockMode {
+		return nil
+	}
+
+	ifaces, err := n.meshInterfaces()
+	if err != nil {
+		return err
+	}
+
+	for _, iface := range ifaces {
+		err = InterfaceRemove(iface)
+		if err != nil {
+			return err
+		}
+	}
+
+	mtu, err := GetDevMTU(n.name)
+	if err != nil {
+		return err
+	}
+
+	protocol, tunID, port := n.meshConfig()
+	peers := n.meshPeers(n.config)
+
+	if protocol == "vxlan" {
+		vxlan := &ip.Vxlan{
+			Link:    ip.Link{Name: fmt.Sprintf("%s-mesh", n.name), MTU: mtu},
+			VxlanID: tunID,
+			Local:   n.config["mesh.local"],
+			DstPort: port,
+		}
+
+		err = vxlan.Add()
+		if err != nil {
+			return fmt.Errorf("Failed creating mesh tunnel interface: %w", err)
+		}
+
+		err = n.attachMeshInterface(vxlan.Name, false)
+		if err != nil {
+			return err
+		}
+
+		for _, peer := range peers {
+			err = vxlan.BridgeFdbAppend(meshFloodHwaddr, peer)
+			if err != nil {
+				return fmt.Errorf("Failed adding mesh peer %q: %w", peer, err)
+			}
+		}
+
+		return nil
+	}
+
+	for i, peer := range peers {
+		geneve := &ip.Geneve{
+			Link:     ip.Link{Name: fmt.Sprintf("%s-m%d", n.name, i), MTU: mtu},
+			GeneveID: tunID,
+			Remote:   peer,
+			DstPort:  port,
+		}
+
+		err = geneve.Add()
+		if err != nil {
+			return fmt.Errorf("Failed creating mesh tunnel interface to %q: %w", peer, err)
+		}
+
+		err = n.attachMeshInterface(geneve.Name, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attachMeshInterface attaches the tunnel interface to the bridge and brings it up.
+func (n *mesh) attachMeshInterface(name string, isolated bool) error {
+	err := AttachInterface(n.name, name)
+	if err != nil {
+		return err
+	}
+
+	link := &ip.Link{Name: name}
+
+	if isolated {
+		err = link.BridgeLinkSetIsolated(true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return link.SetUp()
+}
+
+// updateMeshPeers applies the changes to the peers since the old config.
+func (n *mesh) updateMeshPeers(oldConfig map[string]string) error {
+	// If we are in mock mode, just no-op.
+	if n.state.OS.MockMode {
+		return nil
+	}
+
+	protocol, _, _ := n.meshConfig()
+	if protocol != "vxlan" {
+		return n.setupMesh()
+	}
+
+	oldPeers := n.meshPeers(oldConfig)
+	newPeers := n.meshPeers(n.config)
+	link := &ip.Link{Name: fmt.Sprintf("%s-mesh", n.name)}
+
+	for _, peer := range oldPeers {
+		if slices.Contains(newPeers, peer) {
+			continue
+		}
+
+		err := link.BridgeFdbDelete(meshFloodHwaddr, peer)
+		if err != nil {
+			return fmt.Errorf("Failed removing mesh peer %q: %w", peer, err)
+		}
+	}
+
+	for _, peer := range newPeers {
+		if slices.Contains(oldPeers, peer) {
+			continue
+		}
+
+		err := link.BridgeFdbAppend(meshFloodHwaddr, peer)
+		if err != nil {
+			return fmt.Errorf("Failed adding mesh peer %q: %w", peer, err)
+		}
+	}
+
+	return nil
+}
@@ -3,12 +3,15 @@ package network
 import (
 	"context"
 	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"net"
 	"net/http"
 	"os"
+	"regexp"
 	"slices"
 	"sort"
 	"strconv"
@@ -158,6 +161,11 @@ func (n *ovn) State() (*api.NetworkState, error) {
 		return nil, err
 	}
 
+	gatewayChassis, err := n.gatewayChassis(chassis)
+	if err != nil {
+		return nil, err
+	}
+
 	mtu := int(n.getBridgeMTU())
 	if mtu == 0 {
 		mtu = 1500
@@ -171,8 +179,9 @@ func (n *ovn) State() (*api.NetworkState, error) {
 		State:     "up",
 		Type:      "broadcast",
 		OVN: &api.NetworkStateOVN{
-			Chassis:       chassis,
-			LogicalRouter: string(n.getRouterName()),
+			Chassis:        chassis,
+			LogicalRouter:  string(n.getRouterName()),
+			GatewayChassis: gatewayChassis,
 		},
 	}, nil
 }
@@ -370,6 +379,7 @@ func (n *ovn) Validate(config map[string]string) error {
 		"ipv6.l3only":                          validate.Optional(validate.IsBool),
 		"dns.domain":                           validate.IsAny,
 		"dns.search":                           validate.IsAny,
+		"dns.split_horizon":                    validate.Optional(validate.IsBool),
 		"dns.zone.forward":                     validate.IsAny,
 		"dns.zone.reverse.ipv4":                validate.IsAny,
 		"dns.zone.reverse.ipv6":                validate.IsAny,
@@ -384,6 +394,13 @@ func (n *ovn) Validate(config map[string]string) error {
 		ovnVolatileUplinkIPv6: validate.Optional(validate.IsNetworkAddressV6),
 	}
 
+	// Conditional DNS forwarders rely on dnsmasq which OVN networks don't use.
+	for k := range config {
+		if strings.HasPrefix(k, "dns.forwarders.") {
+			return fmt.Errorf("Conditional DNS forwarders aren't supported on OVN networks (%q)", k)
+		}
+	}
+
 	err := n.validate(config, rules)
 	if err != nil {
 		return err
@@ -1834,6 +1851,14 @@ func (n *ovn) Create(clientType request.ClientType) error {
 	return nil
 }
 
+// Recover sets up the network in the OVN Northbound database after its database record has been recovered.
+// Existing logical objects of the network are reused and updated to match the recovered configuration.
+func (n *ovn) Recover() error {
+	n.logger.Debug("Recover", logger.Ctx{"config": n.config})
+
+	return n.setup(true)
+}
+
 // allowedUplinkNetworks returns a list of allowed networks to use as uplinks based on project restrictions.
 func (n *ovn) allowedUplinkNetworks(p *api.Project) ([]string, error) {
 	var uplinkNetworkNames []string
@@ -2686,6 +2711,90 @@ func (n *ovn) addChassisGroupEntry() error {
 	return nil
 }
 
+// gatewayChassis returns the chassis of the OVN logical network's chassis group, highest priority first.
+func (n *ovn) gatewayChassis(activeChassis string) ([]api.NetworkStateOVNChassis, error) {
+	priorities, err := n.state.OVNNB.GetChassisGroupPriorities(context.TODO(), n.getChassisGroupName())
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting chassis group %q: %w", n.getChassisGroupName(), err)
+	}
+
+	hostnames, err := n.state.OVNSB.GetChassisHostnames(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting chassis hostnames: %w", err)
+	}
+
+	gatewayChassis := make([]api.NetworkStateOVNChassis, 0, len(priorities))
+	for chassisID, priority := range priorities {
+		name := hostnames[chassisID]
+		if name == "" {
+			name = chassisID
+		}
+
+		gatewayChassis = append(gatewayChassis, api.NetworkStateOVNChassis{
+			Name:     name,
+			Priority: priority,
+			Active:   name == activeChassis,
+		})
+	}
+
+	sort.Slice(gatewayChassis, func(i, j int) bool {
+		if gatewayChassis[i].Priority != gatewayChassis[j].Priority {
+			return gatewayChassis[i].Priority > gatewayChassis[j].Priority
+		}
+
+		return gatewayChassis[i].Name < gatewayChassis[j].Name
+	})
+
+	return gatewayChassis, nil
+}
+
+// SetGatewayChassis moves the gateway of the network to the chassis by giving it the highest priority in the
+// chassis group. The chassis which had that priority are moved right below it.
+// The chassis gets its stable-random priority back when the network is next started on it.
+func (n *ovn) SetGatewayChassis(name string) error {
+	priorities, err := n.state.OVNNB.GetChassisGroupPriorities(context.TODO(), n.getChassisGroupName())
+	if err != nil {
+		return fmt.Errorf("Failed getting chassis group %q: %w", n.getChassisGroupName(), err)
+	}
+
+	hostnames, err := n.state.OVNSB.GetChassisHostnames(context.TODO())
+	if err != nil {
+		return fmt.Errorf("Failed getting chassis hostnames: %w", err)
+	}
+
+	var targetID string
+	for chassisID := range priorities {
+		if chassisID == name || hostnames[chassisID] == name {
+			targetID = chassisID
+			break
+		}
+	}
+
+	if targetID == "" {
+		return api.StatusErrorf(http.StatusNotFound, "Chassis %q isn't part of the chassis group of network %q", name, n.name)
+	}
+
+	for chassisID, priority := range priorities {
+		if chassisID == targetID || priority < ovnChassisPriorityMax {
+			continue
+		}
+
+		err = n.state.OVNNB.SetChassisGroupPriority(context.TODO(), n.getChassisGroupName(), chassisID, ovnChassisPriorityMax-1)
+		if err != nil {
+			return fmt.Errorf("Failed lowering priority of chassis %q in chassis group %q: %w", chassisID, n.getChassisGroupName(), err)
+		}
+	}
+
+	err = n.state.OVNNB.SetChassisGroupPriority(context.TODO(), n.getChassisGroupName(), targetID, ovnChassisPriorityMax)
+	if err != nil {
+		return fmt.Errorf("Failed raising priority of chassis %q in chassis group %q: %w", targetID, n.getChassisGroupName(), err)
+	}
+
+	n.logger.Info("Moved network gateway", logger.Ctx{"chassis": name, "chassisGroup": n.getChassisGroupName()})
+
+	return nil
+}
+
 // deleteChassisGroupEntry deletes an entry for the local OVS chassis from the OVN logical network's chassis group.
 func (n *ovn) deleteChassisGroupEntry() error {
 	// Remove local chassis from chassis group.
@@ -2925,9 +3034,85 @@ func (n *ovn) Start() error {
 	// Ensure network is marked as available now its started.
 	n.setAvailable()
 
+	// Store the recovery hint, this also takes care of networks created before hints were introduced.
+	n.updateRecoveryHint()
+
 	return nil
 }
 
+// OVNRecoveryHint is stored on the internal logical switch of an OVN network so that the network, along with its
+// forwards and load balancers, can be recovered if the database is lost.
+type OVNRecoveryHint struct {
+	ID            int64                     `json:"id"`
+	Project       string                    `json:"project"`
+	Network       api.Network               `json:"network"`
+	Forwards      []api.NetworkForward      `json:"forwards"`
+	LoadBalancers []api.NetworkLoadBalancer `json:"load_balancers"`
+}
+
+// updateRecoveryHint stores the recovery hint on the internal logical switch.
+// Failures are only logged as the hint isn't needed for the network to function.
+func (n *ovn) updateRecoveryHint() {
+	hint := OVNRecoveryHint{
+		ID:      n.ID(),
+		Project: n.Project(),
+		Network: api.Network{
+			Name: n.Name(),
+			Type: n.Type(),
+			NetworkPut: api.NetworkPut{
+				Description: n.Description(),
+				Config:      n.Config(),
+			},
+		},
+	}
+
+	err := n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		forwards, err := tx.GetNetworkForwards(ctx, n.ID(), false)
+		if err != nil {
+			return fmt.Errorf("Failed loading network forwards: %w", err)
+		}
+
+		for _, forward := range forwards {
+			hint.Forwards = append(hint.Forwards, *forward)
+		}
+
+		loadBalancers, err := tx.GetNetworkLoadBalancers(ctx, n.ID(), false)
+		if err != nil {
+			return fmt.Errorf("Failed loading network load balancers: %w", err)
+		}
+
+		for _, loadBalancer := range loadBalancers {
+			hint.LoadBalancers = append(hint.LoadBalancers, *loadBalancer)
+		}
+
+		return nil
+	})
+	if err != nil {
+		n.logger.Warn("Failed generating network recovery hint", logger.Ctx{"err": err})
+		return
+	}
+
+	// Keep a stable ordering to avoid needless updates.
+	slices.SortFunc(hint.Forwards, func(a api.NetworkForward, b api.NetworkForward) int {
+		return strings.Compare(a.ListenAddress, b.ListenAddress)
+	})
+
+	slices.SortFunc(hint.LoadBalancers, func(a api.NetworkLoadBalancer, b api.NetworkLoadBalancer) int {
+		return strings.Compare(a.ListenAddress, b.ListenAddress)
+	})
+
+	hintJSON, err := json.Marshal(hint)
+	if err != nil {
+		n.logger.Warn("Failed encoding network recovery hint", logger.Ctx{"err": err})
+		return
+	}
+
+	err = n.state.OVNNB.UpdateLogicalSwitchRecoveryHint(context.TODO(), n.getIntSwitchName(), string(hintJSON))
+	if err != nil {
+		n.logger.Warn("Failed storing network recovery hint", logger.Ctx{"err": err})
+	}
+}
+
 // Stop deletes the local OVS uplink port (if unused) and deletes the local OVS chassis ID from the
 // OVN chassis group.
 func (n *ovn) Stop() error {
@@ -3265,6 +3450,11 @@ func (n *ovn) Update(newNetwork api.NetworkPut, targetNode string, clientType re
 		return err
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	revert.Success()
 	return nil
 }
@@ -4053,6 +4243,32 @@ func (n *ovn) InstanceDevicePortIPs(instanceUUID string, deviceName string) ([]n
 	return devIPs, nil
 }
 
+// InstanceDevicePortState returns the state of the logical switch port of a device.
+func (n *ovn) InstanceDevicePortState(instanceUUID string, deviceName string) (*api.InstanceStateNetworkOVN, error) {
+	if instanceUUID == "" {
+		return nil, fmt.Errorf("Instance UUID is required")
+	}
+
+	instancePortName := n.getInstanceDevicePortName(instanceUUID, deviceName)
+
+	enabled, up, err := n.state.OVNNB.GetLogicalSwitchPortState(context.TODO(), instancePortName)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OVN switch port state: %w", err)
+	}
+
+	chassis, err := n.state.OVNSB.GetLogicalSwitchPortChassisHostname(context.TODO(), instancePortName)
+	if err != nil && !errors.Is(err, ovsClient.ErrNotFound) {
+		return nil, fmt.Errorf("Failed to get OVN switch port chassis: %w", err)
+	}
+
+	return &api.InstanceStateNetworkOVN{
+		LogicalSwitchPort: string(instancePortName),
+		Enabled:           enabled,
+		Up:                up,
+		Chassis:           chassis,
+	}, nil
+}
+
 // InstanceDevicePortStop deletes an instance device port from the internal logical switch.
 func (n *ovn) InstanceDevicePortStop(ovsExternalOVNPort networkOVN.OVNSwitchPort, opts *OVNInstanceNICStopOpts) error {
 	// Decide whether to use OVS provided OVN port name or internally derived OVN port name.
@@ -4721,6 +4937,11 @@ func (n *ovn) ForwardCreate(forward api.NetworkForwardsPost, clientType request.
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.forwardBGPSetupPrefixes()
 	if err != nil {
@@ -4820,6 +5041,11 @@ func (n *ovn) ForwardUpdate(listenAddress string, req api.NetworkForwardPut, cli
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.forwardBGPSetupPrefixes()
 	if err != nil {
@@ -4885,6 +5111,11 @@ func (n *ovn) ForwardDelete(listenAddress string, clientType request.ClientType)
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.forwardBGPSetupPrefixes()
 	if err != nil {
@@ -4932,6 +5163,65 @@ func (n *ovn) loadBalancerFlattenVIPs(listenAddress net.IP, portMaps []*loadBala
 	return vips
 }
 
+// loadBalancerApplyHealthCheck adds the health check settings from the load balancer config to the VIPs.
+func (n *ovn) loadBalancerApplyHealthCheck(vips []networkOVN.OVNLoadBalancerVIP, config map[string]string) error {
+	if util.IsFalseOrEmpty(config["healthcheck"]) {
+		return nil
+	}
+
+	// The health checks are sent from the router's internal address.
+	routerV4, _, err := n.parseRouterIntPortIPv4Net()
+	if err != nil {
+		return err
+	}
+
+	routerV6, _, err := n.parseRouterIntPortIPv6Net()
+	if err != nil {
+		return err
+	}
+
+	// OVN needs to know which logical switch port each target address is behind.
+	portIPs, err := n.state.OVNNB.GetLogicalSwitchIPs(context.TODO(), n.getIntSwitchName())
+	if err != nil {
+		return fmt.Errorf("Failed getting switch port IPs: %w", err)
+	}
+
+	healthCheckSetting := func(key string, defaultValue uint64) uint64 {
+		value, err := strconv.ParseUint(config[key], 10, 32)
+		if err != nil {
+			return defaultValue
+		}
+
+		return value
+	}
+
+	for i := range vips {
+		sourceAddress := routerV4
+		if vips[i].ListenAddress.To4() == nil {
+			sourceAddress = routerV6
+		}
+
+		vips[i].HealthCheck = &networkOVN.OVNLoadBalancerHealthCheck{
+			SourceAddress: sourceAddress,
+			Interval:      healthCheckSetting("healthcheck.interval", 10),
+			Timeout:       healthCheckSetting("healthcheck.timeout", 30),
+			SuccessCount:  healthCheckSetting("healthcheck.success_count", 3),
+			FailureCount:  healthCheckSetting("healthcheck.failure_count", 3),
+		}
+
+		for j, target := range vips[i].Targets {
+			for portName, ips := range portIPs {
+				if IPInSlice(target.Address, ips) {
+					vips[i].Targets[j].SwitchPort = portName
+					break
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadBalancerCreate creates a network load balancer.
 func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) error {
 	revert := revert.New()
@@ -5047,6 +5337,11 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 
 		vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
 
+		err = n.loadBalancerApplyHealthCheck(vips, loadBalancer.Config)
+		if err != nil {
+			return err
+		}
+
 		err = n.state.OVNNB.CreateLoadBalancer(context.TODO(), n.getLoadBalancerName(loadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
 		if err != nil {
 			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
@@ -5091,6 +5386,11 @@ func (n *ovn) LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clie
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.loadBalancerBGPSetupPrefixes()
 	if err != nil {
@@ -5149,6 +5449,11 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 
 		vips := n.loadBalancerFlattenVIPs(net.ParseIP(newLoadBalancer.ListenAddress), portMaps)
 
+		err = n.loadBalancerApplyHealthCheck(vips, newLoadBalancer.Config)
+		if err != nil {
+			return err
+		}
+
 		err = n.state.OVNNB.CreateLoadBalancer(context.TODO(), n.getLoadBalancerName(newLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
 		if err != nil {
 			return fmt.Errorf("Failed applying OVN load balancer: %w", err)
@@ -5159,6 +5464,7 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 			portMaps, err := n.loadBalancerValidate(net.ParseIP(curLoadBalancer.ListenAddress), &curLoadBalancer.NetworkLoadBalancerPut)
 			if err == nil {
 				vips := n.loadBalancerFlattenVIPs(net.ParseIP(curLoadBalancer.ListenAddress), portMaps)
+				_ = n.loadBalancerApplyHealthCheck(vips, curLoadBalancer.Config)
 				_ = n.state.OVNNB.CreateLoadBalancer(context.TODO(), n.getLoadBalancerName(curLoadBalancer.ListenAddress), []networkOVN.OVNRouter{n.getRouterName()}, []networkOVN.OVNSwitch{n.getIntSwitchName()}, vips...)
 				_ = n.forwardBGPSetupPrefixes()
 			}
@@ -5191,6 +5497,11 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.loadBalancerBGPSetupPrefixes()
 	if err != nil {
@@ -5201,6 +5512,86 @@ func (n *ovn) LoadBalancerUpdate(listenAddress string, req api.NetworkLoadBalanc
 	return nil
 }
 
+// LoadBalancerState returns the health of the load balancer backends.
+func (n *ovn) LoadBalancerState(loadBalancer api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error) {
+	portMaps, err := n.loadBalancerValidate(net.ParseIP(loadBalancer.ListenAddress), &loadBalancer.NetworkLoadBalancerPut)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCheck := util.IsTrue(loadBalancer.Config["healthcheck"])
+
+	state := api.NetworkLoadBalancerState{
+		BackendHealth: map[string]api.NetworkLoadBalancerStateBackendHealth{},
+	}
+
+	vips := n.loadBalancerFlattenVIPs(net.ParseIP(loadBalancer.ListenAddress), portMaps)
+
+	// Resolve the switch ports the health checks are bound to.
+	err = n.loadBalancerApplyHealthCheck(vips, loadBalancer.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vip := range vips {
+		for _, target := range vip.Targets {
+			// Find the backend for the target address.
+			var backendName string
+			for _, backend := range loadBalancer.Backends {
+				if net.ParseIP(backend.TargetAddress).Equal(target.Address) {
+					backendName = backend.Name
+					break
+				}
+			}
+
+			if backendName == "" {
+				continue
+			}
+
+			status := "unknown"
+			if healthCheck && target.SwitchPort != "" {
+				monitorStatus, err := n.state.OVNSB.GetServiceMonitorStatus(context.TODO(), target.SwitchPort, target.Address, vip.Protocol, target.Port)
+				if err != nil {
+					return nil, fmt.Errorf("Failed getting health check status: %w", err)
+				}
+
+				switch monitorStatus {
+				case "online":
+					status = "up"
+				case "offline", "error":
+					status = "down"
+				}
+			}
+
+			backendHealth, found := state.BackendHealth[backendName]
+			if !found {
+				backendHealth.Address = target.Address.String()
+			}
+
+			// Skip ports already recorded through another listen port.
+			duplicate := false
+			for _, port := range backendHealth.Ports {
+				if port.Protocol == vip.Protocol && port.Port == int(target.Port) {
+					duplicate = true
+					break
+				}
+			}
+
+			if !duplicate {
+				backendHealth.Ports = append(backendHealth.Ports, api.NetworkLoadBalancerStateBackendHealthPort{
+					Protocol: vip.Protocol,
+					Port:     int(target.Port),
+					Status:   status,
+				})
+			}
+
+			state.BackendHealth[backendName] = backendHealth
+		}
+	}
+
+	return &state, nil
+}
+
 // LoadBalancerDelete deletes a network load balancer.
 func (n *ovn) LoadBalancerDelete(listenAddress string, clientType request.ClientType) error {
 	if clientType == request.ClientTypeNormal {
@@ -5256,6 +5647,11 @@ func (n *ovn) LoadBalancerDelete(listenAddress string, clientType request.Client
 		}
 	}
 
+	// Refresh the recovery hint with the new state.
+	if clientType == request.ClientTypeNormal {
+		n.updateRecoveryHint()
+	}
+
 	// Refresh exported BGP prefixes on local member.
 	err := n.loadBalancerBGPSetupPrefixes()
 	if err != nil {
@@ -5332,6 +5728,139 @@ func (n *ovn) Leases(projectName string, clientType request.ClientType) ([]api.N
 	return leases, nil
 }
 
+// Trace runs ovn-trace for a packet sent by an instance NIC and returns the logical flows it went through.
+func (n *ovn) Trace(inst instance.Instance, trace api.NetworkTracePost) (*api.NetworkTrace, error) {
+	instanceUUID := inst.LocalConfig()["volatile.uuid"]
+
+	sources, err := n.InstanceDevicePortIPs(instanceUUID, trace.Device)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := n.tracePacket(trace, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	hwaddr := inst.ExpandedConfig()[fmt.Sprintf("volatile.%s.hwaddr", trace.Device)]
+	if hwaddr == "" {
+		return nil, fmt.Errorf("Instance NIC %q has no MAC address", trace.Device)
+	}
+
+	// Send the packet to the router unless the destination is on the internal switch.
+	routerMAC, err := n.getRouterMAC()
+	if err != nil {
+		return nil, err
+	}
+
+	dstHwaddr := routerMAC.String()
+	for _, key := range []string{"ipv4.address", "ipv6.address"} {
+		_, subnet, _ := net.ParseCIDR(n.config[key])
+		if subnet == nil || !subnet.Contains(packet.Destination) {
+			continue
+		}
+
+		leases, err := n.Leases(inst.Project().Name, request.ClientTypeNormal)
+		if err != nil {
+			return nil, fmt.Errorf("Failed getting leases: %w", err)
+		}
+
+		for _, lease := range leases {
+			if lease.Hwaddr != "" && net.ParseIP(lease.Address).Equal(packet.Destination) {
+				dstHwaddr = lease.Hwaddr
+				break
+			}
+		}
+	}
+
+	ipFamily := "ip4"
+	icmpEchoRequest := 8
+	if packet.Destination.To4() == nil {
+		ipFamily = "ip6"
+		icmpEchoRequest = 128
+	}
+
+	microflow := []string{
+		fmt.Sprintf("inport == %q", n.getInstanceDevicePortName(instanceUUID, trace.Device)),
+		fmt.Sprintf("eth.src == %s", hwaddr),
+		fmt.Sprintf("eth.dst == %s", dstHwaddr),
+		fmt.Sprintf("%s.src == %s", ipFamily, packet.Source.String()),
+		fmt.Sprintf("%s.dst == %s", ipFamily, packet.Destination.String()),
+		"ip.ttl == 64",
+	}
+
+	switch packet.Protocol {
+	case "tcp", "udp":
+		microflow = append(microflow, fmt.Sprintf("%s.src == %d", packet.Protocol, packet.SourcePort), fmt.Sprintf("%s.dst == %d", packet.Protocol, packet.DestinationPort))
+	case "icmp4", "icmp6":
+		microflow = append(microflow, fmt.Sprintf("%s.type == %d", packet.Protocol, icmpEchoRequest), fmt.Sprintf("%s.code == 0", packet.Protocol))
+	}
+
+	output, err := n.state.OVNSB.Trace(context.TODO(), string(n.getIntSwitchName()), strings.Join(microflow, " && "))
+	if err != nil {
+		return nil, err
+	}
+
+	steps, verdict := ovnTraceParse(output)
+
+	return &api.NetworkTrace{
+		Source:  packet.Source.String(),
+		Verdict: verdict,
+		Steps:   steps,
+	}, nil
+}
+
+// ovnTraceStage matches the logical flow lines of the ovn-trace detailed output.
+var ovnTraceStage = regexp.MustCompile(`^\s*\d+\.\s+(\S+?)(?:\s+\([^)]*\))?:\s+(.*?)(?:, uuid [0-9a-f]+)?$`)
+
+// ovnTraceParse converts the ovn-trace detailed output into steps and returns them along with the verdict.
+func ovnTraceParse(output string) ([]api.NetworkTraceStep, string) {
+	steps := []api.NetworkTraceStep{}
+
+	for _, line := range strings.Split(output, "\n") {
+		match := ovnTraceStage.FindStringSubmatch(line)
+		if match != nil {
+			steps = append(steps, api.NetworkTraceStep{
+				Stage: match[1],
+				Match: match[2],
+			})
+
+			continue
+		}
+
+		// Record the actions of the last logical flow, skipping pipeline headers and comments.
+		action := strings.TrimSpace(line)
+		if len(steps) == 0 || action == "" || !strings.HasPrefix(line, " ") || strings.HasPrefix(action, "---") || strings.HasPrefix(action, "*") || strings.HasPrefix(action, "ingress(") || strings.HasPrefix(action, "egress(") {
+			continue
+		}
+
+		last := &steps[len(steps)-1]
+		if last.Action != "" {
+			last.Action += " "
+		}
+
+		last.Action += action
+	}
+
+	// The packet is dropped unless it's output somewhere.
+	verdict := "drop"
+	for _, step := range steps {
+		if strings.Contains(step.Action, "reject") {
+			return steps, "reject"
+		}
+
+		if strings.Contains(step.Action, "drop;") {
+			return steps, "drop"
+		}
+
+		if strings.Contains(step.Action, "output;") {
+			verdict = "allow"
+		}
+	}
+
+	return steps, verdict
+}
+
 // localPeerCreate creates a network peering with another local network.
 func (n *ovn) localPeerCreate(peer api.NetworkPeersPost) error {
 	ctx := context.TODO()
@@ -5414,10 +5943,53 @@ func (n *ovn) localPeerCreate(peer api.NetworkPeersPost) error {
 		return err
 	}
 
+	// Apply the ACLs of both sides of the peering.
+	var targetPeerConfig map[string]string
+	err = n.state.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		targetPeers, err := tx.GetNetworkPeers(ctx, targetOVNNet.ID())
+		if err != nil {
+			return err
+		}
+
+		for _, targetPeer := range targetPeers {
+			if targetPeer.TargetProject == n.Project() && targetPeer.TargetNetwork == n.Name() {
+				targetPeerConfig = targetPeer.Config
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading target network peer: %w", err)
+	}
+
+	err = n.peerSetupACLs(targetOVNNet, peer.Config)
+	if err != nil {
+		return err
+	}
+
+	err = targetOVNNet.peerSetupACLs(n, targetPeerConfig)
+	if err != nil {
+		return err
+	}
+
 	reverter.Success()
 	return nil
 }
 
+// peerSetupACLs applies the ACLs from the peer config to the traffic arriving from the target network.
+func (n *ovn) peerSetupACLs(targetOVNNet *ovn, peerConfig map[string]string) error {
+	aclNames := util.SplitNTrimSpace(peerConfig["security.acls"], ",", -1, true)
+
+	err := acl.OVNApplyNetworkPeerRules(n.state, n.state.OVNNB, n.Project(), n.ID(), targetOVNNet.ID(), aclNames)
+	if err != nil {
+		return fmt.Errorf("Failed applying network peer ACLs: %w", err)
+	}
+
+	return nil
+}
+
 // remotePeerCreate creates a network peering with an OVN-IC.
 func (n *ovn) remotePeerCreate(peer api.NetworkPeersPost) error {
 	ctx := context.TODO()
@@ -5882,6 +6454,30 @@ func (n *ovn) PeerUpdate(peerName string, req api.NetworkPeerPut) error {
 		return err
 	}
 
+	revert.Add(func() {
+		_ = n.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			return tx.UpdateNetworkPeer(ctx, n.ID(), curPeerID, &curPeer.NetworkPeerPut)
+		})
+	})
+
+	// Apply the ACL changes if the peering is active.
+	if curPeer.Type == "local" && curPeer.Status == api.NetworkStatusCreated && curPeer.Config["security.acls"] != req.Config["security.acls"] {
+		targetNet, err := LoadByName(n.state, curPeer.TargetProject, curPeer.TargetNetwork)
+		if err != nil {
+			return fmt.Errorf("Failed loading target network: %w", err)
+		}
+
+		targetOVNNet, ok := targetNet.(*ovn)
+		if !ok {
+			return fmt.Errorf("Target network is not ovn interface type")
+		}
+
+		err = n.peerSetupACLs(targetOVNNet, req.Config)
+		if err != nil {
+			return err
+		}
+	}
+
 	revert.Success()
 	return nil
 }
@@ -5910,6 +6506,16 @@ func (n *ovn) localPeerDelete(peer *api.NetworkPeer) error {
 		return fmt.Errorf("Failed deleting OVN network peering: %w", err)
 	}
 
+	err = n.peerSetupACLs(targetOVNNet, nil)
+	if err != nil {
+		return err
+	}
+
+	err = targetOVNNet.peerSetupACLs(n, nil)
+	if err != nil {
+		return err
+	}
+
 	err = n.logicalRouterPolicySetup(n.state.OVNNB, targetOVNNet.ID())
 	if err != nil {
 		return fmt.Errorf("Failed applying local router security policy: %w", err)
@@ -0,0 +1,113 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// infoblox allocates addresses as fixed addresses through the Infoblox WAPI.
+// The API URL includes the WAPI version (for example https://gm.example.com/wapi/v2.12).
+type infoblox struct {
+	client *client
+}
+
+type infobloxObject struct {
+	Ref      string `json:"_ref"`
+	IPv4Addr string `json:"ipv4addr"`
+	IPv6Addr string `json:"ipv6addr"`
+}
+
+func newInfoblox(c *client, config map[string]string) (IPAM, error) {
+	if config["ipam.auth.username"] == "" || config["ipam.auth.password"] == "" {
+		return nil, fmt.Errorf("IPAM driver %q requires %q and %q to be set", "infoblox", "ipam.auth.username", "ipam.auth.password")
+	}
+
+	c.username = config["ipam.auth.username"]
+	c.password = config["ipam.auth.password"]
+
+	return &infoblox{client: c}, nil
+}
+
+// object returns the WAPI object type, address field and device identifier used for the subnet's IP family.
+// IPv6 fixed addresses are keyed on a DUID, so a link-layer DUID (DUID-LL) is derived from the MAC address.
+func (i *infoblox) object(req Request) (string, string, url.Values) {
+	if req.Subnet.IP.To4() != nil {
+		return "fixedaddress", "ipv4addr", url.Values{"mac": {req.Hwaddr.String()}}
+	}
+
+	return "ipv6fixedaddress", "ipv6addr", url.Values{"duid": {"00:03:00:01:" + req.Hwaddr.String()}}
+}
+
+// existing returns the fixed addresses of the device within the subnet.
+func (i *infoblox) existing(ctx context.Context, req Request) ([]infobloxObject, error) {
+	objectType, addressField, filters := i.object(req)
+	filters.Set("network", req.Subnet.String())
+	filters.Set("_return_fields", addressField)
+
+	var objects []infobloxObject
+
+	err := i.client.query(ctx, http.MethodGet, objectType, filters, nil, &objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// Allocate returns the fixed address of the device in Infoblox, or creates one with the next available address.
+func (i *infoblox) Allocate(ctx context.Context, req Request) (*Allocation, error) {
+	existing, err := i.existing(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(existing) > 0 {
+		return newAllocation(existing[0].IPv4Addr+existing[0].IPv6Addr, req.Subnet, "")
+	}
+
+	objectType, addressField, device := i.object(req)
+
+	body := map[string]any{
+		addressField: "func:nextavailableip:" + req.Subnet.String(),
+		"name":       req.Hostname,
+		"comment":    req.Description,
+	}
+
+	for k := range device {
+		body[k] = device.Get(k)
+	}
+
+	var object infobloxObject
+
+	err = i.client.query(ctx, http.MethodPost, objectType, url.Values{"_return_fields": {addressField}}, body, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAllocation(object.IPv4Addr+object.IPv6Addr, req.Subnet, "")
+}
+
+// Release deletes the Infoblox fixed address of the device.
+func (i *infoblox) Release(ctx context.Context, req Request, ip net.IP) error {
+	existing, err := i.existing(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range existing {
+		address := net.ParseIP(object.IPv4Addr + object.IPv6Addr)
+		if !address.Equal(ip) {
+			continue
+		}
+
+		err = i.client.query(ctx, http.MethodDelete, object.Ref, nil, nil, nil)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// netbox allocates addresses from the prefixes of a NetBox instance.
+type netbox struct {
+	client *client
+}
+
+type netboxObject struct {
+	ID      int64  `json:"id"`
+	Address string `json:"address"`
+}
+
+type netboxList struct {
+	Count   int            `json:"count"`
+	Results []netboxObject `json:"results"`
+}
+
+func newNetBox(c *client, config map[string]string) (IPAM, error) {
+	if config["ipam.auth.token"] == "" {
+		return nil, fmt.Errorf("IPAM driver %q requires %q to be set", "netbox", "ipam.auth.token")
+	}
+
+	c.header.Set("Authorization", "Token "+config["ipam.auth.token"])
+
+	return &netbox{client: c}, nil
+}
+
+// prefixID returns the ID of the NetBox prefix matching the subnet.
+func (n *netbox) prefixID(ctx context.Context, subnet *net.IPNet) (int64, error) {
+	var prefixes netboxList
+
+	err := n.client.query(ctx, http.MethodGet, "/api/ipam/prefixes/", url.Values{"prefix": {subnet.String()}}, nil, &prefixes)
+	if err != nil {
+		return -1, err
+	}
+
+	if len(prefixes.Results) != 1 {
+		return -1, fmt.Errorf("Expected exactly one NetBox prefix for %q, found %d", subnet.String(), len(prefixes.Results))
+	}
+
+	return prefixes.Results[0].ID, nil
+}
+
+// Allocate returns the NetBox IP address record of the device if its ID is known and the record still exists,
+// or assigns the next available address otherwise. Records are tracked by ID so that renaming the instance or the
+// network doesn't lose track of them.
+func (n *netbox) Allocate(ctx context.Context, req Request) (*Allocation, error) {
+	var address netboxObject
+
+	if req.ID != "" {
+		err := n.client.query(ctx, http.MethodGet, fmt.Sprintf("/api/ipam/ip-addresses/%s/", url.PathEscape(req.ID)), nil, nil, &address)
+		if err == nil {
+			return newAllocation(address.Address, req.Subnet, req.ID)
+		}
+
+		if err != ErrNotFound {
+			return nil, err
+		}
+	}
+
+	prefixID, err := n.prefixID(ctx, req.Subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{
+		"status":      "active",
+		"description": req.Description,
+		"dns_name":    req.Hostname,
+	}
+
+	err = n.client.query(ctx, http.MethodPost, fmt.Sprintf("/api/ipam/prefixes/%d/available-ips/", prefixID), nil, body, &address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAllocation(address.Address, req.Subnet, strconv.FormatInt(address.ID, 10))
+}
+
+// Release deletes the NetBox IP address record of the device.
+func (n *netbox) Release(ctx context.Context, req Request, ip net.IP) error {
+	if req.ID == "" {
+		return nil // Nothing recorded.
+	}
+
+	err := n.client.query(ctx, http.MethodDelete, fmt.Sprintf("/api/ipam/ip-addresses/%s/", url.PathEscape(req.ID)), nil, nil, nil)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	return nil
+}
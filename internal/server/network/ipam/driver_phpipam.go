@@ -0,0 +1,127 @@
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// phpipam allocates addresses from the subnets of a phpIPAM instance.
+// The API URL includes the application ID (for example https://ipam.example.com/api/incus).
+type phpipam struct {
+	client *client
+}
+
+type phpipamResponse struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type phpipamObject struct {
+	ID       json.Number `json:"id"`
+	IP       string      `json:"ip"`
+	MAC      string      `json:"mac"`
+	SubnetID json.Number `json:"subnetId"`
+}
+
+func newPHPIPAM(c *client, config map[string]string) (IPAM, error) {
+	if config["ipam.auth.token"] == "" {
+		return nil, fmt.Errorf("IPAM driver %q requires %q to be set", "phpipam", "ipam.auth.token")
+	}
+
+	c.header.Set("Token", config["ipam.auth.token"])
+
+	return &phpipam{client: c}, nil
+}
+
+// query performs a request against the phpIPAM API and decodes the response data into out (if not nil).
+func (p *phpipam) query(ctx context.Context, method string, path string, in any, out any) error {
+	var resp phpipamResponse
+
+	err := p.client.query(ctx, method, path, nil, in, &resp)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("phpIPAM request failed: %s", resp.Message)
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(resp.Data, out)
+}
+
+// subnetID returns the ID of the phpIPAM subnet matching the subnet.
+func (p *phpipam) subnetID(ctx context.Context, subnet *net.IPNet) (string, error) {
+	var subnets []phpipamObject
+
+	err := p.query(ctx, http.MethodGet, fmt.Sprintf("/subnets/cidr/%s/", subnet.String()), nil, &subnets)
+	if err != nil {
+		return "", err
+	}
+
+	if len(subnets) != 1 {
+		return "", fmt.Errorf("Expected exactly one phpIPAM subnet for %q, found %d", subnet.String(), len(subnets))
+	}
+
+	return subnets[0].ID.String(), nil
+}
+
+// Allocate returns the address recorded for the device in phpIPAM, or assigns the first free one.
+func (p *phpipam) Allocate(ctx context.Context, req Request) (*Allocation, error) {
+	subnetID, err := p.subnetID(ctx, req.Subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []phpipamObject
+
+	err = p.query(ctx, http.MethodGet, fmt.Sprintf("/addresses/search_hostname/%s/", url.PathEscape(req.Hostname)), nil, &existing)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+
+	for _, address := range existing {
+		if address.SubnetID.String() == subnetID && strings.EqualFold(address.MAC, req.Hwaddr.String()) {
+			return newAllocation(address.IP, req.Subnet, "")
+		}
+	}
+
+	body := map[string]any{
+		"hostname":    req.Hostname,
+		"mac":         req.Hwaddr.String(),
+		"description": req.Description,
+	}
+
+	var address string
+
+	err = p.query(ctx, http.MethodPost, fmt.Sprintf("/addresses/first_free/%s/", subnetID), body, &address)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAllocation(address, req.Subnet, "")
+}
+
+// Release deletes the phpIPAM address record of the device.
+func (p *phpipam) Release(ctx context.Context, req Request, ip net.IP) error {
+	subnetID, err := p.subnetID(ctx, req.Subnet)
+	if err != nil {
+		return err
+	}
+
+	err = p.query(ctx, http.MethodDelete, fmt.Sprintf("/addresses/%s/%s/", ip.String(), subnetID), nil, nil)
+	if err != nil && err != ErrNotFound {
+		return err
+	}
+
+	return nil
+}
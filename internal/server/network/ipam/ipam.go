@@ -0,0 +1,220 @@
+package ipam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// ErrNotFound indicates that the requested object doesn't exist in the external IPAM.
+var ErrNotFound = errors.New("Not found in IPAM")
+
+// IPAM represents an external IP address management system that address allocations can be delegated to.
+type IPAM interface {
+	// Allocate returns the address allocated to the device in the request's subnet, allocating a new one if needed.
+	Allocate(ctx context.Context, req Request) (*Allocation, error)
+
+	// Release frees the address allocated to the device in the request's subnet.
+	Release(ctx context.Context, req Request, ip net.IP) error
+}
+
+// Allocation represents an address allocated by the external IPAM.
+type Allocation struct {
+	// IP is the allocated address.
+	IP net.IP
+
+	// ID identifies the allocation within the external IPAM, if the driver keeps track of allocations by ID.
+	ID string
+}
+
+// Request describes the device an address is allocated for.
+type Request struct {
+	// ID identifies the existing allocation of the device within the external IPAM, if known.
+	ID string
+
+	// Subnet is the network subnet the address must be allocated from.
+	Subnet *net.IPNet
+
+	// Hwaddr is the MAC address of the device.
+	Hwaddr net.HardwareAddr
+
+	// Hostname is the DNS name of the device.
+	Hostname string
+
+	// Description identifies the allocation within the external IPAM.
+	Description string
+}
+
+var drivers = map[string]func(c *client, config map[string]string) (IPAM, error){
+	"infoblox": newInfoblox,
+	"netbox":   newNetBox,
+	"phpipam":  newPHPIPAM,
+}
+
+// Drivers returns the names of the supported IPAM drivers.
+func Drivers() []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Load returns the IPAM configured in the network config, or nil if address allocation isn't delegated.
+func Load(config map[string]string) (IPAM, error) {
+	driver := config["ipam.driver"]
+	if driver == "" {
+		return nil, nil
+	}
+
+	driverFunc, ok := drivers[driver]
+	if !ok {
+		return nil, fmt.Errorf("Unknown IPAM driver %q", driver)
+	}
+
+	if config["ipam.api.url"] == "" {
+		return nil, fmt.Errorf("IPAM driver %q requires %q to be set", driver, "ipam.api.url")
+	}
+
+	apiURL, err := url.Parse(config["ipam.api.url"])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid IPAM API URL: %w", err)
+	}
+
+	c := &client{
+		url:    apiURL,
+		header: http.Header{},
+		http:   &http.Client{Timeout: 30 * time.Second},
+	}
+
+	if config["ipam.api.ca_cert"] != "" {
+		tlsConfig, err := localtls.GetTLSConfigMem("", "", config["ipam.api.ca_cert"], "", false)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid IPAM API CA certificate: %w", err)
+		}
+
+		c.http.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return driverFunc(c, config)
+}
+
+// client is a minimal JSON HTTP client shared by the IPAM drivers.
+type client struct {
+	url    *url.URL
+	header http.Header
+	http   *http.Client
+
+	username string
+	password string
+}
+
+// query performs a request against the IPAM API and decodes the JSON response into out (if not nil).
+func (c *client) query(ctx context.Context, method string, path string, values url.Values, in any, out any) error {
+	u := c.url.JoinPath(path)
+	if values != nil {
+		u.RawQuery = values.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("Failed sending request to IPAM: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return fmt.Errorf("Failed reading IPAM response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("IPAM request %s %s failed with status %d: %s", method, u.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return fmt.Errorf("Failed parsing IPAM response: %w", err)
+	}
+
+	return nil
+}
+
+// parseAddress parses an address returned by an IPAM (with or without prefix length) and checks it belongs to the subnet.
+func parseAddress(address string, subnet *net.IPNet) (net.IP, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		var err error
+
+		ip, _, err = net.ParseCIDR(address)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid address %q returned by IPAM", address)
+		}
+	}
+
+	if !subnet.Contains(ip) {
+		return nil, fmt.Errorf("Address %q returned by IPAM isn't part of subnet %q", ip.String(), subnet.String())
+	}
+
+	return ip, nil
+}
+
+// newAllocation returns the allocation of an address returned by an IPAM, checking it belongs to the subnet.
+func newAllocation(address string, subnet *net.IPNet, id string) (*Allocation, error) {
+	ip, err := parseAddress(address, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Allocation{IP: ip, ID: id}, nil
+}
@@ -0,0 +1,104 @@
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	ipam, err := Load(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, ipam)
+
+	_, err = Load(map[string]string{"ipam.driver": "foo", "ipam.api.url": "https://ipam.example.com"})
+	assert.Error(t, err)
+
+	_, err = Load(map[string]string{"ipam.driver": "netbox"})
+	assert.Error(t, err)
+
+	_, err = Load(map[string]string{"ipam.driver": "netbox", "ipam.api.url": "https://ipam.example.com"})
+	assert.Error(t, err)
+
+	_, err = Load(map[string]string{"ipam.driver": "infoblox", "ipam.api.url": "https://ipam.example.com", "ipam.auth.username": "incus"})
+	assert.Error(t, err)
+
+	ipam, err = Load(map[string]string{"ipam.driver": "netbox", "ipam.api.url": "https://ipam.example.com", "ipam.auth.token": "secret"})
+	require.NoError(t, err)
+	assert.NotNil(t, ipam)
+}
+
+func TestNetBox(t *testing.T) {
+	allocated := map[int64]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token secret", r.Header.Get("Authorization"))
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/ipam/prefixes/":
+			assert.Equal(t, "198.51.100.0/24", r.URL.Query().Get("prefix"))
+			_ = json.NewEncoder(w).Encode(netboxList{Count: 1, Results: []netboxObject{{ID: 7}}})
+		case "GET /api/ipam/ip-addresses/1/":
+			address, ok := allocated[1]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(netboxObject{ID: 1, Address: address})
+		case "POST /api/ipam/prefixes/7/available-ips/":
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "c1", body["dns_name"])
+
+			allocated[1] = "198.51.100.2/24"
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(netboxObject{ID: 1, Address: allocated[1]})
+		case "DELETE /api/ipam/ip-addresses/1/":
+			delete(allocated, 1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	ipam, err := Load(map[string]string{"ipam.driver": "netbox", "ipam.api.url": server.URL, "ipam.auth.token": "secret"})
+	require.NoError(t, err)
+
+	_, subnet, _ := net.ParseCIDR("198.51.100.0/24")
+	hwaddr, _ := net.ParseMAC("00:16:3e:00:00:01")
+	req := Request{Subnet: subnet, Hwaddr: hwaddr, Hostname: "c1", Description: "default/c1/eth0"}
+
+	allocation, err := ipam.Allocate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.2", allocation.IP.String())
+	assert.Equal(t, "1", allocation.ID)
+
+	// Allocating again with the recorded ID returns the existing record, even after a rename.
+	req.ID = allocation.ID
+	req.Hostname = "c2"
+	req.Description = "default/c2/eth0"
+	allocation, err = ipam.Allocate(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.2", allocation.IP.String())
+	assert.Equal(t, "1", allocation.ID)
+
+	err = ipam.Release(context.Background(), req, allocation.IP)
+	require.NoError(t, err)
+	assert.Empty(t, allocated)
+
+	// Addresses outside of the subnet are rejected.
+	_, otherSubnet, _ := net.ParseCIDR("203.0.113.0/24")
+	allocated[1] = "198.51.100.2/24"
+	req.Subnet = otherSubnet
+	_, err = ipam.Allocate(context.Background(), req)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/cluster"
 	"github.com/lxc/incus/v6/internal/server/cluster/request"
 	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/instance"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
 )
@@ -52,21 +53,32 @@ type Network interface {
 	Update(newNetwork api.NetworkPut, targetNode string, clientType request.ClientType) error
 	HandleHeartbeat(heartbeatData *cluster.APIHeartbeat) error
 	Delete(clientType request.ClientType) error
+	Recover() error
 	handleDependencyChange(netName string, netConfig map[string]string, changedKeys []string) error
+	SetGatewayChassis(name string) error
 
 	// Status.
 	State() (*api.NetworkState, error)
+	UplinkState() (*api.NetworkStateUplink, error)
 	Leases(projectName string, clientType request.ClientType) ([]api.NetworkLease, error)
+	Trace(inst instance.Instance, trace api.NetworkTracePost) (*api.NetworkTrace, error)
 
 	// Address Forwards.
 	ForwardCreate(forward api.NetworkForwardsPost, clientType request.ClientType) error
 	ForwardUpdate(listenAddress string, newForward api.NetworkForwardPut, clientType request.ClientType) error
 	ForwardDelete(listenAddress string, clientType request.ClientType) error
+	ForwardState(forward api.NetworkForward) (*api.NetworkForwardState, error)
+
+	// DHCP reservations.
+	DHCPReservationCreate(reservation api.NetworkDHCPReservationsPost, clientType request.ClientType) error
+	DHCPReservationUpdate(hwaddr string, newReservation api.NetworkDHCPReservationPut, clientType request.ClientType) error
+	DHCPReservationDelete(hwaddr string, clientType request.ClientType) error
 
 	// Load Balancers.
 	LoadBalancerCreate(loadBalancer api.NetworkLoadBalancersPost, clientType request.ClientType) error
 	LoadBalancerUpdate(listenAddress string, newLoadBalancer api.NetworkLoadBalancerPut, clientType request.ClientType) error
 	LoadBalancerDelete(listenAddress string, clientType request.ClientType) error
+	LoadBalancerState(loadBalancer api.NetworkLoadBalancer) (*api.NetworkLoadBalancerState, error)
 
 	// Peerings.
 	PeerCreate(forward api.NetworkPeersPost) error
@@ -16,6 +16,7 @@ var drivers = map[string]func() Network{
 	"sriov":    func() Network { return &sriov{} },
 	"ovn":      func() Network { return &ovn{} },
 	"physical": func() Network { return &physical{} },
+	"mesh":     func() Network { return &mesh{} },
 }
 
 // ProjectNetwork is a composite type of project name and network name.
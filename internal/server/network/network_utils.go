@@ -7,6 +7,7 @@ import (
 	cryptoRand "crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"net"
@@ -559,6 +560,22 @@ func UpdateDNSMasqStatic(s *state.State, networkName string) error {
 			}
 		}
 
+		// Add the DHCP reservations.
+		var reservations []api.NetworkDHCPReservation
+		err = s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+			reservations, err = tx.GetNetworkDHCPReservations(ctx, n.ID())
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("Failed loading DHCP reservations for network %q: %w", network, err)
+		}
+
+		err = dnsmasq.SyncReservationEntries(network, reservations)
+		if err != nil {
+			return err
+		}
+
 		// Signal dnsmasq.
 		err = dnsmasq.Kill(network, true)
 		if err != nil {
@@ -1053,6 +1070,29 @@ func parseIPRanges(ipRangesList string, allowedNets ...*net.IPNet) ([]*iprange.R
 	return netIPRanges, nil
 }
 
+// ipRangeSize returns the number of addresses in the IP range, capped to the largest uint64.
+func ipRangeSize(ipRange *iprange.Range) uint64 {
+	if ipRange.End == nil {
+		return 1
+	}
+
+	start := big.NewInt(0).SetBytes(ipRange.Start.To16())
+	end := big.NewInt(0).SetBytes(ipRange.End.To16())
+
+	size := big.NewInt(0).Sub(end, start)
+	size.Add(size, big.NewInt(1))
+
+	if size.Sign() <= 0 {
+		return 0
+	}
+
+	if !size.IsUint64() {
+		return math.MaxUint64
+	}
+
+	return size.Uint64()
+}
+
 // VLANInterfaceCreate creates a VLAN interface on parent interface (if needed).
 // Returns boolean indicating if VLAN interface was created.
 func VLANInterfaceCreate(parent string, vlanDevice string, vlanID string, gvrp bool) (bool, error) {
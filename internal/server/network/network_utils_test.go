@@ -137,3 +137,28 @@ func Example_ipRangesOverlap() {
 	// Range1: 10.1.1.4, Range2: 10.1.1.8-10.1.1.9, overlapped: false
 	// Range1: 10.1.1.8-10.1.1.9, Range2: 10.1.1.4, overlapped: false
 }
+
+func Example_ipRangeSize() {
+	ipRanges := []string{
+		"10.1.1.1-10.1.1.1",
+		"10.1.1.1-10.1.1.100",
+		"10.1.0.0-10.1.255.255",
+		"fd22:c952:653e::1-fd22:c952:653e::ffff",
+		"fd22::-fd22:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+	}
+
+	for _, ipRange := range ipRanges {
+		r, _ := parseIPRange(ipRange)
+		fmt.Printf("Range: %v, size: %d\n", r, ipRangeSize(r))
+	}
+
+	fmt.Printf("Range: 10.1.1.4, size: %d\n", ipRangeSize(&iprange.Range{Start: net.ParseIP("10.1.1.4")}))
+
+	// Output:
+	// Range: 10.1.1.1-10.1.1.1, size: 1
+	// Range: 10.1.1.1-10.1.1.100, size: 100
+	// Range: 10.1.0.0-10.1.255.255, size: 65536
+	// Range: fd22:c952:653e::1-fd22:c952:653e::ffff, size: 65535
+	// Range: fd22::-fd22:ffff:ffff:ffff:ffff:ffff:ffff:ffff, size: 18446744073709551615
+	// Range: 10.1.1.4, size: 1
+}
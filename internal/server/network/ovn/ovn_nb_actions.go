@@ -78,6 +78,8 @@ const ovnExtIDIncusSwitchPort = "incus_switch_port"
 const ovnExtIDIncusProjectID = "incus_project_id"
 const ovnExtIDIncusPortGroup = "incus_port_group"
 const ovnExtIDIncusLocation = "incus_location"
+const ovnExtIDIncusPeer = "incus_peer"
+const ovnExtIDIncusRecovery = "incus_recovery"
 
 // OVNIPv6RAOpts IPv6 router advertisements options that can be applied to a router.
 type OVNIPv6RAOpts struct {
@@ -139,8 +141,9 @@ type OVNACLRule struct {
 
 // OVNLoadBalancerTarget represents an OVN load balancer Virtual IP target.
 type OVNLoadBalancerTarget struct {
-	Address net.IP
-	Port    uint64
+	Address    net.IP
+	Port       uint64
+	SwitchPort OVNSwitchPort // Logical switch port owning the address, needed for health checks.
 }
 
 // OVNLoadBalancerVIP represents a OVN load balancer Virtual IP entry.
@@ -149,6 +152,16 @@ type OVNLoadBalancerVIP struct {
 	ListenAddress net.IP
 	ListenPort    uint64
 	Targets       []OVNLoadBalancerTarget
+	HealthCheck   *OVNLoadBalancerHealthCheck // Only applies to port based VIPs.
+}
+
+// OVNLoadBalancerHealthCheck represents the health check settings of a OVN load balancer Virtual IP entry.
+type OVNLoadBalancerHealthCheck struct {
+	SourceAddress net.IP // Address the health checks are sent from.
+	Interval      uint64
+	Timeout       uint64
+	SuccessCount  uint64
+	FailureCount  uint64
 }
 
 // OVNRouterRoute represents a static route added to a logical router.
@@ -1135,6 +1148,64 @@ func (o *NB) UpdateLogicalSwitchDHCPv4Revervations(ctx context.Context, switchNa
 	return nil
 }
 
+// UpdateLogicalSwitchRecoveryHint stores the recovery hint on the logical switch.
+// The hint allows for the network to be recovered if the database is lost.
+func (o *NB) UpdateLogicalSwitchRecoveryHint(ctx context.Context, switchName OVNSwitch, hint string) error {
+	// Get the logical switch.
+	logicalSwitch, err := o.GetLogicalSwitch(ctx, switchName)
+	if err != nil {
+		return err
+	}
+
+	// Check if anything changed.
+	if logicalSwitch.ExternalIDs[ovnExtIDIncusRecovery] == hint {
+		return nil
+	}
+
+	if logicalSwitch.ExternalIDs == nil {
+		logicalSwitch.ExternalIDs = map[string]string{}
+	}
+
+	logicalSwitch.ExternalIDs[ovnExtIDIncusRecovery] = hint
+
+	operations, err := o.client.Where(logicalSwitch).Update(logicalSwitch)
+	if err != nil {
+		return err
+	}
+
+	// Apply the database changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetLogicalSwitchRecoveryHints returns the recovery hints of all logical switches which have one.
+func (o *NB) GetLogicalSwitchRecoveryHints(ctx context.Context) (map[OVNSwitch]string, error) {
+	logicalSwitches := []ovnNB.LogicalSwitch{}
+
+	err := o.client.WhereCache(func(ls *ovnNB.LogicalSwitch) bool {
+		return ls.ExternalIDs != nil && ls.ExternalIDs[ovnExtIDIncusRecovery] != ""
+	}).List(ctx, &logicalSwitches)
+	if err != nil {
+		return nil, err
+	}
+
+	hints := make(map[OVNSwitch]string, len(logicalSwitches))
+	for _, logicalSwitch := range logicalSwitches {
+		hints[OVNSwitch(logicalSwitch.Name)] = logicalSwitch.ExternalIDs[ovnExtIDIncusRecovery]
+	}
+
+	return hints, nil
+}
+
 // GetLogicalSwitchDHCPv4Revervations gets the DHCPv4 IP reservations.
 func (o *NB) GetLogicalSwitchDHCPv4Revervations(ctx context.Context, switchName OVNSwitch) ([]iprange.Range, error) {
 	// Get the logical switch.
@@ -1781,6 +1852,24 @@ func (o *NB) GetLogicalSwitchPortLocation(ctx context.Context, portName OVNSwitc
 	return val, nil
 }
 
+// GetLogicalSwitchPortState returns whether a logical switch port is enabled and whether it's reported as up.
+func (o *NB) GetLogicalSwitchPortState(ctx context.Context, portName OVNSwitchPort) (bool, bool, error) {
+	lsp := ovnNB.LogicalSwitchPort{
+		Name: string(portName),
+	}
+
+	err := o.get(ctx, &lsp)
+	if err != nil {
+		return false, false, err
+	}
+
+	// Ports are enabled unless explicitly disabled.
+	enabled := lsp.Enabled == nil || *lsp.Enabled
+	up := lsp.Up != nil && *lsp.Up
+
+	return enabled, up, nil
+}
+
 // UpdateLogicalSwitchPortOptions sets the options for a logical switch port.
 func (o *NB) UpdateLogicalSwitchPortOptions(ctx context.Context, portName OVNSwitchPort, options map[string]string) error {
 	// Get the logical switch port.
@@ -2322,6 +2411,32 @@ func (o *NB) DeleteChassisGroup(ctx context.Context, haChassisGroupName OVNChass
 	return nil
 }
 
+// GetChassisGroupPriorities returns the priority of each chassis in the chassis group, indexed by chassis name.
+func (o *NB) GetChassisGroupPriorities(ctx context.Context, haChassisGroupName OVNChassisGroup) (map[string]int, error) {
+	// Get the chassis group.
+	haGroup := ovnNB.HAChassisGroup{
+		Name: string(haChassisGroupName),
+	}
+
+	err := o.get(ctx, &haGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := make(map[string]int, len(haGroup.HaChassis))
+	for _, entry := range haGroup.HaChassis {
+		chassis := ovnNB.HAChassis{UUID: entry}
+		err = o.get(ctx, &chassis)
+		if err != nil {
+			return nil, err
+		}
+
+		priorities[chassis.ChassisName] = chassis.Priority
+	}
+
+	return priorities, nil
+}
+
 // SetChassisGroupPriority sets a given priority for the chassis ID in the chassis group..
 func (o *NB) SetChassisGroupPriority(ctx context.Context, haChassisGroupName OVNChassisGroup, chassisID string, priority int) error {
 	operations := []ovsdb.Operation{}
@@ -2566,6 +2681,67 @@ func (o *NB) GetPortGroupsByProject(ctx context.Context, projectID int64) ([]OVN
 	return pgNames, nil
 }
 
+// UpdatePortGroupRecoveryHint stores the recovery hint on the port group.
+// The hint allows for the object the port group was created for to be recovered if the database is lost.
+func (o *NB) UpdatePortGroupRecoveryHint(ctx context.Context, portGroupName OVNPortGroup, hint string) error {
+	pg := &ovnNB.PortGroup{
+		Name: string(portGroupName),
+	}
+
+	err := o.get(ctx, pg)
+	if err != nil {
+		return err
+	}
+
+	// Check if anything changed.
+	if pg.ExternalIDs[ovnExtIDIncusRecovery] == hint {
+		return nil
+	}
+
+	if pg.ExternalIDs == nil {
+		pg.ExternalIDs = map[string]string{}
+	}
+
+	pg.ExternalIDs[ovnExtIDIncusRecovery] = hint
+
+	operations, err := o.client.Where(pg).Update(pg)
+	if err != nil {
+		return err
+	}
+
+	// Apply the database changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetPortGroupRecoveryHints returns the recovery hints of all port groups which have one.
+func (o *NB) GetPortGroupRecoveryHints(ctx context.Context) (map[OVNPortGroup]string, error) {
+	portGroups := []ovnNB.PortGroup{}
+
+	err := o.client.WhereCache(func(pg *ovnNB.PortGroup) bool {
+		return pg.ExternalIDs != nil && pg.ExternalIDs[ovnExtIDIncusRecovery] != ""
+	}).List(ctx, &portGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	hints := make(map[OVNPortGroup]string, len(portGroups))
+	for _, portGroup := range portGroups {
+		hints[OVNPortGroup(portGroup.Name)] = portGroup.ExternalIDs[ovnExtIDIncusRecovery]
+	}
+
+	return hints, nil
+}
+
 // UpdatePortGroupMembers adds/removes logical switch ports (by UUID) to/from existing port groups.
 func (o *NB) UpdatePortGroupMembers(ctx context.Context, addMembers map[OVNPortGroup][]OVNSwitchPortUUID, removeMembers map[OVNPortGroup][]OVNSwitchPortUUID) error {
 	operations := []ovsdb.Operation{}
@@ -2894,6 +3070,79 @@ func (o *NB) ClearPortGroupPortACLRules(ctx context.Context, portGroupName OVNPo
 	return nil
 }
 
+// portGroupPeerACLRules returns the ACL rule UUIDs belonging to a network peer in the specified port group.
+func (o *NB) portGroupPeerACLRules(ctx context.Context, portGroupName OVNPortGroup, peerAddressSet OVNAddressSet) ([]string, error) {
+	acls := []ovnNB.ACL{}
+
+	err := o.client.WhereCache(func(acl *ovnNB.ACL) bool {
+		return acl.ExternalIDs != nil && acl.ExternalIDs[ovnExtIDIncusPortGroup] == string(portGroupName) && acl.ExternalIDs[ovnExtIDIncusPeer] == string(peerAddressSet)
+	}).List(ctx, &acls)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleUUIDs := []string{}
+	for _, acl := range acls {
+		ruleUUIDs = append(ruleUUIDs, acl.UUID)
+	}
+
+	return ruleUUIDs, nil
+}
+
+// UpdatePortGroupPeerACLRules applies a set of rules for the network peer identified by its address set in the
+// specified port group. Any existing rules for that peer in the port group are removed.
+func (o *NB) UpdatePortGroupPeerACLRules(ctx context.Context, portGroupName OVNPortGroup, peerAddressSet OVNAddressSet, aclRules ...OVNACLRule) error {
+	operations := []ovsdb.Operation{}
+
+	// Remove any existing rules assigned to the peer.
+	removeACLRuleUUIDs, err := o.portGroupPeerACLRules(ctx, portGroupName, peerAddressSet)
+	if err != nil {
+		return err
+	}
+
+	deleteOps, err := o.aclRuleDeleteOperations(ctx, "port_group", string(portGroupName), removeACLRuleUUIDs)
+	if err != nil {
+		return err
+	}
+
+	operations = append(operations, deleteOps...)
+
+	// Add new rules.
+	externalIDs := map[string]string{
+		ovnExtIDIncusPortGroup: string(portGroupName),
+		ovnExtIDIncusPeer:      string(peerAddressSet),
+	}
+
+	createOps, err := o.aclRuleAddOperations(ctx, "port_group", string(portGroupName), externalIDs, nil, aclRules...)
+	if err != nil {
+		return err
+	}
+
+	operations = append(operations, createOps...)
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	// Apply the changes.
+	resp, err := o.client.Transact(ctx, operations...)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovsdb.CheckOperationResults(resp, operations)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ClearPortGroupPeerACLRules clears any rules assigned to the network peer in the specified port group.
+func (o *NB) ClearPortGroupPeerACLRules(ctx context.Context, portGroupName OVNPortGroup, peerAddressSet OVNAddressSet) error {
+	return o.UpdatePortGroupPeerACLRules(ctx, portGroupName, peerAddressSet)
+}
+
 // CreateLoadBalancer creates a new load balancer (if doesn't exist) on the specified routers and switches.
 // Providing an empty set of vips will delete the load balancer.
 func (o *NB) CreateLoadBalancer(ctx context.Context, loadBalancerName OVNLoadBalancer, routers []OVNRouter, switches []OVNSwitch, vips ...OVNLoadBalancerVIP) error {
@@ -2944,6 +3193,7 @@ func (o *NB) CreateLoadBalancer(ctx context.Context, loadBalancerName OVNLoadBal
 	}
 
 	// Build up the commands to add VIPs to the load balancer.
+	healthChecks := []*ovnNB.LoadBalancerHealthCheck{}
 	for _, r := range vips {
 		if r.ListenAddress == nil {
 			return fmt.Errorf("Missing VIP listen address")
@@ -2988,7 +3238,47 @@ func (o *NB) CreateLoadBalancer(ctx context.Context, loadBalancerName OVNLoadBal
 			}
 
 			lb.Vips[listenAddress] = strings.Join(targetAddresses, ",")
+
+			// Add the health check, targets failing it are removed from the VIP by OVN.
+			if r.HealthCheck != nil && r.ListenPort > 0 {
+				healthCheck := &ovnNB.LoadBalancerHealthCheck{
+					UUID: fmt.Sprintf("hc%d", len(healthChecks)),
+					Vip:  listenAddress,
+					Options: map[string]string{
+						"interval":      fmt.Sprintf("%d", r.HealthCheck.Interval),
+						"timeout":       fmt.Sprintf("%d", r.HealthCheck.Timeout),
+						"success_count": fmt.Sprintf("%d", r.HealthCheck.SuccessCount),
+						"failure_count": fmt.Sprintf("%d", r.HealthCheck.FailureCount),
+					},
+				}
+
+				healthChecks = append(healthChecks, healthCheck)
+				lb.HealthCheck = append(lb.HealthCheck, healthCheck.UUID)
+
+				// OVN needs to know which port each target is behind and which address to check it from.
+				for _, target := range r.Targets {
+					if target.SwitchPort == "" || r.HealthCheck.SourceAddress == nil {
+						continue
+					}
+
+					if lb.IPPortMappings == nil {
+						lb.IPPortMappings = map[string]string{}
+					}
+
+					lb.IPPortMappings[ipToString(target.Address)] = fmt.Sprintf("%s:%s", target.SwitchPort, ipToString(r.HealthCheck.SourceAddress))
+				}
+			}
+		}
+	}
+
+	// Create the health checks.
+	for _, healthCheck := range healthChecks {
+		createOps, err := o.client.Create(healthCheck)
+		if err != nil {
+			return err
 		}
+
+		operations = append(operations, createOps...)
 	}
 
 	// Create any used load-balancer.
@@ -3,8 +3,13 @@ package ovn
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 
 	ovnSB "github.com/lxc/incus/v6/internal/server/network/ovn/schema/ovn-sb"
+	"github.com/lxc/incus/v6/shared/subprocess"
 )
 
 // GetLogicalRouterPortActiveChassisHostname gets the hostname of the chassis managing the logical router port.
@@ -35,3 +40,124 @@ func (o *SB) GetLogicalRouterPortActiveChassisHostname(ctx context.Context, ovnR
 
 	return chassis.Hostname, nil
 }
+
+// GetChassisHostnames returns the hostnames of all the chassis, indexed by chassis name.
+func (o *SB) GetChassisHostnames(ctx context.Context) (map[string]string, error) {
+	chassis := []ovnSB.Chassis{}
+
+	err := o.client.List(ctx, &chassis)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := make(map[string]string, len(chassis))
+	for _, entry := range chassis {
+		hostnames[entry.Name] = entry.Hostname
+	}
+
+	return hostnames, nil
+}
+
+// GetLogicalSwitchPortChassisHostname gets the hostname of the chassis a logical switch port is bound to.
+func (o *SB) GetLogicalSwitchPortChassisHostname(ctx context.Context, ovnSwitchPort OVNSwitchPort) (string, error) {
+	// Look for the port binding.
+	pb := &ovnSB.PortBinding{
+		LogicalPort: string(ovnSwitchPort),
+	}
+
+	err := o.client.Get(ctx, pb)
+	if err != nil {
+		return "", err
+	}
+
+	if pb.Chassis == nil {
+		return "", nil
+	}
+
+	// Get the associated chassis.
+	chassis := &ovnSB.Chassis{
+		UUID: *pb.Chassis,
+	}
+
+	err = o.client.Get(ctx, chassis)
+	if err != nil {
+		return "", err
+	}
+
+	return chassis.Hostname, nil
+}
+
+// GetServiceMonitorStatus returns the health check status ("online", "offline" or "error") of a load balancer
+// target behind the given logical switch port. An empty string is returned if OVN hasn't checked the target yet.
+func (o *SB) GetServiceMonitorStatus(ctx context.Context, logicalPort OVNSwitchPort, address net.IP, protocol string, port uint64) (string, error) {
+	monitors := []ovnSB.ServiceMonitor{}
+
+	err := o.client.WhereCache(func(sm *ovnSB.ServiceMonitor) bool {
+		if sm.LogicalPort != string(logicalPort) {
+			return false
+		}
+
+		if sm.Protocol != nil && *sm.Protocol != protocol {
+			return false
+		}
+
+		return net.ParseIP(sm.IP).Equal(address) && sm.Port == int(port)
+	}).List(ctx, &monitors)
+	if err != nil {
+		return "", err
+	}
+
+	for _, monitor := range monitors {
+		if monitor.Status != nil {
+			return *monitor.Status, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Trace runs ovn-trace for the microflow in the logical datapath and returns its detailed output.
+func (o *SB) Trace(ctx context.Context, datapath string, microflow string) (string, error) {
+	args := []string{"--db", o.dbAddr}
+
+	if strings.Contains(o.dbAddr, "ssl:") {
+		// ovn-trace only takes the certificates as files.
+		tmpDir, err := os.MkdirTemp("", "incus_ovn_trace_")
+		if err != nil {
+			return "", err
+		}
+
+		defer func() { _ = os.RemoveAll(tmpDir) }()
+
+		files := map[string]string{
+			"--private-key": o.sslClientKey,
+			"--certificate": o.sslClientCert,
+			"--ca-cert":     o.sslCACert,
+		}
+
+		for arg, content := range files {
+			if content == "" {
+				args = append(args, fmt.Sprintf("%s=none", arg))
+				continue
+			}
+
+			path := filepath.Join(tmpDir, strings.TrimPrefix(arg, "--"))
+
+			err = os.WriteFile(path, []byte(content), 0600)
+			if err != nil {
+				return "", err
+			}
+
+			args = append(args, fmt.Sprintf("%s=%s", arg, path))
+		}
+	}
+
+	args = append(args, "--detailed", datapath, microflow)
+
+	output, err := subprocess.RunCommandContext(ctx, "ovn-trace", args...)
+	if err != nil {
+		return "", fmt.Errorf("Failed running ovn-trace: %w", err)
+	}
+
+	return output, nil
+}
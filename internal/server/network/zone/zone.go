@@ -479,10 +479,44 @@ func (d *zone) Content() (*strings.Builder, error) {
 					return nil, err
 				}
 
+				// With split-horizon DNS, external clients get the network forward listen addresses.
+				listenAddresses := map[string][]string{}
+				if util.IsTrue(netConfig["dns.split_horizon"]) {
+					listenAddresses, err = d.forwardListenAddresses(n.ID())
+					if err != nil {
+						return nil, err
+					}
+				}
+
 				// Convert leases to usable records.
+				seen := map[string]bool{}
 				for _, lease := range leases {
 					ip := net.ParseIP(lease.Address)
 
+					if len(listenAddresses[ip.String()]) > 0 {
+						for _, listenAddress := range listenAddresses[ip.String()] {
+							if seen[lease.Hostname+"/"+listenAddress] {
+								continue
+							}
+
+							seen[lease.Hostname+"/"+listenAddress] = true
+
+							recordType := "A"
+							if net.ParseIP(listenAddress).To4() == nil {
+								recordType = "AAAA"
+							}
+
+							records = append(records, map[string]string{
+								"ttl":   "300",
+								"type":  recordType,
+								"name":  lease.Hostname,
+								"value": listenAddress,
+							})
+						}
+
+						continue
+					}
+
 					// Get the record.
 					record := genRecord(lease.Hostname, ip)
 					if record == nil {
@@ -550,6 +584,46 @@ func (d *zone) Content() (*strings.Builder, error) {
 	return sb, nil
 }
 
+// forwardListenAddresses returns the network forward listen addresses indexed by target address.
+func (d *zone) forwardListenAddresses(networkID int64) (map[string][]string, error) {
+	var forwards map[int64]*api.NetworkForward
+	err := d.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		forwards, err = tx.GetNetworkForwards(ctx, networkID, false)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading network forwards: %w", err)
+	}
+
+	listenAddresses := map[string][]string{}
+	addAddress := func(targetAddress string, listenAddress string) {
+		targetIP := net.ParseIP(targetAddress)
+		if targetIP == nil || slices.Contains(listenAddresses[targetIP.String()], listenAddress) {
+			return
+		}
+
+		listenAddresses[targetIP.String()] = append(listenAddresses[targetIP.String()], listenAddress)
+	}
+
+	for _, forward := range forwards {
+		addAddress(forward.Config["target_address"], forward.ListenAddress)
+
+		for _, port := range forward.Ports {
+			addAddress(port.TargetAddress, forward.ListenAddress)
+		}
+	}
+
+	// Keep the generated records stable.
+	for _, addresses := range listenAddresses {
+		slices.Sort(addresses)
+	}
+
+	return listenAddresses, nil
+}
+
 // SOA returns just the DNS zone SOA record.
 func (d *zone) SOA() (*strings.Builder, error) {
 	// Get the nameservers.
@@ -8,6 +8,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/config"
 	"github.com/lxc/incus/v6/internal/server/db"
 	internalUtil "github.com/lxc/incus/v6/internal/util"
+	"github.com/lxc/incus/v6/shared/util"
 	"github.com/lxc/incus/v6/shared/validate"
 )
 
@@ -80,6 +81,21 @@ func (c *Config) DNSAddress() string {
 	return c.m.GetString("core.dns_address")
 }
 
+// LogLevel returns the log level of the daemon, or an empty string to use the command line flags.
+func (c *Config) LogLevel() string {
+	return c.m.GetString("core.log_level")
+}
+
+// LogSyslog returns true if the daemon should log to syslog regardless of the command line flags.
+func (c *Config) LogSyslog() bool {
+	return c.m.GetBool("core.log_syslog")
+}
+
+// LogDebug returns the list of subsystems for which additional debugging information is logged.
+func (c *Config) LogDebug() []string {
+	return util.SplitNTrimSpace(c.m.GetString("core.log_debug"), ",", -1, true)
+}
+
 // MetricsAddress returns the address and port to setup the metrics listener on.
 func (c *Config) MetricsAddress() string {
 	metricsAddress := c.m.GetString("core.metrics_address")
@@ -212,6 +228,39 @@ var ConfigSchema = config.Schema{
 	//  shortdesc: Address to bind the authoritative DNS server to
 	"core.dns_address": {Validator: validate.Optional(validate.IsListenAddress(true, true, false))},
 
+	// Logging
+
+	// gendoc:generate(entity=server, group=core, key=core.log_level)
+	// Possible values are `warning`, `info` and `debug`.
+	// If not set, the level is determined by the `--verbose` and `--debug` command line flags.
+	// Changes apply immediately, without restarting the daemon.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Log level of the daemon
+	"core.log_level": {Validator: validate.Optional(validate.IsOneOf("warning", "info", "debug"))},
+
+	// gendoc:generate(entity=server, group=core, key=core.log_syslog)
+	// Set this option to `true` to send the daemon log messages to syslog, as with the `--syslog` command line flag.
+	// Changes apply immediately, without restarting the daemon.
+	// ---
+	//  type: bool
+	//  scope: local
+	//  defaultdesc: `false`
+	//  shortdesc: Whether to log to syslog
+	"core.log_syslog": {Validator: validate.Optional(validate.IsBool), Type: config.Bool},
+
+	// gendoc:generate(entity=server, group=core, key=core.log_debug)
+	// Comma-separated list of subsystems for which additional debugging information is logged.
+	// Possible values are `api` (full API responses) and `rsync` (verbose `rsync` transfers).
+	// Those are enabled by default when the daemon runs with the `--debug` command line flag.
+	// Changes apply immediately, without restarting the daemon.
+	// ---
+	//  type: string
+	//  scope: local
+	//  shortdesc: Subsystems to debug
+	"core.log_debug": {Validator: validate.Optional(validate.IsListOf(validate.IsOneOf("api", "rsync")))},
+
 	// Network address for the metrics server
 
 	// gendoc:generate(entity=server, group=core, key=core.metrics_address)
@@ -0,0 +1,198 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lxc/incus/v6/internal/server/db/operationtype"
+	"github.com/lxc/incus/v6/shared/logger"
+)
+
+// limitGroups maps the heavy operation types to the group their concurrency is limited by.
+var limitGroups = map[operationtype.Type]string{
+	operationtype.BackupCreate:             "backups",
+	operationtype.BucketBackupCreate:       "backups",
+	operationtype.CustomVolumeBackupCreate: "backups",
+	operationtype.ImageDownload:            "image_downloads",
+	operationtype.InstanceLiveMigrate:      "migrations",
+	operationtype.InstanceMigrate:          "migrations",
+	operationtype.VolumeMigrate:            "migrations",
+}
+
+// errLimitAborted is returned when an operation stops waiting for a slot.
+var errLimitAborted = errors.New("Operation aborted while queued")
+
+// queuedOperation is an operation waiting for the concurrency limits of its group to allow it to run.
+type queuedOperation struct {
+	op           *Operation
+	group        string
+	memberLimit  int64
+	projectLimit int64
+	ready        chan struct{}
+}
+
+// limitQueue tracks the running operations of the limited groups and the operations waiting to run.
+type limitQueue struct {
+	lock    sync.Mutex
+	running map[string]int64 // Keyed by group and by group and project.
+	queued  []*queuedOperation
+}
+
+var limits = limitQueue{running: map[string]int64{}}
+
+// allowed returns whether the queued operation can run. Must be called with the lock held.
+func (q *limitQueue) allowed(qo *queuedOperation) bool {
+	if qo.memberLimit > 0 && q.running[qo.group] >= qo.memberLimit {
+		return false
+	}
+
+	if qo.projectLimit > 0 && q.running[qo.group+"/"+qo.op.projectName] >= qo.projectLimit {
+		return false
+	}
+
+	return true
+}
+
+// schedule starts the queued operations that the limits allow, in order, and returns the queue position of the
+// remaining ones within their group. Must be called with the lock held.
+func (q *limitQueue) schedule() map[*Operation]int {
+	positions := map[*Operation]int{}
+	groupPositions := map[string]int{}
+
+	queued := q.queued[:0]
+	for _, qo := range q.queued {
+		if q.allowed(qo) {
+			q.running[qo.group]++
+			q.running[qo.group+"/"+qo.op.projectName]++
+			close(qo.ready)
+			continue
+		}
+
+		groupPositions[qo.group]++
+		positions[qo.op] = groupPositions[qo.group]
+		queued = append(queued, qo)
+	}
+
+	q.queued = queued
+
+	return positions
+}
+
+// release frees the slot of a running operation of the group and starts the queued operations it unblocks.
+func (q *limitQueue) release(op *Operation, group string) {
+	q.lock.Lock()
+	q.running[group]--
+	q.running[group+"/"+op.projectName]--
+	positions := q.schedule()
+	q.lock.Unlock()
+
+	for queuedOp, position := range positions {
+		queuedOp.setQueuePosition(position)
+	}
+}
+
+// remove removes the operation from the queue, returning false if it was already started.
+func (q *limitQueue) remove(qo *queuedOperation) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for i, entry := range q.queued {
+		if entry == qo {
+			q.queued = append(q.queued[:i], q.queued[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitLimits waits until the concurrency limits of the operation's group allow it to run, recording its position
+// in the queue in the operation metadata meanwhile. The returned function must be called once the operation is done.
+func (op *Operation) waitLimits() (func(), error) {
+	group := limitGroups[op.dbOpType]
+	if group == "" || op.state == nil {
+		return func() {}, nil
+	}
+
+	memberLimit, projectLimit, err := operationsLimits(op, group)
+	if err != nil {
+		return nil, fmt.Errorf("Failed getting the operations limits: %w", err)
+	}
+
+	qo := &queuedOperation{
+		op:           op,
+		group:        group,
+		memberLimit:  memberLimit,
+		projectLimit: projectLimit,
+		ready:        make(chan struct{}),
+	}
+
+	limits.lock.Lock()
+	limits.queued = append(limits.queued, qo)
+	positions := limits.schedule()
+	limits.lock.Unlock()
+
+	release := func() { limits.release(op, group) }
+
+	position, isQueued := positions[op]
+	if !isQueued {
+		return release, nil
+	}
+
+	op.logger.Debug("Queued operation", logger.Ctx{"group": group, "position": position})
+	op.setQueuePosition(position)
+
+	select {
+	case <-qo.ready:
+	case <-op.finished.Done():
+	case <-op.state.ShutdownCtx.Done():
+	}
+
+	// Give up on the slot if the operation didn't get one.
+	select {
+	case <-qo.ready:
+	default:
+		if !limits.remove(qo) {
+			// The operation got a slot concurrently.
+			release()
+		}
+
+		if op.state.ShutdownCtx.Err() != nil {
+			return nil, fmt.Errorf("Incus is shutting down")
+		}
+
+		return nil, errLimitAborted
+	}
+
+	op.setQueuePosition(0)
+
+	return release, nil
+}
+
+// setQueuePosition records the position of the operation in the queue of its group, 0 removing it.
+func (op *Operation) setQueuePosition(position int) {
+	op.lock.Lock()
+	if op.readonly {
+		op.lock.Unlock()
+		return
+	}
+
+	if position > 0 {
+		if op.metadata == nil {
+			op.metadata = map[string]any{}
+		}
+
+		op.metadata["queue_position"] = position
+	} else {
+		delete(op.metadata, "queue_position")
+	}
+
+	op.lock.Unlock()
+
+	_, md, _ := op.Render()
+
+	op.lock.Lock()
+	op.sendEvent(md)
+	op.lock.Unlock()
+}
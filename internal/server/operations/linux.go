@@ -5,6 +5,7 @@ package operations
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/lxc/incus/v6/internal/server/db"
 	"github.com/lxc/incus/v6/internal/server/db/cluster"
@@ -55,6 +56,51 @@ func removeDBOperation(op *Operation) error {
 	return err
 }
 
+// operationsLimits returns the maximum number of concurrent operations of the group on this server and in the
+// operation's project, 0 meaning no limit.
+func operationsLimits(op *Operation, group string) (int64, int64, error) {
+	var memberLimit int64
+	if op.state.GlobalConfig != nil {
+		memberLimit = op.state.GlobalConfig.OperationsLimit(group)
+	}
+
+	if op.projectName == "" {
+		return memberLimit, 0, nil
+	}
+
+	var value string
+
+	err := op.state.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		projectID, err := cluster.GetProjectID(ctx, tx.Tx(), op.projectName)
+		if err != nil {
+			return err
+		}
+
+		config, err := cluster.GetProjectConfig(ctx, tx.Tx(), int(projectID))
+		if err != nil {
+			return err
+		}
+
+		value = config["limits.operations."+group]
+
+		return nil
+	})
+	if err != nil {
+		return -1, -1, err
+	}
+
+	if value == "" {
+		return memberLimit, 0, nil
+	}
+
+	projectLimit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return memberLimit, projectLimit, nil
+}
+
 func (op *Operation) sendEvent(eventMessage any) {
 	if op.events == nil {
 		return
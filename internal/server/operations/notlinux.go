@@ -32,3 +32,7 @@ func (op *Operation) sendEvent(eventMessage any) {
 
 	op.events.Send(op.projectName, api.EventTypeOperation, eventMessage)
 }
+
+func operationsLimits(op *Operation, group string) (int64, int64, error) {
+	return 0, 0, nil
+}
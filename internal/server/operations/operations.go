@@ -2,6 +2,7 @@ package operations
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -26,6 +27,12 @@ var debug bool
 var operationsLock sync.Mutex
 var operations = make(map[string]*Operation)
 
+// recentFailuresMax is the number of failed operations remembered after their removal.
+const recentFailuresMax = 50
+
+var recentFailuresLock sync.Mutex
+var recentFailures []api.Operation
+
 // OperationClass represents the OperationClass type.
 type OperationClass int
 
@@ -74,6 +81,25 @@ func Clone() map[string]*Operation {
 	return localOperations
 }
 
+// RecentFailures returns the most recently failed operations, oldest first.
+func RecentFailures() []api.Operation {
+	recentFailuresLock.Lock()
+	defer recentFailuresLock.Unlock()
+
+	return append([]api.Operation(nil), recentFailures...)
+}
+
+// recordFailure remembers a failed operation.
+func recordFailure(op api.Operation) {
+	recentFailuresLock.Lock()
+	defer recentFailuresLock.Unlock()
+
+	recentFailures = append(recentFailures, op)
+	if len(recentFailures) > recentFailuresMax {
+		recentFailures = recentFailures[len(recentFailures)-recentFailuresMax:]
+	}
+}
+
 // OperationGetInternal returns the operation with the given id. It returns an
 // error if it doesn't exist.
 func OperationGetInternal(id string) (*Operation, error) {
@@ -281,8 +307,18 @@ func (op *Operation) Start() error {
 	op.status = api.Running
 
 	if op.onRun != nil {
-		go func(op *Operation) {
-			err := op.onRun(op)
+		go func(op *Operation, onRun func(*Operation) error) {
+			// Wait for the concurrency limits of heavy operations to allow this one to run.
+			release, err := op.waitLimits()
+			if errors.Is(err, errLimitAborted) {
+				return
+			}
+
+			if err == nil {
+				defer release()
+				err = onRun(op)
+			}
+
 			if err != nil {
 				op.lock.Lock()
 				op.status = api.Failure
@@ -292,6 +328,9 @@ func (op *Operation) Start() error {
 
 				op.logger.Debug("Failure for operation", logger.Ctx{"err": err})
 				_, md, _ := op.Render()
+				if md != nil {
+					recordFailure(*md)
+				}
 
 				op.lock.Lock()
 				op.sendEvent(md)
@@ -311,7 +350,7 @@ func (op *Operation) Start() error {
 			op.lock.Lock()
 			op.sendEvent(md)
 			op.lock.Unlock()
-		}(op)
+		}(op, op.onRun)
 	}
 
 	op.lock.Unlock()
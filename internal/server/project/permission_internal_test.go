@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/idmap"
 )
 
@@ -75,3 +76,25 @@ func TestParseHostIDMapRange(t *testing.T) {
 		assert.Equal(t, idmaps, expected)
 	}
 }
+
+func TestExpandProfilesInheritance(t *testing.T) {
+	profiles := []api.Profile{
+		{Name: "base", ProfilePut: api.ProfilePut{Config: map[string]string{"limits.memory": "4GiB"}}},
+		{Name: "web", ProfilePut: api.ProfilePut{Parents: []string{"base"}, Config: map[string]string{"limits.cpu": "2"}}},
+		{Name: "frontend", ProfilePut: api.ProfilePut{Parents: []string{"web"}}},
+	}
+
+	// Simulate an update of the base profile, the descendants must pick it up.
+	profiles[0].Config = map[string]string{"limits.memory": "8GiB"}
+
+	err := expandProfiles(profiles)
+	assert.NoError(t, err)
+
+	assert.Nil(t, profiles[0].ExpandedConfig)
+	assert.Equal(t, map[string]string{"limits.memory": "8GiB", "limits.cpu": "2"}, profiles[1].ExpandedConfig)
+	assert.Equal(t, map[string]string{"limits.memory": "8GiB", "limits.cpu": "2"}, profiles[2].ExpandedConfig)
+
+	instances, err := expandInstancesConfigAndDevices([]api.Instance{{Name: "c1", Profiles: []string{"frontend"}}}, profiles)
+	assert.NoError(t, err)
+	assert.Equal(t, "8GiB", instances[0].Config["limits.memory"])
+}
@@ -812,6 +812,7 @@ var allRestrictions = map[string]string{
 	"restricted.idmap.uid":                 "",
 	"restricted.idmap.gid":                 "",
 	"restricted.networks.access":           "",
+	"restricted.sftp":                      "allow",
 	"restricted.snapshots":                 "block",
 }
 
@@ -964,6 +965,13 @@ func AllowProfileUpdate(tx *db.ClusterTx, projectName, profileName string, req a
 
 		info.Profiles[i].Config = req.Config
 		info.Profiles[i].Devices = req.Devices
+		info.Profiles[i].Parents = req.Parents
+	}
+
+	// Re-expand the profiles so the change also applies through inheritance.
+	err = expandProfiles(info.Profiles)
+	if err != nil {
+		return err
 	}
 
 	err = checkRestrictionsAndAggregateLimits(tx, info)
@@ -1233,6 +1241,40 @@ func fetchProject(tx *db.ClusterTx, projectName string, skipIfNoLimits bool) (*p
 	return info, nil
 }
 
+// Re-compute the expanded configuration and devices of the given profiles from their
+// parents.
+func expandProfiles(profiles []api.Profile) error {
+	profilesByName := map[string]api.Profile{}
+	for _, profile := range profiles {
+		profilesByName[profile.Name] = profile
+	}
+
+	getProfile := func(name string) (*api.Profile, error) {
+		profile, ok := profilesByName[name]
+		if !ok {
+			return nil, api.StatusErrorf(http.StatusNotFound, "Profile not found")
+		}
+
+		return &profile, nil
+	}
+
+	for i := range profiles {
+		if len(profiles[i].Parents) == 0 {
+			profiles[i].ExpandedConfig = nil
+			profiles[i].ExpandedDevices = nil
+			continue
+		}
+
+		var err error
+		profiles[i].ExpandedConfig, profiles[i].ExpandedDevices, err = cluster.ExpandProfile(profiles[i], getProfile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Expand the configuration and devices of the given instances, taking the give
 // project profiles into account.
 func expandInstancesConfigAndDevices(instances []api.Instance, profiles []api.Profile) ([]api.Instance, error) {
@@ -1534,6 +1576,16 @@ func AllowSnapshotCreation(p *api.Project) error {
 	return nil
 }
 
+// AllowSFTP returns an error if any project-specific restriction is violated
+// when connecting to an instance over SFTP.
+func AllowSFTP(p *api.Project) error {
+	if projectHasRestriction(p, "restricted.sftp", "block") {
+		return fmt.Errorf("Project %q doesn't allow for SFTP access", p.Name)
+	}
+
+	return nil
+}
+
 // GetRestrictedClusterGroups returns a slice of restricted cluster groups for the given project.
 func GetRestrictedClusterGroups(p *api.Project) []string {
 	return util.SplitNTrimSpace(p.Config["restricted.cluster.groups"], ",", -1, true)
@@ -0,0 +1,156 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// CPUAllocation describes a request for a set of CPU threads.
+type CPUAllocation struct {
+	// Number of CPU threads to allocate.
+	Count int
+
+	// CPU threads already in use by other instances.
+	Used []int64
+
+	// NUMA nodes the CPU threads may be picked from (all nodes if empty).
+	Nodes []int64
+
+	// NUMA nodes of the devices used by the instance, the most used first.
+	DeviceNodes []int64
+}
+
+// AllocateCPUs picks a set of online and non-isolated CPU threads which aren't already in use.
+//
+// The threads are taken from a single NUMA node whenever possible, preferring the nodes local to the devices of
+// the instance and then the nodes with the most free threads. Otherwise they're spread over as few NUMA nodes as
+// possible in that same order. Within a NUMA node, threads of the same core are kept together.
+func AllocateCPUs(cpu *api.ResourcesCPU, req CPUAllocation) ([]int64, error) {
+	// Build the list of free threads per NUMA node.
+	free := map[int64][]api.ResourcesCPUThread{}
+	cores := map[int64]uint64{}
+	sockets := map[int64]uint64{}
+	for _, cpuSocket := range cpu.Sockets {
+		for _, cpuCore := range cpuSocket.Cores {
+			for _, cpuThread := range cpuCore.Threads {
+				node := int64(cpuThread.NUMANode)
+
+				if !cpuThread.Online || cpuThread.Isolated || slices.Contains(req.Used, cpuThread.ID) {
+					continue
+				}
+
+				if len(req.Nodes) > 0 && !slices.Contains(req.Nodes, node) {
+					continue
+				}
+
+				free[node] = append(free[node], cpuThread)
+				cores[cpuThread.ID] = cpuCore.Core
+				sockets[cpuThread.ID] = cpuSocket.Socket
+			}
+		}
+	}
+
+	total := 0
+	nodes := make([]int64, 0, len(free))
+	for node, threads := range free {
+		total += len(threads)
+		nodes = append(nodes, node)
+
+		// Keep the threads of a core next to each other.
+		sort.SliceStable(threads, func(i, j int) bool {
+			if sockets[threads[i].ID] != sockets[threads[j].ID] {
+				return sockets[threads[i].ID] < sockets[threads[j].ID]
+			}
+
+			if cores[threads[i].ID] != cores[threads[j].ID] {
+				return cores[threads[i].ID] < cores[threads[j].ID]
+			}
+
+			return threads[i].Thread < threads[j].Thread
+		})
+	}
+
+	if total < req.Count {
+		return nil, fmt.Errorf("Not enough free CPU threads, requested %d but only %d available", req.Count, total)
+	}
+
+	// Order the NUMA nodes by device locality and then by number of free threads.
+	priority := func(node int64) int {
+		idx := slices.Index(req.DeviceNodes, node)
+		if idx < 0 {
+			return len(req.DeviceNodes)
+		}
+
+		return idx
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if priority(nodes[i]) != priority(nodes[j]) {
+			return priority(nodes[i]) < priority(nodes[j])
+		}
+
+		if len(free[nodes[i]]) != len(free[nodes[j]]) {
+			return len(free[nodes[i]]) > len(free[nodes[j]])
+		}
+
+		return nodes[i] < nodes[j]
+	})
+
+	// Try fitting on a single NUMA node.
+	for _, node := range nodes {
+		if len(free[node]) >= req.Count {
+			cpus := []int64{}
+			for _, thread := range free[node][:req.Count] {
+				cpus = append(cpus, thread.ID)
+			}
+
+			slices.Sort(cpus)
+
+			return cpus, nil
+		}
+	}
+
+	// Spread over multiple NUMA nodes.
+	cpus := []int64{}
+	for _, node := range nodes {
+		for _, thread := range free[node] {
+			if len(cpus) == req.Count {
+				break
+			}
+
+			cpus = append(cpus, thread.ID)
+		}
+	}
+
+	slices.Sort(cpus)
+
+	return cpus, nil
+}
+
+// FormatCpuset renders a list of CPU ids as a `limits.cpu` range.
+func FormatCpuset(cpus []int64) string {
+	cpus = slices.Clone(cpus)
+	slices.Sort(cpus)
+
+	chunks := []string{}
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+
+		if j > i {
+			chunks = append(chunks, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		} else {
+			chunks = append(chunks, fmt.Sprintf("%d", cpus[i]))
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(chunks, ",")
+}
@@ -14,6 +14,7 @@ import (
 
 var sysDevicesNode = "/sys/devices/system/node"
 var sysDevicesSystemMemory = "/sys/devices/system/memory"
+var sysKernelMMHugepages = "/sys/kernel/mm/hugepages"
 
 type meminfo struct {
 	Cached         uint64
@@ -21,6 +22,9 @@ type meminfo struct {
 	Total          uint64
 	Free           uint64
 	Used           uint64
+	Available      uint64
+	SwapTotal      uint64
+	SwapFree       uint64
 	HugepagesTotal uint64
 	HugepagesFree  uint64
 	HugepagesSize  uint64
@@ -80,6 +84,36 @@ func parseMeminfo(path string) (*meminfo, error) {
 			continue
 		}
 
+		if key == "MemAvailable" {
+			bytes, err := units.ParseByteSizeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse MemAvailable: %w", err)
+			}
+
+			memory.Available = uint64(bytes)
+			continue
+		}
+
+		if key == "SwapTotal" {
+			bytes, err := units.ParseByteSizeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse SwapTotal: %w", err)
+			}
+
+			memory.SwapTotal = uint64(bytes)
+			continue
+		}
+
+		if key == "SwapFree" {
+			bytes, err := units.ParseByteSizeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse SwapFree: %w", err)
+			}
+
+			memory.SwapFree = uint64(bytes)
+			continue
+		}
+
 		if key == "Cached" {
 			bytes, err := units.ParseByteSizeString(value)
 			if err != nil {
@@ -196,6 +230,60 @@ func getTotalMemory(sysDevicesBase string) uint64 {
 	return blockSize * count
 }
 
+// GetHugepages returns the huge pages of each size supported by the system.
+func GetHugepages() ([]api.ResourcesMemoryHugepages, error) {
+	hugepages := []api.ResourcesMemoryHugepages{}
+
+	if !sysfsExists(sysKernelMMHugepages) {
+		return hugepages, nil
+	}
+
+	entries, err := os.ReadDir(sysKernelMMHugepages)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list %q: %w", sysKernelMMHugepages, err)
+	}
+
+	for _, entry := range entries {
+		entryName := entry.Name()
+		entryPath := filepath.Join(sysKernelMMHugepages, entryName)
+
+		// Entries are named after the page size (e.g. hugepages-2048kB).
+		sizeKiB, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(entryName, "hugepages-"), "kB"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		total, err := readUint(filepath.Join(entryPath, "nr_hugepages"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %q: %w", filepath.Join(entryPath, "nr_hugepages"), err)
+		}
+
+		free, err := readUint(filepath.Join(entryPath, "free_hugepages"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %q: %w", filepath.Join(entryPath, "free_hugepages"), err)
+		}
+
+		reserved, err := readUint(filepath.Join(entryPath, "resv_hugepages"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read %q: %w", filepath.Join(entryPath, "resv_hugepages"), err)
+		}
+
+		// Reserved pages are still counted as free by the kernel.
+		reserved = min(reserved, free)
+
+		size := sizeKiB * 1024
+		hugepages = append(hugepages, api.ResourcesMemoryHugepages{
+			Size:     size,
+			Total:    total * size,
+			Used:     (total - free) * size,
+			Reserved: reserved * size,
+			Free:     (free - reserved) * size,
+		})
+	}
+
+	return hugepages, nil
+}
+
 // GetMemory returns a filled api.ResourcesMemory struct ready for use by Incus.
 func GetMemory() (*api.ResourcesMemory, error) {
 	memory := api.ResourcesMemory{}
@@ -219,6 +307,11 @@ func GetMemory() (*api.ResourcesMemory, error) {
 	memory.HugepagesTotal = info.HugepagesTotal * info.HugepagesSize
 	memory.HugepagesSize = info.HugepagesSize
 
+	memory.Hugepages, err = GetHugepages()
+	if err != nil {
+		return nil, err
+	}
+
 	memory.Used = info.Total - info.Free - info.Cached - info.Buffers
 	memory.Total = info.Total
 
@@ -761,3 +761,90 @@ func GetNetworkCounters(name string) (*api.NetworkStateCounters, error) {
 
 	return &counters, nil
 }
+
+// GetNetworkDropReasons returns the non-zero drop and error counters of a network interface, keyed by counter name.
+func GetNetworkDropReasons(name string) (map[string]int64, error) {
+	statsPath := filepath.Join(sysClassNet, name, "statistics")
+
+	entries, err := os.ReadDir(statsPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list %q: %w", statsPath, err)
+	}
+
+	reasons := map[string]int64{}
+	for _, entry := range entries {
+		entryName := entry.Name()
+
+		// Only keep the counters tracking lost or failed packets.
+		if !strings.HasSuffix(entryName, "_dropped") && !strings.HasSuffix(entryName, "_errors") && entryName != "rx_nohandler" && entryName != "collisions" {
+			continue
+		}
+
+		value, err := readInt(filepath.Join(statsPath, entryName))
+		if err != nil || value <= 0 {
+			continue
+		}
+
+		reasons[entryName] = value
+	}
+
+	return reasons, nil
+}
+
+// GetNetworkQueues returns the queue statistics of a network interface.
+func GetNetworkQueues(name string) (*api.InstanceStateNetworkQueues, error) {
+	devicePath := filepath.Join(sysClassNet, name)
+	queuesPath := filepath.Join(devicePath, "queues")
+
+	entries, err := os.ReadDir(queuesPath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list %q: %w", queuesPath, err)
+	}
+
+	queues := api.InstanceStateNetworkQueues{}
+	for _, entry := range entries {
+		entryName := entry.Name()
+
+		if strings.HasPrefix(entryName, "rx-") {
+			queues.RXQueues++
+		} else if strings.HasPrefix(entryName, "tx-") {
+			queues.TXQueues++
+
+			// Byte queue limits aren't available on all devices.
+			inflight, err := readUint(filepath.Join(queuesPath, entryName, "byte_queue_limits", "inflight"))
+			if err == nil {
+				queues.TXInflightBytes += inflight
+			}
+		}
+	}
+
+	txQueueLength, err := readUint(filepath.Join(devicePath, "tx_queue_len"))
+	if err == nil {
+		queues.TXQueueLength = txQueueLength
+	}
+
+	return &queues, nil
+}
+
+// GetNetworkLink returns the link speed (Mbit/s) and duplex of a network interface.
+// Zero and an empty string are returned when the device doesn't report them.
+func GetNetworkLink(name string) (uint64, string) {
+	devicePath := filepath.Join(sysClassNet, name)
+
+	var speed uint64
+	value, err := readInt(filepath.Join(devicePath, "speed"))
+	if err == nil && value > 0 {
+		speed = uint64(value)
+	}
+
+	var duplex string
+	content, err := os.ReadFile(filepath.Join(devicePath, "duplex"))
+	if err == nil {
+		value := strings.TrimSpace(string(content))
+		if value == "full" || value == "half" {
+			duplex = value
+		}
+	}
+
+	return speed, duplex
+}
@@ -0,0 +1,299 @@
+package resources
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// stateMinInterval is the minimum time between two samples, results are cached in between.
+const stateMinInterval = time.Second
+
+// stateMaxInterval is the maximum age of the previous sample for rates to be computed against it.
+const stateMaxInterval = 5 * time.Minute
+
+// stateSample holds the cumulative system counters at a point in time.
+type stateSample struct {
+	time     time.Time
+	cpu      cpuCounters
+	disks    map[string]diskCounters
+	networks map[string]networkCounters
+}
+
+type cpuCounters struct {
+	total  uint64
+	idle   uint64
+	iowait uint64
+}
+
+type diskCounters struct {
+	readOps      uint64
+	readSectors  uint64
+	writeOps     uint64
+	writeSectors uint64
+	ioTicks      uint64
+}
+
+type networkCounters struct {
+	rxBytes   uint64
+	txBytes   uint64
+	rxPackets uint64
+	txPackets uint64
+}
+
+var stateMu sync.Mutex
+var stateLastSample *stateSample
+var stateLast *api.ResourcesState
+
+// GetState returns the live utilization of the system.
+//
+// Rates are computed over the time elapsed since the previous sample. Samples are taken at most once per
+// stateMinInterval, with the previous result being returned in between, which keeps frequent polling cheap.
+func GetState() (*api.ResourcesState, error) {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	if stateLast != nil && time.Since(stateLastSample.time) < stateMinInterval {
+		return stateLast, nil
+	}
+
+	// Take an initial sample if there is no recent one to compute the rates against.
+	if stateLastSample == nil || time.Since(stateLastSample.time) > stateMaxInterval {
+		sample, err := getStateSample()
+		if err != nil {
+			return nil, err
+		}
+
+		stateLastSample = sample
+		time.Sleep(stateMinInterval)
+	}
+
+	sample, err := getStateSample()
+	if err != nil {
+		return nil, err
+	}
+
+	memory, err := parseMeminfo("/proc/meminfo")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve memory information: %w", err)
+	}
+
+	load, err := GetLoad()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve load information: %w", err)
+	}
+
+	prev := stateLastSample
+	interval := sample.time.Sub(prev.time).Seconds()
+
+	state := api.ResourcesState{
+		SampledAt: sample.time,
+		Interval:  interval,
+		Load:      *load,
+		Memory: api.ResourcesStateMemory{
+			Total:     memory.Total,
+			Available: memory.Available,
+			Cached:    memory.Cached,
+			Buffers:   memory.Buffers,
+		},
+		Swap: api.ResourcesStateSwap{
+			Total: memory.SwapTotal,
+			Used:  memory.SwapTotal - memory.SwapFree,
+		},
+		Disks:    map[string]api.ResourcesStateDisk{},
+		Networks: map[string]api.ResourcesStateNetwork{},
+	}
+
+	// Older kernels don't report the available memory.
+	if state.Memory.Available == 0 {
+		state.Memory.Available = memory.Free + memory.Cached + memory.Buffers
+	}
+
+	state.Memory.Used = state.Memory.Total - min(state.Memory.Available, state.Memory.Total)
+
+	total := sample.cpu.total - prev.cpu.total
+	if total > 0 {
+		busy := total - (sample.cpu.idle - prev.cpu.idle)
+		state.CPU.Usage = percentage(busy, total)
+		state.CPU.IOWait = percentage(sample.cpu.iowait-prev.cpu.iowait, total)
+	}
+
+	perSecond := func(current uint64, previous uint64) uint64 {
+		if current < previous {
+			return 0 // Counter was reset.
+		}
+
+		return uint64(float64(current-previous) / interval)
+	}
+
+	for name, disk := range sample.disks {
+		prevDisk, ok := prev.disks[name]
+		if !ok {
+			continue
+		}
+
+		// The I/O time is counted in milliseconds.
+		ioTicks := perSecond(disk.ioTicks, prevDisk.ioTicks)
+
+		state.Disks[name] = api.ResourcesStateDisk{
+			ReadBytes:    perSecond(disk.readSectors, prevDisk.readSectors) * 512,
+			WrittenBytes: perSecond(disk.writeSectors, prevDisk.writeSectors) * 512,
+			ReadOps:      perSecond(disk.readOps, prevDisk.readOps),
+			WriteOps:     perSecond(disk.writeOps, prevDisk.writeOps),
+			Utilization:  min(percentage(ioTicks, 1000), 100),
+		}
+	}
+
+	for name, network := range sample.networks {
+		prevNetwork, ok := prev.networks[name]
+		if !ok {
+			continue
+		}
+
+		state.Networks[name] = api.ResourcesStateNetwork{
+			ReceivedBytes:   perSecond(network.rxBytes, prevNetwork.rxBytes),
+			SentBytes:       perSecond(network.txBytes, prevNetwork.txBytes),
+			ReceivedPackets: perSecond(network.rxPackets, prevNetwork.rxPackets),
+			SentPackets:     perSecond(network.txPackets, prevNetwork.txPackets),
+		}
+	}
+
+	stateLastSample = sample
+	stateLast = &state
+
+	return stateLast, nil
+}
+
+// percentage returns value as a percentage of total, rounded to two decimals.
+func percentage(value uint64, total uint64) float64 {
+	return float64(value*10000/total) / 100
+}
+
+// getStateSample reads the cumulative CPU, disk and network counters of the system.
+func getStateSample() (*stateSample, error) {
+	sample := stateSample{
+		time:     time.Now(),
+		disks:    map[string]diskCounters{},
+		networks: map[string]networkCounters{},
+	}
+
+	// CPU time.
+	content, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read %q: %w", "/proc/stat", err)
+	}
+
+	line, _, _ := strings.Cut(string(content), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 6 || fields[0] != "cpu" {
+		return nil, fmt.Errorf("Failed to parse %q: Unexpected format", "/proc/stat")
+	}
+
+	// Only consider user, nice, system, idle, iowait, irq, softirq and steal as guest time is included in user.
+	for i, field := range fields[1:min(len(fields), 9)] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse %q: %w", "/proc/stat", err)
+		}
+
+		sample.cpu.total += value
+
+		switch i {
+		case 3:
+			sample.cpu.idle += value
+		case 4:
+			sample.cpu.idle += value
+			sample.cpu.iowait = value
+		}
+	}
+
+	// Disk I/O, only considering physical disks.
+	if sysfsExists(sysClassBlock) {
+		entries, err := os.ReadDir(sysClassBlock)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list %q: %w", sysClassBlock, err)
+		}
+
+		for _, entry := range entries {
+			entryPath := filepath.Join(sysClassBlock, entry.Name())
+			if !sysfsExists(filepath.Join(entryPath, "device")) {
+				continue
+			}
+
+			content, err := os.ReadFile(filepath.Join(entryPath, "stat"))
+			if err != nil {
+				continue
+			}
+
+			fields := strings.Fields(string(content))
+			if len(fields) < 10 {
+				continue
+			}
+
+			values := make([]uint64, 10)
+			for i := range values {
+				values[i], err = strconv.ParseUint(fields[i], 10, 64)
+				if err != nil {
+					break
+				}
+			}
+
+			if err != nil {
+				continue
+			}
+
+			sample.disks[entry.Name()] = diskCounters{
+				readOps:      values[0],
+				readSectors:  values[2],
+				writeOps:     values[4],
+				writeSectors: values[6],
+				ioTicks:      values[9],
+			}
+		}
+	}
+
+	// Network throughput, only considering physical network interfaces.
+	if sysfsExists(sysClassNet) {
+		entries, err := os.ReadDir(sysClassNet)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list %q: %w", sysClassNet, err)
+		}
+
+		for _, entry := range entries {
+			entryPath := filepath.Join(sysClassNet, entry.Name())
+			if !sysfsExists(filepath.Join(entryPath, "device")) {
+				continue
+			}
+
+			counters := networkCounters{}
+			for _, counter := range []struct {
+				name  string
+				value *uint64
+			}{
+				{"rx_bytes", &counters.rxBytes},
+				{"tx_bytes", &counters.txBytes},
+				{"rx_packets", &counters.rxPackets},
+				{"tx_packets", &counters.txPackets},
+			} {
+				*counter.value, err = readUint(filepath.Join(entryPath, "statistics", counter.name))
+				if err != nil {
+					break
+				}
+			}
+
+			if err != nil {
+				continue
+			}
+
+			sample.networks[entry.Name()] = counters
+		}
+	}
+
+	return &sample, nil
+}
@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/lxc/incus/v6/client"
@@ -17,11 +18,11 @@ import (
 	"github.com/lxc/incus/v6/shared/logger"
 )
 
-var debug bool
+var debug atomic.Bool
 
 // Init sets the debug variable to the provided value.
 func Init(d bool) {
-	debug = d
+	debug.Store(d)
 }
 
 // Response represents an API response.
@@ -47,7 +48,7 @@ func (r *devIncusResponse) Render(w http.ResponseWriter) error {
 		w.Header().Set("Content-Type", "application/json")
 
 		var debugLogger logger.Logger
-		if debug {
+		if debug.Load() {
 			debugLogger = logger.Logger(logger.Log)
 		}
 
@@ -226,7 +227,7 @@ func (r *syncResponse) Render(w http.ResponseWriter) error {
 	}
 
 	var debugLogger logger.Logger
-	if debug {
+	if debug.Load() {
 		debugLogger = logger.AddContext(logger.Ctx{"http_code": r.code})
 	}
 
@@ -338,7 +339,7 @@ func (r *errorResponse) Render(w http.ResponseWriter) error {
 	buf := &bytes.Buffer{}
 	output = buf
 	var captured *bytes.Buffer
-	if debug {
+	if debug.Load() {
 		captured = &bytes.Buffer{}
 		output = io.MultiWriter(buf, captured)
 	}
@@ -355,7 +356,7 @@ func (r *errorResponse) Render(w http.ResponseWriter) error {
 		return err
 	}
 
-	if debug {
+	if debug.Load() {
 		debugLogger := logger.AddContext(logger.Ctx{"http_code": r.code})
 		localUtil.DebugJSON("Error Response", captured, debugLogger)
 	}
@@ -0,0 +1,110 @@
+//go:build linux && cgo
+
+package seccomp
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// interceptHandler describes a system call interception handler.
+type interceptHandler struct {
+	name     string
+	syscalls []string
+	policy   string
+}
+
+// interceptHandlers lists the system call interception handlers, each enabled by its
+// security.syscalls.intercept.NAME configuration key.
+var interceptHandlers = []interceptHandler{
+	{name: "bpf", syscalls: []string{"bpf"}, policy: seccompNotifyBpf},
+	{name: "mknod", syscalls: []string{"mknod", "mknodat"}, policy: seccompNotifyMknod},
+	{name: "mount", syscalls: []string{"mount"}, policy: seccompNotifyMount},
+	{name: "sched_setscheduler", syscalls: []string{"sched_setscheduler"}, policy: seccompNotifySchedSetscheduler},
+	{name: "setxattr", syscalls: []string{"setxattr"}, policy: seccompNotifySetxattr},
+	{name: "sysinfo", syscalls: []string{"sysinfo"}, policy: seccompNotifySysinfo},
+}
+
+// interceptCounters holds the interception event counters of a handler for an instance.
+type interceptCounters struct {
+	intercepted atomic.Uint64
+	errors      atomic.Uint64
+	skipped     atomic.Uint64
+}
+
+var interceptStatsMu sync.Mutex
+
+// interceptStats holds the counters of each instance since it was last started, keyed by instance and handler.
+var interceptStats = map[string]map[string]*interceptCounters{}
+
+// interceptCountersGet returns the counters of the given handler for the instance.
+func interceptCountersGet(c Instance, handler string) *interceptCounters {
+	key := project.Instance(c.Project().Name, c.Name())
+
+	interceptStatsMu.Lock()
+	defer interceptStatsMu.Unlock()
+
+	handlers, ok := interceptStats[key]
+	if !ok {
+		handlers = map[string]*interceptCounters{}
+		interceptStats[key] = handlers
+	}
+
+	counters, ok := handlers[handler]
+	if !ok {
+		counters = &interceptCounters{}
+		handlers[handler] = counters
+	}
+
+	return counters
+}
+
+// interceptCountersReset clears the counters of the instance.
+func interceptCountersReset(c Instance) {
+	interceptStatsMu.Lock()
+	defer interceptStatsMu.Unlock()
+
+	delete(interceptStats, project.Instance(c.Project().Name, c.Name()))
+}
+
+// InterceptStatus returns the state of the system call interception handlers of the instance along with their
+// event counters since the instance was last started.
+func InterceptStatus(c Instance, running bool) []api.InstanceSyscallIntercept {
+	config := c.ExpandedConfig()
+
+	// The policy of a running instance tells which system calls are actually notified.
+	var profile string
+	if running {
+		content, err := os.ReadFile(ProfilePath(c))
+		if err == nil {
+			profile = string(content)
+		}
+	}
+
+	intercepts := make([]api.InstanceSyscallIntercept, 0, len(interceptHandlers))
+	for _, handler := range interceptHandlers {
+		intercept := api.InstanceSyscallIntercept{
+			Name:     handler.name,
+			Syscalls: handler.syscalls,
+			Enabled:  util.IsTrue(config["security.syscalls.intercept."+handler.name]),
+			Loaded:   profile != "" && strings.Contains(profile, handler.policy),
+		}
+
+		if intercept.Loaded {
+			counters := interceptCountersGet(c, handler.name)
+			intercept.Intercepted = counters.intercepted.Load()
+			intercept.Errors = counters.errors.Load()
+			intercept.Skipped = counters.skipped.Load()
+		}
+
+		intercepts = append(intercepts, intercept)
+	}
+
+	return intercepts
+}
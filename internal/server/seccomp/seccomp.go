@@ -870,6 +870,9 @@ func CreateProfile(s *state.State, c Instance) error {
 		return err
 	}
 
+	// The interception counters track the events since the instance was started.
+	interceptCountersReset(c)
+
 	err = os.MkdirAll(seccompPath, 0700)
 	if err != nil {
 		return err
@@ -884,6 +887,7 @@ func DeleteProfile(c Instance) {
 	 * delete can fail and that's ok.
 	 */
 	_ = os.Remove(ProfilePath(c))
+	interceptCountersReset(c)
 }
 
 // Server defines a seccomp server.
@@ -2328,24 +2332,46 @@ func (s *Server) HandleBpfSyscall(c Instance, siov *Iovec) int {
 }
 
 func (s *Server) handleSyscall(c Instance, siov *Iovec) int {
+	var handler string
+	var handle func(c Instance, siov *Iovec) int
+
 	switch int(C.seccomp_notify_get_syscall(siov.req, siov.resp)) {
 	case incusSeccompNotifyMknod:
-		return s.HandleMknodSyscall(c, siov)
+		handler, handle = "mknod", s.HandleMknodSyscall
 	case incusSeccompNotifyMknodat:
-		return s.HandleMknodatSyscall(c, siov)
+		handler, handle = "mknod", s.HandleMknodatSyscall
 	case incusSeccompNotifySetxattr:
-		return s.HandleSetxattrSyscall(c, siov)
+		handler, handle = "setxattr", s.HandleSetxattrSyscall
 	case incusSeccompNotifyMount:
-		return s.HandleMountSyscall(c, siov)
+		handler, handle = "mount", s.HandleMountSyscall
 	case incusSeccompNotifyBpf:
-		return s.HandleBpfSyscall(c, siov)
+		handler, handle = "bpf", s.HandleBpfSyscall
 	case incusSeccompNotifySchedSetscheduler:
-		return s.HandleSchedSetschedulerSyscall(c, siov)
+		handler, handle = "sched_setscheduler", s.HandleSchedSetschedulerSyscall
 	case incusSeccompNotifySysinfo:
-		return s.HandleSysinfoSyscall(c, siov)
+		handler, handle = "sysinfo", s.HandleSysinfoSyscall
+	default:
+		return int(-C.EINVAL)
+	}
+
+	counters := interceptCountersGet(c, handler)
+
+	// The seccomp policy can't be changed while the instance is running, so if the interception was disabled
+	// since, let the kernel handle the system call as if it wasn't intercepted.
+	if util.IsFalseOrEmpty(c.ExpandedConfig()["security.syscalls.intercept."+handler]) && s.s.OS.SeccompListenerContinue {
+		counters.skipped.Add(1)
+		C.seccomp_notify_update_response(siov.resp, 0, C.uint32_t(seccompUserNotifFlagContinue))
+		return 0
+	}
+
+	counters.intercepted.Add(1)
+
+	errno := handle(c, siov)
+	if errno != 0 {
+		counters.errors.Add(1)
 	}
 
-	return int(-C.EINVAL)
+	return errno
 }
 
 const seccompUserNotifFlagContinue uint32 = 0x00000001
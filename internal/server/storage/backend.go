@@ -258,6 +258,42 @@ func (b *backend) GetResources() (*api.ResourcesStoragePool, error) {
 	return b.driver.GetResources()
 }
 
+// GetKey returns the status of the encryption key of the storage pool.
+func (b *backend) GetKey() (*api.StoragePoolKey, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("GetKey started")
+	defer l.Debug("GetKey finished")
+
+	return b.driver.GetKey()
+}
+
+// LoadKey loads the encryption key of the storage pool.
+func (b *backend) LoadKey(key string) error {
+	l := b.logger.AddContext(nil)
+	l.Debug("LoadKey started")
+	defer l.Debug("LoadKey finished")
+
+	return b.driver.LoadKey(key)
+}
+
+// UnloadKey unloads the encryption key of the storage pool.
+func (b *backend) UnloadKey() error {
+	l := b.logger.AddContext(nil)
+	l.Debug("UnloadKey started")
+	defer l.Debug("UnloadKey finished")
+
+	return b.driver.UnloadKey()
+}
+
+// ChangeKey replaces the encryption key of the storage pool.
+func (b *backend) ChangeKey(newKey string) error {
+	l := b.logger.AddContext(nil)
+	l.Debug("ChangeKey started")
+	defer l.Debug("ChangeKey finished")
+
+	return b.driver.ChangeKey(newKey)
+}
+
 // IsUsed returns whether the storage pool is used by any volumes or profiles (excluding image volumes).
 func (b *backend) IsUsed() (bool, error) {
 	usedBy, err := UsedBy(context.TODO(), b.state, b, true, true, db.StoragePoolVolumeTypeNameImage)
@@ -646,6 +682,56 @@ func (b *backend) CreateInstance(inst instance.Instance, op *operations.Operatio
 		return err
 	}
 
+	var filler *drivers.VolumeFiller
+	if inst.Type() == instancetype.Container {
+		filler = &drivers.VolumeFiller{
+			Fill: func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool) (int64, error) {
+				// Create an empty rootfs.
+				err := os.Mkdir(filepath.Join(vol.MountPath(), "rootfs"), 0755)
+				if err != nil && !os.IsExist(err) {
+					return 0, err
+				}
+
+				return 0, nil
+			},
+		}
+	}
+
+	return b.createInstance(inst, filler, op)
+}
+
+// CreateInstanceFromDisk creates a new virtual machine volume using the qcow2 or raw disk image file as its
+// root disk.
+func (b *backend) CreateInstanceFromDisk(inst instance.Instance, diskPath string, op *operations.Operation) error {
+	l := b.logger.AddContext(logger.Ctx{"project": inst.Project().Name, "instance": inst.Name(), "diskPath": diskPath})
+	l.Debug("CreateInstanceFromDisk started")
+	defer l.Debug("CreateInstanceFromDisk finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	if inst.Type() != instancetype.VM {
+		return fmt.Errorf("Disk images can only be used for virtual machines")
+	}
+
+	imgFormat, err := diskImageFormat(diskPath)
+	if err != nil {
+		return err
+	}
+
+	filler := &drivers.VolumeFiller{
+		Fill: func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool) (int64, error) {
+			return convertDiskImage(b.state.OS, vol, diskPath, imgFormat, rootBlockPath, allowUnsafeResize)
+		},
+	}
+
+	return b.createInstance(inst, filler, op)
+}
+
+// createInstance creates the instance's root volume, populating it using the optional filler.
+func (b *backend) createInstance(inst instance.Instance, filler *drivers.VolumeFiller, op *operations.Operation) error {
 	volType, err := InstanceTypeToVolumeType(inst.Type())
 	if err != nil {
 		return err
@@ -688,21 +774,6 @@ func (b *backend) CreateInstance(inst instance.Instance, op *operations.Operatio
 		return err
 	}
 
-	var filler *drivers.VolumeFiller
-	if inst.Type() == instancetype.Container {
-		filler = &drivers.VolumeFiller{
-			Fill: func(vol drivers.Volume, rootBlockPath string, allowUnsafeResize bool) (int64, error) {
-				// Create an empty rootfs.
-				err := os.Mkdir(filepath.Join(vol.MountPath(), "rootfs"), 0755)
-				if err != nil && !os.IsExist(err) {
-					return 0, err
-				}
-
-				return 0, nil
-			},
-		}
-	}
-
 	err = b.driver.CreateVolume(vol, filler, op)
 	if err != nil {
 		return err
@@ -2046,8 +2117,9 @@ func (b *backend) CreateInstanceFromMigration(inst instance.Instance, conn io.Re
 		// If the negotiated migration method is rsync and the instance's base image is
 		// already on the host then setup a pre-filler that will unpack the local image
 		// to try and speed up the rsync of the incoming volume by avoiding the need to
-		// transfer the base image files too.
-		if args.MigrationType.FSType == migration.MigrationFSType_RSYNC {
+		// transfer the base image files too. This isn't useful when the volume may be
+		// received as a block stream, as this overwrites the whole volume.
+		if args.MigrationType.FSType == migration.MigrationFSType_RSYNC && !slices.Contains(args.MigrationType.Features, migration.RsyncFeatureBlockStream) {
 			fingerprint := inst.ExpandedConfig()["volatile.base_image"]
 			imageExists := false
 
@@ -4571,6 +4643,11 @@ func (b *backend) CreateCustomVolume(projectName string, volName string, desc st
 		return err
 	}
 
+	err = volumeSharingValidate(b.state, config)
+	if err != nil {
+		return err
+	}
+
 	storagePoolSupported := false
 	for _, supportedType := range b.Driver().Info().VolumeTypes {
 		if supportedType == drivers.VolumeTypeCustom {
@@ -5350,6 +5427,11 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 		return err
 	}
 
+	err = volumeSharingValidate(b.state, newConfig)
+	if err != nil {
+		return err
+	}
+
 	// Apply config changes if there are any.
 	changedConfig, userOnly := b.detectChangedConfig(curVol.Config, newConfig)
 	if len(changedConfig) != 0 {
@@ -5363,8 +5445,12 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 			return fmt.Errorf("Custom volume 'block.filesystem' property cannot be changed")
 		}
 
+		_, sharingModeChanged := changedConfig["sharing.mode"]
+		_, sharingMemberChanged := changedConfig["sharing.nfs.member"]
+		sharingChanged := sharingModeChanged || sharingMemberChanged
+
 		// Check for config changing that is not allowed when running instances are using it.
-		if changedConfig["security.shifted"] != "" {
+		if changedConfig["security.shifted"] != "" || sharingChanged {
 			err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(dbInst db.InstanceArgs, project api.Project, usedByDevices []string) error {
 				inst, err := instance.Load(b.state, dbInst, project)
 				if err != nil {
@@ -5376,6 +5462,11 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 					return fmt.Errorf("Cannot modify shifting with running instances using the volume")
 				}
 
+				// Confirm that no running instances are using it when changing how it's shared.
+				if inst.IsRunning() && sharingChanged {
+					return fmt.Errorf("Cannot modify sharing with running instances using the volume")
+				}
+
 				return nil
 			})
 			if err != nil {
@@ -5416,6 +5507,24 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 			}
 		}
 
+		// Check that the instances using the volume are on a single member when it stops being shared through NFS.
+		if sharingModeChanged && newConfig["sharing.mode"] != VolumeSharingNFS {
+			members := map[string]bool{}
+
+			err = VolumeUsedByInstanceDevices(b.state, b.name, projectName, &curVol.StorageVolume, true, func(inst db.InstanceArgs, project api.Project, usedByDevices []string) error {
+				members[inst.Node] = true
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(members) > 1 {
+				return fmt.Errorf("Cannot stop sharing custom volume through NFS if attached to instances on more than one cluster member")
+			}
+		}
+
 		curVol := b.GetVolume(drivers.VolumeTypeCustom, contentType, volStorageName, curVol.Config)
 		if !userOnly {
 			err = b.driver.UpdateVolume(curVol, changedConfig)
@@ -5441,6 +5550,16 @@ func (b *backend) UpdateCustomVolume(projectName string, volName string, newDesc
 		}
 	}
 
+	// Apply the sharing changes right away when this member exports the volume, others pick them up periodically.
+	_, sharingModeChanged := changedConfig["sharing.mode"]
+	_, sharingMemberChanged := changedConfig["sharing.nfs.member"]
+	if sharingModeChanged || sharingMemberChanged {
+		err = VolumeSharingNFSSync(context.TODO(), b.state)
+		if err != nil {
+			l.Warn("Failed updating NFS exports", logger.Ctx{"err": err})
+		}
+	}
+
 	b.state.Events.SendLifecycle(projectName, lifecycle.StorageVolumeUpdated.Event(newVol, string(newVol.Type()), projectName, op, nil))
 
 	return nil
@@ -5508,6 +5627,17 @@ func (b *backend) DeleteCustomVolume(projectName string, volName string, op *ope
 		return fmt.Errorf("Volume name cannot be a snapshot")
 	}
 
+	// Get the volume.
+	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
+	if err != nil {
+		return err
+	}
+
+	// Volumes shared through NFS are kept mounted by the cluster member exporting them.
+	if VolumeSharingNFSMember(curVol.Config) != "" {
+		return fmt.Errorf(`Cannot delete custom volume shared through NFS (unset "sharing.mode" first)`)
+	}
+
 	// Retrieve a list of snapshots.
 	snapshots, err := VolumeDBSnapshotsGet(b, projectName, volName, drivers.VolumeTypeCustom)
 	if err != nil {
@@ -5525,12 +5655,6 @@ func (b *backend) DeleteCustomVolume(projectName string, volName string, op *ope
 	// Get the volume name on storage.
 	volStorageName := project.StorageVolume(projectName, volName)
 
-	// Get the volume.
-	curVol, err := VolumeDBGet(b, projectName, volName, drivers.VolumeTypeCustom)
-	if err != nil {
-		return err
-	}
-
 	// Get the content type.
 	dbContentType, err := VolumeContentTypeNameToContentType(curVol.ContentType)
 	if err != nil {
@@ -5633,8 +5757,8 @@ func (b *backend) GetCustomVolumeUsage(projectName, volName string) (*VolumeUsag
 
 	val.Used = size
 
-	// Get the total size.
-	sizeStr, ok := vol.Config()["size"]
+	// Get the total size from the volume record as the volume above was loaded without its config.
+	sizeStr, ok := volume.Config["size"]
 	if ok {
 		total, err := units.ParseByteSizeString(sizeStr)
 		if err != nil {
@@ -76,6 +76,38 @@ func (b *mockBackend) GetResources() (*api.ResourcesStoragePool, error) {
 	return nil, nil
 }
 
+func (b *mockBackend) Benchmark(op *operations.Operation) (*api.StoragePoolBenchmark, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) GetKey() (*api.StoragePoolKey, error) {
+	return nil, nil
+}
+
+func (b *mockBackend) LoadKey(key string) error {
+	return nil
+}
+
+func (b *mockBackend) UnloadKey() error {
+	return nil
+}
+
+func (b *mockBackend) ChangeKey(newKey string) error {
+	return nil
+}
+
+func (b *mockBackend) Scrub(op *operations.Operation) (int64, error) {
+	return 0, nil
+}
+
+func (b *mockBackend) Balance(op *operations.Operation) error {
+	return nil
+}
+
+func (b *mockBackend) Monitor() (*drivers.PoolAlert, error) {
+	return nil, nil
+}
+
 func (b *mockBackend) IsUsed() (bool, error) {
 	return false, nil
 }
@@ -112,6 +144,10 @@ func (b *mockBackend) CreateInstance(inst instance.Instance, op *operations.Oper
 	return nil
 }
 
+func (b *mockBackend) CreateInstanceFromDisk(inst instance.Instance, diskPath string, op *operations.Operation) error {
+	return nil
+}
+
 func (b *mockBackend) CreateInstanceFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (func(instance.Instance) error, revert.Hook, error) {
 	return nil, nil, nil
 }
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	cryptoRand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/shared/api"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/units"
+)
+
+// Parameters of the storage pool benchmark.
+const (
+	benchmarkFileSize      = 256 * 1024 * 1024
+	benchmarkSequentialIO  = 1024 * 1024
+	benchmarkRandomIO      = 4096
+	benchmarkRandomRuntime = 5 * time.Second
+)
+
+// Pool config keys recording the result of the last benchmark on the cluster member.
+const (
+	BenchmarkConfigSequentialRead  = "volatile.benchmark.sequential_read"
+	BenchmarkConfigSequentialWrite = "volatile.benchmark.sequential_write"
+	BenchmarkConfigRandomRead      = "volatile.benchmark.random_read"
+	BenchmarkConfigRandomWrite     = "volatile.benchmark.random_write"
+	BenchmarkConfigDate            = "volatile.benchmark.date"
+)
+
+// BenchmarkConfig returns the pool config keys recording the benchmark result.
+func BenchmarkConfig(result *api.StoragePoolBenchmark) map[string]string {
+	return map[string]string{
+		BenchmarkConfigSequentialRead:  strconv.FormatInt(result.SequentialRead, 10),
+		BenchmarkConfigSequentialWrite: strconv.FormatInt(result.SequentialWrite, 10),
+		BenchmarkConfigRandomRead:      strconv.FormatInt(result.RandomRead, 10),
+		BenchmarkConfigRandomWrite:     strconv.FormatInt(result.RandomWrite, 10),
+		BenchmarkConfigDate:            result.Date.Format(time.RFC3339),
+	}
+}
+
+// Benchmark measures the sequential throughput and random IOPS of the pool, using a temporary volume.
+func (b *backend) Benchmark(op *operations.Operation) (*api.StoragePoolBenchmark, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("Benchmark started")
+	defer l.Debug("Benchmark finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(b.driver.Info().VolumeTypes, drivers.VolumeTypeCustom) {
+		return nil, fmt.Errorf("Storage pool driver %q doesn't support custom volumes: %w", b.driver.Info().Name, drivers.ErrNotSupported)
+	}
+
+	config := map[string]string{}
+	if b.driver.Info().BlockBacking {
+		config["size"] = "1GiB"
+	}
+
+	// Custom volume names on storage always include the project, so this one can't conflict.
+	vol := b.GetVolume(drivers.VolumeTypeCustom, drivers.ContentTypeFS, fmt.Sprintf("benchmark-%s", uuid.New().String()), config)
+
+	err = b.driver.FillVolumeConfig(vol)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.driver.CreateVolume(vol, nil, op)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating benchmark volume: %w", err)
+	}
+
+	defer func() {
+		err := b.driver.DeleteVolume(vol, op)
+		if err != nil {
+			l.Warn("Failed deleting benchmark volume", logger.Ctx{"volume": vol.Name(), "err": err})
+		}
+	}()
+
+	var result *api.StoragePoolBenchmark
+
+	err = vol.MountTask(func(mountPath string, op *operations.Operation) error {
+		var err error
+
+		result, err = benchmarkPath(filepath.Join(mountPath, "benchmark"))
+
+		return err
+	}, op)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("Benchmarked storage pool", logger.Ctx{"sequentialRead": units.GetByteSizeStringIEC(result.SequentialRead, 2) + "/s", "sequentialWrite": units.GetByteSizeStringIEC(result.SequentialWrite, 2) + "/s", "randomRead": result.RandomRead, "randomWrite": result.RandomWrite})
+
+	return result, nil
+}
+
+// benchmarkPath measures the IO performance of the filesystem holding the path with a temporary file.
+// The page cache of the file is dropped before reading it so that the reads hit the storage.
+func benchmarkPath(path string) (*api.StoragePoolBenchmark, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Failed creating benchmark file: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(path)
+	}()
+
+	result := &api.StoragePoolBenchmark{Date: time.Now().UTC()}
+
+	// Use random data so that compression doesn't skew the results.
+	buf := make([]byte, benchmarkSequentialIO)
+	_, err = cryptoRand.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sequential write.
+	start := time.Now()
+	for written := 0; written < benchmarkFileSize; written += len(buf) {
+		_, err = f.Write(buf)
+		if err != nil {
+			return nil, fmt.Errorf("Failed writing benchmark file: %w", err)
+		}
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return nil, fmt.Errorf("Failed syncing benchmark file: %w", err)
+	}
+
+	result.SequentialWrite = benchmarkRate(benchmarkFileSize, time.Since(start))
+
+	// Sequential read.
+	_ = unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
+
+	start = time.Now()
+	for offset := int64(0); offset < benchmarkFileSize; offset += int64(len(buf)) {
+		_, err = f.ReadAt(buf, offset)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading benchmark file: %w", err)
+		}
+	}
+
+	result.SequentialRead = benchmarkRate(benchmarkFileSize, time.Since(start))
+
+	// Random read.
+	_ = unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
+
+	ioBuf := buf[:benchmarkRandomIO]
+	blocks := int64(benchmarkFileSize / benchmarkRandomIO)
+
+	ops := 0
+	start = time.Now()
+	for time.Since(start) < benchmarkRandomRuntime {
+		_, err = f.ReadAt(ioBuf, rand.Int63n(blocks)*benchmarkRandomIO)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading benchmark file: %w", err)
+		}
+
+		ops++
+	}
+
+	result.RandomRead = benchmarkRate(int64(ops), time.Since(start))
+
+	// Random synchronous write.
+	ops = 0
+	start = time.Now()
+	for time.Since(start) < benchmarkRandomRuntime {
+		_, err = f.WriteAt(ioBuf, rand.Int63n(blocks)*benchmarkRandomIO)
+		if err != nil {
+			return nil, fmt.Errorf("Failed writing benchmark file: %w", err)
+		}
+
+		err = unix.Fdatasync(int(f.Fd()))
+		if err != nil {
+			return nil, fmt.Errorf("Failed syncing benchmark file: %w", err)
+		}
+
+		ops++
+	}
+
+	result.RandomWrite = benchmarkRate(int64(ops), time.Since(start))
+
+	return result, nil
+}
+
+// benchmarkRate returns the per second rate of the count over the duration.
+func benchmarkRate(count int64, duration time.Duration) int64 {
+	if duration <= 0 {
+		return count
+	}
+
+	return int64(float64(count) / duration.Seconds())
+}
@@ -318,8 +318,10 @@ func (d *btrfs) Delete(op *operations.Operation) error {
 // Validate checks that all provide keys are supported and that no conflicting or missing configuration is present.
 func (d *btrfs) Validate(config map[string]string) error {
 	rules := map[string]func(value string) error{
-		"size":                validate.Optional(validate.IsSize),
-		"btrfs.mount_options": validate.IsAny,
+		"size":                   validate.Optional(validate.IsSize),
+		"btrfs.mount_options":    validate.IsAny,
+		"btrfs.scrub.schedule":   validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
+		"btrfs.balance.schedule": validate.Optional(validate.IsCron([]string{"@hourly", "@daily", "@midnight", "@weekly", "@monthly", "@annually", "@yearly"})),
 	}
 
 	return d.validatePool(config, rules, nil)
@@ -480,6 +482,29 @@ func (d *btrfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return genericVFSGetResources(d)
 }
 
+// ScrubPool verifies the checksums of all the data and metadata of the pool, repairing them from a good copy
+// when the profile of the pool allows it, and returns the number of errors found.
+func (d *btrfs) ScrubPool(op *operations.Operation) (int64, error) {
+	// The report is printed even when the scrub fails with uncorrectable errors.
+	out, err := subprocess.RunCommand("btrfs", "scrub", "start", "-B", "-R", GetPoolMountPath(d.name))
+	errorCount := btrfsScrubErrors(out)
+	if err != nil && errorCount == 0 {
+		return 0, err
+	}
+
+	return errorCount, nil
+}
+
+// BalancePool compacts the partially used chunks of the pool to return their space to the unallocated pool.
+func (d *btrfs) BalancePool(op *operations.Operation) error {
+	_, err := subprocess.RunCommand("btrfs", "balance", "start", "-dusage=50", "-musage=50", GetPoolMountPath(d.name))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MigrationType returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *btrfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool) []localMigration.Type {
 	var rsyncFeatures []string
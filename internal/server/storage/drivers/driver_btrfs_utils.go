@@ -656,3 +656,25 @@ func (d *btrfs) receiveSubVolume(r io.Reader, receivePath string, tracker *iopro
 
 	return subVolPath, nil
 }
+
+// btrfsScrubErrors returns the number of errors reported in the raw output of a scrub.
+func btrfsScrubErrors(output string) int64 {
+	var total int64
+
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case "read_errors", "csum_errors", "verify_errors", "super_errors":
+			count, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err == nil {
+				total += count
+			}
+		}
+	}
+
+	return total
+}
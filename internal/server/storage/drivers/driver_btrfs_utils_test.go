@@ -0,0 +1,44 @@
+package drivers
+
+import (
+	"fmt"
+)
+
+func Example_btrfsScrubErrors() {
+	clean := `scrub done for 5c9a9bd8-1f0c-4a3e-9b8e-3f4a7c1d2e6b
+Scrub started:    Thu Oct 17 10:00:00 2024
+Status:           finished
+Duration:         0:00:12
+	data_extents_scrubbed: 12345
+	tree_extents_scrubbed: 678
+	read_errors: 0
+	csum_errors: 0
+	verify_errors: 0
+	no_csum: 42
+	csum_discards: 0
+	super_errors: 0
+	malloc_errors: 0
+	uncorrectable_errors: 0
+	unverified_errors: 0
+	corrected_errors: 0
+	last_physical: 1234567890
+`
+
+	corrupted := `scrub done for 5c9a9bd8-1f0c-4a3e-9b8e-3f4a7c1d2e6b
+Status:           finished
+	read_errors: 1
+	csum_errors: 3
+	verify_errors: 0
+	super_errors: 0
+	uncorrectable_errors: 4
+	corrected_errors: 0
+`
+
+	fmt.Println(btrfsScrubErrors(clean))
+	fmt.Println(btrfsScrubErrors(corrupted))
+	fmt.Println(btrfsScrubErrors(""))
+
+	// Output: 0
+	// 4
+	// 0
+}
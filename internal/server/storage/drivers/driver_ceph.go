@@ -419,6 +419,9 @@ func (d *ceph) MigrationTypes(contentType ContentType, refresh bool, copySnapsho
 		}
 	}
 
+	// As RBD volumes are block-backed, they can be sent as a block stream to other drivers.
+	rsyncFeatures = append(rsyncFeatures, migration.RsyncFeatureBlockStream)
+
 	if contentType == ContentTypeBlock {
 		return []localMigration.Type{
 			{
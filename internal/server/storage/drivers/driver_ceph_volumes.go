@@ -842,7 +842,15 @@ func (d *ceph) commonVolumeRules() map[string]func(value string) error {
 
 // ValidateVolume validates the supplied volume config.
 func (d *ceph) ValidateVolume(vol Volume, removeUnknownKeys bool) error {
-	return d.validateVolume(vol, d.commonVolumeRules(), removeUnknownKeys)
+	rules := d.commonVolumeRules()
+
+	// Custom filesystem volumes can be shared between cluster members through NFS.
+	if vol.Type() == VolumeTypeCustom && vol.ContentType() == ContentTypeFS {
+		rules["sharing.mode"] = validate.Optional(validate.IsOneOf("exclusive", "nfs"))
+		rules["sharing.nfs.member"] = validate.IsAny
+	}
+
+	return d.validateVolume(vol, rules, removeUnknownKeys)
 }
 
 // UpdateVolume applies config changes to the volume.
@@ -18,6 +18,7 @@ import (
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/project"
 	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/shared/api"
 	"github.com/lxc/incus/v6/shared/logger"
 	"github.com/lxc/incus/v6/shared/subprocess"
 	"github.com/lxc/incus/v6/shared/util"
@@ -216,6 +217,11 @@ func (d *common) MigrationTypes(contentType ContentType, refresh bool, copySnaps
 
 	if IsContentBlock(contentType) {
 		transportType = migration.MigrationFSType_BLOCK_AND_RSYNC
+
+		// Block volumes can be sent as a sparse block stream when not refreshing.
+		if !refresh {
+			rsyncFeatures = append(rsyncFeatures, migration.RsyncFeatureBlockStream)
+		}
 	} else {
 		transportType = migration.MigrationFSType_RSYNC
 	}
@@ -248,6 +254,41 @@ func (d *common) Config() map[string]string {
 	return confCopy
 }
 
+// GetKey returns the status of the encryption key of the storage pool.
+func (d *common) GetKey() (*api.StoragePoolKey, error) {
+	return nil, ErrNotSupported
+}
+
+// LoadKey loads the encryption key of the storage pool.
+func (d *common) LoadKey(key string) error {
+	return ErrNotSupported
+}
+
+// UnloadKey unloads the encryption key of the storage pool.
+func (d *common) UnloadKey() error {
+	return ErrNotSupported
+}
+
+// ChangeKey replaces the encryption key of the storage pool.
+func (d *common) ChangeKey(newKey string) error {
+	return ErrNotSupported
+}
+
+// ScrubPool verifies the integrity of the data of the storage pool and returns the number of errors found.
+func (d *common) ScrubPool(op *operations.Operation) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+// BalancePool rebalances the data of the storage pool.
+func (d *common) BalancePool(op *operations.Operation) error {
+	return ErrNotSupported
+}
+
+// MonitorPool checks the health of the storage pool and returns an alert if it needs attention.
+func (d *common) MonitorPool() (*PoolAlert, error) {
+	return nil, ErrNotSupported
+}
+
 // ApplyPatch looks for a suitable patch and runs it.
 func (d *common) ApplyPatch(name string) error {
 	if d.patches == nil {
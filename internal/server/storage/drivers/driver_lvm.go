@@ -12,8 +12,10 @@ import (
 	"time"
 
 	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/migration"
 	"github.com/lxc/incus/v6/internal/revert"
 	deviceConfig "github.com/lxc/incus/v6/internal/server/device/config"
+	localMigration "github.com/lxc/incus/v6/internal/server/migration"
 	"github.com/lxc/incus/v6/internal/server/operations"
 	"github.com/lxc/incus/v6/internal/server/state"
 	"github.com/lxc/incus/v6/shared/api"
@@ -551,6 +553,8 @@ func (d *lvm) Validate(config map[string]string) error {
 		rules["lvm.thinpool_metadata_size"] = validate.Optional(validate.IsSize)
 		rules["lvm.use_thinpool"] = validate.Optional(validate.IsBool)
 		rules["lvm.vg.force_reuse"] = validate.Optional(validate.IsBool)
+		rules["lvm.thinpool_threshold"] = validate.Optional(validate.IsInRange(1, 100))
+		rules["lvm.thinpool_autoextend_percent"] = validate.Optional(validate.IsInRange(0, 100))
 	}
 
 	err := d.validatePool(config, rules, d.commonVolumeRules())
@@ -800,6 +804,59 @@ func (d *lvm) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// MonitorPool checks the data and metadata usage of the thin pool. When either is above lvm.thinpool_threshold,
+// the thin pool is extended by lvm.thinpool_autoextend_percent if set and the volume group has enough free space.
+// An alert is returned if the usage remains above the threshold.
+func (d *lvm) MonitorPool() (*PoolAlert, error) {
+	if !d.usesThinpool() {
+		return nil, nil
+	}
+
+	threshold := float64(lvmThinpoolDefaultThreshold)
+	if d.config["lvm.thinpool_threshold"] != "" {
+		value, err := strconv.ParseFloat(d.config["lvm.thinpool_threshold"], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		threshold = value
+	}
+
+	thinPoolPath := d.lvmDevPath(d.config["lvm.vg_name"], "", "", d.thinpoolName())
+	usage, err := d.thinPoolUsage(thinPoolPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if usage.dataPercent < threshold && usage.metadataPercent < threshold {
+		return nil, nil
+	}
+
+	percent, _ := strconv.ParseInt(d.config["lvm.thinpool_autoextend_percent"], 10, 64)
+	if percent > 0 {
+		err = d.extendThinPool(thinPoolPath, usage, threshold, percent)
+		if err != nil {
+			d.logger.Warn("Failed extending thin pool", logger.Ctx{"thinpool": thinPoolPath, "err": err})
+		} else {
+			d.logger.Info("Extended thin pool", logger.Ctx{"thinpool": thinPoolPath, "dataPercent": usage.dataPercent, "metadataPercent": usage.metadataPercent})
+
+			usage, err = d.thinPoolUsage(thinPoolPath)
+			if err != nil {
+				return nil, err
+			}
+
+			if usage.dataPercent < threshold && usage.metadataPercent < threshold {
+				return nil, nil
+			}
+		}
+	}
+
+	return &PoolAlert{
+		Message:  fmt.Sprintf("Thin pool %q of storage pool %q is using %.1f%% of its data space and %.1f%% of its metadata space", d.thinpoolName(), d.name, usage.dataPercent, usage.metadataPercent),
+		Critical: max(usage.dataPercent, usage.metadataPercent) >= lvmThinpoolCriticalThreshold,
+	}, nil
+}
+
 // roundVolumeBlockSizeBytes returns size rounded to the nearest multiple of the volume group extent size that is
 // equal to or larger than sizeBytes.
 func (d *lvm) roundVolumeBlockSizeBytes(sizeBytes int64) int64 {
@@ -818,3 +875,17 @@ func (d *lvm) roundVolumeBlockSizeBytes(sizeBytes int64) int64 {
 
 	return roundedSizeBytes
 }
+
+// MigrationTypes returns the type of transfer methods to be used when doing migrations between pools in preference order.
+func (d *lvm) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool) []localMigration.Type {
+	types := d.common.MigrationTypes(contentType, refresh, copySnapshots)
+
+	// As filesystem volumes are block-backed, they can also be sent as a block stream when not refreshing.
+	if !refresh && !IsContentBlock(contentType) {
+		for i := range types {
+			types[i].Features = append(types[i].Features, migration.RsyncFeatureBlockStream)
+		}
+	}
+
+	return types
+}
@@ -39,6 +39,15 @@ const lvmEscapedHyphen = "--"
 // lvmThinpoolDefaultName is the default name for the thinpool volume.
 const lvmThinpoolDefaultName = "IncusThinPool"
 
+// lvmThinpoolDefaultThreshold is the default usage percentage above which the thinpool is extended or warned about.
+const lvmThinpoolDefaultThreshold = 80
+
+// lvmThinpoolCriticalThreshold is the usage percentage above which the thinpool is about to run out of space.
+const lvmThinpoolCriticalThreshold = 95
+
+// lvmThinpoolMaxMetadataSize is the maximum size of the thinpool metadata supported by LVM.
+const lvmThinpoolMaxMetadataSize = 16 * 1024 * 1024 * 1024
+
 // usesThinpool indicates whether the config specifies to use a thin pool or not.
 func (d *lvm) usesThinpool() bool {
 	// No thin pool on clustered LVM.
@@ -730,6 +739,127 @@ func (d *lvm) thinPoolVolumeUsage(volDevPath string) (uint64, uint64, error) {
 	return totalSize, usedSize, nil
 }
 
+// lvmThinPoolUsage represents the data and metadata usage of a thin pool.
+type lvmThinPoolUsage struct {
+	dataSize        int64
+	dataPercent     float64
+	metadataSize    int64
+	metadataPercent float64
+}
+
+// thinPoolUsage gets the sizes and usage percentages of the data and metadata of a thin pool.
+func (d *lvm) thinPoolUsage(thinPoolPath string) (*lvmThinPoolUsage, error) {
+	args := []string{
+		thinPoolPath,
+		"--noheadings",
+		"--units", "b",
+		"--nosuffix",
+		"--separator", ",",
+		"-o", "lv_size,data_percent,lv_metadata_size,metadata_percent",
+	}
+
+	out, err := subprocess.RunCommand("lvs", args...)
+	if err != nil {
+		if d.isLVMNotFoundExitError(err) {
+			return nil, api.StatusErrorf(http.StatusNotFound, "LVM thin pool not found")
+		}
+
+		return nil, fmt.Errorf("Error getting usage of LVM thin pool %q: %w", thinPoolPath, err)
+	}
+
+	parts := util.SplitNTrimSpace(out, ",", -1, false)
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("Unexpected output from lvs command")
+	}
+
+	// Used percentages are not available if the thin pool isn't activated.
+	if parts[1] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("Usage of LVM thin pool %q isn't available", thinPoolPath)
+	}
+
+	usage := &lvmThinPoolUsage{}
+
+	usage.dataSize, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing thin pool data size (%q): %w", parts[0], err)
+	}
+
+	usage.dataPercent, err = strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing thin pool data used percentage (%q): %w", parts[1], err)
+	}
+
+	usage.metadataSize, err = strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing thin pool metadata size (%q): %w", parts[2], err)
+	}
+
+	usage.metadataPercent, err = strconv.ParseFloat(parts[3], 64)
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing thin pool metadata used percentage (%q): %w", parts[3], err)
+	}
+
+	return usage, nil
+}
+
+// volumeGroupFreeSize gets the free space of the volume group in bytes.
+func (d *lvm) volumeGroupFreeSize(vgName string) (int64, error) {
+	output, err := subprocess.RunCommand("vgs", "--noheadings", "--nosuffix", "--units", "b", "-o", "vg_free", vgName)
+	if err != nil {
+		if d.isLVMNotFoundExitError(err) {
+			return -1, api.StatusErrorf(http.StatusNotFound, "LVM volume group not found")
+		}
+
+		return -1, err
+	}
+
+	output = strings.TrimSpace(output)
+	return strconv.ParseInt(output, 10, 64)
+}
+
+// extendThinPool grows the data and/or metadata of the thin pool above the threshold by the given percentage,
+// provided the volume group has enough free space for it.
+func (d *lvm) extendThinPool(thinPoolPath string, usage *lvmThinPoolUsage, threshold float64, percent int64) error {
+	var dataGrowth, metadataGrowth int64
+
+	if usage.dataPercent >= threshold {
+		dataGrowth = usage.dataSize * percent / 100
+	}
+
+	if usage.metadataPercent >= threshold {
+		metadataGrowth = min(usage.metadataSize*percent/100, lvmThinpoolMaxMetadataSize-usage.metadataSize)
+	}
+
+	if dataGrowth <= 0 && metadataGrowth <= 0 {
+		return fmt.Errorf("Thin pool can't be extended any further")
+	}
+
+	vgFree, err := d.volumeGroupFreeSize(d.config["lvm.vg_name"])
+	if err != nil {
+		return err
+	}
+
+	if dataGrowth+metadataGrowth > vgFree {
+		return fmt.Errorf("Not enough free space in volume group %q (%d bytes needed, %d bytes available)", d.config["lvm.vg_name"], dataGrowth+metadataGrowth, vgFree)
+	}
+
+	if metadataGrowth > 0 {
+		_, err = subprocess.TryRunCommand("lvextend", "--poolmetadatasize", fmt.Sprintf("+%db", metadataGrowth), thinPoolPath)
+		if err != nil {
+			return fmt.Errorf("Error extending LVM thin pool metadata %q: %w", thinPoolPath, err)
+		}
+	}
+
+	if dataGrowth > 0 {
+		_, err = subprocess.TryRunCommand("lvextend", "-L", fmt.Sprintf("+%db", dataGrowth), thinPoolPath)
+		if err != nil {
+			return fmt.Errorf("Error extending LVM thin pool %q: %w", thinPoolPath, err)
+		}
+	}
+
+	return nil
+}
+
 // parseLogicalVolumeSnapshot parses a raw logical volume name (from lvs command) and checks whether it is a
 // snapshot of the supplied parent volume. Returns unescaped parsed snapshot name if snapshot volume recognised,
 // empty string if not. The parent is required due to limitations in the naming scheme that Incus has historically
@@ -27,3 +27,9 @@ type VolumeFiller struct {
 
 	Fingerprint string // If the Filler will unpack an image, it should be this fingerprint.
 }
+
+// PoolAlert represents a condition of a storage pool requiring the attention of the administrator.
+type PoolAlert struct {
+	Message  string // Description of the condition.
+	Critical bool   // Whether the pool is about to fail.
+}
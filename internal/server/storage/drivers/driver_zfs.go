@@ -1,6 +1,7 @@
 package drivers
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -471,6 +472,18 @@ func (d *zfs) Validate(config map[string]string) error {
 			return validate.IsBool(value)
 		}),
 		"zfs.export": validate.Optional(validate.IsBool),
+		"zfs.key_url": validate.Optional(func(value string) error {
+			err := validate.IsRequestURL(value)
+			if err != nil {
+				return err
+			}
+
+			if !strings.HasPrefix(value, "https://") {
+				return fmt.Errorf("Key URL must use HTTPS")
+			}
+
+			return nil
+		}),
 	}
 
 	return d.validatePool(config, rules, d.commonVolumeRules())
@@ -579,6 +592,12 @@ func (d *zfs) Mount() (bool, error) {
 		return false, err
 	}
 
+	// Load the encryption key if the pool is encrypted.
+	err = d.ensureKeyLoaded()
+	if err != nil {
+		return false, err
+	}
+
 	// Apply our default configuration.
 	err = d.ensureInitialDatasets(true)
 	if err != nil {
@@ -652,6 +671,144 @@ func (d *zfs) GetResources() (*api.ResourcesStoragePool, error) {
 	return &res, nil
 }
 
+// GetKey returns the status of the encryption key of the storage pool.
+func (d *zfs) GetKey() (*api.StoragePoolKey, error) {
+	root, err := d.encryptionRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	if root == "" {
+		return &api.StoragePoolKey{Encryption: "off"}, nil
+	}
+
+	key := api.StoragePoolKey{EncryptionRoot: root}
+
+	key.Encryption, err = d.getDatasetProperty(root, "encryption")
+	if err != nil {
+		return nil, err
+	}
+
+	key.KeyFormat, err = d.getDatasetProperty(root, "keyformat")
+	if err != nil {
+		return nil, err
+	}
+
+	key.KeyStatus, err = d.getDatasetProperty(root, "keystatus")
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// LoadKey loads the encryption key of the storage pool.
+// If no key is provided, it is fetched from zfs.key_url or from the key location of the encryption root.
+func (d *zfs) LoadKey(key string) error {
+	_, err := d.importPool()
+	if err != nil {
+		return err
+	}
+
+	if key == "" {
+		return d.ensureKeyLoaded()
+	}
+
+	root, err := d.encryptionRoot()
+	if err != nil {
+		return err
+	}
+
+	if root == "" {
+		return fmt.Errorf("Storage pool %q isn't encrypted", d.name)
+	}
+
+	keyStatus, err := d.getDatasetProperty(root, "keystatus")
+	if err != nil {
+		return err
+	}
+
+	if keyStatus == "available" {
+		return nil
+	}
+
+	return d.loadKey(root, key)
+}
+
+// UnloadKey unloads the encryption key of the storage pool.
+// This fails if any of the volumes of the pool are still mounted.
+func (d *zfs) UnloadKey() error {
+	root, err := d.encryptionRoot()
+	if err != nil {
+		return err
+	}
+
+	if root == "" {
+		return fmt.Errorf("Storage pool %q isn't encrypted", d.name)
+	}
+
+	keyStatus, err := d.getDatasetProperty(root, "keystatus")
+	if err != nil {
+		return err
+	}
+
+	if keyStatus != "available" {
+		return nil
+	}
+
+	_, err = subprocess.RunCommand("zfs", "unload-key", root)
+	if err != nil {
+		return fmt.Errorf("Failed unloading encryption key of %q: %w", root, err)
+	}
+
+	return nil
+}
+
+// ChangeKey replaces the encryption key of the storage pool, keeping its format.
+// The key location of the encryption root is changed to prompt as the key is then managed by Incus.
+// When zfs.key_url is set, the new key is stored there first so it can be fetched on the next mount.
+func (d *zfs) ChangeKey(newKey string) error {
+	if newKey == "" {
+		return fmt.Errorf("A new key is required")
+	}
+
+	root, err := d.encryptionRoot()
+	if err != nil {
+		return err
+	}
+
+	if root == "" {
+		return fmt.Errorf("Storage pool %q isn't encrypted", d.name)
+	}
+
+	reverter := revert.New()
+	defer reverter.Fail()
+
+	keyURL := d.config["zfs.key_url"]
+	if keyURL != "" {
+		oldKey, err := d.fetchKey(keyURL)
+		if err != nil {
+			return fmt.Errorf("Failed fetching current encryption key of %q: %w", root, err)
+		}
+
+		err = d.storeKey(keyURL, newKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing new encryption key of %q: %w", root, err)
+		}
+
+		reverter.Add(func() { _ = d.storeKey(keyURL, oldKey) })
+	}
+
+	err = subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(newKey), nil, "zfs", "change-key", "-o", "keylocation=prompt", root)
+	if err != nil {
+		return fmt.Errorf("Failed changing encryption key of %q: %w", root, err)
+	}
+
+	reverter.Success()
+
+	return nil
+}
+
 // MigrationType returns the type of transfer methods to be used when doing migrations between pools in preference order.
 func (d *zfs) MigrationTypes(contentType ContentType, refresh bool, copySnapshots bool) []localMigration.Type {
 	var rsyncFeatures []string
@@ -2,13 +2,17 @@ package drivers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -470,6 +474,143 @@ func (d *zfs) delegateDataset(vol Volume, pid int) error {
 	return nil
 }
 
+// encryptionRoot returns the dataset holding the encryption key of the pool dataset, or an empty string if
+// the pool isn't encrypted.
+func (d *zfs) encryptionRoot() (string, error) {
+	root, err := d.getDatasetProperty(d.config["zfs.pool_name"], "encryptionroot")
+	if err != nil {
+		return "", err
+	}
+
+	if root == "-" {
+		return "", nil
+	}
+
+	return root, nil
+}
+
+// ensureKeyLoaded loads the encryption key of the pool if it isn't loaded yet, fetching it from zfs.key_url or
+// from the key location of the encryption root (unless that's prompt).
+func (d *zfs) ensureKeyLoaded() error {
+	root, err := d.encryptionRoot()
+	if err != nil {
+		return err
+	}
+
+	if root == "" {
+		return nil
+	}
+
+	keyStatus, err := d.getDatasetProperty(root, "keystatus")
+	if err != nil {
+		return err
+	}
+
+	if keyStatus == "available" {
+		return nil
+	}
+
+	if d.config["zfs.key_url"] != "" {
+		key, err := d.fetchKey(d.config["zfs.key_url"])
+		if err != nil {
+			return fmt.Errorf("Failed fetching encryption key of %q: %w", root, err)
+		}
+
+		return d.loadKey(root, key)
+	}
+
+	keyLocation, err := d.getDatasetProperty(root, "keylocation")
+	if err != nil {
+		return err
+	}
+
+	if keyLocation == "prompt" {
+		return fmt.Errorf("Encryption key of %q isn't loaded", root)
+	}
+
+	_, err = subprocess.RunCommand("zfs", "load-key", root)
+	if err != nil {
+		return fmt.Errorf("Failed loading encryption key of %q from %q: %w", root, keyLocation, err)
+	}
+
+	return nil
+}
+
+// loadKey loads the provided encryption key for the encryption root.
+func (d *zfs) loadKey(root string, key string) error {
+	err := subprocess.RunCommandWithFds(context.TODO(), strings.NewReader(key), nil, "zfs", "load-key", "-L", "prompt", root)
+	if err != nil {
+		return fmt.Errorf("Failed loading encryption key of %q: %w", root, err)
+	}
+
+	return nil
+}
+
+// keyRequest sends a request to the key management service.
+// The URL is left out of the returned errors as it may hold credentials.
+func (d *zfs) keyRequest(ctx context.Context, method string, keyURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, keyURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid key URL")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return nil, urlErr.Err
+		}
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// storeKey stores an encryption key in a key management service, the request body being the key.
+func (d *zfs) storeKey(keyURL string, key string) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	resp, err := d.keyRequest(ctx, http.MethodPut, keyURL, strings.NewReader(key))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Unexpected response status %q", resp.Status)
+	}
+
+	return nil
+}
+
+// fetchKey retrieves an encryption key from a key management service, the response body being the key.
+func (d *zfs) fetchKey(keyURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+
+	resp, err := d.keyRequest(ctx, http.MethodGet, keyURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Unexpected response status %q", resp.Status)
+	}
+
+	// Keys are at most 512 bytes long.
+	key, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+
+	return string(key), nil
+}
+
 // ZFSSupportsDelegation returns true if the ZFS version on the system supports user namespace delegation.
 func ZFSSupportsDelegation() bool {
 	return zfsDelegate
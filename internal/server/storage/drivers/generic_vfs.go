@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -165,6 +166,86 @@ func genericVFSMigrateVolume(d Driver, s *state.State, vol Volume, conn io.ReadW
 		return ErrNotSupported
 	}
 
+	blockStream := slices.Contains(volSrcArgs.MigrationType.Features, migration.RsyncFeatureBlockStream)
+
+	// Filesystem volumes can only be read from their block device if nothing else is using them.
+	volInUse := vol.MountInUse()
+
+	// Define function to send a filesystem volume as a block stream if the target accepts it.
+	// Returns false if the volume should be sent using rsync instead.
+	sendFSVolBlockStream := func(vol Volume, conn io.ReadWriteCloser, mountPath string, wrapper *ioprogress.ProgressTracker) (bool, error) {
+		offer := blockStreamOffer{}
+		devPath := ""
+
+		if vol.IsBlockBacked() && (vol.IsSnapshot() || !volInUse) {
+			var err error
+
+			devPath, offer.Filesystem, err = blockStreamDevice(mountPath)
+			if err != nil {
+				return false, err
+			}
+
+			offer.Size, err = BlockDiskSizeBytes(devPath)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		err := blockStreamWriteOffer(conn, offer)
+		if err != nil {
+			return false, err
+		}
+
+		if offer.Filesystem == "" {
+			return false, nil
+		}
+
+		accepted, err := blockStreamReadReply(conn)
+		if err != nil {
+			return false, err
+		}
+
+		if !accepted {
+			d.Logger().Debug("Block stream refused by target, falling back to rsync", logger.Ctx{"volName": vol.name})
+			return false, nil
+		}
+
+		sendDev := func(op *operations.Operation) error {
+			// Close when done to indicate to target side we are finished sending this volume.
+			defer func() { _ = conn.Close() }()
+
+			from, err := os.Open(devPath)
+			if err != nil {
+				return fmt.Errorf("Error opening device for reading %q: %w", devPath, err)
+			}
+
+			defer func() { _ = from.Close() }()
+
+			fromPipe := io.ReadCloser(from)
+			if wrapper != nil {
+				fromPipe = &ioprogress.ProgressReader{
+					ReadCloser: fromPipe,
+					Tracker:    wrapper,
+				}
+			}
+
+			d.Logger().Debug("Sending filesystem volume as block stream", logger.Ctx{"volName": vol.name, "devPath": devPath, "size": offer.Size})
+			err = blockStreamSend(conn, fromPipe, offer.Size)
+			if err != nil {
+				return fmt.Errorf("Error sending %q as block stream: %w", devPath, err)
+			}
+
+			return from.Close()
+		}
+
+		// Snapshots are mounted read-only, other volumes are unmounted to get a consistent copy.
+		if vol.IsSnapshot() {
+			return true, sendDev(op)
+		}
+
+		return true, vol.UnmountTask(sendDev, true, op)
+	}
+
 	// Define function to send a filesystem volume.
 	sendFSVol := func(vol Volume, conn io.ReadWriteCloser, mountPath string) error {
 		var wrapper *ioprogress.ProgressTracker
@@ -172,6 +253,13 @@ func genericVFSMigrateVolume(d Driver, s *state.State, vol Volume, conn io.ReadW
 			wrapper = localMigration.ProgressTracker(op, "fs_progress", vol.name)
 		}
 
+		if blockStream && vol.contentType == ContentTypeFS {
+			sent, err := sendFSVolBlockStream(vol, conn, mountPath, wrapper)
+			if err != nil || sent {
+				return err
+			}
+		}
+
 		path := internalUtil.AddSlash(mountPath)
 
 		d.Logger().Debug("Sending filesystem volume", logger.Ctx{"volName": vol.name, "path": path, "bwlimit": bwlimit, "rsyncArgs": rsyncArgs})
@@ -216,10 +304,23 @@ func genericVFSMigrateVolume(d Driver, s *state.State, vol Volume, conn io.ReadW
 			}
 		}
 
-		d.Logger().Debug("Sending block volume", logger.Ctx{"volName": vol.name, "path": path})
-		_, err = io.Copy(conn, fromPipe)
-		if err != nil {
-			return fmt.Errorf("Error copying %q to migration connection: %w", path, err)
+		if blockStream {
+			size, err := BlockDiskSizeBytes(path)
+			if err != nil {
+				return fmt.Errorf("Error getting size of %q: %w", path, err)
+			}
+
+			d.Logger().Debug("Sending block volume as block stream", logger.Ctx{"volName": vol.name, "path": path, "size": size})
+			err = blockStreamSend(conn, fromPipe, size)
+			if err != nil {
+				return fmt.Errorf("Error sending %q as block stream: %w", path, err)
+			}
+		} else {
+			d.Logger().Debug("Sending block volume", logger.Ctx{"volName": vol.name, "path": path})
+			_, err = io.Copy(conn, fromPipe)
+			if err != nil {
+				return fmt.Errorf("Error copying %q to migration connection: %w", path, err)
+			}
 		}
 
 		err = from.Close()
@@ -305,12 +406,132 @@ func genericVFSCreateVolumeFromMigration(d Driver, initVolume func(vol Volume) (
 		revert.Add(func() { _ = d.DeleteVolume(vol, op) })
 	}
 
+	blockStream := slices.Contains(volTargetArgs.MigrationType.Features, migration.RsyncFeatureBlockStream)
+
+	// Define function to receive a filesystem volume as a block stream if offered by the source and usable for
+	// the volume. The block stream is always written to the main volume's block device.
+	// Returns false if the volume is being sent using rsync instead.
+	recvFSVolBlockStream := func(volName string, conn io.ReadWriteCloser, path string, wrapper *ioprogress.ProgressTracker) (bool, error) {
+		offer, err := blockStreamReadOffer(conn)
+		if err != nil {
+			return false, err
+		}
+
+		if offer.Filesystem == "" {
+			return false, nil
+		}
+
+		// The received filesystem must match the one configured for the volume for it to be mountable.
+		devPath := ""
+		accept := vol.IsBlockBacked() && offer.Filesystem == vol.ConfigBlockFilesystem()
+		if accept {
+			devPath, _, err = blockStreamDevice(path)
+			if err != nil {
+				d.Logger().Warn("Failed getting block device for block stream, falling back to rsync", logger.Ctx{"volName": volName, "err": err})
+				accept = false
+			}
+		}
+
+		err = blockStreamWriteReply(conn, accept)
+		if err != nil {
+			return false, err
+		}
+
+		if !accept {
+			return false, nil
+		}
+
+		// Grow the volume if needed to fit the received filesystem.
+		devSize, err := BlockDiskSizeBytes(devPath)
+		if err != nil {
+			return false, err
+		}
+
+		if devSize < offer.Size {
+			err = d.SetVolumeQuota(vol, fmt.Sprintf("%d", offer.Size), false, op)
+			if err != nil {
+				return false, fmt.Errorf("Failed growing volume to receive block stream: %w", err)
+			}
+
+			devSize, err = BlockDiskSizeBytes(devPath)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		fromPipe := io.ReadCloser(conn)
+		if wrapper != nil {
+			fromPipe = &ioprogress.ProgressReader{
+				ReadCloser: fromPipe,
+				Tracker:    wrapper,
+			}
+		}
+
+		d.Logger().Debug("Receiving filesystem volume as block stream started", logger.Ctx{"volName": volName, "devPath": devPath, "size": offer.Size})
+		defer d.Logger().Debug("Receiving filesystem volume as block stream stopped", logger.Ctx{"volName": volName, "devPath": devPath})
+
+		err = vol.UnmountTask(func(op *operations.Operation) error {
+			to, err := os.OpenFile(devPath, os.O_WRONLY, 0)
+			if err != nil {
+				return fmt.Errorf("Error opening device for writing %q: %w", devPath, err)
+			}
+
+			defer func() { _ = to.Close() }()
+
+			err = blockStreamRecv(to, fromPipe)
+			if err != nil {
+				return fmt.Errorf("Error receiving block stream to %q: %w", devPath, err)
+			}
+
+			err = to.Close()
+			if err != nil {
+				return err
+			}
+
+			// Wait for the sender to indicate it is finished sending this volume.
+			_, err = io.Copy(io.Discard, conn)
+			if err != nil {
+				return err
+			}
+
+			// Avoid conflicts with the source filesystem in case both are on the same host.
+			if renegerateFilesystemUUIDNeeded(offer.Filesystem) {
+				err = regenerateFilesystemUUID(offer.Filesystem, devPath)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}, true, op)
+		if err != nil {
+			return true, err
+		}
+
+		// Make use of the whole volume if it is larger than the received filesystem.
+		if devSize > offer.Size {
+			err = growFileSystem(offer.Filesystem, devPath, vol)
+			if err != nil {
+				return true, err
+			}
+		}
+
+		return true, nil
+	}
+
 	recvFSVol := func(volName string, conn io.ReadWriteCloser, path string) error {
 		var wrapper *ioprogress.ProgressTracker
 		if volTargetArgs.TrackProgress {
 			wrapper = localMigration.ProgressTracker(op, "fs_progress", volName)
 		}
 
+		if blockStream && vol.contentType == ContentTypeFS {
+			received, err := recvFSVolBlockStream(volName, conn, path, wrapper)
+			if err != nil || received {
+				return err
+			}
+		}
+
 		d.Logger().Debug("Receiving filesystem volume started", logger.Ctx{"volName": volName, "path": path, "features": volTargetArgs.MigrationType.Features})
 		defer d.Logger().Debug("Receiving filesystem volume stopped", logger.Ctx{"volName": volName, "path": path})
 
@@ -339,12 +560,25 @@ func genericVFSCreateVolumeFromMigration(d Driver, initVolume func(vol Volume) (
 			}
 		}
 
-		d.Logger().Debug("Receiving block volume started", logger.Ctx{"volName": volName, "path": path})
+		d.Logger().Debug("Receiving block volume started", logger.Ctx{"volName": volName, "path": path, "blockStream": blockStream})
 		defer d.Logger().Debug("Receiving block volume stopped", logger.Ctx{"volName": volName, "path": path})
 
-		_, err = io.Copy(NewSparseFileWrapper(to), fromPipe)
-		if err != nil {
-			return fmt.Errorf("Error copying from migration connection to %q: %w", path, err)
+		if blockStream {
+			err = blockStreamRecv(to, fromPipe)
+			if err != nil {
+				return fmt.Errorf("Error receiving block stream to %q: %w", path, err)
+			}
+
+			// Wait for the sender to indicate it is finished sending this volume.
+			_, err = io.Copy(io.Discard, conn)
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err = io.Copy(NewSparseFileWrapper(to), fromPipe)
+			if err != nil {
+				return fmt.Errorf("Error copying from migration connection to %q: %w", path, err)
+			}
 		}
 
 		return to.Close()
@@ -50,6 +50,17 @@ type Driver interface {
 	Update(changedConfig map[string]string) error
 	ApplyPatch(name string) error
 
+	// Encryption.
+	GetKey() (*api.StoragePoolKey, error)
+	LoadKey(key string) error
+	UnloadKey() error
+	ChangeKey(newKey string) error
+
+	// Maintenance.
+	ScrubPool(op *operations.Operation) (int64, error)
+	BalancePool(op *operations.Operation) error
+	MonitorPool() (*PoolAlert, error)
+
 	// Buckets.
 	ValidateBucket(bucket Volume) error
 	GetBucketURL(bucketName string) *url.URL
@@ -0,0 +1,325 @@
+package drivers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/linux"
+)
+
+// blockStreamBlockSize is the granularity at which blocks only containing zeroes are skipped.
+const blockStreamBlockSize = 64 * 1024
+
+// blockStreamBufferSize is the amount of data read from the source device at once.
+const blockStreamBufferSize = 64 * blockStreamBlockSize
+
+// blockStreamFrameHeaderSize is the size of the offset and length header preceding each frame.
+const blockStreamFrameHeaderSize = 12
+
+// blockStreamOffer is sent ahead of a filesystem volume when the block stream feature has been negotiated.
+// An empty Filesystem indicates that the volume will be sent using rsync instead.
+type blockStreamOffer struct {
+	Filesystem string `json:"filesystem"`
+	Size       int64  `json:"size"`
+}
+
+// blockStreamWriteOffer sends a length prefixed block stream offer.
+func blockStreamWriteOffer(w io.Writer, offer blockStreamOffer) error {
+	data, err := json.Marshal(offer)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w, binary.BigEndian, uint32(len(data)))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// blockStreamReadOffer reads a length prefixed block stream offer.
+func blockStreamReadOffer(r io.Reader) (*blockStreamOffer, error) {
+	var length uint32
+	err := binary.Read(r, binary.BigEndian, &length)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > 4096 {
+		return nil, fmt.Errorf("Block stream offer is too large")
+	}
+
+	data := make([]byte, length)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := blockStreamOffer{}
+	err = json.Unmarshal(data, &offer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &offer, nil
+}
+
+// blockStreamWriteReply tells the sender whether the offered block stream is accepted.
+func blockStreamWriteReply(w io.Writer, accept bool) error {
+	reply := []byte{0}
+	if accept {
+		reply[0] = 1
+	}
+
+	_, err := w.Write(reply)
+	return err
+}
+
+// blockStreamReadReply returns whether the receiver accepted the offered block stream.
+func blockStreamReadReply(r io.Reader) (bool, error) {
+	reply := make([]byte, 1)
+	_, err := io.ReadFull(r, reply)
+	if err != nil {
+		return false, err
+	}
+
+	return reply[0] == 1, nil
+}
+
+// blockStreamDevice returns the block device and filesystem mounted at mountPath.
+func blockStreamDevice(mountPath string) (string, string, error) {
+	fields, err := linux.GetMountinfo(mountPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The filesystem type and mount source follow the separator after the optional fields.
+	sep := slices.Index(fields, "-")
+	if sep < 0 || len(fields) < sep+3 {
+		return "", "", fmt.Errorf("Invalid mountinfo entry for %q", mountPath)
+	}
+
+	devPath := fields[sep+2]
+	if !linux.IsBlockdevPath(devPath) {
+		return "", "", fmt.Errorf("Mount source %q of %q isn't a block device", devPath, mountPath)
+	}
+
+	return devPath, fields[sep+1], nil
+}
+
+// blockStreamSend sends size bytes read from r to w as a block stream.
+// The stream starts with the total size, followed by frames made of an offset, a length and the data of
+// consecutive blocks which aren't only made of zeroes. It ends with an empty frame at the total size and a
+// SHA256 checksum of everything sent before it.
+func blockStreamSend(w io.Writer, r io.Reader, size int64) error {
+	hash := sha256.New()
+	out := io.MultiWriter(w, hash)
+
+	err := binary.Write(out, binary.BigEndian, uint64(size))
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, blockStreamFrameHeaderSize)
+	writeFrame := func(offset int64, data []byte) error {
+		binary.BigEndian.PutUint64(header[0:8], uint64(offset))
+		binary.BigEndian.PutUint32(header[8:12], uint32(len(data)))
+
+		_, err := out.Write(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(data)
+		return err
+	}
+
+	zeroes := make([]byte, blockStreamBlockSize)
+	buf := make([]byte, blockStreamBufferSize)
+
+	var offset int64
+	for offset < size {
+		n := int(min(int64(len(buf)), size-offset))
+
+		_, err := io.ReadFull(r, buf[:n])
+		if err != nil {
+			return fmt.Errorf("Failed reading at offset %d: %w", offset, err)
+		}
+
+		// Send each run of non-zero blocks as a single frame.
+		start := -1
+		for pos := 0; pos < n; pos += blockStreamBlockSize {
+			end := min(pos+blockStreamBlockSize, n)
+
+			if bytes.Equal(buf[pos:end], zeroes[:end-pos]) {
+				if start >= 0 {
+					err = writeFrame(offset+int64(start), buf[start:pos])
+					if err != nil {
+						return err
+					}
+
+					start = -1
+				}
+
+				continue
+			}
+
+			if start < 0 {
+				start = pos
+			}
+		}
+
+		if start >= 0 {
+			err = writeFrame(offset+int64(start), buf[start:n])
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += int64(n)
+	}
+
+	err = writeFrame(size, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(hash.Sum(nil))
+	return err
+}
+
+// blockStreamZero zeroes a region of a block device, punching a hole if supported.
+func blockStreamZero(f *os.File, offset int64, length int64) error {
+	err := unix.Fallocate(int(f.Fd()), unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, offset, length)
+	if err == nil {
+		return nil
+	}
+
+	zeroes := make([]byte, min(length, blockStreamBufferSize))
+	for length > 0 {
+		n := min(length, int64(len(zeroes)))
+
+		_, err := f.WriteAt(zeroes[:n], offset)
+		if err != nil {
+			return err
+		}
+
+		offset += n
+		length -= n
+	}
+
+	return nil
+}
+
+// blockStreamRecv writes a block stream read from r to f, which can be a regular file or a block device.
+// Regions which aren't part of the stream are zeroed. Returns an error if the stream doesn't match its checksum.
+func blockStreamRecv(f *os.File, r io.Reader) error {
+	hash := sha256.New()
+	in := io.TeeReader(r, hash)
+
+	var size uint64
+	err := binary.Read(in, binary.BigEndian, &size)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	regular := fi.Mode().IsRegular()
+	if regular {
+		// Start from an empty sparse file, so that skipped regions don't need zeroing.
+		err = f.Truncate(0)
+		if err != nil {
+			return err
+		}
+
+		err = f.Truncate(int64(size))
+		if err != nil {
+			return err
+		}
+	} else {
+		devSize, err := BlockDiskSizeBytes(f.Name())
+		if err != nil {
+			return err
+		}
+
+		if uint64(devSize) < size {
+			return fmt.Errorf("Block device %q is too small (%d bytes) for the received volume (%d bytes)", f.Name(), devSize, size)
+		}
+	}
+
+	header := make([]byte, blockStreamFrameHeaderSize)
+	buf := make([]byte, blockStreamBufferSize)
+
+	var pos uint64
+	for {
+		_, err = io.ReadFull(in, header)
+		if err != nil {
+			return err
+		}
+
+		offset := binary.BigEndian.Uint64(header[0:8])
+		length := uint64(binary.BigEndian.Uint32(header[8:12]))
+
+		if offset < pos || offset > size || length > size-offset || (length == 0 && offset != size) {
+			return fmt.Errorf("Invalid block stream frame at offset %d", offset)
+		}
+
+		// Zero the skipped region.
+		if !regular && offset > pos {
+			err = blockStreamZero(f, int64(pos), int64(offset-pos))
+			if err != nil {
+				return err
+			}
+		}
+
+		// An empty frame at the end of the volume marks the end of the stream.
+		if length == 0 {
+			break
+		}
+
+		for length > 0 {
+			n := min(length, uint64(len(buf)))
+
+			_, err = io.ReadFull(in, buf[:n])
+			if err != nil {
+				return err
+			}
+
+			_, err = f.WriteAt(buf[:n], int64(offset))
+			if err != nil {
+				return err
+			}
+
+			offset += n
+			length -= n
+		}
+
+		pos = offset
+	}
+
+	checksum := make([]byte, sha256.Size)
+	_, err = io.ReadFull(r, checksum)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(checksum, hash.Sum(nil)) {
+		return fmt.Errorf("Block stream checksum mismatch")
+	}
+
+	return nil
+}
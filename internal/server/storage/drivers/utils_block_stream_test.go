@@ -0,0 +1,96 @@
+package drivers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockStreamTestData returns data made of non-zero and zero regions not aligned with the block size.
+func blockStreamTestData(t *testing.T) []byte {
+	data := make([]byte, 3*blockStreamBufferSize+12345)
+
+	_, err := rand.Read(data[:blockStreamBlockSize+100])
+	require.NoError(t, err)
+
+	_, err = rand.Read(data[blockStreamBufferSize-10 : blockStreamBufferSize+10])
+	require.NoError(t, err)
+
+	_, err = rand.Read(data[len(data)-500:])
+	require.NoError(t, err)
+
+	return data
+}
+
+// Test blockStreamSend and blockStreamRecv.
+func TestBlockStream(t *testing.T) {
+	data := blockStreamTestData(t)
+
+	stream := bytes.Buffer{}
+	err := blockStreamSend(&stream, bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	// Zero blocks are skipped.
+	assert.Less(t, stream.Len(), len(data)/2)
+
+	// Receive into a file with existing content to check it gets replaced.
+	path := filepath.Join(t.TempDir(), "root.img")
+	err = os.WriteFile(path, bytes.Repeat([]byte{0xff}, len(data)*2), 0600)
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+
+	defer func() { _ = f.Close() }()
+
+	err = blockStreamRecv(f, &stream)
+	require.NoError(t, err)
+
+	received, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, received)
+}
+
+// Test that blockStreamRecv detects a corrupted stream.
+func TestBlockStreamCorrupted(t *testing.T) {
+	data := blockStreamTestData(t)
+
+	stream := bytes.Buffer{}
+	err := blockStreamSend(&stream, bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	corrupted := stream.Bytes()
+	corrupted[100] ^= 0xff
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "root.img"))
+	require.NoError(t, err)
+
+	defer func() { _ = f.Close() }()
+
+	err = blockStreamRecv(f, bytes.NewReader(corrupted))
+	assert.ErrorContains(t, err, "checksum mismatch")
+}
+
+// Test blockStreamWriteOffer and blockStreamReadOffer.
+func TestBlockStreamOffer(t *testing.T) {
+	buf := bytes.Buffer{}
+
+	err := blockStreamWriteOffer(&buf, blockStreamOffer{Filesystem: "ext4", Size: 1024})
+	require.NoError(t, err)
+
+	err = blockStreamWriteReply(&buf, true)
+	require.NoError(t, err)
+
+	offer, err := blockStreamReadOffer(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, blockStreamOffer{Filesystem: "ext4", Size: 1024}, *offer)
+
+	accepted, err := blockStreamReadReply(&buf)
+	require.NoError(t, err)
+	assert.True(t, accepted)
+}
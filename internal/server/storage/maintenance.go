@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/lxc/incus/v6/internal/server/operations"
+	"github.com/lxc/incus/v6/internal/server/storage/drivers"
+)
+
+// Pool config keys recording the last maintenance runs on the cluster member.
+const (
+	ScrubConfigLastRun      = "volatile.scrub.last_run"
+	ScrubConfigLastStatus   = "volatile.scrub.last_status"
+	ScrubConfigLastErrors   = "volatile.scrub.last_errors"
+	BalanceConfigLastRun    = "volatile.balance.last_run"
+	BalanceConfigLastStatus = "volatile.balance.last_status"
+)
+
+// MaintenanceStatus returns the value of the last_status keys for the outcome of a maintenance run.
+func MaintenanceStatus(err error) string {
+	if err != nil {
+		return "failure"
+	}
+
+	return "success"
+}
+
+// MaintenanceDate returns the value of the last_run keys for the start of a maintenance run.
+func MaintenanceDate(start time.Time) string {
+	return start.UTC().Format(time.RFC3339)
+}
+
+// Scrub verifies the integrity of the data of the pool and returns the number of errors found.
+func (b *backend) Scrub(op *operations.Operation) (int64, error) {
+	l := b.logger.AddContext(nil)
+	l.Debug("Scrub started")
+	defer l.Debug("Scrub finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return 0, err
+	}
+
+	return b.driver.ScrubPool(op)
+}
+
+// Balance rebalances the data of the pool.
+func (b *backend) Balance(op *operations.Operation) error {
+	l := b.logger.AddContext(nil)
+	l.Debug("Balance started")
+	defer l.Debug("Balance finished")
+
+	err := b.isStatusReady()
+	if err != nil {
+		return err
+	}
+
+	return b.driver.BalancePool(op)
+}
+
+// Monitor checks the health of the pool, letting the driver remediate what it can, and returns an alert if the
+// pool needs attention.
+func (b *backend) Monitor() (*drivers.PoolAlert, error) {
+	err := b.isStatusReady()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.driver.MonitorPool()
+}
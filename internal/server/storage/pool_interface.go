@@ -50,6 +50,14 @@ type Pool interface {
 	ToAPI() api.StoragePool
 
 	GetResources() (*api.ResourcesStoragePool, error)
+	Benchmark(op *operations.Operation) (*api.StoragePoolBenchmark, error)
+	GetKey() (*api.StoragePoolKey, error)
+	LoadKey(key string) error
+	UnloadKey() error
+	ChangeKey(newKey string) error
+	Scrub(op *operations.Operation) (int64, error)
+	Balance(op *operations.Operation) error
+	Monitor() (*drivers.PoolAlert, error)
 	IsUsed() (bool, error)
 	Delete(clientType request.ClientType, op *operations.Operation) error
 	Update(clientType request.ClientType, newDesc string, newConfig map[string]string, op *operations.Operation) error
@@ -66,6 +74,7 @@ type Pool interface {
 	CreateInstance(inst instance.Instance, op *operations.Operation) error
 	CreateInstanceFromBackup(srcBackup backup.Info, srcData io.ReadSeeker, op *operations.Operation) (func(instance.Instance) error, revert.Hook, error)
 	CreateInstanceFromCopy(inst instance.Instance, src instance.Instance, snapshots bool, allowInconsistent bool, op *operations.Operation) error
+	CreateInstanceFromDisk(inst instance.Instance, diskPath string, op *operations.Operation) error
 	CreateInstanceFromImage(inst instance.Instance, fingerprint string, op *operations.Operation) error
 	CreateInstanceFromMigration(inst instance.Instance, conn io.ReadWriteCloser, args migration.VolumeTargetArgs, op *operations.Operation) error
 	RenameInstance(inst instance.Instance, newName string, op *operations.Operation) error
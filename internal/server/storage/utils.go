@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"slices"
@@ -506,11 +507,21 @@ func poolAndVolumeCommonRules(vol *drivers.Volume) map[string]func(string) error
 // validatePoolCommonRules returns a map of pool config rules common to all drivers.
 func validatePoolCommonRules() map[string]func(string) error {
 	rules := map[string]func(string) error{
-		"source":                  validate.IsAny,
-		"source.wipe":             validate.Optional(validate.IsBool),
-		"volatile.initial_source": validate.IsAny,
-		"rsync.bwlimit":           validate.Optional(validate.IsSize),
-		"rsync.compression":       validate.Optional(validate.IsBool),
+		"source":                       validate.IsAny,
+		"source.wipe":                  validate.Optional(validate.IsBool),
+		"volatile.initial_source":      validate.IsAny,
+		BenchmarkConfigSequentialRead:  validate.Optional(validate.IsInt64),
+		BenchmarkConfigSequentialWrite: validate.Optional(validate.IsInt64),
+		BenchmarkConfigRandomRead:      validate.Optional(validate.IsInt64),
+		BenchmarkConfigRandomWrite:     validate.Optional(validate.IsInt64),
+		BenchmarkConfigDate:            validate.IsAny,
+		ScrubConfigLastRun:             validate.IsAny,
+		ScrubConfigLastStatus:          validate.IsAny,
+		ScrubConfigLastErrors:          validate.Optional(validate.IsInt64),
+		BalanceConfigLastRun:           validate.IsAny,
+		BalanceConfigLastStatus:        validate.IsAny,
+		"rsync.bwlimit":                validate.Optional(validate.IsSize),
+		"rsync.compression":            validate.Optional(validate.IsBool),
 	}
 
 	// Add to pool config rules (prefixed with volume.*) which are common for pool and volume.
@@ -625,103 +636,6 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		return -1, fmt.Errorf("Root block path isn't a file: %s", destBlockFile)
 	}
 
-	// convertBlockImage converts the qcow2 block image file into a raw block device. If needed it will attempt
-	// to enlarge the destination volume to accommodate the unpacked qcow2 image file.
-	convertBlockImage := func(v drivers.Volume, imgPath string, dstPath string) (int64, error) {
-		// Get info about qcow2 file. Force input format to qcow2 so we don't rely on qemu-img's detection
-		// logic as that has been known to have vulnerabilities and we only support qcow2 images anyway.
-		// Use prlimit because qemu-img can consume considerable RAM & CPU time if fed a maliciously
-		// crafted disk image. Since cloud tenants are not to be trusted, ensure QEMU is limits to 1 GiB
-		// address space and 2 seconds CPU time, which ought to be more than enough for real world images.
-		cmd := []string{"prlimit", "--cpu=2", "--as=1073741824", "qemu-img", "info", "-f", "qcow2", "--output=json", imgPath}
-		imgJSON, err := apparmor.QemuImg(sysOS, cmd, imgPath, dstPath)
-		if err != nil {
-			return -1, fmt.Errorf("Failed reading image info %q: %w", imgPath, err)
-		}
-
-		imgInfo := struct {
-			Format      string `json:"format"`
-			VirtualSize int64  `json:"virtual-size"`
-		}{}
-
-		err = json.Unmarshal([]byte(imgJSON), &imgInfo)
-		if err != nil {
-			return -1, fmt.Errorf("Failed unmarshalling image info %q: %w (%q)", imgPath, err, imgJSON)
-		}
-
-		// Belt and braces qcow2 check.
-		if imgInfo.Format != "qcow2" {
-			return -1, fmt.Errorf("Unexpected image format %q", imgInfo.Format)
-		}
-
-		// Check whether image is allowed to be unpacked into pool volume. Create a partial image volume
-		// struct and then use it to check that target volume size can be set as needed.
-		imgVolConfig := map[string]string{
-			"volatile.rootfs.size": fmt.Sprintf("%d", imgInfo.VirtualSize),
-		}
-
-		imgVol := drivers.NewVolume(nil, "", drivers.VolumeTypeImage, drivers.ContentTypeBlock, "", imgVolConfig, nil)
-
-		l.Debug("Checking image unpack size")
-		newVolSize, err := vol.ConfigSizeFromSource(imgVol)
-		if err != nil {
-			return -1, err
-		}
-
-		if util.PathExists(dstPath) {
-			volSizeBytes, err := drivers.BlockDiskSizeBytes(dstPath)
-			if err != nil {
-				return -1, fmt.Errorf("Error getting current size of %q: %w", dstPath, err)
-			}
-
-			// If the target volume's size is smaller than the image unpack size, then we need to
-			// increase the target volume's size.
-			if volSizeBytes < imgInfo.VirtualSize {
-				l.Debug("Increasing volume size", logger.Ctx{"imgPath": imgPath, "dstPath": dstPath, "oldSize": volSizeBytes, "newSize": newVolSize, "allowUnsafeResize": allowUnsafeResize})
-				err = vol.SetQuota(newVolSize, allowUnsafeResize, nil)
-				if err != nil {
-					return -1, fmt.Errorf("Error increasing volume size: %w", err)
-				}
-			}
-		}
-
-		// Convert the qcow2 format to a raw block device.
-		l.Debug("Converting qcow2 image to raw disk", logger.Ctx{"imgPath": imgPath, "dstPath": dstPath})
-
-		cmd = []string{
-			"nice", "-n19", // Run with low priority to reduce CPU impact on other processes.
-			"qemu-img", "convert", "-f", "qcow2", "-O", "raw",
-		}
-
-		// Check for Direct I/O support.
-		from, err := os.OpenFile(imgPath, unix.O_DIRECT|unix.O_RDONLY, 0)
-		if err == nil {
-			cmd = append(cmd, "-T", "none")
-			_ = from.Close()
-		}
-
-		to, err := os.OpenFile(dstPath, unix.O_DIRECT|unix.O_RDONLY, 0)
-		if err == nil {
-			cmd = append(cmd, "-t", "none")
-			_ = to.Close()
-		}
-
-		// Check if we should do parallel unpacking.
-		if linux.IsBlockdevPath(dstPath) {
-			cmd = append(cmd, "-W")
-		}
-
-		cmd = append(cmd, imgPath, dstPath)
-
-		_, err = apparmor.QemuImg(sysOS, cmd, imgPath, dstPath)
-
-		if err != nil {
-			return -1, fmt.Errorf("Failed converting image to raw at %q: %w", dstPath, err)
-		}
-
-		return imgInfo.VirtualSize, nil
-	}
-
 	var imgSize int64
 
 	if util.PathExists(imageRootfsFile) {
@@ -732,7 +646,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		}
 
 		// Convert the qcow2 format to a raw block device.
-		imgSize, err = convertBlockImage(vol, imageRootfsFile, destBlockFile)
+		imgSize, err = convertDiskImage(sysOS, vol, imageRootfsFile, "qcow2", destBlockFile, allowUnsafeResize)
 		if err != nil {
 			return -1, err
 		}
@@ -754,7 +668,7 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 		imgPath := filepath.Join(tempDir, "rootfs.img")
 
 		// Convert the qcow2 format to a raw block device.
-		imgSize, err = convertBlockImage(vol, imgPath, destBlockFile)
+		imgSize, err = convertDiskImage(sysOS, vol, imgPath, "qcow2", destBlockFile, allowUnsafeResize)
 		if err != nil {
 			return -1, err
 		}
@@ -776,6 +690,141 @@ func ImageUnpack(imageFile string, vol drivers.Volume, destBlockFile string, sys
 	return imgSize, nil
 }
 
+// diskImageFormat detects the format of a disk image file from its header, returning either "qcow2" or "raw".
+func diskImageFormat(imgPath string) (string, error) {
+	f, err := os.Open(imgPath)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = f.Close() }()
+
+	header := make([]byte, 4)
+	_, err = io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("Failed reading disk image header %q: %w", imgPath, err)
+	}
+
+	if string(header) == "QFI\xfb" {
+		return "qcow2", nil
+	}
+
+	return "raw", nil
+}
+
+// convertDiskImage converts the qcow2 or raw disk image file into a raw block device. If needed it will attempt
+// to enlarge the destination volume to accommodate the converted disk image.
+func convertDiskImage(sysOS *sys.OS, vol drivers.Volume, imgPath string, imgFormat string, dstPath string, allowUnsafeResize bool) (int64, error) {
+	l := logger.Log.AddContext(logger.Ctx{"imgPath": imgPath, "volName": vol.Name()})
+
+	if imgFormat != "qcow2" && imgFormat != "raw" {
+		return -1, fmt.Errorf("Unsupported disk image format %q", imgFormat)
+	}
+
+	// Get info about the image file. Force the input format so we don't rely on qemu-img's detection
+	// logic as that has been known to have vulnerabilities.
+	// Use prlimit because qemu-img can consume considerable RAM & CPU time if fed a maliciously
+	// crafted disk image. Since cloud tenants are not to be trusted, ensure QEMU is limits to 1 GiB
+	// address space and 2 seconds CPU time, which ought to be more than enough for real world images.
+	cmd := []string{"prlimit", "--cpu=2", "--as=1073741824", "qemu-img", "info", "-f", imgFormat, "--output=json", imgPath}
+	imgJSON, err := apparmor.QemuImg(sysOS, cmd, imgPath, dstPath)
+	if err != nil {
+		return -1, fmt.Errorf("Failed reading image info %q: %w", imgPath, err)
+	}
+
+	imgInfo := struct {
+		Format          string `json:"format"`
+		VirtualSize     int64  `json:"virtual-size"`
+		BackingFilename string `json:"backing-filename"`
+		FormatSpecific  struct {
+			Data struct {
+				DataFile string `json:"data-file"`
+			} `json:"data"`
+		} `json:"format-specific"`
+	}{}
+
+	err = json.Unmarshal([]byte(imgJSON), &imgInfo)
+	if err != nil {
+		return -1, fmt.Errorf("Failed unmarshalling image info %q: %w (%q)", imgPath, err, imgJSON)
+	}
+
+	// Belt and braces format check.
+	if imgInfo.Format != imgFormat {
+		return -1, fmt.Errorf("Unexpected image format %q", imgInfo.Format)
+	}
+
+	// Don't let the image reference other files on the host.
+	if imgInfo.BackingFilename != "" || imgInfo.FormatSpecific.Data.DataFile != "" {
+		return -1, fmt.Errorf("Images relying on external files aren't supported")
+	}
+
+	// Check whether image is allowed to be unpacked into pool volume. Create a partial image volume
+	// struct and then use it to check that target volume size can be set as needed.
+	imgVolConfig := map[string]string{
+		"volatile.rootfs.size": fmt.Sprintf("%d", imgInfo.VirtualSize),
+	}
+
+	imgVol := drivers.NewVolume(nil, "", drivers.VolumeTypeImage, drivers.ContentTypeBlock, "", imgVolConfig, nil)
+
+	l.Debug("Checking image unpack size")
+	newVolSize, err := vol.ConfigSizeFromSource(imgVol)
+	if err != nil {
+		return -1, err
+	}
+
+	if util.PathExists(dstPath) {
+		volSizeBytes, err := drivers.BlockDiskSizeBytes(dstPath)
+		if err != nil {
+			return -1, fmt.Errorf("Error getting current size of %q: %w", dstPath, err)
+		}
+
+		// If the target volume's size is smaller than the image unpack size, then we need to
+		// increase the target volume's size.
+		if volSizeBytes < imgInfo.VirtualSize {
+			l.Debug("Increasing volume size", logger.Ctx{"imgPath": imgPath, "dstPath": dstPath, "oldSize": volSizeBytes, "newSize": newVolSize, "allowUnsafeResize": allowUnsafeResize})
+			err = vol.SetQuota(newVolSize, allowUnsafeResize, nil)
+			if err != nil {
+				return -1, fmt.Errorf("Error increasing volume size: %w", err)
+			}
+		}
+	}
+
+	// Convert the image to a raw block device.
+	l.Debug("Converting image to raw disk", logger.Ctx{"imgPath": imgPath, "dstPath": dstPath, "format": imgFormat})
+
+	cmd = []string{
+		"nice", "-n19", // Run with low priority to reduce CPU impact on other processes.
+		"qemu-img", "convert", "-f", imgFormat, "-O", "raw",
+	}
+
+	// Check for Direct I/O support.
+	from, err := os.OpenFile(imgPath, unix.O_DIRECT|unix.O_RDONLY, 0)
+	if err == nil {
+		cmd = append(cmd, "-T", "none")
+		_ = from.Close()
+	}
+
+	to, err := os.OpenFile(dstPath, unix.O_DIRECT|unix.O_RDONLY, 0)
+	if err == nil {
+		cmd = append(cmd, "-t", "none")
+		_ = to.Close()
+	}
+
+	// Check if we should do parallel unpacking.
+	if linux.IsBlockdevPath(dstPath) {
+		cmd = append(cmd, "-W")
+	}
+
+	cmd = append(cmd, imgPath, dstPath)
+
+	_, err = apparmor.QemuImg(sysOS, cmd, imgPath, dstPath)
+	if err != nil {
+		return -1, fmt.Errorf("Failed converting image to raw at %q: %w", dstPath, err)
+	}
+
+	return imgInfo.VirtualSize, nil
+}
+
 // InstanceContentType returns the instance's content type.
 func InstanceContentType(inst instance.Instance) drivers.ContentType {
 	contentType := drivers.ContentTypeFS
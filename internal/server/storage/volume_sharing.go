@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/sys/unix"
+
+	"github.com/lxc/incus/v6/internal/linux"
+	"github.com/lxc/incus/v6/internal/server/db"
+	"github.com/lxc/incus/v6/internal/server/locking"
+	"github.com/lxc/incus/v6/internal/server/project"
+	"github.com/lxc/incus/v6/internal/server/refcount"
+	"github.com/lxc/incus/v6/internal/server/state"
+	"github.com/lxc/incus/v6/internal/server/storage/drivers"
+	"github.com/lxc/incus/v6/shared/logger"
+	"github.com/lxc/incus/v6/shared/subprocess"
+	"github.com/lxc/incus/v6/shared/util"
+)
+
+// Custom volume sharing modes, set through the sharing.mode configuration key.
+const (
+	// VolumeSharingExclusive restricts the volume to the instances of a single cluster member.
+	VolumeSharingExclusive = "exclusive"
+
+	// VolumeSharingNFS exports the volume from a single cluster member to the others through NFS.
+	VolumeSharingNFS = "nfs"
+)
+
+// VolumeSharingNFSMember returns the name of the cluster member exporting the custom volume through NFS.
+// An empty string is returned when the volume isn't shared through NFS.
+func VolumeSharingNFSMember(config map[string]string) string {
+	if config["sharing.mode"] != VolumeSharingNFS {
+		return ""
+	}
+
+	return config["sharing.nfs.member"]
+}
+
+// volumeSharingValidate checks the sharing configuration of a custom volume against the cluster and the other
+// settings of the volume.
+func volumeSharingValidate(s *state.State, config map[string]string) error {
+	if config["sharing.mode"] != VolumeSharingNFS {
+		if config["sharing.nfs.member"] != "" {
+			return fmt.Errorf(`The "sharing.nfs.member" property requires "sharing.mode" to be set to %q`, VolumeSharingNFS)
+		}
+
+		return nil
+	}
+
+	if !s.ServerClustered {
+		return fmt.Errorf("Sharing custom volumes through NFS requires a cluster")
+	}
+
+	member := config["sharing.nfs.member"]
+	if member == "" {
+		return fmt.Errorf(`The "sharing.nfs.member" property is required to share a custom volume through NFS`)
+	}
+
+	err := s.DB.Cluster.Transaction(context.TODO(), func(ctx context.Context, tx *db.ClusterTx) error {
+		_, err := tx.GetNodeByName(ctx, member)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Failed loading cluster member %q exporting the volume: %w", member, err)
+	}
+
+	// Idmapped mounts aren't supported on NFS.
+	if util.IsTrue(config["security.shifted"]) {
+		return fmt.Errorf(`Custom volumes shared through NFS can't use "security.shifted"`)
+	}
+
+	return nil
+}
+
+// volumeSharingNFSExport represents a custom volume exported by this member.
+type volumeSharingNFSExport struct {
+	poolName    string
+	projectName string
+	volumeName  string
+	clients     []string
+}
+
+var volumeSharingNFSExportsMu sync.Mutex
+
+// volumeSharingNFSExports holds the custom volumes exported by this member, keyed by mount path.
+var volumeSharingNFSExports = map[string]volumeSharingNFSExport{}
+
+// volumeSharingNFSHost returns the host part of a cluster member address, as used by exportfs and mount.
+func volumeSharingNFSHost(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+
+	ip := net.ParseIP(host)
+	if ip != nil && ip.To4() == nil {
+		return "[" + host + "]"
+	}
+
+	return host
+}
+
+// volumeSharingNFSOptions returns the export options of a custom volume.
+// The file system ID is derived from the mount path so it remains stable across exports.
+func volumeSharingNFSOptions(path string) string {
+	fsid := uuid.NewSHA1(uuid.NameSpaceURL, []byte("incus:"+path))
+
+	return "rw,sync,no_subtree_check,no_root_squash,fsid=" + fsid.String()
+}
+
+// VolumeSharingNFSSync exports the custom volumes served by this member through NFS to the other cluster
+// members and stops exporting the volumes which aren't served by this member anymore.
+func VolumeSharingNFSSync(ctx context.Context, s *state.State) error {
+	var volumes []db.StorageVolumeArgs
+	var members []db.NodeInfo
+
+	err := s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+		var err error
+
+		volumes, err = tx.GetStoragePoolVolumesWithType(ctx, db.StoragePoolVolumeTypeCustom, true)
+		if err != nil {
+			return fmt.Errorf("Failed getting custom volumes: %w", err)
+		}
+
+		members, err = tx.GetNodes(ctx)
+		if err != nil {
+			return fmt.Errorf("Failed getting cluster members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	clients := []string{}
+	for _, member := range members {
+		if member.Name == s.ServerName {
+			continue
+		}
+
+		clients = append(clients, volumeSharingNFSHost(member.Address))
+	}
+
+	slices.Sort(clients)
+
+	wanted := map[string]volumeSharingNFSExport{}
+	for _, v := range volumes {
+		if VolumeSharingNFSMember(v.Config) != s.ServerName || v.ContentType != db.StoragePoolVolumeContentTypeNameFS {
+			continue
+		}
+
+		path := drivers.GetVolumeMountPath(v.PoolName, drivers.VolumeTypeCustom, project.StorageVolume(v.ProjectName, v.Name))
+		wanted[path] = volumeSharingNFSExport{
+			poolName:    v.PoolName,
+			projectName: v.ProjectName,
+			volumeName:  v.Name,
+			clients:     clients,
+		}
+	}
+
+	volumeSharingNFSExportsMu.Lock()
+	defer volumeSharingNFSExportsMu.Unlock()
+
+	// Stop exporting the volumes which aren't served by this member anymore or to other members.
+	for path, export := range volumeSharingNFSExports {
+		newExport, ok := wanted[path]
+		if ok && slices.Equal(newExport.clients, export.clients) {
+			continue
+		}
+
+		for _, client := range export.clients {
+			_, err := subprocess.RunCommand("exportfs", "-u", client+":"+path)
+			if err != nil {
+				logger.Warn("Failed removing NFS export of custom volume", logger.Ctx{"project": export.projectName, "pool": export.poolName, "volume": export.volumeName, "client": client, "err": err})
+			}
+		}
+
+		if !ok {
+			pool, err := LoadByName(s, export.poolName)
+			if err == nil {
+				_, err = pool.UnmountCustomVolume(export.projectName, export.volumeName, nil)
+			}
+
+			if err != nil {
+				logger.Warn("Failed unmounting custom volume exported through NFS", logger.Ctx{"project": export.projectName, "pool": export.poolName, "volume": export.volumeName, "err": err})
+			}
+		}
+
+		delete(volumeSharingNFSExports, path)
+	}
+
+	// Export the volumes served by this member.
+	for path, export := range wanted {
+		_, ok := volumeSharingNFSExports[path]
+		if ok {
+			continue
+		}
+
+		// Keep the volume mounted for as long as it's exported.
+		pool, err := LoadByName(s, export.poolName)
+		if err != nil {
+			logger.Warn("Failed loading storage pool of custom volume exported through NFS", logger.Ctx{"pool": export.poolName, "err": err})
+			continue
+		}
+
+		_, err = pool.MountCustomVolume(export.projectName, export.volumeName, nil)
+		if err != nil {
+			logger.Warn("Failed mounting custom volume exported through NFS", logger.Ctx{"project": export.projectName, "pool": export.poolName, "volume": export.volumeName, "err": err})
+			continue
+		}
+
+		for _, client := range export.clients {
+			_, err := subprocess.RunCommand("exportfs", "-o", volumeSharingNFSOptions(path), client+":"+path)
+			if err != nil {
+				logger.Warn("Failed exporting custom volume through NFS", logger.Ctx{"project": export.projectName, "pool": export.poolName, "volume": export.volumeName, "client": client, "err": err})
+			}
+		}
+
+		volumeSharingNFSExports[path] = export
+	}
+
+	return nil
+}
+
+// volumeSharingNFSMountName returns the name of the lock and reference counter of a custom volume mounted through NFS.
+func volumeSharingNFSMountName(path string) string {
+	return "storage/nfs/" + path
+}
+
+// VolumeSharingNFSMount mounts a custom volume exported by another cluster member through NFS at its usual
+// mount path and returns that path. The mount is shared by all the instances of this member using the volume.
+func VolumeSharingNFSMount(ctx context.Context, s *state.State, poolName string, projectName string, volumeName string, member string) (string, error) {
+	path := drivers.GetVolumeMountPath(poolName, drivers.VolumeTypeCustom, project.StorageVolume(projectName, volumeName))
+
+	unlock, err := locking.Lock(ctx, volumeSharingNFSMountName(path))
+	if err != nil {
+		return "", err
+	}
+
+	defer unlock()
+
+	if !linux.IsMountPoint(path) {
+		var address string
+		err = s.DB.Cluster.Transaction(ctx, func(ctx context.Context, tx *db.ClusterTx) error {
+			node, err := tx.GetNodeByName(ctx, member)
+			if err != nil {
+				return err
+			}
+
+			address = node.Address
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("Failed getting address of cluster member %q: %w", member, err)
+		}
+
+		err = os.MkdirAll(path, 0o711)
+		if err != nil {
+			return "", fmt.Errorf("Failed creating mount path %q: %w", path, err)
+		}
+
+		_, err = subprocess.RunCommand("mount", "-t", "nfs4", "-o", "hard", volumeSharingNFSHost(address)+":"+path, path)
+		if err != nil {
+			return "", fmt.Errorf("Failed mounting custom volume %q exported through NFS by cluster member %q: %w", volumeName, member, err)
+		}
+	}
+
+	refcount.Increment(volumeSharingNFSMountName(path), 1)
+
+	return path, nil
+}
+
+// VolumeSharingNFSUnmount releases a custom volume mounted through NFS, unmounting it when no longer in use.
+func VolumeSharingNFSUnmount(ctx context.Context, poolName string, projectName string, volumeName string) error {
+	path := drivers.GetVolumeMountPath(poolName, drivers.VolumeTypeCustom, project.StorageVolume(projectName, volumeName))
+
+	unlock, err := locking.Lock(ctx, volumeSharingNFSMountName(path))
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	if refcount.Decrement(volumeSharingNFSMountName(path), 1) > 0 {
+		return nil
+	}
+
+	if linux.IsMountPoint(path) {
+		err = unix.Unmount(path, 0)
+		if err != nil {
+			return fmt.Errorf("Failed unmounting custom volume %q mounted through NFS: %w", volumeName, err)
+		}
+	}
+
+	return nil
+}
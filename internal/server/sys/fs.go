@@ -83,6 +83,7 @@ func (s *OS) initStorageDirs() error {
 		mode os.FileMode
 	}{
 		{filepath.Join(s.VarDir, "backups", "custom"), 0700},
+		{filepath.Join(s.VarDir, "backups", "database"), 0700},
 		{filepath.Join(s.VarDir, "backups", "instances"), 0700},
 	}
 
@@ -0,0 +1,19 @@
+package template
+
+import (
+	"fmt"
+	"io"
+)
+
+// NullLoader is a pong2 compatible file loader which refuses all accesses.
+type NullLoader struct{}
+
+// Abs returns the filename unchanged.
+func (l NullLoader) Abs(base string, name string) string {
+	return name
+}
+
+// Get always fails as templates aren't allowed to load other files.
+func (l NullLoader) Get(path string) (io.Reader, error) {
+	return nil, fmt.Errorf("Loading files from templates isn't allowed")
+}
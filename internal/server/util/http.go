@@ -167,18 +167,9 @@ func CheckTrustState(cert x509.Certificate, trustedCerts map[string]x509.Certifi
 
 		if ca != nil && cert.CheckSignatureFrom(ca) == nil {
 			// Check whether the certificate has been revoked.
-			crl := networkCert.CRL()
-
-			if crl != nil {
-				if crl.CheckSignatureFrom(ca) != nil {
-					return false, "" // CRL not signed by CA
-				}
-
-				for _, revoked := range crl.RevokedCertificates {
-					if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
-						return false, "" // Certificate is revoked, so not trusted anymore.
-					}
-				}
+			revoked, err := CertificateRevoked(&cert, networkCert)
+			if err != nil || revoked {
+				return false, "" // Certificate is revoked or CRL not signed by CA, so not trusted anymore.
 			}
 
 			// Certificate not revoked, so trust it as is signed by CA cert.
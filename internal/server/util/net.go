@@ -85,6 +85,7 @@ func ServerTLSConfig(cert *localtls.CertInfo) *tls.Config {
 		pool.AddCert(cert.CA())
 		config.RootCAs = pool
 		config.ClientCAs = pool
+		config.VerifyConnection = verifyConnectionRevocation(cert)
 
 		logger.Infof("Incus is in CA mode, only CA-signed certificates will be allowed")
 	}
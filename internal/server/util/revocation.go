@@ -0,0 +1,199 @@
+package util
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/lxc/incus/v6/shared/logger"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// ocspDefaultTTL is how long an OCSP response without a next update time is cached for.
+const ocspDefaultTTL = time.Hour
+
+// ocspTimeout is the maximum time spent querying an OCSP responder.
+const ocspTimeout = 5 * time.Second
+
+// ocspEnabled indicates whether certificates signed by the CA are checked against their OCSP responder.
+var ocspEnabled atomic.Bool
+
+// ocspStrict indicates whether certificates are considered revoked when their OCSP responder can't be queried.
+var ocspStrict atomic.Bool
+
+// ocspQueries deduplicates concurrent queries for the same certificate.
+var ocspQueries singleflight.Group
+
+type ocspCacheEntry struct {
+	revoked bool
+	expiry  time.Time
+}
+
+var ocspCacheMu sync.Mutex
+var ocspCache = map[string]ocspCacheEntry{}
+
+// tlsHandshakesRejected counts the TLS handshakes rejected because the client certificate was revoked.
+var tlsHandshakesRejected atomic.Int64
+
+// SetOCSPChecking enables or disables checking certificates signed by the CA against their OCSP responder.
+// When strict, certificates whose OCSP responder can't be queried aren't trusted.
+// The cached OCSP responses are cleared.
+func SetOCSPChecking(enabled bool, strict bool) {
+	ocspCacheMu.Lock()
+	defer ocspCacheMu.Unlock()
+
+	ocspEnabled.Store(enabled)
+	ocspStrict.Store(strict)
+	ocspCache = map[string]ocspCacheEntry{}
+}
+
+// TLSHandshakesRejected returns the number of TLS handshakes rejected because of a revoked client certificate.
+func TLSHandshakesRejected() int64 {
+	return tlsHandshakesRejected.Load()
+}
+
+// CertificateRevoked checks whether a certificate signed by the CA of the network certificate has been revoked,
+// either through the CRL or, if enabled, through the OCSP responder of the certificate.
+// An error is returned if the CRL isn't signed by the CA or, in strict mode, if the OCSP responder can't be
+// queried, in which case the certificate shouldn't be trusted.
+func CertificateRevoked(cert *x509.Certificate, networkCert *localtls.CertInfo) (bool, error) {
+	ca := networkCert.CA()
+
+	crl := networkCert.CRL()
+	if crl != nil {
+		err := crl.CheckSignatureFrom(ca)
+		if err != nil {
+			return false, fmt.Errorf("Revocation list isn't signed by the CA: %w", err)
+		}
+
+		for _, revoked := range crl.RevokedCertificates {
+			if cert.SerialNumber.Cmp(revoked.SerialNumber) == 0 {
+				return true, nil
+			}
+		}
+	}
+
+	if ocspEnabled.Load() && len(cert.OCSPServer) > 0 {
+		return ocspRevoked(cert, ca)
+	}
+
+	return false, nil
+}
+
+// ocspRevoked queries the OCSP responder of the certificate, caching its response until its next update.
+// Concurrent queries for the same certificate share a single request to the responder.
+// Failures to reach the responder are logged and, unless in strict mode, the certificate is then considered valid.
+func ocspRevoked(cert *x509.Certificate, issuer *x509.Certificate) (bool, error) {
+	key := cert.SerialNumber.String()
+
+	ocspCacheMu.Lock()
+	entry, ok := ocspCache[key]
+	ocspCacheMu.Unlock()
+
+	if ok && time.Now().Before(entry.expiry) {
+		return entry.revoked, nil
+	}
+
+	result, err, _ := ocspQueries.Do(key, func() (any, error) {
+		resp, err := ocspQuery(cert, issuer)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := ocspCacheEntry{
+			revoked: resp.Status == ocsp.Revoked,
+			expiry:  resp.NextUpdate,
+		}
+
+		if entry.expiry.IsZero() {
+			entry.expiry = time.Now().Add(ocspDefaultTTL)
+		}
+
+		ocspCacheMu.Lock()
+		ocspCache[key] = entry
+		ocspCacheMu.Unlock()
+
+		return entry, nil
+	})
+	if err != nil {
+		logger.Warn("Failed checking certificate against OCSP responder", logger.Ctx{"serial": key, "err": err})
+
+		if ocspStrict.Load() {
+			return false, fmt.Errorf("Failed checking certificate against OCSP responder: %w", err)
+		}
+
+		return false, nil
+	}
+
+	entry, ok = result.(ocspCacheEntry)
+	if !ok {
+		return false, fmt.Errorf("Unexpected OCSP result type %T", result)
+	}
+
+	return entry.revoked, nil
+}
+
+// ocspQuery sends an OCSP request for the certificate to the first responder listed in it.
+func ocspQuery(cert *x509.Certificate, issuer *x509.Certificate) (*ocsp.Response, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{Timeout: ocspTimeout}
+
+	httpResp, err := client.Post(cert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = httpResp.Body.Close() }()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected OCSP responder status %q", httpResp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1024*1024))
+	if err != nil {
+		return nil, err
+	}
+
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
+
+// verifyConnectionRevocation returns a function rejecting TLS connections from clients presenting a revoked
+// certificate signed by the CA of the network certificate.
+func verifyConnectionRevocation(networkCert *localtls.CertInfo) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return nil
+		}
+
+		cert := state.PeerCertificates[0]
+		if cert.CheckSignatureFrom(networkCert.CA()) != nil {
+			return nil // Not signed by the CA, left to the trust store.
+		}
+
+		revoked, err := CertificateRevoked(cert, networkCert)
+		if err == nil && !revoked {
+			return nil
+		}
+
+		tlsHandshakesRejected.Add(1)
+
+		if err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Client certificate has been revoked")
+	}
+}
@@ -0,0 +1,233 @@
+package util_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/lxc/incus/v6/internal/server/util"
+	localtls "github.com/lxc/incus/v6/shared/tls"
+)
+
+// revocationTestCert creates a certificate with the given serial number signed by the CA.
+func revocationTestCert(t *testing.T, serial int64, ca *x509.Certificate, caKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+	}
+
+	parent := template
+	signer := key
+	if ca != nil {
+		parent = ca
+		signer = caKey
+	} else {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+// revocationTestNetworkCert returns a network certificate in CA mode, along with a revocation list of the
+// given certificates.
+func revocationTestNetworkCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, revoked ...*x509.Certificate) *localtls.CertInfo {
+	dir := t.TempDir()
+
+	writePEM := func(name string, blockType string, data []byte) {
+		err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0600)
+		require.NoError(t, err)
+	}
+
+	server, serverKey := revocationTestCert(t, 2, ca, caKey)
+
+	entries := []x509.RevocationListEntry{}
+	for _, cert := range revoked {
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()})
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, ca, caKey)
+	require.NoError(t, err)
+
+	serverKeyData, err := x509.MarshalECPrivateKey(serverKey)
+	require.NoError(t, err)
+
+	writePEM("server.crt", "CERTIFICATE", server.Raw)
+	writePEM("server.key", "EC PRIVATE KEY", serverKeyData)
+	writePEM("server.ca", "CERTIFICATE", ca.Raw)
+	writePEM("ca.crl", "X509 CRL", crl)
+
+	networkCert, err := localtls.KeyPairAndCA(dir, "server", localtls.CertServer, false)
+	require.NoError(t, err)
+
+	return networkCert
+}
+
+// revocationTestSetup returns a network certificate in CA mode along with a revoked and a valid client
+// certificate signed by the CA.
+func revocationTestSetup(t *testing.T) (*localtls.CertInfo, tls.Certificate, tls.Certificate) {
+	ca, caKey := revocationTestCert(t, 1, nil, nil)
+	revoked, revokedKey := revocationTestCert(t, 3, ca, caKey)
+	valid, validKey := revocationTestCert(t, 4, ca, caKey)
+
+	networkCert := revocationTestNetworkCert(t, ca, caKey, revoked)
+
+	return networkCert,
+		tls.Certificate{Certificate: [][]byte{revoked.Raw}, PrivateKey: revokedKey, Leaf: revoked},
+		tls.Certificate{Certificate: [][]byte{valid.Raw}, PrivateKey: validKey, Leaf: valid}
+}
+
+// Test CertificateRevoked against a revocation list.
+func TestCertificateRevoked(t *testing.T) {
+	networkCert, revoked, valid := revocationTestSetup(t)
+
+	isRevoked, err := util.CertificateRevoked(revoked.Leaf, networkCert)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+
+	isRevoked, err = util.CertificateRevoked(valid.Leaf, networkCert)
+	require.NoError(t, err)
+	assert.False(t, isRevoked)
+}
+
+// Test that TLS handshakes with a revoked client certificate are rejected and counted.
+func TestServerTLSConfigRevoked(t *testing.T) {
+	networkCert, revoked, valid := revocationTestSetup(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer func() { _ = listener.Close() }()
+
+	handshake := func(clientCert tls.Certificate) error {
+		go func() {
+			client, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+				Certificates:       []tls.Certificate{clientCert},
+				InsecureSkipVerify: true,
+			})
+			if err != nil {
+				return
+			}
+
+			// Wait for the server to be done with the connection.
+			_, _ = io.Copy(io.Discard, client)
+			_ = client.Close()
+		}()
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+
+		server := tls.Server(conn, util.ServerTLSConfig(networkCert))
+		defer func() { _ = server.Close() }()
+
+		return server.Handshake()
+	}
+
+	rejected := util.TLSHandshakesRejected()
+
+	err = handshake(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, rejected, util.TLSHandshakesRejected())
+
+	err = handshake(revoked)
+	assert.ErrorContains(t, err, "revoked")
+	assert.Equal(t, rejected+1, util.TLSHandshakesRejected())
+}
+
+// Test that OCSP responses are cached until their next update and that unreachable responders are only
+// trusted outside of strict mode.
+func TestCertificateRevokedOCSP(t *testing.T) {
+	ca, caKey := revocationTestCert(t, 1, nil, nil)
+	networkCert := revocationTestNetworkCert(t, ca, caKey)
+
+	var requests atomic.Int64
+	status := ocsp.Revoked
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		req, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, caKey)
+		require.NoError(t, err)
+
+		_, _ = w.Write(resp)
+	}))
+	defer responder.Close()
+
+	cert, _ := revocationTestCert(t, 10, ca, caKey)
+	cert.OCSPServer = []string{responder.URL}
+
+	util.SetOCSPChecking(true, false)
+	defer util.SetOCSPChecking(false, false)
+
+	isRevoked, err := util.CertificateRevoked(cert, networkCert)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+
+	// The cached response is used until its next update.
+	status = ocsp.Good
+	isRevoked, err = util.CertificateRevoked(cert, networkCert)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+	assert.Equal(t, int64(1), requests.Load())
+
+	// Unreachable responders fail open unless in strict mode.
+	unreachable, _ := revocationTestCert(t, 11, ca, caKey)
+	unreachable.OCSPServer = []string{"http://127.0.0.1:1"}
+
+	isRevoked, err = util.CertificateRevoked(unreachable, networkCert)
+	require.NoError(t, err)
+	assert.False(t, isRevoked)
+
+	util.SetOCSPChecking(true, true)
+	_, err = util.CertificateRevoked(unreachable, networkCert)
+	assert.Error(t, err)
+}
@@ -1,5 +1,9 @@
 package sql
 
+import (
+	"time"
+)
+
 // SQLDump represents a full database dump.
 type SQLDump struct {
 	Text string `json:"text" yaml:"text"`
@@ -23,3 +27,11 @@ type SQLResult struct {
 	Rows         [][]any  `json:"rows"          yaml:"rows"`
 	RowsAffected int64    `json:"rows_affected" yaml:"rows_affected"`
 }
+
+// SQLQueryStats represents the statistics of the slow queries matching a pattern.
+type SQLQueryStats struct {
+	Pattern   string        `json:"pattern"    yaml:"pattern"`
+	Count     int64         `json:"count"      yaml:"count"`
+	TotalTime time.Duration `json:"total_time" yaml:"total_time"`
+	MaxTime   time.Duration `json:"max_time"   yaml:"max_time"`
+}
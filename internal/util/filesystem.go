@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 
 	internalIO "github.com/lxc/incus/v6/internal/io"
 	"github.com/lxc/incus/v6/shared/util"
@@ -150,6 +153,73 @@ func DirCopy(source string, dest string) error {
 	return nil
 }
 
+// RotateFile moves the file at path to path.1, shifting older copies up to path.<keep>.
+// Copies beyond keep are removed. Missing or empty files are left untouched.
+func RotateFile(path string, keep int) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if fi.Size() == 0 {
+		return nil
+	}
+
+	// Remove the copies which would go beyond the limit.
+	for _, rotated := range RotatedFiles(path) {
+		index, _ := strconv.Atoi(strings.TrimPrefix(rotated, path+"."))
+		if index < keep {
+			continue
+		}
+
+		err := os.Remove(rotated)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if keep <= 0 {
+		return os.Truncate(path, 0)
+	}
+
+	for i := keep - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.Rename(path, path+".1")
+}
+
+// RotatedFiles returns the rotated copies of the file at path, oldest first.
+func RotatedFiles(path string) []string {
+	matches, _ := filepath.Glob(path + ".*")
+
+	indexes := make([]int, 0, len(matches))
+	for _, match := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(match, path+"."))
+		if err != nil || index <= 0 {
+			continue
+		}
+
+		indexes = append(indexes, index)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+
+	files := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		files = append(files, fmt.Sprintf("%s.%d", path, index))
+	}
+
+	return files
+}
+
 // AddSlash adds a slash to the end of paths if they don't already have one.
 // This can be useful for rsyncing things, since rsync has behavior present on
 // the presence or absence of a trailing slash.
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotateFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "console.log")
+
+	boots := []string{"boot1", "boot2", "boot3", "boot4"}
+	for _, boot := range boots {
+		err := RotateFile(path, 2)
+		if err != nil {
+			t.Fatalf("Failed rotating file: %v", err)
+		}
+
+		err = os.WriteFile(path, []byte(boot), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{path + ".2", path + ".1"}
+	rotated := RotatedFiles(path)
+	if len(rotated) != len(expected) {
+		t.Fatalf("Expected %v but got %v", expected, rotated)
+	}
+
+	for i, file := range rotated {
+		if file != expected[i] {
+			t.Fatalf("Expected %v but got %v", expected, rotated)
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(content) != boots[i+1] {
+			t.Errorf("Expected %q in %q but got %q", boots[i+1], file, content)
+		}
+	}
+}
+
+func TestRotateFileNoHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "console.log")
+
+	err := os.WriteFile(path, []byte("boot1"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RotateFile(path, 0)
+	if err != nil {
+		t.Fatalf("Failed rotating file: %v", err)
+	}
+
+	if len(RotatedFiles(path)) != 0 {
+		t.Errorf("Expected no rotated files")
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fi.Size() != 0 {
+		t.Errorf("Expected the file to be truncated")
+	}
+}
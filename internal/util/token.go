@@ -39,3 +39,35 @@ func JoinTokenDecode(input string) (*api.ClusterMemberJoinToken, error) {
 
 	return &j, nil
 }
+
+// MigrationTokenDecode decodes a base64 and JSON encoded migration token.
+func MigrationTokenDecode(input string) (*api.MigrationToken, error) {
+	tokenJSON, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var t api.MigrationToken
+	err = json.Unmarshal(tokenJSON, &t)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Project == "" {
+		return nil, fmt.Errorf("No project in migration token")
+	}
+
+	if len(t.Addresses) < 1 {
+		return nil, fmt.Errorf("No server addresses in migration token")
+	}
+
+	if t.Secret == "" {
+		return nil, fmt.Errorf("No secret in migration token")
+	}
+
+	if t.Fingerprint == "" {
+		return nil, fmt.Errorf("No certificate fingerprint in migration token")
+	}
+
+	return &t, nil
+}
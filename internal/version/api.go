@@ -426,6 +426,91 @@ var APIExtensions = []string{
 	"projects_force_delete",
 	"resources_cpu_flags",
 	"disk_io_bus_cache_filesystem",
+	"instance_exec_recording",
+	"instance_file_sync",
+	"instance_file_archive",
+	"instance_sftp_restrictions",
+	"console_log_history",
+	"disk_virtiofs_tuning",
+	"vm_memory_hotplug",
+	"vm_gpu_hotplug",
+	"instance_confidential_computing",
+	"instance_uefi_vars",
+	"agent_features",
+	"instance_exec_policy",
+	"cloud_init_templating",
+	"instance_create_dry_run",
+	"preseed_export",
+	"profile_inheritance",
+	"profile_preview",
+	"instance_state_network_details",
+	"network_load_balancer_health_check",
+	"network_forward_state",
+	"bgp_status",
+	"network_dns_forwarders",
+	"network_dhcp_reservations",
+	"network_ipam_external",
+	"network_peer_requests",
+	"instances_network_usage",
+	"storage_quota_warnings",
+	"warnings_workflow",
+	"database_backups",
+	"database_slow_query_stats",
+	"server_logging_config",
+	"instances_host_shutdown_timeout",
+	"resources_checks",
+	"instance_syscall_intercepts",
+	"projects_apparmor",
+	"instance_idmap",
+	"storage_volume_sharing_nfs",
+	"usb_hotplug_events",
+	"instance_tpm_state",
+	"instance_cpu_allocation",
+	"resources_hugepages",
+	"instance_tasks",
+	"instance_groups",
+	"instances_state_bulk",
+	"project_snapshots",
+	"instances_memory_pressure",
+	"instance_live_migration_tuning",
+	"container_live_migration_lazy_pages",
+	"migration_token",
+	"migration_block_stream",
+	"ovn_network_recovery",
+	"tls_revocation_checking",
+	"certificate_expiry",
+	"cluster_member_config_diff",
+	"resources_state",
+	"support_bundle",
+	"instances_session_limits",
+	"instance_templates",
+	"instances_image_outdated",
+	"instances_expiry",
+	"projects_cleanup",
+	"network_acl_effective",
+	"network_trace",
+	"instance_nic_capture",
+	"nic_routed_live_update",
+	"network_mesh",
+	"network_ovn_gateway_chassis",
+	"network_state_uplink",
+	"storage_pool_benchmark",
+	"storage_pool_key",
+	"storage_btrfs_maintenance",
+	"storage_lvm_thinpool_autoextend",
+	"disk_ceph_qos",
+	"disk_io_nvme_controller",
+	"instance_create_from_disk",
+	"operations_concurrency_limits",
+	"instance_exec_limits",
+	"instance_crash_events",
+	"device_watchdog",
+	"instance_crash_dumps",
+	"instance_boot_dependencies",
+	"instance_probes",
+	"instance_console_screenshot",
+	"instance_spice_options",
+	"instance_users",
 }
 
 // APIExtensionsCount returns the number of available API extensions.
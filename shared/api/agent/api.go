@@ -1,5 +1,48 @@
 package api
 
+import (
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// Features which an agent may support depending on the guest operating system.
+const (
+	// FeatureExec is the ability to run commands.
+	FeatureExec = "exec"
+
+	// FeatureExecInteractive is the ability to run commands attached to a terminal.
+	FeatureExecInteractive = "exec_interactive"
+
+	// FeatureFiles is the ability to transfer files.
+	FeatureFiles = "files"
+
+	// FeatureState is the ability to report the instance state.
+	FeatureState = "state"
+
+	// FeatureMetrics is the ability to report instance metrics.
+	FeatureMetrics = "metrics"
+
+	// FeatureMounts is the ability to mount filesystems shared by the host.
+	FeatureMounts = "mounts"
+
+	// FeatureDevIncus is the ability to expose the guest API (/dev/incus).
+	FeatureDevIncus = "dev_incus"
+
+	// FeatureAttestation is the ability to generate confidential computing attestation reports.
+	FeatureAttestation = "attestation"
+
+	// FeatureUsers is the ability to manage the users of the guest.
+	FeatureUsers = "users"
+)
+
+// API10Get contains the agent information returned to Incus.
+type API10Get struct {
+	api.Server `yaml:",inline"`
+
+	// Features supported by the agent on the guest operating system
+	// Example: ["exec", "files", "state"]
+	Features []string `json:"features" yaml:"features"`
+}
+
 // API10Put contains the fields which are needed for the incus-agent to connect to Incus.
 type API10Put struct {
 	// Context ID
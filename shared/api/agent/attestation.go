@@ -0,0 +1,19 @@
+package api
+
+// AttestationReportPost contains the fields used to request an attestation report from the guest.
+type AttestationReportPost struct {
+	// Data to bind into the report (up to 64 bytes)
+	// Example: nonce
+	Nonce []byte `json:"nonce" yaml:"nonce"`
+}
+
+// AttestationReport contains an attestation report generated by the guest.
+type AttestationReport struct {
+	// Name of the provider which generated the report
+	// Example: sev_guest
+	Provider string `json:"provider" yaml:"provider"`
+
+	// Raw attestation report
+	// Example: report
+	Report []byte `json:"report" yaml:"report"`
+}
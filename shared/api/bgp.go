@@ -0,0 +1,70 @@
+package api
+
+import (
+	"time"
+)
+
+// BGPStatus represents the state of the BGP server.
+//
+// swagger:model
+//
+// API extension: bgp_status.
+type BGPStatus struct {
+	// Whether the BGP server is running
+	// Example: true
+	Running bool `json:"running" yaml:"running"`
+
+	// Address the BGP server is listening on
+	// Example: 192.0.2.50:179
+	Address string `json:"address" yaml:"address"`
+
+	// Local AS number
+	// Example: 65536
+	ASN uint32 `json:"asn" yaml:"asn"`
+
+	// Router ID
+	// Example: 192.0.2.50
+	RouterID string `json:"router_id" yaml:"router_id"`
+
+	// State of the BGP peers
+	Peers []BGPStatusPeer `json:"peers" yaml:"peers"`
+}
+
+// BGPStatusPeer represents the state of a BGP peer.
+//
+// swagger:model
+//
+// API extension: bgp_status.
+type BGPStatusPeer struct {
+	// Peer address
+	// Example: 192.0.2.1
+	Address string `json:"address" yaml:"address"`
+
+	// Peer AS number
+	// Example: 65537
+	ASN uint32 `json:"asn" yaml:"asn"`
+
+	// BGP session state (idle, connect, active, opensent, openconfirm or established)
+	// Example: established
+	State string `json:"state" yaml:"state"`
+
+	// Time at which the session was established (zero if not established)
+	// Example: 2024-05-01T10:00:00Z
+	EstablishedAt time.Time `json:"established_at" yaml:"established_at"`
+
+	// Number of prefixes advertised to the peer
+	// Example: 4
+	PrefixesAdvertised uint64 `json:"prefixes_advertised" yaml:"prefixes_advertised"`
+
+	// Number of prefixes received from the peer
+	// Example: 2
+	PrefixesReceived uint64 `json:"prefixes_received" yaml:"prefixes_received"`
+
+	// Number of prefixes received from the peer and accepted
+	// Example: 2
+	PrefixesAccepted uint64 `json:"prefixes_accepted" yaml:"prefixes_accepted"`
+
+	// BFD session state (admin_down, down, init or up; empty if BFD isn't enabled)
+	// Example: up
+	BFDState string `json:"bfd_state" yaml:"bfd_state"`
+}
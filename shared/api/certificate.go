@@ -33,6 +33,12 @@ type CertificatesPost struct {
 	//
 	// API extension: certificate_token
 	Token bool `json:"token" yaml:"token"`
+
+	// When the certificate is automatically removed from the trust store (zero for never)
+	// Example: 2024-10-22T18:10:58Z
+	//
+	// API extension: certificate_expiry
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
 }
 
 // CertificatePut represents the modifiable fields of a certificate
@@ -84,6 +90,13 @@ type Certificate struct {
 	// Read only: true
 	// Example: fd200419b271f1dc2a5591b693cc5774b7f234e1ff8c6b78ad703b6888fe2b69
 	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// When the certificate is automatically removed from the trust store (zero for never)
+	// Read only: true
+	// Example: 2024-10-22T18:10:58Z
+	//
+	// API extension: certificate_expiry
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
 }
 
 // Writable converts a full Certificate struct into a CertificatePut struct (filters read-only fields).
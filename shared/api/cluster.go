@@ -256,6 +256,34 @@ type ClusterMemberStatePost struct {
 	Mode string `json:"mode" yaml:"mode"`
 }
 
+// ClusterMemberConfigDiff represents a member-specific server configuration key whose value on a cluster
+// member differs from its default or from its value on other cluster members.
+//
+// swagger:model
+//
+// API extension: cluster_member_config_diff.
+type ClusterMemberConfigDiff struct {
+	// The name of the key
+	// Example: core.https_address
+	Key string `json:"key" yaml:"key"`
+
+	// The value on the cluster member
+	// Example: 10.0.0.1:8443
+	Value string `json:"value" yaml:"value"`
+
+	// The default value of the key
+	// Example: ""
+	Default string `json:"default" yaml:"default"`
+
+	// Whether the value differs from the default
+	// Example: true
+	NotDefault bool `json:"not_default" yaml:"not_default"`
+
+	// The values on the other cluster members which differ from the value on the cluster member
+	// Example: {"server02": "10.0.0.2:8443"}
+	Members map[string]string `json:"members" yaml:"members"`
+}
+
 // ClusterGroupsPost represents the fields available for a new cluster group.
 //
 // swagger:model
@@ -4,6 +4,7 @@ package api
 const (
 	EventLifecycleCertificateCreated                = "certificate-created"
 	EventLifecycleCertificateDeleted                = "certificate-deleted"
+	EventLifecycleCertificateExpired                = "certificate-expired"
 	EventLifecycleCertificateUpdated                = "certificate-updated"
 	EventLifecycleClusterCertificateUpdated         = "cluster-certificate-updated"
 	EventLifecycleClusterDisabled                   = "cluster-disabled"
@@ -35,25 +36,41 @@ const (
 	EventLifecycleInstanceConsole                   = "instance-console"
 	EventLifecycleInstanceConsoleReset              = "instance-console-reset"
 	EventLifecycleInstanceConsoleRetrieved          = "instance-console-retrieved"
+	EventLifecycleInstanceCrashed                   = "instance-crashed"
 	EventLifecycleInstanceCreated                   = "instance-created"
 	EventLifecycleInstanceDeleted                   = "instance-deleted"
+	EventLifecycleInstanceDeviceAttached            = "instance-device-attached"
+	EventLifecycleInstanceDeviceDetached            = "instance-device-detached"
 	EventLifecycleInstanceExec                      = "instance-exec"
+	EventLifecycleInstanceExecDenied                = "instance-exec-denied"
+	EventLifecycleInstanceExpiring                  = "instance-expiring"
 	EventLifecycleInstanceFileDeleted               = "instance-file-deleted"
 	EventLifecycleInstanceFilePushed                = "instance-file-pushed"
 	EventLifecycleInstanceFileRetrieved             = "instance-file-retrieved"
+	EventLifecycleInstanceGroupCreated              = "instance-group-created"
+	EventLifecycleInstanceGroupDeleted              = "instance-group-deleted"
+	EventLifecycleInstanceGroupRenamed              = "instance-group-renamed"
+	EventLifecycleInstanceGroupUpdated              = "instance-group-updated"
+	EventLifecycleInstanceImageOutdated             = "instance-image-outdated"
 	EventLifecycleInstanceLogDeleted                = "instance-log-deleted"
 	EventLifecycleInstanceLogRetrieved              = "instance-log-retrieved"
+	EventLifecycleInstanceMemoryPressureFrozen      = "instance-memory-pressure-frozen"
+	EventLifecycleInstanceMemoryPressureUnfrozen    = "instance-memory-pressure-unfrozen"
 	EventLifecycleInstanceMetadataRetrieved         = "instance-metadata-retrieved"
 	EventLifecycleInstanceMetadataTemplateCreated   = "instance-metadata-template-created"
 	EventLifecycleInstanceMetadataTemplateDeleted   = "instance-metadata-template-deleted"
 	EventLifecycleInstanceMetadataTemplateRetrieved = "instance-metadata-template-retrieved"
 	EventLifecycleInstanceMetadataUpdated           = "instance-metadata-updated"
+	EventLifecycleInstanceOOMKilled                 = "instance-oom-killed"
 	EventLifecycleInstancePaused                    = "instance-paused"
+	EventLifecycleInstanceProbeFailed               = "instance-probe-failed"
 	EventLifecycleInstanceReady                     = "instance-ready"
 	EventLifecycleInstanceRenamed                   = "instance-renamed"
 	EventLifecycleInstanceRestarted                 = "instance-restarted"
 	EventLifecycleInstanceRestored                  = "instance-restored"
 	EventLifecycleInstanceResumed                   = "instance-resumed"
+	EventLifecycleInstanceSFTPConnected             = "instance-sftp-connected"
+	EventLifecycleInstanceSFTPDisconnected          = "instance-sftp-disconnected"
 	EventLifecycleInstanceShutdown                  = "instance-shutdown"
 	EventLifecycleInstanceSnapshotCreated           = "instance-snapshot-created"
 	EventLifecycleInstanceSnapshotDeleted           = "instance-snapshot-deleted"
@@ -61,16 +78,27 @@ const (
 	EventLifecycleInstanceSnapshotUpdated           = "instance-snapshot-updated"
 	EventLifecycleInstanceStarted                   = "instance-started"
 	EventLifecycleInstanceStopped                   = "instance-stopped"
+	EventLifecycleInstanceTemplateCreated           = "instance-template-created"
+	EventLifecycleInstanceTemplateDeleted           = "instance-template-deleted"
+	EventLifecycleInstanceTemplateRenamed           = "instance-template-renamed"
+	EventLifecycleInstanceTemplateUpdated           = "instance-template-updated"
 	EventLifecycleInstanceUpdated                   = "instance-updated"
+	EventLifecycleInstanceUserCreated               = "instance-user-created"
+	EventLifecycleInstanceUserDeleted               = "instance-user-deleted"
+	EventLifecycleInstanceUserUpdated               = "instance-user-updated"
 	EventLifecycleNetworkACLCreated                 = "network-acl-created"
 	EventLifecycleNetworkACLDeleted                 = "network-acl-deleted"
 	EventLifecycleNetworkACLRenamed                 = "network-acl-renamed"
 	EventLifecycleNetworkACLUpdated                 = "network-acl-updated"
 	EventLifecycleNetworkCreated                    = "network-created"
 	EventLifecycleNetworkDeleted                    = "network-deleted"
+	EventLifecycleNetworkDHCPReservationCreated     = "network-dhcp-reservation-created"
+	EventLifecycleNetworkDHCPReservationDeleted     = "network-dhcp-reservation-deleted"
+	EventLifecycleNetworkDHCPReservationUpdated     = "network-dhcp-reservation-updated"
 	EventLifecycleNetworkForwardCreated             = "network-forward-created"
 	EventLifecycleNetworkForwardDeleted             = "network-forward-deleted"
 	EventLifecycleNetworkForwardUpdated             = "network-forward-updated"
+	EventLifecycleNetworkGatewayChanged             = "network-gateway-changed"
 	EventLifecycleNetworkIntegrationCreated         = "network-integration-created"
 	EventLifecycleNetworkIntegrationDeleted         = "network-integration-deleted"
 	EventLifecycleNetworkIntegrationRenamed         = "network-integration-renamed"
@@ -117,6 +145,7 @@ const (
 	EventLifecycleStorageVolumeBackupRenamed        = "storage-volume-backup-renamed"
 	EventLifecycleStorageVolumeBackupRetrieved      = "storage-volume-backup-retrieved"
 	EventLifecycleStorageVolumeDeleted              = "storage-volume-deleted"
+	EventLifecycleStorageVolumeQuotaWarning         = "storage-volume-quota-warning"
 	EventLifecycleStorageVolumeRenamed              = "storage-volume-renamed"
 	EventLifecycleStorageVolumeRestored             = "storage-volume-restored"
 	EventLifecycleStorageVolumeSnapshotCreated      = "storage-volume-snapshot-created"
@@ -26,13 +26,19 @@ type InitLocalPreseed struct {
 	// Example: local dir storage pool
 	StoragePools []StoragePoolsPost `json:"storage_pools" yaml:"storage_pools"`
 
-	// Profiles to add
+	// Profiles by project to add
 	// Example: "default" profile with a root disk device
-	Profiles []ProfilesPost `json:"profiles" yaml:"profiles"`
+	Profiles []InitProfileProjectPost `json:"profiles" yaml:"profiles"`
 
 	// Projects to add
 	// Example: "default" project
 	Projects []ProjectsPost `json:"projects" yaml:"projects"`
+
+	// Network ACLs by project to add
+	// Example: Network ACL on the "default" project
+	//
+	// API extension: preseed_export
+	NetworkACLs []InitNetworkACLProjectPost `json:"network_acls" yaml:"network_acls"`
 }
 
 // InitNetworksProjectPost represents the fields of a new network along with its associated project.
@@ -48,6 +54,32 @@ type InitNetworksProjectPost struct {
 	Project string
 }
 
+// InitProfileProjectPost represents the fields of a new profile along with its associated project.
+//
+// swagger:model
+//
+// API extension: preseed_export.
+type InitProfileProjectPost struct {
+	ProfilesPost `yaml:",inline"`
+
+	// Project in which the profile will reside
+	// Example: "default"
+	Project string `json:"project" yaml:"project"`
+}
+
+// InitNetworkACLProjectPost represents the fields of a new network ACL along with its associated project.
+//
+// swagger:model
+//
+// API extension: preseed_export.
+type InitNetworkACLProjectPost struct {
+	NetworkACLsPost `yaml:",inline"`
+
+	// Project in which the network ACL will reside
+	// Example: "default"
+	Project string `json:"project" yaml:"project"`
+}
+
 // InitClusterPreseed represents initialization configuration for the cluster.
 //
 // swagger:model
@@ -55,6 +55,47 @@ type InstancesPost struct {
 	//
 	// API extension: instance_create_start
 	Start bool `json:"start" yaml:"start"`
+
+	// Instance template to base the configuration, devices and profiles on
+	// Example: web
+	//
+	// API extension: instance_templates
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+}
+
+// InstancesPostDryRun represents the result of validating a new instance without creating it.
+//
+// swagger:model
+//
+// API extension: instance_create_dry_run.
+type InstancesPostDryRun struct {
+	// Instance name
+	// Example: foo
+	Name string `json:"name" yaml:"name"`
+
+	// Type (container or virtual-machine)
+	// Example: container
+	Type InstanceType `json:"type" yaml:"type"`
+
+	// Cluster member the instance would be placed on
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// List of profiles applied to the instance
+	// Example: ["default"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+
+	// Expanded configuration (all profiles and local config merged)
+	// Example: {"security.nesting": "true"}
+	ExpandedConfig map[string]string `json:"expanded_config" yaml:"expanded_config"`
+
+	// Expanded devices (all profiles and local devices merged)
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}}
+	ExpandedDevices map[string]map[string]string `json:"expanded_devices" yaml:"expanded_devices"`
+
+	// Validation errors (empty if the instance can be created)
+	// Example: ["Invalid config: Unknown configuration key: foo"]
+	Errors []string `json:"errors" yaml:"errors"`
 }
 
 // InstancesPut represents the fields available for a mass update.
@@ -67,6 +108,36 @@ type InstancesPut struct {
 	State *InstanceStatePut `json:"state" yaml:"state"`
 }
 
+// InstancesStatePut represents the fields available for a state change of a list of instances.
+//
+// swagger:model
+//
+// API extension: instances_state_bulk.
+type InstancesStatePut struct {
+	// List of instances to act on
+	// Example: ["c1", "c2"]
+	Instances []string `json:"instances" yaml:"instances"`
+
+	// Desired runtime state
+	State InstanceStatePut `json:"state" yaml:"state"`
+}
+
+// InstancesStateResult represents the outcome of the state change of a single instance,
+// as reported in the metadata of the bulk state change operation.
+//
+// swagger:model
+//
+// API extension: instances_state_bulk.
+type InstancesStateResult struct {
+	// Outcome of the state change (Success, Failure or Skipped when already in the requested state)
+	// Example: Success
+	Status string `json:"status" yaml:"status"`
+
+	// Error message if the state change failed
+	// Example: Instance is not running
+	Error string `json:"error" yaml:"error"`
+}
+
 // InstancePost represents the fields required to rename/move an instance.
 //
 // swagger:model
@@ -127,6 +198,42 @@ type InstancePost struct {
 	//
 	// API extension: instance_move_config
 	Profiles []string
+
+	// Live migration tuning, overriding the migration.stateful.* options of the instance
+	//
+	// API extension: instance_live_migration_tuning
+	LiveMigration *InstancePostLiveMigration `json:"live_migration" yaml:"live_migration"`
+
+	// Migration token issued by the target server, will push the instance to it (migration only)
+	// Example: eyJwcm9qZWN0IjoiZGVmYXVsdCIsIm5hbWUiOiIiLCJmaW5nZXJwcmludCI6IjU3YmIwZmY0MzQwYjViYjI4NTE3ZTA2MjAyMzEwMWFkZjc4OGMzNzg0NmRjOGI2MTllYjJjM2NiNGVmMjk0MzYiLCJhZGRyZXNzZXMiOlsiMTAuOTguMzAuMjI5Ojg0NDMiXSwic2VjcmV0IjoiMmIyMjg0ZDQ0ZGIzMjY3NTkyM2ZlMGQyMDIwNDc3ZTAifQ==
+	//
+	// API extension: migration_token
+	TargetToken string `json:"target_token" yaml:"target_token"`
+}
+
+// InstancePostLiveMigration represents the tuning of a live migration of an instance.
+//
+// swagger:model
+//
+// API extension: instance_live_migration_tuning.
+type InstancePostLiveMigration struct {
+	// Maximum downtime of the instance at the end of the migration, in milliseconds (virtual machines only)
+	// Example: 300
+	MaxDowntime int64 `json:"max_downtime" yaml:"max_downtime"`
+
+	// Maximum bandwidth used by the memory transfer, in bytes per second or with a size suffix (virtual machines only)
+	// Example: 1GiB
+	Bandwidth string `json:"bandwidth" yaml:"bandwidth"`
+
+	// Whether to throttle down the instance's CPUs when the memory transfer doesn't converge (virtual machines only)
+	// Example: true
+	AutoConverge *bool `json:"auto_converge" yaml:"auto_converge"`
+
+	// Whether to transfer the memory of a container after its restore on the target (containers only)
+	// Example: true
+	//
+	// API extension: container_live_migration_lazy_pages
+	LazyPages bool `json:"lazy_pages" yaml:"lazy_pages"`
 }
 
 // InstancePostTarget represents the migration target host and operation.
@@ -371,4 +478,10 @@ type InstanceSource struct {
 	//
 	// API extension: instance_allow_inconsistent_copy
 	AllowInconsistent bool `json:"allow_inconsistent" yaml:"allow_inconsistent"`
+
+	// Disk image URL (for disk source, unless uploaded)
+	// Example: https://example.com/disk.qcow2
+	//
+	// API extension: instance_create_from_disk
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 }
@@ -0,0 +1,20 @@
+package api
+
+// InstanceAttestation represents the confidential computing state of a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_confidential_computing.
+type InstanceAttestation struct {
+	// Confidential computing technology in use (sev, sev-es, sev-snp or tdx)
+	// Example: sev-snp
+	Type string `json:"type" yaml:"type"`
+
+	// Base64 encoded launch measurement (only available for sev and sev-es)
+	// Example: ZXhhbXBsZQ==
+	Measurement string `json:"measurement" yaml:"measurement"`
+
+	// Guest policy in use (AMD SEV only)
+	// Example: 0x30000
+	Policy string `json:"policy" yaml:"policy"`
+}
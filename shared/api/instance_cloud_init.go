@@ -0,0 +1,20 @@
+package api
+
+// InstanceRenderedCloudInit represents the cloud-init configuration of an instance as passed to the guest.
+//
+// swagger:model
+//
+// API extension: cloud_init_templating.
+type InstanceRenderedCloudInit struct {
+	// Rendered user data
+	// Example: #cloud-config\nhostname: c1
+	UserData string `json:"user_data" yaml:"user_data"`
+
+	// Rendered vendor data
+	// Example: #cloud-config\npackages: [htop]
+	VendorData string `json:"vendor_data" yaml:"vendor_data"`
+
+	// Rendered network configuration
+	// Example: version: 2
+	NetworkConfig string `json:"network_config" yaml:"network_config"`
+}
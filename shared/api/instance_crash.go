@@ -0,0 +1,40 @@
+package api
+
+import (
+	"time"
+)
+
+// InstanceCrashOOMKill is the crash type for processes killed by the out of memory killer.
+const InstanceCrashOOMKill = "oom-kill"
+
+// InstanceCrashPanic is the crash type for guest panics.
+const InstanceCrashPanic = "panic"
+
+// InstanceCrashWatchdog is the crash type for expiries of the watchdog device.
+const InstanceCrashWatchdog = "watchdog"
+
+// InstanceCrashProcess is the crash type for crashes of the process running the instance.
+const InstanceCrashProcess = "process"
+
+// InstanceCrash represents a crash of an instance (out of memory kill, guest panic, watchdog expiry or process crash)
+//
+// swagger:model
+//
+// API extension: instance_crash_events.
+type InstanceCrash struct {
+	// Type of crash (oom-kill, panic, watchdog or process)
+	// Example: oom-kill
+	Type string `json:"type" yaml:"type"`
+
+	// When the crash was detected
+	// Example: 2024-05-12T10:04:05Z
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+
+	// Number of processes killed by the out of memory killer
+	// Example: 1
+	Count int64 `json:"count" yaml:"count"`
+
+	// Information about the panic, the watchdog expiry or the process crash
+	// Example: {"action": "pause", "type": "hyper-v"}
+	Details map[string]string `json:"details" yaml:"details"`
+}
@@ -39,7 +39,7 @@ type InstanceExecPost struct {
 	// Example: 24
 	Height int `json:"height" yaml:"height"`
 
-	// Whether to capture the output for later download (requires non-interactive)
+	// Whether to capture the output for later download (interactive mode requires wait-for-websocket)
 	RecordOutput bool `json:"record-output" yaml:"record-output"`
 
 	// UID of the user to spawn the command as
@@ -53,4 +53,16 @@ type InstanceExecPost struct {
 	// Current working directory for the command
 	// Example: /home/foo/
 	Cwd string `json:"cwd" yaml:"cwd"`
+
+	// CPU allowance for the command, as a share of the instance's CPU time or as a time limit
+	// Example: 25ms/100ms
+	//
+	// API extension: instance_exec_limits
+	LimitsCPU string `json:"limits-cpu,omitempty" yaml:"limits-cpu,omitempty"`
+
+	// Memory limit for the command, as a percentage of the instance's memory or as a size
+	// Example: 512MiB
+	//
+	// API extension: instance_exec_limits
+	LimitsMemory string `json:"limits-memory,omitempty" yaml:"limits-memory,omitempty"`
 }
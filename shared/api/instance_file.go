@@ -0,0 +1,40 @@
+package api
+
+// InstanceFileEntry represents an entry of a directory tree within an instance.
+//
+// swagger:model
+//
+// API extension: instance_file_sync.
+type InstanceFileEntry struct {
+	// Path of the entry relative to the requested directory
+	// Example: etc/hosts
+	Path string `json:"path" yaml:"path"`
+
+	// Type of the entry (file, symlink or directory)
+	// Example: file
+	Type string `json:"type" yaml:"type"`
+
+	// Permission bits of the entry
+	// Example: 420
+	Mode int `json:"mode" yaml:"mode"`
+
+	// Owner UID of the entry
+	// Example: 0
+	UID int64 `json:"uid" yaml:"uid"`
+
+	// Owner GID of the entry
+	// Example: 0
+	GID int64 `json:"gid" yaml:"gid"`
+
+	// Size of the entry in bytes
+	// Example: 1024
+	Size int64 `json:"size" yaml:"size"`
+
+	// Target of the symlink (symlink only)
+	// Example: ../run/resolv.conf
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+
+	// SHA256 checksum of the content (file only, when requested)
+	// Example: 8a7d8b2a1e6aebfd8b8b5b9c2d4a9d3e0f2f3c0a3b6e9e5a2c8d5e6f7a8b9c0d
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+}
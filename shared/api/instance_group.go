@@ -0,0 +1,67 @@
+package api
+
+// InstanceGroupsPost represents the fields available for a new instance group.
+//
+// swagger:model
+//
+// API extension: instance_groups.
+type InstanceGroupsPost struct {
+	InstanceGroupPut `yaml:",inline"`
+
+	// The name of the instance group
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+}
+
+// InstanceGroupPost represents the fields required to rename an instance group.
+//
+// swagger:model
+//
+// API extension: instance_groups.
+type InstanceGroupPost struct {
+	// The new name of the instance group
+	// Example: frontend
+	Name string `json:"name" yaml:"name"`
+}
+
+// InstanceGroupPut represents the modifiable fields of an instance group.
+//
+// swagger:model
+//
+// API extension: instance_groups.
+type InstanceGroupPut struct {
+	// The description of the instance group
+	// Example: Web servers
+	Description string `json:"description" yaml:"description"`
+
+	// List of instances in the group
+	// Example: ["web01", "web02"]
+	Instances []string `json:"instances" yaml:"instances"`
+}
+
+// InstanceGroup represents an instance group.
+//
+// swagger:model
+//
+// API extension: instance_groups.
+type InstanceGroup struct {
+	InstanceGroupPut `yaml:",inline"`
+
+	// The name of the instance group
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+
+	// The project the instance group belongs to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+}
+
+// Etag returns the values used for etag generation.
+func (g *InstanceGroup) Etag() []any {
+	return []any{g.Name, g.Description, g.Instances}
+}
+
+// Writable converts a full InstanceGroup struct into an InstanceGroupPut struct (filters read-only fields).
+func (g *InstanceGroup) Writable() InstanceGroupPut {
+	return g.InstanceGroupPut
+}
@@ -0,0 +1,62 @@
+package api
+
+// InstanceIdmapEntry represents a range of IDs mapped into a container.
+//
+// swagger:model
+//
+// API extension: instance_idmap.
+type InstanceIdmapEntry struct {
+	// Type of IDs (uid, gid or both)
+	// Example: both
+	Type string `json:"type" yaml:"type"`
+
+	// First ID in the container
+	// Example: 0
+	NSID int64 `json:"nsid" yaml:"nsid"`
+
+	// First ID on the host
+	// Example: 1000000
+	HostID int64 `json:"hostid" yaml:"hostid"`
+
+	// Number of IDs
+	// Example: 65536
+	Range int64 `json:"range" yaml:"range"`
+}
+
+// InstanceIdmapPut represents the modifiable idmap settings of a container.
+//
+// swagger:model
+//
+// API extension: instance_idmap.
+type InstanceIdmapPut struct {
+	// Whether the container uses a range of host IDs distinct from other containers
+	// Example: true
+	Isolated bool `json:"isolated" yaml:"isolated"`
+
+	// First host ID of the range (0 to have one allocated, only for isolated containers)
+	// Example: 1065536
+	Base int64 `json:"base" yaml:"base"`
+
+	// Number of host IDs in the range (0 for the default, only for isolated containers)
+	// Example: 65536
+	Size int64 `json:"size" yaml:"size"`
+}
+
+// InstanceIdmap represents the idmap of a container.
+//
+// swagger:model
+//
+// API extension: instance_idmap.
+type InstanceIdmap struct {
+	InstanceIdmapPut `yaml:",inline"`
+
+	// ID map in use by the container (or used last if stopped)
+	Current []InstanceIdmapEntry `json:"current" yaml:"current"`
+
+	// ID map the container will use when next started
+	Next []InstanceIdmapEntry `json:"next" yaml:"next"`
+
+	// Conflicts of the ID map with other containers
+	// Example: ["Host IDs 1065536-1131071 overlap with host IDs 1100000-1165535 of instance \"c2\" in project \"default\""]
+	Conflicts []string `json:"conflicts" yaml:"conflicts"`
+}
@@ -27,6 +27,25 @@ type InstanceStatePut struct {
 	Stateful bool `json:"stateful" yaml:"stateful"`
 }
 
+// InstanceCapturePost represents a request to capture the traffic of an instance NIC.
+//
+// swagger:model
+//
+// API extension: instance_nic_capture.
+type InstanceCapturePost struct {
+	// Name of the NIC device to capture the traffic of
+	// Example: eth0
+	Device string `json:"device" yaml:"device"`
+
+	// How long to capture for (in s, defaults to 60)
+	// Example: 30
+	Duration int64 `json:"duration" yaml:"duration"`
+
+	// Maximum size of the capture (in bytes, defaults to 10MiB)
+	// Example: 1048576
+	MaxSize int64 `json:"max_size" yaml:"max_size"`
+}
+
 // InstanceState represents an instance's state.
 //
 // swagger:model
@@ -65,6 +84,34 @@ type InstanceState struct {
 	//
 	// API extension: instance_state_started_at.
 	StartedAt time.Time `json:"started_at" yaml:"started_at"`
+
+	// State of the readiness and liveness probes
+	//
+	// API extension: instance_probes.
+	Probes map[string]InstanceStateProbe `json:"probes,omitempty" yaml:"probes,omitempty"`
+}
+
+// InstanceStateProbe represents the state of a readiness or liveness probe of an instance.
+//
+// swagger:model
+//
+// API extension: instance_probes.
+type InstanceStateProbe struct {
+	// Whether the probe is passing
+	// Example: true
+	Passing bool `json:"passing" yaml:"passing"`
+
+	// Number of consecutive failures of the probe
+	// Example: 0
+	Failures int64 `json:"failures" yaml:"failures"`
+
+	// When the probe was last run
+	// Example: 2024-05-12T10:04:05Z
+	LastRun time.Time `json:"last_run" yaml:"last_run"`
+
+	// Error of the last failed run of the probe
+	// Example: dial tcp 10.0.0.10:5432: connect: connection refused
+	LastError string `json:"last_error" yaml:"last_error"`
 }
 
 // InstanceStateDisk represents the disk information section of an instance's state.
@@ -82,6 +129,34 @@ type InstanceStateDisk struct {
 	//
 	// API extension: instances_state_total
 	Total int64 `json:"total" yaml:"total"`
+
+	// Virtiofs statistics (only for shared directories of virtual machines)
+	//
+	// API extension: disk_virtiofs_tuning
+	Virtiofs *InstanceStateDiskVirtiofs `json:"virtiofs,omitempty" yaml:"virtiofs,omitempty"`
+}
+
+// InstanceStateDiskVirtiofs represents the virtiofs statistics of a shared directory.
+//
+// swagger:model
+//
+// API extension: disk_virtiofs_tuning.
+type InstanceStateDiskVirtiofs struct {
+	// Bytes read by virtiofsd
+	// Example: 10485760
+	ReadBytes uint64 `json:"read_bytes" yaml:"read_bytes"`
+
+	// Number of read system calls made by virtiofsd
+	// Example: 1024
+	ReadSyscalls uint64 `json:"read_syscalls" yaml:"read_syscalls"`
+
+	// Bytes written by virtiofsd
+	// Example: 5242880
+	WrittenBytes uint64 `json:"written_bytes" yaml:"written_bytes"`
+
+	// Number of write system calls made by virtiofsd
+	// Example: 512
+	WriteSyscalls uint64 `json:"write_syscalls" yaml:"write_syscalls"`
 }
 
 // InstanceStateCPU represents the cpu information section of an instance's state.
@@ -155,6 +230,86 @@ type InstanceStateNetwork struct {
 	// Type of interface (broadcast, loopback, point-to-point, ...)
 	// Example: broadcast
 	Type string `json:"type" yaml:"type"`
+
+	// Non-zero drop and error counters of the host side interface, keyed by kernel counter name
+	// Example: {"tx_dropped": 12, "rx_missed_errors": 3}
+	//
+	// API extension: instance_state_network_details
+	DropReasons map[string]int64 `json:"drop_reasons,omitempty" yaml:"drop_reasons,omitempty"`
+
+	// Queue statistics of the host side interface
+	//
+	// API extension: instance_state_network_details
+	Queues *InstanceStateNetworkQueues `json:"queues,omitempty" yaml:"queues,omitempty"`
+
+	// Link speed of the host side interface (Mbit/s)
+	// Example: 10000
+	//
+	// API extension: instance_state_network_details
+	LinkSpeed uint64 `json:"link_speed,omitempty" yaml:"link_speed,omitempty"`
+
+	// Link duplex of the host side interface (full or half)
+	// Example: full
+	//
+	// API extension: instance_state_network_details
+	LinkDuplex string `json:"link_duplex,omitempty" yaml:"link_duplex,omitempty"`
+
+	// OVN logical switch port state (for NICs connected to OVN networks, only reported by the instance state endpoint)
+	//
+	// API extension: instance_state_network_details
+	OVN *InstanceStateNetworkOVN `json:"ovn,omitempty" yaml:"ovn,omitempty"`
+
+	// Routes to the NIC installed on the host (for routed NICs)
+	// Example: ["192.0.2.10 scope link", "2001:db8::10 metric 1024 pref medium"]
+	//
+	// API extension: nic_routed_live_update
+	HostRoutes []string `json:"host_routes,omitempty" yaml:"host_routes,omitempty"`
+}
+
+// InstanceStateNetworkQueues represents the queue statistics of a network interface.
+//
+// swagger:model
+//
+// API extension: instance_state_network_details.
+type InstanceStateNetworkQueues struct {
+	// Number of receive queues
+	// Example: 4
+	RXQueues uint64 `json:"rx_queues" yaml:"rx_queues"`
+
+	// Number of transmit queues
+	// Example: 4
+	TXQueues uint64 `json:"tx_queues" yaml:"tx_queues"`
+
+	// Length of the transmit queue (packets)
+	// Example: 1000
+	TXQueueLength uint64 `json:"tx_queue_length" yaml:"tx_queue_length"`
+
+	// Bytes currently queued on the device across all transmit queues
+	// Example: 0
+	TXInflightBytes uint64 `json:"tx_inflight_bytes" yaml:"tx_inflight_bytes"`
+}
+
+// InstanceStateNetworkOVN represents the state of the OVN logical switch port backing a NIC.
+//
+// swagger:model
+//
+// API extension: instance_state_network_details.
+type InstanceStateNetworkOVN struct {
+	// Logical switch port name
+	// Example: incus-net3-instance-1e0d0d4e-8e2a-4d2b-9a44-27d4a1e1a4b5-eth0
+	LogicalSwitchPort string `json:"logical_switch_port" yaml:"logical_switch_port"`
+
+	// Whether the port is administratively enabled
+	// Example: true
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Whether OVN reports the port as up
+	// Example: true
+	Up bool `json:"up" yaml:"up"`
+
+	// Hostname of the chassis the port is bound to
+	// Example: server01
+	Chassis string `json:"chassis" yaml:"chassis"`
 }
 
 // InstanceStateNetworkAddress represents a network address as part of the network section of an
@@ -0,0 +1,36 @@
+package api
+
+// InstanceSyscallIntercept represents the state of a system call interception handler of a container.
+//
+// swagger:model
+//
+// API extension: instance_syscall_intercepts.
+type InstanceSyscallIntercept struct {
+	// Name of the handler, as used in the security.syscalls.intercept.NAME configuration key
+	// Example: mknod
+	Name string `json:"name" yaml:"name"`
+
+	// System calls handled
+	// Example: ["mknod", "mknodat"]
+	Syscalls []string `json:"syscalls" yaml:"syscalls"`
+
+	// Whether the handler is enabled in the instance configuration
+	// Example: true
+	Enabled bool `json:"enabled" yaml:"enabled"`
+
+	// Whether the system calls are intercepted by the seccomp policy of the running instance
+	// Example: true
+	Loaded bool `json:"loaded" yaml:"loaded"`
+
+	// Number of system calls handled since the instance was started
+	// Example: 12
+	Intercepted uint64 `json:"intercepted" yaml:"intercepted"`
+
+	// Number of handled system calls which returned an error to the instance
+	// Example: 1
+	Errors uint64 `json:"errors" yaml:"errors"`
+
+	// Number of system calls passed through to the kernel as the handler was disabled since the instance was started
+	// Example: 0
+	Skipped uint64 `json:"skipped" yaml:"skipped"`
+}
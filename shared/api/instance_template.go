@@ -0,0 +1,83 @@
+package api
+
+// InstanceTemplatesPost represents the fields available for a new instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatesPost struct {
+	InstanceTemplatePut `yaml:",inline"`
+
+	// The name of the instance template
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+
+	// Name of an instance to capture the type, configuration, devices and profiles from
+	// Example: web01
+	Source string `json:"source" yaml:"source"`
+}
+
+// InstanceTemplatePost represents the fields required to rename an instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatePost struct {
+	// The new name of the instance template
+	// Example: frontend
+	Name string `json:"name" yaml:"name"`
+}
+
+// InstanceTemplatePut represents the modifiable fields of an instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplatePut struct {
+	// The description of the instance template
+	// Example: Web server
+	Description string `json:"description" yaml:"description"`
+
+	// Type of instances the template applies to (container or virtual-machine), empty for any
+	// Example: container
+	Type InstanceType `json:"type" yaml:"type"`
+
+	// Instance configuration (see doc/instances.md)
+	// Example: {"limits.cpu": "2"}
+	Config map[string]string `json:"config" yaml:"config"`
+
+	// Instance devices (see doc/instances.md)
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}}
+	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+
+	// List of profiles applied to the instances, none meaning the default profiles are used
+	// Example: ["default"]
+	Profiles []string `json:"profiles" yaml:"profiles"`
+}
+
+// InstanceTemplate represents an instance template.
+//
+// swagger:model
+//
+// API extension: instance_templates.
+type InstanceTemplate struct {
+	InstanceTemplatePut `yaml:",inline"`
+
+	// The name of the instance template
+	// Example: web
+	Name string `json:"name" yaml:"name"`
+
+	// The project the instance template belongs to
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+}
+
+// Etag returns the values used for etag generation.
+func (t *InstanceTemplate) Etag() []any {
+	return []any{t.Name, t.Description, t.Type, t.Config, t.Devices, t.Profiles}
+}
+
+// Writable converts a full InstanceTemplate struct into an InstanceTemplatePut struct (filters read-only fields).
+func (t *InstanceTemplate) Writable() InstanceTemplatePut {
+	return t.InstanceTemplatePut
+}
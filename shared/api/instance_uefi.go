@@ -0,0 +1,42 @@
+package api
+
+// InstanceUEFIVars represents the UEFI variables of a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_uefi_vars.
+type InstanceUEFIVars struct {
+	// UEFI variables indexed by "<name>-<vendor GUID>"
+	// Example: {"BootOrder-8be4df61-93ca-11d2-aa0d-00e098032b8c": {"data": "01000000", "attributes": 7}}
+	Variables map[string]InstanceUEFIVariable `json:"variables" yaml:"variables"`
+}
+
+// InstanceUEFIVariable represents a single UEFI variable.
+//
+// swagger:model
+//
+// API extension: instance_uefi_vars.
+type InstanceUEFIVariable struct {
+	// Hex encoded variable data
+	// Example: 01000000
+	Data string `json:"data" yaml:"data"`
+
+	// Variable attributes
+	// Example: 7
+	Attributes uint32 `json:"attributes" yaml:"attributes"`
+}
+
+// InstanceUEFICertificatesPost represents a secure boot certificate to enroll into a virtual machine.
+//
+// swagger:model
+//
+// API extension: instance_uefi_vars.
+type InstanceUEFICertificatesPost struct {
+	// Signature database to add the certificate to (db or KEK)
+	// Example: db
+	Database string `json:"database" yaml:"database"`
+
+	// PEM encoded X.509 certificate
+	// Example: X509 PEM certificate
+	Certificate string `json:"certificate" yaml:"certificate"`
+}
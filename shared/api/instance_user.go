@@ -0,0 +1,63 @@
+package api
+
+// InstanceUsersPost represents the fields required to create a user inside an instance.
+//
+// swagger:model
+//
+// API extension: instance_users.
+type InstanceUsersPost struct {
+	InstanceUserPut `yaml:",inline"`
+
+	// Name of the user
+	// Example: alice
+	Name string `json:"name" yaml:"name"`
+
+	// Login shell of the user (defaults to the guest default)
+	// Example: /bin/bash
+	Shell string `json:"shell" yaml:"shell"`
+}
+
+// InstanceUserPut represents the modifiable fields of a user inside an instance.
+//
+// swagger:model
+//
+// API extension: instance_users.
+type InstanceUserPut struct {
+	// Password of the user (write-only, an empty value leaves the password unchanged)
+	// Example: s3cret
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+
+	// SSH public keys authorized to log in as the user (replaces the existing ones)
+	// Example: ["ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA... alice@laptop"]
+	SSHKeys []string `json:"ssh_keys" yaml:"ssh_keys"`
+
+	// Whether the user can run any command as root through sudo
+	// Example: true
+	Sudo bool `json:"sudo" yaml:"sudo"`
+}
+
+// InstanceUser represents a user inside an instance.
+//
+// swagger:model
+//
+// API extension: instance_users.
+type InstanceUser struct {
+	InstanceUserPut `yaml:",inline"`
+
+	// Name of the user
+	// Example: alice
+	Name string `json:"name" yaml:"name"`
+
+	// User ID
+	// Example: 1000
+	UID int64 `json:"uid" yaml:"uid"`
+
+	// Login shell of the user
+	// Example: /bin/bash
+	Shell string `json:"shell" yaml:"shell"`
+}
+
+// Writable converts a full InstanceUser struct into a InstanceUserPut struct (filters read-only fields).
+func (u *InstanceUser) Writable() InstanceUserPut {
+	return u.InstanceUserPut
+}
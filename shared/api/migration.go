@@ -1,5 +1,11 @@
 package api
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
 // SecretNameControl is the secret name used for the migration control connection.
 const SecretNameControl = "control"
 
@@ -8,3 +14,68 @@ const SecretNameFilesystem = "fs"
 
 // SecretNameState is the secret name used for the migration state connection.
 const SecretNameState = "criu" // Legacy value used for backward compatibility for clients.
+
+// MigrationTokensPost represents the fields available for a new migration token.
+//
+// swagger:model
+//
+// API extension: migration_token.
+type MigrationTokensPost struct {
+	// Name of the instance the token is restricted to (optional)
+	// Example: foo
+	Name string `json:"name" yaml:"name"`
+}
+
+// MigrationToken represents the fields contained within an encoded migration token.
+//
+// swagger:model
+//
+// API extension: migration_token.
+type MigrationToken struct {
+	// The project the instance will be created in
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Name of the instance the token is restricted to
+	// Example: foo
+	Name string `json:"name" yaml:"name"`
+
+	// The fingerprint of the network certificate
+	// Example: 57bb0ff4340b5bb28517e062023101adf788c37846dc8b619eb2c3cb4ef29436
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+
+	// The addresses of the server
+	// Example: ["10.98.30.229:8443"]
+	Addresses []string `json:"addresses" yaml:"addresses"`
+
+	// The random migration secret
+	// Example: 2b2284d44db32675923fe0d2020477e0e9be11801ff70c435e032b97028c35cd
+	Secret string `json:"secret" yaml:"secret"`
+
+	// The token's expiry date.
+	// Example: 2021-03-23T17:38:37.753398689-04:00
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// String encodes the migration token as JSON and then base64.
+func (t *MigrationToken) String() string {
+	tokenJSON, err := json.Marshal(t)
+	if err != nil {
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(tokenJSON)
+}
+
+// MigrationTokenInstancesPost represents the fields required to create an instance using a migration token.
+//
+// swagger:model
+//
+// API extension: migration_token.
+type MigrationTokenInstancesPost struct {
+	InstancesPost `yaml:",inline"`
+
+	// The secret of the migration token
+	// Example: 2b2284d44db32675923fe0d2020477e0e9be11801ff70c435e032b97028c35cd
+	Secret string `json:"secret" yaml:"secret"`
+}
@@ -188,6 +188,11 @@ type NetworkState struct {
 	//
 	// API extension: network_state_ovn
 	OVN *NetworkStateOVN `json:"ovn" yaml:"ovn"`
+
+	// Usage of the network as an uplink of OVN networks
+	//
+	// API extension: network_state_uplink
+	Uplink *NetworkStateUplink `json:"uplink" yaml:"uplink"`
 }
 
 // NetworkStateAddress represents a network address
@@ -328,4 +333,162 @@ type NetworkStateOVN struct {
 	//
 	// API extension: network_state_ovn_lr
 	LogicalRouter string `json:"logical_router" yaml:"logical_router"`
+
+	// Chassis able to host the gateway of the network
+	//
+	// API extension: network_ovn_gateway_chassis
+	GatewayChassis []NetworkStateOVNChassis `json:"gateway_chassis" yaml:"gateway_chassis"`
+}
+
+// NetworkStateOVNChassis represents a chassis able to host the gateway of an OVN network
+//
+// swagger:model
+//
+// API extension: network_ovn_gateway_chassis.
+type NetworkStateOVNChassis struct {
+	// Chassis name
+	// Example: server01
+	Name string `json:"name" yaml:"name"`
+
+	// Chassis priority (the highest priority chassis hosts the gateway)
+	// Example: 32767
+	Priority int `json:"priority" yaml:"priority"`
+
+	// Whether the chassis currently hosts the gateway
+	// Example: true
+	Active bool `json:"active" yaml:"active"`
+}
+
+// NetworkGatewayPost represents the chassis to move the gateway of an OVN network to.
+//
+// swagger:model
+//
+// API extension: network_ovn_gateway_chassis.
+type NetworkGatewayPost struct {
+	// Name of the chassis to host the gateway
+	// Example: server02
+	Chassis string `json:"chassis" yaml:"chassis"`
+}
+
+// NetworkStateUplink represents the usage of an uplink network by OVN networks
+//
+// swagger:model
+//
+// API extension: network_state_uplink.
+type NetworkStateUplink struct {
+	// Usage of the address ranges reserved for OVN networks
+	Ranges []NetworkStateUplinkRange `json:"ranges" yaml:"ranges"`
+
+	// OVN networks using the uplink
+	Networks []NetworkStateUplinkNetwork `json:"networks" yaml:"networks"`
+}
+
+// NetworkStateUplinkRange represents the usage of an address range reserved for OVN networks
+//
+// swagger:model
+//
+// API extension: network_state_uplink.
+type NetworkStateUplinkRange struct {
+	// Address family
+	// Example: inet
+	Family string `json:"family" yaml:"family"`
+
+	// Address range
+	// Example: 192.0.2.100-192.0.2.199
+	Range string `json:"range" yaml:"range"`
+
+	// Number of addresses in the range
+	// Example: 100
+	Total uint64 `json:"total" yaml:"total"`
+
+	// Number of addresses allocated to OVN networks
+	// Example: 42
+	Used uint64 `json:"used" yaml:"used"`
+}
+
+// NetworkStateUplinkNetwork represents the uplink addresses used by an OVN network
+//
+// swagger:model
+//
+// API extension: network_state_uplink.
+type NetworkStateUplinkNetwork struct {
+	// Project of the network
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Name of the network
+	// Example: ovn0
+	Name string `json:"name" yaml:"name"`
+
+	// Uplink addresses used by the network router, forwards and load balancers
+	// Example: ["192.0.2.100", "2001:db8::100"]
+	Addresses []string `json:"addresses" yaml:"addresses"`
+}
+
+// NetworkTracePost represents a packet to trace through a network.
+//
+// swagger:model
+//
+// API extension: network_trace.
+type NetworkTracePost struct {
+	// Name of the instance the packet is sent from
+	// Example: c1
+	Instance string `json:"instance" yaml:"instance"`
+
+	// NIC device of the instance the packet is sent from (defaults to the first NIC connected to the network)
+	// Example: eth0
+	Device string `json:"device" yaml:"device"`
+
+	// Protocol of the packet (tcp, udp, icmp4 or icmp6)
+	// Example: tcp
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Source port (defaults to an ephemeral port)
+	// Example: 50000
+	SourcePort uint64 `json:"source_port" yaml:"source_port"`
+
+	// Destination address
+	// Example: 10.0.0.1
+	Destination string `json:"destination" yaml:"destination"`
+
+	// Destination port
+	// Example: 80
+	DestinationPort uint64 `json:"destination_port" yaml:"destination_port"`
+}
+
+// NetworkTrace represents the path of a packet through a network.
+//
+// swagger:model
+//
+// API extension: network_trace.
+type NetworkTrace struct {
+	// Source address of the packet
+	// Example: 10.0.0.98
+	Source string `json:"source" yaml:"source"`
+
+	// Final verdict for the packet (allow, drop or reject)
+	// Example: allow
+	Verdict string `json:"verdict" yaml:"verdict"`
+
+	// Steps taken by the packet
+	Steps []NetworkTraceStep `json:"steps" yaml:"steps"`
+}
+
+// NetworkTraceStep represents a step of the path of a packet through a network.
+//
+// swagger:model
+//
+// API extension: network_trace.
+type NetworkTraceStep struct {
+	// Stage of the processing
+	// Example: acl
+	Stage string `json:"stage" yaml:"stage"`
+
+	// What the packet was matched against
+	// Example: ACL "web" rule allowing tcp to port 80
+	Match string `json:"match" yaml:"match"`
+
+	// Action taken on the packet
+	// Example: allow
+	Action string `json:"action" yaml:"action"`
 }
@@ -161,3 +161,41 @@ type NetworkACLsPost struct {
 	NetworkACLPost `yaml:",inline"`
 	NetworkACLPut  `yaml:",inline"`
 }
+
+// NetworkACLEffective represents the rules applied to the traffic of an instance NIC.
+//
+// swagger:model
+//
+// API extension: network_acl_effective.
+type NetworkACLEffective struct {
+	// Name of the NIC device
+	// Example: eth0
+	Device string `json:"device" yaml:"device"`
+
+	// Network the NIC is connected to
+	// Example: ovn0
+	Network string `json:"network" yaml:"network"`
+
+	// ACLs applied to the NIC, including the ones of its network
+	// Example: ["web", "default-deny"]
+	ACLs []string `json:"acls" yaml:"acls"`
+
+	// Ingress rules in evaluation order, ending with the default rule
+	Ingress []NetworkACLEffectiveRule `json:"ingress" yaml:"ingress"`
+
+	// Egress rules in evaluation order, ending with the default rule
+	Egress []NetworkACLEffectiveRule `json:"egress" yaml:"egress"`
+}
+
+// NetworkACLEffectiveRule represents a rule applied to the traffic of an instance NIC.
+//
+// swagger:model
+//
+// API extension: network_acl_effective.
+type NetworkACLEffectiveRule struct {
+	NetworkACLRule `yaml:",inline"`
+
+	// Name of the ACL the rule comes from (empty for the default rule)
+	// Example: web
+	ACL string `json:"acl" yaml:"acl"`
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net"
+	"strings"
+)
+
+// NetworkDHCPReservationsPost represents the fields of a new network DHCP reservation
+//
+// swagger:model
+//
+// API extension: network_dhcp_reservations.
+type NetworkDHCPReservationsPost struct {
+	NetworkDHCPReservationPut `yaml:",inline"`
+
+	// MAC address of the device the addresses are reserved for
+	// Example: 00:16:3e:c4:32:77
+	Hwaddr string `json:"hwaddr" yaml:"hwaddr"`
+}
+
+// Normalise normalises the fields in the reservation so that they are comparable with ones stored.
+func (r *NetworkDHCPReservationsPost) Normalise() {
+	mac, err := net.ParseMAC(strings.TrimSpace(r.Hwaddr))
+	if err == nil {
+		r.Hwaddr = mac.String() // Replace with canonical form if specified.
+	}
+
+	r.NetworkDHCPReservationPut.Normalise()
+}
+
+// NetworkDHCPReservationPut represents the modifiable fields of a network DHCP reservation
+//
+// swagger:model
+//
+// API extension: network_dhcp_reservations.
+type NetworkDHCPReservationPut struct {
+	// Description of the reservation
+	// Example: Office printer
+	Description string `json:"description" yaml:"description"`
+
+	// Reserved IPv4 address
+	// Example: 198.51.100.10
+	IPv4Address string `json:"ipv4_address" yaml:"ipv4_address"`
+
+	// Reserved IPv6 address
+	// Example: 2001:db8::10
+	IPv6Address string `json:"ipv6_address" yaml:"ipv6_address"`
+
+	// Host name handed out to the device and registered in DNS (optional)
+	// Example: printer
+	Hostname string `json:"hostname" yaml:"hostname"`
+}
+
+// Normalise normalises the fields in the reservation so that they are comparable with ones stored.
+func (r *NetworkDHCPReservationPut) Normalise() {
+	r.Description = strings.TrimSpace(r.Description)
+	r.Hostname = strings.TrimSpace(r.Hostname)
+
+	ip := net.ParseIP(strings.TrimSpace(r.IPv4Address))
+	if ip != nil {
+		r.IPv4Address = ip.String() // Replace with canonical form if specified.
+	}
+
+	ip = net.ParseIP(strings.TrimSpace(r.IPv6Address))
+	if ip != nil {
+		r.IPv6Address = ip.String() // Replace with canonical form if specified.
+	}
+}
+
+// NetworkDHCPReservation used for displaying a network DHCP reservation.
+//
+// swagger:model
+//
+// API extension: network_dhcp_reservations.
+type NetworkDHCPReservation struct {
+	NetworkDHCPReservationPut `yaml:",inline"`
+
+	// MAC address of the device the addresses are reserved for
+	// Example: 00:16:3e:c4:32:77
+	Hwaddr string `json:"hwaddr" yaml:"hwaddr"`
+}
+
+// Etag returns the values used for etag generation.
+func (r *NetworkDHCPReservation) Etag() []any {
+	return []any{r.Hwaddr, r.Description, r.IPv4Address, r.IPv6Address, r.Hostname}
+}
+
+// Writable converts a full NetworkDHCPReservation struct into a NetworkDHCPReservationPut struct (filters read-only fields).
+func (r *NetworkDHCPReservation) Writable() NetworkDHCPReservationPut {
+	return r.NetworkDHCPReservationPut
+}
@@ -141,3 +141,52 @@ func (f *NetworkForward) Etag() []any {
 func (f *NetworkForward) Writable() NetworkForwardPut {
 	return f.NetworkForwardPut
 }
+
+// NetworkForwardState is used for showing current state of a network address forward
+//
+// swagger:model
+//
+// API extension: network_forward_state.
+type NetworkForwardState struct {
+	// Counters of the default target address (nil if not set)
+	DefaultTarget *NetworkForwardStateCounters `json:"default_target" yaml:"default_target"`
+
+	// Counters of each port specification, in the same order as the forward's ports
+	Ports []NetworkForwardStatePort `json:"ports" yaml:"ports"`
+}
+
+// NetworkForwardStatePort represents the counters of a port specification in a network address forward
+//
+// swagger:model
+//
+// API extension: network_forward_state.
+type NetworkForwardStatePort struct {
+	// Protocol of the port specification (either tcp or udp)
+	// Example: tcp
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// ListenPort(s) of the port specification
+	// Example: 80,81,8080-8090
+	ListenPort string `json:"listen_port" yaml:"listen_port"`
+
+	NetworkForwardStateCounters `yaml:",inline"`
+}
+
+// NetworkForwardStateCounters represents the traffic counters of a network address forward rule
+//
+// swagger:model
+//
+// API extension: network_forward_state.
+type NetworkForwardStateCounters struct {
+	// Number of connections forwarded
+	// Example: 10
+	Connections uint64 `json:"connections" yaml:"connections"`
+
+	// Number of packets forwarded (in both directions)
+	// Example: 1024
+	Packets uint64 `json:"packets" yaml:"packets"`
+
+	// Number of bytes forwarded (in both directions)
+	// Example: 65536
+	Bytes uint64 `json:"bytes" yaml:"bytes"`
+}
@@ -157,3 +157,46 @@ func (f *NetworkLoadBalancer) Etag() []any {
 func (f *NetworkLoadBalancer) Writable() NetworkLoadBalancerPut {
 	return f.NetworkLoadBalancerPut
 }
+
+// NetworkLoadBalancerState is used for showing current state of a load balancer
+//
+// swagger:model
+//
+// API extension: network_load_balancer_health_check.
+type NetworkLoadBalancerState struct {
+	// Health of the backends, keyed by backend name
+	BackendHealth map[string]NetworkLoadBalancerStateBackendHealth `json:"backend_health" yaml:"backend_health"`
+}
+
+// NetworkLoadBalancerStateBackendHealth represents the health of a load balancer backend
+//
+// swagger:model
+//
+// API extension: network_load_balancer_health_check.
+type NetworkLoadBalancerStateBackendHealth struct {
+	// Target address of the backend
+	// Example: 10.0.0.2
+	Address string `json:"address" yaml:"address"`
+
+	// Health of each target port of the backend
+	Ports []NetworkLoadBalancerStateBackendHealthPort `json:"ports" yaml:"ports"`
+}
+
+// NetworkLoadBalancerStateBackendHealthPort represents the health of a single port of a load balancer backend
+//
+// swagger:model
+//
+// API extension: network_load_balancer_health_check.
+type NetworkLoadBalancerStateBackendHealthPort struct {
+	// Protocol of the port (tcp or udp)
+	// Example: tcp
+	Protocol string `json:"protocol" yaml:"protocol"`
+
+	// Target port
+	// Example: 80
+	Port int `json:"port" yaml:"port"`
+
+	// Health status (up, down or unknown)
+	// Example: up
+	Status string `json:"status" yaml:"status"`
+}
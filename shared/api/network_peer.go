@@ -103,3 +103,48 @@ func (p *NetworkPeer) Etag() []any {
 func (p *NetworkPeer) Writable() NetworkPeerPut {
 	return p.NetworkPeerPut
 }
+
+// NetworkPeerRequest represents a pending network peering requested by another network
+//
+// swagger:model
+//
+// API extension: network_peer_requests.
+type NetworkPeerRequest struct {
+	// Name of the peer on the requesting network
+	// Read only: true
+	// Example: project1-network1
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the peer on the requesting network
+	// Read only: true
+	// Example: Peering with network1 in project1
+	Description string `json:"description" yaml:"description"`
+
+	// Name of the requesting project
+	// Read only: true
+	// Example: project2
+	SourceProject string `json:"source_project" yaml:"source_project"`
+
+	// Name of the requesting network
+	// Read only: true
+	// Example: network2
+	SourceNetwork string `json:"source_network" yaml:"source_network"`
+
+	// Configuration of the peer on the requesting network
+	// Read only: true
+	// Example: {"security.acls": "web"}
+	Config map[string]string `json:"config" yaml:"config"`
+}
+
+// NetworkPeerRequestPost represents the fields used to approve a network peering request
+//
+// swagger:model
+//
+// API extension: network_peer_requests.
+type NetworkPeerRequestPost struct {
+	NetworkPeerPut `yaml:",inline"`
+
+	// Name of the peer to create on the approving network
+	// Example: project2-network2
+	Name string `json:"name" yaml:"name"`
+}
@@ -0,0 +1,53 @@
+package api
+
+// NetworkUsageCounters represents the cumulative network transfer of instances
+//
+// swagger:model
+//
+// API extension: instances_network_usage.
+type NetworkUsageCounters struct {
+	// Number of bytes received
+	// Example: 192021
+	BytesReceived int64 `json:"bytes_received" yaml:"bytes_received"`
+
+	// Number of bytes sent
+	// Example: 10888579
+	BytesSent int64 `json:"bytes_sent" yaml:"bytes_sent"`
+
+	// Number of packets received
+	// Example: 1748
+	PacketsReceived int64 `json:"packets_received" yaml:"packets_received"`
+
+	// Number of packets sent
+	// Example: 964
+	PacketsSent int64 `json:"packets_sent" yaml:"packets_sent"`
+}
+
+// InstanceNetworkUsage represents the network transfer of an instance over a monthly period
+//
+// swagger:model
+//
+// API extension: instances_network_usage.
+type InstanceNetworkUsage struct {
+	NetworkUsageCounters `yaml:",inline"`
+
+	// Accounting period (year and month, in UTC)
+	// Example: 2024-05
+	Period string `json:"period" yaml:"period"`
+}
+
+// ProjectNetworkUsage represents the network transfer of the instances of a project over a monthly period
+//
+// swagger:model
+//
+// API extension: instances_network_usage.
+type ProjectNetworkUsage struct {
+	NetworkUsageCounters `yaml:",inline"`
+
+	// Accounting period (year and month, in UTC)
+	// Example: 2024-05
+	Period string `json:"period" yaml:"period"`
+
+	// Network transfer per instance name
+	Instances map[string]NetworkUsageCounters `json:"instances" yaml:"instances"`
+}
@@ -176,3 +176,60 @@ func (op *Operation) ToClusterJoinToken() (*ClusterMemberJoinToken, error) {
 
 	return &joinToken, nil
 }
+
+// ToMigrationToken creates a migration token from the operation metadata.
+func (op *Operation) ToMigrationToken() (*MigrationToken, error) {
+	projectName, ok := op.Metadata["project"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Operation project is type %T not string", op.Metadata["project"])
+	}
+
+	name, ok := op.Metadata["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Operation name is type %T not string", op.Metadata["name"])
+	}
+
+	secret, ok := op.Metadata["secret"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Operation secret is type %T not string", op.Metadata["secret"])
+	}
+
+	fingerprint, ok := op.Metadata["fingerprint"].(string)
+	if !ok {
+		return nil, fmt.Errorf("Operation fingerprint is type %T not string", op.Metadata["fingerprint"])
+	}
+
+	addresses, ok := op.Metadata["addresses"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("Operation addresses is type %T not []any", op.Metadata["addresses"])
+	}
+
+	token := MigrationToken{
+		Project:     projectName,
+		Name:        name,
+		Secret:      secret,
+		Fingerprint: fingerprint,
+		Addresses:   make([]string, 0, len(addresses)),
+	}
+
+	expiresAtStr, ok := op.Metadata["expiresAt"].(string)
+	if ok {
+		expiresAt, err := time.Parse(time.RFC3339Nano, expiresAtStr)
+		if err != nil {
+			return nil, err
+		}
+
+		token.ExpiresAt = expiresAt
+	}
+
+	for i, address := range addresses {
+		addressString, ok := address.(string)
+		if !ok {
+			return nil, fmt.Errorf("Operation address index %d is type %T not string", i, address)
+		}
+
+		token.Addresses = append(token.Addresses, addressString)
+	}
+
+	return &token, nil
+}
@@ -35,6 +35,12 @@ type ProfilePut struct {
 	// List of devices
 	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}, "eth0": {"type": "nic", "network": "mybr0", "name": "eth0"}}
 	Devices map[string]map[string]string `json:"devices" yaml:"devices"`
+
+	// List of parent profiles whose configuration and devices are inherited (in application order)
+	// Example: ["base", "web"]
+	//
+	// API extension: profile_inheritance
+	Parents []string `json:"parents,omitempty" yaml:"parents,omitempty"`
 }
 
 // Profile represents a profile
@@ -60,6 +66,101 @@ type Profile struct {
 	//
 	// API extension: profiles_all_projects
 	Project string `json:"project" yaml:"project"`
+
+	// Configuration map including the keys inherited from the parent profiles
+	// Read only: true
+	// Example: {"limits.cpu": "4", "limits.memory": "4GiB", "security.nesting": "true"}
+	//
+	// API extension: profile_inheritance
+	ExpandedConfig map[string]string `json:"expanded_config,omitempty" yaml:"expanded_config,omitempty"`
+
+	// List of devices including the ones inherited from the parent profiles
+	// Read only: true
+	// Example: {"root": {"type": "disk", "pool": "default", "path": "/"}, "eth0": {"type": "nic", "network": "mybr0", "name": "eth0"}}
+	//
+	// API extension: profile_inheritance
+	ExpandedDevices map[string]map[string]string `json:"expanded_devices,omitempty" yaml:"expanded_devices,omitempty"`
+}
+
+// ProfilePreview represents the impact of a proposed profile change on the instances using it.
+//
+// swagger:model
+//
+// API extension: profile_preview.
+type ProfilePreview struct {
+	// Instances whose effective configuration or devices would change
+	Instances []ProfilePreviewInstance `json:"instances" yaml:"instances"`
+}
+
+// ProfilePreviewInstance represents the changes a proposed profile change would make to an instance.
+//
+// swagger:model
+//
+// API extension: profile_preview.
+type ProfilePreviewInstance struct {
+	// Instance name
+	// Example: c1
+	Name string `json:"name" yaml:"name"`
+
+	// Instance project
+	// Example: default
+	Project string `json:"project" yaml:"project"`
+
+	// Cluster member the instance is on
+	// Example: server01
+	Location string `json:"location" yaml:"location"`
+
+	// Changed effective configuration keys
+	Config []ProfilePreviewConfigChange `json:"config" yaml:"config"`
+
+	// Changed effective devices
+	Devices []ProfilePreviewDeviceChange `json:"devices" yaml:"devices"`
+
+	// Whether some of the changes only take effect after the instance is restarted
+	// Example: true
+	RestartRequired bool `json:"restart_required" yaml:"restart_required"`
+}
+
+// ProfilePreviewConfigChange represents a change to an effective instance configuration key.
+//
+// swagger:model
+//
+// API extension: profile_preview.
+type ProfilePreviewConfigChange struct {
+	// Configuration key
+	// Example: limits.cpu
+	Key string `json:"key" yaml:"key"`
+
+	// Current value (empty if unset)
+	// Example: 2
+	OldValue string `json:"old_value" yaml:"old_value"`
+
+	// Proposed value (empty if unset)
+	// Example: 4
+	NewValue string `json:"new_value" yaml:"new_value"`
+
+	// Whether the change only takes effect after the instance is restarted
+	// Example: false
+	RestartRequired bool `json:"restart_required" yaml:"restart_required"`
+}
+
+// ProfilePreviewDeviceChange represents a change to an effective instance device.
+//
+// swagger:model
+//
+// API extension: profile_preview.
+type ProfilePreviewDeviceChange struct {
+	// Device name
+	// Example: eth0
+	Name string `json:"name" yaml:"name"`
+
+	// Type of change (one of "added", "removed" or "updated")
+	// Example: updated
+	Change string `json:"change" yaml:"change"`
+
+	// Whether the change only takes effect after the instance is restarted
+	// Example: true
+	RestartRequired bool `json:"restart_required" yaml:"restart_required"`
 }
 
 // Writable converts a full Profile struct into a ProfilePut struct (filters read-only fields).
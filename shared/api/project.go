@@ -27,6 +27,45 @@ type ProjectPost struct {
 	Name string `json:"name" yaml:"name"`
 }
 
+// ProjectCleanup represents the resources of a project due for removal by its cleanup policy
+//
+// swagger:model
+//
+// API extension: projects_cleanup.
+type ProjectCleanup struct {
+	// Stopped instances due for removal (URLs)
+	// Read only: true
+	// Example: ["/1.0/instances/c1?project=dev"]
+	Instances []string `json:"instances" yaml:"instances"`
+
+	// Unused custom storage volumes due for removal (URLs)
+	// Read only: true
+	// Example: ["/1.0/storage-pools/default/volumes/custom/data?project=dev"]
+	StorageVolumes []string `json:"storage_volumes" yaml:"storage_volumes"`
+
+	// Unused images due for removal (URLs)
+	// Read only: true
+	// Example: ["/1.0/images/06b86454720d36b20f94e31c6812e05ec51c1b568cf3a8abd273769d213394bb?project=dev"]
+	Images []string `json:"images" yaml:"images"`
+}
+
+// ProjectSnapshotsPost represents the fields available for a snapshot of the instances of a project
+//
+// swagger:model
+//
+// API extension: project_snapshots.
+type ProjectSnapshotsPost struct {
+	InstanceSnapshotsPost `yaml:",inline"`
+
+	// Instances to snapshot (all the instances of the project if empty)
+	// Example: ["c1", "c2"]
+	Instances []string `json:"instances" yaml:"instances"`
+
+	// Whether to flush the filesystem buffers of the running instances through their agent before taking the snapshots
+	// Example: true
+	Quiesce bool `json:"quiesce" yaml:"quiesce"`
+}
+
 // ProjectPut represents the modifiable fields of a project
 //
 // swagger:model
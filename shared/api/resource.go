@@ -46,6 +46,11 @@ type Resources struct {
 	//
 	// API extension: resources_load
 	Load ResourcesLoad `json:"load" yaml:"load"`
+
+	// Host compatibility checks (only included when requested)
+	//
+	// API extension: resources_checks
+	Checks []ResourcesCheck `json:"checks,omitempty" yaml:"checks,omitempty"`
 }
 
 // ResourcesCPU represents the cpu resources available on the system
@@ -739,6 +744,12 @@ type ResourcesMemory struct {
 	// Example: 2097152
 	HugepagesSize uint64 `json:"hugepages_size" yaml:"hugepages_size"`
 
+	// Huge pages by page size
+	// Example: null
+	//
+	// API extension: resources_hugepages
+	Hugepages []ResourcesMemoryHugepages `json:"hugepages,omitempty" yaml:"hugepages,omitempty"`
+
 	// Used system memory (bytes)
 	// Example: 557450502144
 	Used uint64 `json:"used" yaml:"used"`
@@ -775,6 +786,33 @@ type ResourcesMemoryNode struct {
 	Total uint64 `json:"total" yaml:"total"`
 }
 
+// ResourcesMemoryHugepages represents the huge pages of a given size available on the system
+//
+// swagger:model
+//
+// API extension: resources_hugepages.
+type ResourcesMemoryHugepages struct {
+	// Size of the huge pages (bytes)
+	// Example: 2097152
+	Size uint64 `json:"size" yaml:"size"`
+
+	// Total of huge pages (bytes)
+	// Example: 17179869184
+	Total uint64 `json:"total" yaml:"total"`
+
+	// Used huge pages (bytes)
+	// Example: 8589934592
+	Used uint64 `json:"used" yaml:"used"`
+
+	// Huge pages reserved by mappings or by starting instances but not yet used (bytes)
+	// Example: 4294967296
+	Reserved uint64 `json:"reserved" yaml:"reserved"`
+
+	// Huge pages available to new instances (bytes)
+	// Example: 4294967296
+	Free uint64 `json:"free" yaml:"free"`
+}
+
 // ResourcesStoragePool represents the resources available to a given storage pool
 //
 // swagger:model
@@ -1126,3 +1164,35 @@ type ResourcesLoad struct {
 	// Example: 1234
 	Processes int
 }
+
+// ResourcesCheckStatusPass indicates that the host fully supports the checked feature.
+const ResourcesCheckStatusPass = "pass"
+
+// ResourcesCheckStatusWarn indicates that the checked feature is missing or restricted, making some features unavailable.
+const ResourcesCheckStatusWarn = "warn"
+
+// ResourcesCheckStatusFail indicates that the checked feature is missing and required for normal operation.
+const ResourcesCheckStatusFail = "fail"
+
+// ResourcesCheck represents the result of a host compatibility check
+//
+// swagger:model
+//
+// API extension: resources_checks.
+type ResourcesCheck struct {
+	// Name of the check
+	// Example: idmapped_mounts
+	Name string `json:"name" yaml:"name"`
+
+	// Description of the checked feature
+	// Example: Kernel support for idmapped mounts
+	Description string `json:"description" yaml:"description"`
+
+	// Result of the check (pass, warn or fail)
+	// Example: pass
+	Status string `json:"status" yaml:"status"`
+
+	// Details about the result
+	// Example: Containers will be shifted at creation time
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
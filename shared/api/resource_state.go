@@ -0,0 +1,145 @@
+package api
+
+import (
+	"time"
+)
+
+// ResourcesState represents the live utilization of the system
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesState struct {
+	// Time at which the system was sampled
+	// Example: 2024-05-01T10:00:00Z
+	SampledAt time.Time `json:"sampled_at" yaml:"sampled_at"`
+
+	// Number of seconds over which the rates were computed
+	// Example: 5.02
+	Interval float64 `json:"interval" yaml:"interval"`
+
+	// CPU utilization
+	CPU ResourcesStateCPU `json:"cpu" yaml:"cpu"`
+
+	// Load average information
+	Load ResourcesLoad `json:"load" yaml:"load"`
+
+	// Memory utilization
+	Memory ResourcesStateMemory `json:"memory" yaml:"memory"`
+
+	// Swap utilization
+	Swap ResourcesStateSwap `json:"swap" yaml:"swap"`
+
+	// Disk utilization, keyed by disk name
+	Disks map[string]ResourcesStateDisk `json:"disks" yaml:"disks"`
+
+	// Network interface utilization, keyed by interface name
+	Networks map[string]ResourcesStateNetwork `json:"networks" yaml:"networks"`
+}
+
+// ResourcesStateCPU represents the CPU utilization of the system
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesStateCPU struct {
+	// Percentage of the CPU time spent running tasks, across all CPUs
+	// Example: 12.5
+	Usage float64 `json:"usage" yaml:"usage"`
+
+	// Percentage of the CPU time spent waiting for I/O, across all CPUs
+	// Example: 0.4
+	IOWait float64 `json:"iowait" yaml:"iowait"`
+}
+
+// ResourcesStateMemory represents the memory utilization of the system
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesStateMemory struct {
+	// Total memory in bytes
+	// Example: 8589934592
+	Total uint64 `json:"total" yaml:"total"`
+
+	// Used memory in bytes
+	// Example: 4294967296
+	Used uint64 `json:"used" yaml:"used"`
+
+	// Memory available for new workloads in bytes
+	// Example: 4294967296
+	Available uint64 `json:"available" yaml:"available"`
+
+	// Memory used by the page cache in bytes
+	// Example: 2147483648
+	Cached uint64 `json:"cached" yaml:"cached"`
+
+	// Memory used by buffers in bytes
+	// Example: 134217728
+	Buffers uint64 `json:"buffers" yaml:"buffers"`
+}
+
+// ResourcesStateSwap represents the swap utilization of the system
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesStateSwap struct {
+	// Total swap in bytes
+	// Example: 2147483648
+	Total uint64 `json:"total" yaml:"total"`
+
+	// Used swap in bytes
+	// Example: 1048576
+	Used uint64 `json:"used" yaml:"used"`
+}
+
+// ResourcesStateDisk represents the I/O utilization of a disk
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesStateDisk struct {
+	// Bytes read per second
+	// Example: 1048576
+	ReadBytes uint64 `json:"read_bytes" yaml:"read_bytes"`
+
+	// Bytes written per second
+	// Example: 2097152
+	WrittenBytes uint64 `json:"written_bytes" yaml:"written_bytes"`
+
+	// Read operations per second
+	// Example: 25
+	ReadOps uint64 `json:"read_ops" yaml:"read_ops"`
+
+	// Write operations per second
+	// Example: 50
+	WriteOps uint64 `json:"write_ops" yaml:"write_ops"`
+
+	// Percentage of the time the disk was busy
+	// Example: 3.2
+	Utilization float64 `json:"utilization" yaml:"utilization"`
+}
+
+// ResourcesStateNetwork represents the throughput of a network interface
+//
+// swagger:model
+//
+// API extension: resources_state.
+type ResourcesStateNetwork struct {
+	// Bytes received per second
+	// Example: 125000
+	ReceivedBytes uint64 `json:"received_bytes" yaml:"received_bytes"`
+
+	// Bytes sent per second
+	// Example: 250000
+	SentBytes uint64 `json:"sent_bytes" yaml:"sent_bytes"`
+
+	// Packets received per second
+	// Example: 100
+	ReceivedPackets uint64 `json:"received_packets" yaml:"received_packets"`
+
+	// Packets sent per second
+	// Example: 200
+	SentPackets uint64 `json:"sent_packets" yaml:"sent_packets"`
+}
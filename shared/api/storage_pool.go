@@ -1,5 +1,9 @@
 package api
 
+import (
+	"time"
+)
+
 // StoragePoolStatusPending storage pool is pending creation on other cluster nodes.
 const StoragePoolStatusPending = "Pending"
 
@@ -98,3 +102,72 @@ func (storagePool *StoragePool) Writable() StoragePoolPut {
 type StoragePoolState struct {
 	ResourcesStoragePool `yaml:",inline"`
 }
+
+// StoragePoolBenchmark represents the result of a storage pool benchmark.
+//
+// swagger:model
+//
+// API extension: storage_pool_benchmark.
+type StoragePoolBenchmark struct {
+	// Sequential read throughput (bytes per second)
+	// Example: 524288000
+	SequentialRead int64 `json:"sequential_read" yaml:"sequential_read"`
+
+	// Sequential write throughput (bytes per second)
+	// Example: 419430400
+	SequentialWrite int64 `json:"sequential_write" yaml:"sequential_write"`
+
+	// Random 4KiB reads per second
+	// Example: 25000
+	RandomRead int64 `json:"random_read" yaml:"random_read"`
+
+	// Random synchronous 4KiB writes per second
+	// Example: 8000
+	RandomWrite int64 `json:"random_write" yaml:"random_write"`
+
+	// When the benchmark was run
+	// Example: 2024-10-17T12:00:00Z
+	Date time.Time `json:"date" yaml:"date"`
+}
+
+// StoragePoolKey represents the status of the encryption key of a storage pool.
+//
+// swagger:model
+//
+// API extension: storage_pool_key.
+type StoragePoolKey struct {
+	// Encryption algorithm (off if the pool isn't encrypted)
+	// Example: aes-256-gcm
+	Encryption string `json:"encryption" yaml:"encryption"`
+
+	// Dataset holding the encryption key used by the pool
+	// Example: tank/incus
+	EncryptionRoot string `json:"encryption_root" yaml:"encryption_root"`
+
+	// Format of the encryption key (passphrase, hex or raw)
+	// Example: passphrase
+	KeyFormat string `json:"key_format" yaml:"key_format"`
+
+	// Whether the encryption key is loaded (available or unavailable)
+	// Example: available
+	KeyStatus string `json:"key_status" yaml:"key_status"`
+}
+
+// StoragePoolKeyPost represents an action on the encryption key of a storage pool.
+//
+// swagger:model
+//
+// API extension: storage_pool_key.
+type StoragePoolKeyPost struct {
+	// Action to perform (load, unload or change)
+	// Example: load
+	Action string `json:"action" yaml:"action"`
+
+	// Key to load (when empty, the key is fetched from the location configured on the pool)
+	// Example: my-passphrase
+	Key string `json:"key" yaml:"key"`
+
+	// New key to switch to (change action)
+	// Example: my-new-passphrase
+	NewKey string `json:"new_key" yaml:"new_key"`
+}
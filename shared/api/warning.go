@@ -51,6 +51,12 @@ type Warning struct {
 	// The entity affected by this warning
 	// Example: /1.0/instances/c1?project=default
 	EntityURL string `json:"entity_url" yaml:"entity_url"`
+
+	// Who acknowledged the warning
+	// Example: admin
+	//
+	// API extension: warnings_workflow.
+	AcknowledgedBy string `json:"acknowledged_by" yaml:"acknowledged_by"`
 }
 
 // WarningPut represents the modifiable fields of a warning.
@@ -62,4 +68,10 @@ type WarningPut struct {
 	// Status of the warning (new, acknowledged, or resolved)
 	// Example: new
 	Status string `json:"status" yaml:"status"`
+
+	// Severity overriding the default one of the warning type (low, moderate, high or empty for the default)
+	// Example: high
+	//
+	// API extension: warnings_workflow.
+	SeverityOverride string `json:"severity_override" yaml:"severity_override"`
 }
@@ -24,6 +24,9 @@ type Config struct {
 	// Command line aliases for `incus`
 	Aliases map[string]string `yaml:"aliases"`
 
+	// Named column presets for `incus list`
+	ListColumns map[string]string `yaml:"list-columns,omitempty"`
+
 	// Configuration directory
 	ConfigDir string `yaml:"-"`
 
@@ -38,6 +41,9 @@ type Config struct {
 
 	// OIDC tokens
 	oidcTokens map[string]*oidc.Tokens[*oidc.IDTokenClaims]
+
+	// Remotes from the configuration file replaced by remotes defined in the environment
+	shadowedRemotes map[string]Remote
 }
 
 // GlobalConfigPath returns a joined path of the global configuration directory and passed arguments.
@@ -0,0 +1,195 @@
+package cliconfig
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/lxc/incus/v6/shared/api"
+)
+
+// remotesCacheTTL is how long the remotes retrieved from INCUS_REMOTES_URL are used before being retrieved again.
+var remotesCacheTTL = 10 * time.Minute
+
+// LoadEnvironmentRemotes adds the remotes defined through the environment to the configuration.
+//
+// INCUS_REMOTES_URL points to a YAML document listing remotes in the same format as the configuration file,
+// while INCUS_REMOTES holds a comma separated list of "<name>=<address>" remotes. Remotes defined through
+// the environment take priority over the ones from the configuration file but are never saved to it.
+// Unless specified, they use TLS authentication if a client certificate exists and OIDC otherwise.
+//
+// The document retrieved from INCUS_REMOTES_URL is cached in the configuration directory for remotesCacheTTL
+// and the cached copy is used, with a warning, when the document can't be retrieved.
+func (c *Config) LoadEnvironmentRemotes() error {
+	remotes := map[string]Remote{}
+
+	discoveryURL := os.Getenv("INCUS_REMOTES_URL")
+	if discoveryURL != "" {
+		discovered, err := c.discoverRemotes(discoveryURL)
+		if err != nil {
+			return fmt.Errorf("Failed discovering remotes from %q: %w", discoveryURL, err)
+		}
+
+		for name, remote := range discovered {
+			remotes[name] = remote
+		}
+	}
+
+	envRemotes := os.Getenv("INCUS_REMOTES")
+	if envRemotes != "" {
+		for _, entry := range strings.Split(envRemotes, ",") {
+			name, addr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || name == "" || addr == "" {
+				return fmt.Errorf("Invalid remote %q in INCUS_REMOTES, expected <name>=<address>", entry)
+			}
+
+			remotes[name] = Remote{Addr: addr}
+		}
+	}
+
+	if c.Remotes == nil {
+		c.Remotes = make(map[string]Remote)
+	}
+
+	for name, remote := range remotes {
+		_, ok := StaticRemotes[name]
+		if ok {
+			return fmt.Errorf("Remote %q is static and cannot be redefined", name)
+		}
+
+		if remote.Addr == "" {
+			return fmt.Errorf("Missing address for remote %q", name)
+		}
+
+		if remote.Protocol == "" {
+			remote.Protocol = "incus"
+		}
+
+		if remote.AuthType == "" && !remote.Public && remote.Protocol == "incus" && !strings.HasPrefix(remote.Addr, "unix:") {
+			if c.HasClientCertificate() {
+				remote.AuthType = api.AuthenticationMethodTLS
+			} else {
+				remote.AuthType = api.AuthenticationMethodOIDC
+			}
+		}
+
+		// Keep track of the remote being replaced so that it can be saved back.
+		existing, ok := c.Remotes[name]
+		if ok && !existing.Environment {
+			if c.shadowedRemotes == nil {
+				c.shadowedRemotes = make(map[string]Remote)
+			}
+
+			c.shadowedRemotes[name] = existing
+		}
+
+		remote.Environment = true
+		c.Remotes[name] = remote
+	}
+
+	// The default remote may refer to one of the remotes defined in the environment.
+	envDefaultRemote := os.Getenv("INCUS_REMOTE")
+	if envDefaultRemote != "" {
+		c.DefaultRemote = envDefaultRemote
+	}
+
+	return nil
+}
+
+// remotesCachePath returns the path of the cached remotes retrieved from the discovery URL.
+func (c *Config) remotesCachePath(url string) string {
+	if c.ConfigDir == "" {
+		return ""
+	}
+
+	hash := sha256.Sum256([]byte(url))
+
+	return c.ConfigPath("cache", fmt.Sprintf("remotes-%x.yaml", hash[:8]))
+}
+
+// discoverRemotes returns the remotes listed by the discovery URL, using the cached ones while they're
+// fresh or when the discovery URL can't be reached.
+func (c *Config) discoverRemotes(url string) (map[string]Remote, error) {
+	cachePath := c.remotesCachePath(url)
+	if cachePath != "" {
+		fi, err := os.Stat(cachePath)
+		if err == nil && time.Since(fi.ModTime()) < remotesCacheTTL {
+			content, err := os.ReadFile(cachePath)
+			if err == nil {
+				remotes, err := parseRemotes(content)
+				if err == nil {
+					return remotes, nil
+				}
+			}
+		}
+	}
+
+	content, err := fetchRemotes(url)
+	if err != nil {
+		if cachePath != "" {
+			cached, cacheErr := os.ReadFile(cachePath)
+			if cacheErr == nil {
+				remotes, cacheErr := parseRemotes(cached)
+				if cacheErr == nil {
+					fmt.Fprintf(os.Stderr, "Warning: Failed discovering remotes from %q, using the cached ones: %v\n", url, err)
+					return remotes, nil
+				}
+			}
+		}
+
+		return nil, err
+	}
+
+	remotes, err := parseRemotes(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		err = os.MkdirAll(filepath.Dir(cachePath), 0700)
+		if err == nil {
+			_ = os.WriteFile(cachePath, content, 0600)
+		}
+	}
+
+	return remotes, nil
+}
+
+// fetchRemotes retrieves the document listing the remotes from the discovery URL.
+func fetchRemotes(url string) ([]byte, error) {
+	client := http.Client{Timeout: 10 * time.Second}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected status %q", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+}
+
+// parseRemotes decodes the document listing the remotes.
+func parseRemotes(content []byte) (map[string]Remote, error) {
+	discovery := struct {
+		Remotes map[string]Remote `yaml:"remotes"`
+	}{}
+
+	err := yaml.Unmarshal(content, &discovery)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode the remotes: %w", err)
+	}
+
+	return discovery.Remotes, nil
+}
@@ -0,0 +1,57 @@
+package cliconfig
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the discovered remotes are cached and used when the discovery URL fails.
+func TestDiscoverRemotesCache(t *testing.T) {
+	requests := 0
+	failing := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "remotes:\n  prod:\n    addr: https://192.0.2.4:8443\n")
+	}))
+	defer server.Close()
+
+	c := NewConfig(t.TempDir(), true)
+
+	remotes, err := c.discoverRemotes(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "https://192.0.2.4:8443", remotes["prod"].Addr)
+	assert.Equal(t, 1, requests)
+
+	// Fresh cache is used without contacting the server.
+	remotes, err = c.discoverRemotes(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "https://192.0.2.4:8443", remotes["prod"].Addr)
+	assert.Equal(t, 1, requests)
+
+	// Expired cache is refreshed, falling back to it when the server fails.
+	old := time.Now().Add(-2 * remotesCacheTTL)
+	err = os.Chtimes(c.remotesCachePath(server.URL), old, old)
+	require.NoError(t, err)
+
+	failing = true
+	remotes, err = c.discoverRemotes(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "https://192.0.2.4:8443", remotes["prod"].Addr)
+	assert.Equal(t, 2, requests)
+
+	// Without a cache, the failure is returned.
+	_, err = NewConfig(t.TempDir(), true).discoverRemotes(server.URL)
+	assert.Error(t, err)
+}
@@ -63,6 +63,10 @@ func LoadConfig(path string) (*Config, error) {
 			v.Project = c.Remotes[k].Project
 		}
 
+		if c.Remotes[k].ListColumns != "" {
+			v.ListColumns = c.Remotes[k].ListColumns
+		}
+
 		c.Remotes[k] = v
 	}
 
@@ -95,15 +99,29 @@ func (c *Config) SaveConfig(path string) error {
 		return fmt.Errorf("Unable to copy the configuration: %w", err)
 	}
 
-	// Remove the global remotes
+	// Remove the global remotes and the ones defined in the environment
 	for k, v := range c.Remotes {
-		if v.Global {
+		if v.Global || v.Environment {
 			delete(conf.Remotes, k)
 		}
 	}
 
+	// Restore the remotes replaced by the ones defined in the environment
+	for k, v := range c.shadowedRemotes {
+		_, ok := conf.Remotes[k]
+		if !ok {
+			conf.Remotes[k] = v
+		}
+	}
+
 	defaultRemote := DefaultConfig().DefaultRemote
 
+	// Don't save a default remote which only exists in the environment
+	_, ok := conf.Remotes[conf.DefaultRemote]
+	if !ok && c.Remotes[conf.DefaultRemote].Environment {
+		conf.DefaultRemote = defaultRemote
+	}
+
 	// Remove the static remotes
 	for k := range StaticRemotes {
 		if k == defaultRemote {
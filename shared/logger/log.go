@@ -3,6 +3,7 @@ package logger
 import (
 	"io"
 	"os"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	lWriter "github.com/sirupsen/logrus/hooks/writer"
@@ -18,6 +19,16 @@ func init() {
 	Log = newWrapper(logger)
 }
 
+// logTarget keeps track of the logger set up through InitLogger so that it can be reconfigured.
+var logTarget struct {
+	mu         sync.Mutex
+	logger     *logrus.Logger
+	writer     io.Writer
+	hook       logrus.Hook
+	syslogName string
+	syslogHook logrus.Hook
+}
+
 // InitLogger intializes a full logging instance.
 func InitLogger(filepath string, syslogName string, verbose bool, debug bool, hook logrus.Hook) error {
 	logger := logrus.New()
@@ -27,16 +38,8 @@ func InitLogger(filepath string, syslogName string, verbose bool, debug bool, ho
 	// Setup the formatter.
 	logger.Formatter = &logrus.TextFormatter{PadLevelText: true, FullTimestamp: true, ForceColors: termios.IsTerminal(int(os.Stderr.Fd()))}
 
-	// Setup log level.
-	levels := []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
-	if debug {
-		levels = append(levels, logrus.InfoLevel, logrus.DebugLevel)
-	} else if verbose {
-		levels = append(levels, logrus.InfoLevel)
-	}
-
-	// Setup writers.
-	writers := []io.Writer{os.Stderr}
+	// Setup writers, keeping the most recent output in memory.
+	writers := []io.Writer{os.Stderr, &recentLogs}
 
 	if filepath != "" {
 		f, err := os.OpenFile(filepath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
@@ -47,26 +50,79 @@ func InitLogger(filepath string, syslogName string, verbose bool, debug bool, ho
 		writers = append(writers, f)
 	}
 
-	logger.AddHook(&lWriter.Hook{
-		Writer:    io.MultiWriter(writers...),
+	logTarget.mu.Lock()
+	defer logTarget.mu.Unlock()
+
+	logTarget.logger = logger
+	logTarget.writer = io.MultiWriter(writers...)
+	logTarget.hook = hook
+	logTarget.syslogName = ""
+	logTarget.syslogHook = nil
+
+	err := setupHooks(syslogName, verbose, debug)
+	if err != nil {
+		return err
+	}
+
+	// Set the logger.
+	Log = newWrapper(logger)
+
+	return nil
+}
+
+// Reconfigure changes the log level and the syslog output of the logger set up through InitLogger.
+// The change applies to all existing loggers, including those returned by AddContext.
+func Reconfigure(syslogName string, verbose bool, debug bool) error {
+	logTarget.mu.Lock()
+	defer logTarget.mu.Unlock()
+
+	// Nothing to reconfigure if the logger hasn't been initialized.
+	if logTarget.logger == nil {
+		return nil
+	}
+
+	return setupHooks(syslogName, verbose, debug)
+}
+
+// setupHooks replaces the hooks of the logger with ones matching the provided settings.
+// The caller must hold the logTarget lock.
+func setupHooks(syslogName string, verbose bool, debug bool) error {
+	hooks := logrus.LevelHooks{}
+
+	// Setup log level.
+	levels := []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
+	if debug {
+		levels = append(levels, logrus.InfoLevel, logrus.DebugLevel)
+	} else if verbose {
+		levels = append(levels, logrus.InfoLevel)
+	}
+
+	hooks.Add(&lWriter.Hook{
+		Writer:    logTarget.writer,
 		LogLevels: levels,
 	})
 
 	// Setup syslog.
 	if syslogName != "" {
-		err := setupSyslog(logger, syslogName)
-		if err != nil {
-			return err
+		if logTarget.syslogHook == nil || logTarget.syslogName != syslogName {
+			syslogHook, err := newSyslogHook(syslogName)
+			if err != nil {
+				return err
+			}
+
+			logTarget.syslogName = syslogName
+			logTarget.syslogHook = syslogHook
 		}
+
+		hooks.Add(logTarget.syslogHook)
 	}
 
 	// Add hooks.
-	if hook != nil {
-		logger.AddHook(hook)
+	if logTarget.hook != nil {
+		hooks.Add(logTarget.hook)
 	}
 
-	// Set the logger.
-	Log = newWrapper(logger)
+	logTarget.logger.ReplaceHooks(hooks)
 
 	return nil
 }
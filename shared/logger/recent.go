@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"bytes"
+	"sync"
+)
+
+// recentLogSize is the amount of the most recent log output kept in memory.
+const recentLogSize = 1024 * 1024
+
+// recentLogs keeps the most recent output of the logger set up through InitLogger.
+var recentLogs recentWriter
+
+// recentWriter is a writer keeping the last recentLogSize bytes written to it.
+type recentWriter struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write appends to the buffer, discarding the oldest data once the buffer grows past twice its size.
+func (w *recentWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > 2*recentLogSize {
+		w.buf = append([]byte(nil), w.buf[len(w.buf)-recentLogSize:]...)
+	}
+
+	return len(p), nil
+}
+
+// RecentLogs returns the most recent log output, starting at the beginning of a line.
+func RecentLogs() []byte {
+	recentLogs.mu.Lock()
+	defer recentLogs.mu.Unlock()
+
+	logs := recentLogs.buf
+	if len(logs) > recentLogSize {
+		logs = logs[len(logs)-recentLogSize:]
+
+		// Skip the partial first line.
+		idx := bytes.IndexByte(logs, '\n')
+		if idx >= 0 {
+			logs = logs[idx+1:]
+		}
+	}
+
+	return bytes.Clone(logs)
+}
@@ -27,12 +27,11 @@ func (h syslogHandler) Levels() []logrus.Level {
 	}
 }
 
-func setupSyslog(logger *logrus.Logger, syslogName string) error {
+func newSyslogHook(syslogName string) (logrus.Hook, error) {
 	syslogHook, err := lSyslog.NewSyslogHook("", "", syslog.LOG_INFO, syslogName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	logger.AddHook(syslogHandler{syslogHook})
-	return nil
+	return syslogHandler{syslogHook}, nil
 }
@@ -8,6 +8,6 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func setupSyslog(logger *logrus.Logger, syslogName string) error {
-	return fmt.Errorf("Syslog logging isn't supported on this platform")
+func newSyslogHook(syslogName string) (logrus.Hook, error) {
+	return nil, fmt.Errorf("Syslog logging isn't supported on this platform")
 }
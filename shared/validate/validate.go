@@ -729,6 +729,34 @@ func IsHostname(name string) error {
 	return nil
 }
 
+// IsDNSName checks the string is a valid DNS domain name, made of labels containing only alphanumeric,
+// hyphen and underscore characters. A trailing full stop is allowed.
+func IsDNSName(name string) error {
+	name = strings.TrimSuffix(name, ".")
+
+	if len(name) < 1 || len(name) > 253 {
+		return fmt.Errorf("Domain name must be 1-253 characters long")
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) < 1 || len(label) > 63 {
+			return fmt.Errorf("Domain name labels must be 1-63 characters long")
+		}
+
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf(`Domain name labels must not start or end with "-" character`)
+		}
+
+		for _, r := range label {
+			if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && r != '-' && r != '_' {
+				return fmt.Errorf("Domain name can only contain alphanumeric, hyphen, underscore and full stop characters")
+			}
+		}
+	}
+
+	return nil
+}
+
 // IsDeviceName checks name is 1-63 characters long, doesn't start with a full stop and contains only alphanumeric,
 // forward slash, hyphen, colon, underscore and full stop characters.
 func IsDeviceName(name string) error {
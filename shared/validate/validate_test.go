@@ -140,3 +140,30 @@ func ExampleIsValidCPUSet() {
 	// Cannot define CPU multiple times
 	// Cannot define CPU multiple times
 }
+
+func ExampleIsDNSName() {
+	tests := []string{
+		"corp.example.net",
+		"10.in-addr.arpa",
+		"_tcp.example.net.",
+		"example",
+		"corp/example.net",  // invalid character
+		"-corp.example.net", // invalid label start
+		"corp..example.net", // empty label
+		"",
+	}
+
+	for _, v := range tests {
+		err := validate.IsDNSName(v)
+		fmt.Printf("%s, %t\n", v, err == nil)
+	}
+
+	// Output: corp.example.net, true
+	// 10.in-addr.arpa, true
+	// _tcp.example.net., true
+	// example, true
+	// corp/example.net, false
+	// -corp.example.net, false
+	// corp..example.net, false
+	// , false
+}